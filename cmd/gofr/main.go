@@ -0,0 +1,104 @@
+// Command gofr scaffolds GoFr services and runs them in development.
+package main
+
+import (
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr"
+	"github.com/peter-stratton/gofr/pkg/gofr/devmode"
+	"github.com/peter-stratton/gofr/pkg/gofr/scaffold"
+)
+
+func main() {
+	app := gofr.NewCMD()
+
+	app.SubCommand("new", newService,
+		gofr.AddDescription("Creates a new service"),
+		gofr.AddFlag("name", "", "name of the service, which is the name of its directory"),
+		gofr.AddFlag("module", "", "Go module of the service, which is its name by default"),
+		gofr.AddFlag("dir", ".", "directory in which the service is created"),
+	)
+
+	app.SubCommand("add handler", addHandler,
+		gofr.AddDescription("Adds a handler, with its request and response structs and a test, to a service"),
+		gofr.AddFlag("name", "", "name of the handler, like create-order"),
+		gofr.AddFlag("method", "GET", "HTTP method of the route of the handler"),
+		gofr.AddFlag("path", "", "path of the route of the handler, which is its name in kebab case by default"),
+		gofr.AddFlag("dir", ".", "directory of the service"),
+	)
+
+	app.SubCommand("add migration", addMigration,
+		gofr.AddDescription("Adds a migration to a service"),
+		gofr.AddFlag("name", "", "name of the migration, like create-orders-table"),
+		gofr.AddFlag("dir", ".", "directory of the service"),
+	)
+
+	app.SubCommand("run", runService,
+		gofr.AddDescription("Runs a service with APP_ENV=dev, rebuilding and restarting it when its code or configs change"),
+		gofr.AddFlag("dir", ".", "directory of the service"),
+		gofr.AddFlag("package", ".", "package of the main function of the service"),
+		gofr.AddFlag("args", "", "space-separated arguments of the service"),
+	)
+
+	app.Run()
+}
+
+func newService(ctx *gofr.Context) (interface{}, error) {
+	files, err := scaffold.NewService(ctx.Param("dir"), ctx.Param("name"), ctx.Param("module"))
+	printCreated(ctx, files)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Out.Info("run \"go mod tidy\" in the directory of the service to add its dependencies")
+
+	return nil, nil
+}
+
+func addHandler(ctx *gofr.Context) (interface{}, error) {
+	files, err := scaffold.AddHandler(ctx.Param("dir"), ctx.Param("name"), ctx.Param("method"), ctx.Param("path"))
+	printCreated(ctx, files)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Out.Info("register the route of the handler in main.go")
+
+	return nil, nil
+}
+
+func addMigration(ctx *gofr.Context) (interface{}, error) {
+	files, err := scaffold.AddMigration(ctx.Param("dir"), ctx.Param("name"), time.Now())
+	printCreated(ctx, files)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Out.Info("run the migrations using app.Migrate(migrations.All()) in main.go")
+
+	return nil, nil
+}
+
+func runService(ctx *gofr.Context) (interface{}, error) {
+	runCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return nil, devmode.Run(runCtx, devmode.Config{
+		Dir:     ctx.Param("dir"),
+		Package: ctx.Param("package"),
+		Args:    strings.Fields(ctx.Param("args")),
+	}, ctx.Out)
+}
+
+func printCreated(ctx *gofr.Context, files []string) {
+	for _, f := range files {
+		ctx.Out.Success("created %s", f)
+	}
+}
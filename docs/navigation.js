@@ -14,7 +14,25 @@ export const navigation = [
         title: 'Advanced Guide',
         links: [
             { title: "Scheduling Cron Jobs", href: "/docs/advanced-guide/using-cron"},
+            { title: 'Background Tasks', href: '/docs/advanced-guide/background-tasks' },
+            { title: 'Jobs', href: '/docs/advanced-guide/jobs' },
+            { title: 'Sagas', href: '/docs/advanced-guide/sagas' },
+            { title: 'Event Sourcing', href: '/docs/advanced-guide/event-sourcing' },
+            { title: 'Change Data Capture', href: '/docs/advanced-guide/change-data-capture' },
+            { title: 'Webhooks', href: '/docs/advanced-guide/webhooks' },
+            { title: 'Payment Webhooks', href: '/docs/advanced-guide/payment-webhooks' },
+            { title: 'Audit Logging', href: '/docs/advanced-guide/audit-logging' },
+            { title: 'Field Encryption', href: '/docs/advanced-guide/field-encryption' },
+            { title: 'Notifications', href: '/docs/advanced-guide/notifications' },
+            { title: 'Startup Tasks', href: '/docs/advanced-guide/startup-tasks' },
+            { title: 'CLI Applications', href: '/docs/advanced-guide/cli-applications' },
             { title: 'Overriding Default', href: '/docs/advanced-guide/overriding-default' },
+            { title: 'Response Caching', href: '/docs/advanced-guide/response-caching' },
+            { title: 'JSON Schema Validation', href: '/docs/advanced-guide/json-schema-validation' },
+            { title: 'Unix Sockets and Socket Activation', href: '/docs/advanced-guide/socket-listeners' },
+            { title: 'Large File Uploads', href: '/docs/advanced-guide/large-file-uploads' },
+            { title: 'Exports', href: '/docs/advanced-guide/exports' },
+            { title: 'Multi-Tenancy', href: '/docs/advanced-guide/multi-tenancy' },
             { title: 'Remote Log Level Change', href: '/docs/advanced-guide/remote-log-level-change' },
             { title: 'Publishing Custom Metrics', href: '/docs/advanced-guide/publishing-custom-metrics' },
             { title: 'Custom Spans in Tracing', href: '/docs/advanced-guide/custom-spans-in-tracing' },
@@ -22,6 +40,11 @@ export const navigation = [
             { title: 'HTTP Communication', href: '/docs/advanced-guide/http-communication' },
             { title: 'HTTP Authentication', href: '/docs/advanced-guide/http-authentication' },
             { title: 'Circuit Breaker Support', href: '/docs/advanced-guide/circuit-breaker' },
+            { title: 'Bulkheads', href: '/docs/advanced-guide/bulkheads' },
+            { title: 'Load Shedding', href: '/docs/advanced-guide/load-shedding' },
+            { title: 'Request Quotas', href: '/docs/advanced-guide/request-quotas' },
+            { title: 'Fault Injection', href: '/docs/advanced-guide/fault-injection' },
+            { title: 'Kubernetes', href: '/docs/advanced-guide/kubernetes' },
             { title: 'Monitoring Service Health', href: '/docs/advanced-guide/monitoring-service-health' },
             { title: 'Handling Data Migrations', href: '/docs/advanced-guide/handling-data-migrations' },
             { title: 'Writing gRPC Server', href: '/docs/advanced-guide/grpc' },
@@ -29,6 +52,7 @@ export const navigation = [
             { title: 'Injecting Databases', href: '/docs/advanced-guide/injecting-databases-drivers' },
             { title: 'Dealing with Datasources', href: '/docs/advanced-guide/dealing-with-datasources' },
             { title: 'Automatic SwaggerUI Rendering', href: '/docs/advanced-guide/swagger-documentation' },
+            { title: 'Integration Testing', href: '/docs/advanced-guide/integration-testing' },
             {title: 'Error Handling',href: '/docs/advanced-guide/gofr-errors'}
             // { title: 'Dealing with Remote Files', href: '/docs/advanced-guide/remote-files' },
             // { title: 'Supporting OAuth', href: '/docs/advanced-guide/oauth' },
@@ -0,0 +1,74 @@
+package gofr
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+)
+
+// admissionConfig returns the admission queue configured by ADMISSION_MAX_CONCURRENT, ADMISSION_MAX_QUEUE and
+// ADMISSION_QUEUE_TIMEOUT, and whether it is enabled.
+func admissionConfig(c config.Config, cont *container.Container) (middleware.AdmissionConfig, bool) {
+	var cfg middleware.AdmissionConfig
+
+	v := c.Get("ADMISSION_MAX_CONCURRENT")
+	if v == "" {
+		return cfg, false
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		cont.Errorf("invalid ADMISSION_MAX_CONCURRENT %q, the requests are not queued", v)
+
+		return cfg, false
+	}
+
+	cfg.MaxConcurrent = n
+
+	if v = c.Get("ADMISSION_MAX_QUEUE"); v != "" {
+		if cfg.MaxQueue, err = strconv.Atoi(v); err != nil || cfg.MaxQueue < 0 {
+			cont.Errorf("invalid ADMISSION_MAX_QUEUE %q, the requests beyond ADMISSION_MAX_CONCURRENT are rejected", v)
+
+			cfg.MaxQueue = 0
+		}
+	}
+
+	if v = c.Get("ADMISSION_QUEUE_TIMEOUT"); v != "" {
+		if cfg.QueueTimeout, err = time.ParseDuration(v); err != nil {
+			cont.Errorf("invalid ADMISSION_QUEUE_TIMEOUT %q, the requests wait until they are canceled", v)
+		}
+	}
+
+	return cfg, true
+}
+
+// useAdmissionControl adds the load shedding and the priority admission middlewares, if configured.
+func (a *App) useAdmissionControl() {
+	shedConfig, shed := loadShedConfig(a.Config, a.container)
+	queueConfig, queue := admissionConfig(a.Config, a.container)
+
+	if !shed && !queue {
+		return
+	}
+
+	a.routePriorities = &routePriorities{}
+	m := a.container.Metrics()
+
+	if shed {
+		m.NewCounter(middleware.ShedRequests, "Number of HTTP requests rejected as the server was overloaded, by "+
+			"route and priority.")
+		a.httpServer.router.Use(middleware.LoadShedding(middleware.NewLoadShedder(shedConfig), a.routePriorities.priority,
+			m))
+	}
+
+	if queue {
+		m.NewGauge(middleware.AdmissionQueueDepth, "Number of HTTP requests waiting to be admitted, by priority.")
+		m.NewCounter(middleware.AdmissionRejected, "Number of HTTP requests rejected by the admission queue, by "+
+			"priority and reason.")
+		a.httpServer.router.Use(middleware.Admission(middleware.NewAdmissionQueue(queueConfig, m),
+			a.routePriorities.priority))
+	}
+}
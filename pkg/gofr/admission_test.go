@@ -0,0 +1,38 @@
+package gofr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+)
+
+func TestAdmissionConfig(t *testing.T) {
+	c := container.NewContainer(config.NewMockConfig(nil))
+
+	testCases := []struct {
+		configs map[string]string
+		config  middleware.AdmissionConfig
+		enabled bool
+	}{
+		{map[string]string{}, middleware.AdmissionConfig{}, false},
+		{map[string]string{"ADMISSION_MAX_CONCURRENT": "100", "ADMISSION_MAX_QUEUE": "500",
+			"ADMISSION_QUEUE_TIMEOUT": "2s"},
+			middleware.AdmissionConfig{MaxConcurrent: 100, MaxQueue: 500, QueueTimeout: 2 * time.Second}, true},
+		{map[string]string{"ADMISSION_MAX_CONCURRENT": "100", "ADMISSION_MAX_QUEUE": "-1",
+			"ADMISSION_QUEUE_TIMEOUT": "soon"}, middleware.AdmissionConfig{MaxConcurrent: 100}, true},
+		{map[string]string{"ADMISSION_MAX_CONCURRENT": "0"}, middleware.AdmissionConfig{}, false},
+		{map[string]string{"ADMISSION_MAX_CONCURRENT": "many"}, middleware.AdmissionConfig{}, false},
+	}
+
+	for i, tc := range testCases {
+		cfg, enabled := admissionConfig(config.NewMockConfig(tc.configs), c)
+
+		assert.Equal(t, tc.config, cfg, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, tc.enabled, enabled, "TEST[%d], Failed.\n", i)
+	}
+}
@@ -0,0 +1,186 @@
+// Package apm exports the OpenTelemetry spans of the application to APM vendors, like Datadog or New Relic.
+package apm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+var (
+	errUnknownVendor    = errors.New("unknown tracer vendor")
+	errUnexpectedStatus = errors.New("unexpected response status code")
+)
+
+// Kind is the kind of the work of a span.
+type Kind string
+
+const (
+	// KindWeb is a request served by the application.
+	KindWeb Kind = "web"
+	// KindHTTP is a request to another service.
+	KindHTTP Kind = "http"
+	// KindSQL is a query of a SQL database.
+	KindSQL Kind = "sql"
+	// KindCache is a command of a cache, like Redis.
+	KindCache Kind = "cache"
+	// KindQueue is a message published or received.
+	KindQueue Kind = "queue"
+	// KindCustom is any other work, like a job or a span of the handlers.
+	KindCustom Kind = "custom"
+)
+
+// Span is a finished span of the application.
+type Span struct {
+	TraceID trace.TraceID
+	SpanID  trace.SpanID
+	// ParentID is the ID of the parent span, which is not valid for a root span.
+	ParentID trace.SpanID
+	Name     string
+	Service  string
+	Kind     Kind
+	Start    time.Time
+	Duration time.Duration
+	// Error is the description of the error of the span, if it failed.
+	Error      string
+	Failed     bool
+	Attributes map[string]string
+}
+
+// Adapter sends the spans to the tracer of a vendor.
+type Adapter interface {
+	Emit(ctx context.Context, spans []Span) error
+}
+
+// Factory creates the adapter of a vendor from the configs of the application.
+type Factory func(conf config.Config) (Adapter, error)
+
+//nolint:gochecknoglobals // the adapters of the vendors are registered once, like the drivers of database/sql.
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{
+		"datadog":  newDatadog,
+		"newrelic": newNewRelic,
+	}
+)
+
+// Register registers the factory of the adapter of a vendor, selected with TRACER_VENDOR.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	factories[strings.ToLower(name)] = factory
+}
+
+// New returns the adapter of the vendor, which is "datadog", "newrelic", or a vendor added by Register.
+func New(vendor string, conf config.Config) (Adapter, error) {
+	mu.RLock()
+	factory, ok := factories[strings.ToLower(vendor)]
+	mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w %q, the vendors are %v", errUnknownVendor, vendor, vendors())
+	}
+
+	return factory(conf)
+}
+
+func vendors() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Exporter is an OpenTelemetry exporter which converts the spans and emits them with an adapter.
+type Exporter struct {
+	adapter Adapter
+}
+
+// NewExporter returns the exporter emitting the spans with the adapter.
+func NewExporter(adapter Adapter) *Exporter {
+	return &Exporter{adapter: adapter}
+}
+
+// ExportSpans emits the spans with the adapter.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	converted := make([]Span, 0, len(spans))
+
+	for _, s := range spans {
+		converted = append(converted, convert(s))
+	}
+
+	return e.adapter.Emit(ctx, converted)
+}
+
+// Shutdown shuts down the exporter.
+func (*Exporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func convert(s sdktrace.ReadOnlySpan) Span {
+	span := Span{
+		TraceID:    s.SpanContext().TraceID(),
+		SpanID:     s.SpanContext().SpanID(),
+		ParentID:   s.Parent().SpanID(),
+		Name:       s.Name(),
+		Start:      s.StartTime(),
+		Duration:   s.EndTime().Sub(s.StartTime()),
+		Failed:     s.Status().Code == codes.Error,
+		Error:      s.Status().Description,
+		Attributes: make(map[string]string, len(s.Attributes())),
+	}
+
+	for _, kv := range s.Resource().Attributes() {
+		if kv.Key == "service.name" {
+			span.Service = kv.Value.AsString()
+		}
+	}
+
+	for _, kv := range s.Attributes() {
+		span.Attributes[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	span.Kind = kindOf(s.SpanKind(), span.Name, span.Attributes)
+
+	return span
+}
+
+// kindOf returns the kind of the span from its OpenTelemetry kind and attributes.
+func kindOf(kind trace.SpanKind, name string, attributes map[string]string) Kind {
+	switch {
+	case attributes["db.system"] == "redis":
+		return KindCache
+	case attributes["db.system"] != "" || attributes["db.statement"] != "":
+		return KindSQL
+	case attributes["messaging.system"] != "" || strings.Contains(name, "publish") || strings.Contains(name, "subscribe"):
+		return KindQueue
+	case kind == trace.SpanKindServer:
+		return KindWeb
+	case kind == trace.SpanKindClient && (attributes["http.method"] != "" || attributes["http.request.method"] != ""):
+		return KindHTTP
+	default:
+		return KindCustom
+	}
+}
@@ -0,0 +1,192 @@
+package apm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+// recorder is an adapter recording the emitted spans.
+type recorder struct {
+	spans []Span
+}
+
+func (r *recorder) Emit(_ context.Context, spans []Span) error {
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func TestExporter(t *testing.T) {
+	rec := &recorder{}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(NewExporter(rec)),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "orders"))))
+
+	ctx, parent := tp.Tracer("gofr").Start(context.Background(), "gofr-router", trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("http.method", "GET"), attribute.String("http.target", "/orders")))
+
+	_, child := tp.Tracer("otelsql").Start(ctx, "sql.conn.query", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.statement", "SELECT 1")))
+	child.SetStatus(codes.Error, "connection refused")
+	child.End()
+	parent.End()
+
+	require.Len(t, rec.spans, 2)
+
+	query, request := rec.spans[0], rec.spans[1]
+
+	assert.Equal(t, KindSQL, query.Kind)
+	assert.Equal(t, request.SpanID, query.ParentID)
+	assert.Equal(t, request.TraceID, query.TraceID)
+	assert.True(t, query.Failed)
+	assert.Equal(t, "connection refused", query.Error)
+
+	assert.Equal(t, KindWeb, request.Kind)
+	assert.Equal(t, "orders", request.Service)
+	assert.False(t, request.ParentID.IsValid())
+	assert.Equal(t, "GET /orders", resourceOf(&request))
+}
+
+func TestKindOf(t *testing.T) {
+	testCases := []struct {
+		kind       trace.SpanKind
+		name       string
+		attributes map[string]string
+		expected   Kind
+	}{
+		{trace.SpanKindServer, "gofr-router", nil, KindWeb},
+		{trace.SpanKindClient, "http://orders", map[string]string{"http.method": "GET"}, KindHTTP},
+		{trace.SpanKindClient, "query", map[string]string{"db.system": "postgresql"}, KindSQL},
+		{trace.SpanKindClient, "get", map[string]string{"db.system": "redis"}, KindCache},
+		{trace.SpanKindInternal, "kafka-publish", nil, KindQueue},
+		{trace.SpanKindConsumer, "receive", map[string]string{"messaging.system": "nats"}, KindQueue},
+		{trace.SpanKindInternal, "send-invoices", nil, KindCustom},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.expected, kindOf(tc.kind, tc.name, tc.attributes), "TEST[%d], Failed.\n%s", i, tc.name)
+	}
+}
+
+func testSpan() Span {
+	return Span{
+		TraceID:    trace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		SpanID:     trace.SpanID{0, 0, 0, 0, 0, 0, 0, 2},
+		ParentID:   trace.SpanID{0, 0, 0, 0, 0, 0, 0, 3},
+		Name:       "gofr-router",
+		Service:    "orders",
+		Kind:       KindWeb,
+		Start:      time.Unix(10, 0),
+		Duration:   1500 * time.Microsecond,
+		Failed:     true,
+		Error:      "timeout",
+		Attributes: map[string]string{"http.method": "GET", "http.route": "/orders/{id}"},
+	}
+}
+
+// vendorServer records the body and the headers of the requests of an adapter.
+func vendorServer(t *testing.T, status int) (*httptest.Server, *http.Request, *[]byte) {
+	t.Helper()
+
+	var (
+		received = &http.Request{}
+		body     = new([]byte)
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*received = *r
+		*body, _ = io.ReadAll(r.Body)
+
+		w.WriteHeader(status)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv, received, body
+}
+
+func TestDatadog(t *testing.T) {
+	srv, req, body := vendorServer(t, http.StatusOK)
+
+	adapter, err := New("datadog", config.NewMockConfig(map[string]string{
+		"DD_AGENT_HOST":       srv.Listener.Addr().(*net.TCPAddr).IP.String(),
+		"DD_TRACE_AGENT_PORT": strconv.Itoa(srv.Listener.Addr().(*net.TCPAddr).Port),
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, adapter.Emit(context.Background(), []Span{testSpan()}))
+
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "/v0.3/traces", req.URL.Path)
+	assert.Equal(t, "1", req.Header.Get("X-Datadog-Trace-Count"))
+	assert.JSONEq(t, `[[{"trace_id":1,"span_id":2,"parent_id":3,"name":"gofr-router","resource":"GET /orders/{id}",
+		"service":"orders","type":"web","start":10000000000,"duration":1500000,"error":1,
+		"meta":{"http.method":"GET","http.route":"/orders/{id}","error.msg":"timeout"}}]]`, string(*body))
+}
+
+func TestNewRelic(t *testing.T) {
+	srv, req, body := vendorServer(t, http.StatusAccepted)
+
+	adapter, err := New("NewRelic", config.NewMockConfig(map[string]string{
+		"NEW_RELIC_LICENSE_KEY": "key",
+		"NEW_RELIC_TRACE_URL":   srv.URL,
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, adapter.Emit(context.Background(), []Span{testSpan()}))
+
+	assert.Equal(t, "key", req.Header.Get("Api-Key"))
+	assert.Equal(t, "newrelic", req.Header.Get("Data-Format"))
+
+	var payload []map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(*body, &payload))
+
+	span := payload[0]["spans"].([]interface{})[0].(map[string]interface{})
+
+	assert.Equal(t, "00000000000000000000000000000001", span["trace.id"])
+	assert.Equal(t, "0000000000000002", span["id"])
+	assert.InDelta(t, 10000, span["timestamp"], 0)
+	assert.Equal(t, map[string]interface{}{"name": "gofr-router", "service.name": "orders", "duration.ms": 1.5,
+		"span.kind": "web", "parent.id": "0000000000000003", "error.message": "timeout", "http.method": "GET",
+		"http.route": "/orders/{id}"}, span["attributes"])
+}
+
+func TestNew_Errors(t *testing.T) {
+	_, err := New("newrelic", config.NewMockConfig(nil))
+	assert.ErrorIs(t, err, errNewRelicLicenseKey)
+
+	_, err = New("dynatrace", config.NewMockConfig(nil))
+	assert.ErrorIs(t, err, errUnknownVendor)
+
+	Register("dynatrace", func(config.Config) (Adapter, error) {
+		return &recorder{}, nil
+	})
+
+	adapter, err := New("dynatrace", config.NewMockConfig(nil))
+	require.NoError(t, err)
+	assert.IsType(t, &recorder{}, adapter)
+}
+
+func TestSend_UnexpectedStatus(t *testing.T) {
+	srv, _, _ := vendorServer(t, http.StatusForbidden)
+
+	err := send(context.Background(), srv.Client(), http.MethodPost, srv.URL, nil, nil)
+
+	assert.ErrorIs(t, err, errUnexpectedStatus)
+}
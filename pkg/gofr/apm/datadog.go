@@ -0,0 +1,172 @@
+package apm
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+// sendTimeout bounds the time of the sending of the spans to a vendor.
+const sendTimeout = 10 * time.Second
+
+// datadog sends the spans to the Datadog agent, with its trace API of JSON.
+type datadog struct {
+	url    string
+	client *http.Client
+}
+
+// datadogSpan is a span of the trace API of the Datadog agent.
+type datadogSpan struct {
+	TraceID  uint64            `json:"trace_id"`
+	SpanID   uint64            `json:"span_id"`
+	ParentID uint64            `json:"parent_id,omitempty"`
+	Name     string            `json:"name"`
+	Resource string            `json:"resource"`
+	Service  string            `json:"service"`
+	Type     string            `json:"type"`
+	Start    int64             `json:"start"`
+	Duration int64             `json:"duration"`
+	Error    int32             `json:"error"`
+	Meta     map[string]string `json:"meta,omitempty"`
+}
+
+// newDatadog returns the adapter of the Datadog agent at DD_AGENT_HOST and DD_TRACE_AGENT_PORT.
+func newDatadog(conf config.Config) (Adapter, error) {
+	host := conf.GetOrDefault("DD_AGENT_HOST", "localhost")
+	port := conf.GetOrDefault("DD_TRACE_AGENT_PORT", "8126")
+
+	return &datadog{
+		url:    fmt.Sprintf("http://%s:%s/v0.3/traces", host, port),
+		client: &http.Client{Timeout: sendTimeout},
+	}, nil
+}
+
+func (d *datadog) Emit(ctx context.Context, spans []Span) error {
+	traces := make(map[uint64][]datadogSpan)
+	order := make([]uint64, 0)
+
+	for i := range spans {
+		s := d.convert(&spans[i])
+
+		if _, ok := traces[s.TraceID]; !ok {
+			order = append(order, s.TraceID)
+		}
+
+		traces[s.TraceID] = append(traces[s.TraceID], s)
+	}
+
+	payload := make([][]datadogSpan, 0, len(order))
+	for _, id := range order {
+		payload = append(payload, traces[id])
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return send(ctx, d.client, http.MethodPut, d.url, body, map[string]string{
+		"Datadog-Meta-Lang":     "go",
+		"X-Datadog-Trace-Count": strconv.Itoa(len(payload)),
+	})
+}
+
+func (*datadog) convert(s *Span) datadogSpan {
+	span := datadogSpan{
+		// the IDs of Datadog are the lower 64 bits of the IDs of OpenTelemetry.
+		TraceID:  binary.BigEndian.Uint64(s.TraceID[8:]),
+		SpanID:   binary.BigEndian.Uint64(s.SpanID[:]),
+		Name:     s.Name,
+		Resource: resourceOf(s),
+		Service:  s.Service,
+		Type:     string(s.Kind),
+		Start:    s.Start.UnixNano(),
+		Duration: s.Duration.Nanoseconds(),
+		Meta:     s.Attributes,
+	}
+
+	if s.ParentID.IsValid() {
+		span.ParentID = binary.BigEndian.Uint64(s.ParentID[:])
+	}
+
+	if s.Failed {
+		span.Error = 1
+
+		if s.Error != "" {
+			span.Meta = make(map[string]string, len(s.Attributes)+1)
+
+			for k, v := range s.Attributes {
+				span.Meta[k] = v
+			}
+
+			span.Meta["error.msg"] = s.Error
+		}
+	}
+
+	return span
+}
+
+// resourceOf returns the Datadog resource of the span, like "GET /orders/{id}".
+func resourceOf(s *Span) string {
+	switch s.Kind {
+	case KindWeb, KindHTTP:
+		method := first(s.Attributes, "http.method", "http.request.method")
+		target := first(s.Attributes, "http.route", "http.target", "url.path", "http.url")
+
+		if method != "" && target != "" {
+			return method + " " + target
+		}
+	case KindSQL, KindCache:
+		if statement := s.Attributes["db.statement"]; statement != "" {
+			return statement
+		}
+	case KindQueue, KindCustom:
+	}
+
+	return s.Name
+}
+
+// first returns the first of the attributes which is set.
+func first(attributes map[string]string, keys ...string) string {
+	for _, k := range keys {
+		if v := attributes[k]; v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// send sends the body of the spans to the vendor, which must respond with a 2xx status.
+func send(ctx context.Context, client *http.Client, method, url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d from %v", errUnexpectedStatus, resp.StatusCode, url)
+	}
+
+	return nil
+}
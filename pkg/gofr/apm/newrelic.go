@@ -0,0 +1,100 @@
+package apm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+const defaultNewRelicTraceURL = "https://trace-api.newrelic.com/trace/v1"
+
+var errNewRelicLicenseKey = errors.New("NEW_RELIC_LICENSE_KEY is required to export traces to New Relic")
+
+// newRelic sends the spans to the Trace API of New Relic, in its own format.
+type newRelic struct {
+	url        string
+	licenseKey string
+	client     *http.Client
+}
+
+// newRelicPayload is a batch of spans of the Trace API of New Relic.
+type newRelicPayload struct {
+	Common struct {
+		Attributes map[string]interface{} `json:"attributes"`
+	} `json:"common"`
+	Spans []newRelicSpan `json:"spans"`
+}
+
+type newRelicSpan struct {
+	TraceID    string                 `json:"trace.id"`
+	ID         string                 `json:"id"`
+	Timestamp  int64                  `json:"timestamp"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// newNewRelic returns the adapter of the Trace API of New Relic, at NEW_RELIC_TRACE_URL.
+func newNewRelic(conf config.Config) (Adapter, error) {
+	key := conf.Get("NEW_RELIC_LICENSE_KEY")
+	if key == "" {
+		return nil, errNewRelicLicenseKey
+	}
+
+	return &newRelic{
+		url:        conf.GetOrDefault("NEW_RELIC_TRACE_URL", defaultNewRelicTraceURL),
+		licenseKey: key,
+		client:     &http.Client{Timeout: sendTimeout},
+	}, nil
+}
+
+func (n *newRelic) Emit(ctx context.Context, spans []Span) error {
+	var payload newRelicPayload
+
+	payload.Common.Attributes = map[string]interface{}{"instrumentation.provider": "gofr"}
+	payload.Spans = make([]newRelicSpan, 0, len(spans))
+
+	for i := range spans {
+		payload.Spans = append(payload.Spans, n.convert(&spans[i]))
+	}
+
+	body, err := json.Marshal([]newRelicPayload{payload})
+	if err != nil {
+		return err
+	}
+
+	return send(ctx, n.client, http.MethodPost, n.url, body, map[string]string{
+		"Api-Key":             n.licenseKey,
+		"Data-Format":         "newrelic",
+		"Data-Format-Version": "1",
+	})
+}
+
+func (*newRelic) convert(s *Span) newRelicSpan {
+	attributes := make(map[string]interface{}, len(s.Attributes)+6)
+
+	for k, v := range s.Attributes {
+		attributes[k] = v
+	}
+
+	attributes["name"] = s.Name
+	attributes["service.name"] = s.Service
+	attributes["duration.ms"] = float64(s.Duration.Microseconds()) / 1000
+	attributes["span.kind"] = string(s.Kind)
+
+	if s.ParentID.IsValid() {
+		attributes["parent.id"] = s.ParentID.String()
+	}
+
+	if s.Failed {
+		attributes["error.message"] = s.Error
+	}
+
+	return newRelicSpan{
+		TraceID:    s.TraceID.String(),
+		ID:         s.SpanID.String(),
+		Timestamp:  s.Start.UnixMilli(),
+		Attributes: attributes,
+	}
+}
@@ -0,0 +1,70 @@
+package gofr
+
+import (
+	"net/http"
+	"strings"
+)
+
+// The components of the application which are run depending on the APP_MODE config.
+const (
+	// ModeHTTP runs the HTTP and gRPC servers.
+	ModeHTTP = "http"
+	// ModeWorker runs the pubsub subscribers and the enqueued jobs.
+	ModeWorker = "worker"
+	// ModeCron runs the cron jobs.
+	ModeCron = "cron"
+)
+
+// runMode is the set of the components run by the application. All of them are run if it is empty.
+type runMode map[string]bool
+
+// parseRunMode parses the comma-separated components of APP_MODE, ignoring the unknown ones.
+func (a *App) parseRunMode(value string) runMode {
+	mode := make(runMode)
+
+	for _, m := range strings.Split(value, ",") {
+		switch m = strings.ToLower(strings.TrimSpace(m)); m {
+		case "":
+		case ModeHTTP, ModeWorker, ModeCron:
+			mode[m] = true
+		default:
+			a.container.Errorf("unknown APP_MODE %q, expected one of http, worker and cron", m)
+		}
+	}
+
+	return mode
+}
+
+func (m runMode) runs(component string) bool {
+	return len(m) == 0 || m[component]
+}
+
+// RunWorkers runs only the subscribers and the jobs of the application, like APP_MODE=worker.
+func (a *App) RunWorkers() {
+	a.mode = runMode{ModeWorker: true}
+
+	a.Run()
+}
+
+// newCron returns the cron table, which schedules nothing if the mode does not run the cron jobs.
+func (a *App) newCron() *Crontab {
+	c := NewCron(a.container)
+	c.leader = a.leader.elector()
+
+	if !a.mode.runs(ModeCron) {
+		c.ticker.Stop()
+	}
+
+	return c
+}
+
+// handleHealthOnMetricServer serves the health endpoints on the metrics server.
+func (a *App) handleHealthOnMetricServer() {
+	for path, h := range map[string]Handler{
+		"/.well-known/health": healthHandler,
+		a.Config.GetOrDefault("HEALTH_LIVENESS_PATH", defaultLivenessPath):   liveHandler,
+		a.Config.GetOrDefault("HEALTH_READINESS_PATH", defaultReadinessPath): readyHandler,
+	} {
+		a.metricServer.handle(http.MethodGet, path, handler{function: h, container: a.container})
+	}
+}
@@ -0,0 +1,99 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
+)
+
+func TestApp_parseRunMode(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		value   string
+		expHTTP bool
+		expWork bool
+		expCron bool
+	}{
+		{"all by default", "", true, true, true},
+		{"worker", "worker", false, true, false},
+		{"worker and cron", " Worker, cron ", false, true, true},
+		{"http", "http", true, false, false},
+		{"unknown ignored", "http,consumer", true, false, false},
+		{"only unknown runs all", "consumer", true, true, true},
+	}
+
+	for i, tc := range testCases {
+		a := &App{container: container.NewContainer(config.NewMockConfig(nil))}
+
+		var mode runMode
+
+		testutil.StderrOutputForFunc(func() {
+			mode = a.parseRunMode(tc.value)
+		})
+
+		assert.Equal(t, tc.expHTTP, mode.runs(ModeHTTP), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expWork, mode.runs(ModeWorker), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expCron, mode.runs(ModeCron), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_RunWorkers(t *testing.T) {
+	a := &App{
+		Config:              config.NewMockConfig(nil),
+		container:           container.NewContainer(config.NewMockConfig(nil)),
+		httpServer:          &httpServer{router: gofrHTTP.NewRouter(), port: 8011},
+		metricServer:        newMetricServer(2131),
+		subscriptionManager: SubscriptionManager{},
+	}
+
+	a.GET("/hello", func(*Context) (interface{}, error) { return "hello", nil })
+
+	go a.RunWorkers()
+	time.Sleep(300 * time.Millisecond)
+
+	client := &http.Client{Timeout: time.Second}
+
+	get := func(url string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, http.NoBody)
+		require.NoError(t, err)
+
+		return client.Do(req)
+	}
+
+	resp, err := get("http://localhost:2131" + defaultLivenessPath)
+	require.NoError(t, err)
+
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "health should be served on the metrics port")
+
+	resp, err = get("http://localhost:8011/hello")
+	if err == nil {
+		resp.Body.Close()
+	}
+
+	assert.Error(t, err, "HTTP server should not be started in worker mode")
+}
+
+func TestApp_newCron(t *testing.T) {
+	a := &App{container: container.NewContainer(config.NewMockConfig(nil)), mode: runMode{ModeHTTP: true}}
+
+	ran := make(chan struct{}, 1)
+
+	a.AddIntervalJob(time.Second, "tick", func(*Context) { ran <- struct{}{} })
+
+	select {
+	case <-ran:
+		t.Error("cron job should not run when the mode does not include cron")
+	case <-time.After(1500 * time.Millisecond):
+	}
+}
@@ -0,0 +1,267 @@
+// Package apptest runs a GoFr application in a test and provides a client for its HTTP routes.
+//
+//	func TestOrders(t *testing.T) {
+//		ts := apptest.New(t, newApp, apptest.WithConfig(map[string]string{"DB_NAME": "orders_test"}))
+//
+//		ts.POST("/orders", Order{Item: "book"}).AssertStatus(http.StatusCreated)
+//		ts.GET("/orders/1").AssertStatus(http.StatusOK).AssertData(Order{ID: 1, Item: "book"})
+//	}
+package apptest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr"
+)
+
+const (
+	defaultStartTimeout    = 5 * time.Second
+	defaultShutdownTimeout = 5 * time.Second
+	defaultLivenessPath    = "/.well-known/alive"
+	readinessPollInterval  = 20 * time.Millisecond
+)
+
+// Server is a GoFr application running in the process of a test.
+type Server struct {
+	// App is the application, whose container can be used to prepare the data of the tests.
+	App *gofr.App
+
+	// URL is the base URL of the HTTP server, like "http://localhost:43121".
+	URL string
+	// MetricsURL is the base URL of the metrics server.
+	MetricsURL string
+	// GRPCAddr is the address of the gRPC server, like "localhost:43123".
+	GRPCAddr string
+
+	// Header is sent with every request, like an Authorization header.
+	Header http.Header
+
+	t      testing.TB
+	client *http.Client
+}
+
+type options struct {
+	configs      map[string]string
+	startTimeout time.Duration
+}
+
+// Option configures the Server started by New.
+type Option func(o *options)
+
+// WithConfig overrides the configs of the application.
+func WithConfig(configs map[string]string) Option {
+	return func(o *options) {
+		for k, v := range configs {
+			o.configs[k] = v
+		}
+	}
+}
+
+// WithStartTimeout sets the time the application is given to start serving requests, which is 5 seconds by default.
+func WithStartTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.startTimeout = timeout
+	}
+}
+
+// New runs the application created by newApp on ephemeral ports until the test completes.
+// It sets environment variables, so it cannot be used in parallel tests.
+func New(t testing.TB, newApp func() *gofr.App, opts ...Option) *Server {
+	t.Helper()
+
+	o := &options{configs: make(map[string]string), startTimeout: defaultStartTimeout}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	ports := map[string]int{}
+
+	for _, key := range []string{"HTTP_PORT", "GRPC_PORT", "METRICS_PORT"} {
+		if _, ok := o.configs[key]; !ok {
+			ports[key] = freePort(t)
+			o.configs[key] = strconv.Itoa(ports[key])
+		}
+	}
+
+	for k, v := range o.configs {
+		t.Setenv(k, v)
+	}
+
+	s := &Server{
+		App:        newApp(),
+		URL:        "http://localhost:" + o.configs["HTTP_PORT"],
+		MetricsURL: "http://localhost:" + o.configs["METRICS_PORT"],
+		GRPCAddr:   "localhost:" + o.configs["GRPC_PORT"],
+		Header:     make(http.Header),
+		t:          t,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+
+	go s.App.Run()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultShutdownTimeout)
+		defer cancel()
+
+		// the kept-alive connections of the client would otherwise delay the shutdown of the HTTP server.
+		s.client.CloseIdleConnections()
+
+		if err := s.App.Shutdown(ctx); err != nil {
+			t.Errorf("could not shut down the application: %v", err)
+		}
+	})
+
+	s.waitForStart(o)
+
+	return s
+}
+
+// freePort returns a port which is free to listen on.
+func freePort(t testing.TB) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not find a free port: %v", err)
+	}
+
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// waitForStart waits for the liveness endpoint of the application to respond.
+func (s *Server) waitForStart(o *options) {
+	s.t.Helper()
+
+	url := s.URL
+	if mode := s.App.Config.Get("APP_MODE"); mode != "" && !strings.Contains(mode, gofr.ModeHTTP) {
+		url = s.MetricsURL
+	}
+
+	url += s.App.Config.GetOrDefault("HEALTH_LIVENESS_PATH", defaultLivenessPath)
+	deadline := time.Now().Add(o.startTimeout)
+
+	for {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, url, http.NoBody)
+
+		resp, err := s.client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+
+			if resp.StatusCode == http.StatusOK {
+				return
+			}
+		}
+
+		if time.Now().After(deadline) {
+			s.t.Fatalf("application did not start serving %s within %v, last error: %v", url, o.startTimeout, err)
+		}
+
+		time.Sleep(readinessPollInterval)
+	}
+}
+
+// GET sends a GET request to the path of the application.
+func (s *Server) GET(path string) *Response {
+	return s.request(http.MethodGet, path, nil)
+}
+
+// DELETE sends a DELETE request to the path of the application.
+func (s *Server) DELETE(path string) *Response {
+	return s.request(http.MethodDelete, path, nil)
+}
+
+// POST sends a POST request with the body, which is marshalled as JSON unless it is a string or a []byte.
+func (s *Server) POST(path string, body interface{}) *Response {
+	return s.request(http.MethodPost, path, body)
+}
+
+// PUT sends a PUT request to the path of the application, with the body sent like for POST.
+func (s *Server) PUT(path string, body interface{}) *Response {
+	return s.request(http.MethodPut, path, body)
+}
+
+// PATCH sends a PATCH request to the path of the application, with the body sent like for POST.
+func (s *Server) PATCH(path string, body interface{}) *Response {
+	return s.request(http.MethodPatch, path, body)
+}
+
+func (s *Server) request(method, path string, body interface{}) *Response {
+	s.t.Helper()
+
+	var reader io.Reader = http.NoBody
+
+	if body != nil {
+		data, err := requestBody(body)
+		if err != nil {
+			s.t.Fatalf("could not marshal the body of %s %s: %v", method, path, err)
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, s.URL+path, reader)
+	if err != nil {
+		s.t.Fatalf("could not create the request %s %s: %v", method, path, err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return s.Do(req)
+}
+
+func requestBody(body interface{}) ([]byte, error) {
+	switch b := body.(type) {
+	case string:
+		return []byte(b), nil
+	case []byte:
+		return b, nil
+	default:
+		return json.Marshal(body)
+	}
+}
+
+// Do sends the request to the application, along with the headers of the server which it does not set.
+func (s *Server) Do(req *http.Request) *Response {
+	s.t.Helper()
+
+	for k, v := range s.Header {
+		if req.Header.Get(k) == "" {
+			req.Header[k] = v
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.t.Fatalf("request %s %s failed: %v", req.Method, req.URL.Path, err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		s.t.Fatalf("could not read the response of %s %s: %v", req.Method, req.URL.Path, err)
+	}
+
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+		t:          s.t,
+		request:    fmt.Sprintf("%s %s", req.Method, req.URL.Path),
+	}
+}
@@ -0,0 +1,144 @@
+package apptest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr"
+)
+
+type order struct {
+	ID   int    `json:"id"`
+	Item string `json:"item"`
+}
+
+func newTestApp() *gofr.App {
+	app := gofr.New()
+
+	defaultItem := app.Config.Get("DEFAULT_ITEM")
+
+	app.GET("/orders/{id}", func(*gofr.Context) (interface{}, error) {
+		return order{ID: 1, Item: defaultItem}, nil
+	})
+
+	app.POST("/orders", func(ctx *gofr.Context) (interface{}, error) {
+		var o order
+
+		if err := ctx.Bind(&o); err != nil {
+			return nil, err
+		}
+
+		o.ID = 2
+
+		return o, nil
+	})
+
+	app.GET("/whoami", func(ctx *gofr.Context) (interface{}, error) {
+		return ctx.Request.GetHeader("X-User"), nil
+	})
+
+	return app
+}
+
+func TestServer(t *testing.T) {
+	ts := New(t, newTestApp, WithConfig(map[string]string{"DEFAULT_ITEM": "book"}))
+
+	ts.GET("/orders/1").
+		AssertStatus(http.StatusOK).
+		AssertHeader("Content-Type", "application/json").
+		AssertData(order{ID: 1, Item: "book"}).
+		AssertJSON(`{"data":{"item":"book","id":1}}`)
+
+	var created order
+
+	ts.POST("/orders", order{Item: "pen"}).AssertStatus(http.StatusCreated).Decode(&created)
+	assert.Equal(t, order{ID: 2, Item: "pen"}, created)
+
+	ts.POST("/orders", `{"item":"ink"}`).AssertData(order{ID: 2, Item: "ink"})
+
+	ts.Header.Set("X-User", "gofr")
+	ts.GET("/whoami").AssertData("gofr")
+
+	ts.GET("/missing").AssertStatus(http.StatusNotFound)
+
+	assertMetrics(t, ts)
+}
+
+func TestServer_Metrics(t *testing.T) {
+	first := New(t, newTestApp)
+	second := New(t, newTestApp)
+
+	first.GET("/orders/1").AssertStatus(http.StatusOK)
+	second.GET("/orders/1").AssertStatus(http.StatusOK)
+
+	assertMetrics(t, first)
+	assertMetrics(t, second)
+}
+
+func assertMetrics(t *testing.T, ts *Server) {
+	t.Helper()
+
+	resp, err := http.Get(ts.MetricsURL + "/metrics") //nolint:noctx // the test does not need a context.
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "app_http_response")
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	var url string
+
+	t.Run("server", func(t *testing.T) {
+		url = New(t, newTestApp).URL
+	})
+
+	_, err := net.DialTimeout("tcp", url[len("http://"):], time.Second)
+	assert.Error(t, err, "server should be shut down once the test completes")
+}
+
+func TestServer_WorkerMode(t *testing.T) {
+	ts := New(t, func() *gofr.App { return gofr.New() }, WithConfig(map[string]string{"APP_MODE": "worker"}))
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL+"/.well-known/alive", http.NoBody)
+
+	_, err := http.DefaultClient.Do(req)
+	assert.Error(t, err, "HTTP server should not be started in worker mode")
+}
+
+// recordingT records the failures of the assertions, which are expected to fail.
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestResponse_AssertionFailures(t *testing.T) {
+	rt := &recordingT{TB: t}
+
+	r := &Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: []byte(`{"data":{"id":1}}`), t: rt,
+		request: "GET /orders/1"}
+
+	r.AssertStatus(http.StatusCreated).
+		AssertHeader("X-Test", "1").
+		AssertJSON(`{"data":{"id":2}}`).
+		AssertData(order{ID: 1})
+
+	assert.Len(t, rt.errors, 4)
+	assert.Contains(t, rt.errors[0], "unexpected status code of GET /orders/1")
+}
@@ -0,0 +1,85 @@
+package apptest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Response is the response to a request, whose chainable assertions do not stop the test.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+
+	t       testing.TB
+	request string
+}
+
+// AssertStatus asserts that the status code of the response is code.
+func (r *Response) AssertStatus(code int) *Response {
+	r.t.Helper()
+
+	assert.Equal(r.t, code, r.StatusCode, "unexpected status code of %s, body: %s", r.request, r.Body)
+
+	return r
+}
+
+// AssertHeader asserts that the header of the response has the value.
+func (r *Response) AssertHeader(key, value string) *Response {
+	r.t.Helper()
+
+	assert.Equal(r.t, value, r.Header.Get(key), "unexpected header %s of %s", key, r.request)
+
+	return r
+}
+
+// AssertJSON asserts that the body is the expected JSON, regardless of its formatting.
+func (r *Response) AssertJSON(expected string) *Response {
+	r.t.Helper()
+
+	assert.JSONEq(r.t, expected, string(r.Body), "unexpected body of %s", r.request)
+
+	return r
+}
+
+// AssertData asserts that the "data" of the body is expected once marshalled as JSON.
+func (r *Response) AssertData(expected interface{}) *Response {
+	r.t.Helper()
+
+	exp, err := json.Marshal(expected)
+	if !assert.NoError(r.t, err, "could not marshal the expected data of %s", r.request) {
+		return r
+	}
+
+	var body struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if !assert.NoError(r.t, json.Unmarshal(r.Body, &body), "body of %s is not JSON: %s", r.request, r.Body) {
+		return r
+	}
+
+	assert.JSONEq(r.t, string(exp), string(body.Data), "unexpected data of %s", r.request)
+
+	return r
+}
+
+// Decode decodes the data of the response, which GoFr wraps in the "data" key of the body, into v.
+func (r *Response) Decode(v interface{}) {
+	r.t.Helper()
+
+	var body struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(r.Body, &body); err != nil {
+		r.t.Fatalf("body of %s is not JSON: %v, body: %s", r.request, err, r.Body)
+	}
+
+	if err := json.Unmarshal(body.Data, v); err != nil {
+		r.t.Fatalf("could not decode the data of %s: %v, body: %s", r.request, err, r.Body)
+	}
+}
@@ -0,0 +1,104 @@
+package gofr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+)
+
+const maxAuditQueryLimit = 1000
+
+var errInvalidAuditTime = errors.New("from and to must be RFC 3339 times")
+
+// AuditEntry is a record of the audit trail written by Context.Audit.
+type AuditEntry = container.AuditEntry
+
+// Audit records the action on the resource, with its states before and after, in the audit trail chosen by AUDIT_SINK.
+func (c *Context) Audit(action, resource string, before, after interface{}) error {
+	entry := &AuditEntry{Actor: c.actor(), Action: action, Resource: resource}
+
+	var err error
+
+	if entry.Before, err = marshalAuditState(before); err != nil {
+		return err
+	}
+
+	if entry.After, err = marshalAuditState(after); err != nil {
+		return err
+	}
+
+	return c.Container.Audit(c.Context, entry)
+}
+
+// SetAuditSink replaces the sink of the audit entries.
+func (a *App) SetAuditSink(sink container.AuditSink) {
+	a.container.SetAuditSink(sink)
+}
+
+// actor returns the subject of the JWT claims of the request, or "" if it has none.
+func (c *Context) actor() string {
+	claims, ok := c.Context.Value(middleware.JWTClaim("JWTClaims")).(jwt.Claims)
+	if !ok {
+		return ""
+	}
+
+	sub, _ := claims.GetSubject()
+
+	return sub
+}
+
+func marshalAuditState(state interface{}) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(state)
+}
+
+// auditHandler lists the audit entries matching the query parameters, the most recent first.
+func auditHandler(c *container.Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		filter := container.AuditFilter{
+			Actor:    query.Get("actor"),
+			Tenant:   query.Get("tenant"),
+			Action:   query.Get("action"),
+			Resource: query.Get("resource"),
+		}
+
+		for param, t := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+			if query.Get(param) == "" {
+				continue
+			}
+
+			parsed, err := time.Parse(time.RFC3339, query.Get(param))
+			if err != nil {
+				writeAdminError(w, http.StatusBadRequest, errInvalidAuditTime)
+
+				return
+			}
+
+			*t = parsed
+		}
+
+		filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+		filter.Limit = min(filter.Limit, maxAuditQueryLimit)
+
+		entries, err := c.QueryAudit(r.Context(), filter)
+		if err != nil {
+			writeAdminError(w, http.StatusInternalServerError, err)
+
+			return
+		}
+
+		writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": entries})
+	})
+}
@@ -0,0 +1,97 @@
+package gofr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+)
+
+func TestContext_Audit(t *testing.T) {
+	c := container.NewContainer(config.NewMockConfig(map[string]string{
+		"AUDIT_SINK": "file",
+		"AUDIT_FILE": filepath.Join(t.TempDir(), "audit.log"),
+	}))
+
+	claims := jwt.MapClaims{"sub": "alice"}
+
+	ctx := &Context{
+		Context:   context.WithValue(context.Background(), middleware.JWTClaim("JWTClaims"), claims),
+		Container: c,
+	}
+
+	require.NoError(t, ctx.Audit("order.update", "orders/1", map[string]int{"amount": 10}, map[string]int{"amount": 20}))
+	require.NoError(t, (&Context{Context: context.Background(), Container: c}).Audit("order.delete", "orders/2", nil, nil))
+
+	assert.Error(t, ctx.Audit("order.update", "orders/1", nil, make(chan int)))
+
+	tests := []struct {
+		desc       string
+		query      string
+		statusCode int
+		actions    []string
+	}{
+		{"all entries", "", http.StatusOK, []string{"order.delete", "order.update"}},
+		{"by actor", "?actor=alice", http.StatusOK, []string{"order.update"}},
+		{"by time", "?from=2000-01-01T00:00:00Z&to=2001-01-01T00:00:00Z", http.StatusOK, []string{}},
+		{"invalid time", "?from=yesterday", http.StatusBadRequest, []string{}},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+
+		auditHandler(c).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/audit"+tc.query, http.NoBody))
+
+		var body struct {
+			Data []AuditEntry `json:"data"`
+		}
+
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		actions := make([]string, 0, len(body.Data))
+		for _, e := range body.Data {
+			actions = append(actions, e.Action)
+		}
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.actions, actions, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	entries, err := c.QueryAudit(context.Background(), container.AuditFilter{Actor: "alice"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount":10}`, string(entries[0].Before))
+	assert.JSONEq(t, `{"amount":20}`, string(entries[0].After))
+}
+
+func TestApp_SetAuditSink(t *testing.T) {
+	a := &App{container: &container.Container{}}
+
+	_, err := a.container.AuditSink()
+	assert.Error(t, err)
+
+	sink := &auditRecorder{}
+	a.SetAuditSink(sink)
+
+	require.NoError(t, (&Context{Context: context.Background(), Container: a.container}).Audit("login", "users/1", nil, nil))
+	assert.Len(t, sink.entries, 1)
+}
+
+type auditRecorder struct {
+	entries []*AuditEntry
+}
+
+func (r *auditRecorder) Write(_ context.Context, entry *AuditEntry) error {
+	r.entries = append(r.entries, entry)
+
+	return nil
+}
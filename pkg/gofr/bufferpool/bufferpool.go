@@ -0,0 +1,65 @@
+// Package bufferpool provides the pool of byte buffers shared by the framework.
+package bufferpool
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+)
+
+// maxSize is the capacity above which the buffers are not returned to the pool.
+const maxSize = 64 << 10
+
+//nolint:gochecknoglobals // the pool and its counters are shared by the framework.
+var (
+	pool = sync.Pool{
+		New: func() interface{} { return new(bytes.Buffer) },
+	}
+
+	gets, puts, discarded atomic.Uint64
+)
+
+// Stats are the counts of the buffers taken from and returned to the pool since the application started.
+type Stats struct {
+	// Gets is the number of buffers taken from the pool.
+	Gets uint64
+	// Puts is the number of buffers returned to the pool.
+	Puts uint64
+	// Discarded is the number of buffers returned to the pool which were dropped for being larger than 64KB.
+	Discarded uint64
+}
+
+// InUse returns the number of buffers which have not been returned to the pool.
+func (s Stats) InUse() uint64 {
+	return s.Gets - s.Puts
+}
+
+// Get returns an empty buffer from the pool, which must be returned to it using Put once it is no longer used.
+func Get() *bytes.Buffer {
+	gets.Add(1)
+
+	b, _ := pool.Get().(*bytes.Buffer)
+
+	return b
+}
+
+// Put returns the buffer to the pool. The buffer must not be used after it is returned.
+func Put(b *bytes.Buffer) {
+	puts.Add(1)
+
+	if b.Cap() > maxSize {
+		discarded.Add(1)
+		return
+	}
+
+	b.Reset()
+	pool.Put(b)
+}
+
+// ReadStats returns the counts of the buffers taken from and returned to the pool.
+func ReadStats() Stats {
+	// puts is read before gets, so that Puts does not exceed Gets for the buffers taken and returned in between.
+	p := puts.Load()
+
+	return Stats{Gets: gets.Load(), Puts: p, Discarded: discarded.Load()}
+}
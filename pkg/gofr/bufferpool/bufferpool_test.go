@@ -0,0 +1,31 @@
+package bufferpool
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	before := ReadStats()
+
+	b := Get()
+	b.WriteString("gofr")
+
+	assert.Equal(t, uint64(1), ReadStats().InUse()-before.InUse(), "taken buffer should be in use")
+
+	Put(b)
+
+	assert.Zero(t, b.Len(), "returned buffer should be reset")
+
+	large := Get()
+	large.Write(bytes.Repeat([]byte("a"), maxSize+1))
+	Put(large)
+
+	after := ReadStats()
+
+	assert.Equal(t, before.InUse(), after.InUse())
+	assert.Equal(t, before.Gets+2, after.Gets)
+	assert.Equal(t, before.Discarded+1, after.Discarded, "buffer larger than the maximum size should be discarded")
+}
@@ -0,0 +1,192 @@
+// Package bulkhead limits the concurrent calls to a dependency, queueing or rejecting the calls beyond the limit.
+package bulkhead
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+// RejectedMetric counts the calls rejected by the bulkheads, by dependency and reason.
+const RejectedMetric = "app_bulkhead_rejected"
+
+var (
+	// ErrFull is the error of the calls rejected as the bulkhead has as many calls queued as it allows.
+	ErrFull = errors.New("too many calls are queued")
+	// ErrQueueTimeout is the error of the calls rejected as they waited for a slot for longer than the queue timeout.
+	ErrQueueTimeout = errors.New("timed out waiting for a slot")
+)
+
+// Metrics records the calls rejected by a bulkhead.
+type Metrics interface {
+	IncrementCounter(ctx context.Context, name string, labels ...string)
+}
+
+// Config is the configuration of a bulkhead.
+type Config struct {
+	// MaxConcurrent is the number of calls which are made concurrently. The calls are not limited if it is not positive.
+	MaxConcurrent int
+	// MaxQueue is the number of calls waiting for a slot before the calls are rejected.
+	MaxQueue int
+	// QueueTimeout is the time a call waits for a slot. The calls wait until their context is done if it is not positive.
+	QueueTimeout time.Duration
+}
+
+// ConfigFrom reads the configuration from the configs with the prefix, like DB_MAX_CONCURRENT_CALLS.
+func ConfigFrom(c config.Config, prefix string) Config {
+	maxConcurrent, _ := strconv.Atoi(c.Get(prefix + "_MAX_CONCURRENT_CALLS"))
+	maxQueue, _ := strconv.Atoi(c.Get(prefix + "_MAX_QUEUED_CALLS"))
+
+	timeout := c.Get(prefix + "_QUEUE_TIMEOUT")
+
+	queueTimeout, err := time.ParseDuration(timeout)
+	if err != nil {
+		seconds, _ := strconv.ParseFloat(timeout, 64)
+		queueTimeout = time.Duration(seconds * float64(time.Second))
+	}
+
+	return Config{MaxConcurrent: maxConcurrent, MaxQueue: maxQueue, QueueTimeout: queueTimeout}
+}
+
+// RejectedError is the error of a call rejected by a bulkhead, responded with 503 Service Unavailable.
+type RejectedError struct {
+	// Name is the name of the dependency of the bulkhead.
+	Name string
+	Err  error
+}
+
+func (e *RejectedError) Error() string {
+	return "the call to " + e.Name + " is rejected: " + e.Err.Error()
+}
+
+func (e *RejectedError) Unwrap() error {
+	return e.Err
+}
+
+func (*RejectedError) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+// Bulkhead limits the concurrent calls to a dependency. A nil *Bulkhead does not limit them.
+type Bulkhead struct {
+	name    string
+	config  Config
+	metrics Metrics
+
+	slots chan struct{}
+
+	mu     sync.Mutex
+	queued int
+}
+
+// New returns the bulkhead of the dependency, or nil if the configuration does not limit its calls.
+func New(name string, cfg Config, metrics Metrics) *Bulkhead {
+	if cfg.MaxConcurrent <= 0 {
+		return nil
+	}
+
+	if cfg.MaxQueue < 0 {
+		cfg.MaxQueue = 0
+	}
+
+	return &Bulkhead{
+		name:    name,
+		config:  cfg,
+		metrics: metrics,
+		slots:   make(chan struct{}, cfg.MaxConcurrent),
+	}
+}
+
+// Acquire waits for a slot for a call, and returns the function releasing it.
+//
+//	Usage:
+//	release, err := b.Acquire(ctx)
+//	if err != nil {
+//		return err
+//	}
+//
+//	defer release()
+func (b *Bulkhead) Acquire(ctx context.Context) (release func(), err error) {
+	if b == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+		return b.release, nil
+	default:
+	}
+
+	b.mu.Lock()
+
+	if b.queued >= b.config.MaxQueue {
+		b.mu.Unlock()
+
+		return nil, b.reject(ctx, "full", ErrFull)
+	}
+
+	b.queued++
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		b.queued--
+		b.mu.Unlock()
+	}()
+
+	var timeout <-chan time.Time
+
+	if b.config.QueueTimeout > 0 {
+		timer := time.NewTimer(b.config.QueueTimeout)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+		return b.release, nil
+	case <-timeout:
+		return nil, b.reject(ctx, "timeout", ErrQueueTimeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// InFlight returns the number of calls being made.
+func (b *Bulkhead) InFlight() int {
+	if b == nil {
+		return 0
+	}
+
+	return len(b.slots)
+}
+
+// Queued returns the number of calls waiting for a slot.
+func (b *Bulkhead) Queued() int {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.queued
+}
+
+func (b *Bulkhead) release() {
+	<-b.slots
+}
+
+func (b *Bulkhead) reject(ctx context.Context, reason string, err error) error {
+	if b.metrics != nil {
+		b.metrics.IncrementCounter(ctx, RejectedMetric, "dependency", b.name, "reason", reason)
+	}
+
+	return &RejectedError{Name: b.name, Err: err}
+}
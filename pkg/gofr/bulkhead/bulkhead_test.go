@@ -0,0 +1,137 @@
+package bulkhead
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+type counter struct {
+	mu     sync.Mutex
+	labels [][]string
+}
+
+func (c *counter) IncrementCounter(_ context.Context, name string, labels ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.labels = append(c.labels, append([]string{name}, labels...))
+}
+
+func TestConfigFrom(t *testing.T) {
+	testCases := []struct {
+		configs map[string]string
+		config  Config
+	}{
+		{map[string]string{}, Config{}},
+		{map[string]string{"DB_MAX_CONCURRENT_CALLS": "10", "DB_MAX_QUEUED_CALLS": "20", "DB_QUEUE_TIMEOUT": "250ms"},
+			Config{MaxConcurrent: 10, MaxQueue: 20, QueueTimeout: 250 * time.Millisecond}},
+		{map[string]string{"DB_MAX_CONCURRENT_CALLS": "5", "DB_QUEUE_TIMEOUT": "1.5"},
+			Config{MaxConcurrent: 5, QueueTimeout: 1500 * time.Millisecond}},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.config, ConfigFrom(config.NewMockConfig(tc.configs), "DB"), "TEST[%d], Failed.\n", i)
+	}
+}
+
+func TestNew_NoLimit(t *testing.T) {
+	b := New("sql", Config{MaxQueue: 10}, nil)
+	assert.Nil(t, b)
+
+	release, err := b.Acquire(context.Background())
+	require.NoError(t, err)
+
+	release()
+
+	assert.Zero(t, b.InFlight())
+	assert.Zero(t, b.Queued())
+}
+
+func TestBulkhead_Full(t *testing.T) {
+	metrics := &counter{}
+	b := New("orders", Config{MaxConcurrent: 1}, metrics)
+
+	release, err := b.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, b.InFlight())
+
+	_, err = b.Acquire(context.Background())
+
+	var rejected *RejectedError
+
+	require.ErrorAs(t, err, &rejected)
+	assert.ErrorIs(t, err, ErrFull)
+	assert.Equal(t, "orders", rejected.Name)
+	assert.Equal(t, http.StatusServiceUnavailable, rejected.StatusCode())
+	assert.Equal(t, "the call to orders is rejected: too many calls are queued", err.Error())
+	assert.Equal(t, [][]string{{RejectedMetric, "dependency", "orders", "reason", "full"}}, metrics.labels)
+
+	release()
+
+	release, err = b.Acquire(context.Background())
+	require.NoError(t, err)
+
+	release()
+}
+
+func TestBulkhead_Queue(t *testing.T) {
+	metrics := &counter{}
+	b := New("redis", Config{MaxConcurrent: 1, MaxQueue: 1, QueueTimeout: time.Second}, metrics)
+
+	release, err := b.Acquire(context.Background())
+	require.NoError(t, err)
+
+	acquired := make(chan error)
+
+	go func() {
+		queuedRelease, err := b.Acquire(context.Background())
+		if err == nil {
+			queuedRelease()
+		}
+
+		acquired <- err
+	}()
+
+	assert.Eventually(t, func() bool { return b.Queued() == 1 }, time.Second, time.Millisecond)
+
+	// the queue is full.
+	_, err = b.Acquire(context.Background())
+	assert.ErrorIs(t, err, ErrFull)
+
+	release()
+
+	require.NoError(t, <-acquired)
+	assert.Zero(t, b.Queued())
+	assert.Zero(t, b.InFlight())
+}
+
+func TestBulkhead_QueueTimeout(t *testing.T) {
+	metrics := &counter{}
+	b := New("sql", Config{MaxConcurrent: 1, MaxQueue: 1, QueueTimeout: 10 * time.Millisecond}, metrics)
+
+	release, err := b.Acquire(context.Background())
+	require.NoError(t, err)
+
+	defer release()
+
+	_, err = b.Acquire(context.Background())
+	assert.ErrorIs(t, err, ErrQueueTimeout)
+	assert.Equal(t, [][]string{{RejectedMetric, "dependency", "sql", "reason", "timeout"}}, metrics.labels)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	b.config.QueueTimeout = 0
+
+	_, err = b.Acquire(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, b.Queued())
+}
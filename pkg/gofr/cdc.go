@@ -0,0 +1,100 @@
+package gofr
+
+import (
+	"fmt"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/cdc"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+// CDCRouter routes the change events of Debezium to the handlers of the tables.
+type CDCRouter struct {
+	tables       map[string]func(ctx *Context, e *cdc.Envelope) error
+	schemaChange func(ctx *Context, change cdc.SchemaChange) error
+	tombstone    func(ctx *Context, key []byte) error
+}
+
+// SubscribeCDC subscribes to the topics of the change events of Debezium, like "dbserver1.inventory.customers".
+func (a *App) SubscribeCDC(topics ...string) *CDCRouter {
+	r := &CDCRouter{tables: make(map[string]func(ctx *Context, e *cdc.Envelope) error)}
+
+	for _, topic := range topics {
+		a.Subscribe(topic, r.route)
+	}
+
+	return r
+}
+
+// HandleTable adds the handler of the changes of the table, like "inventory.customers", decoded into T.
+func HandleTable[T any](r *CDCRouter, table string, handler func(ctx *Context, change cdc.Change[T]) error) {
+	r.tables[table] = func(ctx *Context, e *cdc.Envelope) error {
+		change, err := cdc.ChangeOf[T](e)
+		if err != nil {
+			return fmt.Errorf("could not decode the change of table %s: %w", e.Table(), err)
+		}
+
+		return handler(ctx, change)
+	}
+}
+
+// OnSchemaChange sets the handler of the schema changes.
+func (r *CDCRouter) OnSchemaChange(handler func(ctx *Context, change cdc.SchemaChange) error) *CDCRouter {
+	r.schemaChange = handler
+
+	return r
+}
+
+// OnTombstone sets the handler of the tombstones, which follow the deletes.
+func (r *CDCRouter) OnTombstone(handler func(ctx *Context, key []byte) error) *CDCRouter {
+	r.tombstone = handler
+
+	return r
+}
+
+// route passes the event of the message to its handler.
+func (r *CDCRouter) route(ctx *Context) error {
+	msg, ok := ctx.Request.(*pubsub.Message)
+	if !ok {
+		return nil
+	}
+
+	e, err := cdc.Decode(msg.Value)
+	if err != nil {
+		// the event would fail again if it was received again, so it is skipped.
+		ctx.Errorf("skipping the message of topic %s which is not a change event: %v", msg.Topic, err)
+
+		return nil
+	}
+
+	switch {
+	case e == nil:
+		if r.tombstone == nil {
+			ctx.Debugf("skipping the tombstone of topic %s", msg.Topic)
+
+			return nil
+		}
+
+		return r.tombstone(ctx, msg.Key)
+	case e.IsSchemaChange():
+		if r.schemaChange == nil {
+			ctx.Debugf("skipping the schema change of topic %s", msg.Topic)
+
+			return nil
+		}
+
+		return r.schemaChange(ctx, cdc.SchemaChangeOf(e))
+	}
+
+	handle, ok := r.tables[e.Table()]
+	if !ok {
+		handle, ok = r.tables[e.Source.Table]
+	}
+
+	if !ok {
+		ctx.Debugf("skipping the change of table %s, which has no handler", e.Table())
+
+		return nil
+	}
+
+	return handle(ctx, e)
+}
@@ -0,0 +1,158 @@
+// Package cdc decodes the change events of Debezium into typed changes.
+package cdc
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Operations of the changes.
+const (
+	OpCreate   = "c"
+	OpUpdate   = "u"
+	OpDelete   = "d"
+	OpRead     = "r" // a row read by a snapshot of the table.
+	OpTruncate = "t"
+)
+
+// Source is the origin of a change, whose fields depend on the connector.
+type Source struct {
+	Connector string `json:"connector"`
+	Name      string `json:"name"`
+	DB        string `json:"db"`
+	Schema    string `json:"schema"`
+	Table     string `json:"table"`
+	TsMs      int64  `json:"ts_ms"`
+}
+
+// TableChange is a table changed by a schema change.
+type TableChange struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Envelope is the payload of a Debezium event.
+type Envelope struct {
+	Op     string          `json:"op"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+	Source Source          `json:"source"`
+	TsMs   int64           `json:"ts_ms"`
+
+	DDL          string        `json:"ddl"`
+	DatabaseName string        `json:"databaseName"`
+	SchemaName   string        `json:"schemaName"`
+	TableChanges []TableChange `json:"tableChanges"`
+}
+
+// Decode decodes the value of a Debezium event. It returns nil for a tombstone.
+func Decode(value []byte) (*Envelope, error) {
+	value = bytes.TrimSpace(value)
+	if len(value) == 0 || bytes.Equal(value, []byte("null")) {
+		return nil, nil
+	}
+
+	// the events of the converters with schemas enabled wrap the payload with its schema.
+	var wrapped struct {
+		Schema  json.RawMessage `json:"schema"`
+		Payload json.RawMessage `json:"payload"`
+	}
+
+	if err := json.Unmarshal(value, &wrapped); err != nil {
+		return nil, err
+	}
+
+	if wrapped.Payload != nil && wrapped.Schema != nil {
+		value = wrapped.Payload
+	}
+
+	var e Envelope
+
+	if err := json.Unmarshal(value, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// IsSchemaChange reports whether the event is a change of the schema of a database.
+func (e *Envelope) IsSchemaChange() bool {
+	return e.Op == "" && e.DDL != ""
+}
+
+// Table returns the name of the changed table, prefixed by its namespace, like "inventory.customers".
+func (e *Envelope) Table() string {
+	namespace := e.Source.Schema
+	if namespace == "" {
+		namespace = e.Source.DB
+	}
+
+	if namespace == "" {
+		return e.Source.Table
+	}
+
+	return namespace + "." + e.Source.Table
+}
+
+// Change is the change of a row, decoded into T. Before is nil for the created rows, and After for the deleted ones.
+type Change[T any] struct {
+	Op     string
+	Before *T
+	After  *T
+	Table  string
+	Source Source
+	// Time is the time at which the change was processed by Debezium.
+	Time time.Time
+}
+
+// ChangeOf returns the change of the row of the event, with its states decoded into T.
+func ChangeOf[T any](e *Envelope) (Change[T], error) {
+	c := Change[T]{Op: e.Op, Table: e.Table(), Source: e.Source, Time: time.UnixMilli(e.TsMs)}
+
+	var err error
+
+	if c.Before, err = decodeRow[T](e.Before); err != nil {
+		return c, err
+	}
+
+	c.After, err = decodeRow[T](e.After)
+
+	return c, err
+}
+
+func decodeRow[T any](raw json.RawMessage) (*T, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+
+	var row T
+
+	if err := json.Unmarshal(raw, &row); err != nil {
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+// SchemaChange is the change of the schema of a database, with the DDL statement which changed it.
+type SchemaChange struct {
+	Database     string
+	Schema       string
+	DDL          string
+	TableChanges []TableChange
+	Source       Source
+	Time         time.Time
+}
+
+// SchemaChangeOf returns the schema change of the event.
+func SchemaChangeOf(e *Envelope) SchemaChange {
+	return SchemaChange{
+		Database:     e.DatabaseName,
+		Schema:       e.SchemaName,
+		DDL:          e.DDL,
+		TableChanges: e.TableChanges,
+		Source:       e.Source,
+		Time:         time.UnixMilli(e.Source.TsMs),
+	}
+}
@@ -0,0 +1,101 @@
+package cdc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type customer struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+}
+
+func TestDecode(t *testing.T) {
+	const payload = `{"before":null,"after":{"id":1001,"email":"sally@example.com"},` +
+		`"source":{"connector":"mysql","name":"dbserver1","db":"inventory","table":"customers","ts_ms":1700000000000},` +
+		`"op":"c","ts_ms":1700000000500}`
+
+	testCases := []struct {
+		desc  string
+		value string
+		table string
+		op    string
+	}{
+		{"unwrapped payload", payload, "inventory.customers", OpCreate},
+		{"payload with schema", `{"schema":{"type":"struct"},"payload":` + payload + `}`, "inventory.customers", OpCreate},
+		{"postgres schema", `{"op":"u","source":{"connector":"postgresql","db":"shop","schema":"public",` +
+			`"table":"orders"}}`, "public.orders", OpUpdate},
+		{"no namespace", `{"op":"d","source":{"table":"orders"}}`, "orders", OpDelete},
+	}
+
+	for i, tc := range testCases {
+		e, err := Decode([]byte(tc.value))
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.table, e.Table(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.op, e.Op, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.False(t, e.IsSchemaChange(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestDecode_Tombstone(t *testing.T) {
+	for i, value := range []string{"", "null", " null\n"} {
+		e, err := Decode([]byte(value))
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%q", i, value)
+		assert.Nil(t, e, "TEST[%d], Failed.\n%q", i, value)
+	}
+}
+
+func TestDecode_Error(t *testing.T) {
+	_, err := Decode([]byte("not json"))
+
+	require.Error(t, err)
+}
+
+func TestChangeOf(t *testing.T) {
+	e, err := Decode([]byte(`{"op":"u","before":{"id":1,"email":"a@example.com"},` +
+		`"after":{"id":1,"email":"b@example.com"},"source":{"db":"inventory","table":"customers"},"ts_ms":1000}`))
+	require.NoError(t, err)
+
+	c, err := ChangeOf[customer](e)
+
+	require.NoError(t, err)
+	assert.Equal(t, OpUpdate, c.Op)
+	assert.Equal(t, "inventory.customers", c.Table)
+	assert.Equal(t, &customer{ID: 1, Email: "a@example.com"}, c.Before)
+	assert.Equal(t, &customer{ID: 1, Email: "b@example.com"}, c.After)
+	assert.Equal(t, time.UnixMilli(1000), c.Time)
+
+	e, err = Decode([]byte(`{"op":"d","before":{"id":1},"after":null}`))
+	require.NoError(t, err)
+
+	c, err = ChangeOf[customer](e)
+
+	require.NoError(t, err)
+	assert.Equal(t, &customer{ID: 1}, c.Before)
+	assert.Nil(t, c.After)
+
+	_, err = ChangeOf[customer](&Envelope{Op: OpCreate, After: []byte(`{"id":"one"}`)})
+
+	require.Error(t, err)
+}
+
+func TestSchemaChangeOf(t *testing.T) {
+	e, err := Decode([]byte(`{"source":{"db":"inventory","ts_ms":2000},"databaseName":"inventory",` +
+		`"ddl":"ALTER TABLE customers ADD COLUMN phone VARCHAR(20)",` +
+		`"tableChanges":[{"type":"ALTER","id":"\"inventory\".\"customers\""}]}`))
+	require.NoError(t, err)
+
+	assert.True(t, e.IsSchemaChange())
+
+	c := SchemaChangeOf(e)
+
+	assert.Equal(t, "inventory", c.Database)
+	assert.Equal(t, "ALTER TABLE customers ADD COLUMN phone VARCHAR(20)", c.DDL)
+	assert.Equal(t, []TableChange{{Type: "ALTER", ID: `"inventory"."customers"`}}, c.TableChanges)
+	assert.Equal(t, time.UnixMilli(2000), c.Time)
+}
@@ -0,0 +1,112 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/cdc"
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+var errCDCHandler = errors.New("search index unavailable")
+
+type cdcCustomer struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+}
+
+func TestApp_SubscribeCDC(t *testing.T) {
+	conf := config.NewMockConfig(map[string]string{"PUBSUB_BACKEND": "MEMORY"})
+	c := container.NewContainer(conf)
+
+	a := &App{Config: conf, container: c, subscriptionManager: newSubscriptionManager(c)}
+
+	var routed []string
+
+	r := a.SubscribeCDC("dbserver1.inventory.customers", "dbserver1.inventory.orders", "dbserver1")
+
+	HandleTable(r, "inventory.customers", func(_ *Context, change cdc.Change[cdcCustomer]) error {
+		if change.After == nil {
+			routed = append(routed, "customers:"+change.Op)
+
+			return nil
+		}
+
+		routed = append(routed, "customers:"+change.Op+":"+change.After.Email)
+
+		return nil
+	})
+
+	HandleTable(r, "orders", func(_ *Context, change cdc.Change[map[string]interface{}]) error {
+		routed = append(routed, "orders:"+change.Op)
+
+		return errCDCHandler
+	})
+
+	r.OnSchemaChange(func(_ *Context, change cdc.SchemaChange) error {
+		routed = append(routed, "schema:"+change.DDL)
+
+		return nil
+	}).OnTombstone(func(_ *Context, key []byte) error {
+		routed = append(routed, "tombstone:"+string(key))
+
+		return nil
+	})
+
+	assert.Len(t, a.subscriptionManager.subscriptions, 3)
+
+	testCases := []struct {
+		desc   string
+		key    string
+		value  string
+		routed []string
+		err    error
+	}{
+		{"create", "", `{"payload":{"op":"c","after":{"id":1,"email":"a@example.com"},` +
+			`"source":{"db":"inventory","table":"customers"}},"schema":{}}`, []string{"customers:c:a@example.com"}, nil},
+		{"delete", "", `{"op":"d","before":{"id":1},"source":{"db":"inventory","table":"customers"}}`,
+			[]string{"customers:d"}, nil},
+		{"table without namespace", "", `{"op":"u","source":{"db":"inventory","table":"orders"}}`,
+			[]string{"orders:u"}, errCDCHandler},
+		{"tombstone", `{"id":1}`, "", []string{`tombstone:{"id":1}`}, nil},
+		{"schema change", "", `{"ddl":"DROP TABLE carts","databaseName":"inventory"}`,
+			[]string{"schema:DROP TABLE carts"}, nil},
+		{"no handler", "", `{"op":"c","source":{"db":"inventory","table":"carts"}}`, nil, nil},
+		{"not a change event", "", `not json`, nil, nil},
+	}
+
+	for i, tc := range testCases {
+		routed = nil
+
+		msg := pubsub.NewMessage(context.Background())
+		msg.Key = []byte(tc.key)
+		msg.Value = []byte(tc.value)
+
+		err := r.route(newContext(nil, msg, c))
+
+		assert.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.routed, routed, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	msg := pubsub.NewMessage(context.Background())
+	msg.Value = []byte(`{"op":"c","after":{"id":"one"},"source":{"db":"inventory","table":"customers"}}`)
+
+	assert.ErrorContains(t, r.route(newContext(nil, msg, c)), "could not decode the change of table inventory.customers")
+}
+
+func TestCDCRouter_SkipsWithoutHandlers(t *testing.T) {
+	c := container.NewContainer(config.NewMockConfig(nil))
+	r := &CDCRouter{tables: make(map[string]func(ctx *Context, e *cdc.Envelope) error)}
+
+	for i, value := range []string{"", `{"ddl":"DROP TABLE carts"}`} {
+		msg := pubsub.NewMessage(context.Background())
+		msg.Value = []byte(value)
+
+		assert.NoError(t, r.route(newContext(nil, msg, c)), "TEST[%d], Failed.\n%q", i, value)
+	}
+}
@@ -0,0 +1,59 @@
+package gofr
+
+import (
+	"net/http"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
+)
+
+// chaosPath is the path of the admin API of the faults injected into the calls to the dependencies.
+const chaosPath = "/.well-known/chaos"
+
+// faultsError is the error of the faults which cannot be injected, as they are invalid.
+type faultsError struct {
+	err error
+}
+
+func (e faultsError) Error() string {
+	return "invalid faults: " + e.err.Error()
+}
+
+func (faultsError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// addChaosRoutes adds the routes listing, replacing and removing the injected faults, if they are enabled.
+func (a *App) addChaosRoutes() {
+	faults := a.container.FaultInjector()
+	if faults == nil {
+		return
+	}
+
+	a.add(http.MethodGet, chaosPath, func(*Context) (interface{}, error) {
+		return faults.Faults(), nil
+	})
+
+	a.add(http.MethodPut, chaosPath, func(c *Context) (interface{}, error) {
+		var list []chaos.Fault
+
+		if err := c.Bind(&list); err != nil {
+			return nil, faultsError{err: err}
+		}
+
+		if err := faults.Set(list...); err != nil {
+			return nil, faultsError{err: err}
+		}
+
+		c.Warnf("the injected faults are replaced by %d faults", len(list))
+
+		return faults.Faults(), nil
+	})
+
+	a.add(http.MethodDelete, chaosPath, func(c *Context) (interface{}, error) {
+		_ = faults.Set()
+
+		c.Warnf("the injected faults are removed")
+
+		return nil, nil
+	})
+}
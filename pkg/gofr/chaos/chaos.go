@@ -0,0 +1,315 @@
+// Package chaos injects faults, like latency, errors or connection resets, into the calls to the dependencies.
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+// InjectedMetric is the counter of the injected faults, by their target and their kind.
+const InjectedMetric = "app_chaos_faults_injected"
+
+// The targets of the faults other than the HTTP services, whose targets are their names.
+const (
+	TargetSQL   = "sql"
+	TargetRedis = "redis"
+	// TargetAll is the target of the faults injected into the calls to all the dependencies.
+	TargetAll = "*"
+)
+
+// Kind is the kind of a fault.
+type Kind string
+
+const (
+	// KindLatency delays the calls by the latency of the fault.
+	KindLatency Kind = "latency"
+	// KindError fails the calls with an *Error.
+	KindError Kind = "error"
+	// KindReset fails the calls with a connection reset, which is an error wrapping syscall.ECONNRESET.
+	KindReset Kind = "reset"
+)
+
+var (
+	errInvalidKind = errors.New("invalid kind of fault")
+	errInvalidRate = errors.New("the rate of the fault must be between 0 and 1")
+	errNoTarget    = errors.New("the target of the fault is missing")
+)
+
+// Metrics records the injected faults.
+type Metrics interface {
+	IncrementCounter(ctx context.Context, name string, labels ...string)
+}
+
+// Duration is a time.Duration encoded in JSON as a string, like "200ms".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(v)
+
+	return nil
+}
+
+// Fault is a fault injected into a fraction of the calls to a target matching its filters.
+type Fault struct {
+	// Target is TargetSQL, TargetRedis, TargetAll or the name of an HTTP service.
+	Target string `json:"target"`
+	// Operation matches the faulted operations, like "GET /orders". All of them are matched if it is empty.
+	Operation string `json:"operation,omitempty"`
+	// Request matches the HTTP requests whose calls are faulted, like "POST /checkout". All the calls match if it is empty.
+	Request string `json:"request,omitempty"`
+	Kind    Kind   `json:"kind"`
+	// Rate is the fraction of the matching calls which are faulted, between 0 and 1.
+	Rate float64 `json:"rate"`
+	// Latency is the delay of the calls of KindLatency.
+	Latency Duration `json:"latency,omitempty"`
+	// Status is the status of the responses of KindError, 503 by default.
+	Status int `json:"status,omitempty"`
+}
+
+// Error is the error of a call failed by a fault of KindError.
+type Error struct {
+	Target    string
+	Operation string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("injected fault: %s %s failed", e.Target, e.Operation)
+}
+
+func (*Error) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+// fault is a fault whose filters are compiled.
+type fault struct {
+	Fault
+
+	operation *regexp.Regexp
+	request   *regexp.Regexp
+}
+
+func compile(f Fault) (*fault, error) {
+	switch f.Kind {
+	case KindLatency, KindError, KindReset:
+	default:
+		return nil, fmt.Errorf("%w %q", errInvalidKind, f.Kind)
+	}
+
+	if f.Target == "" {
+		return nil, errNoTarget
+	}
+
+	if f.Rate < 0 || f.Rate > 1 {
+		return nil, errInvalidRate
+	}
+
+	if f.Kind == KindError && f.Status == 0 {
+		f.Status = http.StatusServiceUnavailable
+	}
+
+	c := &fault{Fault: f}
+
+	var err error
+
+	if f.Operation != "" {
+		if c.operation, err = regexp.Compile(f.Operation); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.Request != "" {
+		if c.request, err = regexp.Compile(f.Request); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (f *fault) matches(ctx context.Context, target, operation string) bool {
+	if f.Target != TargetAll && f.Target != target {
+		return false
+	}
+
+	if f.operation != nil && !f.operation.MatchString(operation) {
+		return false
+	}
+
+	if f.request != nil {
+		request, ok := ctx.Value(requestKey{}).(string)
+		if !ok || !f.request.MatchString(request) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type requestKey struct{}
+
+// WithRequest returns a copy of ctx with the method and the path of the HTTP request.
+func WithRequest(ctx context.Context, method, path string) context.Context {
+	return context.WithValue(ctx, requestKey{}, method+" "+path)
+}
+
+// Injector injects its faults into the calls to the dependencies. A nil *Injector does not inject any.
+type Injector struct {
+	metrics Metrics
+
+	mu     sync.RWMutex
+	faults []*fault
+
+	// sample returns a random number between 0 and 1.
+	sample func() float64
+}
+
+// New returns an injector of the faults, whose injections are counted by the metrics, unless they are nil.
+func New(metrics Metrics, faults ...Fault) (*Injector, error) {
+	i := &Injector{
+		metrics: metrics,
+		//nolint:gosec // the injection of the faults does not need a secure random number.
+		sample: rand.Float64,
+	}
+
+	if err := i.Set(faults...); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// Set replaces the faults of the injector, which are unchanged if any of them is invalid.
+func (i *Injector) Set(faults ...Fault) error {
+	compiled := make([]*fault, len(faults))
+
+	for j, f := range faults {
+		c, err := compile(f)
+		if err != nil {
+			return fmt.Errorf("fault %d: %w", j, err)
+		}
+
+		compiled[j] = c
+	}
+
+	i.mu.Lock()
+	i.faults = compiled
+	i.mu.Unlock()
+
+	return nil
+}
+
+// Faults returns the faults of the injector.
+func (i *Injector) Faults() []Fault {
+	if i == nil {
+		return nil
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	faults := make([]Fault, len(i.faults))
+
+	for j, f := range i.faults {
+		faults[j] = f.Fault
+	}
+
+	return faults
+}
+
+// Inject injects the faults matching the call of the operation to the target.
+func (i *Injector) Inject(ctx context.Context, target, operation string) error {
+	_, err := i.inject(ctx, target, operation)
+
+	return err
+}
+
+// InjectResponse injects the faults into a request to an HTTP service, returning the status of KindError.
+func (i *Injector) InjectResponse(ctx context.Context, target, operation string) (status int, err error) {
+	return i.inject(ctx, target, operation)
+}
+
+func (i *Injector) inject(ctx context.Context, target, operation string) (int, error) {
+	if i == nil {
+		return 0, nil
+	}
+
+	i.mu.RLock()
+	faults := i.faults
+	i.mu.RUnlock()
+
+	for _, f := range faults {
+		if !f.matches(ctx, target, operation) || i.sample() >= f.Rate {
+			continue
+		}
+
+		if i.metrics != nil {
+			i.metrics.IncrementCounter(ctx, InjectedMetric, "target", target, "kind", string(f.Kind))
+		}
+
+		switch f.Kind {
+		case KindLatency:
+			if err := sleep(ctx, time.Duration(f.Latency)); err != nil {
+				return 0, err
+			}
+		case KindError:
+			return f.Status, &Error{Target: target, Operation: operation}
+		case KindReset:
+			return 0, fmt.Errorf("injected fault: %s %s: %w", target, operation, syscall.ECONNRESET)
+		}
+	}
+
+	return 0, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// FromConfig returns the injector of the faults of CHAOS_FAULTS, or nil unless CHAOS_ENABLED is true.
+func FromConfig(c config.Config, metrics Metrics) (*Injector, error) {
+	if c.Get("CHAOS_ENABLED") != "true" {
+		return nil, nil
+	}
+
+	var faults []Fault
+
+	if v := c.Get("CHAOS_FAULTS"); v != "" {
+		if err := json.Unmarshal([]byte(v), &faults); err != nil {
+			return nil, fmt.Errorf("invalid CHAOS_FAULTS: %w", err)
+		}
+	}
+
+	return New(metrics, faults...)
+}
@@ -0,0 +1,169 @@
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+type mockMetrics struct {
+	counts map[string]int
+}
+
+func (m *mockMetrics) IncrementCounter(_ context.Context, name string, labels ...string) {
+	if m.counts == nil {
+		m.counts = make(map[string]int)
+	}
+
+	m.counts[name+" "+labels[1]+" "+labels[3]]++
+}
+
+func TestInjector_Set(t *testing.T) {
+	testCases := []struct {
+		fault Fault
+		valid bool
+	}{
+		{Fault{Target: TargetSQL, Kind: KindError, Rate: 1}, true},
+		{Fault{Target: TargetAll, Kind: KindLatency, Rate: 0.5, Latency: Duration(time.Second)}, true},
+		{Fault{Target: "payments", Kind: KindReset, Rate: 0, Operation: "^POST /charges"}, true},
+		{Fault{Target: TargetSQL, Kind: "crash", Rate: 1}, false},
+		{Fault{Kind: KindError, Rate: 1}, false},
+		{Fault{Target: TargetRedis, Kind: KindError, Rate: 1.5}, false},
+		{Fault{Target: TargetRedis, Kind: KindError, Rate: 1, Operation: "("}, false},
+		{Fault{Target: TargetRedis, Kind: KindError, Rate: 1, Request: "["}, false},
+	}
+
+	for i, tc := range testCases {
+		injector, err := New(nil)
+		require.NoError(t, err)
+
+		err = injector.Set(tc.fault)
+
+		assert.Equal(t, tc.valid, err == nil, "TEST[%d], Failed.\n%v", i, err)
+	}
+}
+
+func TestInjector_SetKeepsFaultsIfInvalid(t *testing.T) {
+	injector, err := New(nil, Fault{Target: TargetSQL, Kind: KindError, Rate: 1})
+	require.NoError(t, err)
+
+	require.Error(t, injector.Set(Fault{Target: TargetSQL, Kind: KindError, Rate: 2}))
+
+	assert.Equal(t, []Fault{{Target: TargetSQL, Kind: KindError, Rate: 1, Status: 503}}, injector.Faults())
+}
+
+func TestInjector_Inject(t *testing.T) {
+	m := &mockMetrics{}
+
+	injector, err := New(m,
+		Fault{Target: TargetSQL, Operation: "(?i)^select", Kind: KindError, Rate: 1},
+		Fault{Target: TargetRedis, Request: "^POST /checkout$", Kind: KindReset, Rate: 1},
+		Fault{Target: "payments", Kind: KindError, Rate: 0.5, Status: 500},
+	)
+	require.NoError(t, err)
+
+	injector.sample = func() float64 { return 0.6 }
+
+	checkout := WithRequest(context.Background(), "POST", "/checkout")
+
+	testCases := []struct {
+		ctx       context.Context
+		target    string
+		operation string
+		err       error
+	}{
+		{context.Background(), TargetSQL, "SELECT * FROM orders", &Error{Target: TargetSQL,
+			Operation: "SELECT * FROM orders"}},
+		{context.Background(), TargetSQL, "UPDATE orders SET paid = true", nil},
+		{context.Background(), TargetRedis, "get", nil},
+		{checkout, TargetRedis, "get", syscall.ECONNRESET},
+		{WithRequest(context.Background(), "GET", "/checkout"), TargetRedis, "get", nil},
+		// the fault of the payments service is sampled out.
+		{context.Background(), "payments", "GET /charges", nil},
+	}
+
+	for i, tc := range testCases {
+		err := injector.Inject(tc.ctx, tc.target, tc.operation)
+
+		if tc.err == nil {
+			assert.NoError(t, err, "TEST[%d], Failed.\n", i)
+			continue
+		}
+
+		if _, ok := tc.err.(*Error); ok {
+			assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n", i)
+			continue
+		}
+
+		assert.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n", i)
+	}
+
+	injector.sample = func() float64 { return 0.4 }
+
+	status, err := injector.InjectResponse(context.Background(), "payments", "GET /charges")
+
+	assert.Equal(t, 500, status)
+	assert.Equal(t, &Error{Target: "payments", Operation: "GET /charges"}, err)
+
+	assert.Equal(t, map[string]int{InjectedMetric + " sql error": 1, InjectedMetric + " redis reset": 1,
+		InjectedMetric + " payments error": 1}, m.counts)
+}
+
+func TestInjector_InjectLatency(t *testing.T) {
+	injector, err := New(nil, Fault{Target: TargetAll, Kind: KindLatency, Rate: 1, Latency: Duration(50 * time.Millisecond)})
+	require.NoError(t, err)
+
+	start := time.Now()
+
+	require.NoError(t, injector.Inject(context.Background(), TargetRedis, "get"))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, injector.Inject(ctx, TargetSQL, "SELECT 1"), context.DeadlineExceeded)
+}
+
+func TestInjector_Nil(t *testing.T) {
+	var injector *Injector
+
+	require.NoError(t, injector.Inject(context.Background(), TargetSQL, "SELECT 1"))
+	assert.Nil(t, injector.Faults())
+}
+
+func TestFromConfig(t *testing.T) {
+	injector, err := FromConfig(config.NewMockConfig(map[string]string{
+		"CHAOS_FAULTS": `[{"target":"sql","kind":"error","rate":1}]`}), nil)
+
+	require.NoError(t, err)
+	assert.Nil(t, injector, "the faults are only injected if CHAOS_ENABLED is true")
+
+	injector, err = FromConfig(config.NewMockConfig(map[string]string{"CHAOS_ENABLED": "true",
+		"CHAOS_FAULTS": `[{"target":"redis","kind":"latency","rate":0.1,"latency":"250ms"}]`}), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Fault{{Target: TargetRedis, Kind: KindLatency, Rate: 0.1, Latency: Duration(250 * time.Millisecond)}},
+		injector.Faults())
+
+	_, err = FromConfig(config.NewMockConfig(map[string]string{"CHAOS_ENABLED": "true", "CHAOS_FAULTS": `{`}), nil)
+	require.Error(t, err)
+}
+
+func TestDuration_JSON(t *testing.T) {
+	b, err := json.Marshal(Fault{Target: TargetSQL, Kind: KindLatency, Rate: 1, Latency: Duration(1500 * time.Millisecond)})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"target":"sql","kind":"latency","rate":1,"latency":"1.5s"}`, string(b))
+
+	var d Duration
+
+	require.Error(t, json.Unmarshal([]byte(`"soon"`), &d))
+	require.Error(t, json.Unmarshal([]byte(`15`), &d))
+}
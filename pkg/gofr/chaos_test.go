@@ -0,0 +1,87 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_ChaosRoutes(t *testing.T) {
+	t.Setenv("CHAOS_ENABLED", "true")
+	t.Setenv("CHAOS_FAULTS", `[{"target":"sql","kind":"error","rate":0.5}]`)
+
+	app := New()
+	app.addChaosRoutes()
+
+	testCases := []struct {
+		method     string
+		body       string
+		statusCode int
+		response   string
+	}{
+		{http.MethodGet, "", http.StatusOK, `{"data":[{"target":"sql","kind":"error","rate":0.5,"status":503}]}`},
+		{http.MethodPut, `[{"target":"payments","kind":"latency","rate":1,"latency":"2s","request":"^POST /checkout"}]`,
+			http.StatusOK,
+			`{"data":[{"target":"payments","request":"^POST /checkout","kind":"latency","rate":1,"latency":"2s"}]}`},
+		{http.MethodPut, `[{"target":"redis","kind":"crash","rate":1}]`, http.StatusBadRequest,
+			`{"error":{"message":"invalid faults: fault 0: invalid kind of fault \"crash\""}}`},
+		{http.MethodGet, "", http.StatusOK,
+			`{"data":[{"target":"payments","request":"^POST /checkout","kind":"latency","rate":1,"latency":"2s"}]}`},
+		{http.MethodDelete, "", http.StatusNoContent, ""},
+		{http.MethodGet, "", http.StatusOK, `{"data":[]}`},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(tc.method, chaosPath, strings.NewReader(tc.body))
+		r.Header.Set("Content-Type", "application/json")
+
+		app.httpServer.router.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.method)
+		if tc.response != "" {
+			assert.JSONEq(t, tc.response, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.method)
+		}
+	}
+}
+
+func TestApp_AddHTTPService_FaultInjector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Setenv("CHAOS_ENABLED", "true")
+	t.Setenv("CHAOS_FAULTS", `[{"target":"orders","kind":"error","rate":1,"status":502}]`)
+
+	app := New()
+	app.AddHTTPService("orders", server.URL)
+	app.AddHTTPService("payments", server.URL)
+
+	resp, err := app.container.GetHTTPService("orders").Get(context.Background(), "orders", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	resp.Body.Close()
+
+	resp, err = app.container.GetHTTPService("payments").Get(context.Background(), "payments", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestApp_ChaosDisabled(t *testing.T) {
+	app := New()
+	app.addChaosRoutes()
+
+	assert.Nil(t, app.container.FaultInjector())
+
+	w := httptest.NewRecorder()
+	app.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, chaosPath, http.NoBody))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
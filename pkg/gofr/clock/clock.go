@@ -0,0 +1,56 @@
+// Package clock provides the time to the framework, so that it can be faked in tests.
+package clock
+
+import "time"
+
+// Clock tells the time, and waits for it to pass.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	// After returns a channel which receives the time once d has passed.
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+	// NewTicker returns a ticker which sends the time on its channel every d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker sends the time on its channel at intervals, like time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// New returns the Clock of the system.
+func New() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
@@ -0,0 +1,156 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only passes when it is advanced.
+type Fake struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	// period is set for the timers of tickers, which fire again every period.
+	period time.Duration
+	c      chan time.Time
+}
+
+// NewFake returns a Fake clock set to now.
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.changed = sync.NewCond(&f.mu)
+
+	return f
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.addTimer(d, 0).c
+}
+
+func (f *Fake) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for clock.NewTicker")
+	}
+
+	return &fakeTicker{clock: f, timer: f.addTimer(d, d)}
+}
+
+// Advance moves the time forward by d, firing the timers and the tickers which are due, in order.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	end := f.now.Add(d)
+
+	for len(f.timers) > 0 && !f.timers[0].at.After(end) {
+		t := f.timers[0]
+		f.now = t.at
+
+		t.send()
+
+		if t.period > 0 {
+			t.at = t.at.Add(t.period)
+			f.sortTimers()
+		} else {
+			f.timers = f.timers[1:]
+		}
+	}
+
+	f.now = end
+	f.changed.Broadcast()
+}
+
+// BlockUntil waits until at least n timers and tickers are waiting on the clock.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for len(f.timers) < n {
+		f.changed.Wait()
+	}
+}
+
+func (f *Fake) addTimer(d, period time.Duration) *fakeTimer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t := &fakeTimer{at: f.now.Add(d), period: period, c: make(chan time.Time, 1)}
+
+	if d <= 0 && period == 0 {
+		t.c <- f.now
+		return t
+	}
+
+	f.timers = append(f.timers, t)
+	f.sortTimers()
+	f.changed.Broadcast()
+
+	return t
+}
+
+func (f *Fake) removeTimer(t *fakeTimer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.timers {
+		if f.timers[i] == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			break
+		}
+	}
+
+	f.changed.Broadcast()
+}
+
+func (f *Fake) sortTimers() {
+	sort.SliceStable(f.timers, func(i, j int) bool { return f.timers[i].at.Before(f.timers[j].at) })
+}
+
+func (t *fakeTimer) send() {
+	for {
+		select {
+		case t.c <- t.at:
+			return
+		default:
+			select {
+			case <-t.c: // the tick which has not been received yet is replaced.
+			default:
+			}
+		}
+	}
+}
+
+type fakeTicker struct {
+	clock *Fake
+	timer *fakeTimer
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.timer.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.removeTimer(t.timer)
+}
@@ -0,0 +1,94 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFake_Now(t *testing.T) {
+	f := NewFake(start)
+
+	f.Advance(time.Minute)
+
+	assert.Equal(t, start.Add(time.Minute), f.Now())
+	assert.Equal(t, time.Minute, f.Since(start))
+}
+
+func TestFake_After(t *testing.T) {
+	f := NewFake(start)
+
+	c := f.After(time.Second)
+
+	f.Advance(999 * time.Millisecond)
+
+	select {
+	case <-c:
+		t.Fatal("timer should not fire before its time")
+	default:
+	}
+
+	f.Advance(time.Millisecond)
+
+	assert.Equal(t, start.Add(time.Second), <-c)
+	assert.Equal(t, start.Add(time.Second), <-f.After(0), "timer without duration should fire right away")
+}
+
+func TestFake_Sleep(t *testing.T) {
+	f := NewFake(start)
+	done := make(chan time.Time)
+
+	go func() {
+		f.Sleep(time.Hour)
+		done <- f.Now()
+	}()
+
+	f.BlockUntil(1)
+	f.Advance(2 * time.Hour)
+
+	assert.Equal(t, start.Add(2*time.Hour), <-done)
+}
+
+func TestFake_NewTicker(t *testing.T) {
+	f := NewFake(start)
+	ticker := f.NewTicker(10 * time.Second)
+	timer := f.After(15 * time.Second)
+
+	f.Advance(10 * time.Second)
+	assert.Equal(t, start.Add(10*time.Second), <-ticker.C())
+
+	f.Advance(10 * time.Second)
+	assert.Equal(t, start.Add(15*time.Second), <-timer, "timers should fire in the order of their time")
+	assert.Equal(t, start.Add(20*time.Second), <-ticker.C())
+
+	f.Advance(time.Minute)
+	assert.Equal(t, start.Add(80*time.Second), <-ticker.C(), "slow receivers should receive the latest tick")
+
+	ticker.Stop()
+	f.Advance(time.Minute)
+
+	select {
+	case <-ticker.C():
+		t.Fatal("stopped ticker should not tick")
+	default:
+	}
+
+	assert.Panics(t, func() { f.NewTicker(0) })
+}
+
+func TestNew(t *testing.T) {
+	c := New()
+
+	assert.WithinDuration(t, time.Now(), c.Now(), time.Second)
+	assert.Less(t, c.Since(time.Now().Add(-time.Minute)), 2*time.Minute)
+
+	ticker := c.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+
+	<-ticker.C()
+	<-c.After(time.Millisecond)
+	c.Sleep(time.Millisecond)
+}
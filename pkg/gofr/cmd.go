@@ -1,21 +1,63 @@
 package gofr
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
 
 	cmd2 "github.com/peter-stratton/gofr/pkg/gofr/cmd"
+	"github.com/peter-stratton/gofr/pkg/gofr/cmd/terminal"
 )
 
+const (
+	// exitCodeError is the exit code of a command whose handler returned an error.
+	exitCodeError = 1
+	// exitCodeUsage is the exit code of a command which is not registered or is called with flags it does not declare.
+	exitCodeUsage = 2
+)
+
+// exit exits the application with the exit code of the command. It is a variable so that tests can replace it.
+var exit = os.Exit //nolint:gochecknoglobals // replaced in tests, as os.Exit would end them.
+
 type cmd struct {
 	routes []route
 }
 
 type route struct {
-	pattern string
-	handler Handler
+	pattern     string
+	handler     Handler
+	description string
+	flags       []flag
+}
+
+type flag struct {
+	name         string
+	defaultValue string
+	usage        string
+}
+
+// CommandOption configures a sub-command added using SubCommand.
+type CommandOption func(r *route)
+
+// AddDescription sets the description of the sub-command, which is shown in the help of the application.
+func AddDescription(description string) CommandOption {
+	return func(r *route) {
+		r.description = description
+	}
+}
+
+// AddFlag declares a flag of the sub-command, read with ctx.Param. The undeclared flags are then rejected.
+func AddFlag(name, defaultValue, usage string) CommandOption {
+	return func(r *route) {
+		r.flags = append(r.flags, flag{name: name, defaultValue: defaultValue, usage: usage})
+	}
 }
 
 type ErrCommandNotFound struct{}
@@ -24,7 +66,32 @@ func (e ErrCommandNotFound) Error() string {
 	return "No Command Found!" //nolint:goconst // This error is needed and repetition is in test to check for the exact string.
 }
 
-func (cmd *cmd) Run(c *container.Container) {
+// ExitCode returns the exit code of the application when the command is not found.
+func (e ErrCommandNotFound) ExitCode() int {
+	return exitCodeUsage
+}
+
+// ErrUnknownFlag is returned when a sub-command is passed a flag it does not declare.
+type ErrUnknownFlag struct {
+	Flag string
+}
+
+func (e ErrUnknownFlag) Error() string {
+	return fmt.Sprintf("unknown flag: -%s", e.Flag)
+}
+
+// ExitCode returns the exit code of the application when a flag is unknown.
+func (e ErrUnknownFlag) ExitCode() int {
+	return exitCodeUsage
+}
+
+// exitCoder is implemented by the errors which set the exit code of the application.
+type exitCoder interface {
+	ExitCode() int
+}
+
+// Run runs the handler of the command and returns the exit code, or writes the help.
+func (cmd *cmd) Run(c *container.Container) int {
 	args := os.Args[1:] // First one is command itself
 	command := ""
 
@@ -40,31 +107,140 @@ func (cmd *cmd) Run(c *container.Container) {
 		}
 	}
 
-	h := cmd.handler(command)
-	ctx := newContext(&cmd2.Responder{}, cmd2.NewRequest(args), c)
+	req := cmd2.NewRequest(args)
+	r := cmd.route(command)
+
+	if strings.TrimSpace(command) == "help" {
+		cmd.help(os.Stdout, nil)
+
+		return 0
+	}
+
+	if req.Param("h") == "true" || req.Param("help") == "true" {
+		cmd.help(os.Stdout, r)
+
+		return 0
+	}
+
+	ctx := newContext(&cmd2.Responder{}, req, c)
+	ctx.Out = terminal.New(os.Stdout)
 
-	if h == nil {
+	if r == nil || r.handler == nil {
 		ctx.responder.Respond(nil, ErrCommandNotFound{})
+		return exitCodeUsage
+	}
+
+	if err := r.bindFlags(req); err != nil {
+		ctx.responder.Respond(nil, err)
+		return exitCodeUsage
+	}
+
+	result, err := r.handler(ctx)
+	ctx.responder.Respond(result, err)
+
+	return exitCode(err)
+}
+
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	if e, ok := err.(exitCoder); ok {
+		return e.ExitCode()
+	}
+
+	return exitCodeError
+}
+
+// bindFlags sets the default values of the flags which are not passed, and rejects the flags which are not declared.
+func (r *route) bindFlags(req *cmd2.Request) error {
+	if len(r.flags) == 0 {
+		return nil
+	}
+
+	declared := make(map[string]bool, len(r.flags))
+
+	for _, f := range r.flags {
+		declared[f.name] = true
+	}
+
+	names := req.ParamNames()
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !declared[name] {
+			return ErrUnknownFlag{Flag: name}
+		}
+	}
+
+	for _, f := range r.flags {
+		req.SetDefault(f.name, f.defaultValue)
+	}
+
+	return nil
+}
+
+// help writes the usage of the command, or the list of the commands of the application if r is nil.
+func (cmd *cmd) help(w io.Writer, r *route) {
+	name := filepath.Base(os.Args[0])
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	if r == nil {
+		fmt.Fprintf(tw, "Usage:\n  %s <command> [flags]\n\nAvailable commands:\n", name)
+
+		for _, route := range cmd.routes {
+			fmt.Fprintf(tw, "  %s\t%s\n", route.pattern, route.description)
+		}
+
+		fmt.Fprintf(tw, "\nUse \"%s <command> -h\" for more information about a command.\n", name)
+		tw.Flush()
+
 		return
 	}
 
-	ctx.responder.Respond(h(ctx))
+	if r.description != "" {
+		fmt.Fprintf(tw, "%s\n\n", r.description)
+	}
+
+	fmt.Fprintf(tw, "Usage:\n  %s %s [flags]\n", name, r.pattern)
+
+	if len(r.flags) > 0 {
+		fmt.Fprint(tw, "\nFlags:\n")
+
+		for _, f := range r.flags {
+			usage := f.usage
+			if f.defaultValue != "" {
+				usage += fmt.Sprintf(" (default %q)", f.defaultValue)
+			}
+
+			fmt.Fprintf(tw, "  -%s\t%s\n", f.name, usage)
+		}
+	}
+
+	tw.Flush()
 }
 
-func (cmd *cmd) handler(path string) Handler {
-	for _, route := range cmd.routes {
+func (cmd *cmd) route(path string) *route {
+	for i, route := range cmd.routes {
 		re := regexp.MustCompile(route.pattern)
 		if re.MatchString(path) {
-			return route.handler
+			return &cmd.routes[i]
 		}
 	}
 
 	return nil
 }
 
-func (cmd *cmd) addRoute(pattern string, handler Handler) {
-	cmd.routes = append(cmd.routes, route{
+func (cmd *cmd) addRoute(pattern string, handler Handler, opts ...CommandOption) {
+	r := route{
 		pattern: pattern,
 		handler: handler,
-	})
+	}
+
+	for _, o := range opts {
+		o(&r)
+	}
+
+	cmd.routes = append(cmd.routes, r)
 }
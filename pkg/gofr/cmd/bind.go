@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var errCSVTarget = errors.New("CSV input can only be bound to a pointer to a slice of structs")
+
+// bindJSON decodes the JSON data, or a stream of JSON values into a slice, into i.
+func bindJSON(data []byte, i interface{}) error {
+	v := reflect.ValueOf(i)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice || data[0] == '[' {
+		return json.Unmarshal(data, i)
+	}
+
+	slice := v.Elem()
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	for dec.More() {
+		e := reflect.New(slice.Type().Elem())
+		if err := dec.Decode(e.Interface()); err != nil {
+			return err
+		}
+
+		slice.Set(reflect.Append(slice, e.Elem()))
+	}
+
+	return nil
+}
+
+// bindCSV decodes the CSV data, whose first row is the header, into i which must be a pointer to a slice of structs.
+func bindCSV(data []byte, i interface{}) error {
+	v := reflect.ValueOf(i)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice || v.Elem().Type().Elem().Kind() != reflect.Struct {
+		return errCSVTarget
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return err
+	}
+
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	fields := csvFields(records[0], elemType)
+
+	for n, record := range records[1:] {
+		e := reflect.New(elemType).Elem()
+
+		for col, value := range record {
+			if fields[col] < 0 {
+				continue
+			}
+
+			if err := setValue(e.Field(fields[col]), value); err != nil {
+				return fmt.Errorf("row %d, column %q: %w", n+1, records[0][col], err)
+			}
+		}
+
+		slice.Set(reflect.Append(slice, e))
+	}
+
+	return nil
+}
+
+// csvFields returns the index of the field of t matching each column of the header, or -1.
+func csvFields(header []string, t reflect.Type) []int {
+	fields := make([]int, len(header))
+
+	for col, name := range header {
+		fields[col] = -1
+
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+
+			tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if tag == name || (tag == "" && strings.EqualFold(f.Name, name)) {
+				fields[col] = i
+				break
+			}
+		}
+	}
+
+	return fields
+}
+
+// setValue sets the field to the value parsed for its kind.
+func setValue(f reflect.Value, v string) error {
+	//nolint:exhaustive // the other kinds are not supported
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(v)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(v, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(v, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(v, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		f.SetFloat(n)
+	}
+
+	return nil
+}
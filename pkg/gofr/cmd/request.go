@@ -1,10 +1,11 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"reflect"
-	"strconv"
 	"strings"
 )
 
@@ -14,6 +15,10 @@ import (
 type Request struct {
 	flags  map[string]bool
 	params map[string]string
+
+	// input is the input piped to the command, which is read into inputData by the first call to Bind.
+	input     io.Reader
+	inputData []byte
 }
 
 const trueString = "true"
@@ -26,6 +31,7 @@ func NewRequest(args []string) *Request {
 	r := Request{
 		flags:  make(map[string]bool),
 		params: make(map[string]string),
+		input:  pipedInput(),
 	}
 
 	const (
@@ -66,11 +72,38 @@ func NewRequest(args []string) *Request {
 	return &r
 }
 
+// pipedInput returns stdin if the input of the command is piped or redirected from a file, and nil if it is a terminal.
+func pipedInput() io.Reader {
+	stat, err := os.Stdin.Stat()
+	if err != nil || stat.Mode()&os.ModeCharDevice != 0 {
+		return nil
+	}
+
+	return os.Stdin
+}
+
 // Param returns the value of the parameter for key.
 func (r *Request) Param(key string) string {
 	return r.params[key]
 }
 
+// ParamNames returns the names of the parameters passed as flags to the command.
+func (r *Request) ParamNames() []string {
+	names := make([]string, 0, len(r.params))
+	for k := range r.params {
+		names = append(names, k)
+	}
+
+	return names
+}
+
+// SetDefault sets the value of the parameter for key if it was not passed to the command.
+func (r *Request) SetDefault(key, value string) {
+	if _, ok := r.params[key]; !ok {
+		r.params[key] = value
+	}
+}
+
 // PathParam returns the value of the parameter for key. This is equivalent to Param.
 func (r *Request) PathParam(key string) string {
 	return r.params[key]
@@ -86,7 +119,12 @@ func (r *Request) HostName() (hostname string) {
 	return hostname
 }
 
+// Bind binds the JSON or CSV input piped to the command, and then its params, to i.
 func (r *Request) Bind(i interface{}) error {
+	if err := r.bindInput(i); err != nil {
+		return err
+	}
+
 	// pointer to struct - addressable
 	ps := reflect.ValueOf(i)
 	// struct
@@ -96,18 +134,7 @@ func (r *Request) Bind(i interface{}) error {
 			f := s.FieldByName(k)
 			// A Value can be changed only if it is addressable and not unexported struct field
 			if f.IsValid() && f.CanSet() {
-				//nolint:exhaustive // no need to add other cases
-				switch f.Kind() {
-				case reflect.String:
-					f.SetString(v)
-				case reflect.Bool:
-					if v == trueString {
-						f.SetBool(true)
-					}
-				case reflect.Int:
-					n, _ := strconv.Atoi(v)
-					f.SetInt(int64(n))
-				}
+				_ = setValue(f, v)
 			}
 		}
 	}
@@ -115,6 +142,27 @@ func (r *Request) Bind(i interface{}) error {
 	return nil
 }
 
+func (r *Request) bindInput(i interface{}) error {
+	if r.input != nil {
+		data, err := io.ReadAll(r.input)
+		if err != nil {
+			return err
+		}
+
+		r.inputData, r.input = bytes.TrimSpace(data), nil
+	}
+
+	if len(r.inputData) == 0 {
+		return nil
+	}
+
+	if r.inputData[0] == '{' || r.inputData[0] == '[' {
+		return bindJSON(r.inputData, i)
+	}
+
+	return bindCSV(r.inputData, i)
+}
+
 func (r *Request) GetHeader(string) string {
 	return ""
 }
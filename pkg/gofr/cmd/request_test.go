@@ -3,9 +3,11 @@ package cmd
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRequest_Bind(t *testing.T) {
@@ -69,3 +71,92 @@ func TestHostName(t *testing.T) {
 
 	assert.Equal(t, hostname, result, "TestHostName Failed!")
 }
+
+func TestRequest_SetDefault(t *testing.T) {
+	r := NewRequest([]string{"import", "-file=data.csv"})
+
+	r.SetDefault("file", "default.csv")
+	r.SetDefault("batch", "100")
+
+	assert.Equal(t, "data.csv", r.Param("file"))
+	assert.Equal(t, "100", r.Param("batch"))
+	assert.ElementsMatch(t, []string{"file", "batch"}, r.ParamNames())
+}
+
+type testUser struct {
+	ID       int     `json:"id"`
+	Name     string  `json:"name"`
+	Admin    bool    `json:"admin"`
+	Balance  float64 `json:"balance"`
+	Comments string
+}
+
+func TestRequest_BindInput(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input string
+		exp   []testUser
+	}{
+		{"JSON array", `[{"id":1,"name":"gofr"},{"id":2,"name":"go","admin":true}]`,
+			[]testUser{{ID: 1, Name: "gofr"}, {ID: 2, Name: "go", Admin: true}}},
+		{"JSON lines", "{\"id\":1,\"name\":\"gofr\"}\n{\"id\":2,\"name\":\"go\"}\n",
+			[]testUser{{ID: 1, Name: "gofr"}, {ID: 2, Name: "go"}}},
+		{"CSV", "id,name,admin,balance,comments,unknown\n1,gofr,true,10.5,first,x\n2,go,false,0,,y\n",
+			[]testUser{{ID: 1, Name: "gofr", Admin: true, Balance: 10.5, Comments: "first"}, {ID: 2, Name: "go"}}},
+		{"no input", "", nil},
+	}
+
+	for i, tc := range testCases {
+		r := NewRequest([]string{"import"})
+		r.input = strings.NewReader(tc.input)
+
+		var users []testUser
+
+		err := r.Bind(&users)
+
+		assert.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.exp, users, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestRequest_BindInputWithParams(t *testing.T) {
+	r := NewRequest([]string{"import", "-Name=flag"})
+	r.input = strings.NewReader(`{"id":1,"name":"gofr","admin":true}`)
+
+	var user testUser
+
+	require.NoError(t, r.Bind(&user))
+
+	assert.Equal(t, testUser{ID: 1, Name: "flag", Admin: true}, user, "params should override the input")
+
+	// the input is read once, and can be bound again.
+	var again testUser
+
+	require.NoError(t, r.Bind(&again))
+	assert.Equal(t, 1, again.ID)
+}
+
+func TestRequest_BindInputErrors(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		input  string
+		target interface{}
+		expErr string
+	}{
+		{"CSV into struct", "id,name\n1,gofr\n", &testUser{}, errCSVTarget.Error()},
+		{"invalid CSV value", "id,name\nx,gofr\n", &[]testUser{}, `row 1, column "id": strconv.ParseInt: parsing "x": invalid syntax`},
+		{"invalid CSV", "id,name\n1,\"gofr\n", &[]testUser{}, "extraneous or missing \" in quoted-field"},
+		{"invalid JSON", `{"id":"x"}`, &testUser{}, "cannot unmarshal string"},
+		{"invalid JSON lines", "{\"id\":1}\n{\"id\":\"x\"}", &[]testUser{}, "cannot unmarshal string"},
+	}
+
+	for i, tc := range testCases {
+		r := NewRequest(nil)
+		r.input = strings.NewReader(tc.input)
+
+		err := r.Bind(tc.target)
+
+		require.Error(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Contains(t, err.Error(), tc.expErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
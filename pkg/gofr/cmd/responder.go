@@ -5,10 +5,10 @@ import (
 	"os"
 )
 
+// Responder writes the result of a command to stdout and its error to stderr.
 type Responder struct{}
 
 func (r *Responder) Respond(data interface{}, err error) {
-	// TODO - provide proper exit codes here. Using os.Exit directly is a problem for tests.
 	if data != nil {
 		fmt.Fprint(os.Stdout, data)
 	}
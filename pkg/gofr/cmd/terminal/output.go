@@ -0,0 +1,140 @@
+// Package terminal provides the status lines, progress bars, spinners and tables of CMD applications.
+package terminal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// Color is an ANSI 256 color code.
+type Color int
+
+const (
+	Red    Color = 160
+	Green  Color = 34
+	Yellow Color = 220
+	Cyan   Color = 6
+	Gray   Color = 8
+)
+
+// Output writes the output of a command. It is safe for concurrent use.
+type Output struct {
+	out        io.Writer
+	isTerminal bool
+	colors     bool
+	mu         sync.Mutex
+}
+
+// New returns the Output writing to w, which is colored and animated on a terminal unless NO_COLOR is set.
+func New(w io.Writer) *Output {
+	isTerminal := false
+	if f, ok := w.(*os.File); ok {
+		isTerminal = term.IsTerminal(int(f.Fd()))
+	}
+
+	return &Output{out: w, isTerminal: isTerminal, colors: isTerminal && os.Getenv("NO_COLOR") == ""}
+}
+
+// IsTerminal returns whether the output is written to a terminal.
+func (o *Output) IsTerminal() bool {
+	return o.isTerminal
+}
+
+// Print writes the operands to the output, like fmt.Print.
+func (o *Output) Print(args ...interface{}) {
+	o.write(fmt.Sprint(args...))
+}
+
+// Printf writes the formatted string to the output, like fmt.Printf.
+func (o *Output) Printf(format string, args ...interface{}) {
+	o.write(fmt.Sprintf(format, args...))
+}
+
+// Println writes the operands to the output followed by a newline, like fmt.Println.
+func (o *Output) Println(args ...interface{}) {
+	o.write(fmt.Sprintln(args...))
+}
+
+// JSON writes v as a line of JSON.
+func (o *Output) JSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	o.write(string(data) + "\n")
+
+	return nil
+}
+
+// CSV writes the records as lines of CSV.
+func (o *Output) CSV(records ...[]string) error {
+	var b strings.Builder
+
+	w := csv.NewWriter(&b)
+	if err := w.WriteAll(records); err != nil {
+		return err
+	}
+
+	o.write(b.String())
+
+	return nil
+}
+
+// Colorize returns s in the color if the output is colored, and s as is otherwise.
+func (o *Output) Colorize(s string, c Color) string {
+	if !o.colors {
+		return s
+	}
+
+	return fmt.Sprintf("\u001B[38;5;%dm%s\u001B[0m", c, s)
+}
+
+// Success writes a line reporting that a step succeeded.
+func (o *Output) Success(format string, args ...interface{}) {
+	o.status("✔", "OK", Green, format, args...)
+}
+
+// Info writes a line reporting the progress of the command.
+func (o *Output) Info(format string, args ...interface{}) {
+	o.status("•", "INFO", Cyan, format, args...)
+}
+
+// Warn writes a line reporting a problem which does not stop the command.
+func (o *Output) Warn(format string, args ...interface{}) {
+	o.status("!", "WARN", Yellow, format, args...)
+}
+
+// Error writes a line reporting that a step failed.
+func (o *Output) Error(format string, args ...interface{}) {
+	o.status("✘", "ERROR", Red, format, args...)
+}
+
+// status writes a line prefixed by the symbol in the color on a terminal, and by the label otherwise.
+func (o *Output) status(symbol, label string, c Color, format string, args ...interface{}) {
+	prefix := "[" + label + "]"
+	if o.isTerminal {
+		prefix = o.Colorize(symbol, c)
+	}
+
+	o.write(prefix + " " + fmt.Sprintf(format, args...) + "\n")
+}
+
+func (o *Output) write(s string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	_, _ = io.WriteString(o.out, s)
+}
+
+// rewrite replaces the current line of the terminal with s, for the animations.
+func (o *Output) rewrite(s string) {
+	o.write("\r\u001B[K" + s)
+}
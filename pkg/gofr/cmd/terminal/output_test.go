@@ -0,0 +1,76 @@
+package terminal
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+
+	out := New(&buf)
+
+	assert.False(t, out.IsTerminal(), "buffer should not be a terminal")
+}
+
+func TestOutput_Status(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		isTerminal bool
+		exp        string
+	}{
+		{"plain", false, "[OK] imported 3 users\n[INFO] reading users.csv\n[WARN] skipped row 2\n[ERROR] row 3 is invalid\n"},
+		{"terminal", true, "\u001B[38;5;34m✔\u001B[0m imported 3 users\n\u001B[38;5;6m•\u001B[0m reading users.csv\n" +
+			"\u001B[38;5;220m!\u001B[0m skipped row 2\n\u001B[38;5;160m✘\u001B[0m row 3 is invalid\n"},
+	}
+
+	for i, tc := range testCases {
+		var buf bytes.Buffer
+
+		out := &Output{out: &buf, isTerminal: tc.isTerminal, colors: tc.isTerminal}
+
+		out.Success("imported %d users", 3)
+		out.Info("reading %s", "users.csv")
+		out.Warn("skipped row %d", 2)
+		out.Error("row %d is invalid", 3)
+
+		assert.Equal(t, tc.exp, buf.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestOutput_Print(t *testing.T) {
+	var buf bytes.Buffer
+
+	out := New(&buf)
+
+	out.Print("a", "b")
+	out.Printf(" %d", 1)
+	out.Println()
+
+	assert.Equal(t, "ab 1\n", buf.String())
+	assert.Equal(t, "done", out.Colorize("done", Green), "plain output should not be colored")
+}
+
+func TestOutput_JSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	out := New(&buf)
+
+	assert.NoError(t, out.JSON(map[string]int{"id": 1}))
+	assert.NoError(t, out.JSON(map[string]int{"id": 2}))
+	assert.Error(t, out.JSON(make(chan int)))
+
+	assert.Equal(t, "{\"id\":1}\n{\"id\":2}\n", buf.String())
+}
+
+func TestOutput_CSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	out := New(&buf)
+
+	assert.NoError(t, out.CSV([]string{"id", "name"}, []string{"1", "gofr, go"}))
+
+	assert.Equal(t, "id,name\n1,\"gofr, go\"\n", buf.String())
+}
@@ -0,0 +1,86 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	progressBarWidth = 40
+	// plainProgressStep is the step, in percent, at which the progress is written when the output is not a terminal.
+	plainProgressStep = 10
+)
+
+// ProgressBar shows the progress of a command, redrawn on a terminal, and every 10 percent otherwise.
+type ProgressBar struct {
+	out     *Output
+	total   int64
+	current int64
+	// written is the last percentage written when the output is not a terminal.
+	written int
+	mu      sync.Mutex
+}
+
+// NewProgressBar returns a ProgressBar for total items, and draws it.
+func NewProgressBar(out *Output, total int64) *ProgressBar {
+	p := &ProgressBar{out: out, total: total, written: -1}
+
+	p.mu.Lock()
+	p.draw()
+	p.mu.Unlock()
+
+	return p
+}
+
+// Incr increments the number of items processed by n. It is safe to call concurrently.
+func (p *ProgressBar) Incr(n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current += n
+	if p.current > p.total {
+		p.current = p.total
+	}
+
+	p.draw()
+}
+
+// Done completes the progress bar, ending its line on a terminal.
+func (p *ProgressBar) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current = p.total
+	p.draw()
+
+	if p.out.isTerminal {
+		p.out.write("\n")
+	}
+}
+
+func (p *ProgressBar) percent() int {
+	if p.total <= 0 {
+		return 100
+	}
+
+	return int(p.current * 100 / p.total)
+}
+
+func (p *ProgressBar) draw() {
+	percent := p.percent()
+
+	if !p.out.isTerminal {
+		if step := percent - percent%plainProgressStep; step > p.written {
+			p.written = step
+			p.out.write(fmt.Sprintf("%d/%d (%d%%)\n", p.current, p.total, percent))
+		}
+
+		return
+	}
+
+	filled := progressBarWidth * percent / 100
+	bar := p.out.Colorize(strings.Repeat("█", filled), Green) + strings.Repeat("░", progressBarWidth-filled)
+
+	p.out.rewrite(fmt.Sprintf("%s %3d%% (%d/%d)", bar, percent, p.current, p.total))
+}
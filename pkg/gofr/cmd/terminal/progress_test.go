@@ -0,0 +1,48 @@
+package terminal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressBar_Plain(t *testing.T) {
+	var buf bytes.Buffer
+
+	p := NewProgressBar(New(&buf), 200)
+
+	for i := 0; i < 100; i++ {
+		p.Incr(2)
+	}
+
+	p.Done()
+
+	assert.Equal(t, "0/200 (0%)\n20/200 (10%)\n40/200 (20%)\n", strings.Join(strings.SplitAfter(buf.String(), "\n")[:3], ""))
+	assert.Equal(t, 11, strings.Count(buf.String(), "\n"), "a line should be written every 10 percent")
+	assert.True(t, strings.HasSuffix(buf.String(), "200/200 (100%)\n"))
+}
+
+func TestProgressBar_Terminal(t *testing.T) {
+	var buf bytes.Buffer
+
+	p := NewProgressBar(&Output{out: &buf, isTerminal: true}, 4)
+
+	p.Incr(1)
+	p.Incr(10)
+	p.Done()
+
+	lines := strings.Split(buf.String(), "\r\u001B[K")
+
+	assert.Equal(t, strings.Repeat("█", 10)+strings.Repeat("░", 30)+"  25% (1/4)", lines[2])
+	assert.Equal(t, strings.Repeat("█", 40)+" 100% (4/4)\n", lines[len(lines)-1])
+}
+
+func TestProgressBar_EmptyTotal(t *testing.T) {
+	var buf bytes.Buffer
+
+	NewProgressBar(New(&buf), 0).Done()
+
+	assert.Equal(t, "0/0 (100%)\n", buf.String())
+}
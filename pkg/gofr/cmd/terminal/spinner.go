@@ -0,0 +1,80 @@
+package terminal
+
+import (
+	"time"
+)
+
+const spinnerInterval = 100 * time.Millisecond
+
+//nolint:gochecknoglobals // frames of the animation of the spinner.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Spinner shows that a command is waiting, animated on a terminal.
+type Spinner struct {
+	out     *Output
+	message string
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewSpinner returns a Spinner showing the message, which is shown once it is started.
+func NewSpinner(out *Output, message string) *Spinner {
+	return &Spinner{out: out, message: message}
+}
+
+// Start shows the spinner. It returns the spinner, so that it can be started when it is created.
+func (s *Spinner) Start() *Spinner {
+	if s.stop != nil {
+		return s
+	}
+
+	s.stop, s.done = make(chan struct{}), make(chan struct{})
+
+	if !s.out.isTerminal {
+		s.out.write(s.message + "...\n")
+		close(s.done)
+
+		return s
+	}
+
+	go s.animate()
+
+	return s
+}
+
+// Stop stops the spinner, and clears its line on a terminal.
+func (s *Spinner) Stop() {
+	if s.stop == nil {
+		return
+	}
+
+	select {
+	case <-s.stop:
+		return
+	default:
+		close(s.stop)
+	}
+
+	<-s.done
+
+	if s.out.isTerminal {
+		s.out.rewrite("")
+	}
+}
+
+func (s *Spinner) animate() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for i := 0; ; i++ {
+		s.out.rewrite(s.out.Colorize(spinnerFrames[i%len(spinnerFrames)], Cyan) + " " + s.message)
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
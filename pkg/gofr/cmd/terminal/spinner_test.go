@@ -0,0 +1,47 @@
+package terminal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpinner_Plain(t *testing.T) {
+	var buf bytes.Buffer
+
+	s := NewSpinner(New(&buf), "connecting to the database").Start()
+	s.Start()
+	s.Stop()
+	s.Stop()
+
+	assert.Equal(t, "connecting to the database...\n", buf.String())
+}
+
+func TestSpinner_Terminal(t *testing.T) {
+	var buf bytes.Buffer
+
+	out := &Output{out: &buf, isTerminal: true}
+
+	s := NewSpinner(out, "connecting").Start()
+
+	time.Sleep(2*spinnerInterval + spinnerInterval/2)
+
+	s.Stop()
+
+	out.mu.Lock()
+	defer out.mu.Unlock()
+
+	assert.Contains(t, buf.String(), "\r\u001B[K⠋ connecting\r\u001B[K⠙ connecting")
+	assert.True(t, strings.HasSuffix(buf.String(), "\r\u001B[K"), "line of the spinner should be cleared")
+}
+
+func TestSpinner_StopWithoutStart(t *testing.T) {
+	var buf bytes.Buffer
+
+	NewSpinner(New(&buf), "connecting").Stop()
+
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,57 @@
+package terminal
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const tableColumnGap = 3
+
+// Table writes the rows in aligned columns under the headers.
+func (o *Output) Table(headers []string, rows [][]string) {
+	widths := make([]int, len(headers))
+
+	for i, h := range headers {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+
+	for _, row := range rows {
+		for i := 0; i < len(row) && i < len(widths); i++ {
+			widths[i] = max(widths[i], utf8.RuneCountInString(row[i]))
+		}
+	}
+
+	var b strings.Builder
+
+	header := formatRow(headers, widths)
+	if o.colors {
+		header = "\u001B[1m" + header + "\u001B[0m"
+	}
+
+	b.WriteString(header + "\n")
+
+	for _, row := range rows {
+		b.WriteString(formatRow(row, widths) + "\n")
+	}
+
+	o.write(b.String())
+}
+
+func formatRow(cells []string, widths []int) string {
+	var b strings.Builder
+
+	for i, w := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+
+		b.WriteString(cell)
+
+		if i < len(widths)-1 {
+			b.WriteString(strings.Repeat(" ", w-utf8.RuneCountInString(cell)+tableColumnGap))
+		}
+	}
+
+	return strings.TrimRight(b.String(), " ")
+}
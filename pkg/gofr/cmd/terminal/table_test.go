@@ -0,0 +1,29 @@
+package terminal
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutput_Table(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		colors bool
+		exp    string
+	}{
+		{"plain", false, "ID    NAME      STATUS\n1     Gofr      ✔\n200   Ünïcödé\n"},
+		{"colors", true, "\u001B[1mID    NAME      STATUS\u001B[0m\n1     Gofr      ✔\n200   Ünïcödé\n"},
+	}
+
+	for i, tc := range testCases {
+		var buf bytes.Buffer
+
+		out := &Output{out: &buf, isTerminal: tc.colors, colors: tc.colors}
+
+		out.Table([]string{"ID", "NAME", "STATUS"}, [][]string{{"1", "Gofr", "✔"}, {"200", "Ünïcödé"}})
+
+		assert.Equal(t, tc.exp, buf.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -222,3 +222,146 @@ func Test_Run_SuccessCallInvalidHyphens(t *testing.T) {
 
 	assert.Contains(t, logs, "handler called")
 }
+
+func Test_Run_Flags(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		args    []string
+		expFile string
+		expDry  string
+		expCode int
+	}{
+		{"default values", []string{"", "import"}, "data.csv", "false", 0},
+		{"flags passed", []string{"", "import", "-file=users.csv", "--dry-run"}, "users.csv", "true", 0},
+		{"unknown flag", []string{"", "import", "-files=users.csv"}, "", "", exitCodeUsage},
+	}
+
+	for i, tc := range testCases {
+		os.Args = tc.args
+
+		var file, dryRun string
+
+		c := cmd{}
+
+		c.addRoute("import", func(c *Context) (interface{}, error) {
+			file, dryRun = c.Param("file"), c.Param("dry-run")
+
+			return nil, nil
+		}, AddFlag("file", "data.csv", "path of the file"), AddFlag("dry-run", "false", "validate without importing"))
+
+		var code int
+
+		testutil.StderrOutputForFunc(func() {
+			code = c.Run(container.NewContainer(config.NewMockConfig(nil)))
+		})
+
+		assert.Equal(t, tc.expCode, code, "TEST[%d] Failed.\n %s", i, tc.desc)
+		assert.Equal(t, tc.expFile, file, "TEST[%d] Failed.\n %s", i, tc.desc)
+		assert.Equal(t, tc.expDry, dryRun, "TEST[%d] Failed.\n %s", i, tc.desc)
+	}
+}
+
+type testExitError struct{}
+
+func (testExitError) Error() string { return "partially imported" }
+
+func (testExitError) ExitCode() int { return 3 }
+
+func Test_Run_ExitCodes(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		args    []string
+		err     error
+		expCode int
+	}{
+		{"success", []string{"", "import"}, nil, 0},
+		{"handler error", []string{"", "import"}, testutil.CustomError{ErrorMessage: "import failed"}, exitCodeError},
+		{"error with exit code", []string{"", "import"}, testExitError{}, 3},
+		{"command not found", []string{"", "export"}, nil, exitCodeUsage},
+	}
+
+	for i, tc := range testCases {
+		os.Args = tc.args
+
+		c := cmd{}
+
+		c.addRoute("import", func(*Context) (interface{}, error) {
+			return nil, tc.err
+		})
+
+		var code int
+
+		testutil.StderrOutputForFunc(func() {
+			code = c.Run(container.NewContainer(config.NewMockConfig(nil)))
+		})
+
+		assert.Equal(t, tc.expCode, code, "TEST[%d] Failed.\n %s", i, tc.desc)
+	}
+}
+
+func Test_Run_Help(t *testing.T) {
+	c := cmd{}
+
+	c.addRoute("import", nil, AddDescription("Imports the users of a file"),
+		AddFlag("file", "data.csv", "path of the file"))
+	c.addRoute("export", nil, AddDescription("Exports the users to a file"))
+
+	os.Args = []string{"/bin/admin", "help"}
+
+	logs := testutil.StdoutOutputForFunc(func() {
+		assert.Equal(t, 0, c.Run(container.NewContainer(config.NewMockConfig(nil))))
+	})
+
+	assert.Contains(t, logs, "admin <command> [flags]")
+	assert.Contains(t, logs, "  import   Imports the users of a file\n")
+	assert.Contains(t, logs, "  export   Exports the users to a file\n")
+
+	os.Args = []string{"/bin/admin", "import", "-h"}
+
+	logs = testutil.StdoutOutputForFunc(func() {
+		assert.Equal(t, 0, c.Run(container.NewContainer(config.NewMockConfig(nil))))
+	})
+
+	assert.Contains(t, logs, "Imports the users of a file\n\nUsage:\n  admin import [flags]\n")
+	assert.Contains(t, logs, `-file   path of the file (default "data.csv")`)
+}
+
+func TestApp_Run_ExitCode(t *testing.T) {
+	var code int
+
+	exit = func(c int) { code = c }
+
+	defer func() { exit = os.Exit }()
+
+	os.Args = []string{"", "import"}
+
+	testutil.StderrOutputForFunc(func() {
+		a := NewCMD()
+
+		a.SubCommand("import", func(*Context) (interface{}, error) {
+			return nil, testutil.CustomError{ErrorMessage: "import failed"}
+		})
+
+		a.Run()
+	})
+
+	assert.Equal(t, exitCodeError, code)
+}
+
+func Test_Run_Output(t *testing.T) {
+	os.Args = []string{"", "import"}
+
+	c := cmd{}
+
+	c.addRoute("import", func(c *Context) (interface{}, error) {
+		c.Out.Success("imported %d users", 3)
+
+		return nil, nil
+	})
+
+	logs := testutil.StdoutOutputForFunc(func() {
+		c.Run(container.NewContainer(config.NewMockConfig(nil)))
+	})
+
+	assert.Equal(t, "[OK] imported 3 users\n", logs)
+}
@@ -0,0 +1,100 @@
+package gofr
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/singleflight"
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+//nolint:gochecknoglobals // the headers on which any response may depend are constant.
+var coalescedHeaders = []string{"Accept", "Accept-Encoding", "Accept-Language", "Authorization", "Cookie", "X-Api-Key",
+	"Range", "If-None-Match", "If-Modified-Since"}
+
+// coalescer collapses the concurrent identical GET requests of a route into a single call of its handler.
+type coalescer struct {
+	headers []string
+	group   singleflight.Group[*coalescedResponse]
+}
+
+func newCoalescer(headers []string) *coalescer {
+	c := &coalescer{headers: append([]string{}, coalescedHeaders...)}
+
+	for _, h := range headers {
+		c.headers = append(c.headers, http.CanonicalHeaderKey(h))
+	}
+
+	return c
+}
+
+// serve responds to the request with a copy of the response of an identical request in flight, if any.
+func (c *coalescer) serve(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	res, _, err := c.group.Do(r.Context(), c.key(r), func(ctx context.Context) (*coalescedResponse, error) {
+		rec := &coalescedResponse{header: make(http.Header)}
+		next(rec, r.WithContext(ctx))
+
+		return rec, nil
+	})
+	if err != nil {
+		// the client of the request is gone.
+		return
+	}
+
+	res.writeTo(w)
+}
+
+// key identifies the identical requests.
+func (c *coalescer) key(r *http.Request) string {
+	var key strings.Builder
+
+	key.WriteString(r.URL.RequestURI())
+	key.WriteString("\n")
+	key.WriteString(tenant.FromContext(r.Context()))
+
+	for _, h := range c.headers {
+		key.WriteString("\n")
+		key.WriteString(strings.Join(r.Header.Values(h), ","))
+	}
+
+	return key.String()
+}
+
+// coalescedResponse records a response, which is copied to all the requests sharing it.
+type coalescedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *coalescedResponse) Header() http.Header {
+	return r.header
+}
+
+func (r *coalescedResponse) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *coalescedResponse) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	return r.body.Write(b)
+}
+
+func (r *coalescedResponse) writeTo(w http.ResponseWriter) {
+	for name, values := range r.header {
+		w.Header()[name] = append([]string{}, values...)
+	}
+
+	if r.status != 0 {
+		w.WriteHeader(r.status)
+	}
+
+	_, _ = w.Write(r.body.Bytes())
+}
@@ -0,0 +1,109 @@
+package gofr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalesceRequests(t *testing.T) {
+	app := New()
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+
+	report := func(c *Context) (interface{}, error) {
+		calls.Add(1)
+		<-release
+
+		return "report of " + c.PathParam("id"), nil
+	}
+
+	app.GET("/reports/{id}", report, CoalesceRequests("X-Region"))
+	app.GET("/uncoalesced/{id}", report)
+
+	requests := []struct {
+		path   string
+		region string
+		body   string
+	}{
+		{"/reports/1", "eu", `{"data":"report of 1"}`},
+		{"/reports/1", "eu", `{"data":"report of 1"}`},
+		{"/reports/1", "eu", `{"data":"report of 1"}`},
+		{"/reports/1", "us", `{"data":"report of 1"}`},
+		{"/reports/2", "eu", `{"data":"report of 2"}`},
+		{"/uncoalesced/1", "eu", `{"data":"report of 1"}`},
+		{"/uncoalesced/1", "eu", `{"data":"report of 1"}`},
+	}
+
+	responses := make([]*httptest.ResponseRecorder, len(requests))
+
+	var wg sync.WaitGroup
+
+	for i, req := range requests {
+		wg.Add(1)
+
+		go func(i int, path, region string) {
+			defer wg.Done()
+
+			r := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+			r.Header.Set("X-Region", region)
+
+			responses[i] = httptest.NewRecorder()
+			app.httpServer.router.ServeHTTP(responses[i], r)
+		}(i, req.path, req.region)
+	}
+
+	// the requests are given the time to reach the handler, or to wait for an identical request.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i, req := range requests {
+		assert.Equal(t, http.StatusOK, responses[i].Code, "TEST[%d], Failed.\n%s", i, req.path)
+		assert.JSONEq(t, req.body, responses[i].Body.String(), "TEST[%d], Failed.\n%s", i, req.path)
+		assert.Equal(t, "application/json", responses[i].Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, req.path)
+	}
+
+	// the identical requests share a call, while the requests of another region, id or route do not.
+	assert.Equal(t, int32(5), calls.Load())
+}
+
+func TestCoalescer_key(t *testing.T) {
+	c := newCoalescer([]string{"x-region"})
+
+	newRequest := func(target string, headers ...string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+
+		for i := 0; i < len(headers); i += 2 {
+			r.Header.Add(headers[i], headers[i+1])
+		}
+
+		return r
+	}
+
+	base := newRequest("/reports?page=1", "Authorization", "Bearer a", "X-Region", "eu")
+
+	testCases := []struct {
+		desc      string
+		req       *http.Request
+		identical bool
+	}{
+		{"same request", newRequest("/reports?page=1", "Authorization", "Bearer a", "X-Region", "eu"), true},
+		{"unrelated header", newRequest("/reports?page=1", "Authorization", "Bearer a", "X-Region", "eu",
+			"User-Agent", "curl"), true},
+		{"other query", newRequest("/reports?page=2", "Authorization", "Bearer a", "X-Region", "eu"), false},
+		{"other user", newRequest("/reports?page=1", "Authorization", "Bearer b", "X-Region", "eu"), false},
+		{"other route header", newRequest("/reports?page=1", "Authorization", "Bearer a", "X-Region", "us"), false},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.identical, c.key(base) == c.key(tc.req), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
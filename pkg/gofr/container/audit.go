@@ -0,0 +1,157 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+const (
+	defaultAuditTopic = "gofr-audit"
+	defaultAuditFile  = "audit.log"
+
+	defaultAuditQueryLimit = 100
+)
+
+var (
+	errAuditSinkNotConfigured = errors.New("audit sink not configured, either sql, pubsub or file is required")
+	errAuditQueryNotSupported = errors.New("the audit sink does not support queries")
+)
+
+// AuditEntry is a record of an action taken on a resource, with the state of the resource before and after it.
+type AuditEntry struct {
+	ID       string    `json:"id"`
+	Time     time.Time `json:"time"`
+	Actor    string    `json:"actor,omitempty"`
+	Tenant   string    `json:"tenant,omitempty"`
+	Action   string    `json:"action"`
+	Resource string    `json:"resource"`
+	// Before and After are the JSON of the resource before and after the action, or null if it did not exist.
+	Before  json.RawMessage `json:"before,omitempty"`
+	After   json.RawMessage `json:"after,omitempty"`
+	TraceID string          `json:"traceId,omitempty"`
+}
+
+// AuditFilter selects the entries returned by QueryAudit. The empty fields do not filter the entries.
+type AuditFilter struct {
+	Actor    string
+	Tenant   string
+	Action   string
+	Resource string
+	From     time.Time
+	To       time.Time
+	// Limit is the maximum number of entries returned, 100 by default.
+	Limit int
+}
+
+// AuditSink persists the audit entries.
+type AuditSink interface {
+	Write(ctx context.Context, entry *AuditEntry) error
+}
+
+// AuditQuerier is implemented by the audit sinks whose entries can be queried, the most recent first.
+type AuditQuerier interface {
+	Query(ctx context.Context, filter AuditFilter) ([]AuditEntry, error)
+}
+
+type audit struct {
+	mu      sync.Mutex
+	backend string
+	topic   string
+	file    string
+	sink    AuditSink
+}
+
+// Audit persists the entry to the audit sink, after setting its ID, its time, and the tenant and the trace of ctx.
+func (c *Container) Audit(ctx context.Context, entry *AuditEntry) error {
+	sink, err := c.AuditSink()
+	if err != nil {
+		return err
+	}
+
+	entry.ID = uuid.NewString()
+
+	if entry.Time.IsZero() {
+		entry.Time = c.Clock().Now()
+	}
+
+	entry.Time = entry.Time.UTC().Truncate(time.Millisecond)
+
+	if entry.Tenant == "" {
+		entry.Tenant = tenant.FromContext(ctx)
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		entry.TraceID = sc.TraceID().String()
+	}
+
+	return sink.Write(ctx, entry)
+}
+
+// QueryAudit returns the entries of the audit sink matching the filter, the most recent first.
+func (c *Container) QueryAudit(ctx context.Context, filter AuditFilter) ([]AuditEntry, error) {
+	sink, err := c.AuditSink()
+	if err != nil {
+		return nil, err
+	}
+
+	q, ok := sink.(AuditQuerier)
+	if !ok {
+		return nil, errAuditQueryNotSupported
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditQueryLimit
+	}
+
+	return q.Query(ctx, filter)
+}
+
+// SetAuditSink replaces the sink of the audit entries, like with a sink of another store.
+func (c *Container) SetAuditSink(sink AuditSink) {
+	c.audit.mu.Lock()
+	defer c.audit.mu.Unlock()
+
+	c.audit.sink = sink
+}
+
+// AuditSink returns the sink of the audit entries chosen by AUDIT_SINK, the SQL database by default.
+func (c *Container) AuditSink() (AuditSink, error) {
+	c.audit.mu.Lock()
+	defer c.audit.mu.Unlock()
+
+	if c.audit.sink != nil {
+		return c.audit.sink, nil
+	}
+
+	switch backend := strings.ToLower(c.audit.backend); {
+	case (backend == "" || backend == "sql") && !isNil(c.SQL):
+		c.audit.sink = &sqlAuditSink{db: c.SQL}
+	case backend == "pubsub" && !isNil(c.PubSub):
+		c.audit.sink = &pubsubAuditSink{publisher: c.PubSub, topic: c.audit.topic}
+	case backend == "file":
+		c.audit.sink = &fileAuditSink{path: c.audit.file}
+	default:
+		return nil, errAuditSinkNotConfigured
+	}
+
+	return c.audit.sink, nil
+}
+
+// matches reports whether the entry is selected by the filter.
+func (f *AuditFilter) matches(e *AuditEntry) bool {
+	return (f.Actor == "" || e.Actor == f.Actor) &&
+		(f.Tenant == "" || e.Tenant == f.Tenant) &&
+		(f.Action == "" || e.Action == f.Action) &&
+		(f.Resource == "" || e.Resource == f.Resource) &&
+		(f.From.IsZero() || !e.Time.Before(f.From)) &&
+		(f.To.IsZero() || e.Time.Before(f.To))
+}
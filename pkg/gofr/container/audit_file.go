@@ -0,0 +1,90 @@
+package container
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+)
+
+const auditFileMode = 0o600
+
+// fileAuditSink appends the audit entries to a file as JSON lines.
+type fileAuditSink struct {
+	path string
+
+	mu sync.Mutex
+}
+
+func (s *fileAuditSink) Write(_ context.Context, entry *AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, auditFileMode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
+
+func (s *fileAuditSink) Query(_ context.Context, filter AuditFilter) ([]AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]AuditEntry, 0)
+
+	f, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return entries, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, bufio.MaxScanTokenSize<<8)
+
+	for scanner.Scan() {
+		var e AuditEntry
+
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, err
+		}
+
+		if filter.matches(&e) {
+			entries = append(entries, e)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// the last entry of the same time is the most recent.
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.After(entries[j].Time) })
+
+	return entries[:min(len(entries), filter.Limit)], nil
+}
@@ -0,0 +1,23 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+// pubsubAuditSink publishes the audit entries as JSON on a topic. Its entries cannot be queried.
+type pubsubAuditSink struct {
+	publisher pubsub.Publisher
+	topic     string
+}
+
+func (s *pubsubAuditSink) Write(ctx context.Context, entry *AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return s.publisher.Publish(ctx, s.topic, data)
+}
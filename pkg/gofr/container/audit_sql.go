@@ -0,0 +1,123 @@
+package container
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+const (
+	createSQLAuditTable = `CREATE TABLE IF NOT EXISTS gofr_audit_log (
+    id VARCHAR(36) not null primary key,
+    created_at BIGINT not null,
+    actor VARCHAR(255) not null,
+    tenant VARCHAR(63) not null,
+    action VARCHAR(255) not null,
+    resource VARCHAR(255) not null,
+    before_state TEXT not null,
+    after_state TEXT not null,
+    trace_id VARCHAR(32) not null
+);`
+
+	sqlAuditColumns = `id, created_at, actor, tenant, action, resource, before_state, after_state, trace_id`
+
+	insertSQLAudit = `INSERT INTO gofr_audit_log (` + sqlAuditColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	selectSQLAudit = `SELECT ` + sqlAuditColumns + ` FROM gofr_audit_log`
+)
+
+// sqlAuditSink keeps the audit entries in the gofr_audit_log table.
+type sqlAuditSink struct {
+	db DB
+
+	schema gofrSQL.Schema
+}
+
+func (s *sqlAuditSink) Write(ctx context.Context, entry *AuditEntry) error {
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), insertSQLAudit), entry.ID, entry.Time.UnixMilli(), entry.Actor,
+		entry.Tenant, entry.Action, entry.Resource, string(entry.Before), string(entry.After), entry.TraceID)
+
+	return err
+}
+
+func (s *sqlAuditSink) Query(ctx context.Context, filter AuditFilter) ([]AuditEntry, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	for column, value := range map[string]string{"actor": filter.Actor, "tenant": filter.Tenant,
+		"action": filter.Action, "resource": filter.Resource} {
+		if value != "" {
+			conditions = append(conditions, column+" = ?")
+			args = append(args, value)
+		}
+	}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.From.UnixMilli())
+	}
+
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.To.UnixMilli())
+	}
+
+	q := selectSQLAudit
+
+	if len(conditions) > 0 {
+		q += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	q += " ORDER BY created_at DESC" + gofrSQL.Limit(s.db.Dialect())
+
+	rows, err := s.db.QueryContext(ctx, gofrSQL.Rebind(s.db.Dialect(), q), append(args, filter.Limit)...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	entries := make([]AuditEntry, 0)
+
+	for rows.Next() {
+		var (
+			e             AuditEntry
+			createdAt     int64
+			before, after string
+		)
+
+		err := rows.Scan(&e.ID, &createdAt, &e.Actor, &e.Tenant, &e.Action, &e.Resource, &before, &after, &e.TraceID)
+		if err != nil {
+			return nil, err
+		}
+
+		e.Time = time.UnixMilli(createdAt).UTC()
+
+		if before != "" {
+			e.Before = []byte(before)
+		}
+
+		if after != "" {
+			e.After = []byte(after)
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+func (s *sqlAuditSink) migrate(ctx context.Context) error {
+	return s.schema.Create(ctx, s.db, createSQLAuditTable)
+}
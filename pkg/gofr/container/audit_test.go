@@ -0,0 +1,119 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+func TestAuditSinks(t *testing.T) {
+	containers := map[string]func(t *testing.T) *Container{
+		"sql": newSQLJobsContainer,
+		"file": func(t *testing.T) *Container {
+			t.Helper()
+
+			return NewContainer(config.NewMockConfig(map[string]string{
+				"AUDIT_SINK": "file",
+				"AUDIT_FILE": filepath.Join(t.TempDir(), "audit.log"),
+			}))
+		},
+	}
+
+	for name, newContainer := range containers {
+		t.Run(name, func(t *testing.T) {
+			testAuditSink(t, newContainer(t))
+		})
+	}
+}
+
+func testAuditSink(t *testing.T, c *Container) {
+	t.Helper()
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(tenant.NewContext(context.Background(), "acme"), "audit")
+	defer span.End()
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	entries := []*AuditEntry{
+		{Actor: "alice", Action: "order.create", Resource: "orders/1", After: json.RawMessage(`{"amount":10}`),
+			Time: start},
+		{Actor: "bob", Action: "order.update", Resource: "orders/1", Before: json.RawMessage(`{"amount":10}`),
+			After: json.RawMessage(`{"amount":20}`), Time: start.Add(time.Minute)},
+		{Actor: "alice", Action: "order.delete", Resource: "orders/2", Before: json.RawMessage(`{"amount":5}`),
+			Time: start.Add(2 * time.Minute)},
+	}
+
+	for _, e := range entries {
+		require.NoError(t, c.Audit(ctx, e))
+	}
+
+	assert.NotEmpty(t, entries[0].ID)
+	assert.Equal(t, "acme", entries[0].Tenant)
+	assert.Equal(t, span.SpanContext().TraceID().String(), entries[0].TraceID)
+
+	testCases := []struct {
+		desc    string
+		filter  AuditFilter
+		entries []*AuditEntry
+	}{
+		{"all entries, the most recent first", AuditFilter{}, []*AuditEntry{entries[2], entries[1], entries[0]}},
+		{"by actor", AuditFilter{Actor: "alice"}, []*AuditEntry{entries[2], entries[0]}},
+		{"by resource and action", AuditFilter{Resource: "orders/1", Action: "order.update"}, []*AuditEntry{entries[1]}},
+		{"by tenant", AuditFilter{Tenant: "other"}, []*AuditEntry{}},
+		{"by time", AuditFilter{From: start.Add(time.Minute), To: start.Add(2 * time.Minute)}, []*AuditEntry{entries[1]}},
+		{"limited", AuditFilter{Limit: 1}, []*AuditEntry{entries[2]}},
+	}
+
+	for i, tc := range testCases {
+		got, err := c.QueryAudit(ctx, tc.filter)
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		want := make([]AuditEntry, 0, len(tc.entries))
+		for _, e := range tc.entries {
+			want = append(want, *e)
+		}
+
+		assert.Equal(t, want, got, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestAuditSink_PubSub(t *testing.T) {
+	publisher := &MockPubSub{}
+
+	c := &Container{PubSub: publisher, audit: audit{backend: "pubsub", topic: defaultAuditTopic}}
+
+	require.NoError(t, c.Audit(context.Background(), &AuditEntry{Actor: "alice", Action: "login", Resource: "users/1"}))
+
+	published := publisher.Published(defaultAuditTopic)
+	require.Len(t, published, 1)
+
+	var e AuditEntry
+
+	require.NoError(t, json.Unmarshal(published[0].Value, &e))
+	assert.Equal(t, "login", e.Action)
+
+	_, err := c.QueryAudit(context.Background(), AuditFilter{})
+	assert.Equal(t, errAuditQueryNotSupported, err)
+}
+
+func TestAuditSink_NotConfigured(t *testing.T) {
+	c := &Container{}
+
+	assert.Equal(t, errAuditSinkNotConfigured, c.Audit(context.Background(), &AuditEntry{}))
+
+	sink := &fileAuditSink{path: filepath.Join(t.TempDir(), "audit.log")}
+	c.SetAuditSink(sink)
+
+	got, err := c.AuditSink()
+	require.NoError(t, err)
+	assert.Same(t, sink, got)
+}
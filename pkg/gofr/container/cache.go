@@ -0,0 +1,53 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var errCacheNotConfigured = errors.New("cache not configured")
+
+// Cache stores values until their ttl.
+type Cache interface {
+	// Get returns the value of the key, or false if the key is not stored or is expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value of the key until the ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes the values of the keys matching the pattern, and returns their number.
+	Delete(ctx context.Context, pattern string) (int, error)
+}
+
+type cache struct {
+	mu      sync.Mutex
+	backend string
+	store   Cache
+}
+
+// Cache returns the cache chosen by CACHE_STORE, which is Redis if configured, or memory.
+func (c *Container) Cache() (Cache, error) {
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+
+	if c.cache.store != nil {
+		return c.cache.store, nil
+	}
+
+	backend := strings.ToLower(c.cache.backend)
+
+	switch {
+	case (backend == "" || backend == "redis") && !isNil(c.Redis):
+		c.cache.store = &redisCache{redis: c.Redis}
+	case backend == "" || backend == "memory":
+		c.cache.store = newMemoryCache(c.Clock())
+	case backend == "redis":
+		return nil, fmt.Errorf("%w: CACHE_STORE is redis but redis is not configured", errCacheNotConfigured)
+	default:
+		return nil, fmt.Errorf("%w: unknown CACHE_STORE %q, either redis or memory", errCacheNotConfigured, backend)
+	}
+
+	return c.cache.store, nil
+}
@@ -0,0 +1,114 @@
+package container
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+// memoryCacheSweepInterval is the interval at which the expired values are removed.
+const memoryCacheSweepInterval = time.Minute
+
+// memoryCache keeps the values in the memory of the application, which are not shared with its other instances.
+type memoryCache struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+	sweptAt time.Time
+}
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func newMemoryCache(c clock.Clock) *memoryCache {
+	return &memoryCache{clock: c, entries: make(map[string]memoryCacheEntry), sweptAt: c.Now()}
+}
+
+func (m *memoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if !m.clock.Now().Before(e.expiresAt) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+
+	return e.value, true, nil
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+
+	if now.Sub(m.sweptAt) >= memoryCacheSweepInterval {
+		for k, e := range m.entries {
+			if !now.Before(e.expiresAt) {
+				delete(m.entries, k)
+			}
+		}
+
+		m.sweptAt = now
+	}
+
+	m.entries[key] = memoryCacheEntry{value: append([]byte{}, value...), expiresAt: now.Add(ttl)}
+
+	return nil
+}
+
+func (m *memoryCache) Delete(_ context.Context, pattern string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := 0
+
+	for k := range m.entries {
+		if matchPattern(pattern, k) {
+			delete(m.entries, k)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+// matchPattern reports whether the key matches the pattern, like the patterns of Redis.
+func matchPattern(pattern, key string) bool {
+	// the position after the last "*", and the position of the key it is matched up to.
+	star, starKey := -1, 0
+	p, k := 0, 0
+
+	for k < len(key) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == key[k]):
+			p++
+			k++
+		case p < len(pattern) && pattern[p] == '*':
+			star, starKey = p+1, k
+			p++
+		case star != -1:
+			// the last "*" matches one more character.
+			starKey++
+			p, k = star, starKey
+		default:
+			return false
+		}
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+
+	return p == len(pattern)
+}
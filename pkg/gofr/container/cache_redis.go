@@ -0,0 +1,66 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisCachePrefix = "gofr:cache:"
+	// redisCacheScanCount is the number of the keys scanned at a time when deleting the keys matching a pattern.
+	redisCacheScanCount = 100
+)
+
+// redisCache keeps each value as a string expiring after its ttl.
+type redisCache struct {
+	redis Redis
+}
+
+func (r *redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.redis.Get(ctx, redisCachePrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+func (r *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.redis.Set(ctx, redisCachePrefix+key, value, ttl).Err()
+}
+
+func (r *redisCache) Delete(ctx context.Context, pattern string) (int, error) {
+	var (
+		cursor  uint64
+		deleted int
+	)
+
+	for {
+		keys, next, err := r.redis.Scan(ctx, cursor, redisCachePrefix+pattern, redisCacheScanCount).Result()
+		if err != nil {
+			return deleted, err
+		}
+
+		if len(keys) > 0 {
+			n, err := r.redis.Del(ctx, keys...).Result()
+			if err != nil {
+				return deleted, err
+			}
+
+			deleted += int(n)
+		}
+
+		if next == 0 {
+			return deleted, nil
+		}
+
+		cursor = next
+	}
+}
@@ -0,0 +1,126 @@
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+func TestCaches(t *testing.T) {
+	containers := map[string]func(t *testing.T) *Container{
+		"redis":  newRedisJobsContainer,
+		"memory": func(*testing.T) *Container { return NewContainer(config.NewMockConfig(nil)) },
+	}
+
+	for name, newContainer := range containers {
+		t.Run(name, func(t *testing.T) {
+			testCache(t, newContainer(t))
+		})
+	}
+}
+
+func testCache(t *testing.T, c *Container) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	store, err := c.Cache()
+	require.NoError(t, err)
+
+	_, ok, err := store.Get(ctx, "/orders")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	for _, key := range []string{"/orders", "/orders?page=2", "/orders/1", "/users/1"} {
+		require.NoError(t, store.Set(ctx, key, []byte("value of "+key), time.Hour))
+	}
+
+	value, ok, err := store.Get(ctx, "/orders?page=2")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "value of /orders?page=2", string(value))
+
+	deleted, err := store.Delete(ctx, "/orders?*")
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted, "the pattern should match /orders?page=2 and /orders/1")
+
+	deleted, err = store.Delete(ctx, "/orders*")
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	_, ok, err = store.Get(ctx, "/users/1")
+	require.NoError(t, err)
+	assert.True(t, ok, "the values not matching the patterns should be kept")
+}
+
+func TestCache_Backend(t *testing.T) {
+	testCases := []struct {
+		backend string
+		cache   Cache
+		err     bool
+	}{
+		{"", &memoryCache{}, false},
+		{"memory", &memoryCache{}, false},
+		{"redis", nil, true},
+		{"disk", nil, true},
+	}
+
+	for i, tc := range testCases {
+		c := NewContainer(config.NewMockConfig(map[string]string{"CACHE_STORE": tc.backend}))
+
+		store, err := c.Cache()
+
+		assert.Equal(t, tc.err, err != nil, "TEST[%d], Failed.\n%s", i, tc.backend)
+		assert.IsType(t, tc.cache, store, "TEST[%d], Failed.\n%s", i, tc.backend)
+	}
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Now())
+	store := newMemoryCache(fake)
+
+	require.NoError(t, store.Set(ctx, "short", []byte("1"), time.Second))
+	require.NoError(t, store.Set(ctx, "long", []byte("2"), time.Hour))
+
+	fake.Advance(time.Second)
+
+	_, ok, _ := store.Get(ctx, "short")
+	assert.False(t, ok, "the value should expire after its ttl")
+
+	_, ok, _ = store.Get(ctx, "long")
+	assert.True(t, ok)
+
+	fake.Advance(2 * time.Hour)
+	require.NoError(t, store.Set(ctx, "new", []byte("3"), time.Hour))
+
+	assert.Len(t, store.entries, 1, "the expired values should be swept")
+}
+
+func TestMatchPattern(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		key     string
+		match   bool
+	}{
+		{"/orders", "/orders", true},
+		{"/orders*", "/orders/1/items", true},
+		{"/orders/*/items", "/orders/1/items", true},
+		{"/orders/?", "/orders/1", true},
+		{"/orders/?", "/orders/12", false},
+		{"*", "", true},
+		{"/orders", "/orders/1", false},
+		{"/users*", "/orders", false},
+		{"*1*2", "/a1b1c2", true},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.match, matchPattern(tc.pattern, tc.key), "TEST[%d], Failed.\n%s %s", i, tc.pattern, tc.key)
+	}
+}
@@ -3,19 +3,34 @@ package container
 import (
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/bulkhead"
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
 	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/crypto"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/cassandra"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub/google"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub/kafka"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub/memory"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub/mqtt"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub/nats"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource/redis"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+	"github.com/peter-stratton/gofr/pkg/gofr/export"
+	"github.com/peter-stratton/gofr/pkg/gofr/k8s"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging/remotelogger"
 	"github.com/peter-stratton/gofr/pkg/gofr/metrics"
 	"github.com/peter-stratton/gofr/pkg/gofr/metrics/exporters"
+	"github.com/peter-stratton/gofr/pkg/gofr/notification"
 	"github.com/peter-stratton/gofr/pkg/gofr/service"
 	"github.com/peter-stratton/gofr/pkg/gofr/version"
 
@@ -31,12 +46,40 @@ type Container struct {
 	appVersion string
 
 	Services       map[string]service.HTTP
+	GRPCServices   map[string]service.GRPC
 	metricsManager metrics.Manager
 	PubSub         pubsub.Client
 
-	Redis Redis
-	SQL   DB
-	Mongo datasource.Mongo
+	Redis     Redis
+	SQL       DB
+	Mongo     datasource.Mongo
+	Cassandra datasource.Cassandra
+
+	clock clock.Clock
+	// faults injects faults into the calls to the dependencies, unless it is nil.
+	faults *chaos.Injector
+
+	healthCheckTimeout time.Duration
+	customHealthChecks map[string]healthCheck
+	nonCritical        map[string]bool
+	healthCache        healthCache
+	healthMonitor      healthMonitor
+	workerPool         workerPool
+	jobs               jobs
+	sagas              sagas
+	events             events
+	idempotency        idempotency
+	quotas             quotas
+	cache              cache
+	audit              audit
+	notifications      notifications
+	pdf                pdf
+	shutdown           shutdown
+	tenancy            tenancy
+	registry           registry
+
+	waitingForDependencies atomic.Bool
+	warmingUp              atomic.Bool
 }
 
 func NewContainer(conf config.Config) *Container {
@@ -54,34 +97,162 @@ func NewContainer(conf config.Config) *Container {
 	return c
 }
 
+// Clock returns the clock of the application.
+func (c *Container) Clock() clock.Clock {
+	if c == nil || c.clock == nil {
+		return clock.New()
+	}
+
+	return c.clock
+}
+
+// FaultInjector returns the injector of the faults, which is nil unless CHAOS_ENABLED is true.
+func (c *Container) FaultInjector() *chaos.Injector {
+	if c == nil {
+		return nil
+	}
+
+	return c.faults
+}
+
+// LogOptions are the options of the loggers of the application, which label the logs with its pod on Kubernetes.
+func LogOptions() []logging.Option {
+	if pod := k8s.CurrentPod(); pod.Known() {
+		return []logging.Option{logging.WithLabels(pod.Labels())}
+	}
+
+	return nil
+}
+
 func (c *Container) Create(conf config.Config) {
-	if c.appName != "" {
+	if c.appName == "" {
 		c.appName = conf.GetOrDefault("APP_NAME", "gofr-app")
 	}
 
-	if c.appVersion != "" {
+	if c.appVersion == "" {
 		c.appVersion = conf.GetOrDefault("APP_VERSION", "dev")
 	}
 
 	if c.Logger == nil {
 		c.Logger = remotelogger.New(logging.GetLevelFromString(conf.Get("LOG_LEVEL")), conf.Get("REMOTE_LOG_URL"),
-			conf.GetOrDefault("REMOTE_LOG_FETCH_INTERVAL", "15"))
+			conf.GetOrDefault("REMOTE_LOG_FETCH_INTERVAL", "15"), LogOptions()...)
 	}
 
 	c.Debug("Container is being created")
 
-	c.metricsManager = metrics.NewMetricsManager(exporters.Prometheus(c.appName, c.appVersion), c.Logger)
+	if timeout, err := strconv.Atoi(conf.Get("HEALTH_CHECK_TIMEOUT")); err == nil && timeout > 0 {
+		c.healthCheckTimeout = time.Duration(timeout) * time.Second
+	}
+
+	if ttl, err := strconv.Atoi(conf.GetOrDefault("HEALTH_CACHE_TTL", defaultHealthCacheTTL)); err == nil && ttl > 0 {
+		c.healthCache.ttl = time.Duration(ttl) * time.Second
+	}
+
+	if interval, err := strconv.Atoi(conf.Get("HEALTH_MONITOR_INTERVAL")); err == nil && interval > 0 {
+		c.healthMonitor.interval = time.Duration(interval) * time.Second
+	}
+
+	if count, err := strconv.Atoi(conf.Get("HEALTH_DEBOUNCE_COUNT")); err == nil && count > 0 {
+		c.healthMonitor.debounceCount = count
+	}
+
+	c.jobs.backend = conf.Get("JOB_STORE")
+	c.idempotency.backend = conf.Get("IDEMPOTENCY_STORE")
+	c.quotas.backend = conf.Get("QUOTA_STORE")
+	c.cache.backend = conf.Get("CACHE_STORE")
+	c.audit.backend = conf.Get("AUDIT_SINK")
+	c.audit.topic = conf.GetOrDefault("AUDIT_TOPIC", defaultAuditTopic)
+	c.audit.file = conf.GetOrDefault("AUDIT_FILE", defaultAuditFile)
+	c.events.topic = conf.GetOrDefault("EVENTSTORE_TOPIC", DefaultEventTopic)
+
+	if grace, err := strconv.Atoi(conf.Get("JOB_GRACE_PERIOD")); err == nil && grace > 0 {
+		c.shutdown.gracePeriod = time.Duration(grace) * time.Second
+	}
+
+	c.tenancy.schemaPrefix = conf.GetOrDefault("TENANT_SCHEMA_PREFIX", defaultTenantSchemaPrefix)
+
+	c.workerPool.workers, _ = strconv.Atoi(conf.Get("BACKGROUND_WORKERS"))
+	c.workerPool.queueSize, _ = strconv.Atoi(conf.Get("BACKGROUND_QUEUE_SIZE"))
+
+	c.healthMonitor.webhookURL = conf.Get("HEALTH_WEBHOOK_URL")
+	c.healthMonitor.topic = conf.Get("HEALTH_NOTIFY_TOPIC")
+
+	for _, name := range strings.Split(conf.Get("HEALTH_NON_CRITICAL"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			c.setCritical(name, false)
+		}
+	}
+
+	// each application has a registry of its own, so that the applications of a process, like those of the tests, can
+	// all serve their metrics.
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	metricsOpts := []metrics.ManagerOption{metrics.WithRegistry(registry)}
+
+	if conf.Get("METRICS_ASYNC_HISTOGRAMS") == "true" {
+		metricsOpts = append(metricsOpts, metrics.WithAsyncHistograms())
+	}
+
+	c.metricsManager = metrics.NewMetricsManager(exporters.PrometheusRegistry(c.appName, c.appVersion, registry), c.Logger,
+		metricsOpts...)
 
 	// Register framework metrics
 	c.registerFrameworkMetrics()
 
 	// Populating an instance of app_info with the app details, the value is set as 1 to depict the no. of instances
-	c.Metrics().SetGauge("app_info", 1,
-		"app_name", c.GetAppName(), "app_version", c.GetAppVersion(), "framework_version", version.Framework)
+	// the pod of the application is added to the labels of app_info on Kubernetes.
+	info := []string{"app_name", c.GetAppName(), "app_version", c.GetAppVersion(), "framework_version", version.Framework}
+
+	for key, value := range k8s.CurrentPod().Labels() {
+		info = append(info, key, value)
+	}
+
+	c.Metrics().SetGauge("app_info", 1, info...)
+
+	faults, err := chaos.FromConfig(conf, c.metricsManager)
+	if err != nil {
+		c.Errorf("faults are not injected: %v", err)
+	} else if faults != nil {
+		c.Warnf("faults are injected into the calls to the dependencies, as CHAOS_ENABLED is true")
+	}
+
+	c.faults = faults
 
-	c.Redis = redis.NewClient(conf, c.Logger, c.metricsManager)
+	c.Redis = redis.NewClient(conf, c.Logger, c.metricsManager,
+		redis.WithBulkhead(bulkhead.New("redis", bulkhead.ConfigFrom(conf, "REDIS"), c.metricsManager)),
+		redis.WithFaultInjector(faults))
 
-	c.SQL = sql.NewSQL(conf, c.Logger, c.metricsManager)
+	notifier, err := notification.FromConfig(conf, c.Clock())
+	if err != nil {
+		c.Errorf("notifications are not sent: %v", err)
+	}
+
+	c.notifications.notifier = notifier
+
+	converter, err := export.ConverterFromConfig(conf)
+	if err != nil {
+		c.Errorf("templates are not converted to PDF: %v", err)
+	}
+
+	c.pdf.converter = converter
+
+	keyring, err := crypto.FromConfig(conf)
+	if err != nil {
+		c.Errorf("encrypted fields are not encrypted: %v", err)
+	}
+
+	c.SQL = sql.NewSQL(conf, c.Logger, c.metricsManager, sql.WithClock(c.Clock()),
+		sql.WithBulkhead(bulkhead.New("sql", bulkhead.ConfigFrom(conf, "DB"), c.metricsManager)),
+		sql.WithFaultInjector(faults), sql.WithTenantGuard(tenantGuardFrom(conf)), sql.WithKeyring(keyring))
+
+	if client := cassandra.FromConfig(conf); client != nil {
+		client.UseLogger(c.Logger)
+		client.UseMetrics(c.Metrics())
+		client.Connect()
+
+		c.Cassandra = client
+	}
 
 	switch strings.ToUpper(conf.Get("PUBSUB_BACKEND")) {
 	case "KAFKA":
@@ -102,6 +273,16 @@ func (c *Container) Create(conf config.Config) {
 				BatchTimeout:    batchTimeout,
 			}, c.Logger, c.metricsManager)
 		}
+	case "NATS":
+		if conf.Get("PUBSUB_BROKER") != "" {
+			maxWait, _ := time.ParseDuration(conf.Get("NATS_MAX_WAIT"))
+
+			c.PubSub = nats.New(nats.Config{
+				Server:          conf.Get("PUBSUB_BROKER"),
+				ConsumerGroupID: conf.Get("CONSUMER_ID"),
+				MaxWait:         maxWait,
+			}, c.Logger, c.metricsManager)
+		}
 	case "GOOGLE":
 		c.PubSub = google.New(google.Config{
 			ProjectID:        conf.Get("GOOGLE_PROJECT_ID"),
@@ -134,6 +315,15 @@ func (c *Container) Create(conf config.Config) {
 		}
 
 		c.PubSub = mqtt.New(configs, c.Logger, c.metricsManager)
+	case "MEMORY":
+		delay, _ := time.ParseDuration(conf.GetOrDefault("PUBSUB_MEMORY_DELAY", "0s"))
+		failureRate, _ := strconv.ParseFloat(conf.GetOrDefault("PUBSUB_MEMORY_FAILURE_RATE", "0"), 64)
+
+		c.PubSub = memory.New(memory.Config{
+			ConsumerGroupID: conf.Get("CONSUMER_ID"),
+			DeliveryDelay:   delay,
+			FailureRate:     failureRate,
+		}, c.Logger, c.metricsManager)
 	}
 }
 
@@ -143,6 +333,12 @@ func (c *Container) GetHTTPService(serviceName string) service.HTTP {
 	return c.Services[serviceName]
 }
 
+// GetGRPCService returns the connection to a registered gRPC service.
+// gRPC services are registered from AddGRPCService method of GoFr object.
+func (c *Container) GetGRPCService(serviceName string) service.GRPC {
+	return c.GRPCServices[serviceName]
+}
+
 func (c *Container) Metrics() metrics.Manager {
 	return c.metricsManager
 }
@@ -155,11 +351,24 @@ func (c *Container) registerFrameworkMetrics() {
 	c.Metrics().NewGauge("app_sys_total_alloc", "Number of cumulative bytes allocated for heap objects.")
 	c.Metrics().NewGauge("app_go_numGC", "Number of completed Garbage Collector cycles.")
 	c.Metrics().NewGauge("app_go_sys", "Number of total bytes of memory.")
+	c.Metrics().NewGauge("app_buffer_pool_in_use", "Number of buffers taken from the buffer pool which are not returned.")
+	c.Metrics().NewGauge("app_buffer_pool_discarded", "Number of buffers discarded by the buffer pool for their size.")
 
 	{ // HTTP metrics
 		httpBuckets := []float64{.001, .003, .005, .01, .02, .03, .05, .1, .2, .3, .5, .75, 1, 2, 3, 5, 10, 30}
 		c.Metrics().NewHistogram("app_http_response", "Response time of HTTP requests in seconds.", httpBuckets...)
 		c.Metrics().NewHistogram("app_http_service_response", "Response time of HTTP service requests in seconds.", httpBuckets...)
+		c.Metrics().NewHistogram("app_tenant_http_response", "Response time of HTTP requests of each tenant in seconds.", httpBuckets...)
+	}
+
+	{ // gRPC metrics
+		grpcBuckets := []float64{.001, .003, .005, .01, .02, .03, .05, .1, .2, .3, .5, .75, 1, 2, 3, 5, 10, 30}
+		c.Metrics().NewHistogram("app_grpc_response", "Response time of gRPC calls in seconds.", grpcBuckets...)
+		c.Metrics().NewHistogram("app_grpc_service_response", "Response time of gRPC service calls in seconds.", grpcBuckets...)
+		c.Metrics().NewHistogram("app_grpc_stream_send_wait", "Time for which sending a stream message is blocked in seconds.",
+			grpcBuckets...)
+		c.Metrics().NewCounter("app_grpc_stream_messages_sent", "Number of messages sent on gRPC streams.")
+		c.Metrics().NewCounter("app_grpc_stream_messages_received", "Number of messages received on gRPC streams.")
 	}
 
 	{ // Redis metrics
@@ -174,6 +383,32 @@ func (c *Container) registerFrameworkMetrics() {
 		c.Metrics().NewGauge("app_sql_inUse_connections", "Number of inUse SQL connections.")
 	}
 
+	{ // Cron metrics
+		cronBuckets := []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 600}
+		c.Metrics().NewHistogram("app_cron_job_duration", "Duration of cron job runs in seconds.", cronBuckets...)
+		c.Metrics().NewCounter("app_cron_job_failures", "Number of failed cron job runs.")
+	}
+
+	{ // Background task metrics
+		taskBuckets := []float64{.001, .005, .01, .05, .1, .5, 1, 5, 10, 30, 60}
+		c.Metrics().NewHistogram("app_background_task_duration", "Duration of background tasks in seconds.", taskBuckets...)
+		c.Metrics().NewCounter("app_background_task_failures", "Number of failed background tasks.")
+		c.Metrics().NewCounter("app_background_task_dropped", "Number of background tasks dropped as the queue was full.")
+	}
+
+	{ // Job metrics
+		jobBuckets := []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 600}
+		c.Metrics().NewHistogram("app_job_duration", "Duration of job runs in seconds.", jobBuckets...)
+		c.Metrics().NewCounter("app_job_failures", "Number of failed job runs.")
+		c.Metrics().NewCounter("app_job_dead", "Number of jobs moved to the DEAD state after exhausting their attempts.")
+	}
+
+	c.Metrics().NewHistogram("app_warmup_duration", "Duration of the warmers run on startup in seconds.",
+		.01, .05, .1, .5, 1, 5, 10, 30, 60)
+
+	c.Metrics().NewCounter(bulkhead.RejectedMetric, "Number of calls to the dependencies rejected by their bulkhead.")
+	c.Metrics().NewCounter(chaos.InjectedMetric, "Number of faults injected into the calls to the dependencies.")
+
 	// pubsub metrics
 	c.Metrics().NewCounter("app_pubsub_publish_total_count", "Number of total publish operations.")
 	c.Metrics().NewCounter("app_pubsub_publish_success_count", "Number of successful publish operations.")
@@ -1,11 +1,20 @@
 package container
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/cassandra"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub/mqtt"
 	gofrRedis "github.com/peter-stratton/gofr/pkg/gofr/datasource/redis"
 	gofrSql "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
@@ -59,6 +68,21 @@ func Test_newContainerPubSubInitializationFail(t *testing.T) {
 	}
 }
 
+func Test_newContainerCassandra(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{
+		"CASSANDRA_HOSTS": "localhost", "CASSANDRA_PORT": "2", "CASSANDRA_KEYSPACE": "orders",
+	}))
+
+	client, ok := c.Cassandra.(*cassandra.Client)
+	require.True(t, ok, "TEST, Failed.\ncassandra is not configured from CASSANDRA_HOSTS")
+
+	health := client.HealthCheck(context.Background())
+
+	assert.Equal(t, datasource.StatusDown, health.Status)
+	assert.Equal(t, "orders", health.Details["keyspace"])
+	assert.Nil(t, NewContainer(config.NewMockConfig(nil)).Cassandra, "TEST, Failed.\ncassandra is configured without CASSANDRA_HOSTS")
+}
+
 func TestContainer_MQTTInitialization_Default(t *testing.T) {
 	configs := map[string]string{
 		"PUBSUB_BACKEND": "MQTT",
@@ -72,6 +96,38 @@ func TestContainer_MQTTInitialization_Default(t *testing.T) {
 	assert.NotNil(t, m.Client)
 }
 
+func TestContainer_MemoryPubSubInitialization(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{
+		"PUBSUB_BACKEND":      "memory",
+		"CONSUMER_ID":         "orders",
+		"PUBSUB_MEMORY_DELAY": "10ms",
+	}))
+
+	require.NotNil(t, c.PubSub)
+
+	health := c.PubSub.Health()
+
+	assert.Equal(t, "MEMORY", health.Details["backend"])
+	assert.Equal(t, "orders", health.Details["consumer_group"])
+}
+
+func TestContainer_NATSPubSubInitialization(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{
+		"PUBSUB_BACKEND": "NATS",
+		"PUBSUB_BROKER":  "nats://localhost:1",
+		"CONSUMER_ID":    "orders",
+	}))
+
+	require.NotNil(t, c.PubSub)
+
+	health := c.PubSub.Health()
+
+	assert.Equal(t, "NATS", health.Details["backend"])
+	assert.Equal(t, datasource.StatusDown, health.Status)
+	assert.Nil(t, NewContainer(config.NewMockConfig(map[string]string{"PUBSUB_BACKEND": "NATS"})).PubSub,
+		"TEST, Failed.\nnats is configured without PUBSUB_BROKER")
+}
+
 func TestContainer_GetHTTPService(t *testing.T) {
 	svc := service.NewHTTPService("", nil, nil)
 
@@ -150,3 +206,75 @@ func TestContainer_newContainerWithNilConfig(t *testing.T) {
 	assert.Nil(t, container.PubSub, "%s", failureMsg)
 	assert.Nil(t, container.Logger, "%s", failureMsg)
 }
+
+func Test_newContainerHealthCheckTimeout(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{"HEALTH_CHECK_TIMEOUT": "5"}))
+	assert.Equal(t, 5*time.Second, c.healthCheckTimeout)
+
+	c = NewContainer(config.NewMockConfig(map[string]string{"HEALTH_CHECK_TIMEOUT": "invalid"}))
+	assert.Equal(t, time.Duration(0), c.healthCheckTimeout)
+}
+
+func Test_newContainerHealthNonCritical(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{"HEALTH_NON_CRITICAL": "redis, payment-service,"}))
+
+	assert.Equal(t, map[string]bool{"redis": true, "payment-service": true}, c.nonCritical)
+}
+
+func Test_newContainerHealthCacheTTL(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{}))
+	assert.Equal(t, 5*time.Second, c.healthCache.ttl)
+
+	c = NewContainer(config.NewMockConfig(map[string]string{"HEALTH_CACHE_TTL": "0"}))
+	assert.Equal(t, time.Duration(0), c.healthCache.ttl)
+}
+
+func TestMockPubSub(t *testing.T) {
+	m := &MockPubSub{}
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "publish")
+	defer span.End()
+
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	require.NoError(t, m.Publish(ctx, "orders", []byte("1")))
+	require.NoError(t, m.Publish(context.Background(), "invoices", []byte("2")))
+
+	assert.Len(t, m.Published(""), 2)
+	assert.Equal(t, "orders", m.Published("orders")[0].Topic)
+	assert.Contains(t, m.Published("orders")[0].Headers["traceparent"], span.SpanContext().TraceID().String(),
+		"trace context of the publisher should be recorded as headers")
+
+	committed := m.Inject("orders", []byte("3"), map[string]string{"traceparent": m.Published("orders")[0].Headers["traceparent"]})
+
+	msg, err := m.Subscribe(context.Background(), "orders")
+	require.NoError(t, err)
+
+	assert.Equal(t, "3", string(msg.Value))
+	assert.Equal(t, span.SpanContext().TraceID(), trace.SpanContextFromContext(msg.Context()).TraceID())
+
+	msg.Commit()
+	msg.Commit()
+
+	_, open := <-committed
+	assert.False(t, open, "committed channel should be closed on commit")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	msg, err = m.Subscribe(ctx, "orders")
+
+	assert.Nil(t, msg)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLogOptions(t *testing.T) {
+	t.Setenv("POD_NAME", "")
+
+	assert.Empty(t, LogOptions())
+
+	t.Setenv("POD_NAME", "orders-5d8f7")
+
+	assert.Len(t, LogOptions(), 1)
+}
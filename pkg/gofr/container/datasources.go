@@ -3,6 +3,7 @@ package container
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 
@@ -19,7 +20,11 @@ type DB interface {
 	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
 	Prepare(query string) (*sql.Stmt, error)
 	Begin() (*gofrSQL.Tx, error)
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*gofrSQL.Tx, error)
 	Select(ctx context.Context, data interface{}, query string, args ...interface{})
+	NamedExec(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	UpdateWithVersion(ctx context.Context, update *gofrSQL.UpdateBuilder, version int64) (int64, error)
+	SoftDelete(ctx context.Context, table, condition string, args ...interface{}) (int64, error)
 	HealthCheck() *datasource.Health
 	Dialect() string
 }
@@ -27,5 +32,22 @@ type DB interface {
 type Redis interface {
 	redis.Cmdable
 	redis.HashCmdable
+	TypedRedis
 	HealthCheck() datasource.Health
 }
+
+// TypedRedis provides helpers over the primitive Redis commands which marshal and unmarshal values
+// as JSON or struct fields.
+type TypedRedis interface {
+	SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	GetInto(ctx context.Context, key string, dest interface{}) error
+	HSetStruct(ctx context.Context, key string, value interface{}) error
+	HGetAllInto(ctx context.Context, key string, dest interface{}) error
+	LPushJSON(ctx context.Context, key string, values ...interface{}) error
+	RPushJSON(ctx context.Context, key string, values ...interface{}) error
+	LPopInto(ctx context.Context, key string, dest interface{}) error
+	RPopInto(ctx context.Context, key string, dest interface{}) error
+	LRangeInto(ctx context.Context, key string, start, stop int64, dest interface{}) error
+	SAddJSON(ctx context.Context, key string, members ...interface{}) error
+	SMembersInto(ctx context.Context, key string, dest interface{}) error
+}
@@ -0,0 +1,108 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// AnyVersion is the expected version of Append which appends the events whatever the version of the aggregate is.
+	AnyVersion = -1
+
+	// DefaultEventTopic is the topic to which the appended events are published, unless EVENTSTORE_TOPIC is set.
+	DefaultEventTopic = "gofr-events"
+)
+
+var (
+	// ErrVersionConflict is returned by Append when the version of the aggregate is not the expected one.
+	ErrVersionConflict = errors.New("aggregate version conflict")
+
+	errEventStoreNotConfigured = errors.New("event store not configured, sql is required")
+)
+
+// Event is an event of an aggregate, like an order.
+type Event struct {
+	AggregateID string          `json:"aggregateId"`
+	Version     int64           `json:"version"`
+	Type        string          `json:"type"`
+	Data        json.RawMessage `json:"data"`
+	RecordedAt  time.Time       `json:"recordedAt"`
+}
+
+// NewEvent returns an event of the type, with the data marshalled as JSON, to be appended using Append.
+func NewEvent(eventType string, data interface{}) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{Type: eventType, Data: raw}, nil
+}
+
+// Bind unmarshals the data of the event into v.
+func (e Event) Bind(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// EventStore is an append-only store of the events of the aggregates.
+type EventStore interface {
+	// Append appends the events to the aggregate if its version is expectedVersion, and publishes them.
+	Append(ctx context.Context, aggregateID string, expectedVersion int64, events ...Event) ([]Event, error)
+	// Read returns the events of the aggregate after the version fromVersion, in the order of their versions.
+	Read(ctx context.Context, aggregateID string, fromVersion int64) ([]Event, error)
+	// SaveSnapshot saves the state of the aggregate at the version.
+	SaveSnapshot(ctx context.Context, aggregateID string, version int64, state interface{}) error
+	// LoadSnapshot loads the last snapshot of the aggregate into state, and returns its version, or 0.
+	LoadSnapshot(ctx context.Context, aggregateID string, state interface{}) (int64, error)
+}
+
+type events struct {
+	mu    sync.Mutex
+	topic string
+	store EventStore
+}
+
+// EventStore returns the store of the events of the aggregates, which are published to EVENTSTORE_TOPIC.
+func (c *Container) EventStore() (EventStore, error) {
+	c.events.mu.Lock()
+	defer c.events.mu.Unlock()
+
+	if c.events.store != nil {
+		return c.events.store, nil
+	}
+
+	if isNil(c.SQL) {
+		return nil, errEventStoreNotConfigured
+	}
+
+	topic := c.events.topic
+	if topic == "" {
+		topic = DefaultEventTopic
+	}
+
+	c.events.store = &sqlEventStore{db: c.SQL, container: c, topic: topic}
+
+	return c.events.store, nil
+}
+
+// publishEvents publishes the appended events to the event topic, logging the failures.
+func (c *Container) publishEvents(ctx context.Context, topic string, events []Event) {
+	if isNil(c.PubSub) {
+		return
+	}
+
+	for _, e := range events {
+		msg, err := json.Marshal(e)
+		if err == nil {
+			err = c.PubSub.Publish(ctx, topic, msg)
+		}
+
+		if err != nil {
+			c.Errorf("could not publish event %s of version %d of aggregate %s, error: %v", e.Type, e.Version,
+				e.AggregateID, err)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package container
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+const (
+	createSQLEventsTable = `CREATE TABLE IF NOT EXISTS gofr_events (
+    aggregate_id VARCHAR(255) not null,
+    version BIGINT not null,
+    type VARCHAR(255) not null,
+    data TEXT not null,
+    recorded_at BIGINT not null,
+    primary key (aggregate_id, version)
+);`
+
+	createSQLSnapshotsTable = `CREATE TABLE IF NOT EXISTS gofr_snapshots (
+    aggregate_id VARCHAR(255) not null primary key,
+    version BIGINT not null,
+    state TEXT not null,
+    created_at BIGINT not null
+);`
+
+	selectSQLAggregateVersion = `SELECT COALESCE(MAX(version), 0) FROM gofr_events WHERE aggregate_id = ?;`
+	insertSQLEvent            = `INSERT INTO gofr_events (aggregate_id, version, type, data, recorded_at) ` +
+		`VALUES (?, ?, ?, ?, ?);`
+	selectSQLEvents = `SELECT aggregate_id, version, type, data, recorded_at FROM gofr_events ` +
+		`WHERE aggregate_id = ? AND version > ? ORDER BY version;`
+
+	deleteSQLSnapshot = `DELETE FROM gofr_snapshots WHERE aggregate_id = ?;`
+	insertSQLSnapshot = `INSERT INTO gofr_snapshots (aggregate_id, version, state, created_at) VALUES (?, ?, ?, ?);`
+	selectSQLSnapshot = `SELECT version, state FROM gofr_snapshots WHERE aggregate_id = ?;`
+)
+
+// sqlEventStore keeps the events in the gofr_events table, and the snapshots in the gofr_snapshots table.
+type sqlEventStore struct {
+	db        DB
+	container *Container
+	topic     string
+
+	schema gofrSQL.Schema
+}
+
+func (s *sqlEventStore) Append(ctx context.Context, aggregateID string, expectedVersion int64,
+	events ...Event) ([]Event, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	appended, err := s.append(ctx, tx, aggregateID, expectedVersion, events)
+	if err != nil {
+		_ = tx.Rollback()
+
+		// the insert of an event appended concurrently with the same version fails, which is a conflict.
+		if !errors.Is(err, ErrVersionConflict) && expectedVersion != AnyVersion {
+			if version, vErr := s.version(ctx, s.db, aggregateID); vErr == nil && version != expectedVersion {
+				return nil, fmt.Errorf("%w: expected version %d, found %d", ErrVersionConflict, expectedVersion, version)
+			}
+		}
+
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.container.publishEvents(ctx, s.topic, appended)
+
+	return appended, nil
+}
+
+type sqlQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func (s *sqlEventStore) append(ctx context.Context, tx sqlQuerier, aggregateID string, expectedVersion int64,
+	events []Event) ([]Event, error) {
+	version, err := s.version(ctx, tx, aggregateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if expectedVersion != AnyVersion && version != expectedVersion {
+		return nil, fmt.Errorf("%w: expected version %d, found %d", ErrVersionConflict, expectedVersion, version)
+	}
+
+	now := time.UnixMilli(time.Now().UnixMilli())
+	appended := make([]Event, len(events))
+
+	for i, e := range events {
+		version++
+
+		e.AggregateID = aggregateID
+		e.Version = version
+		e.RecordedAt = now
+
+		if _, err := tx.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), insertSQLEvent), e.AggregateID, e.Version, e.Type, string(e.Data),
+			e.RecordedAt.UnixMilli()); err != nil {
+			return nil, err
+		}
+
+		appended[i] = e
+	}
+
+	return appended, nil
+}
+
+func (s *sqlEventStore) version(ctx context.Context, q sqlQuerier, aggregateID string) (int64, error) {
+	var version int64
+
+	err := q.QueryRowContext(ctx, gofrSQL.Rebind(s.db.Dialect(), selectSQLAggregateVersion), aggregateID).Scan(&version)
+
+	return version, err
+}
+
+func (s *sqlEventStore) Read(ctx context.Context, aggregateID string, fromVersion int64) ([]Event, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, gofrSQL.Rebind(s.db.Dialect(), selectSQLEvents), aggregateID, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := make([]Event, 0)
+
+	for rows.Next() {
+		var (
+			e          Event
+			data       string
+			recordedAt int64
+		)
+
+		if err := rows.Scan(&e.AggregateID, &e.Version, &e.Type, &data, &recordedAt); err != nil {
+			return nil, err
+		}
+
+		e.Data = json.RawMessage(data)
+		e.RecordedAt = time.UnixMilli(recordedAt)
+
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+func (s *sqlEventStore) SaveSnapshot(ctx context.Context, aggregateID string, version int64, state interface{}) error {
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), deleteSQLSnapshot), aggregateID); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), insertSQLSnapshot), aggregateID, version, string(data),
+		time.Now().UnixMilli()); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqlEventStore) LoadSnapshot(ctx context.Context, aggregateID string, state interface{}) (int64, error) {
+	if err := s.migrate(ctx); err != nil {
+		return 0, err
+	}
+
+	var (
+		version int64
+		data    string
+	)
+
+	err := s.db.QueryRowContext(ctx, gofrSQL.Rebind(s.db.Dialect(), selectSQLSnapshot), aggregateID).Scan(&version, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return version, json.Unmarshal([]byte(data), state)
+}
+
+func (s *sqlEventStore) migrate(ctx context.Context) error {
+	return s.schema.Create(ctx, s.db, createSQLEventsTable, createSQLSnapshotsTable)
+}
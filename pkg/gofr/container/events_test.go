@@ -0,0 +1,123 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+func newEventsContainer(t *testing.T) *Container {
+	t.Helper()
+
+	return NewContainer(config.NewMockConfig(map[string]string{
+		"DB_DIALECT":       "sqlite",
+		"DB_NAME":          filepath.Join(t.TempDir(), "events"),
+		"PUBSUB_BACKEND":   "MEMORY",
+		"CONSUMER_ID":      t.Name(),
+		"EVENTSTORE_TOPIC": t.Name(),
+	}))
+}
+
+func mustEvent(t *testing.T, eventType string, data interface{}) Event {
+	t.Helper()
+
+	e, err := NewEvent(eventType, data)
+	require.NoError(t, err)
+
+	return e
+}
+
+func TestEventStore_Append(t *testing.T) {
+	c := newEventsContainer(t)
+	ctx := context.Background()
+
+	store, err := c.EventStore()
+	require.NoError(t, err)
+
+	appended, err := store.Append(ctx, "order-1", 0,
+		mustEvent(t, "OrderPlaced", map[string]int{"amount": 10}), mustEvent(t, "OrderPaid", nil))
+	require.NoError(t, err)
+	require.Len(t, appended, 2)
+
+	assert.Equal(t, int64(2), appended[1].Version)
+	assert.Equal(t, "order-1", appended[1].AggregateID)
+
+	testCases := []struct {
+		desc            string
+		expectedVersion int64
+		err             error
+	}{
+		{"stale version", 1, ErrVersionConflict},
+		{"new aggregate version", 0, ErrVersionConflict},
+		{"current version", 2, nil},
+		{"any version", AnyVersion, nil},
+	}
+
+	for i, tc := range testCases {
+		_, err := store.Append(ctx, "order-1", tc.expectedVersion, mustEvent(t, "OrderShipped", nil))
+
+		assert.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	events, err := store.Read(ctx, "order-1", 1)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, "OrderPaid", events[0].Type)
+	assert.Equal(t, int64(4), events[2].Version)
+
+	var placed map[string]int
+
+	first, err := store.Read(ctx, "order-1", 0)
+	require.NoError(t, err)
+	require.NoError(t, first[0].Bind(&placed))
+	assert.Equal(t, 10, placed["amount"])
+
+	// the appended events are published, for the projections.
+	subCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	msg, err := c.PubSub.Subscribe(subCtx, t.Name())
+	require.NoError(t, err)
+
+	var published Event
+
+	require.NoError(t, json.Unmarshal(msg.Value, &published))
+	assert.Equal(t, "OrderPlaced", published.Type)
+	assert.Equal(t, int64(1), published.Version)
+}
+
+func TestEventStore_Snapshots(t *testing.T) {
+	store, err := newEventsContainer(t).EventStore()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	var state map[string]int
+
+	version, err := store.LoadSnapshot(ctx, "order-1", &state)
+	require.NoError(t, err)
+	assert.Zero(t, version)
+	assert.Nil(t, state)
+
+	require.NoError(t, store.SaveSnapshot(ctx, "order-1", 5, map[string]int{"amount": 10}))
+	require.NoError(t, store.SaveSnapshot(ctx, "order-1", 8, map[string]int{"amount": 30}))
+
+	version, err = store.LoadSnapshot(ctx, "order-1", &state)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), version)
+	assert.Equal(t, map[string]int{"amount": 30}, state)
+}
+
+func TestContainer_EventStoreNotConfigured(t *testing.T) {
+	_, err := NewContainer(config.NewMockConfig(nil)).EventStore()
+
+	assert.Equal(t, errEventStoreNotConfigured, err)
+}
@@ -2,29 +2,265 @@ package container
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/service"
+)
+
+const (
+	defaultHealthCheckTimeout = 2 * time.Second
+
+	// defaultHealthCacheTTL is the number of seconds for which the aggregated health is served from cache.
+	defaultHealthCacheTTL = "5"
 )
 
+// Readiness is the aggregated health of the dependencies of the application.
+type Readiness struct {
+	Status string                 `json:"status"`
+	Checks map[string]interface{} `json:"checks"`
+}
+
+// Unhealthy returns the names of the dependencies which are not UP, in sorted order.
+func (r Readiness) Unhealthy() []string {
+	names := make([]string, 0)
+
+	for name, h := range r.Checks {
+		if healthStatus(h) != datasource.StatusUp {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 func (c *Container) Health(ctx context.Context) interface{} {
-	datasources := make(map[string]interface{})
+	return c.healthChecks(ctx)
+}
+
+// Ready checks the health of all the dependencies. It is DOWN if a critical one is not UP, and DEGRADED otherwise.
+func (c *Container) Ready(ctx context.Context) Readiness {
+	r := Readiness{
+		Status: datasource.StatusUp,
+		Checks: c.healthChecks(ctx),
+	}
+
+	if c.waitingForDependencies.Load() || c.warmingUp.Load() {
+		r.Status = datasource.StatusDown
+
+		return r
+	}
+
+	for _, name := range r.Unhealthy() {
+		if c.nonCritical[name] {
+			r.Status = datasource.StatusDegraded
+
+			continue
+		}
+
+		r.Status = datasource.StatusDown
+
+		break
+	}
+
+	return r
+}
+
+// AddHealthCheck registers a custom health check, whose failure only degrades the application unless it is critical.
+func (c *Container) AddHealthCheck(name string, check func(ctx context.Context) datasource.Health, critical bool) {
+	if c.customHealthChecks == nil {
+		c.customHealthChecks = make(map[string]healthCheck)
+	}
+
+	c.customHealthChecks[name] = func(ctx context.Context) interface{} { return check(ctx) }
+
+	c.setCritical(name, critical)
+}
+
+func (c *Container) setCritical(name string, critical bool) {
+	if c.nonCritical == nil {
+		c.nonCritical = make(map[string]bool)
+	}
+
+	if critical {
+		delete(c.nonCritical, name)
+
+		return
+	}
+
+	c.nonCritical[name] = true
+}
+
+type healthCheck func(ctx context.Context) interface{}
+
+// healthCache holds the last aggregated health results, so that frequent probes do not query every dependency.
+type healthCache struct {
+	mu sync.Mutex
+
+	ttl       time.Duration
+	results   map[string]interface{}
+	checkedAt time.Time
+}
+
+// healthChecks returns the health of the dependencies, cached for the configured TTL.
+func (c *Container) healthChecks(ctx context.Context) map[string]interface{} {
+	if c.healthCache.ttl <= 0 {
+		return c.runHealthChecks(ctx)
+	}
+
+	// the lock is held while the checks run, for a single round of checks.
+	c.healthCache.mu.Lock()
+	defer c.healthCache.mu.Unlock()
+
+	if c.healthCache.results == nil || c.Clock().Since(c.healthCache.checkedAt) >= c.healthCache.ttl {
+		c.healthCache.results = c.runHealthChecks(ctx)
+		c.healthCache.checkedAt = c.Clock().Now()
+	}
+
+	results := make(map[string]interface{}, len(c.healthCache.results))
+	for name, h := range c.healthCache.results {
+		results[name] = h
+	}
+
+	return results
+}
+
+// runHealthChecks runs the health checks concurrently.
+func (c *Container) runHealthChecks(ctx context.Context) map[string]interface{} {
+	checks := c.registeredHealthChecks()
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+
+		results = make(map[string]interface{}, len(checks))
+	)
+
+	for name, check := range checks {
+		wg.Add(1)
+
+		go func(name string, check healthCheck) {
+			defer wg.Done()
+
+			h := c.runHealthCheck(ctx, check)
+
+			mu.Lock()
+			results[name] = h
+			mu.Unlock()
+		}(name, check)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func (c *Container) registeredHealthChecks() map[string]healthCheck {
+	checks := make(map[string]healthCheck)
 
 	if !isNil(c.SQL) {
-		datasources["sql"] = c.SQL.HealthCheck()
+		checks["sql"] = func(context.Context) interface{} { return c.SQL.HealthCheck() }
 	}
 
 	if !isNil(c.Redis) {
-		datasources["redis"] = c.Redis.HealthCheck()
+		checks["redis"] = func(context.Context) interface{} { return c.Redis.HealthCheck() }
+	}
+
+	if m, ok := c.Mongo.(interface{ HealthCheck() interface{} }); ok && !isNil(c.Mongo) {
+		checks["mongo"] = func(context.Context) interface{} { return m.HealthCheck() }
+	}
+
+	if cs, ok := c.Cassandra.(interface {
+		HealthCheck(ctx context.Context) datasource.Health
+	}); ok && !isNil(c.Cassandra) {
+		checks["cassandra"] = func(ctx context.Context) interface{} { return cs.HealthCheck(ctx) }
 	}
 
 	if c.PubSub != nil {
-		datasources["pubsub"] = c.PubSub.Health()
+		checks["pubsub"] = func(context.Context) interface{} { return c.PubSub.Health() }
 	}
 
 	for name, svc := range c.Services {
-		datasources[name] = svc.HealthCheck(ctx)
+		svc := svc
+
+		checks[name] = func(ctx context.Context) interface{} { return svc.HealthCheck(ctx) }
+	}
+
+	for name, svc := range c.GRPCServices {
+		svc := svc
+
+		checks[name] = func(ctx context.Context) interface{} { return svc.HealthCheck(ctx) }
+	}
+
+	for name, ds := range c.externalDatasources() {
+		ds := ds
+
+		checks[name] = func(ctx context.Context) interface{} { return ds.HealthCheck(ctx) }
+	}
+
+	for name, check := range c.customHealthChecks {
+		checks[name] = check
+	}
+
+	return checks
+}
+
+// runHealthCheck runs the check with the configured timeout, reporting the TIMEOUT status if it does not complete in time.
+func (c *Container) runHealthCheck(ctx context.Context, check healthCheck) interface{} {
+	timeout := c.healthCheckTimeout
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
 	}
 
-	return datasources
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// result is buffered so that the goroutine of a timed out check is not blocked forever.
+	result := make(chan interface{}, 1)
+
+	go func() {
+		result <- check(ctx)
+	}()
+
+	select {
+	case h := <-result:
+		return h
+	case <-ctx.Done():
+		return datasource.Health{
+			Status:  datasource.StatusTimeout,
+			Details: map[string]interface{}{"error": fmt.Sprintf("health check did not complete within %v", timeout)},
+		}
+	}
+}
+
+// healthStatus returns the status from the different health types reported by datasources and services.
+func healthStatus(h interface{}) string {
+	switch v := h.(type) {
+	case datasource.Health:
+		return v.Status
+	case *datasource.Health:
+		return v.Status
+	case *service.Health:
+		return v.Status
+	default:
+		// the health of the datasources of the other modules, like mongo, is a struct of their own with a Status.
+		val := reflect.Indirect(reflect.ValueOf(h))
+		if val.Kind() != reflect.Struct {
+			return ""
+		}
+
+		if status := val.FieldByName("Status"); status.Kind() == reflect.String {
+			return status.String()
+		}
+
+		return ""
+	}
 }
 
 func isNil(i interface{}) bool {
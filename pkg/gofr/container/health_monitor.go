@@ -0,0 +1,190 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+const (
+	defaultHealthMonitorInterval = 10 * time.Second
+	defaultHealthDebounceCount   = 2
+	healthWebhookTimeout         = 5 * time.Second
+)
+
+// HealthChange describes a dependency whose health transitioned between UP and DOWN.
+type HealthChange struct {
+	Dependency string      `json:"dependency"`
+	From       string      `json:"from"`
+	To         string      `json:"to"`
+	Health     interface{} `json:"health"`
+	Time       time.Time   `json:"time"`
+}
+
+// HealthChangeHandler is called whenever a dependency transitions between UP and DOWN.
+type HealthChangeHandler func(ctx context.Context, change HealthChange)
+
+// healthMonitor reports the transitions of the status of each dependency, once observed debounceCount times.
+type healthMonitor struct {
+	interval      time.Duration
+	debounceCount int
+	webhookURL    string
+	topic         string
+	handlers      []HealthChangeHandler
+
+	statuses map[string]string
+	counts   map[string]int
+}
+
+// OnHealthChange registers a handler of the transitions of the dependencies between UP and DOWN.
+func (c *Container) OnHealthChange(handler HealthChangeHandler) {
+	c.healthMonitor.handlers = append(c.healthMonitor.handlers, handler)
+}
+
+// MonitorHealth checks the health of the dependencies and notifies the transitions until ctx is done.
+func (c *Container) MonitorHealth(ctx context.Context) {
+	handlers := c.healthChangeHandlers()
+	if len(handlers) == 0 {
+		return
+	}
+
+	interval := c.healthMonitor.interval
+	if interval <= 0 {
+		interval = defaultHealthMonitorInterval
+	}
+
+	ticker := c.Clock().NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, change := range c.healthMonitor.observe(c.runHealthChecks(ctx), c.Clock().Now()) {
+			c.Warnf("health of %s changed from %s to %s", change.Dependency, change.From, change.To)
+
+			for _, h := range handlers {
+				h(ctx, change)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+func (c *Container) healthChangeHandlers() []HealthChangeHandler {
+	handlers := c.healthMonitor.handlers
+
+	if c.healthMonitor.webhookURL != "" {
+		handlers = append(handlers, c.healthWebhookHandler(c.healthMonitor.webhookURL))
+	}
+
+	if c.healthMonitor.topic != "" && c.PubSub != nil {
+		handlers = append(handlers, c.healthPublishHandler(c.healthMonitor.topic))
+	}
+
+	return handlers
+}
+
+// healthWebhookHandler posts each change as JSON to the given URL.
+func (c *Container) healthWebhookHandler(url string) HealthChangeHandler {
+	client := &http.Client{Timeout: healthWebhookTimeout}
+
+	return func(ctx context.Context, change HealthChange) {
+		body, err := json.Marshal(change)
+		if err != nil {
+			c.Errorf("could not marshal health change of %s, error: %v", change.Dependency, err)
+
+			return
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			c.Errorf("could not create health webhook request, error: %v", err)
+
+			return
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			c.Errorf("could not send health change of %s to webhook, error: %v", change.Dependency, err)
+
+			return
+		}
+
+		resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			c.Errorf("health webhook responded with status code %d", resp.StatusCode)
+		}
+	}
+}
+
+// healthPublishHandler publishes each change as JSON on the given pubsub topic.
+func (c *Container) healthPublishHandler(topic string) HealthChangeHandler {
+	return func(ctx context.Context, change HealthChange) {
+		body, err := json.Marshal(change)
+		if err != nil {
+			c.Errorf("could not marshal health change of %s, error: %v", change.Dependency, err)
+
+			return
+		}
+
+		if err := c.PubSub.Publish(ctx, topic, body); err != nil {
+			c.Errorf("could not publish health change of %s, error: %v", change.Dependency, err)
+		}
+	}
+}
+
+// observe records the results of a round of checks and returns the debounced transitions.
+func (m *healthMonitor) observe(results map[string]interface{}, now time.Time) []HealthChange {
+	if m.statuses == nil {
+		m.statuses = make(map[string]string)
+		m.counts = make(map[string]int)
+	}
+
+	debounceCount := m.debounceCount
+	if debounceCount <= 0 {
+		debounceCount = defaultHealthDebounceCount
+	}
+
+	changes := make([]HealthChange, 0)
+
+	for name, h := range results {
+		status := datasource.StatusUp
+		if healthStatus(h) != datasource.StatusUp {
+			status = datasource.StatusDown
+		}
+
+		current, ok := m.statuses[name]
+
+		switch {
+		case !ok:
+			m.statuses[name] = status
+		case status == current:
+			delete(m.counts, name)
+		default:
+			// the status is either UP or DOWN, so consecutive differing observations are all the same transition.
+			m.counts[name]++
+
+			if m.counts[name] < debounceCount {
+				continue
+			}
+
+			m.statuses[name] = status
+
+			delete(m.counts, name)
+
+			changes = append(changes, HealthChange{Dependency: name, From: current, To: status, Health: h, Time: now})
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,98 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+func TestHealthMonitor_observe(t *testing.T) {
+	m := healthMonitor{debounceCount: 2}
+	now := time.Now()
+
+	up := map[string]interface{}{"sql": datasource.Health{Status: datasource.StatusUp}}
+	down := map[string]interface{}{"sql": &datasource.Health{Status: datasource.StatusTimeout}}
+
+	testCases := []struct {
+		desc     string
+		results  map[string]interface{}
+		expected []HealthChange
+	}{
+		{"baseline is not reported", up, []HealthChange{}},
+		{"first failure is debounced", down, []HealthChange{}},
+		{"recovery resets the debounce", up, []HealthChange{}},
+		{"failure is debounced again", down, []HealthChange{}},
+		{"consecutive failure is reported", down,
+			[]HealthChange{{Dependency: "sql", From: "UP", To: "DOWN", Health: down["sql"], Time: now}}},
+		{"unchanged status is not reported", down, []HealthChange{}},
+		{"recovery is debounced", up, []HealthChange{}},
+		{"consecutive recovery is reported", up,
+			[]HealthChange{{Dependency: "sql", From: "DOWN", To: "UP", Health: up["sql"], Time: now}}},
+	}
+
+	for i, tc := range testCases {
+		changes := m.observe(tc.results, now)
+
+		assert.Equal(t, tc.expected, changes, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestContainer_MonitorHealth(t *testing.T) {
+	received := make(chan HealthChange, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var change HealthChange
+
+		_ = json.NewDecoder(r.Body).Decode(&change)
+
+		received <- change
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c, mocks := NewMockContainer(t)
+	c.healthMonitor = healthMonitor{interval: 10 * time.Millisecond, debounceCount: 1, webhookURL: srv.URL}
+
+	var handled []HealthChange
+
+	c.OnHealthChange(func(_ context.Context, change HealthChange) {
+		handled = append(handled, change)
+	})
+
+	mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp}).AnyTimes()
+
+	first := mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusUp}).Times(1)
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusDown}).After(first).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go c.MonitorHealth(ctx)
+
+	select {
+	case change := <-received:
+		assert.Equal(t, "redis", change.Dependency)
+		assert.Equal(t, "UP", change.From)
+		assert.Equal(t, "DOWN", change.To)
+	case <-time.After(time.Second):
+		t.Fatal("TestContainer_MonitorHealth Failed! health change not sent to the webhook")
+	}
+
+	cancel()
+
+	assert.Len(t, handled, 1)
+}
+
+func TestContainer_MonitorHealth_NoHandlers(t *testing.T) {
+	c, _ := NewMockContainer(t)
+
+	// returns without checking the health, as nothing is interested in the changes
+	c.MonitorHealth(context.Background())
+}
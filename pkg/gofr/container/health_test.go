@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -53,6 +54,7 @@ func TestContainer_Health(t *testing.T) {
 				"host": strings.TrimPrefix(srv.URL, "http://"),
 			},
 		},
+		"pubsub": datasource.Health{Status: "UP"},
 	}
 
 	c, mocks := NewMockContainer(t)
@@ -90,3 +92,159 @@ func TestContainer_Health(t *testing.T) {
 
 	assert.Equal(t, expected, healthData)
 }
+
+func TestContainer_Ready(t *testing.T) {
+	c, mocks := NewMockContainer(t)
+
+	mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp}).Times(2)
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusUp})
+
+	ready := c.Ready(context.Background())
+
+	assert.Equal(t, datasource.StatusUp, ready.Status)
+	assert.Empty(t, ready.Unhealthy())
+	assert.Len(t, ready.Checks, 3)
+
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusDown})
+
+	c.Services = map[string]service.HTTP{
+		"test-service": service.NewHTTPService("http://localhost:0", logging.NewMockLogger(logging.ERROR), nil),
+	}
+
+	ready = c.Ready(context.Background())
+
+	assert.Equal(t, datasource.StatusDown, ready.Status)
+	assert.Equal(t, []string{"redis", "test-service"}, ready.Unhealthy())
+}
+
+func TestContainer_Ready_Critical(t *testing.T) {
+	c, mocks := NewMockContainer(t)
+
+	status := datasource.StatusUp
+
+	c.setCritical("redis", false)
+	c.AddHealthCheck("search", func(context.Context) datasource.Health {
+		return datasource.Health{Status: status}
+	}, true)
+
+	testCases := []struct {
+		desc        string
+		redis       string
+		search      string
+		expectedRes string
+	}{
+		{"all checks up", datasource.StatusUp, datasource.StatusUp, datasource.StatusUp},
+		{"non-critical check down", datasource.StatusDown, datasource.StatusUp, datasource.StatusDegraded},
+		{"critical check down", datasource.StatusUp, datasource.StatusDown, datasource.StatusDown},
+		{"both checks down", datasource.StatusDown, datasource.StatusDown, datasource.StatusDown},
+	}
+
+	for i, tc := range testCases {
+		status = tc.search
+
+		mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp})
+		mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: tc.redis})
+
+		ready := c.Ready(context.Background())
+
+		assert.Equal(t, tc.expectedRes, ready.Status, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Contains(t, ready.Checks, "search", "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+// mongoHealth is the health of a datasource of another module, like the one of the mongo client.
+type mongoHealth struct {
+	Status string
+}
+
+type mockMongo struct {
+	datasource.Mongo
+
+	status string
+}
+
+func (m *mockMongo) HealthCheck() interface{} {
+	return &mongoHealth{Status: m.status}
+}
+
+func TestContainer_Ready_Mongo(t *testing.T) {
+	for i, status := range []string{datasource.StatusUp, datasource.StatusDown} {
+		c, mocks := NewMockContainer(t)
+
+		c.Mongo = &mockMongo{status: status}
+
+		mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp})
+		mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusUp})
+
+		ready := c.Ready(context.Background())
+
+		assert.Equal(t, &mongoHealth{Status: status}, ready.Checks["mongo"], "TEST[%d], Failed.\n%s", i, status)
+		assert.Equal(t, status, ready.Status, "TEST[%d], Failed.\n%s", i, status)
+	}
+}
+
+func TestContainer_Health_Cache(t *testing.T) {
+	c, mocks := NewMockContainer(t, WithFakeClock())
+	c.healthCache.ttl = 100 * time.Millisecond
+
+	mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp}).Times(2)
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusUp}).Times(2)
+
+	first := c.Health(context.Background())
+
+	// served from the cache, the datasources are not checked again
+	assert.Equal(t, first, c.Health(context.Background()))
+	assert.Equal(t, datasource.StatusUp, c.Ready(context.Background()).Status)
+
+	mocks.Clock.Advance(99 * time.Millisecond)
+	assert.Equal(t, first, c.Health(context.Background()))
+
+	mocks.Clock.Advance(time.Millisecond)
+	assert.Equal(t, first, c.Health(context.Background()))
+}
+
+type slowHTTPService struct {
+	service.HTTP
+}
+
+func (s slowHTTPService) HealthCheck(ctx context.Context) *service.Health {
+	<-ctx.Done()
+
+	time.Sleep(10 * time.Millisecond)
+
+	return &service.Health{Status: "UP"}
+}
+
+func TestContainer_Health_Timeout(t *testing.T) {
+	c, mocks := NewMockContainer(t)
+	c.healthCheckTimeout = 50 * time.Millisecond
+
+	c.Services = map[string]service.HTTP{
+		"slow-1": slowHTTPService{},
+		"slow-2": slowHTTPService{},
+	}
+
+	mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp})
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusUp})
+
+	start := time.Now()
+	health := c.Health(context.Background()).(map[string]interface{})
+
+	// checks run concurrently, so the slow services time out together
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	expectedTimeout := datasource.Health{
+		Status:  datasource.StatusTimeout,
+		Details: map[string]interface{}{"error": "health check did not complete within 50ms"},
+	}
+
+	assert.Equal(t, expectedTimeout, health["slow-1"])
+	assert.Equal(t, expectedTimeout, health["slow-2"])
+	assert.Equal(t, &datasource.Health{Status: datasource.StatusUp}, health["sql"])
+	assert.Equal(t, datasource.Health{Status: datasource.StatusUp}, health["redis"])
+
+	mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp})
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusUp})
+
+	assert.Equal(t, []string{"slow-1", "slow-2"}, c.Ready(context.Background()).Unhealthy())
+}
@@ -0,0 +1,50 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+var errIdempotencyStoreNotConfigured = errors.New("idempotency store not configured, either redis or sql is required")
+
+// IdempotencyStore persists the records of the requests with an idempotency key.
+type IdempotencyStore interface {
+	// Reserve stores the record for the key until the ttl, or returns the record stored for it already.
+	Reserve(ctx context.Context, key string, record []byte, ttl time.Duration) ([]byte, error)
+	// Save replaces the record of the key, which is kept until the ttl.
+	Save(ctx context.Context, key string, record []byte, ttl time.Duration) error
+	// Release removes the record of the key.
+	Release(ctx context.Context, key string) error
+}
+
+type idempotency struct {
+	mu      sync.Mutex
+	backend string
+	store   IdempotencyStore
+}
+
+// IdempotencyStore returns the store chosen by IDEMPOTENCY_STORE, which is Redis if configured, or SQL.
+func (c *Container) IdempotencyStore() (IdempotencyStore, error) {
+	c.idempotency.mu.Lock()
+	defer c.idempotency.mu.Unlock()
+
+	if c.idempotency.store != nil {
+		return c.idempotency.store, nil
+	}
+
+	backend := strings.ToLower(c.idempotency.backend)
+
+	switch {
+	case (backend == "" || backend == "redis") && !isNil(c.Redis):
+		c.idempotency.store = &redisIdempotencyStore{redis: c.Redis}
+	case (backend == "" || backend == "sql") && !isNil(c.SQL):
+		c.idempotency.store = &sqlIdempotencyStore{db: c.SQL}
+	default:
+		return nil, errIdempotencyStoreNotConfigured
+	}
+
+	return c.idempotency.store, nil
+}
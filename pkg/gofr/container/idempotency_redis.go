@@ -0,0 +1,45 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisIdempotencyPrefix = "gofr:idempotency:"
+
+// redisIdempotencyStore keeps each record as a string expiring after its ttl.
+type redisIdempotencyStore struct {
+	redis Redis
+}
+
+func (r *redisIdempotencyStore) Reserve(ctx context.Context, key string, record []byte, ttl time.Duration) ([]byte, error) {
+	for {
+		ok, err := r.redis.SetNX(ctx, redisIdempotencyPrefix+key, record, ttl).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			return nil, nil
+		}
+
+		existing, err := r.redis.Get(ctx, redisIdempotencyPrefix+key).Bytes()
+		// the record expired since it was found, so the key is reserved again.
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+
+		return existing, err
+	}
+}
+
+func (r *redisIdempotencyStore) Save(ctx context.Context, key string, record []byte, ttl time.Duration) error {
+	return r.redis.Set(ctx, redisIdempotencyPrefix+key, record, ttl).Err()
+}
+
+func (r *redisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return r.redis.Del(ctx, redisIdempotencyPrefix+key).Err()
+}
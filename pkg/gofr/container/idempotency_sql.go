@@ -0,0 +1,88 @@
+package container
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+const (
+	createSQLIdempotencyTable = `CREATE TABLE IF NOT EXISTS gofr_idempotency (
+    idempotency_key VARCHAR(255) not null primary key,
+    record TEXT not null,
+    expires_at BIGINT not null
+);`
+
+	insertSQLIdempotency = `INSERT INTO gofr_idempotency (idempotency_key, record, expires_at) VALUES (?, ?, ?);`
+	selectSQLIdempotency = `SELECT record FROM gofr_idempotency WHERE idempotency_key = ? AND expires_at > ?;`
+	updateSQLIdempotency = `UPDATE gofr_idempotency SET record = ?, expires_at = ? WHERE idempotency_key = ?;`
+	deleteSQLIdempotency = `DELETE FROM gofr_idempotency WHERE idempotency_key = ?;`
+
+	// the expired records are removed when their key is reserved again.
+	deleteExpiredSQLIdempotency = `DELETE FROM gofr_idempotency WHERE idempotency_key = ? AND expires_at <= ?;`
+)
+
+// sqlIdempotencyStore keeps the records in the gofr_idempotency table.
+type sqlIdempotencyStore struct {
+	db DB
+
+	schema gofrSQL.Schema
+}
+
+func (s *sqlIdempotencyStore) Reserve(ctx context.Context, key string, record []byte, ttl time.Duration) ([]byte, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if _, err := s.db.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), deleteExpiredSQLIdempotency), key, now.UnixMilli()); err != nil {
+		return nil, err
+	}
+
+	_, insertErr := s.db.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), insertSQLIdempotency), key, string(record), now.Add(ttl).UnixMilli())
+	if insertErr == nil {
+		return nil, nil
+	}
+
+	// the insert fails if the key is reserved already, whose record is returned then.
+	var existing string
+
+	err := s.db.QueryRowContext(ctx, gofrSQL.Rebind(s.db.Dialect(), selectSQLIdempotency), key, now.UnixMilli()).Scan(&existing)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, insertErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(existing), nil
+}
+
+func (s *sqlIdempotencyStore) Save(ctx context.Context, key string, record []byte, ttl time.Duration) error {
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), updateSQLIdempotency), string(record), time.Now().Add(ttl).UnixMilli(), key)
+
+	return err
+}
+
+func (s *sqlIdempotencyStore) Release(ctx context.Context, key string) error {
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), deleteSQLIdempotency), key)
+
+	return err
+}
+
+func (s *sqlIdempotencyStore) migrate(ctx context.Context) error {
+	return s.schema.Create(ctx, s.db, createSQLIdempotencyTable)
+}
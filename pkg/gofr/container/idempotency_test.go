@@ -0,0 +1,78 @@
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyStores(t *testing.T) {
+	containers := map[string]func(t *testing.T) *Container{
+		"redis": newRedisJobsContainer,
+		"sql":   newSQLJobsContainer,
+	}
+
+	for name, newContainer := range containers {
+		t.Run(name, func(t *testing.T) {
+			testIdempotencyStore(t, newContainer(t))
+		})
+	}
+}
+
+func testIdempotencyStore(t *testing.T, c *Container) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	store, err := c.IdempotencyStore()
+	require.NoError(t, err)
+
+	existing, err := store.Reserve(ctx, "key", []byte("in-flight"), time.Hour)
+	require.NoError(t, err)
+	assert.Nil(t, existing, "the key should be reserved")
+
+	existing, err = store.Reserve(ctx, "key", []byte("other"), time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "in-flight", string(existing))
+
+	require.NoError(t, store.Save(ctx, "key", []byte("completed"), time.Hour))
+
+	existing, err = store.Reserve(ctx, "key", []byte("other"), time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", string(existing))
+
+	require.NoError(t, store.Release(ctx, "key"))
+
+	existing, err = store.Reserve(ctx, "key", []byte("retried"), time.Millisecond)
+	require.NoError(t, err)
+	assert.Nil(t, existing, "the released key should be reserved again")
+
+	// miniredis expires the keys only when its time is fast-forwarded, so the expiry is checked for SQL only.
+	if _, ok := store.(*sqlIdempotencyStore); ok {
+		time.Sleep(5 * time.Millisecond)
+
+		existing, err = store.Reserve(ctx, "key", []byte("expired"), time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, existing, "the expired key should be reserved again")
+	}
+}
+
+func TestContainer_IdempotencyStore(t *testing.T) {
+	_, err := (&Container{}).IdempotencyStore()
+	assert.Equal(t, errIdempotencyStoreNotConfigured, err)
+
+	c := newSQLJobsContainer(t)
+	c.idempotency.backend = "redis"
+
+	_, err = c.IdempotencyStore()
+	assert.Equal(t, errIdempotencyStoreNotConfigured, err, "redis should not be used when not configured")
+
+	c.idempotency.backend = "SQL"
+
+	store, err := c.IdempotencyStore()
+	require.NoError(t, err)
+	assert.IsType(t, &sqlIdempotencyStore{}, store)
+}
@@ -0,0 +1,138 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of the jobs enqueued using EnqueueJob.
+const (
+	JobPending = "PENDING"
+	JobRunning = "RUNNING"
+	JobDead    = "DEAD"
+)
+
+const defaultJobMaxAttempts = 3
+
+var errJobStoreNotConfigured = errors.New("job store not configured, either redis or sql is required")
+
+// Job is a unit of work persisted in the job store, which is run by the handler registered for its name.
+type Job struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"maxAttempts"`
+	RunAt       time.Time       `json:"runAt"`
+	LastError   string          `json:"lastError,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+// JobOptions configure when and how many times an enqueued job is run.
+type JobOptions struct {
+	// Delay postpones the job by the given duration. It is ignored if RunAt is set.
+	Delay time.Duration
+	// RunAt schedules the job to run at the given time.
+	RunAt time.Time
+	// MaxAttempts is the number of times the job is attempted before it is moved to the DEAD state, 3 by default.
+	MaxAttempts int
+}
+
+// JobStore persists the jobs, so that they survive restarts of the application and are run by only one instance.
+type JobStore interface {
+	// Enqueue persists a new job in the PENDING state.
+	Enqueue(ctx context.Context, job *Job) error
+	// Claim marks a due job RUNNING until the lease expires. It returns nil if no job is due.
+	Claim(ctx context.Context, lease time.Duration) (*Job, error)
+	// Complete removes a successfully run job.
+	Complete(ctx context.Context, job *Job) error
+	// Retry moves a failed job back to the PENDING state, to be run again at runAt.
+	Retry(ctx context.Context, job *Job, runAt time.Time, cause error) error
+	// Bury moves a job which has exhausted its attempts to the DEAD state.
+	Bury(ctx context.Context, job *Job, cause error) error
+	// List returns up to limit jobs in the given state, ordered by the time they are due.
+	List(ctx context.Context, status string, limit int) ([]Job, error)
+}
+
+// EnqueueJob persists a job run in the background by the handler of the name, and returns its ID. The job is stored in
+// the store chosen by JOB_STORE, which is Redis if configured, or SQL.
+func (c *Container) EnqueueJob(ctx context.Context, name string, payload interface{}, opts ...JobOptions) (string, error) {
+	store, err := c.JobStore()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	now := c.Clock().Now()
+
+	job := &Job{
+		ID:          uuid.NewString(),
+		Name:        name,
+		Payload:     data,
+		Status:      JobPending,
+		MaxAttempts: defaultJobMaxAttempts,
+		RunAt:       now,
+		CreatedAt:   now,
+	}
+
+	for _, o := range opts {
+		switch {
+		case !o.RunAt.IsZero():
+			job.RunAt = o.RunAt
+		case o.Delay > 0:
+			job.RunAt = now.Add(o.Delay)
+		}
+
+		if o.MaxAttempts > 0 {
+			job.MaxAttempts = o.MaxAttempts
+		}
+	}
+
+	if err := store.Enqueue(ctx, job); err != nil {
+		return "", err
+	}
+
+	c.Debugf("enqueued job %s with ID %s to run at %v", name, job.ID, job.RunAt)
+
+	return job.ID, nil
+}
+
+type jobs struct {
+	mu      sync.Mutex
+	backend string
+	store   JobStore
+}
+
+// JobStore returns the store in which the jobs enqueued using EnqueueJob are persisted.
+func (c *Container) JobStore() (JobStore, error) {
+	c.jobs.mu.Lock()
+	defer c.jobs.mu.Unlock()
+
+	if c.jobs.store != nil {
+		return c.jobs.store, nil
+	}
+
+	backend := strings.ToLower(c.jobs.backend)
+
+	switch {
+	case (backend == "" || backend == "redis") && !isNil(c.Redis):
+		c.jobs.store = &redisJobStore{redis: c.Redis, clock: c.Clock()}
+	case (backend == "" || backend == "sql") && !isNil(c.SQL):
+		c.jobs.store = &sqlJobStore{db: c.SQL, clock: c.Clock()}
+	default:
+		return nil, errJobStoreNotConfigured
+	}
+
+	return c.jobs.store, nil
+}
@@ -0,0 +1,188 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+const (
+	redisJobQueueKey = "gofr:jobs:queue"
+	redisJobDeadKey  = "gofr:jobs:dead"
+	redisJobPrefix   = "gofr:jobs:job:"
+
+	redisJobListBatch = 100
+
+	// claimRedisJobScript atomically pushes the score of the earliest due job to the expiry of its lease.
+	claimRedisJobScript = `local ids = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, 1)
+if #ids == 0 then
+	return false
+end
+redis.call("ZADD", KEYS[1], ARGV[2], ids[1])
+return ids[1]`
+)
+
+// redisJobStore keeps the jobs as JSON, in sorted sets scored by the time they are due, or died.
+type redisJobStore struct {
+	redis Redis
+	clock clock.Clock
+}
+
+func (r *redisJobStore) Enqueue(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.redis.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, redisJobPrefix+job.ID, data, 0)
+		p.ZAdd(ctx, redisJobQueueKey, redis.Z{Score: float64(job.RunAt.UnixMilli()), Member: job.ID})
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *redisJobStore) Claim(ctx context.Context, lease time.Duration) (*Job, error) {
+	now := r.clock.Now()
+
+	id, err := r.redis.Eval(ctx, claimRedisJobScript, []string{redisJobQueueKey},
+		now.UnixMilli(), now.Add(lease).UnixMilli()).Text()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := r.get(ctx, id)
+	if errors.Is(err, redis.Nil) {
+		// the job was removed while it was queued, so it is dropped from the queue as well.
+		return nil, r.redis.ZRem(ctx, redisJobQueueKey, id).Err()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	job.Status = JobRunning
+	job.Attempts++
+
+	return job, r.set(ctx, job)
+}
+
+func (r *redisJobStore) Complete(ctx context.Context, job *Job) error {
+	_, err := r.redis.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.ZRem(ctx, redisJobQueueKey, job.ID)
+		p.Del(ctx, redisJobPrefix+job.ID)
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *redisJobStore) Retry(ctx context.Context, job *Job, runAt time.Time, cause error) error {
+	job.Status = JobPending
+	job.RunAt = runAt
+	job.LastError = cause.Error()
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.redis.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, redisJobPrefix+job.ID, data, 0)
+		p.ZAdd(ctx, redisJobQueueKey, redis.Z{Score: float64(runAt.UnixMilli()), Member: job.ID})
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *redisJobStore) Bury(ctx context.Context, job *Job, cause error) error {
+	job.Status = JobDead
+	job.LastError = cause.Error()
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.redis.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, redisJobPrefix+job.ID, data, 0)
+		p.ZRem(ctx, redisJobQueueKey, job.ID)
+		p.ZAdd(ctx, redisJobDeadKey, redis.Z{Score: float64(r.clock.Now().UnixMilli()), Member: job.ID})
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *redisJobStore) List(ctx context.Context, status string, limit int) ([]Job, error) {
+	key := redisJobQueueKey
+	if status == JobDead {
+		key = redisJobDeadKey
+	}
+
+	jobs := make([]Job, 0)
+
+	// PENDING and RUNNING jobs share the queue, so it is read in batches until enough jobs in the state are found.
+	for start := int64(0); len(jobs) < limit; start += redisJobListBatch {
+		ids, err := r.redis.ZRange(ctx, key, start, start+redisJobListBatch-1).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			job, err := r.get(ctx, id)
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			if job.Status == status && len(jobs) < limit {
+				jobs = append(jobs, *job)
+			}
+		}
+
+		if len(ids) < redisJobListBatch {
+			break
+		}
+	}
+
+	return jobs, nil
+}
+
+func (r *redisJobStore) get(ctx context.Context, id string) (*Job, error) {
+	data, err := r.redis.Get(ctx, redisJobPrefix+id).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var job Job
+
+	return &job, json.Unmarshal(data, &job)
+}
+
+func (r *redisJobStore) set(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	return r.redis.Set(ctx, redisJobPrefix+job.ID, data, 0).Err()
+}
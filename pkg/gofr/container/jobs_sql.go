@@ -0,0 +1,210 @@
+package container
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+const (
+	createSQLJobsTable = `CREATE TABLE IF NOT EXISTS gofr_jobs (
+    id VARCHAR(36) not null primary key,
+    name VARCHAR(255) not null,
+    payload TEXT not null,
+    status VARCHAR(16) not null,
+    attempts INT not null,
+    max_attempts INT not null,
+    run_at BIGINT not null,
+    last_error TEXT not null,
+    created_at BIGINT not null
+);`
+
+	sqlJobColumns = `id, name, payload, status, attempts, max_attempts, run_at, last_error, created_at`
+
+	insertSQLJob = `INSERT INTO gofr_jobs (` + sqlJobColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	// the RUNNING jobs are due once their lease, which is stored as their run_at, expires.
+	selectDueSQLJob = `SELECT ` + sqlJobColumns + ` FROM gofr_jobs WHERE status IN (?, ?) AND run_at <= ? ORDER BY run_at`
+
+	// claimSQLJob only succeeds if the job has not been claimed by another instance since it was selected.
+	claimSQLJob = `UPDATE gofr_jobs SET status = ?, attempts = attempts + 1, run_at = ? WHERE id = ? AND status = ? AND run_at = ?;`
+
+	updateSQLJob  = `UPDATE gofr_jobs SET status = ?, run_at = ?, last_error = ? WHERE id = ?;`
+	deleteSQLJob  = `DELETE FROM gofr_jobs WHERE id = ?;`
+	selectSQLJobs = `SELECT ` + sqlJobColumns + ` FROM gofr_jobs WHERE status = ? ORDER BY run_at`
+)
+
+// skipLockedJobs is the lock of the job selected by Claim, in the dialects which can skip the locked rows.
+var skipLockedJobs = map[string]string{
+	"mysql":    " FOR UPDATE SKIP LOCKED",
+	"postgres": " FOR UPDATE SKIP LOCKED",
+}
+
+// sqlExecutor runs the queries of the store, on the DB or in a transaction.
+type sqlExecutor interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// sqlJobStore keeps the jobs in the gofr_jobs table.
+type sqlJobStore struct {
+	db    DB
+	clock clock.Clock
+
+	schema gofrSQL.Schema
+}
+
+func (s *sqlJobStore) Enqueue(ctx context.Context, job *Job) error {
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, s.query(insertSQLJob), job.ID, job.Name, string(job.Payload), job.Status, job.Attempts,
+		job.MaxAttempts, job.RunAt.UnixMilli(), job.LastError, job.CreatedAt.UnixMilli())
+
+	return err
+}
+
+func (s *sqlJobStore) Claim(ctx context.Context, lease time.Duration) (*Job, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	lock, ok := skipLockedJobs[s.db.Dialect()]
+	if !ok {
+		return s.claim(ctx, s.db, "", lease)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback() //nolint:errcheck // the transaction is rolled back unless it is committed.
+
+	job, err := s.claim(ctx, tx, lock, lease)
+	if err != nil || job == nil {
+		return nil, err
+	}
+
+	return job, tx.Commit()
+}
+
+// claim claims the oldest due job, selected with the lock.
+func (s *sqlJobStore) claim(ctx context.Context, db sqlExecutor, lock string, lease time.Duration) (*Job, error) {
+	now := s.clock.Now()
+
+	job, err := scanSQLJob(db.QueryRowContext(ctx, s.query(selectDueSQLJob+gofrSQL.Limit(s.db.Dialect())+lock), JobPending, JobRunning,
+		now.UnixMilli(), 1))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	runAt := now.Add(lease)
+
+	res, err := db.ExecContext(ctx, s.query(claimSQLJob), JobRunning, runAt.UnixMilli(), job.ID, job.Status, job.RunAt.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n != 1 {
+		// the job has been claimed by another instance, it is left to the next poll to claim a different one.
+		return nil, err
+	}
+
+	job.Status = JobRunning
+	job.Attempts++
+	job.RunAt = time.UnixMilli(runAt.UnixMilli())
+
+	return job, nil
+}
+
+func (s *sqlJobStore) Complete(ctx context.Context, job *Job) error {
+	_, err := s.db.ExecContext(ctx, s.query(deleteSQLJob), job.ID)
+
+	return err
+}
+
+func (s *sqlJobStore) Retry(ctx context.Context, job *Job, runAt time.Time, cause error) error {
+	job.Status = JobPending
+	job.RunAt = runAt
+	job.LastError = cause.Error()
+
+	_, err := s.db.ExecContext(ctx, s.query(updateSQLJob), job.Status, runAt.UnixMilli(), job.LastError, job.ID)
+
+	return err
+}
+
+func (s *sqlJobStore) Bury(ctx context.Context, job *Job, cause error) error {
+	job.Status = JobDead
+	job.LastError = cause.Error()
+
+	_, err := s.db.ExecContext(ctx, s.query(updateSQLJob), job.Status, s.clock.Now().UnixMilli(), job.LastError, job.ID)
+
+	return err
+}
+
+func (s *sqlJobStore) List(ctx context.Context, status string, limit int) ([]Job, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.query(selectSQLJobs+gofrSQL.Limit(s.db.Dialect())), status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	jobs := make([]Job, 0)
+
+	for rows.Next() {
+		job, err := scanSQLJob(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, rows.Err()
+}
+
+func (s *sqlJobStore) migrate(ctx context.Context) error {
+	return s.schema.Create(ctx, s.db, createSQLJobsTable)
+}
+
+func (s *sqlJobStore) query(q string) string {
+	return gofrSQL.Rebind(s.db.Dialect(), q)
+}
+
+type sqlJobScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSQLJob(row sqlJobScanner) (*Job, error) {
+	var (
+		job              Job
+		payload          string
+		runAt, createdAt int64
+	)
+
+	err := row.Scan(&job.ID, &job.Name, &payload, &job.Status, &job.Attempts, &job.MaxAttempts, &runAt, &job.LastError, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Payload = []byte(payload)
+	job.RunAt = time.UnixMilli(runAt)
+	job.CreatedAt = time.UnixMilli(createdAt)
+
+	return &job, nil
+}
@@ -0,0 +1,309 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	gofrSql "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+var errJobFailed = errors.New("job failed")
+
+func newRedisJobsContainer(t *testing.T) *Container {
+	t.Helper()
+
+	s, err := miniredis.Run()
+	assert.NoError(t, err)
+
+	t.Cleanup(s.Close)
+
+	return NewContainer(config.NewMockConfig(map[string]string{
+		"REDIS_HOST": s.Host(),
+		"REDIS_PORT": s.Port(),
+	}))
+}
+
+func newSQLJobsContainer(t *testing.T) *Container {
+	t.Helper()
+
+	return NewContainer(config.NewMockConfig(map[string]string{
+		"DB_DIALECT": "sqlite",
+		"DB_NAME":    filepath.Join(t.TempDir(), "jobs"),
+	}))
+}
+
+func TestJobStores(t *testing.T) {
+	containers := map[string]func(t *testing.T) *Container{
+		"redis": newRedisJobsContainer,
+		"sql":   newSQLJobsContainer,
+	}
+
+	for name, newContainer := range containers {
+		t.Run(name, func(t *testing.T) {
+			testJobStore(t, newContainer(t))
+		})
+	}
+}
+
+func testJobStore(t *testing.T, c *Container) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	store, err := c.JobStore()
+	assert.NoError(t, err)
+
+	id, err := c.EnqueueJob(ctx, "send-email", map[string]string{"to": "a@b.c"}, JobOptions{MaxAttempts: 2})
+	assert.NoError(t, err)
+
+	_, err = c.EnqueueJob(ctx, "send-email", "later", JobOptions{Delay: time.Hour})
+	assert.NoError(t, err)
+
+	pending, err := store.List(ctx, JobPending, 10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	job, err := store.Claim(ctx, time.Minute)
+	assert.NoError(t, err)
+
+	if !assert.NotNil(t, job) {
+		return
+	}
+
+	assert.Equal(t, id, job.ID)
+	assert.Equal(t, JobRunning, job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.Equal(t, 2, job.MaxAttempts)
+	assert.JSONEq(t, `{"to":"a@b.c"}`, string(job.Payload))
+
+	// the claimed job is leased and the other one is not due yet
+	next, err := store.Claim(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+
+	running, err := store.List(ctx, JobRunning, 10)
+	assert.NoError(t, err)
+	assert.Len(t, running, 1)
+
+	assert.NoError(t, store.Retry(ctx, job, time.Now(), errJobFailed))
+
+	job, err = store.Claim(ctx, time.Minute)
+	assert.NoError(t, err)
+
+	if !assert.NotNil(t, job) {
+		return
+	}
+
+	assert.Equal(t, 2, job.Attempts)
+	assert.Equal(t, errJobFailed.Error(), job.LastError)
+
+	assert.NoError(t, store.Bury(ctx, job, errJobFailed))
+
+	dead, err := store.List(ctx, JobDead, 10)
+	assert.NoError(t, err)
+
+	if assert.Len(t, dead, 1) {
+		assert.Equal(t, id, dead[0].ID)
+		assert.Equal(t, 2, dead[0].Attempts)
+	}
+
+	pending, err = store.List(ctx, JobPending, 10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+
+	assert.NoError(t, store.Complete(ctx, &pending[0]))
+
+	pending, err = store.List(ctx, JobPending, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestJobStore_ExpiredLeaseIsClaimedAgain(t *testing.T) {
+	c := newSQLJobsContainer(t)
+	ctx := context.Background()
+
+	_, err := c.EnqueueJob(ctx, "job", nil)
+	assert.NoError(t, err)
+
+	store, _ := c.JobStore()
+
+	job, err := store.Claim(ctx, -time.Second)
+	assert.NoError(t, err)
+	assert.NotNil(t, job)
+
+	job, err = store.Claim(ctx, time.Minute)
+	assert.NoError(t, err)
+
+	if assert.NotNil(t, job) {
+		assert.Equal(t, 2, job.Attempts)
+	}
+}
+
+func TestJobStore_FakeClock(t *testing.T) {
+	c := newSQLJobsContainer(t)
+	ctx := context.Background()
+
+	fake := clock.NewFake(time.Now())
+	c.clock = fake
+
+	_, err := c.EnqueueJob(ctx, "job", nil, JobOptions{Delay: time.Hour})
+	assert.NoError(t, err)
+
+	store, _ := c.JobStore()
+
+	job, err := store.Claim(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+
+	fake.Advance(time.Hour)
+
+	job, err = store.Claim(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.NotNil(t, job)
+}
+
+func TestContainer_EnqueueJob_RunAt(t *testing.T) {
+	c := newRedisJobsContainer(t)
+	ctx := context.Background()
+	runAt := time.Now().Add(time.Minute).Truncate(time.Millisecond)
+
+	_, err := c.EnqueueJob(ctx, "job", nil, JobOptions{RunAt: runAt, Delay: time.Hour})
+	assert.NoError(t, err)
+
+	store, _ := c.JobStore()
+
+	jobs, err := store.List(ctx, JobPending, 1)
+	assert.NoError(t, err)
+
+	if assert.Len(t, jobs, 1) {
+		assert.True(t, runAt.Equal(jobs[0].RunAt), "RunAt should take precedence over Delay")
+		assert.Equal(t, defaultJobMaxAttempts, jobs[0].MaxAttempts)
+	}
+}
+
+func TestContainer_JobStore(t *testing.T) {
+	c := &Container{}
+
+	_, err := c.EnqueueJob(context.Background(), "job", nil)
+	assert.Equal(t, errJobStoreNotConfigured, err)
+
+	c = newSQLJobsContainer(t)
+	c.jobs.backend = "redis"
+
+	_, err = c.JobStore()
+	assert.Equal(t, errJobStoreNotConfigured, err, "redis should not be used when not configured")
+
+	c.jobs.backend = "SQL"
+
+	store, err := c.JobStore()
+	assert.NoError(t, err)
+	assert.IsType(t, &sqlJobStore{}, store)
+}
+
+func TestSQLJobStore_query(t *testing.T) {
+	mysql, _, _ := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "mysql"})
+	postgres, _, _ := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "postgres"})
+
+	assert.Equal(t, updateSQLJob, (&sqlJobStore{db: mysql}).query(updateSQLJob))
+	assert.Equal(t, `UPDATE gofr_jobs SET status = $1, run_at = $2, last_error = $3 WHERE id = $4;`,
+		(&sqlJobStore{db: postgres}).query(updateSQLJob))
+}
+
+func TestSQLJobStore_ClaimSkipsLocked(t *testing.T) {
+	db, mock, mockMetrics := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "postgres"})
+	defer db.Close()
+
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", gomock.Any(), "hostname", gomock.Any(),
+		"database", gomock.Any(), "type", gomock.Any()).AnyTimes()
+
+	store := &sqlJobStore{db: db, clock: clock.New()}
+
+	mock.ExpectExec(createSQLJobsTable).WillReturnError(errJobFailed)
+
+	_, err := store.Claim(context.Background(), time.Minute)
+	assert.Equal(t, errJobFailed, err)
+
+	mock.ExpectExec(createSQLJobsTable).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectQuery(gofrSql.Rebind("postgres", selectDueSQLJob+" LIMIT ? FOR UPDATE SKIP LOCKED")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "payload", "status", "attempts", "max_attempts", "run_at",
+			"last_error", "created_at"}).AddRow("job-1", "send-email", "{}", JobPending, 0, 3, 1000, "", 1000))
+	mock.ExpectExec(gofrSql.Rebind("postgres", claimSQLJob)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	job, err := store.Claim(context.Background(), time.Minute)
+	assert.NoError(t, err)
+
+	if assert.NotNil(t, job) {
+		assert.Equal(t, "job-1", job.ID)
+		assert.Equal(t, JobRunning, job.Status)
+		assert.Equal(t, 1, job.Attempts)
+	}
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLJobStore_ClaimMSSQL(t *testing.T) {
+	db, mock, mockMetrics := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "mssql"})
+	defer db.Close()
+
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", gomock.Any(), "hostname", gomock.Any(),
+		"database", gomock.Any(), "type", gomock.Any()).AnyTimes()
+
+	store := &sqlJobStore{db: db, clock: clock.New()}
+
+	mock.ExpectExec(gofrSql.CreateTable("mssql", createSQLJobsTable)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(gofrSql.Rebind("mssql", selectDueSQLJob+" OFFSET 0 ROWS FETCH NEXT ? ROWS ONLY")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	job, err := store.Claim(context.Background(), time.Minute)
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLStores_ClickHouse(t *testing.T) {
+	db, mock, _ := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "clickhouse"})
+	defer db.Close()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		desc string
+		run  func() error
+	}{
+		{"jobs", func() error { return (&sqlJobStore{db: db}).Enqueue(ctx, &Job{ID: "job-1"}) }},
+		{"idempotency", func() error {
+			_, err := (&sqlIdempotencyStore{db: db}).Reserve(ctx, "key", nil, time.Minute)
+			return err
+		}},
+		{"quotas", func() error {
+			_, err := (&sqlQuotaStore{db: db}).Increment(ctx, "key", time.Now())
+			return err
+		}},
+		{"sagas", func() error { return (&sqlSagaStore{db: db}).Create(ctx, &Saga{ID: "saga-1"}) }},
+		{"audit", func() error { return (&sqlAuditSink{db: db}).Write(ctx, &AuditEntry{ID: "entry-1"}) }},
+		{"events", func() error {
+			_, err := (&sqlEventStore{db: db}).Read(ctx, "order-1", 0)
+			return err
+		}},
+	}
+
+	for i, tc := range tests {
+		require.ErrorIs(t, tc.run(), gofrSql.ErrUnsupportedDialect, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
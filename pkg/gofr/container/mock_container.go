@@ -2,21 +2,51 @@ package container
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/mock/gomock"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/notification"
 )
 
+// mockPubSubBuffer is the number of the messages which can be injected for a topic before they are subscribed.
+const mockPubSubBuffer = 100
+
 type Mocks struct {
-	Redis *MockRedis
-	SQL   *MockDB
+	Redis     *MockRedis
+	SQL       *MockDB
+	Mongo     *MockMongo
+	Cassandra *MockCassandra
+	PubSub    *MockPubSub
+	// SMS and Push record the notifications sent with the notifier of the container.
+	SMS  *notification.MockProvider
+	Push *notification.MockProvider
+	// Clock is the fake clock of the container, which is set using WithFakeClock.
+	Clock *clock.Fake
 }
 
-func NewMockContainer(t *testing.T) (*Container, Mocks) {
+// MockOption configures the container created using NewMockContainer.
+type MockOption func(c *Container, mocks *Mocks)
+
+// WithFakeClock makes the container use a fake clock, returned as Mocks.Clock, which starts at the current time and
+// only moves when it is advanced. The retries, cron schedules and cache TTLs of the container are then tested
+// deterministically, without waiting for the time to pass.
+func WithFakeClock() MockOption {
+	return func(c *Container, mocks *Mocks) {
+		mocks.Clock = clock.NewFake(time.Now())
+		c.clock = mocks.Clock
+	}
+}
+
+func NewMockContainer(t *testing.T, opts ...MockOption) (*Container, Mocks) {
 	container := &Container{}
 	container.Logger = logging.NewLogger(logging.DEBUG)
 
@@ -26,12 +56,44 @@ func NewMockContainer(t *testing.T) (*Container, Mocks) {
 	redisMock := NewMockRedis(gomock.NewController(t))
 	container.Redis = redisMock
 
-	mocks := Mocks{Redis: redisMock, SQL: sqlMock}
+	mongoMock := NewMockMongo(gomock.NewController(t))
+	container.Mongo = mongoMock
+
+	cassandraMock := NewMockCassandra(gomock.NewController(t))
+	container.Cassandra = cassandraMock
+
+	pubsubMock := &MockPubSub{}
+	container.PubSub = pubsubMock
+
+	smsMock, pushMock := notification.NewMockProvider(notification.SMS), notification.NewMockProvider(notification.Push)
+	container.notifications.notifier = notification.New(notification.WithProvider(smsMock),
+		notification.WithProvider(pushMock))
+
+	mocks := Mocks{Redis: redisMock, SQL: sqlMock, Mongo: mongoMock, Cassandra: cassandraMock, PubSub: pubsubMock,
+		SMS: smsMock, Push: pushMock}
+
+	for _, o := range opts {
+		o(container, &mocks)
+	}
 
 	return container, mocks
 }
 
+// PublishedMessage is a message published using MockPubSub.
+type PublishedMessage struct {
+	Topic string
+	Value []byte
+	// Headers are the headers a broker would carry with the message, which is the trace context of the publisher.
+	Headers map[string]string
+}
+
+// MockPubSub is a PubSub client which does not need a broker. The messages published using it are recorded, and the
+// messages injected using Inject are returned by Subscribe, so that the subscribe handlers of the app can be tested.
+// Its zero value is ready to use.
 type MockPubSub struct {
+	mu        sync.Mutex
+	published []PublishedMessage
+	topics    map[string]chan *pubsub.Message
 }
 
 func (m *MockPubSub) CreateTopic(_ context.Context, _ string) error {
@@ -43,13 +105,87 @@ func (m *MockPubSub) DeleteTopic(_ context.Context, _ string) error {
 }
 
 func (m *MockPubSub) Health() datasource.Health {
-	return datasource.Health{}
+	return datasource.Health{Status: datasource.StatusUp}
 }
 
-func (m *MockPubSub) Publish(_ context.Context, _ string, _ []byte) error {
+func (m *MockPubSub) Publish(ctx context.Context, topic string, message []byte) error {
+	headers := make(map[string]string)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(headers))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.published = append(m.published, PublishedMessage{Topic: topic, Value: message, Headers: headers})
+
 	return nil
 }
 
-func (m *MockPubSub) Subscribe(_ context.Context, _ string) (*pubsub.Message, error) {
-	return nil, nil
+// Subscribe returns the next message injected for the topic, waiting for one until ctx is done.
+func (m *MockPubSub) Subscribe(ctx context.Context, topic string) (*pubsub.Message, error) {
+	select {
+	case msg := <-m.topic(topic):
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Inject queues a message for the subscribers of the topic, as if it was published to the broker, with headers which
+// are read using GetHeader in the subscribe handler. The returned channel is closed when the message is committed,
+// which is when the handler returns without an error.
+func (m *MockPubSub) Inject(topic string, value []byte, headers map[string]string) <-chan struct{} {
+	committed := make(chan struct{})
+
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(headers))
+
+	msg := pubsub.NewMessage(ctx)
+	msg.Topic = topic
+	msg.Value = value
+	msg.MetaData = headers
+	msg.Committer = &mockCommitter{committed: committed}
+
+	m.topic(topic) <- msg
+
+	return committed
+}
+
+// Published returns the messages published to the topic, or to all the topics if it is empty, in the order in which
+// they were published.
+func (m *MockPubSub) Published(topic string) []PublishedMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var published []PublishedMessage
+
+	for _, msg := range m.published {
+		if topic == "" || msg.Topic == topic {
+			published = append(published, msg)
+		}
+	}
+
+	return published
+}
+
+func (m *MockPubSub) topic(name string) chan *pubsub.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.topics == nil {
+		m.topics = make(map[string]chan *pubsub.Message)
+	}
+
+	if m.topics[name] == nil {
+		m.topics[name] = make(chan *pubsub.Message, mockPubSubBuffer)
+	}
+
+	return m.topics[name]
+}
+
+type mockCommitter struct {
+	once      sync.Once
+	committed chan struct{}
+}
+
+func (c *mockCommitter) Commit() {
+	c.once.Do(func() { close(c.committed) })
 }
@@ -15,10 +15,10 @@ import (
 	reflect "reflect"
 	time "time"
 
-	redis "github.com/redis/go-redis/v9"
-	gomock "go.uber.org/mock/gomock"
 	datasource "github.com/peter-stratton/gofr/pkg/gofr/datasource"
 	sql0 "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+	redis "github.com/redis/go-redis/v9"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockDB is a mock of DB interface.
@@ -59,6 +59,21 @@ func (mr *MockDBMockRecorder) Begin() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockDB)(nil).Begin))
 }
 
+// BeginTx mocks base method.
+func (m *MockDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql0.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx, opts)
+	ret0, _ := ret[0].(*sql0.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockDBMockRecorder) BeginTx(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockDB)(nil).BeginTx), ctx, opts)
+}
+
 // Dialect mocks base method.
 func (m *MockDB) Dialect() string {
 	m.ctrl.T.Helper()
@@ -127,6 +142,21 @@ func (mr *MockDBMockRecorder) HealthCheck() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockDB)(nil).HealthCheck))
 }
 
+// NamedExec mocks base method.
+func (m *MockDB) NamedExec(ctx context.Context, query string, arg any) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamedExec", ctx, query, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NamedExec indicates an expected call of NamedExec.
+func (mr *MockDBMockRecorder) NamedExec(ctx, query, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamedExec", reflect.TypeOf((*MockDB)(nil).NamedExec), ctx, query, arg)
+}
+
 // Prepare mocks base method.
 func (m *MockDB) Prepare(query string) (*sql.Stmt, error) {
 	m.ctrl.T.Helper()
@@ -237,6 +267,41 @@ func (mr *MockDBMockRecorder) Select(ctx, data, query any, args ...any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Select", reflect.TypeOf((*MockDB)(nil).Select), varargs...)
 }
 
+// SoftDelete mocks base method.
+func (m *MockDB) SoftDelete(ctx context.Context, table, condition string, args ...any) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, table, condition}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SoftDelete", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockDBMockRecorder) SoftDelete(ctx, table, condition any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, table, condition}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockDB)(nil).SoftDelete), varargs...)
+}
+
+// UpdateWithVersion mocks base method.
+func (m *MockDB) UpdateWithVersion(ctx context.Context, update *sql0.UpdateBuilder, version int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithVersion", ctx, update, version)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWithVersion indicates an expected call of UpdateWithVersion.
+func (mr *MockDBMockRecorder) UpdateWithVersion(ctx, update, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithVersion", reflect.TypeOf((*MockDB)(nil).UpdateWithVersion), ctx, update, version)
+}
+
 // MockRedis is a mock of Redis interface.
 type MockRedis struct {
 	ctrl     *gomock.Controller
@@ -2634,6 +2699,20 @@ func (mr *MockRedisMockRecorder) GetEx(ctx, key, expiration any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEx", reflect.TypeOf((*MockRedis)(nil).GetEx), ctx, key, expiration)
 }
 
+// GetInto mocks base method.
+func (m *MockRedis) GetInto(ctx context.Context, key string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInto", ctx, key, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetInto indicates an expected call of GetInto.
+func (mr *MockRedisMockRecorder) GetInto(ctx, key, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInto", reflect.TypeOf((*MockRedis)(nil).GetInto), ctx, key, dest)
+}
+
 // GetRange mocks base method.
 func (m *MockRedis) GetRange(ctx context.Context, key string, start, end int64) *redis.StringCmd {
 	m.ctrl.T.Helper()
@@ -2723,6 +2802,20 @@ func (mr *MockRedisMockRecorder) HGetAll(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HGetAll", reflect.TypeOf((*MockRedis)(nil).HGetAll), ctx, key)
 }
 
+// HGetAllInto mocks base method.
+func (m *MockRedis) HGetAllInto(ctx context.Context, key string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HGetAllInto", ctx, key, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HGetAllInto indicates an expected call of HGetAllInto.
+func (mr *MockRedisMockRecorder) HGetAllInto(ctx, key, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HGetAllInto", reflect.TypeOf((*MockRedis)(nil).HGetAllInto), ctx, key, dest)
+}
+
 // HIncrBy mocks base method.
 func (m *MockRedis) HIncrBy(ctx context.Context, key, field string, incr int64) *redis.IntCmd {
 	m.ctrl.T.Helper()
@@ -2892,6 +2985,20 @@ func (mr *MockRedisMockRecorder) HSetNX(ctx, key, field, value any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HSetNX", reflect.TypeOf((*MockRedis)(nil).HSetNX), ctx, key, field, value)
 }
 
+// HSetStruct mocks base method.
+func (m *MockRedis) HSetStruct(ctx context.Context, key string, value any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HSetStruct", ctx, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HSetStruct indicates an expected call of HSetStruct.
+func (mr *MockRedisMockRecorder) HSetStruct(ctx, key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HSetStruct", reflect.TypeOf((*MockRedis)(nil).HSetStruct), ctx, key, value)
+}
+
 // HVals mocks base method.
 func (m *MockRedis) HVals(ctx context.Context, key string) *redis.StringSliceCmd {
 	m.ctrl.T.Helper()
@@ -3558,6 +3665,20 @@ func (mr *MockRedisMockRecorder) LPopCount(ctx, key, count any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPopCount", reflect.TypeOf((*MockRedis)(nil).LPopCount), ctx, key, count)
 }
 
+// LPopInto mocks base method.
+func (m *MockRedis) LPopInto(ctx context.Context, key string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LPopInto", ctx, key, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LPopInto indicates an expected call of LPopInto.
+func (mr *MockRedisMockRecorder) LPopInto(ctx, key, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPopInto", reflect.TypeOf((*MockRedis)(nil).LPopInto), ctx, key, dest)
+}
+
 // LPos mocks base method.
 func (m *MockRedis) LPos(ctx context.Context, key, value string, args redis.LPosArgs) *redis.IntCmd {
 	m.ctrl.T.Helper()
@@ -3605,6 +3726,25 @@ func (mr *MockRedisMockRecorder) LPush(ctx, key any, values ...any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPush", reflect.TypeOf((*MockRedis)(nil).LPush), varargs...)
 }
 
+// LPushJSON mocks base method.
+func (m *MockRedis) LPushJSON(ctx context.Context, key string, values ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range values {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LPushJSON", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LPushJSON indicates an expected call of LPushJSON.
+func (mr *MockRedisMockRecorder) LPushJSON(ctx, key any, values ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, values...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPushJSON", reflect.TypeOf((*MockRedis)(nil).LPushJSON), varargs...)
+}
+
 // LPushX mocks base method.
 func (m *MockRedis) LPushX(ctx context.Context, key string, values ...any) *redis.IntCmd {
 	m.ctrl.T.Helper()
@@ -3638,6 +3778,20 @@ func (mr *MockRedisMockRecorder) LRange(ctx, key, start, stop any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LRange", reflect.TypeOf((*MockRedis)(nil).LRange), ctx, key, start, stop)
 }
 
+// LRangeInto mocks base method.
+func (m *MockRedis) LRangeInto(ctx context.Context, key string, start, stop int64, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LRangeInto", ctx, key, start, stop, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LRangeInto indicates an expected call of LRangeInto.
+func (mr *MockRedisMockRecorder) LRangeInto(ctx, key, start, stop, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LRangeInto", reflect.TypeOf((*MockRedis)(nil).LRangeInto), ctx, key, start, stop, dest)
+}
+
 // LRem mocks base method.
 func (m *MockRedis) LRem(ctx context.Context, key string, count int64, value any) *redis.IntCmd {
 	m.ctrl.T.Helper()
@@ -4174,6 +4328,20 @@ func (mr *MockRedisMockRecorder) RPopCount(ctx, key, count any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPopCount", reflect.TypeOf((*MockRedis)(nil).RPopCount), ctx, key, count)
 }
 
+// RPopInto mocks base method.
+func (m *MockRedis) RPopInto(ctx context.Context, key string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RPopInto", ctx, key, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RPopInto indicates an expected call of RPopInto.
+func (mr *MockRedisMockRecorder) RPopInto(ctx, key, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPopInto", reflect.TypeOf((*MockRedis)(nil).RPopInto), ctx, key, dest)
+}
+
 // RPopLPush mocks base method.
 func (m *MockRedis) RPopLPush(ctx context.Context, source, destination string) *redis.StringCmd {
 	m.ctrl.T.Helper()
@@ -4207,6 +4375,25 @@ func (mr *MockRedisMockRecorder) RPush(ctx, key any, values ...any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPush", reflect.TypeOf((*MockRedis)(nil).RPush), varargs...)
 }
 
+// RPushJSON mocks base method.
+func (m *MockRedis) RPushJSON(ctx context.Context, key string, values ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range values {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RPushJSON", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RPushJSON indicates an expected call of RPushJSON.
+func (mr *MockRedisMockRecorder) RPushJSON(ctx, key any, values ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, values...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPushJSON", reflect.TypeOf((*MockRedis)(nil).RPushJSON), varargs...)
+}
+
 // RPushX mocks base method.
 func (m *MockRedis) RPushX(ctx context.Context, key string, values ...any) *redis.IntCmd {
 	m.ctrl.T.Helper()
@@ -4343,6 +4530,25 @@ func (mr *MockRedisMockRecorder) SAdd(ctx, key any, members ...any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SAdd", reflect.TypeOf((*MockRedis)(nil).SAdd), varargs...)
 }
 
+// SAddJSON mocks base method.
+func (m *MockRedis) SAddJSON(ctx context.Context, key string, members ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range members {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SAddJSON", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SAddJSON indicates an expected call of SAddJSON.
+func (mr *MockRedisMockRecorder) SAddJSON(ctx, key any, members ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, members...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SAddJSON", reflect.TypeOf((*MockRedis)(nil).SAddJSON), varargs...)
+}
+
 // SCard mocks base method.
 func (m *MockRedis) SCard(ctx context.Context, key string) *redis.IntCmd {
 	m.ctrl.T.Helper()
@@ -4499,6 +4705,20 @@ func (mr *MockRedisMockRecorder) SMembers(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMembers", reflect.TypeOf((*MockRedis)(nil).SMembers), ctx, key)
 }
 
+// SMembersInto mocks base method.
+func (m *MockRedis) SMembersInto(ctx context.Context, key string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SMembersInto", ctx, key, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SMembersInto indicates an expected call of SMembersInto.
+func (mr *MockRedisMockRecorder) SMembersInto(ctx, key, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMembersInto", reflect.TypeOf((*MockRedis)(nil).SMembersInto), ctx, key, dest)
+}
+
 // SMembersMap mocks base method.
 func (m *MockRedis) SMembersMap(ctx context.Context, key string) *redis.StringStructMapCmd {
 	m.ctrl.T.Helper()
@@ -4827,6 +5047,20 @@ func (mr *MockRedisMockRecorder) SetEx(ctx, key, value, expiration any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEx", reflect.TypeOf((*MockRedis)(nil).SetEx), ctx, key, value, expiration)
 }
 
+// SetJSON mocks base method.
+func (m *MockRedis) SetJSON(ctx context.Context, key string, value any, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetJSON", ctx, key, value, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetJSON indicates an expected call of SetJSON.
+func (mr *MockRedisMockRecorder) SetJSON(ctx, key, value, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJSON", reflect.TypeOf((*MockRedis)(nil).SetJSON), ctx, key, value, expiration)
+}
+
 // SetNX mocks base method.
 func (m *MockRedis) SetNX(ctx context.Context, key string, value any, expiration time.Duration) *redis.BoolCmd {
 	m.ctrl.T.Helper()
@@ -7264,3 +7498,195 @@ func (mr *MockRedisMockRecorder) ZUnionWithScores(ctx, store any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZUnionWithScores", reflect.TypeOf((*MockRedis)(nil).ZUnionWithScores), ctx, store)
 }
+
+// MockTypedRedis is a mock of TypedRedis interface.
+type MockTypedRedis struct {
+	ctrl     *gomock.Controller
+	recorder *MockTypedRedisMockRecorder
+}
+
+// MockTypedRedisMockRecorder is the mock recorder for MockTypedRedis.
+type MockTypedRedisMockRecorder struct {
+	mock *MockTypedRedis
+}
+
+// NewMockTypedRedis creates a new mock instance.
+func NewMockTypedRedis(ctrl *gomock.Controller) *MockTypedRedis {
+	mock := &MockTypedRedis{ctrl: ctrl}
+	mock.recorder = &MockTypedRedisMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTypedRedis) EXPECT() *MockTypedRedisMockRecorder {
+	return m.recorder
+}
+
+// GetInto mocks base method.
+func (m *MockTypedRedis) GetInto(ctx context.Context, key string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInto", ctx, key, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetInto indicates an expected call of GetInto.
+func (mr *MockTypedRedisMockRecorder) GetInto(ctx, key, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInto", reflect.TypeOf((*MockTypedRedis)(nil).GetInto), ctx, key, dest)
+}
+
+// HGetAllInto mocks base method.
+func (m *MockTypedRedis) HGetAllInto(ctx context.Context, key string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HGetAllInto", ctx, key, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HGetAllInto indicates an expected call of HGetAllInto.
+func (mr *MockTypedRedisMockRecorder) HGetAllInto(ctx, key, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HGetAllInto", reflect.TypeOf((*MockTypedRedis)(nil).HGetAllInto), ctx, key, dest)
+}
+
+// HSetStruct mocks base method.
+func (m *MockTypedRedis) HSetStruct(ctx context.Context, key string, value any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HSetStruct", ctx, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HSetStruct indicates an expected call of HSetStruct.
+func (mr *MockTypedRedisMockRecorder) HSetStruct(ctx, key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HSetStruct", reflect.TypeOf((*MockTypedRedis)(nil).HSetStruct), ctx, key, value)
+}
+
+// LPopInto mocks base method.
+func (m *MockTypedRedis) LPopInto(ctx context.Context, key string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LPopInto", ctx, key, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LPopInto indicates an expected call of LPopInto.
+func (mr *MockTypedRedisMockRecorder) LPopInto(ctx, key, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPopInto", reflect.TypeOf((*MockTypedRedis)(nil).LPopInto), ctx, key, dest)
+}
+
+// LPushJSON mocks base method.
+func (m *MockTypedRedis) LPushJSON(ctx context.Context, key string, values ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range values {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LPushJSON", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LPushJSON indicates an expected call of LPushJSON.
+func (mr *MockTypedRedisMockRecorder) LPushJSON(ctx, key any, values ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, values...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPushJSON", reflect.TypeOf((*MockTypedRedis)(nil).LPushJSON), varargs...)
+}
+
+// LRangeInto mocks base method.
+func (m *MockTypedRedis) LRangeInto(ctx context.Context, key string, start, stop int64, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LRangeInto", ctx, key, start, stop, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LRangeInto indicates an expected call of LRangeInto.
+func (mr *MockTypedRedisMockRecorder) LRangeInto(ctx, key, start, stop, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LRangeInto", reflect.TypeOf((*MockTypedRedis)(nil).LRangeInto), ctx, key, start, stop, dest)
+}
+
+// RPopInto mocks base method.
+func (m *MockTypedRedis) RPopInto(ctx context.Context, key string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RPopInto", ctx, key, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RPopInto indicates an expected call of RPopInto.
+func (mr *MockTypedRedisMockRecorder) RPopInto(ctx, key, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPopInto", reflect.TypeOf((*MockTypedRedis)(nil).RPopInto), ctx, key, dest)
+}
+
+// RPushJSON mocks base method.
+func (m *MockTypedRedis) RPushJSON(ctx context.Context, key string, values ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range values {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RPushJSON", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RPushJSON indicates an expected call of RPushJSON.
+func (mr *MockTypedRedisMockRecorder) RPushJSON(ctx, key any, values ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, values...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPushJSON", reflect.TypeOf((*MockTypedRedis)(nil).RPushJSON), varargs...)
+}
+
+// SAddJSON mocks base method.
+func (m *MockTypedRedis) SAddJSON(ctx context.Context, key string, members ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range members {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SAddJSON", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SAddJSON indicates an expected call of SAddJSON.
+func (mr *MockTypedRedisMockRecorder) SAddJSON(ctx, key any, members ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, members...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SAddJSON", reflect.TypeOf((*MockTypedRedis)(nil).SAddJSON), varargs...)
+}
+
+// SMembersInto mocks base method.
+func (m *MockTypedRedis) SMembersInto(ctx context.Context, key string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SMembersInto", ctx, key, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SMembersInto indicates an expected call of SMembersInto.
+func (mr *MockTypedRedisMockRecorder) SMembersInto(ctx, key, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMembersInto", reflect.TypeOf((*MockTypedRedis)(nil).SMembersInto), ctx, key, dest)
+}
+
+// SetJSON mocks base method.
+func (m *MockTypedRedis) SetJSON(ctx context.Context, key string, value any, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetJSON", ctx, key, value, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetJSON indicates an expected call of SetJSON.
+func (mr *MockTypedRedisMockRecorder) SetJSON(ctx, key, value, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJSON", reflect.TypeOf((*MockTypedRedis)(nil).SetJSON), ctx, key, value, expiration)
+}
@@ -0,0 +1,43 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/notification"
+)
+
+var errNotificationsNotConfigured = errors.New("notifications not configured, NOTIFICATION_SMS_PROVIDER or " +
+	"NOTIFICATION_PUSH_PROVIDER is required")
+
+type notifications struct {
+	mu       sync.RWMutex
+	notifier *notification.Notifier
+}
+
+// Notifier returns the notifier configured by the NOTIFICATION_* configs, or nil.
+func (c *Container) Notifier() *notification.Notifier {
+	c.notifications.mu.RLock()
+	defer c.notifications.mu.RUnlock()
+
+	return c.notifications.notifier
+}
+
+// SetNotifier replaces the notifier of the application, like with a notifier of other providers.
+func (c *Container) SetNotifier(n *notification.Notifier) {
+	c.notifications.mu.Lock()
+	defer c.notifications.mu.Unlock()
+
+	c.notifications.notifier = n
+}
+
+// Notify sends the notification with the notifier of the application, returning its ID given by its provider.
+func (c *Container) Notify(ctx context.Context, msg *notification.Message) (string, error) {
+	n := c.Notifier()
+	if n == nil {
+		return "", errNotificationsNotConfigured
+	}
+
+	return n.Send(ctx, msg)
+}
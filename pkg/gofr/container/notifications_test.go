@@ -0,0 +1,31 @@
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/notification"
+)
+
+func TestContainer_Notify(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{"NOTIFICATION_SMS_PROVIDER": "twilio"}))
+
+	require.NotNil(t, c.Notifier())
+
+	c = NewContainer(config.NewMockConfig(nil))
+
+	_, err := c.Notify(context.Background(), &notification.Message{Channel: notification.SMS, To: "+14155550100"})
+	require.ErrorIs(t, err, errNotificationsNotConfigured)
+
+	sms := notification.NewMockProvider(notification.SMS)
+	c.SetNotifier(notification.New(notification.WithProvider(sms)))
+
+	id, err := c.Notify(context.Background(), &notification.Message{Channel: notification.SMS, To: "+14155550100", Body: "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "mock-1", id)
+	assert.Len(t, sms.Sent(), 1)
+}
@@ -0,0 +1,28 @@
+package container
+
+import (
+	"sync"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/export"
+)
+
+type pdf struct {
+	mu        sync.RWMutex
+	converter export.Converter
+}
+
+// PDFConverter returns the converter configured by PDF_CONVERTER, or nil.
+func (c *Container) PDFConverter() export.Converter {
+	c.pdf.mu.RLock()
+	defer c.pdf.mu.RUnlock()
+
+	return c.pdf.converter
+}
+
+// SetPDFConverter replaces the converter of the HTML of the templates to PDF.
+func (c *Container) SetPDFConverter(converter export.Converter) {
+	c.pdf.mu.Lock()
+	defer c.pdf.mu.Unlock()
+
+	c.pdf.converter = converter
+}
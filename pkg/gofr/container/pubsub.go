@@ -0,0 +1,33 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+var errPubSubNotConfigured = errors.New("pubsub not configured, PUBSUB_BACKEND is required")
+
+// Publish publishes the message on the topic, as JSON unless it is a []byte or a string.
+func (c *Container) Publish(ctx context.Context, topic string, message interface{}) error {
+	if c.PubSub == nil {
+		return errPubSubNotConfigured
+	}
+
+	var data []byte
+
+	switch m := message.(type) {
+	case []byte:
+		data = m
+	case string:
+		data = []byte(m)
+	default:
+		var err error
+
+		if data, err = json.Marshal(message); err != nil {
+			return err
+		}
+	}
+
+	return c.PubSub.Publish(ctx, topic, data)
+}
@@ -0,0 +1,38 @@
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_Publish(t *testing.T) {
+	c, mocks := NewMockContainer(t)
+
+	testCases := []struct {
+		message interface{}
+		value   string
+	}{
+		{[]byte("raw"), "raw"},
+		{"text", "text"},
+		{struct {
+			ID string `json:"id"`
+		}{ID: "123"}, `{"id":"123"}`},
+	}
+
+	for i, tc := range testCases {
+		require.NoError(t, c.Publish(context.Background(), "orders", tc.message), "TEST[%d], Failed.\n", i)
+
+		published := mocks.PubSub.Published("orders")
+
+		assert.Equal(t, tc.value, string(published[len(published)-1].Value), "TEST[%d], Failed.\n", i)
+	}
+
+	require.Error(t, c.Publish(context.Background(), "orders", func() {}))
+
+	c.PubSub = nil
+
+	require.ErrorIs(t, c.Publish(context.Background(), "orders", "text"), errPubSubNotConfigured)
+}
@@ -0,0 +1,48 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+var errQuotaStoreNotConfigured = errors.New("quota store not configured, either redis or sql is required")
+
+// QuotaStore counts the requests of the principals in the periods of their quotas.
+type QuotaStore interface {
+	// Increment counts a request for the key until expiresAt, and returns its count.
+	Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error)
+	// Count returns the number of the requests counted for the key, which is 0 once its counter is removed.
+	Count(ctx context.Context, key string) (int64, error)
+}
+
+type quotas struct {
+	mu      sync.Mutex
+	backend string
+	store   QuotaStore
+}
+
+// QuotaStore returns the store chosen by QUOTA_STORE, which is Redis if configured, or SQL.
+func (c *Container) QuotaStore() (QuotaStore, error) {
+	c.quotas.mu.Lock()
+	defer c.quotas.mu.Unlock()
+
+	if c.quotas.store != nil {
+		return c.quotas.store, nil
+	}
+
+	backend := strings.ToLower(c.quotas.backend)
+
+	switch {
+	case (backend == "" || backend == "redis") && !isNil(c.Redis):
+		c.quotas.store = &redisQuotaStore{redis: c.Redis}
+	case (backend == "" || backend == "sql") && !isNil(c.SQL):
+		c.quotas.store = &sqlQuotaStore{db: c.SQL, clock: c.Clock()}
+	default:
+		return nil, errQuotaStoreNotConfigured
+	}
+
+	return c.quotas.store, nil
+}
@@ -0,0 +1,41 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisQuotaPrefix = "gofr:quota:"
+
+// redisQuotaStore keeps each counter as an integer expiring at the end of its period.
+type redisQuotaStore struct {
+	redis Redis
+}
+
+func (r *redisQuotaStore) Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error) {
+	var incr *redis.IntCmd
+
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, redisQuotaPrefix+key)
+		pipe.ExpireAt(ctx, redisQuotaPrefix+key, expiresAt)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *redisQuotaStore) Count(ctx context.Context, key string) (int64, error) {
+	count, err := r.redis.Get(ctx, redisQuotaPrefix+key).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+
+	return count, err
+}
@@ -0,0 +1,85 @@
+package container
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+const (
+	createSQLQuotaTable = `CREATE TABLE IF NOT EXISTS gofr_quotas (
+    quota_key VARCHAR(255) not null primary key,
+    requests BIGINT not null,
+    expires_at BIGINT not null
+);`
+
+	insertSQLQuota = `INSERT INTO gofr_quotas (quota_key, requests, expires_at) VALUES (?, 1, ?);`
+	updateSQLQuota = `UPDATE gofr_quotas SET requests = requests + 1 WHERE quota_key = ? AND expires_at > ?;`
+	selectSQLQuota = `SELECT requests FROM gofr_quotas WHERE quota_key = ? AND expires_at > ?;`
+
+	// the expired counters are removed when their key is counted again.
+	deleteExpiredSQLQuota = `DELETE FROM gofr_quotas WHERE quota_key = ? AND expires_at <= ?;`
+)
+
+// sqlQuotaStore keeps the counters in the gofr_quotas table.
+type sqlQuotaStore struct {
+	db    DB
+	clock clock.Clock
+
+	schema gofrSQL.Schema
+}
+
+func (s *sqlQuotaStore) Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error) {
+	if err := s.migrate(ctx); err != nil {
+		return 0, err
+	}
+
+	now := s.clock.Now().UnixMilli()
+
+	if _, err := s.db.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), deleteExpiredSQLQuota), key, now); err != nil {
+		return 0, err
+	}
+
+	var insertErr error
+
+	// the counter is inserted if it cannot be updated, and updated again if another instance inserted it meanwhile.
+	for attempt := 0; attempt < 2; attempt++ {
+		res, err := s.db.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), updateSQLQuota), key, now)
+		if err != nil {
+			return 0, err
+		}
+
+		if updated, _ := res.RowsAffected(); updated == 0 {
+			if _, insertErr = s.db.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), insertSQLQuota), key, expiresAt.UnixMilli()); insertErr != nil {
+				continue
+			}
+		}
+
+		return s.Count(ctx, key)
+	}
+
+	return 0, insertErr
+}
+
+func (s *sqlQuotaStore) Count(ctx context.Context, key string) (int64, error) {
+	if err := s.migrate(ctx); err != nil {
+		return 0, err
+	}
+
+	var count int64
+
+	err := s.db.QueryRowContext(ctx, gofrSQL.Rebind(s.db.Dialect(), selectSQLQuota), key, s.clock.Now().UnixMilli()).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	return count, err
+}
+
+func (s *sqlQuotaStore) migrate(ctx context.Context) error {
+	return s.schema.Create(ctx, s.db, createSQLQuotaTable)
+}
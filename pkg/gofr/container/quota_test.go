@@ -0,0 +1,88 @@
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+func TestQuotaStores(t *testing.T) {
+	containers := map[string]func(t *testing.T) *Container{
+		"redis": newRedisJobsContainer,
+		"sql":   newSQLJobsContainer,
+	}
+
+	for name, newContainer := range containers {
+		t.Run(name, func(t *testing.T) {
+			testQuotaStore(t, newContainer(t))
+		})
+	}
+}
+
+func testQuotaStore(t *testing.T, c *Container) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	fake := clock.NewFake(time.Now())
+	c.clock = fake
+
+	store, err := c.QuotaStore()
+	require.NoError(t, err)
+
+	count, err := store.Count(ctx, "key:abc:day:2026-10-17")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	for i := int64(1); i <= 3; i++ {
+		count, err = store.Increment(ctx, "key:abc:day:2026-10-17", fake.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, i, count)
+	}
+
+	count, err = store.Count(ctx, "key:abc:day:2026-10-17")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = store.Increment(ctx, "key:xyz:day:2026-10-17", fake.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count, "the counters of the keys should be distinct")
+
+	// miniredis expires the keys only when its time is fast-forwarded, so the expiry is checked for SQL only.
+	if _, ok := store.(*sqlQuotaStore); ok {
+		_, err = store.Increment(ctx, "key:abc:month:2026-10", fake.Now().Add(time.Millisecond))
+		require.NoError(t, err)
+
+		fake.Advance(5 * time.Millisecond)
+
+		count, err = store.Count(ctx, "key:abc:month:2026-10")
+		require.NoError(t, err)
+		assert.Zero(t, count, "the expired counter should not be counted")
+
+		count, err = store.Increment(ctx, "key:abc:month:2026-10", fake.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count, "the expired counter should be counted again from 1")
+	}
+}
+
+func TestContainer_QuotaStore(t *testing.T) {
+	_, err := (&Container{}).QuotaStore()
+	assert.Equal(t, errQuotaStoreNotConfigured, err)
+
+	c := newSQLJobsContainer(t)
+	c.quotas.backend = "redis"
+
+	_, err = c.QuotaStore()
+	assert.Equal(t, errQuotaStoreNotConfigured, err, "redis should not be used when not configured")
+
+	c.quotas.backend = "SQL"
+
+	store, err := c.QuotaStore()
+	require.NoError(t, err)
+	assert.IsType(t, &sqlQuotaStore{}, store)
+}
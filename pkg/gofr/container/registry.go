@@ -0,0 +1,134 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+var (
+	// ErrDatasourceNotFound is returned by Get for the names of no datasource, or of one which is not configured.
+	ErrDatasourceNotFound = errors.New("datasource not found")
+	// ErrDatasourceType is returned by Get for the datasources of another type than the one requested.
+	ErrDatasourceType = errors.New("datasource is not of the requested type")
+	// ErrReservedDatasource is returned by AddDatasource for the names of the datasources of the container, like "sql".
+	ErrReservedDatasource = errors.New("datasource name is reserved")
+)
+
+// registry holds the datasources added to the container by their name.
+type registry struct {
+	mu          sync.RWMutex
+	datasources map[string]interface{}
+}
+
+// AddDatasource adds the datasource to the container with the name, to be retrieved with Get. The names of the
+// datasources of the container, like "sql", are reserved.
+func (c *Container) AddDatasource(name string, ds interface{}) error {
+	if IsReservedDatasource(name) {
+		return fmt.Errorf("%w: %s", ErrReservedDatasource, name)
+	}
+
+	if ds == nil {
+		return nil
+	}
+
+	c.registry.mu.Lock()
+	defer c.registry.mu.Unlock()
+
+	if c.registry.datasources == nil {
+		c.registry.datasources = make(map[string]interface{})
+	}
+
+	c.registry.datasources[name] = ds
+
+	return nil
+}
+
+// IsReservedDatasource reports whether the name is reserved for a datasource of the container, like "sql".
+func IsReservedDatasource(name string) bool {
+	switch name {
+	case "sql", "redis", "mongo", "cassandra", "pubsub":
+		return true
+	default:
+		return false
+	}
+}
+
+// GetDataSource returns the datasource.Datasource added with the name.
+func (c *Container) GetDataSource(name string) (datasource.Datasource, error) {
+	return Get[datasource.Datasource](c, name)
+}
+
+// externalDatasources returns the datasources added to the container which implement datasource.Datasource.
+func (c *Container) externalDatasources() map[string]datasource.Datasource {
+	c.registry.mu.RLock()
+	defer c.registry.mu.RUnlock()
+
+	datasources := make(map[string]datasource.Datasource)
+
+	for name, ds := range c.registry.datasources {
+		if d, ok := ds.(datasource.Datasource); ok && !IsReservedDatasource(name) {
+			datasources[name] = d
+		}
+	}
+
+	return datasources
+}
+
+// datasource returns the datasource of the name, and whether it is configured.
+func (c *Container) datasource(name string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	switch name {
+	case "sql":
+		return c.SQL, !isNil(c.SQL)
+	case "redis":
+		return c.Redis, !isNil(c.Redis)
+	case "mongo":
+		return c.Mongo, !isNil(c.Mongo)
+	case "cassandra":
+		return c.Cassandra, !isNil(c.Cassandra)
+	case "pubsub":
+		return c.PubSub, !isNil(c.PubSub)
+	}
+
+	c.registry.mu.RLock()
+	defer c.registry.mu.RUnlock()
+
+	ds, ok := c.registry.datasources[name]
+
+	return ds, ok
+}
+
+// Get returns the datasource of the container with the name as a T.
+//
+//	Usage:
+//	app.AddDatasource("search", elastic.NewClient(cfg))
+//
+//	func search(ctx *gofr.Context) (interface{}, error) {
+//		client, err := container.Get[*elastic.Client](ctx.Container, "search")
+//		if err != nil {
+//			return nil, err
+//		}
+//		...
+//	}
+func Get[T any](c *Container, name string) (T, error) {
+	var zero T
+
+	ds, ok := c.datasource(name)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s", ErrDatasourceNotFound, name)
+	}
+
+	t, ok := ds.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s is a %T, not a %v", ErrDatasourceType, name, ds, reflect.TypeOf((*T)(nil)).Elem())
+	}
+
+	return t, nil
+}
@@ -0,0 +1,109 @@
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+type searchClient struct{ index string }
+
+type searcher interface{}
+
+func TestGet(t *testing.T) {
+	c, mocks := NewMockContainer(t)
+
+	require.NoError(t, c.AddDatasource("search", &searchClient{index: "products"}))
+	require.NoError(t, c.AddDatasource("nothing", nil))
+
+	client, err := Get[*searchClient](c, "search")
+	require.NoError(t, err)
+	assert.Equal(t, "products", client.index)
+
+	db, err := Get[DB](c, "sql")
+	require.NoError(t, err)
+	assert.Equal(t, mocks.SQL, db)
+
+	_, err = Get[*searchClient](c, "nothing")
+	require.ErrorIs(t, err, ErrDatasourceNotFound)
+	assert.Equal(t, "datasource not found: nothing", err.Error())
+
+	_, err = Get[DB](c, "search")
+	require.ErrorIs(t, err, ErrDatasourceType)
+	assert.Equal(t, "datasource is not of the requested type: search is a *container.searchClient, not a container.DB",
+		err.Error())
+
+	_, err = Get[searcher](nil, "search")
+	require.ErrorIs(t, err, ErrDatasourceNotFound)
+}
+
+func TestGet_NotConfigured(t *testing.T) {
+	c := &Container{}
+
+	for i, name := range []string{"sql", "redis", "mongo", "cassandra", "pubsub", "search"} {
+		_, err := Get[interface{}](c, name)
+
+		assert.ErrorIs(t, err, ErrDatasourceNotFound, "TEST[%d], Failed.\n%s", i, name)
+	}
+}
+
+func TestAddDatasource_Reserved(t *testing.T) {
+	c := &Container{}
+
+	for i, name := range []string{"sql", "redis", "mongo", "cassandra", "pubsub"} {
+		err := c.AddDatasource(name, &searchClient{})
+
+		require.ErrorIs(t, err, ErrReservedDatasource, "TEST[%d], Failed.\n%s", i, name)
+		assert.Equal(t, "datasource name is reserved: "+name, err.Error(), "TEST[%d], Failed.\n%s", i, name)
+	}
+
+	_, err := Get[*searchClient](c, "sql")
+	require.ErrorIs(t, err, ErrDatasourceNotFound)
+}
+
+// graphClient is a datasource.Datasource which gofr does not provide.
+type graphClient struct {
+	health datasource.Health
+	closed bool
+}
+
+func (*graphClient) Connect(context.Context, config.Config) error { return nil }
+
+func (g *graphClient) HealthCheck(context.Context) datasource.Health { return g.health }
+
+func (g *graphClient) Close() error {
+	g.closed = true
+
+	return nil
+}
+
+func TestGetDataSource(t *testing.T) {
+	c := &Container{}
+	graph := &graphClient{health: datasource.Health{Status: datasource.StatusDown}}
+
+	c.AddDatasource("graph", graph)
+	c.AddDatasource("search", &searchClient{})
+
+	ds, err := c.GetDataSource("graph")
+	require.NoError(t, err)
+	assert.Equal(t, graph, ds)
+
+	_, err = c.GetDataSource("search")
+	require.ErrorIs(t, err, ErrDatasourceType)
+
+	_, err = c.GetDataSource("missing")
+	require.ErrorIs(t, err, ErrDatasourceNotFound)
+
+	r := c.Ready(context.Background())
+
+	assert.Equal(t, datasource.StatusDown, r.Status)
+	assert.Equal(t, []string{"graph"}, r.Unhealthy(), "only the datasources implementing datasource.Datasource are checked")
+
+	require.NoError(t, c.Close())
+	assert.True(t, graph.closed)
+}
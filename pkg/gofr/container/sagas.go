@@ -0,0 +1,168 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of the sagas started using StartSaga.
+const (
+	SagaRunning      = "RUNNING"
+	SagaCompensating = "COMPENSATING"
+	SagaCompleted    = "COMPLETED"
+	SagaCompensated  = "COMPENSATED"
+	SagaFailed       = "FAILED"
+)
+
+// sagaJobMaxAttempts is the number of attempts of the job running a saga, whose steps are retried by the saga.
+const sagaJobMaxAttempts = math.MaxInt32
+
+var (
+	// ErrSagaNotFound is returned for the ID of a saga which is not in the saga store.
+	ErrSagaNotFound = errors.New("saga not found")
+
+	errSagaStoreNotConfigured = errors.New("saga store not configured, sql is required")
+)
+
+// Saga is the state of a saga, which is persisted after each of its steps so that it is resumed after a crash.
+type Saga struct {
+	ID      string                     `json:"id"`
+	Name    string                     `json:"name"`
+	Payload json.RawMessage            `json:"payload"`
+	Data    map[string]json.RawMessage `json:"data"`
+	Status  string                     `json:"status"`
+	// Step is the index of the next step to run, or of the next step to compensate while the saga is COMPENSATING.
+	Step int `json:"step"`
+	// Attempts is the number of failed attempts of the step.
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"lastError,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Bind unmarshals the payload with which the saga is started into v.
+func (s *Saga) Bind(v interface{}) error {
+	return json.Unmarshal(s.Payload, v)
+}
+
+// Set records the value in the data of the saga, for its next steps and compensations, marshalled as JSON.
+func (s *Saga) Set(key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if s.Data == nil {
+		s.Data = make(map[string]json.RawMessage)
+	}
+
+	s.Data[key] = data
+
+	return nil
+}
+
+// Get unmarshals the value recorded for the key using Set into v, and reports whether the key is recorded.
+func (s *Saga) Get(key string, v interface{}) (bool, error) {
+	data, ok := s.Data[key]
+	if !ok {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(data, v)
+}
+
+// SagaStore persists the state of the sagas.
+type SagaStore interface {
+	// Create persists a new saga.
+	Create(ctx context.Context, saga *Saga) error
+	// Get returns the saga of the ID, or ErrSagaNotFound.
+	Get(ctx context.Context, id string) (*Saga, error)
+	// Update persists the state of the saga.
+	Update(ctx context.Context, saga *Saga) error
+	// List returns up to limit sagas in the given state, the most recently updated first.
+	List(ctx context.Context, status string, limit int) ([]Saga, error)
+}
+
+// SagaJobPayload is the payload of the job running a saga.
+type SagaJobPayload struct {
+	SagaID string `json:"sagaId"`
+}
+
+// SagaJobName returns the name of the jobs running the sagas of the name.
+func SagaJobName(name string) string {
+	return "gofr-saga:" + name
+}
+
+// StartSaga persists a RUNNING saga and enqueues the job running its steps. It returns the ID of the saga.
+func (c *Container) StartSaga(ctx context.Context, name string, payload interface{}) (string, error) {
+	store, err := c.SagaStore()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	saga := &Saga{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Payload:   data,
+		Data:      make(map[string]json.RawMessage),
+		Status:    SagaRunning,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := store.Create(ctx, saga); err != nil {
+		return "", err
+	}
+
+	if _, err := c.EnqueueJob(ctx, SagaJobName(name), SagaJobPayload{SagaID: saga.ID},
+		JobOptions{MaxAttempts: sagaJobMaxAttempts}); err != nil {
+		saga.Status = SagaFailed
+		saga.LastError = err.Error()
+
+		if err := store.Update(ctx, saga); err != nil {
+			c.Errorf("could not update saga %s with ID %s, error: %v", name, saga.ID, err)
+		}
+
+		return "", err
+	}
+
+	c.Debugf("started saga %s with ID %s", name, saga.ID)
+
+	return saga.ID, nil
+}
+
+type sagas struct {
+	mu    sync.Mutex
+	store SagaStore
+}
+
+// SagaStore returns the store of the sagas, the gofr_sagas table of the SQL database.
+func (c *Container) SagaStore() (SagaStore, error) {
+	c.sagas.mu.Lock()
+	defer c.sagas.mu.Unlock()
+
+	if c.sagas.store != nil {
+		return c.sagas.store, nil
+	}
+
+	if isNil(c.SQL) {
+		return nil, errSagaStoreNotConfigured
+	}
+
+	c.sagas.store = &sqlSagaStore{db: c.SQL}
+
+	return c.sagas.store, nil
+}
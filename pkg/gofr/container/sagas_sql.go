@@ -0,0 +1,138 @@
+package container
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+const (
+	createSQLSagasTable = `CREATE TABLE IF NOT EXISTS gofr_sagas (
+    id VARCHAR(36) not null primary key,
+    name VARCHAR(255) not null,
+    payload TEXT not null,
+    data TEXT not null,
+    status VARCHAR(16) not null,
+    step INT not null,
+    attempts INT not null,
+    last_error TEXT not null,
+    created_at BIGINT not null,
+    updated_at BIGINT not null
+);`
+
+	sqlSagaColumns = `id, name, payload, data, status, step, attempts, last_error, created_at, updated_at`
+
+	insertSQLSaga = `INSERT INTO gofr_sagas (` + sqlSagaColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	selectSQLSaga = `SELECT ` + sqlSagaColumns + ` FROM gofr_sagas WHERE id = ?;`
+	updateSQLSaga = `UPDATE gofr_sagas SET data = ?, status = ?, step = ?, attempts = ?, last_error = ?, updated_at = ? ` +
+		`WHERE id = ?;`
+	selectSQLSagas = `SELECT ` + sqlSagaColumns + ` FROM gofr_sagas WHERE status = ? ORDER BY updated_at DESC`
+)
+
+// sqlSagaStore keeps the sagas in the gofr_sagas table.
+type sqlSagaStore struct {
+	db DB
+
+	schema gofrSQL.Schema
+}
+
+func (s *sqlSagaStore) Create(ctx context.Context, saga *Saga) error {
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(saga.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), insertSQLSaga), saga.ID, saga.Name, string(saga.Payload), string(data),
+		saga.Status, saga.Step, saga.Attempts, saga.LastError, saga.CreatedAt.UnixMilli(), saga.UpdatedAt.UnixMilli())
+
+	return err
+}
+
+func (s *sqlSagaStore) Get(ctx context.Context, id string) (*Saga, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	saga, err := scanSQLSaga(s.db.QueryRowContext(ctx, gofrSQL.Rebind(s.db.Dialect(), selectSQLSaga), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSagaNotFound
+	}
+
+	return saga, err
+}
+
+func (s *sqlSagaStore) Update(ctx context.Context, saga *Saga) error {
+	data, err := json.Marshal(saga.Data)
+	if err != nil {
+		return err
+	}
+
+	saga.UpdatedAt = time.Now()
+
+	_, err = s.db.ExecContext(ctx, gofrSQL.Rebind(s.db.Dialect(), updateSQLSaga), string(data), saga.Status, saga.Step, saga.Attempts,
+		saga.LastError, saga.UpdatedAt.UnixMilli(), saga.ID)
+
+	return err
+}
+
+func (s *sqlSagaStore) List(ctx context.Context, status string, limit int) ([]Saga, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, gofrSQL.Rebind(s.db.Dialect(), selectSQLSagas+gofrSQL.Limit(s.db.Dialect())), status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	sagas := make([]Saga, 0)
+
+	for rows.Next() {
+		saga, err := scanSQLSaga(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sagas = append(sagas, *saga)
+	}
+
+	return sagas, rows.Err()
+}
+
+func (s *sqlSagaStore) migrate(ctx context.Context) error {
+	return s.schema.Create(ctx, s.db, createSQLSagasTable)
+}
+
+func scanSQLSaga(row sqlJobScanner) (*Saga, error) {
+	var (
+		saga                 Saga
+		payload, data        string
+		createdAt, updatedAt int64
+	)
+
+	err := row.Scan(&saga.ID, &saga.Name, &payload, &data, &saga.Status, &saga.Step, &saga.Attempts, &saga.LastError,
+		&createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(data), &saga.Data); err != nil {
+		return nil, err
+	}
+
+	saga.Payload = []byte(payload)
+	saga.CreatedAt = time.UnixMilli(createdAt)
+	saga.UpdatedAt = time.UnixMilli(updatedAt)
+
+	return &saga, nil
+}
@@ -0,0 +1,67 @@
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+func TestContainer_StartSaga(t *testing.T) {
+	c := newSQLJobsContainer(t)
+	ctx := context.Background()
+
+	id, err := c.StartSaga(ctx, "place-order", map[string]int{"orderId": 7})
+	require.NoError(t, err)
+
+	store, err := c.SagaStore()
+	require.NoError(t, err)
+
+	saga, err := store.Get(ctx, id)
+	require.NoError(t, err)
+
+	assert.Equal(t, "place-order", saga.Name)
+	assert.Equal(t, SagaRunning, saga.Status)
+	assert.JSONEq(t, `{"orderId":7}`, string(saga.Payload))
+
+	jobs, err := c.jobs.store.List(ctx, JobPending, 10)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+
+	assert.Equal(t, SagaJobName("place-order"), jobs[0].Name)
+	assert.JSONEq(t, `{"sagaId":"`+id+`"}`, string(jobs[0].Payload))
+
+	require.NoError(t, saga.Set("paymentId", "p-1"))
+
+	saga.Status = SagaCompleted
+	saga.Step = 2
+
+	require.NoError(t, store.Update(ctx, saga))
+
+	completed, err := store.List(ctx, SagaCompleted, 10)
+	require.NoError(t, err)
+	require.Len(t, completed, 1)
+
+	var paymentID string
+
+	ok, err := completed[0].Get("paymentId", &paymentID)
+
+	assert.True(t, ok)
+	require.NoError(t, err)
+	assert.Equal(t, "p-1", paymentID)
+	assert.Equal(t, 2, completed[0].Step)
+
+	_, err = store.Get(ctx, "missing")
+	assert.ErrorIs(t, err, ErrSagaNotFound)
+}
+
+func TestContainer_SagaStoreNotConfigured(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(nil))
+
+	_, err := c.StartSaga(context.Background(), "place-order", nil)
+
+	assert.Equal(t, errSagaStoreNotConfigured, err)
+}
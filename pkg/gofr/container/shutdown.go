@@ -0,0 +1,102 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+const defaultJobGracePeriod = 30 * time.Second
+
+// ErrShuttingDown is the cause of the cancellation of the context of a job whose grace period elapsed.
+var ErrShuttingDown = errors.New("application is shutting down")
+
+// shutdown is signalled once when the application starts shutting down.
+type shutdown struct {
+	mu          sync.Mutex
+	done        chan struct{}
+	gracePeriod time.Duration
+}
+
+func (s *shutdown) channel() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.done == nil {
+		s.done = make(chan struct{})
+	}
+
+	return s.done
+}
+
+// BeginShutdown signals the background jobs that the application is shutting down.
+func (c *Container) BeginShutdown() {
+	done := c.shutdown.channel()
+
+	c.shutdown.mu.Lock()
+	defer c.shutdown.mu.Unlock()
+
+	select {
+	case <-done:
+	default:
+		close(done)
+	}
+}
+
+// ShuttingDown returns a channel which is closed once the application starts shutting down.
+func (c *Container) ShuttingDown() <-chan struct{} {
+	return c.shutdown.channel()
+}
+
+// JobContext returns the context of a background job, cancelled with ErrShuttingDown once the grace period has elapsed
+// after the shutdown began. A non-positive gracePeriod defaults to JOB_GRACE_PERIOD, or 30 seconds.
+func (c *Container) JobContext(ctx context.Context, gracePeriod time.Duration) (context.Context, context.CancelFunc) {
+	if gracePeriod <= 0 {
+		gracePeriod = c.shutdown.gracePeriod
+	}
+
+	if gracePeriod <= 0 {
+		gracePeriod = defaultJobGracePeriod
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	done := c.shutdown.channel()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+		}
+
+		timer := time.NewTimer(gracePeriod)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+			cancel(ErrShuttingDown)
+		}
+	}()
+
+	return ctx, func() { cancel(context.Canceled) }
+}
+
+// Close closes the datasources of the container.
+func (c *Container) Close() error {
+	errs := []error{c.tenancy.close()}
+
+	for _, ds := range []interface{}{c.SQL, c.Redis, c.PubSub, c.Mongo, c.Cassandra} {
+		if closer, ok := ds.(io.Closer); ok && !isNil(ds) {
+			errs = append(errs, closer.Close())
+		}
+	}
+
+	for _, ds := range c.externalDatasources() {
+		errs = append(errs, ds.Close())
+	}
+
+	return errors.Join(errs...)
+}
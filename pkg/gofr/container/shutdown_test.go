@@ -0,0 +1,93 @@
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/redis"
+)
+
+func TestContainer_JobContext(t *testing.T) {
+	c := &Container{}
+
+	ctx, cancel := c.JobContext(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	other, cancelOther := c.JobContext(context.Background(), time.Hour)
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("context should not be cancelled before the shutdown")
+	case <-time.After(30 * time.Millisecond):
+	}
+
+	c.BeginShutdown()
+	c.BeginShutdown()
+
+	select {
+	case <-c.ShuttingDown():
+	default:
+		t.Fatal("shutdown should be signalled")
+	}
+
+	assert.Nil(t, ctx.Err(), "context should be cancelled only after the grace period")
+
+	select {
+	case <-ctx.Done():
+		assert.Equal(t, context.Canceled, ctx.Err())
+		assert.Equal(t, ErrShuttingDown, context.Cause(ctx))
+	case <-time.After(time.Second):
+		t.Fatal("context should be cancelled once the grace period elapses")
+	}
+
+	assert.Nil(t, other.Err(), "context with a longer grace period should not be cancelled yet")
+
+	cancelOther()
+
+	assert.Equal(t, context.Canceled, context.Cause(other))
+}
+
+func TestContainer_JobContext_DefaultGracePeriod(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{"JOB_GRACE_PERIOD": "1"}))
+
+	assert.Equal(t, time.Second, c.shutdown.gracePeriod)
+
+	c.BeginShutdown()
+
+	start := time.Now()
+
+	ctx, cancel := c.JobContext(context.Background(), 0)
+	defer cancel()
+
+	<-ctx.Done()
+
+	assert.WithinDuration(t, start.Add(time.Second), time.Now(), 500*time.Millisecond)
+}
+
+type closingPubSub struct {
+	MockPubSub
+	closed bool
+}
+
+func (c *closingPubSub) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestContainer_Close(t *testing.T) {
+	ps := &closingPubSub{}
+
+	c := &Container{PubSub: ps, Redis: (*redis.Redis)(nil)}
+
+	assert.NoError(t, c.Close())
+	assert.True(t, ps.closed)
+
+	// a client which could not connect to Redis has no connections to close.
+	c = &Container{Redis: &redis.Redis{}}
+
+	assert.NoError(t, c.Close())
+}
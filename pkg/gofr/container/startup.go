@@ -0,0 +1,75 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+const (
+	initialStartupBackoff = 100 * time.Millisecond
+	maxStartupBackoff     = 5 * time.Second
+)
+
+var errDependenciesUnavailable = errors.New("dependencies not available")
+
+// WaitForDependencies waits until the given dependencies, or all of them, are UP, or ctx is done.
+func (c *Container) WaitForDependencies(ctx context.Context, dependencies ...string) error {
+	c.waitingForDependencies.Store(true)
+	defer c.waitingForDependencies.Store(false)
+
+	backoff := initialStartupBackoff
+
+	for {
+		pending := c.pendingDependencies(ctx, dependencies)
+		if len(pending) == 0 {
+			return nil
+		}
+
+		c.Debugf("waiting for dependencies: %s, retrying in %v", strings.Join(pending, ", "), backoff)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s", errDependenciesUnavailable, strings.Join(pending, ", "))
+		case <-c.Clock().After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxStartupBackoff {
+			backoff = maxStartupBackoff
+		}
+	}
+}
+
+// StartWarmUp reports the application as not ready until done is called, once the application is warmed up.
+func (c *Container) StartWarmUp() (done func()) {
+	c.warmingUp.Store(true)
+
+	return func() { c.warmingUp.Store(false) }
+}
+
+// pendingDependencies returns the names of the dependencies which are not UP.
+func (c *Container) pendingDependencies(ctx context.Context, dependencies []string) []string {
+	checks := c.registeredHealthChecks()
+
+	if len(dependencies) == 0 {
+		for name := range checks {
+			dependencies = append(dependencies, name)
+		}
+	}
+
+	pending := make([]string, 0)
+
+	for _, name := range dependencies {
+		check, ok := checks[name]
+		if !ok || healthStatus(c.runHealthCheck(ctx, check)) != datasource.StatusUp {
+			pending = append(pending, name)
+		}
+	}
+
+	return pending
+}
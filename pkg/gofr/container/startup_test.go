@@ -0,0 +1,98 @@
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+func TestContainer_WaitForDependencies(t *testing.T) {
+	c, mocks := NewMockContainer(t, WithFakeClock())
+
+	calls := 0
+
+	mocks.SQL.EXPECT().HealthCheck().DoAndReturn(func() *datasource.Health {
+		calls++
+
+		if calls < 3 {
+			return &datasource.Health{Status: datasource.StatusDown}
+		}
+
+		return &datasource.Health{Status: datasource.StatusUp}
+	}).Times(3)
+
+	start := mocks.Clock.Now()
+	done := make(chan error)
+
+	go func() {
+		done <- c.WaitForDependencies(context.Background(), "sql")
+	}()
+
+	// the retries back off exponentially, from 100ms
+	mocks.Clock.BlockUntil(1)
+	mocks.Clock.Advance(100 * time.Millisecond)
+	mocks.Clock.BlockUntil(1)
+	mocks.Clock.Advance(200 * time.Millisecond)
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 300*time.Millisecond, mocks.Clock.Since(start))
+	assert.False(t, c.waitingForDependencies.Load())
+}
+
+func TestContainer_WaitForDependencies_Timeout(t *testing.T) {
+	c, mocks := NewMockContainer(t)
+
+	mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp}).AnyTimes()
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusDown}).AnyTimes()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error)
+
+	go func() {
+		done <- c.WaitForDependencies(ctx, "sql", "redis", "mongo")
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	// the application is not ready while it waits for its dependencies
+	assert.Equal(t, datasource.StatusDown, c.Ready(context.Background()).Status)
+
+	err := <-done
+
+	assert.ErrorIs(t, err, errDependenciesUnavailable)
+	assert.EqualError(t, err, "dependencies not available: redis, mongo")
+	assert.Equal(t, datasource.StatusDown, c.Ready(context.Background()).Status)
+}
+
+func TestContainer_WaitForDependencies_All(t *testing.T) {
+	c, mocks := NewMockContainer(t)
+
+	mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp}).Times(2)
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusUp}).Times(2)
+
+	assert.NoError(t, c.WaitForDependencies(context.Background()))
+	assert.Equal(t, datasource.StatusUp, c.Ready(context.Background()).Status)
+}
+
+func TestContainer_StartWarmUp(t *testing.T) {
+	c, mocks := NewMockContainer(t)
+
+	mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp}).AnyTimes()
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusUp}).AnyTimes()
+
+	done := c.StartWarmUp()
+
+	// the application is not ready while it warms up
+	assert.Equal(t, datasource.StatusDown, c.Ready(context.Background()).Status)
+
+	done()
+
+	assert.Equal(t, datasource.StatusUp, c.Ready(context.Background()).Status)
+}
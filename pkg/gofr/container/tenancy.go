@@ -0,0 +1,124 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/redis"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+const defaultTenantSchemaPrefix = "tenant_"
+
+// errNoTenantDatasource is returned for the tenants of a datasource which is not configured, or does not support them.
+var errNoTenantDatasource = errors.New("datasource does not support tenants")
+
+// schemaDB is implemented by the SQL datasources with a schema per tenant, like sql.DB.
+type schemaDB interface {
+	WithSchema(schema string) (*sql.DB, error)
+}
+
+// keyPrefixRedis is implemented by the Redis datasources with a key prefix per tenant, like redis.Redis.
+type keyPrefixRedis interface {
+	WithKeyPrefix(prefix func(ctx context.Context) (string, error)) *redis.Redis
+}
+
+// tenancy holds the datasources of the tenants, which are created on their first use.
+type tenancy struct {
+	schemaPrefix string
+
+	mu    sync.Mutex
+	sql   map[string]*sql.DB
+	redis Redis
+}
+
+// TenantSQL returns the SQL datasource using the schema of the tenant of ctx, like "tenant_acme".
+func (c *Container) TenantSQL(ctx context.Context) (DB, error) {
+	id := tenant.FromContext(ctx)
+	if id == "" {
+		return nil, tenant.ErrNoTenant
+	}
+
+	db, ok := c.SQL.(schemaDB)
+	if !ok || isNil(c.SQL) {
+		return nil, errNoTenantDatasource
+	}
+
+	c.tenancy.mu.Lock()
+	defer c.tenancy.mu.Unlock()
+
+	if tdb, ok := c.tenancy.sql[id]; ok {
+		return tdb, nil
+	}
+
+	tdb, err := db.WithSchema(c.tenancy.schemaPrefix + id)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.tenancy.sql == nil {
+		c.tenancy.sql = make(map[string]*sql.DB)
+	}
+
+	c.tenancy.sql[id] = tdb
+
+	return tdb, nil
+}
+
+// TenantRedis returns the Redis datasource whose keys are prefixed with the tenant, like "acme:cart", or nil.
+func (c *Container) TenantRedis() Redis {
+	r, ok := c.Redis.(keyPrefixRedis)
+	if !ok || isNil(c.Redis) {
+		return nil
+	}
+
+	c.tenancy.mu.Lock()
+	defer c.tenancy.mu.Unlock()
+
+	if c.tenancy.redis == nil {
+		c.tenancy.redis = r.WithKeyPrefix(tenantKeyPrefix)
+	}
+
+	return c.tenancy.redis
+}
+
+func tenantKeyPrefix(ctx context.Context) (string, error) {
+	id := tenant.FromContext(ctx)
+	if id == "" {
+		return "", tenant.ErrNoTenant
+	}
+
+	return id, nil
+}
+
+// close closes the connections of the SQL datasources of the tenants.
+func (t *tenancy) close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var errs []error
+
+	for id, db := range t.sql {
+		errs = append(errs, db.Close())
+		delete(t.sql, id)
+	}
+
+	return errors.Join(errs...)
+}
+
+// tenantGuardFrom returns the tenant guard of the TENANT_GUARD_* configs.
+func tenantGuardFrom(conf config.Config) sql.TenantGuard {
+	var tables []string
+
+	for _, table := range strings.Split(conf.Get("TENANT_GUARD_TABLES"), ",") {
+		if table = strings.TrimSpace(table); table != "" {
+			tables = append(tables, table)
+		}
+	}
+
+	return sql.TenantGuard{Tables: tables, Column: conf.Get("TENANT_GUARD_COLUMN"), Setting: conf.Get("TENANT_GUARD_SETTING")}
+}
@@ -0,0 +1,92 @@
+package container
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+func TestContainer_TenantSQL(t *testing.T) {
+	dir := t.TempDir()
+
+	c := NewContainer(config.NewMockConfig(map[string]string{
+		"DB_DIALECT": "sqlite",
+		"DB_NAME":    filepath.Join(dir, "app.db"),
+	}))
+
+	defer c.Close()
+
+	_, err := c.TenantSQL(context.Background())
+	assert.Equal(t, tenant.ErrNoTenant, err)
+
+	for _, id := range []string{"acme", "globex"} {
+		db, err := c.TenantSQL(tenant.NewContext(context.Background(), id))
+		require.NoError(t, err)
+
+		_, err = db.Exec("CREATE TABLE orders (tenant TEXT)")
+		require.NoError(t, err)
+
+		_, err = db.Exec("INSERT INTO orders VALUES (?)", id)
+		require.NoError(t, err)
+	}
+
+	acme, err := c.TenantSQL(tenant.NewContext(context.Background(), "acme"))
+	require.NoError(t, err)
+
+	var name string
+
+	require.NoError(t, acme.QueryRow("SELECT tenant FROM orders").Scan(&name))
+	assert.Equal(t, "acme", name)
+
+	assert.FileExists(t, filepath.Join(dir, "tenant_acme.db"))
+	assert.FileExists(t, filepath.Join(dir, "tenant_globex.db"))
+
+	require.NoError(t, c.Close())
+	assert.Empty(t, c.tenancy.sql)
+}
+
+func TestContainer_TenantSQL_NotConfigured(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{}))
+
+	_, err := c.TenantSQL(tenant.NewContext(context.Background(), "acme"))
+
+	assert.Equal(t, errNoTenantDatasource, err)
+	assert.Nil(t, c.TenantRedis())
+}
+
+func TestContainer_TenantRedis(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	c := NewContainer(config.NewMockConfig(map[string]string{"REDIS_HOST": s.Host(), "REDIS_PORT": s.Port()}))
+
+	defer c.Close()
+
+	r := c.TenantRedis()
+	assert.Same(t, r, c.TenantRedis())
+
+	require.NoError(t, r.Set(tenant.NewContext(context.Background(), "acme"), "cart", "1", 0).Err())
+	assert.Equal(t, []string{"acme:cart"}, s.Keys())
+
+	assert.ErrorIs(t, r.Get(context.Background(), "cart").Err(), tenant.ErrNoTenant)
+}
+
+func TestTenantGuardFrom(t *testing.T) {
+	g := tenantGuardFrom(config.NewMockConfig(map[string]string{
+		"TENANT_GUARD_TABLES":  "orders, invoices,,",
+		"TENANT_GUARD_SETTING": "app.tenant",
+	}))
+
+	assert.Equal(t, sql.TenantGuard{Tables: []string{"orders", "invoices"}, Setting: "app.tenant"}, g)
+	assert.Empty(t, tenantGuardFrom(config.NewMockConfig(map[string]string{})).Tables)
+}
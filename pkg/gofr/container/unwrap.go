@@ -0,0 +1,58 @@
+package container
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var errNoUnderlyingClient = errors.New("datasource not configured, or it does not expose its client")
+
+// sqlUnwrapper is implemented by the SQL datasources which expose their pool, like sql.DB.
+type sqlUnwrapper interface {
+	Unwrap() *sql.DB
+}
+
+// redisUnwrapper is implemented by the Redis datasources which expose their client, like redis.Redis.
+type redisUnwrapper interface {
+	Unwrap() *redis.Client
+}
+
+// SQLDB returns the pool of the SQL datasource, for the libraries which take a *sql.DB. See sql.DB.Unwrap.
+//
+//	Usage:
+//	db, err := ctx.SQLDB()
+//	if err != nil {
+//		return nil, err
+//	}
+//
+//	queries := orders.New(db)
+func (c *Container) SQLDB() (*sql.DB, error) {
+	u, ok := c.SQL.(sqlUnwrapper)
+	if !ok || isNil(c.SQL) {
+		return nil, errNoUnderlyingClient
+	}
+
+	db := u.Unwrap()
+	if db == nil {
+		return nil, errNoUnderlyingClient
+	}
+
+	return db, nil
+}
+
+// RedisClient returns the client of the Redis datasource, for the libraries which take a *redis.Client.
+func (c *Container) RedisClient() (*redis.Client, error) {
+	u, ok := c.Redis.(redisUnwrapper)
+	if !ok || isNil(c.Redis) {
+		return nil, errNoUnderlyingClient
+	}
+
+	client := u.Unwrap()
+	if client == nil {
+		return nil, errNoUnderlyingClient
+	}
+
+	return client, nil
+}
@@ -0,0 +1,71 @@
+package container
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+func TestContainer_SQLDB(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{
+		"DB_DIALECT": "sqlite",
+		"DB_NAME":    filepath.Join(t.TempDir(), "app.db"),
+	}))
+
+	defer c.Close()
+
+	db, err := c.SQLDB()
+	require.NoError(t, err)
+
+	_, err = db.Exec("CREATE TABLE orders (id INTEGER)")
+	require.NoError(t, err)
+
+	_, err = c.SQL.Exec("INSERT INTO orders VALUES (1)")
+	require.NoError(t, err)
+
+	var id int
+
+	require.NoError(t, db.QueryRow("SELECT id FROM orders").Scan(&id))
+	assert.Equal(t, 1, id)
+}
+
+func TestContainer_RedisClient(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	c := NewContainer(config.NewMockConfig(map[string]string{"REDIS_HOST": s.Host(), "REDIS_PORT": s.Port()}))
+
+	defer c.Close()
+
+	client, err := c.RedisClient()
+	require.NoError(t, err)
+
+	require.NoError(t, client.Set(context.Background(), "cart", "1", 0).Err())
+
+	v, err := c.Redis.Get(context.Background(), "cart").Result()
+	require.NoError(t, err)
+	assert.Equal(t, "1", v)
+}
+
+func TestContainer_Unwrap_NotConfigured(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{}))
+
+	_, err := c.SQLDB()
+	assert.Equal(t, errNoUnderlyingClient, err)
+
+	_, err = c.RedisClient()
+	assert.Equal(t, errNoUnderlyingClient, err)
+
+	mock, _ := NewMockContainer(t)
+
+	_, err = mock.SQLDB()
+	assert.Equal(t, errNoUnderlyingClient, err)
+}
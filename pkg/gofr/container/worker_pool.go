@@ -0,0 +1,162 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	defaultBackgroundWorkers   = 10
+	defaultBackgroundQueueSize = 100
+)
+
+var (
+	errWorkerPoolFull   = errors.New("background task queue is full")
+	errWorkerPoolClosed = errors.New("background worker pool is shut down")
+	errTaskPanicked     = errors.New("panicked")
+)
+
+// BackgroundTask is the work scheduled on the background worker pool using Go.
+type BackgroundTask func(ctx context.Context) error
+
+type backgroundTask struct {
+	ctx  context.Context
+	name string
+	fn   BackgroundTask
+}
+
+// workerPool runs background tasks on a fixed number of workers, queueing up to queueSize tasks when all of them are busy.
+type workerPool struct {
+	workers   int
+	queueSize int
+
+	once  sync.Once
+	tasks chan backgroundTask
+
+	// mu guards closed, so that no task is queued once the pool starts draining.
+	mu      sync.RWMutex
+	closed  bool
+	pending sync.WaitGroup
+}
+
+// Go runs the task in the background on the worker pool, with a context which is not cancelled along with ctx.
+// It returns an error if the pool is full or shut down.
+func (c *Container) Go(ctx context.Context, name string, task BackgroundTask) error {
+	p := &c.workerPool
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return errWorkerPoolClosed
+	}
+
+	p.once.Do(func() { c.startWorkers() })
+
+	p.pending.Add(1)
+
+	select {
+	case p.tasks <- backgroundTask{ctx: context.WithoutCancel(ctx), name: name, fn: task}:
+		return nil
+	default:
+		p.pending.Done()
+
+		if m := c.Metrics(); m != nil {
+			m.IncrementCounter(ctx, "app_background_task_dropped", "task", name)
+		}
+
+		return errWorkerPoolFull
+	}
+}
+
+// ShutdownWorkers waits for the background tasks to complete, or for ctx to be done.
+func (c *Container) ShutdownWorkers(ctx context.Context) error {
+	p := &c.workerPool
+
+	p.mu.Lock()
+
+	// closing the queue lets the workers exit once they have run the queued tasks.
+	if !p.closed && p.tasks != nil {
+		close(p.tasks)
+	}
+
+	p.closed = true
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		p.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Container) startWorkers() {
+	p := &c.workerPool
+
+	if p.workers <= 0 {
+		p.workers = defaultBackgroundWorkers
+	}
+
+	if p.queueSize <= 0 {
+		p.queueSize = defaultBackgroundQueueSize
+	}
+
+	p.tasks = make(chan backgroundTask, p.queueSize)
+
+	for i := 0; i < p.workers; i++ {
+		go func() {
+			for t := range p.tasks {
+				c.runBackgroundTask(t)
+				p.pending.Done()
+			}
+		}()
+	}
+}
+
+func (c *Container) runBackgroundTask(t backgroundTask) {
+	ctx, cancel := c.JobContext(t.ctx, 0)
+	defer cancel()
+
+	ctx, span := otel.GetTracerProvider().Tracer("gofr-background").Start(ctx, t.name)
+	defer span.End()
+
+	start := time.Now()
+
+	var err error
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", errTaskPanicked, r)
+		}
+
+		if err != nil {
+			c.Errorf("background task %s failed, error: %v", t.name, err)
+		}
+
+		m := c.Metrics()
+		if m == nil {
+			return
+		}
+
+		if err != nil {
+			m.IncrementCounter(ctx, "app_background_task_failures", "task", t.name)
+		}
+
+		m.RecordHistogram(ctx, "app_background_task_duration", time.Since(start).Seconds(), "task", t.name)
+	}()
+
+	err = t.fn(ctx)
+}
@@ -0,0 +1,91 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+func TestContainer_Go(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{"BACKGROUND_WORKERS": "2"}))
+	c.Logger = logging.NewMockLogger(logging.ERROR)
+
+	type ctxKey struct{}
+
+	reqCtx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "request"))
+
+	var (
+		runs  int32
+		value = make(chan interface{}, 1)
+	)
+
+	err := c.Go(reqCtx, "read-value", func(ctx context.Context) error {
+		// the task outlives the request which scheduled it
+		<-time.After(20 * time.Millisecond)
+
+		value <- ctx.Value(ctxKey{})
+
+		return ctx.Err()
+	})
+	assert.NoError(t, err)
+
+	cancel()
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, c.Go(context.Background(), "count", func(context.Context) error {
+			atomic.AddInt32(&runs, 1)
+
+			return nil
+		}))
+	}
+
+	assert.NoError(t, c.Go(context.Background(), "panic", func(context.Context) error { panic("boom") }))
+	assert.NoError(t, c.Go(context.Background(), "fail", func(context.Context) error { return errors.New("failed") }))
+
+	assert.NoError(t, c.ShutdownWorkers(context.Background()))
+
+	assert.Equal(t, "request", <-value)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&runs))
+
+	err = c.Go(context.Background(), "after-shutdown", func(context.Context) error { return nil })
+	assert.Equal(t, errWorkerPoolClosed, err)
+
+	// shutting down again is a no-op
+	assert.NoError(t, c.ShutdownWorkers(context.Background()))
+}
+
+func TestContainer_Go_QueueFull(t *testing.T) {
+	c := NewContainer(config.NewMockConfig(map[string]string{"BACKGROUND_WORKERS": "1", "BACKGROUND_QUEUE_SIZE": "1"}))
+	c.Logger = logging.NewMockLogger(logging.ERROR)
+
+	release := make(chan struct{})
+	blocking := func(context.Context) error {
+		<-release
+
+		return nil
+	}
+
+	assert.NoError(t, c.Go(context.Background(), "running", blocking))
+
+	time.Sleep(20 * time.Millisecond)
+
+	assert.NoError(t, c.Go(context.Background(), "queued", blocking))
+	assert.Equal(t, errWorkerPoolFull, c.Go(context.Background(), "dropped", blocking))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// the tasks are still running when the shutdown deadline is reached
+	assert.Equal(t, context.DeadlineExceeded, c.ShutdownWorkers(ctx))
+
+	close(release)
+
+	assert.NoError(t, c.ShutdownWorkers(context.Background()))
+}
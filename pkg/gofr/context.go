@@ -2,11 +2,16 @@ package gofr
 
 import (
 	"context"
+	"math"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/cmd/terminal"
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
 )
 
 type Context struct {
@@ -20,6 +25,12 @@ type Context struct {
 	// Same logic as above.
 	*container.Container
 
+	// Out writes the output of the sub-commands of CMD applications. It is nil for the other applications.
+	Out *terminal.Output
+
+	// tx is the transaction of the request, if its route is Transactional.
+	tx *gofrSQL.Tx
+
 	// responder is private as Handlers do not need to worry about how to respond. But it is still an abstraction over
 	// normal response writer as we want to keep the context independent of http. Will help us in writing CMD application
 	// or gRPC servers etc using the same handler signature.
@@ -54,6 +65,48 @@ func (c *Context) Trace(name string) trace.Span {
 	return span
 }
 
+// Go runs fn in the background on the worker pool of the application, which outlives the request.
+// It returns an error if the pool is full or shutting down.
+func (c *Context) Go(name string, fn func(ctx *Context) error) error {
+	id := tenant.FromContext(c.Context)
+
+	return c.Container.Go(c.Context, name, func(ctx context.Context) error {
+		if id != "" {
+			ctx = tenant.NewContext(ctx, id)
+		}
+
+		return fn(&Context{
+			Context:   ctx,
+			Container: c.Container,
+			Request:   noopRequest{},
+		})
+	})
+}
+
+// Tenant returns the tenant of the request, or "".
+func (c *Context) Tenant() string {
+	return tenant.FromContext(c.Context)
+}
+
+// RemainingBudget returns the time left before the deadline of the request.
+//
+//	Usage:
+//	if ctx.RemainingBudget() < 200*time.Millisecond {
+//		return cachedReport(ctx)
+//	}
+func (c *Context) RemainingBudget() time.Duration {
+	deadline, ok := c.Context.Deadline()
+	if !ok {
+		return math.MaxInt64
+	}
+
+	if remaining := time.Until(deadline); remaining > 0 {
+		return remaining
+	}
+
+	return 0
+}
+
 func (c *Context) Bind(i interface{}) error {
 	return c.Request.Bind(i)
 }
@@ -3,8 +3,10 @@ package gofr
 import (
 	"bytes"
 	"context"
+	"math"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -35,3 +37,43 @@ func Test_newContextSuccess(t *testing.T) {
 	assert.Equal(t, map[string]string{"key": "value"}, body, "TEST Failed \n unable to read body")
 	assert.Nil(t, err, "TEST Failed \n unable to read body")
 }
+
+func TestContext_Go(t *testing.T) {
+	c := container.NewContainer(config.NewMockConfig(map[string]string{}))
+	ctx := &Context{Context: context.Background(), Container: c}
+
+	done := make(chan string, 1)
+
+	err := ctx.Go("background", func(ctx *Context) error {
+		done <- ctx.Request.HostName()
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "gofr", <-done)
+
+	app := &App{container: c}
+	assert.NoError(t, app.Shutdown(context.Background()))
+}
+
+func TestContext_RemainingBudget(t *testing.T) {
+	ctx := &Context{Context: context.Background()}
+
+	assert.Equal(t, time.Duration(math.MaxInt64), ctx.RemainingBudget())
+
+	deadlineCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	ctx.Context = deadlineCtx
+
+	budget := ctx.RemainingBudget()
+	assert.True(t, budget > 50*time.Second && budget <= time.Minute, "unexpected budget %v", budget)
+
+	expiredCtx, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	ctx.Context = expiredCtx
+
+	assert.Equal(t, time.Duration(0), ctx.RemainingBudget())
+}
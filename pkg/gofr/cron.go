@@ -4,49 +4,80 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
 	"github.com/peter-stratton/gofr/pkg/gofr/version"
 )
 
 const (
-	minutes       = 59
-	hrs           = 23
-	days          = 31
-	months        = 12
-	dayOfWeek     = 6
-	scheduleParts = 5
+	seconds                  = 59
+	minutes                  = 59
+	hrs                      = 23
+	days                     = 31
+	months                   = 12
+	dayOfWeek                = 6
+	scheduleParts            = 5
+	scheduleWithSecondsParts = 6
 )
 
+// CronFunc is the function executed by a cron job. A panic in the function is recovered and recorded as a failure of the job.
 type CronFunc func(ctx *Context)
 
 // Crontab maintains the job scheduling and runs the jobs at their scheduled time by
 // going through them at each tick using a ticker.
 type Crontab struct {
 	// contains unexported fields
-	ticker    *time.Ticker
+	ticker    clock.Ticker
 	jobs      []*job
 	container *container.Container
 
 	mu sync.RWMutex
+	// runs tracks the runs in progress, so that they are waited for on shutdown.
+	runs sync.WaitGroup
+	// leader runs the jobs only on the leader of the replicas of the application, if it is set.
+	leader LeaderElector
 }
 
 type job struct {
+	// sec is nil for schedules without seconds, which run once in each matching minute.
+	sec       map[int]struct{}
 	min       map[int]struct{}
 	hour      map[int]struct{}
 	day       map[int]struct{}
 	month     map[int]struct{}
 	dayOfWeek map[int]struct{}
 
-	name string
-	fn   CronFunc
+	name     string
+	schedule string
+	fn       CronFunc
+
+	// running is set while the job is being executed, so that a run is skipped if the previous one is still in progress.
+	running int32
+	// lastSlot is the minute in which a job without seconds, or the interval in which an interval job, was last run.
+	lastSlot time.Time
+	// interval is set for the jobs added using AddIntervalJob, which run once in every interval.
+	interval time.Duration
+	// at is set for the jobs added using ScheduleOnce, which run once at that time and are done afterwards.
+	at   time.Time
+	done bool
+	// jitter is the maximum random delay of each run of the job.
+	jitter time.Duration
+	// gracePeriod is the time a run is given to complete once the application starts shutting down.
+	gracePeriod time.Duration
+	// lock makes the job run on only one instance of the application at a time, if set.
+	lock *cronLock
+	// history tracks the runs of the job on this instance.
+	history cronHistory
 }
 
 type tick struct {
@@ -55,18 +86,19 @@ type tick struct {
 	day       int
 	month     int
 	dayOfWeek int
+	sec       int
 }
 
 // NewCron initializes and returns new cron tab.
 func NewCron(cntnr *container.Container) *Crontab {
 	c := &Crontab{
-		ticker:    time.NewTicker(time.Minute),
+		ticker:    cntnr.Clock().NewTicker(time.Second),
 		container: cntnr,
 		jobs:      make([]*job, 0),
 	}
 
 	go func() {
-		for t := range c.ticker.C {
+		for t := range c.ticker.C() {
 			c.runScheduled(t)
 		}
 	}()
@@ -74,6 +106,32 @@ func NewCron(cntnr *container.Container) *Crontab {
 	return c
 }
 
+// WithGracePeriod sets the time a run is given to complete on shutdown. It defaults to JOB_GRACE_PERIOD, or 30s.
+func WithGracePeriod(gracePeriod time.Duration) CronOption {
+	return func(j *job) {
+		j.gracePeriod = gracePeriod
+	}
+}
+
+// Stop stops scheduling the jobs and waits for the runs in progress, or for ctx to be done.
+func (c *Crontab) Stop(ctx context.Context) error {
+	c.ticker.Stop()
+
+	done := make(chan struct{})
+
+	go func() {
+		c.runs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // this will compile the regex once instead of compiling it each time when it is being called.
 var (
 	matchSpaces = regexp.MustCompile(`\s+`)
@@ -82,7 +140,7 @@ var (
 )
 
 // parseSchedule parses schedule string and create job struct with filled times to launch,
-// or error if syntax is wrong.
+// or error if syntax is wrong. An optional leading sixth part denotes the seconds.
 func parseSchedule(s string) (*job, error) {
 	var err error
 
@@ -91,7 +149,16 @@ func parseSchedule(s string) (*job, error) {
 	s = strings.Trim(s, " ")
 	parts := strings.Split(s, " ")
 
-	if len(parts) != scheduleParts {
+	switch len(parts) {
+	case scheduleParts:
+	case scheduleWithSecondsParts:
+		j.sec, err = parsePart(parts[0], 0, seconds)
+		if err != nil {
+			return nil, err
+		}
+
+		parts = parts[1:]
+	default:
 		return nil, errBadScheduleFormat
 	}
 
@@ -222,15 +289,71 @@ func (c *Crontab) runScheduled(t time.Time) {
 	jb := make([]*job, n)
 	copy(jb, c.jobs)
 
+	leader := c.leader
+
 	c.mu.Unlock()
 
+	if leader != nil && !leader.IsLeader() {
+		return
+	}
+
 	for _, j := range jb {
-		if j.tick(getTick(t)) {
-			go j.run(c.container)
+		if !j.due(t) {
+			continue
 		}
+
+		c.runs.Add(1)
+
+		go func(j *job) {
+			defer c.runs.Done()
+
+			if j.jitter > 0 {
+				c.container.Clock().Sleep(time.Duration(rand.Int63n(int64(j.jitter)))) //nolint:gosec // jitter does not need a secure random number
+			}
+
+			j.run(c.container)
+		}(j)
 	}
 }
 
+// due reports whether the job is to be run at t, and marks the schedule slot of t as run if so.
+func (j *job) due(t time.Time) bool {
+	switch {
+	case j.interval > 0:
+		slot := t.Truncate(j.interval)
+		if !slot.After(j.lastSlot) {
+			return false
+		}
+
+		j.lastSlot = slot
+
+		return true
+	case !j.at.IsZero():
+		if j.done || t.Before(j.at) {
+			return false
+		}
+
+		j.done = true
+
+		return true
+	}
+
+	if !j.tick(getTick(t)) {
+		return false
+	}
+
+	if j.sec == nil {
+		minute := t.Truncate(time.Minute)
+		if minute.Equal(j.lastSlot) {
+			return false
+		}
+
+		j.lastSlot = minute
+	}
+
+	return true
+}
+
 func getTick(t time.Time) *tick {
 	return &tick{
 		min:       t.Minute(),
@@ -238,14 +361,68 @@ func getTick(t time.Time) *tick {
 		day:       t.Day(),
 		month:     int(t.Month()),
 		dayOfWeek: int(t.Weekday()),
+		sec:       t.Second(),
 	}
 }
 
 func (j *job) run(cntnr *container.Container) {
-	ctx, span := otel.GetTracerProvider().Tracer("gofr-"+version.Framework).
-		Start(context.Background(), j.name)
+	if !atomic.CompareAndSwapInt32(&j.running, 0, 1) {
+		if cntnr != nil && cntnr.Logger != nil {
+			cntnr.Warnf("skipping cron job %s as its previous run is still in progress", j.name)
+		}
+
+		return
+	}
+
+	defer atomic.StoreInt32(&j.running, 0)
+
+	ctx := context.Background()
+
+	if cntnr != nil {
+		var cancel context.CancelFunc
+
+		ctx, cancel = cntnr.JobContext(ctx, j.gracePeriod)
+		defer cancel()
+	}
+
+	ctx, span := otel.GetTracerProvider().Tracer("gofr-"+version.Framework).Start(ctx, j.name)
 	defer span.End()
 
+	if j.lock != nil {
+		// the lock is held in a datasource of the container, without which the job cannot be run safely.
+		if cntnr == nil {
+			return
+		}
+
+		release, acquired := j.holdLock(ctx, cntnr, j.slotEnd(cntnr.Clock().Now()))
+		if !acquired {
+			return
+		}
+
+		defer release()
+	}
+
+	start := cntnr.Clock().Now()
+
+	defer func() {
+		r := recover()
+		failed := r != nil
+
+		j.history.record(start, cntnr.Clock().Since(start), r)
+
+		// the container may not be fully initialised, e.g. when the job runs before the app is created.
+		if cntnr == nil || cntnr.Logger == nil || cntnr.Metrics() == nil {
+			return
+		}
+
+		if failed {
+			cntnr.Errorf("cron job %s panicked", j.name)
+			cntnr.Metrics().IncrementCounter(ctx, "app_cron_job_failures", "job", j.name)
+		}
+
+		cntnr.Metrics().RecordHistogram(ctx, "app_cron_job_duration", cntnr.Clock().Since(start).Seconds(), "job", j.name)
+	}()
+
 	j.fn(&Context{
 		Context:   ctx,
 		Container: cntnr,
@@ -253,7 +430,25 @@ func (j *job) run(cntnr *container.Container) {
 	})
 }
 
+// slotEnd returns the end of the schedule slot of t.
+func (j *job) slotEnd(t time.Time) time.Time {
+	slot := time.Minute
+
+	switch {
+	case j.interval > 0:
+		slot = j.interval
+	case j.sec != nil:
+		slot = time.Second
+	}
+
+	return t.Truncate(slot).Add(slot)
+}
+
 func (j *job) tick(t *tick) bool {
+	if _, ok := j.sec[t.sec]; j.sec != nil && !ok {
+		return false
+	}
+
 	if _, ok := j.min[t.min]; !ok {
 		return false
 	}
@@ -278,23 +473,23 @@ func (j *job) tick(t *tick) bool {
 }
 
 // AddJob to cron tab, returns error if the cron syntax can't be parsed or is out of bounds.
-func (c *Crontab) AddJob(schedule, jobName string, fn CronFunc) error {
+func (c *Crontab) AddJob(schedule, jobName string, fn CronFunc, opts ...CronOption) error {
 	j, err := parseSchedule(schedule)
 	if err != nil {
 		return err
 	}
 
 	j.name = jobName
+	j.schedule = schedule
 	j.fn = fn
 
-	c.mu.Lock()
-	c.jobs = append(c.jobs, j)
-	c.mu.Unlock()
+	c.add(j, opts)
 
 	return nil
 }
 
-var errBadScheduleFormat = errors.New("schedule string must have five components like * * * * *")
+var errBadScheduleFormat = errors.New("schedule string must have five components like * * * * *, " +
+	"or six components like * * * * * * with the first one denoting seconds")
 
 // errOutOfRange denotes the errors that occur when a range in schedule is out of scope for the particular time unit.
 type errOutOfRange struct {
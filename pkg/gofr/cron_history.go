@@ -0,0 +1,140 @@
+package gofr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Results of the runs of cron jobs.
+const (
+	cronRunSucceeded = "SUCCEEDED"
+	cronRunFailed    = "FAILED"
+)
+
+var (
+	errCronJobNotFound = errors.New("cron job not found")
+	errCronJobRunning  = errors.New("cron job is already running")
+)
+
+// cronRun describes the last run of a cron job.
+type cronRun struct {
+	StartedAt time.Time `json:"startedAt"`
+	Duration  float64   `json:"durationSeconds"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// cronHistory tracks the last run of a cron job along with the number of its runs and failures.
+type cronHistory struct {
+	mu       sync.Mutex
+	last     *cronRun
+	runs     int
+	failures int
+}
+
+func (h *cronHistory) record(start time.Time, duration time.Duration, panicked interface{}) {
+	run := &cronRun{StartedAt: start, Duration: duration.Seconds(), Result: cronRunSucceeded}
+
+	if panicked != nil {
+		run.Result = cronRunFailed
+		run.Error = fmt.Sprintf("panicked: %v", panicked)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.last = run
+	h.runs++
+
+	if panicked != nil {
+		h.failures++
+	}
+}
+
+type cronJobStatus struct {
+	Name     string   `json:"name"`
+	Schedule string   `json:"schedule"`
+	Running  bool     `json:"running"`
+	Runs     int      `json:"runs"`
+	Failures int      `json:"failures"`
+	LastRun  *cronRun `json:"lastRun"`
+}
+
+func (j *job) status() cronJobStatus {
+	j.history.mu.Lock()
+	defer j.history.mu.Unlock()
+
+	return cronJobStatus{
+		Name:     j.name,
+		Schedule: j.schedule,
+		Running:  atomic.LoadInt32(&j.running) == 1,
+		Runs:     j.history.runs,
+		Failures: j.history.failures,
+		LastRun:  j.history.last,
+	}
+}
+
+func (c *Crontab) job(name string) *job {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, j := range c.jobs {
+		if j.name == name {
+			return j
+		}
+	}
+
+	return nil
+}
+
+// cronHandler lists the cron jobs along with their last run.
+func cronHandler(c *Crontab) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		c.mu.RLock()
+
+		statuses := make([]cronJobStatus, 0, len(c.jobs))
+		for _, j := range c.jobs {
+			statuses = append(statuses, j.status())
+		}
+
+		c.mu.RUnlock()
+
+		writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": statuses})
+	})
+}
+
+// cronTriggerHandler runs the cron job of the path right away, unless it is running.
+func cronTriggerHandler(c *Crontab) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		j := c.job(mux.Vars(r)["name"])
+		if j == nil {
+			writeAdminError(w, http.StatusNotFound, errCronJobNotFound)
+
+			return
+		}
+
+		if atomic.LoadInt32(&j.running) == 1 {
+			writeAdminError(w, http.StatusConflict, errCronJobRunning)
+
+			return
+		}
+
+		c.container.Infof("cron job %s triggered manually", j.name)
+
+		c.runs.Add(1)
+
+		go func() {
+			defer c.runs.Done()
+
+			j.run(c.container)
+		}()
+
+		writeAdminJSON(w, http.StatusAccepted, map[string]interface{}{"data": j.status()})
+	})
+}
@@ -0,0 +1,125 @@
+package gofr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+)
+
+func newTestCrontab(t *testing.T) *Crontab {
+	t.Helper()
+
+	c, _ := container.NewMockContainer(t)
+
+	// the ticker is stopped, so that the jobs are only run by the tests
+	cron := NewCron(c)
+	cron.ticker.Stop()
+
+	return cron
+}
+
+func TestJob_run_RecordsHistory(t *testing.T) {
+	cron := newTestCrontab(t)
+
+	fail := false
+
+	assert.NoError(t, cron.AddJob("* * * * *", "sync-inventory", func(*Context) {
+		if fail {
+			panic("inventory unavailable")
+		}
+	}))
+
+	j := cron.job("sync-inventory")
+	assert.Nil(t, j.status().LastRun)
+
+	j.run(cron.container)
+
+	status := j.status()
+	assert.Equal(t, "* * * * *", status.Schedule)
+	assert.Equal(t, 1, status.Runs)
+	assert.Equal(t, 0, status.Failures)
+	assert.Equal(t, cronRunSucceeded, status.LastRun.Result)
+	assert.WithinDuration(t, time.Now(), status.LastRun.StartedAt, time.Second)
+
+	fail = true
+
+	j.run(cron.container)
+
+	status = j.status()
+	assert.Equal(t, 2, status.Runs)
+	assert.Equal(t, 1, status.Failures)
+	assert.Equal(t, cronRunFailed, status.LastRun.Result)
+	assert.Equal(t, "panicked: inventory unavailable", status.LastRun.Error)
+}
+
+func TestCronHandler(t *testing.T) {
+	cron := newTestCrontab(t)
+
+	assert.NoError(t, cron.AddJob("* * * * *", "first", func(*Context) {}))
+	assert.NoError(t, cron.AddJob("*/5 * * * * *", "second", func(*Context) {}))
+
+	cron.job("first").run(cron.container)
+
+	w := httptest.NewRecorder()
+
+	cronHandler(cron).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/cron", http.NoBody))
+
+	var body struct {
+		Data []cronJobStatus `json:"data"`
+	}
+
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	if assert.Len(t, body.Data, 2) {
+		assert.Equal(t, "first", body.Data[0].Name)
+		assert.Equal(t, 1, body.Data[0].Runs)
+		assert.Equal(t, "*/5 * * * * *", body.Data[1].Schedule)
+		assert.Nil(t, body.Data[1].LastRun)
+	}
+}
+
+func TestCronTriggerHandler(t *testing.T) {
+	cron := newTestCrontab(t)
+
+	release := make(chan struct{})
+	ran := make(chan struct{}, 1)
+
+	assert.NoError(t, cron.AddJob("0 0 1 1 *", "yearly", func(*Context) {
+		ran <- struct{}{}
+		<-release
+	}))
+
+	router := mux.NewRouter()
+	router.Handle("/cron/{name}/run", cronTriggerHandler(cron)).Methods(http.MethodPost)
+
+	trigger := func(name string) int {
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/cron/"+name+"/run", http.NoBody))
+
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusNotFound, trigger("unknown"))
+	assert.Equal(t, http.StatusAccepted, trigger("yearly"))
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("triggered job was not run")
+	}
+
+	assert.Equal(t, http.StatusConflict, trigger("yearly"), "job should not be triggered while it is running")
+
+	close(release)
+
+	assert.Eventually(t, func() bool { return cron.job("yearly").status().Runs == 1 }, time.Second, 10*time.Millisecond)
+}
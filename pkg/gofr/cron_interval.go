@@ -0,0 +1,55 @@
+package gofr
+
+import (
+	"errors"
+	"time"
+)
+
+var errIntervalTooShort = errors.New("interval of a job must be at least a second")
+
+// WithJitter delays each run of the job by a random duration of up to maxJitter.
+func WithJitter(maxJitter time.Duration) CronOption {
+	return func(j *job) {
+		j.jitter = maxJitter
+	}
+}
+
+// AddIntervalJob adds a job run once in every interval of at least a second, aligned to the clock.
+func (c *Crontab) AddIntervalJob(interval time.Duration, jobName string, fn CronFunc, opts ...CronOption) error {
+	if interval < time.Second {
+		return errIntervalTooShort
+	}
+
+	j := &job{
+		name:     jobName,
+		schedule: "@every " + interval.String(),
+		fn:       fn,
+		interval: interval,
+		// the job is first run at the start of the next interval.
+		lastSlot: c.container.Clock().Now().Truncate(interval),
+	}
+
+	c.add(j, opts)
+
+	return nil
+}
+
+// ScheduleOnce adds a job which is run once at the given time, or right away if the time has passed.
+func (c *Crontab) ScheduleOnce(at time.Time, jobName string, fn CronFunc, opts ...CronOption) {
+	c.add(&job{
+		name:     jobName,
+		schedule: "@at " + at.Format(time.RFC3339),
+		fn:       fn,
+		at:       at,
+	}, opts)
+}
+
+func (c *Crontab) add(j *job, opts []CronOption) {
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	c.mu.Lock()
+	c.jobs = append(c.jobs, j)
+	c.mu.Unlock()
+}
@@ -0,0 +1,112 @@
+package gofr
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
+)
+
+func TestCronTab_AddIntervalJob(t *testing.T) {
+	cron := newTestCrontab(t)
+
+	assert.Equal(t, errIntervalTooShort, cron.AddIntervalJob(500*time.Millisecond, "too-short", func(*Context) {}))
+	assert.NoError(t, cron.AddIntervalJob(10*time.Second, "refresh", func(*Context) {}, WithJitter(time.Second)))
+
+	j := cron.job("refresh")
+
+	assert.Equal(t, "@every 10s", j.schedule)
+	assert.Equal(t, time.Second, j.jitter)
+	assert.Nil(t, cron.job("too-short"))
+}
+
+func TestJob_due_Interval(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 3, 0, time.UTC)
+	j := &job{interval: 10 * time.Second, lastSlot: start.Truncate(10 * time.Second)}
+
+	var runs []time.Time
+
+	for i := 0; i < 30; i++ {
+		if at := start.Add(time.Duration(i) * time.Second); j.due(at) {
+			runs = append(runs, at)
+		}
+	}
+
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 1, 1, 10, 0, 10, 0, time.UTC),
+		time.Date(2024, 1, 1, 10, 0, 20, 0, time.UTC),
+		time.Date(2024, 1, 1, 10, 0, 30, 0, time.UTC),
+	}, runs, "interval job should run once at the start of each interval")
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 40, 0, time.UTC), j.slotEnd(runs[2].Add(time.Second)))
+}
+
+func TestJob_due_Once(t *testing.T) {
+	at := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	j := &job{at: at}
+
+	assert.False(t, j.due(at.Add(-time.Second)))
+	assert.True(t, j.due(at.Add(time.Second)))
+	assert.False(t, j.due(at.Add(2*time.Second)), "job should run only once")
+}
+
+func TestCronTab_runScheduled_WithJitter(t *testing.T) {
+	cron := newTestCrontab(t)
+
+	var runs int32
+
+	cron.ScheduleOnce(time.Now().Add(-time.Minute), "migrate-cache", func(*Context) {
+		atomic.AddInt32(&runs, 1)
+	}, WithJitter(50*time.Millisecond))
+
+	cron.runScheduled(time.Now())
+	cron.runScheduled(time.Now())
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, cronRunSucceeded, cron.job("migrate-cache").status().LastRun.Result)
+}
+
+func TestApp_AddIntervalJob(t *testing.T) {
+	logs := testutil.StderrOutputForFunc(func() {
+		c, _ := container.NewMockContainer(t)
+		c.Logger = logging.NewLogger(logging.ERROR)
+
+		a := &App{container: c}
+
+		a.AddIntervalJob(time.Millisecond, "too-short", func(*Context) {})
+		a.ScheduleOnce(time.Now().Add(time.Hour), "later", func(*Context) {})
+
+		assert.Len(t, a.cron.jobs, 1)
+	})
+
+	assert.Contains(t, logs, errIntervalTooShort.Error())
+}
+
+func TestCronTab_IntervalJob_FakeClock(t *testing.T) {
+	c, mocks := container.NewMockContainer(t, container.WithFakeClock())
+	cron := NewCron(c)
+
+	t.Cleanup(func() { _ = cron.Stop(context.Background()) })
+
+	runs := make(chan time.Time, 10)
+
+	assert.NoError(t, cron.AddIntervalJob(time.Minute, "refresh", func(ctx *Context) { runs <- ctx.Clock().Now() }))
+
+	// the job is first run on the first tick of the next interval, which comes within a second of its start.
+	next := mocks.Clock.Now().Truncate(time.Minute).Add(time.Minute)
+	mocks.Clock.Advance(next.Sub(mocks.Clock.Now()) + time.Second)
+
+	select {
+	case at := <-runs:
+		assert.WithinRange(t, at, next, next.Add(time.Second))
+	case <-time.After(time.Second):
+		t.Fatal("interval job should run at the start of the next interval")
+	}
+
+	assert.Empty(t, runs, "interval job should run once in each interval")
+}
@@ -0,0 +1,263 @@
+package gofr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+const (
+	cronLockKeyPrefix = "gofr:cron:"
+
+	defaultCronLockTTL = time.Minute
+
+	// cronLockRenewals is the number of times a lease is renewed within its TTL while the job is running.
+	cronLockRenewals = 3
+
+	createSQLCronLocksTable = `CREATE TABLE IF NOT EXISTS gofr_cron_locks (
+    name VARCHAR(255) not null primary key,
+    owner VARCHAR(255) not null,
+    expires_at BIGINT not null
+);`
+
+	acquireCronLock = `UPDATE gofr_cron_locks SET owner = ?, expires_at = ? WHERE name = ? AND (expires_at < ? OR owner = ?);`
+	insertCronLock  = `INSERT INTO gofr_cron_locks (name, owner, expires_at) VALUES (?, ?, ?);`
+	renewCronLock   = `UPDATE gofr_cron_locks SET expires_at = ? WHERE name = ? AND owner = ?;`
+
+	// renewCronLockScript extends the lease only if it is still held by the owner.
+	renewCronLockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`
+)
+
+// CronOption configures a cron job added using AddCronJob.
+type CronOption func(j *job)
+
+// WithRedisLock runs the cron job on one instance at a time, holding a lease in Redis. The ttl defaults to a minute.
+func WithRedisLock(ttl time.Duration) CronOption {
+	return func(j *job) {
+		j.lock = newCronLock(cronLockRedis, ttl)
+	}
+}
+
+// WithSQLLock is similar to WithRedisLock, but holds the lease in the gofr_cron_locks table of the SQL database.
+func WithSQLLock(ttl time.Duration) CronOption {
+	return func(j *job) {
+		j.lock = newCronLock(cronLockSQL, ttl)
+	}
+}
+
+type cronLockBackend int
+
+const (
+	cronLockRedis cronLockBackend = iota + 1
+	cronLockSQL
+)
+
+type cronLock struct {
+	backend cronLockBackend
+	ttl     time.Duration
+
+	// cached is reused across the runs of the job, which never run concurrently.
+	cached cronLocker
+}
+
+func newCronLock(backend cronLockBackend, ttl time.Duration) *cronLock {
+	if ttl <= 0 {
+		ttl = defaultCronLockTTL
+	}
+
+	return &cronLock{backend: backend, ttl: ttl}
+}
+
+// cronLocker holds leases on cron jobs identified by name on behalf of an owner.
+type cronLocker interface {
+	acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+	renew(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+}
+
+func (l *cronLock) locker(c *container.Container) (cronLocker, error) {
+	if l.cached != nil {
+		return l.cached, nil
+	}
+
+	var err error
+
+	l.cached, err = l.newLocker(c)
+
+	return l.cached, err
+}
+
+func (l *cronLock) newLocker(c *container.Container) (cronLocker, error) {
+	switch l.backend {
+	case cronLockRedis:
+		if isNil(c.Redis) {
+			return nil, errCronLockBackend{"redis"}
+		}
+
+		return redisCronLocker{c.Redis}, nil
+	case cronLockSQL:
+		if isNil(c.SQL) {
+			return nil, errCronLockBackend{"sql"}
+		}
+
+		return &sqlCronLocker{db: c.SQL}, nil
+	default:
+		return nil, errCronLockBackend{}
+	}
+}
+
+// cronInstanceID identifies this instance of the application as the owner of cron leases.
+func cronInstanceID() string {
+	host, _ := os.Hostname()
+
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// holdLock acquires the lease of the job, kept until the end of the slot once released. It returns false if the
+// lease is held by another instance.
+func (j *job) holdLock(ctx context.Context, c *container.Container, slotEnd time.Time) (release func(), acquired bool) {
+	locker, err := j.lock.locker(c)
+	if err != nil {
+		c.Errorf("could not lock cron job %s, error: %v", j.name, err)
+
+		return nil, false
+	}
+
+	owner := cronInstanceID()
+
+	acquired, err = locker.acquire(ctx, j.name, owner, j.lock.ttl)
+	if err != nil {
+		c.Errorf("could not lock cron job %s, error: %v", j.name, err)
+
+		return nil, false
+	}
+
+	if !acquired {
+		c.Debugf("skipping cron job %s as it is running on another instance", j.name)
+
+		return nil, false
+	}
+
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := c.Clock().NewTicker(j.lock.ttl / cronLockRenewals)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C():
+				if ok, err := locker.renew(ctx, j.name, owner, j.lock.ttl); err != nil || !ok {
+					c.Warnf("could not renew the lock of cron job %s, error: %v", j.name, err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+
+		// a lease must have a positive TTL, so it is shortened to a millisecond once the slot is over.
+		hold := slotEnd.Sub(c.Clock().Now())
+		if hold < time.Millisecond {
+			hold = time.Millisecond
+		}
+
+		if _, err := locker.renew(ctx, j.name, owner, hold); err != nil {
+			c.Warnf("could not release the lock of cron job %s, error: %v", j.name, err)
+		}
+	}, true
+}
+
+type redisCronLocker struct {
+	redis container.Redis
+}
+
+func (r redisCronLocker) acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	return r.redis.SetNX(ctx, cronLockKeyPrefix+name, owner, ttl).Result()
+}
+
+func (r redisCronLocker) renew(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	res, err := r.redis.Eval(ctx, renewCronLockScript, []string{cronLockKeyPrefix + name}, owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return res == 1, nil
+}
+
+type sqlCronLocker struct {
+	db container.DB
+
+	schema gofrSQL.Schema
+}
+
+func (s *sqlCronLocker) acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	if err := s.schema.Create(ctx, s.db, createSQLCronLocksTable); err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl).UnixMilli()
+
+	res, err := s.db.ExecContext(ctx, s.query(acquireCronLock),
+		owner, expiresAt, name, now.UnixMilli(), owner)
+	if err != nil {
+		return false, err
+	}
+
+	if n, _ := res.RowsAffected(); n == 1 {
+		return true, nil
+	}
+
+	// the lease either does not exist yet, or is held by another instance in which case the insert fails.
+	if _, err := s.db.ExecContext(ctx, s.query(insertCronLock), name, owner, expiresAt); err != nil {
+		return false, nil //nolint:nilerr // a failed insert means that the lease is held by another instance
+	}
+
+	return true, nil
+}
+
+func (s *sqlCronLocker) renew(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	res, err := s.db.ExecContext(ctx, s.query(renewCronLock), time.Now().Add(ttl).UnixMilli(), name, owner)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+
+	return n == 1, err
+}
+
+func (s *sqlCronLocker) query(q string) string {
+	return gofrSQL.Rebind(s.db.Dialect(), q)
+}
+
+type errCronLockBackend struct {
+	backend string
+}
+
+func (e errCronLockBackend) Error() string {
+	if e.backend == "" {
+		return "unknown cron lock backend"
+	}
+
+	return fmt.Sprintf("%s is not configured, cron lock cannot be held", strings.ToUpper(e.backend))
+}
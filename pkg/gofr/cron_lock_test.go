@@ -0,0 +1,181 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	gofrSql "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+func newRedisLockContainer(t *testing.T) (*container.Container, *miniredis.Miniredis) {
+	t.Helper()
+
+	s, err := miniredis.Run()
+	assert.NoError(t, err)
+
+	t.Cleanup(s.Close)
+
+	c := container.NewContainer(config.NewMockConfig(map[string]string{
+		"REDIS_HOST": s.Host(),
+		"REDIS_PORT": s.Port(),
+	}))
+
+	return c, s
+}
+
+func TestRedisCronLocker(t *testing.T) {
+	c, s := newRedisLockContainer(t)
+	ctx := context.Background()
+	l := redisCronLocker{c.Redis}
+
+	acquired, err := l.acquire(ctx, "job", "instance-1", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = l.acquire(ctx, "job", "instance-2", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, acquired, "lease held by instance-1 should not be acquired")
+
+	renewed, err := l.renew(ctx, "job", "instance-2", time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, renewed, "lease should only be renewed by its owner")
+	assert.Equal(t, time.Minute, s.TTL("gofr:cron:job"))
+
+	renewed, err = l.renew(ctx, "job", "instance-1", time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, renewed)
+	assert.Equal(t, time.Hour, s.TTL("gofr:cron:job"))
+}
+
+func TestSQLCronLocker(t *testing.T) {
+	db, mock, mockMetrics := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "mysql"})
+	defer db.Close()
+
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", gomock.Any(), "hostname", gomock.Any(),
+		"database", gomock.Any(), "type", gomock.Any()).AnyTimes()
+
+	ctx := context.Background()
+	l := &sqlCronLocker{db: db}
+
+	mock.ExpectExec(createSQLCronLocksTable).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(acquireCronLock).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(insertCronLock).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	acquired, err := l.acquire(ctx, "job", "instance-1", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, acquired, "missing lease should be inserted")
+
+	mock.ExpectExec(acquireCronLock).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(insertCronLock).WillReturnError(errors.New("duplicate entry"))
+
+	acquired, err = l.acquire(ctx, "job", "instance-2", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, acquired, "lease held by another instance should not be acquired")
+
+	mock.ExpectExec(acquireCronLock).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	acquired, err = l.acquire(ctx, "job", "instance-2", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, acquired, "expired lease should be taken over")
+
+	mock.ExpectExec(renewCronLock).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	renewed, err := l.renew(ctx, "job", "instance-2", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, renewed)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLCronLocker_Postgres(t *testing.T) {
+	db, mock, mockMetrics := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "postgres"})
+	defer db.Close()
+
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", gomock.Any(), "hostname", gomock.Any(),
+		"database", gomock.Any(), "type", gomock.Any()).AnyTimes()
+
+	l := &sqlCronLocker{db: db}
+
+	mock.ExpectExec(createSQLCronLocksTable).WillReturnError(errors.New("permission denied"))
+
+	acquired, err := l.acquire(context.Background(), "job", "instance-1", time.Minute)
+	assert.EqualError(t, err, "permission denied")
+	assert.False(t, acquired)
+
+	// the table is created again on the next use, once the error is resolved.
+	mock.ExpectExec(createSQLCronLocksTable).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(gofrSql.Rebind("postgres", acquireCronLock)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	acquired, err = l.acquire(context.Background(), "job", "instance-1", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	mock.ExpectExec(gofrSql.Rebind("postgres", renewCronLock)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	renewed, err := l.renew(context.Background(), "job", "instance-1", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, renewed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestJob_run_WithLock(t *testing.T) {
+	c, s := newRedisLockContainer(t)
+
+	runs := 0
+
+	j, _ := parseSchedule("* * * * * *")
+	j.name = "locked-job"
+	j.fn = func(*Context) { runs++ }
+
+	WithRedisLock(time.Minute)(j)
+
+	// another instance holds the lease
+	assert.NoError(t, s.Set("gofr:cron:locked-job", "another-instance"))
+
+	j.run(c)
+	assert.Equal(t, 0, runs)
+
+	s.Del("gofr:cron:locked-job")
+
+	j.run(c)
+	assert.Equal(t, 1, runs)
+
+	// the lease is kept only until the end of the schedule slot, which is a second for jobs with seconds
+	owner, err := s.Get("gofr:cron:locked-job")
+	assert.NoError(t, err)
+	assert.Equal(t, cronInstanceID(), owner)
+	assert.LessOrEqual(t, s.TTL("gofr:cron:locked-job"), time.Second)
+}
+
+func TestJob_run_WithLock_BackendNotConfigured(t *testing.T) {
+	c, _ := container.NewMockContainer(t)
+	c.SQL = nil
+
+	runs := 0
+
+	j := &job{name: "locked-job", fn: func(*Context) { runs++ }}
+	WithSQLLock(0)(j)
+
+	assert.Equal(t, defaultCronLockTTL, j.lock.ttl)
+
+	j.run(c)
+	assert.Equal(t, 0, runs)
+}
+
+func TestCronTab_AddJob_Options(t *testing.T) {
+	c := NewCron(nil)
+
+	err := c.AddJob("* * * * *", "locked-job", func(*Context) {}, WithRedisLock(time.Second))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &cronLock{backend: cronLockRedis, ttl: time.Second}, c.jobs[0].lock)
+}
@@ -3,11 +3,14 @@ package gofr
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
 	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
 )
 
@@ -104,10 +107,27 @@ func TestCron_parseSchedule_Success(t *testing.T) {
 		j, err := parseSchedule(tc.schedule)
 
 		assert.Nil(t, err)
-		assert.Equal(t, *tc.expJob, *j)
+		assert.Equal(t, tc.expJob, j)
 	}
 }
 
+func TestCron_parseSchedule_Seconds(t *testing.T) {
+	j, err := parseSchedule("*/15 */5 * * * *")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &job{
+		sec:       getDefaultJobField(0, 59, 15),
+		min:       getDefaultJobField(0, 59, 5),
+		hour:      getDefaultJobField(0, 23, 1),
+		day:       getDefaultJobField(1, 31, 1),
+		month:     getDefaultJobField(1, 12, 1),
+		dayOfWeek: getDefaultJobField(0, 6, 1),
+	}, j)
+
+	assert.True(t, j.tick(&tick{min: 5, hour: 1, day: 1, month: 1, dayOfWeek: 1, sec: 30}))
+	assert.False(t, j.tick(&tick{min: 5, hour: 1, day: 1, month: 1, dayOfWeek: 1, sec: 31}))
+}
+
 func TestCron_parseSchedule_Error(t *testing.T) {
 	testCases := []struct {
 		desc         string
@@ -116,7 +136,7 @@ func TestCron_parseSchedule_Error(t *testing.T) {
 	}{
 		{
 			desc:         "incorrect numnber of schedule parts: less",
-			schedules:    []string{"* * * * ", "* * * * * *"},
+			schedules:    []string{"* * * * ", "* * * * * * *"},
 			expErrString: "schedule string must have five components like * * * * *",
 		},
 		{
@@ -130,6 +150,7 @@ func TestCron_parseSchedule_Error(t *testing.T) {
 				"* * * * 0-7",
 				"* * 1-40/2 * *",
 				"60 * * * *",
+				"60 * * * * *",
 			},
 			expErrString: "out of range",
 		},
@@ -175,7 +196,7 @@ func TestCron_getDefaultJobField(t *testing.T) {
 }
 
 func TestCron_getTick(t *testing.T) {
-	expTick := &tick{20, 13, 10, 5, 5}
+	expTick := &tick{20, 13, 10, 5, 5, 1}
 
 	tM := time.Date(2024, 5, 10, 13, 20, 1, 1, time.Local)
 
@@ -236,8 +257,62 @@ func TestCronTab_runScheduled(t *testing.T) {
 	assert.Contains(t, out, "hello from cron")
 }
 
+func TestCronTab_runScheduled_OncePerMinute(t *testing.T) {
+	var runs int32
+
+	j, _ := parseSchedule("* * * * *")
+	j.fn = func(*Context) { atomic.AddInt32(&runs, 1) }
+
+	c := NewCron(nil)
+	c.ticker.Stop()
+	c.jobs = []*job{j}
+
+	start := time.Date(2024, 1, 1, 1, 1, 0, 0, time.Local)
+
+	// jobs without seconds are run only on the first tick of each minute
+	for i := 0; i < 61; i++ {
+		c.runScheduled(start.Add(time.Duration(i) * time.Second))
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&runs))
+}
+
+func TestJob_run_SkipsOverlappingRuns(t *testing.T) {
+	c, _ := container.NewMockContainer(t)
+	c.Create(config.NewMockConfig(map[string]string{}))
+
+	var runs int32
+
+	release := make(chan struct{})
+
+	j := &job{name: "overlap", fn: func(*Context) {
+		atomic.AddInt32(&runs, 1)
+		<-release
+	}}
+
+	go j.run(c)
+
+	time.Sleep(50 * time.Millisecond)
+
+	// the previous run is still in progress, so this one is skipped
+	j.run(c)
+
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+
+	// a panicking job is recovered and can run again
+	j.fn = func(*Context) { panic("cron job failed") }
+
+	assert.NotPanics(t, func() { j.run(c) })
+	assert.Equal(t, int32(0), atomic.LoadInt32(&j.running))
+}
+
 func TestJob_tick(t *testing.T) {
-	tck := &tick{1, 1, 1, 1, 1}
+	tck := &tick{1, 1, 1, 1, 1, 0}
 
 	testCases := []struct {
 		desc string
@@ -313,3 +388,30 @@ func Test_noopRequest(t *testing.T) {
 	assert.Equal(t, "gofr", noop.HostName())
 	assert.Equal(t, nil, noop.Bind(nil))
 }
+
+func TestApp_Shutdown_CancelsCronJobsAfterGracePeriod(t *testing.T) {
+	c, _ := container.NewMockContainer(t)
+	a := &App{container: c, cron: newTestCrontab(t)}
+	a.cron.container = c
+
+	started := make(chan struct{})
+	cause := make(chan error, 1)
+
+	assert.NoError(t, a.cron.AddJob("* * * * * *", "long-running", func(ctx *Context) {
+		close(started)
+
+		<-ctx.Done()
+
+		cause <- context.Cause(ctx)
+	}, WithGracePeriod(10*time.Millisecond)))
+
+	a.cron.runScheduled(time.Now())
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, a.Shutdown(ctx))
+	assert.Equal(t, container.ErrShuttingDown, <-cause)
+}
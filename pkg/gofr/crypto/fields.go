@@ -0,0 +1,225 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const (
+	// TagName is the tag of the struct fields which are encrypted, whose value is the algorithm, AESGCM.
+	TagName = "encrypt"
+	// AESGCM is the algorithm of the encrypted fields, AES-256 in Galois/Counter Mode.
+	AESGCM = "aes-gcm"
+)
+
+var (
+	matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
+	matchAllCap   = regexp.MustCompile("([a-z0-9])([A-Z])")
+
+	// ErrNoKeyring is returned for the encrypted fields when no keyring is configured.
+	ErrNoKeyring = errors.New("encrypted fields require a keyring, which is not configured; set ENCRYPTION_KEYS")
+
+	errUnsupportedAlgorithm = errors.New("unsupported encryption algorithm")
+	errUnsupportedField     = errors.New("only the fields of type string, *string and []byte can be encrypted")
+	errNotStruct            = errors.New("the fields can only be encrypted in a pointer to a struct or to a slice of structs")
+)
+
+// Encrypted reports whether the field is tagged with encrypt:"aes-gcm".
+func Encrypted(f reflect.StructField) (bool, error) {
+	alg, ok := f.Tag.Lookup(TagName)
+
+	switch {
+	case !ok:
+		return false, nil
+	case alg == AESGCM:
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w %q of the field %s", errUnsupportedAlgorithm, alg, f.Name)
+	}
+}
+
+// AdditionalData returns the additional data of the field, "<table>.<column>".
+func AdditionalData(t reflect.Type, f reflect.StructField) []byte {
+	table := toSnakeCase(t.Name())
+	if n, ok := reflect.New(t).Interface().(interface{ TableName() string }); ok {
+		table = n.TableName()
+	}
+
+	column := f.Tag.Get("db")
+	if column == "" {
+		column = toSnakeCase(f.Name)
+	}
+
+	return []byte(table + "." + column)
+}
+
+// EncryptValue returns the encrypted value of a field of type string, *string or []byte.
+func (k *Keyring) EncryptValue(v reflect.Value, additionalData []byte) (interface{}, error) {
+	if k == nil {
+		return nil, ErrNoKeyring
+	}
+
+	switch {
+	case v.Kind() == reflect.String:
+		return k.Seal([]byte(v.String()), additionalData)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		s, err := k.Seal(v.Bytes(), additionalData)
+
+		return []byte(s), err
+	case v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.String:
+		if v.IsNil() {
+			return (*string)(nil), nil
+		}
+
+		s, err := k.Seal([]byte(v.Elem().String()), additionalData)
+
+		return &s, err
+	default:
+		return nil, errUnsupportedField
+	}
+}
+
+// DecryptValue decrypts the value of a field of type string, *string or []byte in place.
+func (k *Keyring) DecryptValue(v reflect.Value, additionalData []byte) error {
+	if k == nil {
+		return ErrNoKeyring
+	}
+
+	switch {
+	case v.Kind() == reflect.String:
+		plaintext, err := k.Open(v.String(), additionalData)
+		if err != nil {
+			return err
+		}
+
+		v.SetString(string(plaintext))
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		if v.IsNil() {
+			return nil
+		}
+
+		plaintext, err := k.Open(string(v.Bytes()), additionalData)
+		if err != nil {
+			return err
+		}
+
+		v.SetBytes(plaintext)
+	case v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.String:
+		if v.IsNil() {
+			return nil
+		}
+
+		return k.DecryptValue(v.Elem(), additionalData)
+	default:
+		return errUnsupportedField
+	}
+
+	return nil
+}
+
+// EncryptFields encrypts the encrypted fields of v, a pointer to a struct or to a slice of structs, in place.
+//
+//	Usage:
+//	type Customer struct {
+//		ID    int
+//		Email string `encrypt:"aes-gcm"`
+//	}
+//
+//	err := keyring.EncryptFields(&customer)
+func (k *Keyring) EncryptFields(v interface{}) error {
+	return eachEncryptedField(v, func(f reflect.Value, field reflect.StructField, additionalData []byte) error {
+		encrypted, err := k.EncryptValue(f, additionalData)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		// the value is converted to the type of the field, which can be a named type, like type SSN string.
+		f.Set(reflect.ValueOf(encrypted).Convert(f.Type()))
+
+		return nil
+	})
+}
+
+// DecryptFields decrypts the encrypted fields of v in place. The fields which cannot be decrypted are zeroed.
+func (k *Keyring) DecryptFields(v interface{}) error {
+	var first error
+
+	err := eachEncryptedField(v, func(f reflect.Value, field reflect.StructField, additionalData []byte) error {
+		if err := k.DecryptValue(f, additionalData); err != nil {
+			f.Set(reflect.Zero(f.Type()))
+
+			if first == nil {
+				first = fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return first
+}
+
+// fieldFunc is called with each encrypted field, and the additional data of its values.
+type fieldFunc func(v reflect.Value, field reflect.StructField, additionalData []byte) error
+
+func eachEncryptedField(v interface{}, f fieldFunc) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errNotStruct
+	}
+
+	rv = rv.Elem()
+
+	switch rv.Kind() { //nolint:exhaustive // only the structs and the slices of structs have fields.
+	case reflect.Struct:
+		return eachStructField(rv, f)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() != reflect.Struct {
+			return errNotStruct
+		}
+
+		for i := 0; i < rv.Len(); i++ {
+			if err := eachStructField(rv.Index(i), f); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return errNotStruct
+	}
+}
+
+func eachStructField(v reflect.Value, f fieldFunc) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		encrypted, err := Encrypted(field)
+		if err != nil {
+			return err
+		}
+
+		if !encrypted {
+			continue
+		}
+
+		if err = f(v.Field(i), field, AdditionalData(v.Type(), field)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toSnakeCase(str string) string {
+	snake := matchFirstCap.ReplaceAllString(str, "${1}_${2}")
+	snake = matchAllCap.ReplaceAllString(snake, "${1}_${2}")
+
+	return strings.ToLower(snake)
+}
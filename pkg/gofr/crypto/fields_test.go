@@ -0,0 +1,145 @@
+package crypto
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type patient struct {
+	ID        int
+	Name      string
+	SSN       string  `encrypt:"aes-gcm"`
+	Notes     []byte  `encrypt:"aes-gcm"`
+	Allergies *string `encrypt:"aes-gcm"`
+	Insurer   *string `encrypt:"aes-gcm"`
+}
+
+func TestKeyring_EncryptDecryptFields(t *testing.T) {
+	keyring, err := NewKeyring(oldKey)
+	require.NoError(t, err)
+
+	allergies := "penicillin"
+	patients := []patient{{ID: 1, Name: "Jane", SSN: "123-45-6789", Notes: []byte("none"), Allergies: &allergies}}
+
+	require.NoError(t, keyring.EncryptFields(&patients))
+
+	p := patients[0]
+
+	assert.Equal(t, "Jane", p.Name)
+	assert.Nil(t, p.Insurer)
+
+	for _, encrypted := range []string{p.SSN, string(p.Notes), *p.Allergies} {
+		assert.Regexp(t, "^enc:v1:", encrypted)
+	}
+
+	assert.Equal(t, "penicillin", allergies, "the pointed string must not be encrypted in place")
+
+	require.NoError(t, keyring.DecryptFields(&p))
+
+	assert.Equal(t, patient{ID: 1, Name: "Jane", SSN: "123-45-6789", Notes: []byte("none"), Allergies: &allergies}, p)
+}
+
+type (
+	ssn  string
+	blob []byte
+)
+
+func TestKeyring_EncryptDecryptNamedFields(t *testing.T) {
+	keyring, err := NewKeyring(oldKey)
+	require.NoError(t, err)
+
+	type record struct {
+		SSN   ssn  `encrypt:"aes-gcm"`
+		Scan  blob `encrypt:"aes-gcm"`
+		Other *ssn `encrypt:"aes-gcm"`
+	}
+
+	other := ssn("987-65-4321")
+	r := record{SSN: "123-45-6789", Scan: blob("scan"), Other: &other}
+
+	require.NoError(t, keyring.EncryptFields(&r))
+
+	for _, encrypted := range []string{string(r.SSN), string(r.Scan), string(*r.Other)} {
+		assert.Regexp(t, "^enc:v1:", encrypted)
+	}
+
+	require.NoError(t, keyring.DecryptFields(&r))
+
+	assert.Equal(t, record{SSN: "123-45-6789", Scan: blob("scan"), Other: &other}, r)
+}
+
+func TestKeyring_FieldsErrors(t *testing.T) {
+	keyring, err := NewKeyring(oldKey)
+	require.NoError(t, err)
+
+	var noKeyring *Keyring
+
+	testCases := []struct {
+		desc    string
+		keyring *Keyring
+		v       interface{}
+		err     error
+	}{
+		{"no keyring", noKeyring, &patient{}, ErrNoKeyring},
+		{"not a pointer", keyring, patient{}, errNotStruct},
+		{"not a struct", keyring, &[]string{}, errNotStruct},
+		{"unsupported field", keyring, &struct {
+			Age int `encrypt:"aes-gcm"`
+		}{}, errUnsupportedField},
+		{"unsupported algorithm", keyring, &struct {
+			SSN string `encrypt:"des"`
+		}{}, errUnsupportedAlgorithm},
+	}
+
+	for i, tc := range testCases {
+		require.ErrorIs(t, tc.keyring.EncryptFields(tc.v), tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		require.ErrorIs(t, tc.keyring.DecryptFields(tc.v), tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestKeyring_DecryptFieldsZeroesFailures(t *testing.T) {
+	keyring, err := NewKeyring(oldKey)
+	require.NoError(t, err)
+
+	p := patient{ID: 1, SSN: "123-45-6789", Notes: []byte("none")}
+	require.NoError(t, keyring.EncryptFields(&p))
+
+	// the SSN is moved to the column of the notes, where it cannot be decrypted.
+	moved := patient{ID: 2, SSN: p.SSN, Notes: []byte(p.SSN)}
+
+	require.ErrorIs(t, keyring.DecryptFields(&moved), ErrInvalidCiphertext)
+	assert.Equal(t, patient{ID: 2, SSN: "123-45-6789"}, moved)
+
+	var noKeyring *Keyring
+
+	require.ErrorIs(t, noKeyring.DecryptFields(&p), ErrNoKeyring)
+	assert.Equal(t, patient{ID: 1}, p, "TEST Failed.\nthe ciphertext is returned as the plaintext")
+}
+
+type account struct {
+	Token string `db:"api_token" encrypt:"aes-gcm"`
+}
+
+func (account) TableName() string {
+	return "users"
+}
+
+func TestAdditionalData(t *testing.T) {
+	field, _ := reflect.TypeOf(patient{}).FieldByName("SSN")
+	assert.Equal(t, "patient.ssn", string(AdditionalData(reflect.TypeOf(patient{}), field)))
+
+	field, _ = reflect.TypeOf(account{}).FieldByName("Token")
+	assert.Equal(t, "users.api_token", string(AdditionalData(reflect.TypeOf(account{}), field)))
+}
+
+func TestEncrypted(t *testing.T) {
+	field, _ := reflect.TypeOf(patient{}).FieldByName("Name")
+
+	encrypted, err := Encrypted(field)
+
+	require.NoError(t, err)
+	assert.False(t, encrypted)
+}
@@ -0,0 +1,229 @@
+// Package crypto provides the encryption of the fields, the hashes of the passwords, the tokens and the TOTPs.
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+const (
+	// prefix starts the encrypted values, followed by the version of their key, like "enc:v2:".
+	prefix = "enc:v"
+
+	keySize = 32
+)
+
+var (
+	// ErrNoKeys is returned for a keyring without any key.
+	ErrNoKeys = errors.New("the keyring has no key")
+	// ErrUnknownKey is returned when decrypting a value encrypted with a key which is not in the keyring.
+	ErrUnknownKey = errors.New("the value is encrypted with a key which is not in the keyring")
+	// ErrInvalidCiphertext is returned when decrypting a value which is not encrypted by a keyring, or is tampered with.
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+	errInvalidKeySize = fmt.Errorf("the keys must be of %d bytes, for AES-256", keySize)
+	errInvalidKeys    = errors.New("ENCRYPTION_KEYS must be a comma separated list of <version>:<base64 key>")
+)
+
+// Key is a versioned key of AES-256.
+type Key struct {
+	Version uint32
+	// Secret is the key, of 32 bytes.
+	Secret []byte
+}
+
+// KeyProvider provides the keys of a keyring, like from a KMS decrypting the data keys stored by the application.
+type KeyProvider interface {
+	Keys(ctx context.Context) ([]Key, error)
+}
+
+// Keyring encrypts the values with the key of the highest version, and decrypts them with any of its keys.
+type Keyring struct {
+	current uint32
+	aeads   map[uint32]cipher.AEAD
+}
+
+// NewKeyring returns the keyring of the keys.
+func NewKeyring(keys ...Key) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	k := &Keyring{aeads: make(map[uint32]cipher.AEAD, len(keys))}
+
+	for _, key := range keys {
+		if len(key.Secret) != keySize {
+			return nil, fmt.Errorf("key %d: %w", key.Version, errInvalidKeySize)
+		}
+
+		block, err := aes.NewCipher(key.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		k.aeads[key.Version] = aead
+
+		if key.Version > k.current {
+			k.current = key.Version
+		}
+	}
+
+	return k, nil
+}
+
+// Load returns the keyring of the keys of the provider.
+func Load(ctx context.Context, p KeyProvider) (*Keyring, error) {
+	keys, err := p.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeyring(keys...)
+}
+
+// FromConfig returns the keyring of ENCRYPTION_KEYS, like "2:<base64 key>,1:<base64 key>", or nil.
+func FromConfig(conf config.Config) (*Keyring, error) {
+	value := conf.Get("ENCRYPTION_KEYS")
+	if value == "" {
+		return nil, nil
+	}
+
+	var keys []Key
+
+	for _, part := range strings.Split(value, ",") {
+		version, secret, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, errInvalidKeys
+		}
+
+		v, err := strconv.ParseUint(version, 10, 32)
+		if err != nil {
+			return nil, errInvalidKeys
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(secret)
+		if err != nil {
+			return nil, errInvalidKeys
+		}
+
+		keys = append(keys, Key{Version: uint32(v), Secret: decoded})
+	}
+
+	return NewKeyring(keys...)
+}
+
+// Versions returns the versions of the keys of the keyring, in increasing order.
+func (k *Keyring) Versions() []uint32 {
+	versions := make([]uint32, 0, len(k.aeads))
+
+	for v := range k.aeads {
+		versions = append(versions, v)
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	return versions
+}
+
+// Encrypt encrypts the plaintext with AES-GCM with the current key, as "enc:v<version>:<base64 nonce and ciphertext>".
+func (k *Keyring) Encrypt(plaintext []byte) (string, error) {
+	return k.Seal(plaintext, nil)
+}
+
+// Seal encrypts the plaintext and authenticates the additional data.
+func (k *Keyring) Seal(plaintext, additionalData []byte) (string, error) {
+	aead := k.aeads[k.current]
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, additionalData)
+
+	return prefix + strconv.FormatUint(uint64(k.current), 10) + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts the value encrypted by Encrypt. The values which are not encrypted are returned as they are.
+func (k *Keyring) Decrypt(value string) ([]byte, error) {
+	return k.Open(value, nil)
+}
+
+// Open decrypts the value encrypted by Seal with the same additional data.
+func (k *Keyring) Open(value string, additionalData []byte) ([]byte, error) {
+	version, ok := versionOf(value)
+	if !ok {
+		return []byte(value), nil
+	}
+
+	aead, ok := k.aeads[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: version %d", ErrUnknownKey, version)
+	}
+
+	_, encoded, _ := strings.Cut(value[len(prefix):], ":")
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+
+	return plaintext, nil
+}
+
+// NeedsRotation reports whether the value is not encrypted with the current key.
+func (k *Keyring) NeedsRotation(value string) bool {
+	version, ok := versionOf(value)
+
+	return !ok || version != k.current
+}
+
+// Rotate encrypts the value again with the current key.
+func (k *Keyring) Rotate(value string, additionalData []byte) (string, error) {
+	plaintext, err := k.Open(value, additionalData)
+	if err != nil {
+		return "", err
+	}
+
+	return k.Seal(plaintext, additionalData)
+}
+
+// versionOf returns the version of the key of the encrypted value, or false if the value is not encrypted.
+func versionOf(value string) (uint32, bool) {
+	if !strings.HasPrefix(value, prefix) {
+		return 0, false
+	}
+
+	version, _, ok := strings.Cut(value[len(prefix):], ":")
+	if !ok {
+		return 0, false
+	}
+
+	v, err := strconv.ParseUint(version, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint32(v), true
+}
@@ -0,0 +1,172 @@
+package crypto
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+var (
+	oldKey = Key{Version: 1, Secret: []byte(strings.Repeat("a", keySize))}
+	newKey = Key{Version: 2, Secret: []byte(strings.Repeat("b", keySize))}
+
+	errKMS = errors.New("kms unavailable")
+)
+
+type keyProvider struct {
+	keys []Key
+	err  error
+}
+
+func (p keyProvider) Keys(context.Context) ([]Key, error) {
+	return p.keys, p.err
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	keyring, err := NewKeyring(oldKey, newKey)
+	require.NoError(t, err)
+
+	encrypted, err := keyring.Encrypt([]byte("jane@example.com"))
+	require.NoError(t, err)
+
+	again, err := keyring.Encrypt([]byte("jane@example.com"))
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(encrypted, "enc:v2:"))
+	assert.NotEqual(t, encrypted, again, "the nonces must differ")
+
+	plaintext, err := keyring.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", string(plaintext))
+
+	plaintext, err = keyring.Decrypt("not encrypted")
+	require.NoError(t, err)
+	assert.Equal(t, "not encrypted", string(plaintext))
+}
+
+func TestKeyring_Rotate(t *testing.T) {
+	old, err := NewKeyring(oldKey)
+	require.NoError(t, err)
+
+	encrypted, err := old.Seal([]byte("jane@example.com"), []byte("customers.email"))
+	require.NoError(t, err)
+
+	keyring, err := NewKeyring(newKey, oldKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint32{1, 2}, keyring.Versions())
+	assert.True(t, keyring.NeedsRotation(encrypted))
+	assert.True(t, keyring.NeedsRotation("jane@example.com"))
+
+	_, err = keyring.Rotate(encrypted, nil)
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	rotated, err := keyring.Rotate(encrypted, []byte("customers.email"))
+	require.NoError(t, err)
+	assert.False(t, keyring.NeedsRotation(rotated))
+
+	plaintext, err := keyring.Open(rotated, []byte("customers.email"))
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", string(plaintext))
+
+	_, err = old.Decrypt(rotated)
+	require.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	keyring, err := NewKeyring(oldKey)
+	require.NoError(t, err)
+
+	sealed, err := keyring.Seal([]byte("123-45-6789"), []byte("patients.ssn"))
+	require.NoError(t, err)
+
+	plaintext, err := keyring.Open(sealed, []byte("patients.ssn"))
+	require.NoError(t, err)
+	assert.Equal(t, "123-45-6789", string(plaintext))
+
+	_, err = keyring.Open(sealed, []byte("patients.name"))
+	require.ErrorIs(t, err, ErrInvalidCiphertext, "TEST Failed.\nthe value is decrypted in another column")
+
+	_, err = keyring.Decrypt(sealed)
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestKeyring_DecryptErrors(t *testing.T) {
+	keyring, err := NewKeyring(oldKey)
+	require.NoError(t, err)
+
+	encrypted, err := keyring.Encrypt([]byte("jane@example.com"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc  string
+		value string
+		err   error
+	}{
+		{"unknown key", "enc:v3:AAAA", ErrUnknownKey},
+		{"invalid base64", "enc:v1:!!!", ErrInvalidCiphertext},
+		{"too short", "enc:v1:AAAA", ErrInvalidCiphertext},
+		{"tampered", encrypted[:len(encrypted)-2] + "AA", ErrInvalidCiphertext},
+	}
+
+	for i, tc := range testCases {
+		_, err := keyring.Decrypt(tc.value)
+
+		require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestNewKeyring_Errors(t *testing.T) {
+	_, err := NewKeyring()
+	require.ErrorIs(t, err, ErrNoKeys)
+
+	_, err = NewKeyring(Key{Version: 1, Secret: []byte("short")})
+	require.ErrorIs(t, err, errInvalidKeySize)
+}
+
+func TestLoad(t *testing.T) {
+	keyring, err := Load(context.Background(), keyProvider{keys: []Key{oldKey, newKey}})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, keyring.Versions())
+
+	_, err = Load(context.Background(), keyProvider{err: errKMS})
+	require.ErrorIs(t, err, errKMS)
+}
+
+func TestFromConfig(t *testing.T) {
+	encoded := func(k Key) string { return base64.StdEncoding.EncodeToString(k.Secret) }
+
+	testCases := []struct {
+		desc     string
+		keys     string
+		versions []uint32
+		err      error
+	}{
+		{desc: "not set"},
+		{desc: "versioned keys", keys: "2:" + encoded(newKey) + ", 1:" + encoded(oldKey), versions: []uint32{1, 2}},
+		{desc: "no version", keys: encoded(newKey), err: errInvalidKeys},
+		{desc: "invalid version", keys: "v2:" + encoded(newKey), err: errInvalidKeys},
+		{desc: "invalid base64", keys: "2:!!!", err: errInvalidKeys},
+		{desc: "invalid key size", keys: "2:c2hvcnQ=", err: errInvalidKeySize},
+	}
+
+	for i, tc := range testCases {
+		keyring, err := FromConfig(config.NewMockConfig(map[string]string{"ENCRYPTION_KEYS": tc.keys}))
+
+		require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.versions == nil {
+			assert.Nil(t, keyring, "TEST[%d], Failed.\n%s", i, tc.desc)
+			continue
+		}
+
+		assert.Equal(t, tc.versions, keyring.Versions(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
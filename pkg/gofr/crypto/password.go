@@ -0,0 +1,234 @@
+package crypto
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+const (
+	// Argon2id hashes the passwords with argon2id, as recommended by OWASP.
+	Argon2id = "argon2id"
+	// Bcrypt hashes the passwords with bcrypt, for the applications sharing their passwords with other systems.
+	Bcrypt = "bcrypt"
+
+	argon2SaltSize = 16
+	argon2KeySize  = 32
+
+	// the params of argon2id are capped, so that a hash cannot make Verify use gigabytes of memory or seconds of CPU.
+	maxArgon2Time   = 10
+	maxArgon2Memory = 1024 * 1024
+)
+
+var (
+	// ErrInvalidHash is returned when verifying a password against a hash which is not one of a Hasher.
+	ErrInvalidHash = errors.New("the hash is not a hash of argon2id or bcrypt")
+
+	errUnsupportedHashAlgorithm = errors.New("PASSWORD_HASH_ALGORITHM must be argon2id or bcrypt")
+	errInvalidHashParams        = errors.New("invalid params of the password hash")
+)
+
+// HasherConfig are the params of the hashes, which default to the minimums recommended by OWASP.
+type HasherConfig struct {
+	// Algorithm is Argon2id, by default, or Bcrypt.
+	Algorithm string
+	// Time is the number of iterations of argon2id, 2 by default.
+	Time uint32
+	// Memory is the memory of argon2id in KiB, 19456 (19 MiB) by default.
+	Memory uint32
+	// Threads is the parallelism of argon2id, 1 by default.
+	Threads uint8
+	// Cost is the cost of bcrypt, 10 by default.
+	Cost int
+}
+
+// Hasher hashes the passwords, and verifies them against their hashes, whatever the params they were hashed with.
+type Hasher struct {
+	config HasherConfig
+}
+
+// NewHasher returns a hasher of the config.
+func NewHasher(c HasherConfig) (*Hasher, error) {
+	if c.Algorithm == "" {
+		c.Algorithm = Argon2id
+	}
+
+	if c.Algorithm != Argon2id && c.Algorithm != Bcrypt {
+		return nil, errUnsupportedHashAlgorithm
+	}
+
+	if c.Time == 0 {
+		c.Time = 2
+	}
+
+	if c.Memory == 0 {
+		c.Memory = 19 * 1024
+	}
+
+	if c.Threads == 0 {
+		c.Threads = 1
+	}
+
+	if c.Cost == 0 {
+		c.Cost = bcrypt.DefaultCost
+	}
+
+	if c.Time > maxArgon2Time || c.Memory > maxArgon2Memory {
+		return nil, fmt.Errorf("%w: argon2id is limited to %d iterations and %d KiB of memory", errInvalidHashParams,
+			maxArgon2Time, maxArgon2Memory)
+	}
+
+	if c.Cost < bcrypt.MinCost || c.Cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("%w: the cost of bcrypt must be between %d and %d", errInvalidHashParams,
+			bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	return &Hasher{config: c}, nil
+}
+
+// HasherFromConfig returns the hasher of PASSWORD_HASH_ALGORITHM, argon2id by default, and its params.
+func HasherFromConfig(conf config.Config) (*Hasher, error) {
+	c := HasherConfig{Algorithm: conf.Get("PASSWORD_HASH_ALGORITHM")}
+
+	params := []struct {
+		name string
+		bits int
+		set  func(uint64)
+	}{
+		{"PASSWORD_ARGON2_TIME", 32, func(v uint64) { c.Time = uint32(v) }},
+		{"PASSWORD_ARGON2_MEMORY", 32, func(v uint64) { c.Memory = uint32(v) }},
+		{"PASSWORD_ARGON2_THREADS", 8, func(v uint64) { c.Threads = uint8(v) }},
+		{"PASSWORD_BCRYPT_COST", 8, func(v uint64) { c.Cost = int(v) }},
+	}
+
+	for _, p := range params {
+		value := conf.Get(p.name)
+		if value == "" {
+			continue
+		}
+
+		v, err := strconv.ParseUint(value, 10, p.bits)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidHashParams, p.name)
+		}
+
+		p.set(v)
+	}
+
+	return NewHasher(c)
+}
+
+// Hash returns the salted hash of the password.
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.config.Algorithm == Bcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.config.Cost)
+
+		return string(hash), err
+	}
+
+	salt, err := RandomBytes(argon2SaltSize)
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.config.Time, h.config.Memory, h.config.Threads, argon2KeySize)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.config.Memory, h.config.Time,
+		h.config.Threads, base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify reports whether the password is the one of the argon2id or bcrypt hash.
+func (*Hasher) Verify(password, hash string) (bool, error) {
+	if isBcrypt(hash) {
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+		}
+
+		return true, nil
+	}
+
+	a, err := parseArgon2(hash)
+	if err != nil {
+		return false, err
+	}
+
+	key := argon2.IDKey([]byte(password), a.salt, a.time, a.memory, a.threads, uint32(len(a.key)))
+
+	return subtle.ConstantTimeCompare(key, a.key) == 1, nil
+}
+
+// NeedsRehash reports whether the hash is not of the algorithm and the params of the hasher.
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if isBcrypt(hash) {
+		cost, err := bcrypt.Cost([]byte(hash))
+
+		return h.config.Algorithm != Bcrypt || err != nil || cost != h.config.Cost
+	}
+
+	a, err := parseArgon2(hash)
+
+	return h.config.Algorithm != Argon2id || err != nil ||
+		a.time != h.config.Time || a.memory != h.config.Memory || a.threads != h.config.Threads
+}
+
+// Equal reports whether a and b are equal, in constant time.
+func Equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+type argon2Hash struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+// parseArgon2 parses the hash encoded by Hash, in the PHC string format.
+func parseArgon2(hash string) (*argon2Hash, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != Argon2id || parts[2] != "v="+strconv.Itoa(argon2.Version) {
+		return nil, ErrInvalidHash
+	}
+
+	var a argon2Hash
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &a.memory, &a.time, &a.threads); err != nil {
+		return nil, ErrInvalidHash
+	}
+
+	// argon2 panics on the params it does not accept.
+	if a.time == 0 || a.time > maxArgon2Time || a.threads == 0 || a.memory < 8*uint32(a.threads) ||
+		a.memory > maxArgon2Memory {
+		return nil, ErrInvalidHash
+	}
+
+	var err error
+
+	if a.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil || len(a.salt) == 0 {
+		return nil, ErrInvalidHash
+	}
+
+	if a.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(a.key) == 0 {
+		return nil, ErrInvalidHash
+	}
+
+	return &a, nil
+}
@@ -0,0 +1,140 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+func TestHasher_HashVerify(t *testing.T) {
+	testCases := []struct {
+		config HasherConfig
+		prefix string
+	}{
+		{HasherConfig{Memory: 1024, Time: 1}, "$argon2id$v=19$m=1024,t=1,p=1$"},
+		{HasherConfig{Algorithm: Bcrypt, Cost: 4}, "$2a$04$"},
+	}
+
+	for i, tc := range testCases {
+		hasher, err := NewHasher(tc.config)
+		require.NoError(t, err, "TEST[%d], Failed.\n", i)
+
+		hash, err := hasher.Hash("correct horse")
+		require.NoError(t, err, "TEST[%d], Failed.\n", i)
+
+		again, err := hasher.Hash("correct horse")
+		require.NoError(t, err, "TEST[%d], Failed.\n", i)
+
+		assert.True(t, strings.HasPrefix(hash, tc.prefix), "TEST[%d], Failed.\n%s", i, hash)
+		assert.NotEqual(t, hash, again, "TEST[%d], Failed.\nthe salts must differ", i)
+
+		ok, err := hasher.Verify("correct horse", hash)
+		require.NoError(t, err, "TEST[%d], Failed.\n", i)
+		assert.True(t, ok, "TEST[%d], Failed.\n", i)
+
+		ok, err = hasher.Verify("battery staple", hash)
+		require.NoError(t, err, "TEST[%d], Failed.\n", i)
+		assert.False(t, ok, "TEST[%d], Failed.\n", i)
+
+		assert.False(t, hasher.NeedsRehash(hash), "TEST[%d], Failed.\n", i)
+	}
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	old, err := NewHasher(HasherConfig{Algorithm: Bcrypt, Cost: 4})
+	require.NoError(t, err)
+
+	hash, err := old.Hash("correct horse")
+	require.NoError(t, err)
+
+	hasher, err := NewHasher(HasherConfig{Memory: 1024, Time: 1})
+	require.NoError(t, err)
+
+	ok, err := hasher.Verify("correct horse", hash)
+	require.NoError(t, err)
+	assert.True(t, ok, "the hashes of the previous algorithm must be verified")
+	assert.True(t, hasher.NeedsRehash(hash))
+
+	hash, err = hasher.Hash("correct horse")
+	require.NoError(t, err)
+
+	stronger, err := NewHasher(HasherConfig{Memory: 2048, Time: 1})
+	require.NoError(t, err)
+
+	assert.True(t, stronger.NeedsRehash(hash))
+	assert.True(t, stronger.NeedsRehash("plaintext"))
+}
+
+func TestHasher_VerifyInvalidHash(t *testing.T) {
+	hasher, err := NewHasher(HasherConfig{})
+	require.NoError(t, err)
+
+	for i, hash := range []string{
+		"plaintext",
+		"$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!!$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$",
+		"$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=0$c2FsdA$a2V5",
+		"$argon2id$v=19$m=7,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=4194304,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=11,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=4096,t=1,p=256$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$$a2V5",
+		"$2a$04$short",
+	} {
+		_, err := hasher.Verify("correct horse", hash)
+
+		require.ErrorIs(t, err, ErrInvalidHash, "TEST[%d], Failed.\n%s", i, hash)
+	}
+}
+
+func TestHasherFromConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config map[string]string
+		hasher *Hasher
+		err    error
+	}{
+		{desc: "defaults", hasher: &Hasher{config: HasherConfig{Algorithm: Argon2id, Time: 2, Memory: 19456, Threads: 1, Cost: 10}}},
+		{desc: "tuned", config: map[string]string{"PASSWORD_HASH_ALGORITHM": "bcrypt", "PASSWORD_BCRYPT_COST": "12",
+			"PASSWORD_ARGON2_TIME": "3", "PASSWORD_ARGON2_MEMORY": "65536", "PASSWORD_ARGON2_THREADS": "4"},
+			hasher: &Hasher{config: HasherConfig{Algorithm: Bcrypt, Time: 3, Memory: 65536, Threads: 4, Cost: 12}}},
+		{desc: "unsupported algorithm", config: map[string]string{"PASSWORD_HASH_ALGORITHM": "md5"},
+			err: errUnsupportedHashAlgorithm},
+		{desc: "invalid param", config: map[string]string{"PASSWORD_ARGON2_THREADS": "256"}, err: errInvalidHashParams},
+		{desc: "too much memory", config: map[string]string{"PASSWORD_ARGON2_MEMORY": "2097152"}, err: errInvalidHashParams},
+		{desc: "invalid cost", config: map[string]string{"PASSWORD_BCRYPT_COST": "40"}, err: errInvalidHashParams},
+	}
+
+	for i, tc := range testCases {
+		hasher, err := HasherFromConfig(config.NewMockConfig(tc.config))
+
+		require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.hasher, hasher, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	assert.True(t, Equal("secret", "secret"))
+	assert.False(t, Equal("secret", "Secret"))
+	assert.False(t, Equal("secret", "secret2"))
+}
+
+func TestRandomToken(t *testing.T) {
+	token, err := RandomToken(32)
+	require.NoError(t, err)
+
+	again, err := RandomToken(32)
+	require.NoError(t, err)
+
+	assert.Len(t, token, 43)
+	assert.NotEqual(t, token, again)
+	assert.NotContains(t, token, "+")
+	assert.NotContains(t, token, "/")
+}
@@ -0,0 +1,28 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+)
+
+// RandomBytes returns n bytes of the cryptographically secure random generator.
+func RandomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// RandomToken returns a token of n random bytes in URL safe base64.
+func RandomToken(n int) (string, error) {
+	b, err := RandomBytes(n)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
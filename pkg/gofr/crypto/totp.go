@@ -0,0 +1,154 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // RFC 6238 uses HMAC-SHA1, which the authenticator apps support.
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const totpSecretSize = 20
+
+var (
+	// errInvalidTOTPSecret is returned for a secret which is not encoded in base32.
+	errInvalidTOTPSecret = errors.New("the TOTP secret must be encoded in base32")
+	// errTOTPBeforeEpoch is returned for the codes of the times before the Unix epoch, which have no counter.
+	errTOTPBeforeEpoch = errors.New("the TOTP codes start at the Unix epoch")
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP generates and validates the time-based one-time passwords of RFC 6238.
+type TOTP struct {
+	// Digits is the number of digits of the codes, 6 by default, and 8 at most for the authenticator apps.
+	Digits int
+	// Period is the period of the codes, 30 seconds by default.
+	Period time.Duration
+	// Skew is the number of periods around the current one whose codes are accepted, 1 by default, or 0 if negative.
+	Skew int
+}
+
+// NewTOTPSecret returns a random secret of 160 bits in base32.
+func NewTOTPSecret() (string, error) {
+	b, err := RandomBytes(totpSecretSize)
+	if err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// Code returns the code of the secret at the time.
+func (t TOTP) Code(secret string, at time.Time) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	if at.Unix() < 0 {
+		return "", errTOTPBeforeEpoch
+	}
+
+	return t.code(key, uint64(at.Unix())/uint64(t.period().Seconds())), nil
+}
+
+// Validate reports whether the code is the one of the secret at the time, within the skew.
+func (t TOTP) Validate(secret, code string, at time.Time) (bool, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return false, err
+	}
+
+	counter := int64(at.Unix()) / int64(t.period().Seconds())
+
+	valid := false
+
+	for i := -t.skew(); i <= t.skew(); i++ {
+		if counter+i < 0 {
+			continue
+		}
+
+		// all the codes are compared, so that the time does not depend on the matching period.
+		if Equal(t.code(key, uint64(counter+i)), code) {
+			valid = true
+		}
+	}
+
+	return valid, nil
+}
+
+// URI returns the otpauth URI of the secret, for the QR codes of the authenticator apps.
+func (t TOTP) URI(secret, issuer, account string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(t.digits()))
+	params.Set("period", fmt.Sprint(int(t.period().Seconds())))
+
+	u := url.URL{Scheme: "otpauth", Host: "totp", Path: "/" + issuer + ":" + account, RawQuery: params.Encode()}
+
+	return u.String()
+}
+
+// code returns the HOTP code of the counter, as of RFC 4226.
+func (t TOTP) code(key []byte, counter uint64) string {
+	var msg [8]byte
+
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint64(1)
+	for i := 0; i < t.digits(); i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", t.digits(), uint64(value)%mod)
+}
+
+func (t TOTP) digits() int {
+	if t.Digits <= 0 {
+		return 6
+	}
+
+	return t.Digits
+}
+
+func (t TOTP) period() time.Duration {
+	if t.Period < time.Second {
+		return 30 * time.Second
+	}
+
+	return t.Period
+}
+
+func (t TOTP) skew() int64 {
+	switch {
+	case t.Skew < 0:
+		return 0
+	case t.Skew == 0:
+		return 1
+	default:
+		return int64(t.Skew)
+	}
+}
+
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	key, err := totpEncoding.DecodeString(strings.TrimRight(strings.ToUpper(strings.ReplaceAll(secret, " ", "")), "="))
+	if err != nil || len(key) == 0 {
+		return nil, errInvalidTOTPSecret
+	}
+
+	return key, nil
+}
@@ -0,0 +1,84 @@
+package crypto
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the secret of the test vectors of RFC 6238 for SHA1.
+var rfc6238Secret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTP_Code(t *testing.T) {
+	testCases := []struct {
+		at   int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	totp := TOTP{Digits: 8}
+
+	for i, tc := range testCases {
+		code, err := totp.Code(rfc6238Secret, time.Unix(tc.at, 0))
+
+		require.NoError(t, err, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, tc.code, code, "TEST[%d], Failed.\n", i)
+	}
+}
+
+func TestTOTP_CodeBeforeEpoch(t *testing.T) {
+	_, err := TOTP{}.Code(rfc6238Secret, time.Unix(-1, 0))
+
+	assert.ErrorIs(t, err, errTOTPBeforeEpoch)
+}
+
+func TestTOTP_Validate(t *testing.T) {
+	secret, err := NewTOTPSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	now := time.Unix(1700000000, 0)
+
+	code, err := TOTP{}.Code(secret, now)
+	require.NoError(t, err)
+	assert.Len(t, code, 6)
+
+	testCases := []struct {
+		desc  string
+		totp  TOTP
+		at    time.Time
+		valid bool
+	}{
+		{"current period", TOTP{}, now, true},
+		{"previous period", TOTP{}, now.Add(30 * time.Second), true},
+		{"next period", TOTP{}, now.Add(-30 * time.Second), true},
+		{"expired", TOTP{}, now.Add(time.Minute), false},
+		{"no skew", TOTP{Skew: -1}, now.Add(30 * time.Second), false},
+		{"larger skew", TOTP{Skew: 2}, now.Add(time.Minute), true},
+	}
+
+	for i, tc := range testCases {
+		valid, err := tc.totp.Validate(secret, code, tc.at)
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.valid, valid, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	_, err = TOTP{}.Validate("not base32!", code, now)
+	require.ErrorIs(t, err, errInvalidTOTPSecret)
+}
+
+func TestTOTP_URI(t *testing.T) {
+	uri := TOTP{}.URI("JBSWY3DPEHPK3PXP", "Acme", "jane@example.com")
+
+	assert.Equal(t, "otpauth://totp/Acme:jane@example.com?algorithm=SHA1&digits=6&issuer=Acme&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+}
@@ -0,0 +1,15 @@
+package datasource
+
+import "context"
+
+// Cassandra is an interface representing a Cassandra cluster client, with the queries of the keyspace of the client.
+type Cassandra interface {
+	// Query executes the CQL query and binds its rows into dest, a pointer to a slice, a struct or a map.
+	Query(ctx context.Context, dest interface{}, stmt string, values ...interface{}) error
+
+	// Exec executes the CQL statement with the values, like an INSERT or a CREATE TABLE.
+	Exec(ctx context.Context, stmt string, values ...interface{}) error
+
+	// ExecCAS executes the lightweight transaction and returns whether it is applied, binding the existing row otherwise.
+	ExecCAS(ctx context.Context, dest interface{}, stmt string, values ...interface{}) (bool, error)
+}
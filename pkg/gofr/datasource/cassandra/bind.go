@@ -0,0 +1,80 @@
+package cassandra
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// bind sets the columns of the row to v, which is a struct or a map[string]interface{}.
+func bind(row map[string]interface{}, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || v.Type().Elem().Kind() != reflect.Interface {
+			return errUnexpectedDestination
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), len(row)))
+		}
+
+		for column, value := range row {
+			v.SetMapIndex(reflect.ValueOf(column), reflect.ValueOf(&value).Elem())
+		}
+
+		return nil
+	case reflect.Struct:
+		return bindStruct(row, v)
+	default:
+		return errUnexpectedDestination
+	}
+}
+
+// bindStruct sets the columns of the row to the fields of their cql tag, or of their name in lower case.
+func bindStruct(row map[string]interface{}, v reflect.Value) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		column := field.Tag.Get("cql")
+		if column == "-" {
+			continue
+		}
+
+		if column == "" {
+			column = strings.ToLower(field.Name)
+		}
+
+		value, ok := row[column]
+		if !ok || value == nil {
+			continue
+		}
+
+		rv := reflect.ValueOf(value)
+
+		switch {
+		case rv.Type().AssignableTo(field.Type):
+			v.Field(i).Set(rv)
+		case convertible(rv.Kind(), field.Type.Kind()):
+			v.Field(i).Set(rv.Convert(field.Type))
+		default:
+			return fmt.Errorf("%w: column %s of type %v cannot be bound to the field %s of type %v",
+				errUnexpectedDestination, column, rv.Type(), field.Name, field.Type)
+		}
+	}
+
+	return nil
+}
+
+// convertible reports whether a value of the kind can be converted to the other kind without changing its meaning.
+func convertible(from, to reflect.Kind) bool {
+	return from == to || isNumber(from) && isNumber(to)
+}
+
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
@@ -0,0 +1,315 @@
+// Package cassandra provides the client of a Cassandra keyspace.
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+const (
+	statsMetric = "app_cassandra_stats"
+
+	defaultPort = 9042
+	// reconnectInterval is the interval between the attempts to connect to a cluster which is not reachable.
+	reconnectInterval = 5 * time.Second
+	healthTimeout     = time.Second
+)
+
+var (
+	// ErrNotConnected is returned by the queries while the cluster is not reachable.
+	ErrNotConnected = errors.New("cassandra is not connected")
+
+	errDestinationIsNotPointer = errors.New("destination must be a non-nil pointer")
+	errUnexpectedDestination   = errors.New("destination must be a slice, a struct or a map[string]interface{}")
+)
+
+type Config struct {
+	// Hosts are the hosts of the cluster.
+	Hosts    []string
+	Keyspace string
+	// Port is the port of the hosts, which is 9042 by default.
+	Port     int
+	Username string
+	Password string
+}
+
+type Client struct {
+	config  Config
+	logger  Logger
+	metrics Metrics
+	// hosts are the hosts of the cluster, which label the metrics.
+	hosts string
+
+	// connect creates the session of the cluster.
+	connect func() (session, error)
+
+	mu          sync.Mutex
+	session     session
+	lastAttempt time.Time
+}
+
+// FromConfig returns the client of CASSANDRA_KEYSPACE on CASSANDRA_HOSTS, or nil if CASSANDRA_HOSTS is not set.
+func FromConfig(conf config.Config) *Client {
+	hosts := conf.Get("CASSANDRA_HOSTS")
+	if hosts == "" {
+		return nil
+	}
+
+	port, err := strconv.Atoi(conf.Get("CASSANDRA_PORT"))
+	if err != nil {
+		port = defaultPort
+	}
+
+	return New(Config{
+		Hosts:    strings.Split(hosts, ","),
+		Keyspace: conf.Get("CASSANDRA_KEYSPACE"),
+		Port:     port,
+		Username: conf.Get("CASSANDRA_USERNAME"),
+		Password: conf.Get("CASSANDRA_PASSWORD"),
+	})
+}
+
+// New returns the client of the keyspace, to be connected with Connect.
+func New(c Config) *Client {
+	if c.Port == 0 {
+		c.Port = defaultPort
+	}
+
+	for i := range c.Hosts {
+		c.Hosts[i] = strings.TrimSpace(c.Hosts[i])
+	}
+
+	client := &Client{config: c, hosts: strings.Join(c.Hosts, ",")}
+	client.connect = client.createSession
+
+	return client
+}
+
+// UseLogger sets the logger for the Cassandra client which asserts the Logger interface.
+func (c *Client) UseLogger(logger interface{}) {
+	if l, ok := logger.(Logger); ok {
+		c.logger = l
+	}
+}
+
+// UseMetrics sets the metrics for the Cassandra client which asserts the Metrics interface.
+func (c *Client) UseMetrics(metrics interface{}) {
+	if m, ok := metrics.(Metrics); ok {
+		c.metrics = m
+	}
+}
+
+// Connect connects to the cluster and registers the metrics.
+func (c *Client) Connect() {
+	cassandraBuckets := []float64{.05, .075, .1, .125, .15, .2, .3, .5, .75, 1, 2, 3, 4, 5, 7.5, 10}
+	c.metrics.NewHistogram(statsMetric, "Response time of CASSANDRA queries in milliseconds.", cassandraBuckets...)
+
+	c.logger.Logf("connecting to cassandra at %v on port %v to keyspace %v", c.hosts, c.config.Port, c.config.Keyspace)
+
+	if _, err := c.getSession(); err != nil {
+		c.logger.Errorf("error connecting to cassandra, err: %v", err)
+	}
+}
+
+func (c *Client) createSession() (session, error) {
+	cluster := gocql.NewCluster(c.config.Hosts...)
+	cluster.Keyspace = c.config.Keyspace
+	cluster.Port = c.config.Port
+
+	if c.config.Username != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{Username: c.config.Username, Password: c.config.Password}
+	}
+
+	s, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return cassandraSession{session: s}, nil
+}
+
+// getSession returns the session of the cluster, reconnecting at most every reconnectInterval.
+func (c *Client) getSession() (session, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.session != nil {
+		return c.session, nil
+	}
+
+	if time.Since(c.lastAttempt) < reconnectInterval {
+		return nil, ErrNotConnected
+	}
+
+	c.lastAttempt = time.Now()
+
+	s, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	c.session = s
+
+	c.logger.Logf("connected to cassandra at %v to keyspace %v", c.hosts, c.config.Keyspace)
+
+	return s, nil
+}
+
+// Query executes the CQL query and binds its rows into dest.
+func (c *Client) Query(ctx context.Context, dest interface{}, stmt string, values ...interface{}) error {
+	defer c.postProcess(stmt, time.Now())
+
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errDestinationIsNotPointer
+	}
+
+	s, err := c.getSession()
+	if err != nil {
+		return err
+	}
+
+	iter := s.query(stmt, values...).withContext(ctx).iter()
+	elem := rv.Elem()
+
+	switch elem.Kind() {
+	case reflect.Slice:
+		for {
+			row := make(map[string]interface{})
+			if !iter.mapScan(row) {
+				break
+			}
+
+			v := reflect.New(elem.Type().Elem()).Elem()
+			if err = bind(row, v); err != nil {
+				_ = iter.close()
+
+				return err
+			}
+
+			elem.Set(reflect.Append(elem, v))
+		}
+	case reflect.Struct, reflect.Map:
+		row := make(map[string]interface{})
+		if iter.mapScan(row) {
+			if err = bind(row, elem); err != nil {
+				_ = iter.close()
+
+				return err
+			}
+		}
+	default:
+		_ = iter.close()
+
+		return errUnexpectedDestination
+	}
+
+	return iter.close()
+}
+
+// Exec executes the CQL statement with the values.
+func (c *Client) Exec(ctx context.Context, stmt string, values ...interface{}) error {
+	defer c.postProcess(stmt, time.Now())
+
+	s, err := c.getSession()
+	if err != nil {
+		return err
+	}
+
+	return s.query(stmt, values...).withContext(ctx).exec()
+}
+
+// ExecCAS executes the lightweight transaction and returns whether it is applied.
+func (c *Client) ExecCAS(ctx context.Context, dest interface{}, stmt string, values ...interface{}) (bool, error) {
+	defer c.postProcess(stmt, time.Now())
+
+	var rv reflect.Value
+
+	if dest != nil {
+		rv = reflect.ValueOf(dest)
+		if rv.Kind() != reflect.Pointer || rv.IsNil() {
+			return false, errDestinationIsNotPointer
+		}
+
+		if k := rv.Elem().Kind(); k != reflect.Struct && k != reflect.Map {
+			return false, errUnexpectedDestination
+		}
+	}
+
+	s, err := c.getSession()
+	if err != nil {
+		return false, err
+	}
+
+	row := make(map[string]interface{})
+
+	applied, err := s.query(stmt, values...).withContext(ctx).mapScanCAS(row)
+	if err != nil || applied || dest == nil {
+		return applied, err
+	}
+
+	return applied, bind(row, rv.Elem())
+}
+
+// HealthCheck reports whether the cluster responds to a query, with its hosts and the keyspace of the client.
+func (c *Client) HealthCheck(ctx context.Context) datasource.Health {
+	h := datasource.Health{
+		Details: map[string]interface{}{"hosts": c.hosts, "keyspace": c.config.Keyspace},
+	}
+
+	s, err := c.getSession()
+	if err != nil {
+		h.Status = datasource.StatusDown
+		h.Details["error"] = err.Error()
+
+		return h
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	if err = s.query("SELECT now() FROM system.local").withContext(ctx).exec(); err != nil {
+		h.Status = datasource.StatusDown
+		h.Details["error"] = err.Error()
+
+		return h
+	}
+
+	h.Status = datasource.StatusUp
+
+	return h
+}
+
+// Close closes the session of the cluster.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.session != nil {
+		c.session.close()
+		c.session = nil
+	}
+
+	return nil
+}
+
+func (c *Client) postProcess(stmt string, startTime time.Time) {
+	duration := time.Since(startTime).Milliseconds()
+
+	c.logger.Debugf("%v", &QueryLog{Query: stmt, Duration: duration, Keyspace: c.config.Keyspace})
+
+	queryType, _, _ := strings.Cut(strings.TrimSpace(stmt), " ")
+
+	c.metrics.RecordHistogram(context.Background(), statsMetric, float64(duration), "hostname", c.hosts,
+		"keyspace", c.config.Keyspace, "type", strings.ToUpper(queryType))
+}
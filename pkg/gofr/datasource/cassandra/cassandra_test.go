@@ -0,0 +1,261 @@
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+var errUnavailable = errors.New("no hosts available in the pool")
+
+type user struct {
+	ID      int64 `cql:"user_id"`
+	Name    string
+	Age     int
+	ignored string
+}
+
+type mocks struct {
+	session *Mocksession
+	query   *Mockquery
+	iter    *Mockiterator
+}
+
+func newClient(t *testing.T) (*Client, mocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	m := mocks{session: NewMocksession(ctrl), query: NewMockquery(ctrl), iter: NewMockiterator(ctrl)}
+
+	logger := NewMockLogger(ctrl)
+	logger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Logf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	metrics := NewMockMetrics(ctrl)
+	metrics.EXPECT().NewHistogram(statsMetric, gomock.Any(), gomock.Any()).AnyTimes()
+	metrics.EXPECT().RecordHistogram(gomock.Any(), statsMetric, gomock.Any(), "hostname", "host1,host2",
+		"keyspace", "app", "type", gomock.Any()).AnyTimes()
+
+	client := New(Config{Hosts: []string{"host1", " host2"}, Keyspace: "app"})
+	client.UseLogger(logger)
+	client.UseMetrics(metrics)
+	client.connect = func() (session, error) { return m.session, nil }
+	client.Connect()
+
+	return client, m
+}
+
+// rows makes the iterator scan the rows, and then the end of the rows unless only the first row is scanned.
+func (m mocks) rows(first bool, rows ...map[string]interface{}) {
+	for _, row := range rows {
+		row := row
+
+		m.iter.EXPECT().mapScan(gomock.Any()).DoAndReturn(func(dest map[string]interface{}) bool {
+			for k, v := range row {
+				dest[k] = v
+			}
+
+			return true
+		})
+	}
+
+	if !first {
+		m.iter.EXPECT().mapScan(gomock.Any()).Return(false)
+	}
+
+	m.iter.EXPECT().close().Return(nil)
+}
+
+func TestClient_Query(t *testing.T) {
+	ctx := context.Background()
+	stmt := "SELECT user_id, name, age FROM users"
+	rows := []map[string]interface{}{
+		{"user_id": int64(1), "name": "Alice", "age": 30},
+		{"user_id": int64(2), "name": "Bob", "age": nil},
+	}
+
+	client, m := newClient(t)
+
+	m.session.EXPECT().query(stmt).Return(m.query).Times(3)
+	m.query.EXPECT().withContext(ctx).Return(m.query).Times(3)
+	m.query.EXPECT().iter().Return(m.iter).Times(3)
+
+	var users []user
+
+	m.rows(false, rows...)
+	require.NoError(t, client.Query(ctx, &users, stmt))
+	assert.Equal(t, []user{{ID: 1, Name: "Alice", Age: 30}, {ID: 2, Name: "Bob"}}, users)
+
+	var first user
+
+	m.rows(true, rows[0])
+	require.NoError(t, client.Query(ctx, &first, stmt))
+	assert.Equal(t, user{ID: 1, Name: "Alice", Age: 30}, first)
+
+	var maps []map[string]interface{}
+
+	m.rows(false, rows...)
+	require.NoError(t, client.Query(ctx, &maps, stmt))
+	assert.Equal(t, rows, maps)
+}
+
+func TestClient_QueryInvalidDestination(t *testing.T) {
+	client, m := newClient(t)
+
+	var (
+		users []user
+		count int
+	)
+
+	assert.Equal(t, errDestinationIsNotPointer, client.Query(context.Background(), users, "SELECT * FROM users"))
+
+	m.session.EXPECT().query(gomock.Any()).Return(m.query)
+	m.query.EXPECT().withContext(gomock.Any()).Return(m.query)
+	m.query.EXPECT().iter().Return(m.iter)
+	m.iter.EXPECT().close().Return(nil)
+
+	assert.Equal(t, errUnexpectedDestination, client.Query(context.Background(), &count, "SELECT count(*) FROM users"))
+
+	var names []struct{ Name int }
+
+	m.session.EXPECT().query(gomock.Any()).Return(m.query)
+	m.query.EXPECT().withContext(gomock.Any()).Return(m.query)
+	m.query.EXPECT().iter().Return(m.iter)
+	m.iter.EXPECT().mapScan(gomock.Any()).DoAndReturn(func(dest map[string]interface{}) bool {
+		dest["name"] = "Alice"
+
+		return true
+	})
+	m.iter.EXPECT().close().Return(nil)
+
+	assert.ErrorIs(t, client.Query(context.Background(), &names, "SELECT name FROM users"), errUnexpectedDestination)
+}
+
+func TestClient_Exec(t *testing.T) {
+	ctx := context.Background()
+	stmt := "INSERT INTO users (user_id, name) VALUES (?, ?)"
+
+	client, m := newClient(t)
+
+	m.session.EXPECT().query(stmt, int64(1), "Alice").Return(m.query)
+	m.query.EXPECT().withContext(ctx).Return(m.query)
+	m.query.EXPECT().exec().Return(nil)
+
+	assert.NoError(t, client.Exec(ctx, stmt, int64(1), "Alice"))
+}
+
+func TestClient_ExecCAS(t *testing.T) {
+	ctx := context.Background()
+	stmt := "INSERT INTO users (user_id, name) VALUES (?, ?) IF NOT EXISTS"
+
+	client, m := newClient(t)
+
+	m.session.EXPECT().query(stmt, int64(1), "Alice").Return(m.query).Times(2)
+	m.query.EXPECT().withContext(ctx).Return(m.query).Times(2)
+	m.query.EXPECT().mapScanCAS(gomock.Any()).Return(true, nil)
+	m.query.EXPECT().mapScanCAS(gomock.Any()).DoAndReturn(func(dest map[string]interface{}) (bool, error) {
+		dest["user_id"], dest["name"] = int64(1), "Alicia"
+
+		return false, nil
+	})
+
+	var existing user
+
+	applied, err := client.ExecCAS(ctx, &existing, stmt, int64(1), "Alice")
+	require.NoError(t, err)
+	assert.True(t, applied)
+	assert.Equal(t, user{}, existing)
+
+	applied, err = client.ExecCAS(ctx, &existing, stmt, int64(1), "Alice")
+	require.NoError(t, err)
+	assert.False(t, applied)
+	assert.Equal(t, user{ID: 1, Name: "Alicia"}, existing)
+
+	_, err = client.ExecCAS(ctx, &[]user{}, stmt)
+	assert.Equal(t, errUnexpectedDestination, err)
+}
+
+func TestClient_HealthCheck(t *testing.T) {
+	client, m := newClient(t)
+
+	m.session.EXPECT().query("SELECT now() FROM system.local").Return(m.query).Times(2)
+	m.query.EXPECT().withContext(gomock.Any()).Return(m.query).Times(2)
+	m.query.EXPECT().exec().Return(nil)
+	m.query.EXPECT().exec().Return(errUnavailable)
+
+	assert.Equal(t, datasource.Health{Status: datasource.StatusUp,
+		Details: map[string]interface{}{"hosts": "host1,host2", "keyspace": "app"}}, client.HealthCheck(context.Background()))
+	assert.Equal(t, datasource.Health{Status: datasource.StatusDown,
+		Details: map[string]interface{}{"hosts": "host1,host2", "keyspace": "app", "error": errUnavailable.Error()}},
+		client.HealthCheck(context.Background()))
+
+	m.session.EXPECT().close()
+
+	require.NoError(t, client.Close())
+}
+
+func TestClient_Reconnect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger := NewMockLogger(ctrl)
+	logger.EXPECT().Logf(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Errorf("error connecting to cassandra, err: %v", errUnavailable)
+
+	metrics := NewMockMetrics(ctrl)
+	metrics.EXPECT().NewHistogram(gomock.Any(), gomock.Any(), gomock.Any())
+	metrics.EXPECT().RecordHistogram(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	s := NewMocksession(ctrl)
+	attempts := 0
+
+	client := New(Config{Hosts: []string{"localhost"}})
+	client.UseLogger(logger)
+	client.UseMetrics(metrics)
+	client.connect = func() (session, error) {
+		attempts++
+
+		if attempts == 1 {
+			return nil, errUnavailable
+		}
+
+		return s, nil
+	}
+
+	client.Connect()
+
+	// the cluster is not connected again within the reconnect interval.
+	assert.Equal(t, ErrNotConnected, client.Exec(context.Background(), "TRUNCATE users"))
+	assert.Equal(t, "DOWN", client.HealthCheck(context.Background()).Status)
+
+	client.lastAttempt = time.Now().Add(-reconnectInterval)
+
+	q := NewMockquery(ctrl)
+	s.EXPECT().query("TRUNCATE users").Return(q)
+	q.EXPECT().withContext(gomock.Any()).Return(q)
+	q.EXPECT().exec().Return(nil)
+
+	assert.NoError(t, client.Exec(context.Background(), "TRUNCATE users"))
+	assert.Equal(t, 2, attempts)
+}
+
+func TestFromConfig(t *testing.T) {
+	assert.Nil(t, FromConfig(config.NewMockConfig(nil)))
+
+	client := FromConfig(config.NewMockConfig(map[string]string{
+		"CASSANDRA_HOSTS": "host1, host2", "CASSANDRA_KEYSPACE": "app", "CASSANDRA_USERNAME": "gofr",
+	}))
+
+	require.NotNil(t, client)
+	assert.Equal(t, Config{Hosts: []string{"host1", "host2"}, Keyspace: "app", Port: defaultPort, Username: "gofr"},
+		client.config)
+}
@@ -0,0 +1,71 @@
+package cassandra
+
+import (
+	"context"
+
+	"github.com/gocql/gocql"
+)
+
+// session, query and iterator are the parts of gocql used by the client, so that they can be mocked.
+type (
+	session interface {
+		query(stmt string, values ...interface{}) query
+		close()
+	}
+
+	query interface {
+		withContext(ctx context.Context) query
+		exec() error
+		iter() iterator
+		mapScanCAS(dest map[string]interface{}) (bool, error)
+	}
+
+	iterator interface {
+		mapScan(m map[string]interface{}) bool
+		close() error
+	}
+)
+
+type cassandraSession struct {
+	session *gocql.Session
+}
+
+func (s cassandraSession) query(stmt string, values ...interface{}) query {
+	return cassandraQuery{query: s.session.Query(stmt, values...)}
+}
+
+func (s cassandraSession) close() {
+	s.session.Close()
+}
+
+type cassandraQuery struct {
+	query *gocql.Query
+}
+
+func (q cassandraQuery) withContext(ctx context.Context) query {
+	return cassandraQuery{query: q.query.WithContext(ctx)}
+}
+
+func (q cassandraQuery) exec() error {
+	return q.query.Exec()
+}
+
+func (q cassandraQuery) iter() iterator {
+	return cassandraIterator{iter: q.query.Iter()}
+}
+
+func (q cassandraQuery) mapScanCAS(dest map[string]interface{}) (bool, error) {
+	return q.query.MapScanCAS(dest)
+}
+
+type cassandraIterator struct {
+	iter *gocql.Iter
+}
+
+func (i cassandraIterator) mapScan(m map[string]interface{}) bool {
+	return i.iter.MapScan(m)
+}
+
+func (i cassandraIterator) close() error {
+	return i.iter.Close()
+}
@@ -0,0 +1,33 @@
+package cassandra
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+type Logger interface {
+	Debugf(pattern string, args ...interface{})
+	Logf(pattern string, args ...interface{})
+	Errorf(pattern string, args ...interface{})
+}
+
+// QueryLog is the log of a query, which is pretty printed by the logger of the application in the terminal.
+type QueryLog struct {
+	Query    string `json:"query"`
+	Duration int64  `json:"duration"`
+	Keyspace string `json:"keyspace,omitempty"`
+}
+
+func (ql *QueryLog) PrettyPrint(writer io.Writer) {
+	fmt.Fprintf(writer, "\u001B[38;5;8m%-32s \u001B[38;5;206m%-6s\u001B[0m %8d\u001B[38;5;8mµs\u001B[0m %s\n",
+		clean(ql.Keyspace), "CQL", ql.Duration, clean(ql.Query))
+}
+
+var whitespaces = regexp.MustCompile(`\s+`)
+
+// clean replaces the consecutive whitespaces of the query with a single space, and trims it.
+func clean(query string) string {
+	return strings.TrimSpace(whitespaces.ReplaceAllString(query, " "))
+}
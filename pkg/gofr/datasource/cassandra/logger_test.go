@@ -0,0 +1,19 @@
+package cassandra
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryLog_PrettyPrint(t *testing.T) {
+	var buf bytes.Buffer
+
+	ql := QueryLog{Query: "SELECT *\n\tFROM users  WHERE user_id = ?", Duration: 1234, Keyspace: "app"}
+	ql.PrettyPrint(&buf)
+
+	assert.Contains(t, buf.String(), "SELECT * FROM users WHERE user_id = ?")
+	assert.Contains(t, buf.String(), "app")
+	assert.Contains(t, buf.String(), "CQL")
+}
@@ -0,0 +1,9 @@
+package cassandra
+
+import "context"
+
+type Metrics interface {
+	NewHistogram(name, desc string, buckets ...float64)
+
+	RecordHistogram(ctx context.Context, name string, value float64, labels ...string)
+}
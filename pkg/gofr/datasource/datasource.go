@@ -1,10 +1,17 @@
 package datasource
 
-import "github.com/peter-stratton/gofr/pkg/gofr/config"
+import (
+	"context"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+// Datasource is a datasource which gofr does not provide, added with App.AddDatasource.
 type Datasource interface {
-	Register(config config.Config)
+	// Connect connects to the datasource with the configs of the application, once it is added.
+	Connect(ctx context.Context, conf config.Config) error
+	// HealthCheck returns the health of the datasource, which is reported on the health endpoints.
+	HealthCheck(ctx context.Context) Health
+	// Close closes the connections of the datasource, once the application has stopped using them on shutdown.
+	Close() error
 }
-
-// Question is: is container aware exactly "Redis" is there or some opaque datasource. in the later case, how do we
-// retrieve from context?
@@ -3,6 +3,12 @@ package datasource
 const (
 	StatusUp   = "UP"
 	StatusDown = "DOWN"
+
+	// StatusDegraded is reported when only non-critical dependencies of the application are not UP.
+	StatusDegraded = "DEGRADED"
+
+	// StatusTimeout is reported when a health check does not complete within the configured timeout.
+	StatusTimeout = "TIMEOUT"
 )
 
 type Health struct {
@@ -39,6 +39,18 @@ func (m *MockMetrics) EXPECT() *MockMetricsMockRecorder {
 	return m.recorder
 }
 
+// NewGauge mocks base method.
+func (m *MockMetrics) NewGauge(name, desc string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NewGauge", name, desc)
+}
+
+// NewGauge indicates an expected call of NewGauge.
+func (mr *MockMetricsMockRecorder) NewGauge(name, desc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewGauge", reflect.TypeOf((*MockMetrics)(nil).NewGauge), name, desc)
+}
+
 // NewHistogram mocks base method.
 func (m *MockMetrics) NewHistogram(name, desc string, buckets ...float64) {
 	m.ctrl.T.Helper()
@@ -72,3 +84,20 @@ func (mr *MockMetricsMockRecorder) RecordHistogram(ctx, name, value any, labels
 	varargs := append([]any{ctx, name, value}, labels...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordHistogram", reflect.TypeOf((*MockMetrics)(nil).RecordHistogram), varargs...)
 }
+
+// SetGauge mocks base method.
+func (m *MockMetrics) SetGauge(name string, value float64, labels ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{name, value}
+	for _, a := range labels {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SetGauge", varargs...)
+}
+
+// SetGauge indicates an expected call of SetGauge.
+func (mr *MockMetricsMockRecorder) SetGauge(name, value any, labels ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name, value}, labels...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGauge", reflect.TypeOf((*MockMetrics)(nil).SetGauge), varargs...)
+}
@@ -2,22 +2,30 @@ package mongo
 
 import (
 	"context"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
+const openConnectionsMetric = "app_mongo_open_connections"
+
 type Client struct {
 	*mongo.Database
 
-	uri      string
+	// hosts are the hosts of the URI, which label the metrics instead of the URI, as it can hold the credentials.
+	hosts    string
 	database string
 	logger   Logger
 	metrics  Metrics
 	config   Config
+
+	openConnections atomic.Int64
 }
 
 type Config struct {
@@ -25,6 +33,25 @@ type Config struct {
 	Database string
 }
 
+// ConfigReader reads the configs of the application, like the Config of a gofr.App.
+type ConfigReader interface {
+	Get(key string) string
+}
+
+// FromConfig returns the client of MONGO_DATABASE on MONGO_URI, or nil if MONGO_URI is not set.
+//
+//	if client := mongo.FromConfig(app.Config); client != nil {
+//		app.AddMongo(client)
+//	}
+func FromConfig(conf ConfigReader) *Client {
+	uri := conf.Get("MONGO_URI")
+	if uri == "" {
+		return nil
+	}
+
+	return New(Config{URI: uri, Database: conf.Get("MONGO_DATABASE")})
+}
+
 /*
 Developer Note: We could have accepted logger and metrics as part of the factory function `New`, but when mongo driver is
 initialised in GoFr, We want to ensure that the user need not to provides logger and metrics and then connect to the database,
@@ -58,9 +85,13 @@ func (c *Client) UseMetrics(metrics interface{}) {
 
 // Connect establishes a connection to MongoDB and registers metrics using the provided configuration when the client was Created.
 func (c *Client) Connect() {
-	c.logger.Logf("connecting to mongoDB at %v to database %v", c.config.URI, c.config.Database)
+	opts := options.Client().ApplyURI(c.config.URI).SetPoolMonitor(&event.PoolMonitor{Event: c.monitorPool})
+
+	c.hosts, c.database = strings.Join(opts.Hosts, ","), c.config.Database
+
+	c.logger.Logf("connecting to mongoDB at %v to database %v", c.hosts, c.database)
 
-	m, err := mongo.Connect(context.Background(), options.Client().ApplyURI(c.config.URI))
+	m, err := mongo.Connect(context.Background(), opts)
 	if err != nil {
 		c.logger.Errorf("error connecting to mongoDB, err:%v", err)
 
@@ -69,10 +100,64 @@ func (c *Client) Connect() {
 
 	mongoBuckets := []float64{.05, .075, .1, .125, .15, .2, .3, .5, .75, 1, 2, 3, 4, 5, 7.5, 10}
 	c.metrics.NewHistogram("app_mongo_stats", "Response time of MONGO queries in milliseconds.", mongoBuckets...)
+	c.metrics.NewGauge(openConnectionsMetric, "Number of open MONGO connections.")
 
 	c.Database = m.Database(c.config.Database)
 }
 
+// monitorPool counts the connections of the pool, which are reported by the app_mongo_open_connections gauge.
+func (c *Client) monitorPool(e *event.PoolEvent) {
+	var open int64
+
+	switch e.Type {
+	case event.ConnectionCreated:
+		open = c.openConnections.Add(1)
+	case event.ConnectionClosed:
+		open = c.openConnections.Add(-1)
+	default:
+		return
+	}
+
+	c.metrics.SetGauge(openConnectionsMetric, float64(open), "hostname", c.hosts, "database", c.database)
+}
+
+// Close disconnects the client from MongoDB, closing the connections of its pool.
+func (c *Client) Close() error {
+	if c.Database == nil {
+		return nil
+	}
+
+	return c.Database.Client().Disconnect(context.Background())
+}
+
+// CreateCollection creates the collection.
+func (c *Client) CreateCollection(ctx context.Context, name string) error {
+	defer c.postProcess(&QueryLog{Query: "createCollection", Collection: name}, time.Now())
+
+	return c.Database.CreateCollection(ctx, name)
+}
+
+// CreateIndex creates the index of the name on the keys, like bson.D{{"email", 1}}.
+func (c *Client) CreateIndex(ctx context.Context, collection, name string, keys interface{}, unique bool) error {
+	defer c.postProcess(&QueryLog{Query: "createIndex", Collection: collection, Filter: keys}, time.Now())
+
+	_, err := c.Database.Collection(collection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetName(name).SetUnique(unique),
+	})
+
+	return err
+}
+
+// DropIndex drops the index of the name of the collection.
+func (c *Client) DropIndex(ctx context.Context, collection, name string) error {
+	defer c.postProcess(&QueryLog{Query: "dropIndex", Collection: collection, ID: name}, time.Now())
+
+	_, err := c.Database.Collection(collection).Indexes().DropOne(ctx, name)
+
+	return err
+}
+
 // InsertOne inserts a single document into the specified collection.
 func (c *Client) InsertOne(ctx context.Context, collection string, document interface{}) (interface{}, error) {
 	defer c.postProcess(&QueryLog{Query: "insertOne", Collection: collection, Filter: document}, time.Now())
@@ -194,7 +279,7 @@ func (c *Client) postProcess(ql *QueryLog, startTime time.Time) {
 
 	c.logger.Debugf("%v", ql)
 
-	c.metrics.RecordHistogram(context.Background(), "app_mongo_stats", float64(duration), "hostname", c.uri,
+	c.metrics.RecordHistogram(context.Background(), "app_mongo_stats", float64(duration), "hostname", c.hosts,
 		"database", c.database, "type", ql.Query)
 }
 
@@ -203,21 +288,25 @@ type Health struct {
 	Details map[string]interface{} `json:"details,omitempty"`
 }
 
-// HealthCheck checks the health of the MongoDB client by pinging the database.
+// HealthCheck checks the health of the MongoDB client by pinging the primary of the database.
 func (c *Client) HealthCheck() interface{} {
 	h := Health{
-		Details: make(map[string]interface{}),
+		Details: map[string]interface{}{"host": c.hosts, "database": c.database},
 	}
 
-	h.Details["host"] = c.uri
-	h.Details["database"] = c.database
+	if c.Database == nil {
+		h.Status = "DOWN"
+		h.Details["error"] = "not connected"
+
+		return &h
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	err := c.Database.Client().Ping(ctx, readpref.Primary())
-	if err != nil {
+	if err := c.Database.Client().Ping(ctx, readpref.Primary()); err != nil {
 		h.Status = "DOWN"
+		h.Details["error"] = err.Error()
 
 		return &h
 	}
@@ -2,14 +2,13 @@ package mongo
 
 import (
 	"context"
-	"fmt"
-
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.uber.org/mock/gomock"
@@ -19,6 +18,7 @@ func Test_NewMongoClient(t *testing.T) {
 	metrics := NewMockMetrics(gomock.NewController(t))
 
 	metrics.EXPECT().NewHistogram("app_mongo_stats", "Response time of MONGO queries in milliseconds.", gomock.Any())
+	metrics.EXPECT().NewGauge("app_mongo_open_connections", "Number of open MONGO connections.")
 
 	client := New(Config{URI: "mongodb://localhost:27017", Database: "test"})
 	client.UseLogger(NewMockLogger(DEBUG))
@@ -418,7 +418,7 @@ func Test_HealthCheck(t *testing.T) {
 
 		resp := cl.HealthCheck()
 
-		assert.Contains(t, fmt.Sprint(resp), "UP")
+		assert.Equal(t, "UP", resp.(*Health).Status)
 	})
 
 	mt.Run("HealthCheck Error", func(mt *mtest.T) {
@@ -431,6 +431,63 @@ func Test_HealthCheck(t *testing.T) {
 
 		resp := cl.HealthCheck()
 
-		assert.Contains(t, fmt.Sprint(resp), "DOWN")
+		assert.Equal(t, "DOWN", resp.(*Health).Status)
 	})
 }
+
+func Test_IndexCommands(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	metrics := NewMockMetrics(gomock.NewController(t))
+
+	cl := Client{metrics: metrics, logger: NewMockLogger(DEBUG)}
+
+	metrics.EXPECT().RecordHistogram(context.Background(), "app_mongo_stats", gomock.Any(), "hostname",
+		gomock.Any(), "database", gomock.Any(), "type", gomock.Any()).Times(3)
+
+	mt.Run("index commands", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		require.NoError(t, cl.CreateCollection(context.Background(), "users"))
+		require.NoError(t, cl.CreateIndex(context.Background(), "users", "email_1", bson.D{{Key: "email", Value: 1}}, true))
+		require.NoError(t, cl.DropIndex(context.Background(), "users", "email_1"))
+	})
+}
+
+func Test_OpenConnections(t *testing.T) {
+	metrics := NewMockMetrics(gomock.NewController(t))
+
+	cl := Client{metrics: metrics, hosts: "localhost:27017", database: "test"}
+
+	gomock.InOrder(
+		metrics.EXPECT().SetGauge("app_mongo_open_connections", float64(1), "hostname", "localhost:27017", "database", "test"),
+		metrics.EXPECT().SetGauge("app_mongo_open_connections", float64(2), "hostname", "localhost:27017", "database", "test"),
+		metrics.EXPECT().SetGauge("app_mongo_open_connections", float64(1), "hostname", "localhost:27017", "database", "test"),
+	)
+
+	for _, e := range []string{event.ConnectionCreated, event.ConnectionCreated, event.ConnectionReady, event.ConnectionClosed} {
+		cl.monitorPool(&event.PoolEvent{Type: e})
+	}
+}
+
+type mapConfig map[string]string
+
+func (m mapConfig) Get(key string) string {
+	return m[key]
+}
+
+func Test_FromConfig(t *testing.T) {
+	assert.Nil(t, FromConfig(mapConfig{}))
+
+	client := FromConfig(mapConfig{"MONGO_URI": "mongodb://user:secret@db:27017", "MONGO_DATABASE": "test"})
+
+	assert.Equal(t, Config{URI: "mongodb://user:secret@db:27017", Database: "test"}, client.config)
+}
+
+func Test_HealthCheckNotConnected(t *testing.T) {
+	h := (&Client{}).HealthCheck()
+
+	assert.Equal(t, "DOWN", h.(*Health).Status)
+	require.NoError(t, (&Client{}).Close())
+}
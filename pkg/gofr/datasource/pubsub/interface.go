@@ -4,6 +4,7 @@ package pubsub
 
 import (
 	"context"
+	"time"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
 )
@@ -25,6 +26,25 @@ type Client interface {
 	DeleteTopic(context context.Context, name string) error
 }
 
+// Position is a position in a topic, the message at Offset, or the first one at or after Time.
+type Position struct {
+	Offset int64
+	Time   time.Time
+}
+
+// IsZero reports whether the position is the zero Position.
+func (p Position) IsZero() bool {
+	return p.Offset == 0 && p.Time.IsZero()
+}
+
+// OffsetManager is implemented by the clients whose consumer groups can be moved, and topics replayed.
+type OffsetManager interface {
+	// Seek moves the consumer group to the position. Its subscribers should be stopped first.
+	Seek(ctx context.Context, topic string, pos Position) error
+	// Replay passes the messages of the topic between the positions to the handler, and returns their number.
+	Replay(ctx context.Context, topic string, from, to Position, handler func(*Message) error) (int, error)
+}
+
 type Committer interface {
 	Commit()
 }
@@ -23,3 +23,17 @@ type Connection interface {
 	CreateTopics(topics ...kafka.TopicConfig) error
 	DeleteTopics(topics ...string) error
 }
+
+// Admin reads the partitions and the offsets of the topics, and commits the offsets of the consumer groups.
+type Admin interface {
+	Metadata(ctx context.Context, req *kafka.MetadataRequest) (*kafka.MetadataResponse, error)
+	ListOffsets(ctx context.Context, req *kafka.ListOffsetsRequest) (*kafka.ListOffsetsResponse, error)
+	OffsetCommit(ctx context.Context, req *kafka.OffsetCommitRequest) (*kafka.OffsetCommitResponse, error)
+}
+
+// PartitionReader reads the messages of a partition from an offset, without a consumer group.
+type PartitionReader interface {
+	ReadMessage(ctx context.Context) (kafka.Message, error)
+	SetOffset(offset int64) error
+	Close() error
+}
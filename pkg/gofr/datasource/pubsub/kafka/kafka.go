@@ -46,6 +46,9 @@ type kafkaClient struct {
 	writer Writer
 	reader map[string]Reader
 
+	admin              Admin
+	newPartitionReader func(topic string, partition int) PartitionReader
+
 	mu *sync.RWMutex
 
 	logger  pubsub.Logger
@@ -92,7 +95,7 @@ func New(conf Config, logger pubsub.Logger, metrics Metrics) *kafkaClient {
 
 	logger.Logf("connected to kafka broker '%s'", conf.Broker)
 
-	return &kafkaClient{
+	client := &kafkaClient{
 		config:  conf,
 		dialer:  dialer,
 		reader:  reader,
@@ -101,7 +104,12 @@ func New(conf Config, logger pubsub.Logger, metrics Metrics) *kafkaClient {
 		writer:  writer,
 		mu:      &sync.RWMutex{},
 		metrics: metrics,
+		admin:   &kafka.Client{Addr: kafka.TCP(conf.Broker), Timeout: dialer.Timeout},
 	}
+
+	client.newPartitionReader = client.getPartitionReader
+
+	return client
 }
 
 func validateConfigs(conf Config) error {
@@ -198,6 +206,7 @@ func (k *kafkaClient) Subscribe(ctx context.Context, topic string) (*pubsub.Mess
 	}
 
 	m := pubsub.NewMessage(ctx)
+	m.Key = msg.Key
 	m.Value = msg.Value
 	m.Topic = topic
 	m.Committer = newKafkaMessage(&msg, k.reader[topic], k.logger)
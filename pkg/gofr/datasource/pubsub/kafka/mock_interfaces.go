@@ -231,3 +231,137 @@ func (mr *MockConnectionMockRecorder) DeleteTopics(topics ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTopics", reflect.TypeOf((*MockConnection)(nil).DeleteTopics), topics...)
 }
+
+// MockAdmin is a mock of Admin interface.
+type MockAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminMockRecorder
+}
+
+// MockAdminMockRecorder is the mock recorder for MockAdmin.
+type MockAdminMockRecorder struct {
+	mock *MockAdmin
+}
+
+// NewMockAdmin creates a new mock instance.
+func NewMockAdmin(ctrl *gomock.Controller) *MockAdmin {
+	mock := &MockAdmin{ctrl: ctrl}
+	mock.recorder = &MockAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdmin) EXPECT() *MockAdminMockRecorder {
+	return m.recorder
+}
+
+// ListOffsets mocks base method.
+func (m *MockAdmin) ListOffsets(ctx context.Context, req *kafka.ListOffsetsRequest) (*kafka.ListOffsetsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOffsets", ctx, req)
+	ret0, _ := ret[0].(*kafka.ListOffsetsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOffsets indicates an expected call of ListOffsets.
+func (mr *MockAdminMockRecorder) ListOffsets(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOffsets", reflect.TypeOf((*MockAdmin)(nil).ListOffsets), ctx, req)
+}
+
+// Metadata mocks base method.
+func (m *MockAdmin) Metadata(ctx context.Context, req *kafka.MetadataRequest) (*kafka.MetadataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Metadata", ctx, req)
+	ret0, _ := ret[0].(*kafka.MetadataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Metadata indicates an expected call of Metadata.
+func (mr *MockAdminMockRecorder) Metadata(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metadata", reflect.TypeOf((*MockAdmin)(nil).Metadata), ctx, req)
+}
+
+// OffsetCommit mocks base method.
+func (m *MockAdmin) OffsetCommit(ctx context.Context, req *kafka.OffsetCommitRequest) (*kafka.OffsetCommitResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OffsetCommit", ctx, req)
+	ret0, _ := ret[0].(*kafka.OffsetCommitResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OffsetCommit indicates an expected call of OffsetCommit.
+func (mr *MockAdminMockRecorder) OffsetCommit(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffsetCommit", reflect.TypeOf((*MockAdmin)(nil).OffsetCommit), ctx, req)
+}
+
+// MockPartitionReader is a mock of PartitionReader interface.
+type MockPartitionReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartitionReaderMockRecorder
+}
+
+// MockPartitionReaderMockRecorder is the mock recorder for MockPartitionReader.
+type MockPartitionReaderMockRecorder struct {
+	mock *MockPartitionReader
+}
+
+// NewMockPartitionReader creates a new mock instance.
+func NewMockPartitionReader(ctrl *gomock.Controller) *MockPartitionReader {
+	mock := &MockPartitionReader{ctrl: ctrl}
+	mock.recorder = &MockPartitionReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartitionReader) EXPECT() *MockPartitionReaderMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPartitionReader) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPartitionReaderMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPartitionReader)(nil).Close))
+}
+
+// ReadMessage mocks base method.
+func (m *MockPartitionReader) ReadMessage(ctx context.Context) (kafka.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMessage", ctx)
+	ret0, _ := ret[0].(kafka.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadMessage indicates an expected call of ReadMessage.
+func (mr *MockPartitionReaderMockRecorder) ReadMessage(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMessage", reflect.TypeOf((*MockPartitionReader)(nil).ReadMessage), ctx)
+}
+
+// SetOffset mocks base method.
+func (m *MockPartitionReader) SetOffset(offset int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOffset", offset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOffset indicates an expected call of SetOffset.
+func (mr *MockPartitionReaderMockRecorder) SetOffset(offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOffset", reflect.TypeOf((*MockPartitionReader)(nil).SetOffset), offset)
+}
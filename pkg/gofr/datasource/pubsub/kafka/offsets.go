@@ -0,0 +1,228 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+var errNotConnected = errors.New("kafka client is not connected")
+
+// Seek commits the offsets of the position for the consumer group, which must have no members.
+func (k *kafkaClient) Seek(ctx context.Context, topic string, pos pubsub.Position) error {
+	if k.config.ConsumerGroupID == "" {
+		return ErrConsumerGroupNotProvided
+	}
+
+	if k.admin == nil {
+		return errNotConnected
+	}
+
+	offsets, err := k.offsets(ctx, topic, pos)
+	if err != nil {
+		return err
+	}
+
+	commits := make([]kafka.OffsetCommit, 0, len(offsets))
+	committed := make(map[int]int64, len(offsets))
+
+	for partition, offset := range offsets {
+		commits = append(commits, kafka.OffsetCommit{Partition: partition, Offset: offset.at})
+		committed[partition] = offset.at
+	}
+
+	// the generation -1 commits the offsets of a group without members.
+	res, err := k.admin.OffsetCommit(ctx, &kafka.OffsetCommitRequest{
+		GroupID:      k.config.ConsumerGroupID,
+		GenerationID: -1,
+		Topics:       map[string][]kafka.OffsetCommit{topic: commits},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range res.Topics[topic] {
+		if p.Error != nil {
+			return p.Error
+		}
+	}
+
+	k.logger.Logf("moved consumer group %s of topic %s to the offsets %v", k.config.ConsumerGroupID, topic, committed)
+
+	return nil
+}
+
+// Replay reads each partition of the topic between the positions and passes its messages to the handler.
+func (k *kafkaClient) Replay(ctx context.Context, topic string, from, to pubsub.Position,
+	handler func(*pubsub.Message) error) (int, error) {
+	if k.admin == nil {
+		return 0, errNotConnected
+	}
+
+	start, err := k.offsets(ctx, topic, from)
+	if err != nil {
+		return 0, err
+	}
+
+	end, err := k.offsets(ctx, topic, to)
+	if err != nil {
+		return 0, err
+	}
+
+	handled := 0
+
+	for partition, offset := range start {
+		last := end[partition].at
+		if to.IsZero() {
+			last = end[partition].last
+		}
+
+		n, err := k.replayPartition(ctx, topic, partition, offset.at, last, handler)
+		handled += n
+
+		if err != nil {
+			return handled, err
+		}
+	}
+
+	return handled, nil
+}
+
+func (k *kafkaClient) replayPartition(ctx context.Context, topic string, partition int, from, to int64,
+	handler func(*pubsub.Message) error) (int, error) {
+	if from >= to {
+		return 0, nil
+	}
+
+	reader := k.newPartitionReader(topic, partition)
+	defer reader.Close()
+
+	if err := reader.SetOffset(from); err != nil {
+		return 0, err
+	}
+
+	handled := 0
+
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			return handled, err
+		}
+
+		if msg.Offset >= to {
+			return handled, nil
+		}
+
+		m := pubsub.NewMessage(ctx)
+		m.Topic = topic
+		m.Key = msg.Key
+		m.Value = msg.Value
+		m.Committer = replayCommitter{}
+
+		if err := handler(m); err != nil {
+			return handled, err
+		}
+
+		handled++
+
+		if msg.Offset+1 >= to {
+			return handled, nil
+		}
+	}
+}
+
+// partitionOffset is the offset of a position in a partition, and the last offset of the partition.
+type partitionOffset struct {
+	at   int64
+	last int64
+}
+
+// offsets returns the offsets of the position in the partitions of the topic, between their first and last offsets.
+func (k *kafkaClient) offsets(ctx context.Context, topic string, pos pubsub.Position) (map[int]partitionOffset, error) {
+	partitions, err := k.partitions(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]kafka.OffsetRequest, 0, 3*len(partitions))
+
+	for _, p := range partitions {
+		requests = append(requests, kafka.FirstOffsetOf(p), kafka.LastOffsetOf(p))
+
+		if !pos.Time.IsZero() {
+			requests = append(requests, kafka.TimeOffsetOf(p, pos.Time))
+		}
+	}
+
+	res, err := k.admin.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{topic: requests},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make(map[int]partitionOffset, len(partitions))
+
+	for _, p := range res.Topics[topic] {
+		if p.Error != nil {
+			return nil, p.Error
+		}
+
+		at := pos.Offset
+
+		if !pos.Time.IsZero() {
+			// the offset of the time is -1 when no message is published after it.
+			at = p.LastOffset
+
+			for offset := range p.Offsets {
+				if offset >= 0 {
+					at = offset
+				}
+			}
+		}
+
+		offsets[p.Partition] = partitionOffset{at: min(max(at, p.FirstOffset), p.LastOffset), last: p.LastOffset}
+	}
+
+	return offsets, nil
+}
+
+func (k *kafkaClient) partitions(ctx context.Context, topic string) ([]int, error) {
+	res, err := k.admin.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{topic}})
+	if err != nil {
+		return nil, err
+	}
+
+	var partitions []int
+
+	for _, t := range res.Topics {
+		if t.Error != nil {
+			return nil, t.Error
+		}
+
+		for _, p := range t.Partitions {
+			partitions = append(partitions, p.ID)
+		}
+	}
+
+	return partitions, nil
+}
+
+func (k *kafkaClient) getPartitionReader(topic string, partition int) PartitionReader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   []string{k.config.Broker},
+		Topic:     topic,
+		Partition: partition,
+		MinBytes:  10e3,
+		MaxBytes:  10e6,
+		Dialer:    k.dialer,
+	})
+}
+
+// replayCommitter commits the replayed messages, which do not change the offsets of the consumer group.
+type replayCommitter struct{}
+
+func (replayCommitter) Commit() {}
@@ -0,0 +1,156 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+var errHandler = errors.New("handler failed")
+
+// newOffsetsClient returns a client whose topic "orders" has the partitions of the first and the last offsets, and
+// whose time offset of each partition is timeOffsets.
+func newOffsetsClient(t *testing.T, first, last, timeOffsets []int64) (*kafkaClient, *MockAdmin) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	admin := NewMockAdmin(ctrl)
+
+	partitions := make([]kafka.Partition, len(first))
+	for i := range first {
+		partitions[i] = kafka.Partition{Topic: "orders", ID: i}
+	}
+
+	admin.EXPECT().Metadata(gomock.Any(), &kafka.MetadataRequest{Topics: []string{"orders"}}).
+		Return(&kafka.MetadataResponse{Topics: []kafka.Topic{{Name: "orders", Partitions: partitions}}}, nil).AnyTimes()
+
+	admin.EXPECT().ListOffsets(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *kafka.ListOffsetsRequest) (*kafka.ListOffsetsResponse, error) {
+			offsets := make([]kafka.PartitionOffsets, len(first))
+
+			for i := range first {
+				offsets[i] = kafka.PartitionOffsets{Partition: i, FirstOffset: first[i], LastOffset: last[i],
+					Offsets: map[int64]time.Time{}}
+
+				if len(req.Topics["orders"]) > 2*len(first) {
+					offsets[i].Offsets[timeOffsets[i]] = time.Time{}
+				}
+			}
+
+			return &kafka.ListOffsetsResponse{Topics: map[string][]kafka.PartitionOffsets{"orders": offsets}}, nil
+		}).AnyTimes()
+
+	return &kafkaClient{
+		admin:  admin,
+		config: Config{ConsumerGroupID: "billing"},
+		logger: logging.NewMockLogger(logging.ERROR),
+	}, admin
+}
+
+func TestKafkaClient_Seek(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		pos       pubsub.Position
+		committed []kafka.OffsetCommit
+	}{
+		{"offset", pubsub.Position{Offset: 3}, []kafka.OffsetCommit{{Partition: 0, Offset: 3}, {Partition: 1, Offset: 3}}},
+		{"offset out of the partitions", pubsub.Position{Offset: 20},
+			[]kafka.OffsetCommit{{Partition: 0, Offset: 10}, {Partition: 1, Offset: 5}}},
+		{"time", pubsub.Position{Time: time.Now()},
+			[]kafka.OffsetCommit{{Partition: 0, Offset: 4}, {Partition: 1, Offset: 5}}},
+	}
+
+	for i, tc := range testCases {
+		k, admin := newOffsetsClient(t, []int64{0, 2}, []int64{10, 5}, []int64{4, -1})
+
+		var committed []kafka.OffsetCommit
+
+		admin.EXPECT().OffsetCommit(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req *kafka.OffsetCommitRequest) (*kafka.OffsetCommitResponse, error) {
+				assert.Equal(t, "billing", req.GroupID)
+				assert.Equal(t, -1, req.GenerationID)
+
+				committed = req.Topics["orders"]
+
+				return &kafka.OffsetCommitResponse{}, nil
+			})
+
+		require.NoError(t, k.Seek(context.Background(), "orders", tc.pos), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		sort.Slice(committed, func(i, j int) bool { return committed[i].Partition < committed[j].Partition })
+
+		assert.Equal(t, tc.committed, committed, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestKafkaClient_SeekErrors(t *testing.T) {
+	k := &kafkaClient{}
+
+	assert.Equal(t, ErrConsumerGroupNotProvided, k.Seek(context.Background(), "orders", pubsub.Position{}))
+
+	k.config.ConsumerGroupID = "billing"
+
+	assert.Equal(t, errNotConnected, k.Seek(context.Background(), "orders", pubsub.Position{}))
+}
+
+func TestKafkaClient_Replay(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		from, to pubsub.Position
+		handled  []string
+		err      error
+	}{
+		{"whole partition", pubsub.Position{}, pubsub.Position{}, []string{"2", "3", "4", "5"}, nil},
+		{"range of offsets", pubsub.Position{Offset: 3}, pubsub.Position{Offset: 5}, []string{"3", "4"}, nil},
+		{"empty range", pubsub.Position{Offset: 4}, pubsub.Position{Offset: 4}, nil, nil},
+		{"handler error", pubsub.Position{}, pubsub.Position{}, []string{"2"}, errHandler},
+	}
+
+	for i, tc := range testCases {
+		k, _ := newOffsetsClient(t, []int64{2}, []int64{6}, nil)
+		reader := NewMockPartitionReader(gomock.NewController(t))
+
+		k.newPartitionReader = func(topic string, partition int) PartitionReader {
+			assert.Equal(t, "orders", topic)
+			assert.Equal(t, 0, partition)
+
+			return reader
+		}
+
+		var offset int64
+
+		reader.EXPECT().SetOffset(gomock.Any()).DoAndReturn(func(o int64) error {
+			offset = o
+			return nil
+		}).AnyTimes()
+		reader.EXPECT().ReadMessage(gomock.Any()).DoAndReturn(func(context.Context) (kafka.Message, error) {
+			offset++
+			return kafka.Message{Offset: offset - 1, Value: []byte{byte('0' + offset - 1)}}, nil
+		}).AnyTimes()
+		reader.EXPECT().Close().AnyTimes()
+
+		var handled []string
+
+		n, err := k.Replay(context.Background(), "orders", tc.from, tc.to, func(msg *pubsub.Message) error {
+			handled = append(handled, string(msg.Value))
+			return tc.err
+		})
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.handled, handled, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.err == nil {
+			assert.Equal(t, len(tc.handled), n, "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+func (m *memoryClient) Health() datasource.Health {
+	m.broker.mu.Lock()
+	defer m.broker.mu.Unlock()
+
+	topics := make(map[string]interface{}, len(m.broker.topics))
+
+	for name, t := range m.broker.topics {
+		// the offsets are copied, as they change once the lock is released.
+		offsets := make(map[string]int, len(t.offsets))
+		for group, offset := range t.offsets {
+			offsets[group] = offset
+		}
+
+		topics[name] = map[string]interface{}{
+			"retained": len(t.messages),
+			"offsets":  offsets,
+		}
+	}
+
+	return datasource.Health{
+		Status: datasource.StatusUp,
+		Details: map[string]interface{}{
+			"host":           "memory",
+			"backend":        "MEMORY",
+			"consumer_group": m.config.ConsumerGroupID,
+			"topics":         topics,
+		},
+	}
+}
@@ -0,0 +1,265 @@
+// Package memory provides an in-process pub/sub client, for the local development and the tests.
+package memory
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+// ErrInjectedFailure is returned by the publishes which fail by the failure rate of the client.
+var ErrInjectedFailure = errors.New("injected publish failure")
+
+var errTopicNotProvided = errors.New("can't publish message. topic is empty")
+
+const (
+	// DefaultConsumerGroup is the consumer group of the clients without one.
+	DefaultConsumerGroup = "gofr-consumer"
+
+	// maxRetained is the number of the messages retained by a topic.
+	maxRetained = 10000
+)
+
+type Config struct {
+	// ConsumerGroupID is the consumer group of the subscriptions, which receive each message of a topic once.
+	ConsumerGroupID string
+	// DeliveryDelay delays the delivery of the messages after they are published, like the latency of a broker.
+	DeliveryDelay time.Duration
+	// FailureRate is the fraction of the publishes, between 0 and 1, which fail with ErrInjectedFailure.
+	FailureRate float64
+}
+
+type Metrics interface {
+	IncrementCounter(ctx context.Context, name string, labels ...string)
+}
+
+//nolint:gochecknoglobals // the topics are shared by the clients of the process, like those of a broker.
+var defaultBroker = newBroker()
+
+type memoryClient struct {
+	broker  *broker
+	config  Config
+	logger  pubsub.Logger
+	metrics Metrics
+}
+
+//nolint:revive // We do not want anyone using the client without initialization steps.
+func New(conf Config, logger pubsub.Logger, metrics Metrics) *memoryClient {
+	if conf.ConsumerGroupID == "" {
+		conf.ConsumerGroupID = DefaultConsumerGroup
+	}
+
+	logger.Logf("using the in-memory pubsub, whose messages are not shared with other processes")
+
+	return &memoryClient{broker: defaultBroker, config: conf, logger: logger, metrics: metrics}
+}
+
+func (m *memoryClient) Publish(ctx context.Context, topic string, message []byte) error {
+	ctx, span := otel.GetTracerProvider().Tracer("gofr").Start(ctx, "memory-publish")
+	defer span.End()
+
+	m.metrics.IncrementCounter(ctx, "app_pubsub_publish_total_count", "topic", topic)
+
+	if topic == "" {
+		return errTopicNotProvided
+	}
+
+	//nolint:gosec // the injection of the failures does not need a secure random number.
+	if m.config.FailureRate > 0 && rand.Float64() < m.config.FailureRate {
+		m.logger.Errorf("failed to publish message to topic %s: %v", topic, ErrInjectedFailure)
+		return ErrInjectedFailure
+	}
+
+	start := time.Now()
+
+	m.broker.publish(topic, message, start.Add(m.config.DeliveryDelay))
+
+	m.logger.Debug(&pubsub.Log{
+		Mode:          "PUB",
+		CorrelationID: span.SpanContext().TraceID().String(),
+		MessageValue:  string(message),
+		Topic:         topic,
+		Host:          "memory",
+		PubSubBackend: "MEMORY",
+		Time:          time.Since(start).Microseconds(),
+	})
+
+	m.metrics.IncrementCounter(ctx, "app_pubsub_publish_success_count", "topic", topic)
+
+	return nil
+}
+
+// Subscribe returns the next message of the topic for the consumer group, or waits until ctx is done.
+func (m *memoryClient) Subscribe(ctx context.Context, topic string) (*pubsub.Message, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("gofr").Start(ctx, "memory-subscribe")
+	defer span.End()
+
+	m.metrics.IncrementCounter(ctx, "app_pubsub_subscribe_total_count", "topic", topic,
+		"consumer_group", m.config.ConsumerGroupID)
+
+	start := time.Now()
+
+	value, err := m.broker.next(ctx, topic, m.config.ConsumerGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := pubsub.NewMessage(ctx)
+	msg.Topic = topic
+	msg.Value = value
+	msg.Committer = committer{}
+
+	m.logger.Debug(&pubsub.Log{
+		Mode:          "SUB",
+		CorrelationID: span.SpanContext().TraceID().String(),
+		MessageValue:  string(value),
+		Topic:         topic,
+		Host:          "memory",
+		PubSubBackend: "MEMORY",
+		Time:          time.Since(start).Microseconds(),
+	})
+
+	m.metrics.IncrementCounter(ctx, "app_pubsub_subscribe_success_count", "topic", topic,
+		"consumer_group", m.config.ConsumerGroupID)
+
+	return msg, nil
+}
+
+func (m *memoryClient) CreateTopic(_ context.Context, name string) error {
+	m.broker.topic(name)
+	return nil
+}
+
+func (m *memoryClient) DeleteTopic(_ context.Context, name string) error {
+	m.broker.deleteTopic(name)
+	return nil
+}
+
+// committer commits the messages, which are received once by each consumer group whether they are committed or not.
+type committer struct{}
+
+func (committer) Commit() {}
+
+// broker holds the topics of the clients.
+type broker struct {
+	mu     sync.Mutex
+	topics map[string]*topic
+}
+
+// topic is the log of the messages of a topic, which each consumer group reads from its offset.
+type topic struct {
+	// base is the offset of the first retained message.
+	base     int
+	messages []message
+	// offsets are the offsets of the next messages of the consumer groups.
+	offsets map[string]int
+	// published is closed when a message is published, or the topic is deleted, to wake up its subscribers.
+	published chan struct{}
+}
+
+type message struct {
+	value       []byte
+	deliverAt   time.Time
+	publishedAt time.Time
+}
+
+func newBroker() *broker {
+	return &broker{topics: make(map[string]*topic)}
+}
+
+// topic returns the topic of the name, which is created if it does not exist.
+func (b *broker) topic(name string) *topic {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.topicLocked(name)
+}
+
+// topicLocked is topic for the callers holding the lock of the broker.
+func (b *broker) topicLocked(name string) *topic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic{offsets: make(map[string]int), published: make(chan struct{})}
+		b.topics[name] = t
+	}
+
+	return t
+}
+
+func (b *broker) deleteTopic(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if t, ok := b.topics[name]; ok {
+		close(t.published)
+		delete(b.topics, name)
+	}
+}
+
+func (b *broker) publish(name string, value []byte, deliverAt time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topicLocked(name)
+	t.messages = append(t.messages, message{value: append([]byte{}, value...), deliverAt: deliverAt,
+		publishedAt: time.Now()})
+
+	if len(t.messages) > maxRetained {
+		discarded := len(t.messages) - maxRetained
+		t.messages = append([]message{}, t.messages[discarded:]...)
+		t.base += discarded
+	}
+
+	close(t.published)
+	t.published = make(chan struct{})
+}
+
+// next returns the value of the next message of the topic for the consumer group, once it is delivered.
+func (b *broker) next(ctx context.Context, name, group string) ([]byte, error) {
+	for {
+		b.mu.Lock()
+
+		t := b.topicLocked(name)
+
+		offset := max(t.offsets[group], t.base)
+		if offset < t.base+len(t.messages) {
+			msg := t.messages[offset-t.base]
+			t.offsets[group] = offset + 1
+			b.mu.Unlock()
+
+			return msg.value, waitUntil(ctx, msg.deliverAt)
+		}
+
+		published := t.published
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-published:
+		}
+	}
+}
+
+func waitUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+type testMetrics struct {
+	mu       sync.Mutex
+	counters map[string]int
+}
+
+func (m *testMetrics) IncrementCounter(_ context.Context, name string, _ ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.counters == nil {
+		m.counters = make(map[string]int)
+	}
+
+	m.counters[name]++
+}
+
+func newTestClient(b *broker, conf Config) *memoryClient {
+	c := New(conf, logging.NewMockLogger(logging.ERROR), &testMetrics{})
+	c.broker = b
+
+	return c
+}
+
+func subscribe(t *testing.T, c *memoryClient, topic string) string {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	msg, err := c.Subscribe(ctx, topic)
+	require.NoError(t, err)
+
+	return string(msg.Value)
+}
+
+func TestMemory_PublishSubscribe(t *testing.T) {
+	b := newBroker()
+	orders := newTestClient(b, Config{ConsumerGroupID: "orders"})
+	billing := newTestClient(b, Config{ConsumerGroupID: "billing"})
+	otherBilling := newTestClient(b, Config{ConsumerGroupID: "billing"})
+
+	ctx := context.Background()
+
+	require.NoError(t, orders.Publish(ctx, "products", []byte("book")))
+	require.NoError(t, orders.Publish(ctx, "products", []byte("pen")))
+
+	assert.Equal(t, "book", subscribe(t, orders, "products"))
+	assert.Equal(t, "pen", subscribe(t, orders, "products"))
+
+	// the subscribers of a consumer group receive each message once between them.
+	assert.Equal(t, "book", subscribe(t, billing, "products"))
+	assert.Equal(t, "pen", subscribe(t, otherBilling, "products"))
+
+	assert.Equal(t, 2, orders.metrics.(*testMetrics).counters["app_pubsub_publish_success_count"])
+	assert.Equal(t, 2, orders.metrics.(*testMetrics).counters["app_pubsub_subscribe_success_count"])
+}
+
+func TestMemory_SubscribeWaitsForPublish(t *testing.T) {
+	c := newTestClient(newBroker(), Config{})
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+
+		_ = c.Publish(context.Background(), "products", []byte("book"))
+	}()
+
+	assert.Equal(t, "book", subscribe(t, c, "products"))
+	assert.Equal(t, DefaultConsumerGroup, c.config.ConsumerGroupID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	msg, err := c.Subscribe(ctx, "products")
+
+	assert.Nil(t, msg)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestMemory_DeliveryDelay(t *testing.T) {
+	c := newTestClient(newBroker(), Config{DeliveryDelay: 50 * time.Millisecond})
+
+	require.NoError(t, c.Publish(context.Background(), "products", []byte("book")))
+
+	start := time.Now()
+
+	assert.Equal(t, "book", subscribe(t, c, "products"))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestMemory_PublishErrors(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		failureRate float64
+		topic       string
+		err         error
+	}{
+		{"topic is empty", 0, "", errTopicNotProvided},
+		{"failure is injected", 1, "products", ErrInjectedFailure},
+	}
+
+	for i, tc := range testCases {
+		c := newTestClient(newBroker(), Config{FailureRate: tc.failureRate})
+
+		err := c.Publish(context.Background(), tc.topic, []byte("book"))
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestMemory_Retention(t *testing.T) {
+	c := newTestClient(newBroker(), Config{})
+
+	for i := 0; i < maxRetained+2; i++ {
+		c.broker.publish("products", []byte{byte(i)}, time.Time{})
+	}
+
+	msg, err := c.Subscribe(context.Background(), "products")
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, msg.Value)
+}
+
+func TestMemory_Topics(t *testing.T) {
+	c := newTestClient(newBroker(), Config{})
+	ctx := context.Background()
+
+	require.NoError(t, c.CreateTopic(ctx, "products"))
+	require.NoError(t, c.Publish(ctx, "products", []byte("book")))
+
+	health := c.Health()
+
+	assert.Equal(t, datasource.StatusUp, health.Status)
+	assert.Equal(t, map[string]interface{}{"retained": 1, "offsets": map[string]int{}},
+		health.Details["topics"].(map[string]interface{})["products"])
+
+	require.NoError(t, c.DeleteTopic(ctx, "products"))
+
+	assert.Empty(t, c.Health().Details["topics"])
+}
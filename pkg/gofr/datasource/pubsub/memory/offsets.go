@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+// Seek moves the consumer group of the client to the position of the topic.
+func (m *memoryClient) Seek(_ context.Context, topic string, pos pubsub.Position) error {
+	b := m.broker
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topicLocked(topic)
+	t.offsets[m.config.ConsumerGroupID] = t.offset(pos)
+
+	return nil
+}
+
+// Replay passes the messages of the topic between the positions to the handler.
+func (m *memoryClient) Replay(ctx context.Context, topic string, from, to pubsub.Position,
+	handler func(*pubsub.Message) error) (int, error) {
+	b := m.broker
+
+	b.mu.Lock()
+
+	t := b.topicLocked(topic)
+	start, end := t.offset(from), t.base+len(t.messages)
+
+	if !to.IsZero() {
+		end = min(t.offset(to), end)
+	}
+
+	messages := make([]message, 0, max(end-start, 0))
+	if start < end {
+		messages = append(messages, t.messages[start-t.base:end-t.base]...)
+	}
+
+	b.mu.Unlock()
+
+	for i, stored := range messages {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+
+		msg := pubsub.NewMessage(ctx)
+		msg.Topic = topic
+		msg.Value = stored.value
+		msg.Committer = committer{}
+
+		if err := handler(msg); err != nil {
+			return i, err
+		}
+	}
+
+	return len(messages), nil
+}
+
+// offset returns the offset of the position. The lock of the broker must be held.
+func (t *topic) offset(pos pubsub.Position) int {
+	if pos.Time.IsZero() {
+		return min(max(int(pos.Offset), t.base), t.base+len(t.messages))
+	}
+
+	i := sort.Search(len(t.messages), func(i int) bool {
+		return !t.messages[i].publishedAt.Before(pos.Time)
+	})
+
+	return t.base + i
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+var errHandler = errors.New("handler failed")
+
+func publishAt(b *broker, values ...string) []time.Time {
+	times := make([]time.Time, len(values))
+
+	for i, v := range values {
+		b.publish("orders", []byte(v), time.Time{})
+		times[i] = b.topics["orders"].messages[i].publishedAt
+
+		time.Sleep(time.Millisecond)
+	}
+
+	return times
+}
+
+func TestMemory_Seek(t *testing.T) {
+	b := newBroker()
+	times := publishAt(b, "a", "b", "c")
+
+	testCases := []struct {
+		desc string
+		pos  pubsub.Position
+		next string
+	}{
+		{"offset", pubsub.Position{Offset: 1}, "b"},
+		{"offset before the first message", pubsub.Position{Offset: -5}, "a"},
+		{"time", pubsub.Position{Time: times[2]}, "c"},
+		{"time between messages", pubsub.Position{Time: times[1].Add(-time.Nanosecond)}, "b"},
+	}
+
+	for i, tc := range testCases {
+		c := newTestClient(b, Config{ConsumerGroupID: "orders"})
+
+		require.NoError(t, c.Seek(context.Background(), "orders", tc.pos))
+
+		assert.Equal(t, tc.next, subscribe(t, c, "orders"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestMemory_Replay(t *testing.T) {
+	b := newBroker()
+	times := publishAt(b, "a", "b", "c", "d")
+
+	testCases := []struct {
+		desc     string
+		from, to pubsub.Position
+		handled  []string
+		count    int
+		err      error
+	}{
+		{"whole topic", pubsub.Position{}, pubsub.Position{}, []string{"a", "b", "c", "d"}, 4, nil},
+		{"offsets", pubsub.Position{Offset: 1}, pubsub.Position{Offset: 3}, []string{"b", "c"}, 2, nil},
+		{"times", pubsub.Position{Time: times[2]}, pubsub.Position{}, []string{"c", "d"}, 2, nil},
+		{"offset after the end", pubsub.Position{Offset: 10}, pubsub.Position{}, []string{}, 0, nil},
+		{"handler error", pubsub.Position{}, pubsub.Position{}, []string{"a", "b"}, 1, errHandler},
+	}
+
+	for i, tc := range testCases {
+		c := newTestClient(b, Config{ConsumerGroupID: "orders"})
+		handled := []string{}
+
+		n, err := c.Replay(context.Background(), "orders", tc.from, tc.to, func(msg *pubsub.Message) error {
+			handled = append(handled, string(msg.Value))
+
+			if tc.err != nil && len(handled) == 2 {
+				return tc.err
+			}
+
+			return nil
+		})
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.handled, handled, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.count, n, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	// the replays do not change the offset of the consumer group.
+	assert.Equal(t, "a", subscribe(t, newTestClient(b, Config{ConsumerGroupID: "orders"}), "orders"))
+}
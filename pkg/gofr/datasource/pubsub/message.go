@@ -13,7 +13,9 @@ var errNotPointer = errors.New("input should be a pointer to a variable")
 type Message struct {
 	ctx context.Context
 
-	Topic    string
+	Topic string
+	// Key is the key of the message, for the backends whose messages have one, like Kafka.
+	Key      []byte
 	Value    []byte
 	MetaData interface{}
 
@@ -110,6 +112,11 @@ func (m *Message) HostName() string {
 	return ""
 }
 
-func (m *Message) GetHeader(string) string {
+// GetHeader returns the value of the header of the message.
+func (m *Message) GetHeader(key string) string {
+	if headers, ok := m.MetaData.(map[string]string); ok {
+		return headers[key]
+	}
+
 	return ""
 }
@@ -227,3 +227,11 @@ func TestMessage_HostName(t *testing.T) {
 
 	assert.Equal(t, "", out)
 }
+
+func TestMessage_GetHeader(t *testing.T) {
+	m := &Message{MetaData: map[string]string{"tenant": "acme"}}
+
+	assert.Equal(t, "acme", m.GetHeader("tenant"))
+	assert.Equal(t, "", m.GetHeader("missing"))
+	assert.Equal(t, "", (&Message{MetaData: struct{}{}}).GetHeader("tenant"))
+}
@@ -0,0 +1,33 @@
+package nats
+
+import (
+	"github.com/nats-io/nats.go"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+func (n *natsClient) Health() datasource.Health {
+	health := datasource.Health{
+		Status:  datasource.StatusDown,
+		Details: map[string]interface{}{"host": n.config.Server, "backend": "NATS"},
+	}
+
+	if n.conn == nil {
+		health.Details["error"] = "not connected"
+
+		return health
+	}
+
+	status := n.conn.Status()
+	if status == nats.CONNECTED {
+		health.Status = datasource.StatusUp
+	}
+
+	health.Details["connection_status"] = status.String()
+
+	n.mu.Lock()
+	health.Details["consumers"] = len(n.consumers)
+	n.mu.Unlock()
+
+	return health
+}
@@ -0,0 +1,110 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Connection is the connection to the NATS server.
+type Connection interface {
+	Status() nats.Status
+	Drain() error
+}
+
+// JetStream publishes the messages on the subjects of the streams, and creates their consumers.
+type JetStream interface {
+	Publish(ctx context.Context, subject string, data []byte) error
+	// CreateStream creates the stream of the subject, unless it exists.
+	CreateStream(ctx context.Context, name, subject string) error
+	DeleteStream(ctx context.Context, name string) error
+	// Consumer returns the durable consumer of the name, creating it unless it exists.
+	Consumer(ctx context.Context, stream, name, subject string) (Consumer, error)
+}
+
+// Consumer fetches the messages of a durable consumer.
+type Consumer interface {
+	// Next returns the next message, or nil if none is available within maxWait.
+	Next(maxWait time.Duration) (Msg, error)
+}
+
+// Msg is a message of a stream, which is acknowledged once it is handled.
+type Msg interface {
+	Data() []byte
+	Headers() nats.Header
+	Ack() error
+}
+
+type jetStream struct {
+	js jetstream.JetStream
+}
+
+func newJetStream(conn *nats.Conn) (JetStream, error) {
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jetStream{js: js}, nil
+}
+
+func (j *jetStream) Publish(ctx context.Context, subject string, data []byte) error {
+	_, err := j.js.Publish(ctx, subject, data)
+
+	return err
+}
+
+func (j *jetStream) CreateStream(ctx context.Context, name, subject string) error {
+	_, err := j.js.Stream(ctx, name)
+	if !errors.Is(err, jetstream.ErrStreamNotFound) {
+		return err
+	}
+
+	_, err = j.js.CreateStream(ctx, jetstream.StreamConfig{Name: name, Subjects: []string{subject}})
+	if errors.Is(err, jetstream.ErrStreamNameAlreadyInUse) {
+		return nil
+	}
+
+	return err
+}
+
+func (j *jetStream) DeleteStream(ctx context.Context, name string) error {
+	return j.js.DeleteStream(ctx, name)
+}
+
+func (j *jetStream) Consumer(ctx context.Context, stream, name, subject string) (Consumer, error) {
+	c, err := j.js.CreateOrUpdateConsumer(ctx, stream, jetstream.ConsumerConfig{
+		Durable:       name,
+		FilterSubject: subject,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &consumer{c: c}, nil
+}
+
+type consumer struct {
+	c jetstream.Consumer
+}
+
+func (c *consumer) Next(maxWait time.Duration) (Msg, error) {
+	batch, err := c.c.Fetch(1, jetstream.FetchMaxWait(maxWait))
+	if err != nil {
+		return nil, err
+	}
+
+	if msg, ok := <-batch.Messages(); ok {
+		return msg, nil
+	}
+
+	if err = batch.Error(); err != nil && !errors.Is(err, nats.ErrTimeout) {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,14 @@
+package nats
+
+import "github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+
+type natsMessage struct {
+	msg    Msg
+	logger pubsub.Logger
+}
+
+func (m *natsMessage) Commit() {
+	if err := m.msg.Ack(); err != nil {
+		m.logger.Errorf("unable to commit message on nats, error: %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+// Package nats provides a client of NATS JetStream.
+package nats
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+const defaultMaxWait = 5 * time.Second
+
+var (
+	errServerNotProvided      = errors.New("nats server address not provided")
+	errPublisherNotConfigured = errors.New("can't publish message. Publisher not configured or topic is empty")
+	// ErrConsumerGroupNotProvided is returned by Subscribe when the client has no consumer group.
+	ErrConsumerGroupNotProvided = errors.New("consumer group id not provided")
+)
+
+type Config struct {
+	// Server is the URL of the NATS server, like nats://localhost:4222.
+	Server string
+	// ConsumerGroupID is the name of the durable consumers of the topics, shared by the instances of the application.
+	ConsumerGroupID string
+	// MaxWait is how long Subscribe waits for a message before polling again, 5 seconds by default.
+	MaxWait time.Duration
+}
+
+type natsClient struct {
+	conn Connection
+	js   JetStream
+
+	mu        sync.Mutex
+	consumers map[string]Consumer
+
+	logger  pubsub.Logger
+	config  Config
+	metrics Metrics
+}
+
+//nolint:revive // We do not want anyone using the client without initialization steps.
+func New(conf Config, logger pubsub.Logger, metrics Metrics) *natsClient {
+	if conf.Server == "" {
+		logger.Errorf("could not initialize nats, error: %v", errServerNotProvided)
+
+		return nil
+	}
+
+	if conf.MaxWait <= 0 {
+		conf.MaxWait = defaultMaxWait
+	}
+
+	client := &natsClient{config: conf, consumers: make(map[string]Consumer), logger: logger, metrics: metrics}
+
+	logger.Debugf("connecting to nats server '%s'", conf.Server)
+
+	conn, err := nats.Connect(conf.Server, nats.Name("gofr"), nats.MaxReconnects(-1))
+	if err != nil {
+		logger.Errorf("failed to connect to nats at %v, error: %v", conf.Server, err)
+
+		return client
+	}
+
+	js, err := newJetStream(conn)
+	if err != nil {
+		logger.Errorf("failed to use the jetstream of nats at %v, error: %v", conf.Server, err)
+
+		return client
+	}
+
+	logger.Logf("connected to nats server '%s'", conf.Server)
+
+	client.conn, client.js = conn, js
+
+	return client
+}
+
+func (n *natsClient) Publish(ctx context.Context, topic string, message []byte) error {
+	ctx, span := otel.GetTracerProvider().Tracer("gofr").Start(ctx, "nats-publish")
+	defer span.End()
+
+	n.metrics.IncrementCounter(ctx, "app_pubsub_publish_total_count", "topic", topic)
+
+	if n.js == nil || topic == "" {
+		return errPublisherNotConfigured
+	}
+
+	start := time.Now()
+
+	if err := n.js.Publish(ctx, topic, message); err != nil {
+		n.logger.Errorf("failed to publish message to nats server, error: %v", err)
+
+		return err
+	}
+
+	n.logger.Debug(&pubsub.Log{
+		Mode:          "PUB",
+		CorrelationID: span.SpanContext().TraceID().String(),
+		MessageValue:  string(message),
+		Topic:         topic,
+		Host:          n.config.Server,
+		PubSubBackend: "NATS",
+		Time:          time.Since(start).Microseconds(),
+	})
+
+	n.metrics.IncrementCounter(ctx, "app_pubsub_publish_success_count", "topic", topic)
+
+	return nil
+}
+
+// Subscribe returns the next message of the topic for the consumer group, or waits until ctx is done.
+func (n *natsClient) Subscribe(ctx context.Context, topic string) (*pubsub.Message, error) {
+	if n.config.ConsumerGroupID == "" {
+		return &pubsub.Message{}, ErrConsumerGroupNotProvided
+	}
+
+	if n.js == nil {
+		return nil, errPublisherNotConfigured
+	}
+
+	ctx, span := otel.GetTracerProvider().Tracer("gofr").Start(ctx, "nats-subscribe")
+	defer span.End()
+
+	n.metrics.IncrementCounter(ctx, "app_pubsub_subscribe_total_count", "topic", topic, "consumer_group", n.config.ConsumerGroupID)
+
+	consumer, err := n.consumer(ctx, topic)
+	if err != nil {
+		n.logger.Errorf("failed to create the consumer of nats topic %s: %v", topic, err)
+
+		return nil, err
+	}
+
+	start := time.Now()
+
+	var msg Msg
+
+	for msg == nil {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if msg, err = consumer.Next(n.config.MaxWait); err != nil {
+			n.logger.Errorf("failed to read message from nats topic %s: %v", topic, err)
+
+			return nil, err
+		}
+	}
+
+	m := pubsub.NewMessage(ctx)
+	m.Topic = topic
+	m.Value = msg.Data()
+	m.MetaData = msg.Headers()
+	m.Committer = &natsMessage{msg: msg, logger: n.logger}
+
+	n.logger.Debug(&pubsub.Log{
+		Mode:          "SUB",
+		CorrelationID: span.SpanContext().TraceID().String(),
+		MessageValue:  string(msg.Data()),
+		Topic:         topic,
+		Host:          n.config.Server,
+		PubSubBackend: "NATS",
+		Time:          time.Since(start).Microseconds(),
+	})
+
+	n.metrics.IncrementCounter(ctx, "app_pubsub_subscribe_success_count", "topic", topic, "consumer_group", n.config.ConsumerGroupID)
+
+	return m, nil
+}
+
+// CreateTopic creates the stream of the topic, which stores the messages published on its subject.
+func (n *natsClient) CreateTopic(ctx context.Context, name string) error {
+	if n.js == nil {
+		return errPublisherNotConfigured
+	}
+
+	return n.js.CreateStream(ctx, streamName(name), name)
+}
+
+// DeleteTopic deletes the stream of the topic, with its messages and its consumers.
+func (n *natsClient) DeleteTopic(ctx context.Context, name string) error {
+	if n.js == nil {
+		return errPublisherNotConfigured
+	}
+
+	n.mu.Lock()
+	delete(n.consumers, name)
+	n.mu.Unlock()
+
+	return n.js.DeleteStream(ctx, streamName(name))
+}
+
+// Close drains the connection, so that the messages being published are sent before it is closed.
+func (n *natsClient) Close() error {
+	if n.conn == nil {
+		return nil
+	}
+
+	return n.conn.Drain()
+}
+
+// consumer returns the durable consumer of the group, creating the stream of the topic if needed.
+func (n *natsClient) consumer(ctx context.Context, topic string) (Consumer, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if c, ok := n.consumers[topic]; ok {
+		return c, nil
+	}
+
+	if err := n.js.CreateStream(ctx, streamName(topic), topic); err != nil {
+		return nil, err
+	}
+
+	c, err := n.js.Consumer(ctx, streamName(topic), streamName(n.config.ConsumerGroupID), topic)
+	if err != nil {
+		return nil, err
+	}
+
+	n.consumers[topic] = c
+
+	return c, nil
+}
+
+// streamName returns the name of the stream or the consumer, without the characters NATS rejects.
+func streamName(name string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_").Replace(name)
+}
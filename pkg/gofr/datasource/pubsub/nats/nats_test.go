@@ -0,0 +1,190 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+var errNATS = errors.New("nats error")
+
+func newTestClient(t *testing.T, group string) (*natsClient, *MockJetStream, *MockMetrics) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	js := NewMockJetStream(ctrl)
+	metrics := NewMockMetrics(ctrl)
+
+	client := &natsClient{
+		js:        js,
+		consumers: make(map[string]Consumer),
+		logger:    logging.NewMockLogger(logging.DEBUG),
+		metrics:   metrics,
+		config:    Config{Server: "nats://localhost:4222", ConsumerGroupID: group, MaxWait: time.Millisecond},
+	}
+
+	return client, js, metrics
+}
+
+func TestNew_WithoutServer(t *testing.T) {
+	assert.Nil(t, New(Config{}, logging.NewMockLogger(logging.ERROR), nil))
+}
+
+func TestNatsClient_Publish(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		topic string
+		err   error
+	}{
+		{desc: "published", topic: "orders.created"},
+		{desc: "publish error", topic: "orders.created", err: errNATS},
+		{desc: "no topic", err: errPublisherNotConfigured},
+	}
+
+	for i, tc := range testCases {
+		client, js, metrics := newTestClient(t, "")
+
+		metrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_publish_total_count", "topic", tc.topic)
+
+		if tc.topic != "" {
+			js.EXPECT().Publish(gomock.Any(), tc.topic, []byte("hello")).Return(tc.err)
+		}
+
+		if tc.err == nil {
+			metrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_publish_success_count", "topic", tc.topic)
+		}
+
+		err := client.Publish(context.Background(), tc.topic, []byte("hello"))
+
+		require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestNatsClient_Subscribe(t *testing.T) {
+	client, js, metrics := newTestClient(t, "orders.service")
+
+	ctrl := gomock.NewController(t)
+	consumer := NewMockConsumer(ctrl)
+	msg := NewMockMsg(ctrl)
+
+	js.EXPECT().CreateStream(gomock.Any(), "orders_created", "orders.created").Return(nil)
+	js.EXPECT().Consumer(gomock.Any(), "orders_created", "orders_service", "orders.created").Return(consumer, nil)
+
+	// the consumer is polled again until a message is available.
+	gomock.InOrder(
+		consumer.EXPECT().Next(time.Millisecond).Return(nil, nil),
+		consumer.EXPECT().Next(time.Millisecond).Return(msg, nil).Times(2),
+	)
+
+	msg.EXPECT().Data().Return([]byte("hello")).AnyTimes()
+	msg.EXPECT().Headers().Return(nats.Header{"Id": []string{"1"}}).AnyTimes()
+	msg.EXPECT().Ack().Return(nil)
+
+	metrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_subscribe_total_count", "topic", "orders.created",
+		"consumer_group", "orders.service").Times(2)
+	metrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_subscribe_success_count", "topic", "orders.created",
+		"consumer_group", "orders.service").Times(2)
+
+	for i := 0; i < 2; i++ {
+		m, err := client.Subscribe(context.Background(), "orders.created")
+
+		require.NoError(t, err)
+		assert.Equal(t, "orders.created", m.Topic)
+		assert.Equal(t, []byte("hello"), m.Value)
+		assert.Equal(t, nats.Header{"Id": []string{"1"}}, m.MetaData)
+
+		if i == 0 {
+			m.Commit()
+		}
+	}
+}
+
+func TestNatsClient_SubscribeErrors(t *testing.T) {
+	client, _, _ := newTestClient(t, "")
+
+	_, err := client.Subscribe(context.Background(), "orders.created")
+	require.ErrorIs(t, err, ErrConsumerGroupNotProvided)
+
+	client, js, metrics := newTestClient(t, "orders")
+	metrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_subscribe_total_count", gomock.Any()).AnyTimes()
+
+	js.EXPECT().CreateStream(gomock.Any(), "orders_created", "orders.created").Return(errNATS)
+
+	_, err = client.Subscribe(context.Background(), "orders.created")
+	require.ErrorIs(t, err, errNATS)
+
+	consumer := NewMockConsumer(gomock.NewController(t))
+
+	js.EXPECT().CreateStream(gomock.Any(), "orders_created", "orders.created").Return(nil)
+	js.EXPECT().Consumer(gomock.Any(), "orders_created", "orders", "orders.created").Return(consumer, nil)
+	consumer.EXPECT().Next(time.Millisecond).Return(nil, errNATS)
+
+	_, err = client.Subscribe(context.Background(), "orders.created")
+	require.ErrorIs(t, err, errNATS)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.Subscribe(ctx, "orders.created")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNatsClient_Topics(t *testing.T) {
+	client, js, _ := newTestClient(t, "orders")
+	client.consumers["orders.created"] = nil
+
+	js.EXPECT().CreateStream(gomock.Any(), "orders_created", "orders.created").Return(nil)
+	js.EXPECT().DeleteStream(gomock.Any(), "orders_created").Return(nil)
+
+	require.NoError(t, client.CreateTopic(context.Background(), "orders.created"))
+	require.NoError(t, client.DeleteTopic(context.Background(), "orders.created"))
+	assert.Empty(t, client.consumers)
+
+	notConnected := &natsClient{}
+
+	require.ErrorIs(t, notConnected.CreateTopic(context.Background(), "orders"), errPublisherNotConfigured)
+	require.ErrorIs(t, notConnected.DeleteTopic(context.Background(), "orders"), errPublisherNotConfigured)
+	require.NoError(t, notConnected.Close())
+}
+
+func TestNatsClient_Health(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	testCases := []struct {
+		desc   string
+		status nats.Status
+		health datasource.Health
+	}{
+		{desc: "connected", status: nats.CONNECTED, health: datasource.Health{Status: datasource.StatusUp,
+			Details: map[string]interface{}{"host": "nats://localhost:4222", "backend": "NATS",
+				"connection_status": "CONNECTED", "consumers": 0}}},
+		{desc: "reconnecting", status: nats.RECONNECTING, health: datasource.Health{Status: datasource.StatusDown,
+			Details: map[string]interface{}{"host": "nats://localhost:4222", "backend": "NATS",
+				"connection_status": "RECONNECTING", "consumers": 0}}},
+	}
+
+	for i, tc := range testCases {
+		conn := NewMockConnection(ctrl)
+		conn.EXPECT().Status().Return(tc.status)
+
+		client, _, _ := newTestClient(t, "")
+		client.conn = conn
+
+		assert.Equal(t, tc.health, client.Health(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	client, _, _ := newTestClient(t, "")
+
+	assert.Equal(t, datasource.Health{Status: datasource.StatusDown, Details: map[string]interface{}{
+		"host": "nats://localhost:4222", "backend": "NATS", "error": "not connected"}}, client.Health())
+}
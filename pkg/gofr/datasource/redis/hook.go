@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/bulkhead"
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
 
 	"github.com/redis/go-redis/v9"
@@ -18,6 +20,10 @@ type redisHook struct {
 	config  *Config
 	logger  datasource.Logger
 	metrics Metrics
+	// bulkhead limits the concurrent commands, unless it is nil.
+	bulkhead *bulkhead.Bulkhead
+	// faults injects faults into the commands, unless it is nil.
+	faults *chaos.Injector
 }
 
 // QueryLog represents a logged Redis query.
@@ -82,11 +88,35 @@ func (r *redisHook) DialHook(next redis.DialHook) redis.DialHook {
 	return next
 }
 
+// acquire takes a slot of the bulkhead for the operation, and injects the faults matching it.
+func (r *redisHook) acquire(ctx context.Context, operation string) (release func(), err error) {
+	release, err = r.bulkhead.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.faults.Inject(ctx, chaos.TargetRedis, operation); err != nil {
+		release()
+
+		return nil, err
+	}
+
+	return release, nil
+}
+
 // ProcessHook implements the redis.ProcessHook interface.
 func (r *redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
 	return func(ctx context.Context, cmd redis.Cmder) error {
+		release, err := r.acquire(ctx, cmd.Name())
+		if err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+
+		defer release()
+
 		start := time.Now()
-		err := next(ctx, cmd)
+		err = next(ctx, cmd)
 		r.logQuery(start, cmd.Name(), cmd.Args()...)
 
 		return err
@@ -96,8 +126,19 @@ func (r *redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
 // ProcessPipelineHook implements the redis.ProcessPipelineHook interface.
 func (r *redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
 	return func(ctx context.Context, cmds []redis.Cmder) error {
+		release, err := r.acquire(ctx, "pipeline")
+		if err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+
+			return err
+		}
+
+		defer release()
+
 		start := time.Now()
-		err := next(ctx, cmds)
+		err = next(ctx, cmds)
 		r.logQuery(start, "pipeline", cmds[:len(cmds)-1])
 
 		return err
@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+const (
+	keyspacePrefix = "__keyspace@"
+	keyeventPrefix = "__keyevent@"
+)
+
+var errNotConnected = errors.New("redis not connected")
+
+// KeyspaceEvent represents a keyspace or keyevent notification published by Redis.
+//
+// Keyspace notifications are published on channels of the form `__keyspace@<db>__:<key>` with the event name
+// as payload, whereas keyevent notifications are published on `__keyevent@<db>__:<event>` with the key as payload.
+type KeyspaceEvent struct {
+	Pattern string `json:"pattern"`
+	Channel string `json:"channel"`
+	Payload string `json:"payload"`
+}
+
+// Key returns the key on which the event occurred.
+func (e KeyspaceEvent) Key() string {
+	if strings.HasPrefix(e.Channel, keyspacePrefix) {
+		return channelSuffix(e.Channel)
+	}
+
+	return e.Payload
+}
+
+// Event returns the name of the event, such as "set", "del" or "expired".
+func (e KeyspaceEvent) Event() string {
+	if strings.HasPrefix(e.Channel, keyeventPrefix) {
+		return channelSuffix(e.Channel)
+	}
+
+	return e.Payload
+}
+
+func channelSuffix(channel string) string {
+	_, suffix, _ := strings.Cut(channel, "__:")
+
+	return suffix
+}
+
+// SubscribeKeyspace subscribes to the keyspace notifications matching the patterns, like `__keyevent@0__:expired`,
+// until ctx is done. They must be enabled on the server, like with REDIS_KEYSPACE_EVENTS.
+func (r *Redis) SubscribeKeyspace(ctx context.Context, patterns ...string) (<-chan KeyspaceEvent, error) {
+	if r.Client == nil {
+		return nil, errNotConnected
+	}
+
+	ps := r.PSubscribe(ctx, patterns...)
+
+	// Receive waits for the subscription confirmation, so that no events published afterwards are missed.
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+
+		return nil, err
+	}
+
+	events := make(chan KeyspaceEvent)
+
+	go func() {
+		defer close(events)
+		defer ps.Close()
+
+		ch := ps.Channel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+
+				r.logger.Debugf("received keyspace notification on channel '%s': %s", msg.Channel, msg.Payload)
+
+				select {
+				case events <- KeyspaceEvent{Pattern: msg.Pattern, Channel: msg.Channel, Payload: msg.Payload}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// enableKeyspaceEvents sets the `notify-keyspace-events` server config, like "Ex".
+func (r *Redis) enableKeyspaceEvents(ctx context.Context, flags string) error {
+	return r.ConfigSet(ctx, "notify-keyspace-events", flags).Err()
+}
@@ -0,0 +1,84 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+func TestKeyspaceEvent_KeyAndEvent(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		event KeyspaceEvent
+		key   string
+		name  string
+	}{
+		{"keyspace notification", KeyspaceEvent{Channel: "__keyspace@0__:user:1", Payload: "set"}, "user:1", "set"},
+		{"keyevent notification", KeyspaceEvent{Channel: "__keyevent@0__:expired", Payload: "session:1"}, "session:1", "expired"},
+		{"key containing separator", KeyspaceEvent{Channel: "__keyspace@2__:a__:b", Payload: "del"}, "a__:b", "del"},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.key, tc.event.Key(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.name, tc.event.Event(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestRedis_SubscribeKeyspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	s, err := miniredis.Run()
+	assert.NoError(t, err)
+
+	defer s.Close()
+
+	mockMetrics := NewMockMetrics(ctrl)
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_redis_stats", gomock.Any(), "hostname", gomock.Any(),
+		"type", gomock.Any()).AnyTimes()
+
+	client := NewClient(config.NewMockConfig(map[string]string{
+		"REDIS_HOST": s.Host(),
+		"REDIS_PORT": s.Port(),
+	}), logging.NewMockLogger(logging.ERROR), mockMetrics)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, err := client.SubscribeKeyspace(ctx, "__keyevent@0__:*")
+	assert.NoError(t, err)
+
+	s.Publish("__keyevent@0__:expired", "session:1")
+
+	select {
+	case e := <-events:
+		assert.Equal(t, "__keyevent@0__:*", e.Pattern)
+		assert.Equal(t, "session:1", e.Key())
+		assert.Equal(t, "expired", e.Event())
+	case <-time.After(time.Second):
+		t.Fatal("TestRedis_SubscribeKeyspace Failed! notification not received")
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "TestRedis_SubscribeKeyspace Failed! expected events channel to be closed")
+	case <-time.After(time.Second):
+		t.Fatal("TestRedis_SubscribeKeyspace Failed! events channel not closed on context cancellation")
+	}
+}
+
+func TestRedis_SubscribeKeyspace_NotConnected(t *testing.T) {
+	r := &Redis{config: &Config{}, logger: logging.NewMockLogger(logging.ERROR)}
+
+	events, err := r.SubscribeKeyspace(context.Background(), "__keyevent@0__:expired")
+
+	assert.Nil(t, events)
+	assert.Equal(t, errNotConnected, err)
+}
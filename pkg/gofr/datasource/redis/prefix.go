@@ -0,0 +1,286 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var errUnscopedCommand = errors.New("command is not supported with a key prefix, as its keys are not known or not scoped")
+
+// keyPositions returns the positions of the keys in the arguments of a command.
+type keyPositions func(args []interface{}) []int
+
+//nolint:gochecknoglobals // the keys of the commands are constant.
+var (
+	// commandKeys are the positions of the keys of the commands whose keys are not only their first argument.
+	commandKeys = map[string]keyPositions{
+		"del": allKeys, "unlink": allKeys, "exists": allKeys, "touch": allKeys, "mget": allKeys, "watch": allKeys,
+		"sinter": allKeys, "sunion": allKeys, "sdiff": allKeys, "sinterstore": allKeys, "sunionstore": allKeys,
+		"sdiffstore": allKeys, "pfcount": allKeys, "pfmerge": allKeys,
+		"mset": everyOtherKey, "msetnx": everyOtherKey,
+		"rename": twoKeys, "renamenx": twoKeys, "rpoplpush": twoKeys, "smove": twoKeys, "lmove": twoKeys, "lcs": twoKeys,
+		"blmove": twoKeys, "brpoplpush": twoKeys, "copy": twoKeys, "zrangestore": twoKeys, "geosearchstore": twoKeys,
+		"blpop": allButLastKeys, "brpop": allButLastKeys, "bzpopmin": allButLastKeys, "bzpopmax": allButLastKeys,
+		"eval": numKeys(2), "evalsha": numKeys(2), "eval_ro": numKeys(2), "evalsha_ro": numKeys(2),
+		"fcall": numKeys(2), "fcall_ro": numKeys(2),
+		"zunion": numKeys(1), "zinter": numKeys(1), "zdiff": numKeys(1), "zintercard": numKeys(1),
+		"sintercard": numKeys(1), "lmpop": numKeys(1), "zmpop": numKeys(1), "blmpop": numKeys(2), "bzmpop": numKeys(2),
+		"zunionstore": storeNumKeys, "zinterstore": storeNumKeys, "zdiffstore": storeNumKeys,
+		"bitop": bitopKeys, "sort": sortKeys, "sort_ro": sortKeys,
+		"georadius": geoRadiusKeys, "georadiusbymember": geoRadiusKeys,
+		"xread": streamKeys, "xreadgroup": streamKeys,
+		"object": subcommandKey, "xinfo": subcommandKey, "xgroup": subcommandKey,
+		"ping": noKeys, "echo": noKeys, "info": noKeys, "time": noKeys, "hello": noKeys, "auth": noKeys,
+		"select": noKeys, "client": noKeys, "config": noKeys, "command": noKeys, "multi": noKeys, "exec": noKeys,
+		"discard": noKeys, "unwatch": noKeys, "script": noKeys, "function": noKeys, "dbsize": noKeys, "quit": noKeys,
+		"readonly": noKeys, "readwrite": noKeys, "wait": noKeys,
+	}
+
+	// firstKeyCommands are the commands whose only key is their first argument.
+	firstKeyCommands = commandSet(`get set setnx setex psetex getset getdel getex append strlen incr incrby incrbyfloat
+		decr decrby getrange setrange substr expire pexpire expireat pexpireat expiretime pexpiretime ttl pttl persist
+		type dump restore publish spublish
+		hget hset hsetnx hmset hmget hdel hexists hgetall hkeys hvals hlen hincrby hincrbyfloat hscan hstrlen hrandfield
+		hexpire hpexpire hexpireat hpexpireat hexpiretime hpexpiretime httl hpttl hpersist
+		lpush rpush lpushx rpushx lpop rpop llen lrange lindex lset linsert lrem ltrim lpos
+		sadd srem smembers sismember smismember scard spop srandmember sscan
+		zadd zrem zscore zmscore zincrby zcard zcount zlexcount zrange zrangebyscore zrangebylex zrevrange
+		zrevrangebyscore zrevrangebylex zrank zrevrank zremrangebyrank zremrangebyscore zremrangebylex zpopmin zpopmax
+		zrandmember zscan
+		pfadd setbit getbit bitcount bitpos bitfield bitfield_ro
+		geoadd geodist geohash geopos geosearch georadius_ro georadiusbymember_ro
+		xadd xlen xrange xrevrange xdel xtrim xack xpending xclaim xautoclaim xsetid`)
+
+	// unscopedCommands are the commands acting on all the keys of the database, which would escape the prefix.
+	unscopedCommands = commandSet("keys scan randomkey flushdb flushall swapdb move migrate")
+)
+
+func commandSet(names string) map[string]bool {
+	set := make(map[string]bool)
+
+	for _, name := range strings.Fields(names) {
+		set[name] = true
+	}
+
+	return set
+}
+
+func noKeys([]interface{}) []int { return nil }
+
+func firstKey(args []interface{}) []int {
+	return positions(1, min(2, len(args)), 1)
+}
+
+func allKeys(args []interface{}) []int {
+	return positions(1, len(args), 1)
+}
+
+func everyOtherKey(args []interface{}) []int {
+	return positions(1, len(args), 2)
+}
+
+func twoKeys(args []interface{}) []int {
+	return positions(1, min(3, len(args)), 1)
+}
+
+func allButLastKeys(args []interface{}) []int {
+	return positions(1, len(args)-1, 1)
+}
+
+// subcommandKey returns the position of the key following the subcommand, like for OBJECT ENCODING.
+func subcommandKey(args []interface{}) []int {
+	return positions(2, min(3, len(args)), 1)
+}
+
+// numKeys returns the positions of the keys following the number of keys at the position, like for EVAL.
+func numKeys(at int) keyPositions {
+	return func(args []interface{}) []int {
+		if len(args) <= at {
+			return nil
+		}
+
+		n, err := strconv.Atoi(fmt.Sprint(args[at]))
+		if err != nil {
+			return nil
+		}
+
+		return positions(at+1, min(at+1+n, len(args)), 1)
+	}
+}
+
+// storeNumKeys returns the positions of the keys of the commands like ZUNIONSTORE.
+func storeNumKeys(args []interface{}) []int {
+	if len(args) < 2 {
+		return nil
+	}
+
+	return append([]int{1}, numKeys(2)(args)...)
+}
+
+// bitopKeys returns the positions of the destination and the source keys following the operation of BITOP.
+func bitopKeys(args []interface{}) []int {
+	return positions(2, len(args), 1)
+}
+
+// sortKeys returns the positions of the key of SORT, of its STORE destination, and of its BY and GET patterns.
+func sortKeys(args []interface{}) []int {
+	p := firstKey(args)
+
+	for i := 2; i < len(args)-1; i++ {
+		value := fmt.Sprint(args[i+1])
+
+		switch strings.ToLower(fmt.Sprint(args[i])) {
+		case "store":
+		case "by":
+			if strings.EqualFold(value, "nosort") {
+				continue
+			}
+		case "get":
+			if value == "#" {
+				continue
+			}
+		default:
+			continue
+		}
+
+		i++
+		p = append(p, i)
+	}
+
+	return p
+}
+
+// geoRadiusKeys returns the positions of the key of GEORADIUS and of its STORE or STOREDIST destination.
+func geoRadiusKeys(args []interface{}) []int {
+	p := firstKey(args)
+
+	for i := 2; i < len(args)-1; i++ {
+		if name := strings.ToLower(fmt.Sprint(args[i])); name == "store" || name == "storedist" {
+			i++
+			p = append(p, i)
+		}
+	}
+
+	return p
+}
+
+// streamKeys returns the positions of the keys following the STREAMS of XREAD, which are followed by as many IDs.
+func streamKeys(args []interface{}) []int {
+	for i := 1; i < len(args); i++ {
+		if strings.EqualFold(fmt.Sprint(args[i]), "streams") {
+			return positions(i+1, i+1+(len(args)-i-1)/2, 1)
+		}
+	}
+
+	return nil
+}
+
+func positions(from, to, step int) []int {
+	var p []int
+
+	for i := from; i < to; i += step {
+		p = append(p, i)
+	}
+
+	return p
+}
+
+// keyPrefixHook prefixes the keys of the commands with the prefix of their context.
+type keyPrefixHook struct {
+	prefix func(ctx context.Context) (string, error)
+}
+
+// WithKeyPrefix returns a Redis sharing the connections of r, which prefixes the keys with the prefix of the context
+// of each command, like the tenant.
+func (r *Redis) WithKeyPrefix(prefix func(ctx context.Context) (string, error)) *Redis {
+	if r == nil || r.Client == nil {
+		return r
+	}
+
+	// WithTimeout clones the client with the same connections, so that the hook is not added to r.
+	rc := r.Client.WithTimeout(r.Client.Options().ReadTimeout)
+	rc.AddHook(keyPrefixHook{prefix: prefix})
+
+	return &Redis{Client: rc, logger: r.logger, config: r.config}
+}
+
+func (keyPrefixHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (h keyPrefixHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := h.prefixKeys(ctx, cmd); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h keyPrefixHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := h.prefixKeys(ctx, cmd); err != nil {
+				for _, c := range cmds {
+					c.SetErr(err)
+				}
+
+				return err
+			}
+		}
+
+		return next(ctx, cmds)
+	}
+}
+
+func (h keyPrefixHook) prefixKeys(ctx context.Context, cmd redis.Cmder) error {
+	name := strings.ToLower(cmd.Name())
+	if unscopedCommands[name] {
+		return fmt.Errorf("%w: %s", errUnscopedCommand, name)
+	}
+
+	keys, ok := commandKeys[name]
+
+	switch {
+	case ok:
+	case firstKeyCommands[name]:
+		keys = firstKey
+	default:
+		return fmt.Errorf("%w: %s", errUnscopedCommand, name)
+	}
+
+	args := cmd.Args()
+
+	p := keys(args)
+	if len(p) == 0 {
+		return nil
+	}
+
+	prefix, err := h.prefix(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, i := range p {
+		switch key := args[i].(type) {
+		case string:
+			args[i] = prefix + ":" + key
+		case []byte:
+			args[i] = append([]byte(prefix+":"), key...)
+		default:
+			args[i] = prefix + ":" + fmt.Sprint(key)
+		}
+	}
+
+	return nil
+}
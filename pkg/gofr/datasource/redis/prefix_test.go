@@ -0,0 +1,119 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+type prefixKey struct{}
+
+func contextPrefix(ctx context.Context) (string, error) {
+	prefix, ok := ctx.Value(prefixKey{}).(string)
+	if !ok {
+		return "", errors.New("no prefix")
+	}
+
+	return prefix, nil
+}
+
+func TestKeyPrefixHook_Keys(t *testing.T) {
+	testCases := []struct {
+		args []interface{}
+		want []interface{}
+	}{
+		{[]interface{}{"get", "a"}, []interface{}{"get", "t:a"}},
+		{[]interface{}{"hset", "a", "f", "v"}, []interface{}{"hset", "t:a", "f", "v"}},
+		{[]interface{}{"del", "a", "b"}, []interface{}{"del", "t:a", "t:b"}},
+		{[]interface{}{"mset", "a", "1", "b", "2"}, []interface{}{"mset", "t:a", "1", "t:b", "2"}},
+		{[]interface{}{"rename", "a", "b"}, []interface{}{"rename", "t:a", "t:b"}},
+		{[]interface{}{"blpop", "a", "b", 0}, []interface{}{"blpop", "t:a", "t:b", 0}},
+		{[]interface{}{"eval", "script", 2, "a", "b", "arg"}, []interface{}{"eval", "script", 2, "t:a", "t:b", "arg"}},
+		{[]interface{}{"zunionstore", "d", 2, "a", "b"}, []interface{}{"zunionstore", "t:d", 2, "t:a", "t:b"}},
+		{[]interface{}{"zrangestore", "d", "a", 0, -1}, []interface{}{"zrangestore", "t:d", "t:a", 0, -1}},
+		{[]interface{}{"geosearchstore", "d", "a", "frommember", "m", "byradius", 1, "km"},
+			[]interface{}{"geosearchstore", "t:d", "t:a", "frommember", "m", "byradius", 1, "km"}},
+		{[]interface{}{"georadius", "a", 1, 2, 3, "km", "store", "d"}, []interface{}{"georadius", "t:a", 1, 2, 3, "km", "store", "t:d"}},
+		{[]interface{}{"sort", "a", "by", "w_*", "get", "#", "get", "o_*", "store", "d"},
+			[]interface{}{"sort", "t:a", "by", "t:w_*", "get", "#", "get", "t:o_*", "store", "t:d"}},
+		{[]interface{}{"sort", "a", "by", "nosort"}, []interface{}{"sort", "t:a", "by", "nosort"}},
+		{[]interface{}{"bitop", "and", "d", "a", "b"}, []interface{}{"bitop", "and", "t:d", "t:a", "t:b"}},
+		{[]interface{}{"zunion", 2, "a", "b", "withscores"}, []interface{}{"zunion", 2, "t:a", "t:b", "withscores"}},
+		{[]interface{}{"zinter", 2, "a", "b"}, []interface{}{"zinter", 2, "t:a", "t:b"}},
+		{[]interface{}{"zdiff", 2, "a", "b"}, []interface{}{"zdiff", 2, "t:a", "t:b"}},
+		{[]interface{}{"sintercard", 2, "a", "b", "limit", 1}, []interface{}{"sintercard", 2, "t:a", "t:b", "limit", 1}},
+		{[]interface{}{"lmpop", 2, "a", "b", "left"}, []interface{}{"lmpop", 2, "t:a", "t:b", "left"}},
+		{[]interface{}{"blmpop", 0, 1, "a", "left"}, []interface{}{"blmpop", 0, 1, "t:a", "left"}},
+		{[]interface{}{"zmpop", 1, "a", "min"}, []interface{}{"zmpop", 1, "t:a", "min"}},
+		{[]interface{}{"xread", "count", 1, "streams", "a", "b", "0", "0"},
+			[]interface{}{"xread", "count", 1, "streams", "t:a", "t:b", "0", "0"}},
+		{[]interface{}{"xreadgroup", "group", "g", "c", "streams", "a", ">"},
+			[]interface{}{"xreadgroup", "group", "g", "c", "streams", "t:a", ">"}},
+		{[]interface{}{"object", "encoding", "a"}, []interface{}{"object", "encoding", "t:a"}},
+		{[]interface{}{"xgroup", "create", "a", "g", "$"}, []interface{}{"xgroup", "create", "t:a", "g", "$"}},
+		{[]interface{}{"get", []byte("a")}, []interface{}{"get", []byte("t:a")}},
+		{[]interface{}{"ping"}, []interface{}{"ping"}},
+	}
+
+	h := keyPrefixHook{prefix: func(context.Context) (string, error) { return "t", nil }}
+
+	for i, tc := range testCases {
+		cmd := redis.NewCmd(context.Background(), tc.args...)
+
+		require.NoError(t, h.prefixKeys(context.Background(), cmd), "TEST[%d], Failed.\n%v", i, tc.args)
+		assert.Equal(t, tc.want, cmd.Args(), "TEST[%d], Failed.\n%v", i, tc.args)
+	}
+
+	for _, args := range [][]interface{}{{"keys", "*"}, {"flushdb"}, {"json.get", "a"}, {"unknown", "a", "b"}} {
+		err := h.prefixKeys(context.Background(), redis.NewCmd(context.Background(), args...))
+		assert.ErrorIs(t, err, errUnscopedCommand, "%v", args)
+	}
+}
+
+func TestRedis_WithKeyPrefix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	client := newTypedTestClient(t, ctrl, s, logging.NewMockLogger(logging.ERROR))
+	scoped := client.WithKeyPrefix(contextPrefix)
+
+	acme := context.WithValue(context.Background(), prefixKey{}, "acme")
+	globex := context.WithValue(context.Background(), prefixKey{}, "globex")
+
+	require.NoError(t, scoped.Set(acme, "plan", "pro", 0).Err())
+	require.NoError(t, scoped.Set(globex, "plan", "free", 0).Err())
+
+	assert.Equal(t, "pro", scoped.Get(acme, "plan").Val())
+	assert.Equal(t, "free", scoped.Get(globex, "plan").Val())
+	assert.Equal(t, []string{"acme:plan", "globex:plan"}, s.Keys())
+
+	// the client the scoped one is derived from is not prefixed.
+	require.NoError(t, client.Set(acme, "plan", "none", 0).Err())
+	assert.Equal(t, "none", client.Get(acme, "plan").Val())
+	assert.Equal(t, "pro", scoped.Get(acme, "plan").Val())
+
+	pipe := scoped.Pipeline()
+	pipe.Incr(acme, "visits")
+	pipe.Incr(acme, "visits")
+
+	_, err = pipe.Exec(acme)
+	require.NoError(t, err)
+
+	visits, _ := s.Get("acme:visits")
+	assert.Equal(t, "2", visits)
+
+	assert.EqualError(t, scoped.Get(context.Background(), "plan").Err(), "no prefix")
+	assert.ErrorIs(t, scoped.FlushDB(acme).Err(), errUnscopedCommand)
+}
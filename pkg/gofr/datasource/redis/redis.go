@@ -9,6 +9,8 @@ import (
 	otel "github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/bulkhead"
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
 	"github.com/peter-stratton/gofr/pkg/gofr/config"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
 )
@@ -22,6 +24,9 @@ type Config struct {
 	HostName string
 	Port     int
 	Options  *redis.Options
+
+	// KeyspaceEvents is set as the `notify-keyspace-events` server config once connected, like "Ex".
+	KeyspaceEvents string
 }
 
 type Redis struct {
@@ -30,9 +35,27 @@ type Redis struct {
 	config *Config
 }
 
+// Option configures the client created using NewClient.
+type Option func(h *redisHook)
+
+// WithBulkhead limits the concurrent commands of the client with the bulkhead.
+func WithBulkhead(b *bulkhead.Bulkhead) Option {
+	return func(h *redisHook) {
+		h.bulkhead = b
+	}
+}
+
+// WithFaultInjector injects the faults of the injector into the commands and pipelines of the client, whose
+// operations are the names of the commands, or "pipeline".
+func WithFaultInjector(i *chaos.Injector) Option {
+	return func(h *redisHook) {
+		h.faults = i
+	}
+}
+
 // NewClient return a redis client if connection is successful based on Config.
 // In case of error, it returns an error as second parameter.
-func NewClient(c config.Config, logger datasource.Logger, metrics Metrics) *Redis {
+func NewClient(c config.Config, logger datasource.Logger, metrics Metrics, opts ...Option) *Redis {
 	redisConfig := getRedisConfig(c)
 
 	// if Hostname is not provided, we won't try to connect to Redis
@@ -43,7 +66,13 @@ func NewClient(c config.Config, logger datasource.Logger, metrics Metrics) *Redi
 	logger.Debugf("connecting to redis at '%s:%d'", redisConfig.HostName, redisConfig.Port)
 
 	rc := redis.NewClient(redisConfig.Options)
-	rc.AddHook(&redisHook{config: redisConfig, logger: logger, metrics: metrics})
+	hook := &redisHook{config: redisConfig, logger: logger, metrics: metrics}
+
+	for _, o := range opts {
+		o(hook)
+	}
+
+	rc.AddHook(hook)
 
 	ctx, cancel := context.WithTimeout(context.TODO(), redisPingTimeout)
 	defer cancel()
@@ -60,7 +89,42 @@ func NewClient(c config.Config, logger datasource.Logger, metrics Metrics) *Redi
 
 	logger.Logf("connected to redis at %s:%d", redisConfig.HostName, redisConfig.Port)
 
-	return &Redis{Client: rc, config: redisConfig, logger: logger}
+	r := &Redis{Client: rc, config: redisConfig, logger: logger}
+
+	if redisConfig.KeyspaceEvents != "" {
+		if err := r.enableKeyspaceEvents(ctx, redisConfig.KeyspaceEvents); err != nil {
+			logger.Errorf("could not enable keyspace notifications '%s', error: %s", redisConfig.KeyspaceEvents, err)
+		}
+	}
+
+	return r
+}
+
+// Unwrap returns the client of r, so that the libraries which take a *redis.Client, like the queues of asynq, share the
+// connections managed by gofr instead of opening a pool of their own. The client:
+//
+//   - is configured by the REDIS_* configs, and its commands are traced, logged, recorded by the app_redis_stats metric,
+//     limited by the bulkhead and subject to the injected faults, as those of r are;
+//   - is monitored by the health checks of the application;
+//   - stays open until the application shuts down, and is closed by it once the server stopped, so it must not be
+//     closed by its users.
+//
+// It returns nil if r is nil, or if it could not connect to Redis.
+func (r *Redis) Unwrap() *redis.Client {
+	if r == nil {
+		return nil
+	}
+
+	return r.Client
+}
+
+// Close closes the connections of the client, unless it could not connect to Redis.
+func (r *Redis) Close() error {
+	if r.Client == nil {
+		return nil
+	}
+
+	return r.Client.Close()
 }
 
 func getRedisConfig(c config.Config) *Config {
@@ -75,6 +139,8 @@ func getRedisConfig(c config.Config) *Config {
 
 	redisConfig.Port = port
 
+	redisConfig.KeyspaceEvents = c.Get("REDIS_KEYSPACE_EVENTS")
+
 	options := new(redis.Options)
 
 	if options.Addr == "" {
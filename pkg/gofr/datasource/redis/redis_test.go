@@ -2,14 +2,18 @@ package redis
 
 import (
 	"context"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/bulkhead"
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
 	"github.com/peter-stratton/gofr/pkg/gofr/config"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
 	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
@@ -122,3 +126,63 @@ func TestRedis_PipelineQueryLogging(t *testing.T) {
 	assert.Contains(t, result, "ping")
 	assert.Contains(t, result, "set key1 value1 ex 60: OK")
 }
+
+func TestRedis_Bulkhead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, err := miniredis.Run()
+	assert.Nil(t, err)
+
+	defer s.Close()
+
+	mockMetric := NewMockMetrics(ctrl)
+	mockMetric.EXPECT().RecordHistogram(gomock.Any(), "app_redis_stats", gomock.Any(), "hostname", gomock.Any(),
+		"type", gomock.Any()).AnyTimes()
+
+	b := bulkhead.New("redis", bulkhead.Config{MaxConcurrent: 1}, nil)
+
+	client := NewClient(config.NewMockConfig(map[string]string{"REDIS_HOST": s.Host(), "REDIS_PORT": s.Port()}),
+		logging.NewMockLogger(logging.ERROR), mockMetric, WithBulkhead(b))
+
+	release, err := b.Acquire(context.Background())
+	assert.Nil(t, err)
+
+	err = client.Set(context.Background(), "key", "value", 0).Err()
+	assert.ErrorIs(t, err, bulkhead.ErrFull)
+
+	cmds, err := client.Pipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.Get(context.Background(), "key")
+		return nil
+	})
+	assert.ErrorIs(t, err, bulkhead.ErrFull)
+	assert.ErrorIs(t, cmds[0].Err(), bulkhead.ErrFull)
+
+	release()
+
+	assert.Nil(t, client.Set(context.Background(), "key", "value", 0).Err())
+}
+
+func TestRedis_FaultInjector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, err := miniredis.Run()
+	assert.Nil(t, err)
+
+	defer s.Close()
+
+	mockMetric := NewMockMetrics(ctrl)
+	mockMetric.EXPECT().RecordHistogram(gomock.Any(), "app_redis_stats", gomock.Any(), "hostname", gomock.Any(),
+		"type", gomock.Any()).AnyTimes()
+
+	faults, err := chaos.New(nil, chaos.Fault{Target: chaos.TargetRedis, Operation: "^get$", Kind: chaos.KindReset,
+		Rate: 1})
+	assert.Nil(t, err)
+
+	client := NewClient(config.NewMockConfig(map[string]string{"REDIS_HOST": s.Host(), "REDIS_PORT": s.Port()}),
+		logging.NewMockLogger(logging.ERROR), mockMetric, WithFaultInjector(faults))
+
+	assert.Nil(t, client.Set(context.Background(), "key", "value", 0).Err())
+	assert.ErrorIs(t, client.Get(context.Background(), "key").Err(), syscall.ECONNRESET)
+}
@@ -0,0 +1,289 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	errNotStructPointer = errors.New("destination should be a pointer to a struct")
+	errNotSlicePointer  = errors.New("destination should be a pointer to a slice")
+	errNotStruct        = errors.New("value should be a struct or a pointer to a struct")
+)
+
+// SetJSON stores the value at key as JSON. A zero expiration means the key has no expiration.
+func (r *Redis) SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return r.Set(ctx, key, string(data), expiration).Err()
+}
+
+// GetInto unmarshals the JSON value at key into dest. It returns redis.Nil if the key does not exist.
+func (r *Redis) GetInto(ctx context.Context, key string, dest interface{}) error {
+	data, err := r.Get(ctx, key).Bytes()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, dest)
+}
+
+// HSetStruct stores the exported fields of a struct as the fields of the hash at key.
+//
+// The hash field name is taken from the `redis` tag, falling back to the `json` tag and then to the struct field
+// name. Fields tagged with "-" are skipped. Strings, booleans and numbers are stored as is, while all other types are
+// stored as JSON.
+func (r *Redis) HSetStruct(ctx context.Context, key string, value interface{}) error {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return errNotStruct
+	}
+
+	fields := make(map[string]interface{})
+
+	for i := 0; i < v.NumField(); i++ {
+		name, ok := hashFieldName(v.Type().Field(i))
+		if !ok {
+			continue
+		}
+
+		val, err := encodeHashField(v.Field(i))
+		if err != nil {
+			return err
+		}
+
+		fields[name] = val
+	}
+
+	return r.HSet(ctx, key, fields).Err()
+}
+
+// HGetAllInto populates the struct dest points to with the fields of the hash at key, as named by HSetStruct.
+func (r *Redis) HGetAllInto(ctx context.Context, key string, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return errNotStructPointer
+	}
+
+	values, err := r.HGetAll(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		return redis.Nil
+	}
+
+	v = v.Elem()
+
+	for i := 0; i < v.NumField(); i++ {
+		name, ok := hashFieldName(v.Type().Field(i))
+		if !ok {
+			continue
+		}
+
+		val, ok := values[name]
+		if !ok {
+			continue
+		}
+
+		if err := decodeHashField(v.Field(i), val); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LPushJSON marshals each value as JSON and prepends them to the list at key.
+func (r *Redis) LPushJSON(ctx context.Context, key string, values ...interface{}) error {
+	data, err := marshalAll(values)
+	if err != nil {
+		return err
+	}
+
+	return r.LPush(ctx, key, data...).Err()
+}
+
+// RPushJSON marshals each value as JSON and appends them to the list at key.
+func (r *Redis) RPushJSON(ctx context.Context, key string, values ...interface{}) error {
+	data, err := marshalAll(values)
+	if err != nil {
+		return err
+	}
+
+	return r.RPush(ctx, key, data...).Err()
+}
+
+// LPopInto removes the first element of the list at key and unmarshals it into dest.
+func (r *Redis) LPopInto(ctx context.Context, key string, dest interface{}) error {
+	data, err := r.LPop(ctx, key).Bytes()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, dest)
+}
+
+// RPopInto removes the last element of the list at key and unmarshals it into dest.
+func (r *Redis) RPopInto(ctx context.Context, key string, dest interface{}) error {
+	data, err := r.RPop(ctx, key).Bytes()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, dest)
+}
+
+// LRangeInto unmarshals the elements of the list at key between start and stop into the slice dest points to.
+func (r *Redis) LRangeInto(ctx context.Context, key string, start, stop int64, dest interface{}) error {
+	values, err := r.LRange(ctx, key, start, stop).Result()
+	if err != nil {
+		return err
+	}
+
+	return unmarshalAll(values, dest)
+}
+
+// SAddJSON marshals each member as JSON and adds them to the set at key.
+func (r *Redis) SAddJSON(ctx context.Context, key string, members ...interface{}) error {
+	data, err := marshalAll(members)
+	if err != nil {
+		return err
+	}
+
+	return r.SAdd(ctx, key, data...).Err()
+}
+
+// SMembersInto fetches all the members of the set at key and unmarshals them into the slice dest points to.
+func (r *Redis) SMembersInto(ctx context.Context, key string, dest interface{}) error {
+	values, err := r.SMembers(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	return unmarshalAll(values, dest)
+}
+
+func marshalAll(values []interface{}) ([]interface{}, error) {
+	data := make([]interface{}, 0, len(values))
+
+	for _, v := range values {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, string(b))
+	}
+
+	return data, nil
+}
+
+// unmarshalAll decodes a list of JSON values into the slice dest points to, by decoding them as a single JSON array.
+func unmarshalAll(values []string, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return errNotSlicePointer
+	}
+
+	return json.Unmarshal([]byte("["+strings.Join(values, ",")+"]"), dest)
+}
+
+func hashFieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+
+	for _, tagName := range []string{"redis", "json"} {
+		tag, _, _ := strings.Cut(f.Tag.Get(tagName), ",")
+
+		switch tag {
+		case "-":
+			return "", false
+		case "":
+			continue
+		default:
+			return tag, true
+		}
+	}
+
+	return f.Name, true
+}
+
+func encodeHashField(v reflect.Value) (interface{}, error) {
+	//nolint:exhaustive // all the other kinds are stored as JSON
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func decodeHashField(f reflect.Value, val string) error {
+	//nolint:exhaustive // all the other kinds are stored as JSON
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(val, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		f.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(val, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		f.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		fl, err := strconv.ParseFloat(val, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		f.SetFloat(fl)
+	default:
+		return json.Unmarshal([]byte(val), f.Addr().Interface())
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
+)
+
+type address struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type user struct {
+	ID        int       `redis:"id"`
+	Name      string    `json:"name"`
+	Active    bool      `redis:"active"`
+	Score     float64   `redis:"score"`
+	Tags      []string  `redis:"tags"`
+	Address   address   `redis:"address"`
+	CreatedAt time.Time `redis:"created_at"`
+	Ignored   string    `redis:"-"`
+	Untagged  uint
+	internal  string
+}
+
+func newTypedTestClient(t *testing.T, ctrl *gomock.Controller, s *miniredis.Miniredis, logger logging.Logger) *Redis {
+	t.Helper()
+
+	mockMetrics := NewMockMetrics(ctrl)
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_redis_stats", gomock.Any(), "hostname", gomock.Any(),
+		"type", gomock.Any()).AnyTimes()
+
+	return NewClient(config.NewMockConfig(map[string]string{
+		"REDIS_HOST": s.Host(),
+		"REDIS_PORT": s.Port(),
+	}), logger, mockMetrics)
+}
+
+func TestRedis_HashStruct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	s, err := miniredis.Run()
+	assert.NoError(t, err)
+
+	defer s.Close()
+
+	client := newTypedTestClient(t, ctrl, s, logging.NewMockLogger(logging.ERROR))
+	ctx := context.Background()
+
+	in := user{
+		ID: 1, Name: "gofr", Active: true, Score: 9.5, Tags: []string{"a", "b"},
+		Address:   address{City: "Bengaluru", Zip: "560001"},
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Ignored:   "ignored", Untagged: 7, internal: "internal",
+	}
+
+	err = client.HSetStruct(ctx, "user:1", &in)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1", s.HGet("user:1", "id"))
+	assert.Equal(t, "gofr", s.HGet("user:1", "name"))
+	assert.Equal(t, `["a","b"]`, s.HGet("user:1", "tags"))
+	assert.Equal(t, "7", s.HGet("user:1", "Untagged"))
+
+	keys, err := s.HKeys("user:1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Untagged", "active", "address", "created_at", "id", "name", "score", "tags"}, keys)
+
+	var out user
+
+	err = client.HGetAllInto(ctx, "user:1", &out)
+	assert.NoError(t, err)
+
+	in.Ignored, in.internal = "", ""
+	assert.Equal(t, in, out)
+}
+
+func TestRedis_HashStruct_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	s, err := miniredis.Run()
+	assert.NoError(t, err)
+
+	defer s.Close()
+
+	client := newTypedTestClient(t, ctrl, s, logging.NewMockLogger(logging.ERROR))
+	ctx := context.Background()
+
+	var u user
+
+	assert.Equal(t, errNotStruct, client.HSetStruct(ctx, "key", "not a struct"))
+	assert.Equal(t, errNotStructPointer, client.HGetAllInto(ctx, "key", u))
+	assert.Equal(t, redis.Nil, client.HGetAllInto(ctx, "missing", &u))
+
+	s.HSet("user:2", "id", "abc")
+	assert.Error(t, client.HGetAllInto(ctx, "user:2", &u))
+}
+
+func TestRedis_JSONHelpers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	s, err := miniredis.Run()
+	assert.NoError(t, err)
+
+	defer s.Close()
+
+	var (
+		client *Redis
+		ctx    = context.Background()
+	)
+
+	logs := testutil.StdoutOutputForFunc(func() {
+		client = newTypedTestClient(t, ctrl, s, logging.NewMockLogger(logging.DEBUG))
+
+		assert.NoError(t, client.SetJSON(ctx, "addr", address{City: "Pune"}, time.Minute))
+	})
+
+	// typed helpers are logged in the same way as primitive commands
+	assert.Contains(t, logs, `set addr {"city":"Pune","zip":""} ex 60`)
+
+	var addr address
+
+	assert.NoError(t, client.GetInto(ctx, "addr", &addr))
+	assert.Equal(t, address{City: "Pune"}, addr)
+	assert.Equal(t, redis.Nil, client.GetInto(ctx, "missing", &addr))
+
+	assert.NoError(t, client.RPushJSON(ctx, "list", address{City: "b"}, address{City: "c"}))
+	assert.NoError(t, client.LPushJSON(ctx, "list", address{City: "a"}))
+
+	var list []address
+
+	assert.NoError(t, client.LRangeInto(ctx, "list", 0, -1, &list))
+	assert.Equal(t, []address{{City: "a"}, {City: "b"}, {City: "c"}}, list)
+	assert.Equal(t, errNotSlicePointer, client.LRangeInto(ctx, "list", 0, -1, list))
+
+	assert.NoError(t, client.LPopInto(ctx, "list", &addr))
+	assert.Equal(t, address{City: "a"}, addr)
+
+	assert.NoError(t, client.RPopInto(ctx, "list", &addr))
+	assert.Equal(t, address{City: "c"}, addr)
+
+	assert.NoError(t, client.SAddJSON(ctx, "set", 1, 2, 2))
+
+	var members []int
+
+	assert.NoError(t, client.SMembersInto(ctx, "set", &members))
+	assert.ElementsMatch(t, []int{1, 2}, members)
+}
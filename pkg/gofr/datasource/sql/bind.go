@@ -2,11 +2,14 @@ package sql
 
 import (
 	"fmt"
+	"strings"
 )
 
 const (
-	dialectMysql    = "mysql"
-	dialectPostgres = "postgres"
+	dialectMysql      = "mysql"
+	dialectPostgres   = "postgres"
+	dialectMSSQL      = "mssql"
+	dialectClickHouse = "clickhouse"
 
 	quoteBack   = "`"
 	quoteDouble = `"`
@@ -19,28 +22,69 @@ const (
 	UNKNOWN BindVarType = iota + 1
 	QUESTION
 	DOLLAR
+	// AT is the "@p1" bindvars of SQL Server.
+	AT
 )
 
 func bindType(dialect string) BindVarType {
 	switch dialect {
-	case dialectMysql:
+	case dialectMysql, dialectClickHouse:
 		return QUESTION
 	case dialectPostgres:
 		return DOLLAR
+	case dialectMSSQL:
+		return AT
 	default:
 		return UNKNOWN
 	}
 }
 
 func bindVar(dialect string, position int) string {
-	if DOLLAR == bindType(dialect) {
+	switch bindType(dialect) {
+	case DOLLAR:
 		return fmt.Sprintf("$%v", position)
+	case AT:
+		return fmt.Sprintf("@p%v", position)
+	default:
+		return "?"
+	}
+}
+
+// Rebind rewrites the "?" bindvars of the query to those of the dialect, like "$1" for postgres.
+func Rebind(dialect, query string) string {
+	if t := bindType(dialect); t != DOLLAR && t != AT {
+		return query
 	}
 
-	return "?"
+	var b strings.Builder
+
+	n := 0
+
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+
+		n++
+
+		b.WriteString(bindVar(dialect, n))
+	}
+
+	return b.String()
 }
+
+// Limit returns the clause limiting the rows of a query to a "?" bindvar, after its ORDER BY.
+func Limit(dialect string) string {
+	if dialect == dialectMSSQL {
+		return " OFFSET 0 ROWS FETCH NEXT ? ROWS ONLY"
+	}
+
+	return " LIMIT ?"
+}
+
 func quote(dialect string) string {
-	if dialectPostgres == dialect {
+	if dialectPostgres == dialect || dialectMSSQL == dialect {
 		return quoteDouble
 	}
 
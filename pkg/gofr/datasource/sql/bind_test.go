@@ -19,6 +19,14 @@ func Test_BindType(t *testing.T) {
 			dialect:  "postgres",
 			expected: DOLLAR,
 		},
+		{
+			dialect:  "mssql",
+			expected: AT,
+		},
+		{
+			dialect:  "clickhouse",
+			expected: QUESTION,
+		},
 		{
 			dialect:  "any-other-dialect",
 			expected: UNKNOWN,
@@ -51,6 +59,12 @@ func Test_BindVar(t *testing.T) {
 			position: 1,
 			expected: "?",
 		},
+		{
+			name:     "MSSQL bind var",
+			dialect:  dialectMSSQL,
+			position: 2,
+			expected: "@p2",
+		},
 		{
 			name:     "Unknown dialect bind var",
 			dialect:  "unknown",
@@ -67,6 +81,47 @@ func Test_BindVar(t *testing.T) {
 	}
 }
 
+func Test_Rebind(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  string
+		expected string
+	}{
+		{
+			name:     "Postgres bind vars",
+			dialect:  dialectPostgres,
+			expected: "UPDATE t SET a = $1 WHERE id = $2",
+		},
+		{
+			name:     "MySQL bind vars",
+			dialect:  dialectMysql,
+			expected: "UPDATE t SET a = ? WHERE id = ?",
+		},
+		{
+			name:     "SQLite bind vars",
+			dialect:  "sqlite",
+			expected: "UPDATE t SET a = ? WHERE id = ?",
+		},
+		{
+			name:     "MSSQL bind vars",
+			dialect:  dialectMSSQL,
+			expected: "UPDATE t SET a = @p1 WHERE id = @p2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Rebind(tc.dialect, "UPDATE t SET a = ? WHERE id = ?"))
+		})
+	}
+}
+
+func Test_Limit(t *testing.T) {
+	assert.Equal(t, "SELECT id FROM t ORDER BY id LIMIT ?", "SELECT id FROM t ORDER BY id"+Limit(dialectPostgres))
+	assert.Equal(t, "SELECT id FROM t ORDER BY id OFFSET 0 ROWS FETCH NEXT ? ROWS ONLY",
+		"SELECT id FROM t ORDER BY id"+Limit(dialectMSSQL))
+}
+
 func Test_Quote(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,558 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	errNoTable             = errors.New("the table of the query is missing")
+	errNoValues            = errors.New("the values of the query are missing")
+	errValuesCount         = errors.New("the number of the values does not match the number of the columns")
+	errNoWhere             = errors.New("the query has no where clause, so it would change every row of the table")
+	errReturningNotAllowed = errors.New("RETURNING is not supported by the dialect")
+	errEmptyIn             = errors.New("the slice of the values of IN is empty")
+	errPagingWithoutOrder  = errors.New("the limit and the offset of the query require an ORDER BY in the dialect")
+)
+
+// Querier is the datasource on which the built queries are run, like the DB of gofr.
+type Querier interface {
+	Dialect() string
+	Select(ctx context.Context, data interface{}, query string, args ...interface{})
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// condition is a condition of a where clause, whose "?" are the bindvars of its args.
+type condition struct {
+	expr string
+	args []interface{}
+}
+
+type conditions []condition
+
+func (c *conditions) add(expr string, args []interface{}) {
+	*c = append(*c, condition{expr: expr, args: args})
+}
+
+// build writes the where clause, which is the conjunction of the conditions, and appends their args to args.
+func (c conditions) build(b *strings.Builder, args []interface{}) ([]interface{}, error) {
+	for i, cond := range c {
+		if i == 0 {
+			b.WriteString(" WHERE ")
+		} else {
+			b.WriteString(" AND ")
+		}
+
+		expr, condArgs, err := expand(cond.expr, cond.args)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(c) > 1 {
+			expr = "(" + expr + ")"
+		}
+
+		b.WriteString(expr)
+
+		args = append(args, condArgs...)
+	}
+
+	return args, nil
+}
+
+// expand replaces the bindvar of each slice arg, other than []byte, by one bindvar per element.
+func expand(expr string, args []interface{}) (string, []interface{}, error) {
+	var (
+		b        strings.Builder
+		expanded = make([]interface{}, 0, len(args))
+		n        int
+	)
+
+	for _, r := range expr {
+		if r != '?' || n >= len(args) {
+			b.WriteRune(r)
+			continue
+		}
+
+		arg := args[n]
+		n++
+
+		v := reflect.ValueOf(arg)
+		if v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+			b.WriteRune(r)
+
+			expanded = append(expanded, arg)
+
+			continue
+		}
+
+		if v.Len() == 0 {
+			return "", nil, errEmptyIn
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+
+			b.WriteRune('?')
+
+			expanded = append(expanded, v.Index(i).Interface())
+		}
+	}
+
+	return b.String(), append(expanded, args[n:]...), nil
+}
+
+func writeReturning(b *strings.Builder, dialect string, columns []string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	// mssql has OUTPUT instead of RETURNING, written before the VALUES or the WHERE of the query.
+	if dialect == dialectMysql || dialect == dialectMSSQL || dialect == dialectClickHouse {
+		return fmt.Errorf("%w: %s", errReturningNotAllowed, dialect)
+	}
+
+	b.WriteString(" RETURNING ")
+	b.WriteString(strings.Join(columns, ", "))
+
+	return nil
+}
+
+// SelectBuilder builds a SELECT query. Its identifiers are written as they are, and its values are bound.
+type SelectBuilder struct {
+	columns []string
+	table   string
+	joins   []string
+	where   conditions
+	groupBy []string
+	orderBy []string
+	limit   int
+	offset  int
+}
+
+// Select returns the builder of a query selecting the columns, or every column if there is none.
+//
+//	Usage:
+//	var users []User
+//
+//	err := sql.Select("id", "name").From("users").Where("status = ?", "active").OrderBy("name").Limit(10).
+//		Load(ctx, ctx.SQL, &users)
+func Select(columns ...string) *SelectBuilder {
+	return &SelectBuilder{columns: columns, limit: -1, offset: -1}
+}
+
+// From sets the table of the query.
+func (s *SelectBuilder) From(table string) *SelectBuilder {
+	s.table = table
+	return s
+}
+
+// Join adds the join clause to the query, like "JOIN orders o ON o.user_id = u.id".
+func (s *SelectBuilder) Join(clause string) *SelectBuilder {
+	s.joins = append(s.joins, clause)
+	return s
+}
+
+// Where adds the condition to the where clause, like Where("id IN (?)", ids).
+func (s *SelectBuilder) Where(condition string, args ...interface{}) *SelectBuilder {
+	s.where.add(condition, args)
+	return s
+}
+
+// GroupBy sets the columns by which the rows are grouped.
+func (s *SelectBuilder) GroupBy(columns ...string) *SelectBuilder {
+	s.groupBy = append(s.groupBy, columns...)
+	return s
+}
+
+// OrderBy sets the columns by which the rows are ordered, like OrderBy("created_at DESC", "id").
+func (s *SelectBuilder) OrderBy(columns ...string) *SelectBuilder {
+	s.orderBy = append(s.orderBy, columns...)
+	return s
+}
+
+// Limit sets the maximum number of rows selected.
+func (s *SelectBuilder) Limit(n int) *SelectBuilder {
+	s.limit = n
+	return s
+}
+
+// Offset sets the number of rows skipped.
+func (s *SelectBuilder) Offset(n int) *SelectBuilder {
+	s.offset = n
+	return s
+}
+
+// Build returns the query with the bindvars of the dialect, and its args.
+func (s *SelectBuilder) Build(dialect string) (query string, args []interface{}, err error) {
+	if s.table == "" {
+		return "", nil, errNoTable
+	}
+
+	var b strings.Builder
+
+	columns := "*"
+	if len(s.columns) > 0 {
+		columns = strings.Join(s.columns, ", ")
+	}
+
+	b.WriteString("SELECT " + columns + " FROM " + s.table)
+
+	for _, j := range s.joins {
+		b.WriteString(" " + j)
+	}
+
+	if args, err = s.where.build(&b, args); err != nil {
+		return "", nil, err
+	}
+
+	if len(s.groupBy) > 0 {
+		b.WriteString(" GROUP BY " + strings.Join(s.groupBy, ", "))
+	}
+
+	if len(s.orderBy) > 0 {
+		b.WriteString(" ORDER BY " + strings.Join(s.orderBy, ", "))
+	}
+
+	if err = s.writePaging(&b, dialect); err != nil {
+		return "", nil, err
+	}
+
+	return Rebind(dialect, b.String()), args, nil
+}
+
+// writePaging writes the limit and the offset of the query.
+func (s *SelectBuilder) writePaging(b *strings.Builder, dialect string) error {
+	if dialect != dialectMSSQL {
+		if s.limit >= 0 {
+			b.WriteString(" LIMIT " + strconv.Itoa(s.limit))
+		}
+
+		if s.offset >= 0 {
+			b.WriteString(" OFFSET " + strconv.Itoa(s.offset))
+		}
+
+		return nil
+	}
+
+	if s.limit < 0 && s.offset < 0 {
+		return nil
+	}
+
+	if len(s.orderBy) == 0 {
+		return fmt.Errorf("%w: %s", errPagingWithoutOrder, dialect)
+	}
+
+	b.WriteString(" OFFSET " + strconv.Itoa(max(s.offset, 0)) + " ROWS")
+
+	if s.limit >= 0 {
+		b.WriteString(" FETCH NEXT " + strconv.Itoa(s.limit) + " ROWS ONLY")
+	}
+
+	return nil
+}
+
+// Load runs the query on the datasource and loads its rows into data, like DB.Select.
+func (s *SelectBuilder) Load(ctx context.Context, db Querier, data interface{}) error {
+	scoped, err := s.scope(ctx, guardOf(db))
+	if err != nil {
+		return err
+	}
+
+	query, args, err := scoped.Build(db.Dialect())
+	if err != nil {
+		return err
+	}
+
+	db.Select(ctx, data, query, args...)
+
+	return nil
+}
+
+// InsertBuilder builds an INSERT query of one or more rows.
+type InsertBuilder struct {
+	table     string
+	columns   []string
+	rows      [][]interface{}
+	returning []string
+}
+
+// Insert returns the builder of a query inserting rows into the table.
+//
+//	Usage:
+//	row, err := sql.Insert("users").Columns("name", "email").Values("ann", "ann@example.com").Returning("id").
+//		QueryRow(ctx, ctx.SQL)
+func Insert(table string) *InsertBuilder {
+	return &InsertBuilder{table: table}
+}
+
+// Columns sets the columns of the inserted values.
+func (i *InsertBuilder) Columns(columns ...string) *InsertBuilder {
+	i.columns = append(i.columns, columns...)
+	return i
+}
+
+// Values adds a row of values, one per column.
+func (i *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
+	i.rows = append(i.rows, values)
+	return i
+}
+
+// Returning sets the columns of the inserted rows returned by the query, which MySQL does not support.
+func (i *InsertBuilder) Returning(columns ...string) *InsertBuilder {
+	i.returning = append(i.returning, columns...)
+	return i
+}
+
+// Build returns the query with the bindvars of the dialect, and its args.
+func (i *InsertBuilder) Build(dialect string) (query string, args []interface{}, err error) {
+	if i.table == "" {
+		return "", nil, errNoTable
+	}
+
+	if len(i.columns) == 0 || len(i.rows) == 0 {
+		return "", nil, errNoValues
+	}
+
+	var b strings.Builder
+
+	b.WriteString("INSERT INTO " + i.table + " (" + strings.Join(i.columns, ", ") + ") VALUES ")
+
+	bindVars := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(i.columns)), ", ") + ")"
+
+	for n, row := range i.rows {
+		if len(row) != len(i.columns) {
+			return "", nil, fmt.Errorf("%w: row %d has %d values for %d columns", errValuesCount, n, len(row),
+				len(i.columns))
+		}
+
+		if n > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString(bindVars)
+
+		args = append(args, row...)
+	}
+
+	if err = writeReturning(&b, dialect, i.returning); err != nil {
+		return "", nil, err
+	}
+
+	return Rebind(dialect, b.String()), args, nil
+}
+
+// Exec runs the query on the datasource.
+func (i *InsertBuilder) Exec(ctx context.Context, db Querier) (sql.Result, error) {
+	scoped, err := i.scope(ctx, guardOf(db))
+	if err != nil {
+		return nil, err
+	}
+
+	return exec(ctx, db, scoped.Build)
+}
+
+// QueryRow runs the query on the datasource, and returns the row of its returned columns.
+func (i *InsertBuilder) QueryRow(ctx context.Context, db Querier) (*sql.Row, error) {
+	scoped, err := i.scope(ctx, guardOf(db))
+	if err != nil {
+		return nil, err
+	}
+
+	return queryRow(ctx, db, scoped.Build)
+}
+
+// assignment is a column set by an UPDATE query.
+type assignment struct {
+	column string
+	value  interface{}
+}
+
+// UpdateBuilder builds an UPDATE query, which must have a where clause.
+type UpdateBuilder struct {
+	table     string
+	set       []assignment
+	where     conditions
+	returning []string
+}
+
+// Update returns the builder of a query updating the rows of the table.
+//
+//	Usage:
+//	_, err := sql.Update("users").Set("name", "ann").Where("id = ?", id).Exec(ctx, ctx.SQL)
+func Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{table: table}
+}
+
+// Set sets the column to the value, in the order in which the columns are set.
+func (u *UpdateBuilder) Set(column string, value interface{}) *UpdateBuilder {
+	u.set = append(u.set, assignment{column: column, value: value})
+	return u
+}
+
+// Where adds the condition to the where clause, as SelectBuilder.Where does.
+func (u *UpdateBuilder) Where(condition string, args ...interface{}) *UpdateBuilder {
+	u.where.add(condition, args)
+	return u
+}
+
+// Returning sets the columns of the updated rows returned by the query, which MySQL does not support.
+func (u *UpdateBuilder) Returning(columns ...string) *UpdateBuilder {
+	u.returning = append(u.returning, columns...)
+	return u
+}
+
+// Build returns the query with the bindvars of the dialect, and its args.
+func (u *UpdateBuilder) Build(dialect string) (query string, args []interface{}, err error) {
+	switch {
+	case u.table == "":
+		return "", nil, errNoTable
+	case len(u.set) == 0:
+		return "", nil, errNoValues
+	case len(u.where) == 0:
+		return "", nil, errNoWhere
+	}
+
+	var b strings.Builder
+
+	b.WriteString("UPDATE " + u.table + " SET ")
+
+	for n, a := range u.set {
+		if n > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString(a.column + " = ?")
+
+		args = append(args, a.value)
+	}
+
+	if args, err = u.where.build(&b, args); err != nil {
+		return "", nil, err
+	}
+
+	if err = writeReturning(&b, dialect, u.returning); err != nil {
+		return "", nil, err
+	}
+
+	return Rebind(dialect, b.String()), args, nil
+}
+
+// Exec runs the query on the datasource.
+func (u *UpdateBuilder) Exec(ctx context.Context, db Querier) (sql.Result, error) {
+	scoped, err := u.scope(ctx, guardOf(db))
+	if err != nil {
+		return nil, err
+	}
+
+	return exec(ctx, db, scoped.Build)
+}
+
+// QueryRow runs the query on the datasource, and returns the row of its returned columns.
+func (u *UpdateBuilder) QueryRow(ctx context.Context, db Querier) (*sql.Row, error) {
+	scoped, err := u.scope(ctx, guardOf(db))
+	if err != nil {
+		return nil, err
+	}
+
+	return queryRow(ctx, db, scoped.Build)
+}
+
+// DeleteBuilder builds a DELETE query, which must have a where clause.
+type DeleteBuilder struct {
+	table     string
+	where     conditions
+	returning []string
+}
+
+// Delete returns the builder of a query deleting the rows of the table.
+//
+//	Usage:
+//	_, err := sql.Delete("sessions").Where("expires_at < ?", time.Now()).Exec(ctx, ctx.SQL)
+func Delete(table string) *DeleteBuilder {
+	return &DeleteBuilder{table: table}
+}
+
+// Where adds the condition to the where clause, as SelectBuilder.Where does.
+func (d *DeleteBuilder) Where(condition string, args ...interface{}) *DeleteBuilder {
+	d.where.add(condition, args)
+	return d
+}
+
+// Returning sets the columns of the deleted rows returned by the query, which MySQL does not support.
+func (d *DeleteBuilder) Returning(columns ...string) *DeleteBuilder {
+	d.returning = append(d.returning, columns...)
+	return d
+}
+
+// Build returns the query with the bindvars of the dialect, and its args.
+func (d *DeleteBuilder) Build(dialect string) (query string, args []interface{}, err error) {
+	switch {
+	case d.table == "":
+		return "", nil, errNoTable
+	case len(d.where) == 0:
+		return "", nil, errNoWhere
+	}
+
+	var b strings.Builder
+
+	b.WriteString("DELETE FROM " + d.table)
+
+	if args, err = d.where.build(&b, args); err != nil {
+		return "", nil, err
+	}
+
+	if err = writeReturning(&b, dialect, d.returning); err != nil {
+		return "", nil, err
+	}
+
+	return Rebind(dialect, b.String()), args, nil
+}
+
+// Exec runs the query on the datasource.
+func (d *DeleteBuilder) Exec(ctx context.Context, db Querier) (sql.Result, error) {
+	scoped, err := d.scope(ctx, guardOf(db))
+	if err != nil {
+		return nil, err
+	}
+
+	return exec(ctx, db, scoped.Build)
+}
+
+// QueryRow runs the query on the datasource, and returns the row of its returned columns.
+func (d *DeleteBuilder) QueryRow(ctx context.Context, db Querier) (*sql.Row, error) {
+	scoped, err := d.scope(ctx, guardOf(db))
+	if err != nil {
+		return nil, err
+	}
+
+	return queryRow(ctx, db, scoped.Build)
+}
+
+type buildFunc func(dialect string) (string, []interface{}, error)
+
+func exec(ctx context.Context, db Querier, build buildFunc) (sql.Result, error) {
+	query, args, err := build(db.Dialect())
+	if err != nil {
+		return nil, err
+	}
+
+	return db.ExecContext(ctx, query, args...)
+}
+
+func queryRow(ctx context.Context, db Querier, build buildFunc) (*sql.Row, error) {
+	query, args, err := build(db.Dialect())
+	if err != nil {
+		return nil, err
+	}
+
+	return db.QueryRowContext(ctx, query, args...), nil
+}
@@ -0,0 +1,142 @@
+package sql
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+type builder interface {
+	Build(dialect string) (string, []interface{}, error)
+}
+
+func TestBuilders_Build(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		builder builder
+		dialect string
+		query   string
+		args    []interface{}
+		err     error
+	}{
+		{"select every column", Select().From("users"), dialectMysql, "SELECT * FROM users", nil, nil},
+		{"select with clauses",
+			Select("u.id", "COUNT(o.id)").From("users u").Join("JOIN orders o ON o.user_id = u.id").
+				Where("u.status = ? OR u.owner = ?", "active", "me").Where("u.id IN (?)", []int{1, 2, 3}).
+				GroupBy("u.id").OrderBy("u.id DESC").Limit(10).Offset(20), dialectPostgres,
+			"SELECT u.id, COUNT(o.id) FROM users u JOIN orders o ON o.user_id = u.id WHERE (u.status = $1 OR u.owner = $2) " +
+				"AND (u.id IN ($3, $4, $5)) GROUP BY u.id ORDER BY u.id DESC LIMIT 10 OFFSET 20",
+			[]interface{}{"active", "me", 1, 2, 3}, nil},
+		{"mssql paging", Select("id").From("users").Where("status = ?", "active").OrderBy("id").Limit(10).Offset(20),
+			dialectMSSQL, "SELECT id FROM users WHERE status = @p1 ORDER BY id OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+			[]interface{}{"active"}, nil},
+		{"mssql limit", Select("id").From("users").OrderBy("id").Limit(10), dialectMSSQL,
+			"SELECT id FROM users ORDER BY id OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY", nil, nil},
+		{"mssql offset", Select("id").From("users").OrderBy("id").Offset(20), dialectMSSQL,
+			"SELECT id FROM users ORDER BY id OFFSET 20 ROWS", nil, nil},
+		{"mssql paging without order", Select("id").From("users").Limit(10), dialectMSSQL, "", nil, errPagingWithoutOrder},
+		{"bytes are not expanded", Select("id").From("files").Where("hash = ?", []byte("abc")), dialectMysql,
+			"SELECT id FROM files WHERE hash = ?", []interface{}{[]byte("abc")}, nil},
+		{"empty IN", Select().From("users").Where("id IN (?)", []int{}), dialectMysql, "", nil, errEmptyIn},
+		{"select without table", Select("id"), dialectMysql, "", nil, errNoTable},
+		{"insert rows", Insert("users").Columns("name", "age").Values("ann", 30).Values("bob", 40).Returning("id"),
+			dialectPostgres, "INSERT INTO users (name, age) VALUES ($1, $2), ($3, $4) RETURNING id",
+			[]interface{}{"ann", 30, "bob", 40}, nil},
+		{"insert without values", Insert("users").Columns("name"), dialectMysql, "", nil, errNoValues},
+		{"insert with missing values", Insert("users").Columns("name", "age").Values("ann"), dialectMysql, "", nil,
+			errValuesCount},
+		{"returning on mysql", Insert("users").Columns("name").Values("ann").Returning("id"), dialectMysql, "", nil,
+			errReturningNotAllowed},
+		{"update", Update("users").Set("name", "ann").Set("age", 31).Where("id = ?", 1).Returning("updated_at"),
+			dialectPostgres, "UPDATE users SET name = $1, age = $2 WHERE id = $3 RETURNING updated_at",
+			[]interface{}{"ann", 31, 1}, nil},
+		{"update without where", Update("users").Set("name", "ann"), dialectMysql, "", nil, errNoWhere},
+		{"update without values", Update("users").Where("id = ?", 1), dialectMysql, "", nil, errNoValues},
+		{"delete", Delete("sessions").Where("user_id = ?", 7).Where("expired"), "sqlite",
+			"DELETE FROM sessions WHERE (user_id = ?) AND (expired)", []interface{}{7}, nil},
+		{"delete without where", Delete("sessions"), dialectMysql, "", nil, errNoWhere},
+		{"delete without table", Delete("").Where("id = ?", 1), dialectMysql, "", nil, errNoTable},
+	}
+
+	for i, tc := range testCases {
+		query, args, err := tc.builder.Build(tc.dialect)
+
+		assert.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.query, query, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.args, args, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+// newSQLite returns a DB of a SQLite file, which is closed once the test ends.
+func newSQLite(t *testing.T, opts ...Option) *DB {
+	t.Helper()
+
+	mockMetrics := NewMockMetrics(gomock.NewController(t))
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().SetGauge(gomock.Any(), gomock.Any()).AnyTimes()
+
+	db := NewSQL(config.NewMockConfig(map[string]string{
+		"DB_DIALECT": "sqlite",
+		"DB_NAME":    filepath.Join(t.TempDir(), "app.db"),
+	}), logging.NewMockLogger(logging.ERROR), mockMetrics, opts...)
+	require.NotNil(t, db)
+
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func TestBuilders_Run(t *testing.T) {
+	db := newSQLite(t)
+	ctx := context.Background()
+
+	_, err := db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT, age INTEGER)")
+	require.NoError(t, err)
+
+	_, err = Insert("users").Columns("name", "age").Values("ann", 30).Values("bob", 40).Exec(ctx, db)
+	require.NoError(t, err)
+
+	row, err := Insert("users").Columns("name", "age").Values("cid", 50).Returning("id").QueryRow(ctx, db)
+	require.NoError(t, err)
+
+	var id int
+
+	require.NoError(t, row.Scan(&id))
+	assert.Equal(t, 3, id)
+
+	_, err = Update("users").Set("age", 41).Where("name = ?", "bob").Exec(ctx, db)
+	require.NoError(t, err)
+
+	row, err = Delete("users").Where("name IN (?)", []string{"ann"}).Returning("age").QueryRow(ctx, db)
+	require.NoError(t, err)
+
+	var age int
+
+	require.NoError(t, row.Scan(&age))
+	assert.Equal(t, 30, age)
+
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	var users []user
+
+	require.NoError(t, Select("name", "age").From("users").Where("age > ?", 40).OrderBy("age").Load(ctx, db, &users))
+	assert.Equal(t, []user{{"bob", 41}, {"cid", 50}}, users)
+
+	require.ErrorIs(t, Select().Load(ctx, db, &users), errNoTable)
+
+	_, err = Update("users").Exec(ctx, db)
+	require.ErrorIs(t, err, errNoValues)
+
+	_, err = Delete("users").QueryRow(ctx, db)
+	require.ErrorIs(t, err, errNoWhere)
+}
@@ -6,6 +6,7 @@ package sql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"io"
 	"reflect"
@@ -13,6 +14,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/bulkhead"
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+	"github.com/peter-stratton/gofr/pkg/gofr/crypto"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
 )
 
@@ -23,6 +28,17 @@ type DB struct {
 	logger  datasource.Logger
 	config  *DBConfig
 	metrics Metrics
+	clock   clock.Clock
+	// driver is the name of the driver of the connections, which is registered for the traces.
+	driver string
+	// bulkhead limits the concurrent queries, unless it is nil.
+	bulkhead *bulkhead.Bulkhead
+	// faults injects faults into the queries, unless it is nil.
+	faults *chaos.Injector
+	// guard rejects the queries crossing the tenants, unless it is nil.
+	guard *tenantGuard
+	// keyring encrypts and decrypts the fields tagged with encrypt:"aes-gcm", if it is set by WithKeyring.
+	keyring *crypto.Keyring
 }
 
 type Log struct {
@@ -65,13 +81,49 @@ func getOperationType(query string) string {
 	return words[0]
 }
 
+// acquire checks the query with the tenant guard, takes a slot of the bulkhead and injects the faults.
+func (d *DB) acquire(ctx context.Context, query string, args []interface{}) (release func(), err error) {
+	if err = d.guard.check(ctx, query, args); err != nil {
+		return nil, err
+	}
+
+	release, err = d.bulkhead.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = d.faults.Inject(ctx, chaos.TargetSQL, query); err != nil {
+		release()
+
+		return nil, err
+	}
+
+	return release, nil
+}
+
 func (d *DB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	release, err := d.acquire(context.Background(), query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
 	defer d.logQuery(time.Now(), "Query", query, args...)
+
 	return d.DB.Query(query, args...)
 }
 
 func (d *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	release, err := d.acquire(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
 	defer d.logQuery(time.Now(), "QueryContext", query, args...)
+
 	return d.DB.QueryContext(ctx, query, args...)
 }
 
@@ -79,38 +131,142 @@ func (d *DB) Dialect() string {
 	return d.config.Dialect
 }
 
+// Unwrap returns the pool of connections of d, for the libraries which take a *sql.DB, like sqlc. The pool is closed
+// on shutdown, and its queries are not logged, limited or faulted like those of d. It returns nil if d is nil.
+func (d *DB) Unwrap() *sql.DB {
+	if d == nil {
+		return nil
+	}
+
+	return d.DB
+}
+
 func (d *DB) QueryRow(query string, args ...interface{}) *sql.Row {
-	defer d.logQuery(time.Now(), "QueryRow", query, args...)
-	return d.DB.QueryRow(query, args...)
+	return d.queryRow(context.Background(), "QueryRow", query, args...)
 }
 
 func (d *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
-	defer d.logQuery(time.Now(), "QueryRowContext", query, args...)
+	return d.queryRow(ctx, "QueryRowContext", query, args...)
+}
+
+// queryRow queries a single row, whose error is the one of acquire if the query is rejected.
+func (d *DB) queryRow(ctx context.Context, queryType, query string, args ...interface{}) *sql.Row {
+	release, err := d.acquire(ctx, query, args)
+	if err != nil {
+		d.logger.Errorf("%v", err)
+
+		return errRow(err)
+	}
+
+	defer release()
+
+	defer d.logQuery(time.Now(), queryType, query, args...)
+
 	return d.DB.QueryRowContext(ctx, query, args...)
 }
 
+// errRow returns a row whose error is err, as the rows of database/sql are only built by its queries.
+func errRow(err error) *sql.Row {
+	db := sql.OpenDB(errConnector{err: err})
+	defer db.Close()
+
+	return db.QueryRow("")
+}
+
+// errConnector fails to connect with its error.
+type errConnector struct {
+	err error
+}
+
+func (c errConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, c.err
+}
+
+func (c errConnector) Driver() driver.Driver {
+	return c
+}
+
+func (c errConnector) Open(string) (driver.Conn, error) {
+	return nil, c.err
+}
+
 func (d *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	release, err := d.acquire(context.Background(), query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
 	defer d.logQuery(time.Now(), "Exec", query, args...)
+
 	return d.DB.Exec(query, args...)
 }
 
 func (d *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	release, err := d.acquire(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
 	defer d.logQuery(time.Now(), "ExecContext", query, args...)
+
 	return d.DB.ExecContext(ctx, query, args...)
 }
 
 func (d *DB) Prepare(query string) (*sql.Stmt, error) {
+	if err := d.guard.checkPrepared(context.Background(), query); err != nil {
+		return nil, err
+	}
+
 	defer d.logQuery(time.Now(), "Prepare", query)
+
 	return d.DB.Prepare(query)
 }
 
+func (d *DB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	if err := d.guard.checkPrepared(ctx, query); err != nil {
+		return nil, err
+	}
+
+	defer d.logQuery(time.Now(), "PrepareContext", query)
+
+	return d.DB.PrepareContext(ctx, query)
+}
+
 func (d *DB) Begin() (*Tx, error) {
 	tx, err := d.DB.Begin()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Tx{Tx: tx, config: d.config, logger: d.logger, metrics: d.metrics}, nil
+	return &Tx{Tx: tx, config: d.config, logger: d.logger, metrics: d.metrics, guard: d.guard,
+		ctx: context.Background()}, nil
+}
+
+// BeginTx starts a transaction, which is rolled back if ctx is done before it is committed.
+func (d *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := d.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Tx{Tx: tx, config: d.config, logger: d.logger, metrics: d.metrics, guard: d.guard, ctx: ctx}
+
+	// the queries of a transaction begun with a context bypassing the guard are not guarded.
+	if _, guarded := d.guard.tenant(ctx); !guarded {
+		t.guard = nil
+	}
+
+	if err := d.guard.setTenant(ctx, t); err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	return t, nil
 }
 
 type Tx struct {
@@ -118,6 +274,10 @@ type Tx struct {
 	config  *DBConfig
 	logger  datasource.Logger
 	metrics Metrics
+	// guard rejects the queries crossing the tenants, unless it is nil.
+	guard *tenantGuard
+	// ctx is the context the transaction was begun with, whose tenant is the one of the queries without a context.
+	ctx context.Context
 }
 
 func (t *Tx) logQuery(start time.Time, queryType, query string, args ...interface{}) {
@@ -135,35 +295,86 @@ func (t *Tx) logQuery(start time.Time, queryType, query string, args ...interfac
 }
 
 func (t *Tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	if err := t.guard.check(t.ctx, query, args); err != nil {
+		return nil, err
+	}
+
 	defer t.logQuery(time.Now(), "TxQuery", query, args...)
+
 	return t.Tx.Query(query, args...)
 }
 
+func (t *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if err := t.guard.check(ctx, query, args); err != nil {
+		return nil, err
+	}
+
+	defer t.logQuery(time.Now(), "QueryContext", query, args...)
+
+	return t.Tx.QueryContext(ctx, query, args...)
+}
+
 func (t *Tx) QueryRow(query string, args ...interface{}) *sql.Row {
-	defer t.logQuery(time.Now(), "TxQueryRow", query, args...)
-	return t.Tx.QueryRow(query, args...)
+	return t.queryRow(t.ctx, "TxQueryRow", query, args...)
 }
 
 func (t *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
-	defer t.logQuery(time.Now(), "TxQueryRowContext", query, args...)
+	return t.queryRow(ctx, "TxQueryRowContext", query, args...)
+}
+
+// queryRow queries a single row, whose error is the one of the tenant guard if the query is rejected.
+func (t *Tx) queryRow(ctx context.Context, queryType, query string, args ...interface{}) *sql.Row {
+	if err := t.guard.check(ctx, query, args); err != nil {
+		t.logger.Errorf("%v", err)
+
+		return errRow(err)
+	}
+
+	defer t.logQuery(time.Now(), queryType, query, args...)
+
 	return t.Tx.QueryRowContext(ctx, query, args...)
 }
 
 func (t *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if err := t.guard.check(t.ctx, query, args); err != nil {
+		return nil, err
+	}
+
 	defer t.logQuery(time.Now(), "TxExec", query, args...)
+
 	return t.Tx.Exec(query, args...)
 }
 
 func (t *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if err := t.guard.check(ctx, query, args); err != nil {
+		return nil, err
+	}
+
 	defer t.logQuery(time.Now(), "TxExecContext", query, args...)
+
 	return t.Tx.ExecContext(ctx, query, args...)
 }
 
 func (t *Tx) Prepare(query string) (*sql.Stmt, error) {
+	if err := t.guard.checkPrepared(t.ctx, query); err != nil {
+		return nil, err
+	}
+
 	defer t.logQuery(time.Now(), "TxPrepare", query)
+
 	return t.Tx.Prepare(query)
 }
 
+func (t *Tx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	if err := t.guard.checkPrepared(ctx, query); err != nil {
+		return nil, err
+	}
+
+	defer t.logQuery(time.Now(), "TxPrepareContext", query)
+
+	return t.Tx.PrepareContext(ctx, query)
+}
+
 func (t *Tx) Commit() error {
 	defer t.logQuery(time.Now(), "TxCommit", "COMMIT")
 	return t.Tx.Commit()
@@ -246,12 +457,16 @@ func (d *DB) Select(ctx context.Context, data interface{}, query string, args ..
 			rvo.Elem().Set(rv)
 		}
 
+		d.decryptFields(data)
+
 	case reflect.Struct:
 		rows, _ := d.QueryContext(ctx, query, args...)
 		for rows.Next() {
 			d.rowsToStruct(rows, rv)
 		}
 
+		d.decryptFields(data)
+
 	default:
 		d.logger.Debugf("a pointer to %v was not expected.", rv.Kind().String())
 	}
@@ -9,8 +9,11 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/bulkhead"
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
 	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
 )
@@ -27,7 +30,7 @@ func getDB(t *testing.T, logLevel logging.Level) (*DB, sqlmock.Sqlmock) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	db := &DB{mockDB, logging.NewMockLogger(logLevel), nil, nil}
+	db := &DB{mockDB, logging.NewMockLogger(logLevel), nil, nil, nil, "", nil, nil, nil, nil}
 	db.config = &DBConfig{}
 
 	return db, mock
@@ -701,6 +704,48 @@ func TestDB_BeginError(t *testing.T) {
 	assert.Equal(t, errTx, err)
 }
 
+func TestDB_BeginTx(t *testing.T) {
+	db, mock := getDB(t, logging.INFO)
+	defer db.DB.Close()
+
+	mock.ExpectBegin()
+
+	tx, err := db.BeginTx(context.Background(), &sql.TxOptions{ReadOnly: true})
+	require.NoError(t, err)
+	assert.NotNil(t, tx.Tx)
+
+	mock.ExpectBegin().WillReturnError(errTx)
+
+	tx, err = db.BeginTx(context.Background(), nil)
+	assert.Nil(t, tx)
+	assert.Equal(t, errTx, err)
+}
+
+func TestTx_QueryContext(t *testing.T) {
+	out := testutil.StdoutOutputForFunc(func() {
+		db, mock := getDB(t, logging.DEBUG)
+		ctrl := gomock.NewController(t)
+		mockMetrics := NewMockMetrics(ctrl)
+
+		db.metrics = mockMetrics
+
+		tx := getTransaction(db, mock)
+
+		defer db.DB.Close()
+
+		mock.ExpectQuery("SELECT 1").
+			WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow("1"))
+		mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats",
+			gomock.Any(), "hostname", gomock.Any(), "database", gomock.Any(), "type", "SELECT")
+
+		rows, err := tx.QueryContext(context.Background(), "SELECT 1")
+		require.NoError(t, err)
+		assert.NoError(t, rows.Err())
+	})
+
+	assert.Contains(t, out, "QueryContext SELECT 1")
+}
+
 func getTransaction(db *DB, mock sqlmock.Sqlmock) *Tx {
 	mock.ExpectBegin()
 
@@ -1129,3 +1174,72 @@ func TestClean(t *testing.T) {
 
 	assert.Equal(t, "", out)
 }
+
+func TestDB_Bulkhead(t *testing.T) {
+	db, mock := getDB(t, logging.INFO)
+	defer db.DB.Close()
+
+	db.bulkhead = bulkhead.New("sql", bulkhead.Config{MaxConcurrent: 1}, nil)
+
+	release, err := db.bulkhead.Acquire(context.Background())
+	require.NoError(t, err)
+
+	_, err = db.Query("SELECT 1")
+	require.ErrorIs(t, err, bulkhead.ErrFull)
+
+	_, err = db.ExecContext(context.Background(), "DELETE FROM users")
+	require.ErrorIs(t, err, bulkhead.ErrFull)
+
+	var id int
+
+	err = db.QueryRow("SELECT id FROM users").Scan(&id)
+	require.ErrorIs(t, err, bulkhead.ErrFull)
+
+	release()
+
+	ctrl := gomock.NewController(t)
+	mockMetrics := NewMockMetrics(ctrl)
+	db.metrics = mockMetrics
+
+	mock.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", gomock.Any(), "hostname", gomock.Any(),
+		"database", gomock.Any(), "type", "DELETE")
+
+	_, err = db.Exec("DELETE FROM users")
+	require.NoError(t, err)
+	assert.Zero(t, db.bulkhead.InFlight())
+}
+
+func TestDB_FaultInjector(t *testing.T) {
+	db, mock := getDB(t, logging.INFO)
+	defer db.DB.Close()
+
+	db.bulkhead = bulkhead.New("sql", bulkhead.Config{MaxConcurrent: 1}, nil)
+
+	faults, err := chaos.New(nil, chaos.Fault{Target: chaos.TargetSQL, Operation: "^SELECT", Kind: chaos.KindError, Rate: 1})
+	require.NoError(t, err)
+
+	db.faults = faults
+
+	_, err = db.QueryContext(context.Background(), "SELECT id FROM users")
+	require.Equal(t, &chaos.Error{Target: chaos.TargetSQL, Operation: "SELECT id FROM users"}, err)
+
+	var id int
+
+	err = db.QueryRow("SELECT id FROM users").Scan(&id)
+	require.Equal(t, &chaos.Error{Target: chaos.TargetSQL, Operation: "SELECT id FROM users"}, err)
+
+	// the slots of the faulted queries are released.
+	assert.Zero(t, db.bulkhead.InFlight())
+
+	ctrl := gomock.NewController(t)
+	mockMetrics := NewMockMetrics(ctrl)
+	db.metrics = mockMetrics
+
+	mock.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", gomock.Any(), "hostname", gomock.Any(),
+		"database", gomock.Any(), "type", "DELETE")
+
+	_, err = db.Exec("DELETE FROM users")
+	require.NoError(t, err)
+}
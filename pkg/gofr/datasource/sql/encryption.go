@@ -0,0 +1,170 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/crypto"
+)
+
+var (
+	errMissingNamedArg = errors.New("the named parameter has no value")
+	errNamedArg        = errors.New("the named parameters must be bound from a struct or a map[string]interface{}")
+)
+
+// WithKeyring encrypts the fields tagged with encrypt:"aes-gcm" with the keyring.
+func WithKeyring(k *crypto.Keyring) Option {
+	return func(d *DB) {
+		d.keyring = k
+	}
+}
+
+// UseKeyring sets the keyring of the encrypted fields. It must be set before the DB is used.
+func (d *DB) UseKeyring(k *crypto.Keyring) {
+	if d == nil {
+		return
+	}
+
+	d.keyring = k
+}
+
+// NamedExec executes the query with the named parameters, like ":email", bound from arg, encrypting its encrypted fields.
+//
+//	Usage:
+//	type Customer struct {
+//		ID    int
+//		Email string `encrypt:"aes-gcm"`
+//	}
+//
+//	_, err := db.NamedExec(ctx, "INSERT INTO customers (id, email) VALUES (:id, :email)", customer)
+func (d *DB) NamedExec(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	values, err := namedValues(arg, d.keyring)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := bindNamed(d.Dialect(), query, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.ExecContext(ctx, query, args...)
+}
+
+// decryptFields decrypts the encrypted fields of the structs selected into data, logging the errors.
+func (d *DB) decryptFields(data interface{}) {
+	rv := reflect.ValueOf(data).Elem()
+	if rv.Kind() == reflect.Slice {
+		rv = reflect.New(rv.Type().Elem()).Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+
+	if err := d.keyring.DecryptFields(data); err != nil {
+		d.logger.Errorf("could not decrypt the selected rows: %v", err)
+	}
+}
+
+// namedValues returns the values of the named parameters bound from arg.
+func namedValues(arg interface{}, keyring *crypto.Keyring) (map[string]interface{}, error) {
+	if m, ok := arg.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(arg))
+	if rv.Kind() != reflect.Struct {
+		return nil, errNamedArg
+	}
+
+	values := make(map[string]interface{}, rv.NumField())
+
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Tag.Get("db")
+		if name == "" {
+			name = ToSnakeCase(field.Name)
+		}
+
+		encrypted, err := crypto.Encrypted(field)
+		if err != nil {
+			return nil, err
+		}
+
+		if !encrypted {
+			values[name] = rv.Field(i).Interface()
+			continue
+		}
+
+		if values[name], err = keyring.EncryptValue(rv.Field(i), crypto.AdditionalData(rv.Type(), field)); err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	return values, nil
+}
+
+// bindNamed replaces the named parameters of the query by the bindvars of the dialect, and returns their values.
+func bindNamed(dialect, query string, values map[string]interface{}) (string, []interface{}, error) {
+	var (
+		b     strings.Builder
+		args  []interface{}
+		quote rune
+	)
+
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == ':' && i+1 < len(runes) && runes[i+1] == ':':
+			b.WriteString("::")
+			i++
+
+			continue
+		case r == ':' && i+1 < len(runes) && isNameRune(runes[i+1]):
+			j := i + 1
+			for j < len(runes) && isNameRune(runes[j]) {
+				j++
+			}
+
+			name := string(runes[i+1 : j])
+
+			value, ok := values[name]
+			if !ok {
+				return "", nil, fmt.Errorf("%w: %s", errMissingNamedArg, name)
+			}
+
+			args = append(args, value)
+			b.WriteString(bindVar(dialect, len(args)))
+
+			i = j - 1
+
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String(), args, nil
+}
+
+func isNameRune(r rune) bool {
+	return r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
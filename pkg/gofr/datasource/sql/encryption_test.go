@@ -0,0 +1,187 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/crypto"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+type customer struct {
+	ID    int
+	Email string  `encrypt:"aes-gcm"`
+	Phone *string `db:"mobile" encrypt:"aes-gcm"`
+}
+
+func testKeyring(t *testing.T) *crypto.Keyring {
+	t.Helper()
+
+	keyring, err := crypto.NewKeyring(crypto.Key{Version: 1, Secret: []byte(strings.Repeat("k", 32))})
+	require.NoError(t, err)
+
+	return keyring
+}
+
+// encryptedArg matches the values encrypted by the keyring, which differ on each encryption.
+type encryptedArg struct {
+	keyring   *crypto.Keyring
+	plaintext string
+	column    string
+}
+
+func (e encryptedArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	if !ok || !strings.HasPrefix(s, "enc:v1:") {
+		return false
+	}
+
+	plaintext, err := e.keyring.Open(s, []byte("customer."+e.column))
+
+	return err == nil && string(plaintext) == e.plaintext
+}
+
+func TestDB_NamedExec(t *testing.T) {
+	db, mock := getDB(t, logging.INFO)
+	defer db.DB.Close()
+
+	keyring := testKeyring(t)
+	db.UseKeyring(keyring)
+
+	ctrl := gomock.NewController(t)
+	mockMetrics := NewMockMetrics(ctrl)
+	db.metrics = mockMetrics
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats",
+		gomock.Any(), "hostname", gomock.Any(), "database", gomock.Any(), "type", gomock.Any())
+
+	phone := "+15550100"
+	c := customer{ID: 1, Email: "jane@example.com", Phone: &phone}
+
+	mock.ExpectExec("INSERT INTO customers (id, email, mobile, note) VALUES (?, ?, ?, 'a:b')").
+		WithArgs(1, encryptedArg{keyring, "jane@example.com", "email"}, encryptedArg{keyring, "+15550100", "mobile"}).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	_, err := db.NamedExec(context.Background(),
+		"INSERT INTO customers (id, email, mobile, note) VALUES (:id, :email, :mobile, 'a:b')", &c)
+
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", c.Email, "the argument must not be encrypted in place")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDB_NamedExecErrors(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		keyring bool
+		query   string
+		arg     interface{}
+		err     error
+	}{
+		{desc: "no keyring", query: "INSERT INTO customers (email) VALUES (:email)", arg: customer{},
+			err: crypto.ErrNoKeyring},
+		{desc: "missing parameter", keyring: true, query: "UPDATE customers SET email = :email WHERE id = :customer_id",
+			arg: customer{}, err: errMissingNamedArg},
+		{desc: "not a struct", keyring: true, query: "DELETE FROM customers WHERE id = :id", arg: 1, err: errNamedArg},
+	}
+
+	for i, tc := range testCases {
+		db, _ := getDB(t, logging.INFO)
+
+		if tc.keyring {
+			db.UseKeyring(testKeyring(t))
+		}
+
+		_, err := db.NamedExec(context.Background(), tc.query, tc.arg)
+
+		require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		db.DB.Close()
+	}
+}
+
+func Test_bindNamed(t *testing.T) {
+	values := map[string]interface{}{"id": 1, "name": "jane"}
+
+	testCases := []struct {
+		dialect string
+		query   string
+		bound   string
+		args    []interface{}
+	}{
+		{"mysql", "SELECT * FROM users WHERE id = :id AND name = :name", "SELECT * FROM users WHERE id = ? AND name = ?",
+			[]interface{}{1, "jane"}},
+		{"postgres", "SELECT id::text FROM users WHERE name = :name OR id = :id", "SELECT id::text FROM users WHERE name = $1 OR id = $2",
+			[]interface{}{"jane", 1}},
+		{"postgres", "SELECT ':id' FROM users WHERE id = :id", "SELECT ':id' FROM users WHERE id = $1", []interface{}{1}},
+	}
+
+	for i, tc := range testCases {
+		bound, args, err := bindNamed(tc.dialect, tc.query, values)
+
+		require.NoError(t, err, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, tc.bound, bound, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, tc.args, args, "TEST[%d], Failed.\n", i)
+	}
+}
+
+func TestDB_SelectDecryptsFields(t *testing.T) {
+	db, mock := getDB(t, logging.INFO)
+	defer db.DB.Close()
+
+	keyring := testKeyring(t)
+	db.UseKeyring(keyring)
+
+	email, err := keyring.Seal([]byte("jane@example.com"), []byte("customer.email"))
+	require.NoError(t, err)
+
+	// the email is moved to the row of another customer, in the column of the phone.
+	moved := email
+
+	mock.ExpectQuery("SELECT id, email, mobile FROM customers").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "mobile"}).
+			AddRow(1, email, nil).
+			AddRow(2, "john@example.com", nil).
+			AddRow(3, "", moved))
+
+	ctrl := gomock.NewController(t)
+	mockMetrics := NewMockMetrics(ctrl)
+	db.metrics = mockMetrics
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats",
+		gomock.Any(), "hostname", gomock.Any(), "database", gomock.Any(), "type", gomock.Any())
+
+	var customers []customer
+
+	db.Select(context.Background(), &customers, "SELECT id, email, mobile FROM customers")
+
+	assert.Equal(t, []customer{{ID: 1, Email: "jane@example.com"}, {ID: 2, Email: "john@example.com"}, {ID: 3}}, customers)
+}
+
+func TestDB_SelectWithoutKeyring(t *testing.T) {
+	db, mock := getDB(t, logging.INFO)
+	defer db.DB.Close()
+
+	email, err := testKeyring(t).Encrypt([]byte("jane@example.com"))
+	require.NoError(t, err)
+
+	mock.ExpectQuery("SELECT id, email FROM customers").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, email))
+
+	ctrl := gomock.NewController(t)
+	mockMetrics := NewMockMetrics(ctrl)
+	db.metrics = mockMetrics
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats",
+		gomock.Any(), "hostname", gomock.Any(), "database", gomock.Any(), "type", gomock.Any())
+
+	var c customer
+
+	db.Select(context.Background(), &c, "SELECT id, email FROM customers")
+
+	assert.Equal(t, customer{ID: 1}, c, "TEST Failed.\nthe ciphertext is selected as the plaintext")
+}
@@ -23,6 +23,17 @@ type DBStats struct {
 	MaxLifetimeClosed int64         `json:"maxLifetimeClosed"` // The total number of connections closed due to SetConnMaxLifetime.
 }
 
+// versionQueries return the version of the server of each dialect, which is part of the health of the DB.
+//
+//nolint:gochecknoglobals // the queries are constant, but a map can not be declared as a constant.
+var versionQueries = map[string]string{
+	dialectMysql:      "SELECT VERSION()",
+	dialectPostgres:   "SHOW server_version",
+	sqlite:            "SELECT sqlite_version()",
+	dialectMSSQL:      "SELECT CAST(SERVERPROPERTY('ProductVersion') AS VARCHAR(128))",
+	dialectClickHouse: "SELECT version()",
+}
+
 func (d *DB) HealthCheck() *datasource.Health {
 	h := datasource.Health{
 		Details: make(map[string]interface{}),
@@ -46,6 +57,19 @@ func (d *DB) HealthCheck() *datasource.Health {
 		return &h
 	}
 
+	// the server may accept connections while it cannot run queries, like a database of SQL Server being recovered.
+	if query, ok := versionQueries[d.config.Dialect]; ok {
+		var version string
+
+		if err := d.DB.QueryRowContext(ctx, query).Scan(&version); err != nil {
+			h.Status = datasource.StatusDown
+
+			return &h
+		}
+
+		h.Details["version"] = version
+	}
+
 	h.Status = datasource.StatusUp
 
 	dbStats := d.Stats()
@@ -3,6 +3,7 @@ package sql
 import (
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
@@ -88,3 +89,37 @@ func TestHealth_HealthCheckDBPingFailed(t *testing.T) {
 
 	assert.Equal(t, expected, out)
 }
+
+func TestHealth_HealthCheckVersion(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		err     error
+		status  string
+		version interface{}
+	}{
+		{"version of the server", nil, datasource.StatusUp, "16.0.1000.6"},
+		{"server cannot run queries", errDB, datasource.StatusDown, nil},
+	}
+
+	for i, tc := range testCases {
+		db, mock := getDB(t, logging.INFO)
+
+		db.config = &DBConfig{Dialect: dialectMSSQL, HostName: "host", Port: "1433", Database: "test"}
+
+		mock.ExpectPing()
+
+		query := mock.ExpectQuery(versionQueries[dialectMSSQL])
+		if tc.err != nil {
+			query.WillReturnError(tc.err)
+		} else {
+			query.WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(tc.version))
+		}
+
+		out := db.HealthCheck()
+
+		assert.Equal(t, tc.status, out.Status, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.version, out.Details["version"], "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		db.DB.Close()
+	}
+}
@@ -0,0 +1,89 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// VersionColumn is the column of the version of the rows, which UpdateWithVersion increments.
+	VersionColumn = "version"
+	// DeletedAtColumn is the time at which the rows are soft deleted, which is NULL for the others.
+	DeletedAtColumn = "deleted_at"
+)
+
+// ErrVersionConflict is the error of the updates of a row whose version changed since it was read.
+var ErrVersionConflict = errors.New("version conflict")
+
+// VersionConflictError is returned by UpdateWithVersion when no row has the expected version.
+type VersionConflictError struct {
+	Table   string
+	Version int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("the row of %s was changed or deleted since its version %d was read", e.Table, e.Version)
+}
+
+func (*VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+func (*VersionConflictError) StatusCode() int {
+	return http.StatusConflict
+}
+
+// UpdateWithVersion runs the update on the rows which have the version, and returns the new version.
+//
+//	Usage:
+//	version, err := ctx.SQL.UpdateWithVersion(ctx, sql.Update("orders").Set("status", "paid").Where("id = ?", id),
+//		order.Version)
+func (d *DB) UpdateWithVersion(ctx context.Context, update *UpdateBuilder, version int64) (int64, error) {
+	u := *update
+	u.set = append(append([]assignment(nil), update.set...), assignment{column: VersionColumn, value: version + 1})
+	u.where = append(append(conditions(nil), update.where...), condition{expr: VersionColumn + " = ?",
+		args: []interface{}{version}})
+
+	res, err := u.Exec(ctx, d)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if n == 0 {
+		return 0, &VersionConflictError{Table: update.table, Version: version}
+	}
+
+	return version + 1, nil
+}
+
+// SoftDelete sets the DeletedAtColumn of the rows matching the condition, and returns their number.
+//
+//	Usage:
+//	n, err := ctx.SQL.SoftDelete(ctx, "orders", "id = ?", id)
+func (d *DB) SoftDelete(ctx context.Context, table, condition string, args ...interface{}) (int64, error) {
+	now := time.Now()
+	if d.clock != nil {
+		now = d.clock.Now()
+	}
+
+	res, err := Update(table).Set(DeletedAtColumn, now.UTC()).Where(DeletedAtColumn+" IS NULL").
+		Where(condition, args...).Exec(ctx, d)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// NotDeleted leaves the soft deleted rows out of the query.
+func (s *SelectBuilder) NotDeleted() *SelectBuilder {
+	return s.Where(DeletedAtColumn + " IS NULL")
+}
@@ -0,0 +1,84 @@
+package sql
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+func TestDB_UpdateWithVersion(t *testing.T) {
+	db := newSQLite(t)
+	ctx := context.Background()
+
+	_, err := db.Exec("CREATE TABLE orders (id INTEGER, status TEXT, version INTEGER)")
+	require.NoError(t, err)
+
+	_, err = db.Exec("INSERT INTO orders VALUES (1, 'new', 1)")
+	require.NoError(t, err)
+
+	update := Update("orders").Set("status", "paid").Where("id = ?", 1)
+
+	version, err := db.UpdateWithVersion(ctx, update, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+
+	// the update read at the first version conflicts with the one which was made.
+	_, err = db.UpdateWithVersion(ctx, Update("orders").Set("status", "canceled").Where("id = ?", 1), 1)
+
+	var conflict *VersionConflictError
+
+	require.ErrorAs(t, err, &conflict)
+	require.ErrorIs(t, err, ErrVersionConflict)
+	assert.Equal(t, http.StatusConflict, conflict.StatusCode())
+	assert.Equal(t, "the row of orders was changed or deleted since its version 1 was read", err.Error())
+
+	var status string
+
+	require.NoError(t, db.QueryRow("SELECT status FROM orders WHERE id = 1").Scan(&status))
+	assert.Equal(t, "paid", status)
+
+	query, _, err := update.Build(db.Dialect())
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE orders SET status = ? WHERE id = ?", query, "the update must not be modified")
+
+	_, err = db.UpdateWithVersion(ctx, Update("").Set("status", "paid").Where("id = ?", 1), 2)
+	require.ErrorIs(t, err, errNoTable)
+}
+
+func TestDB_SoftDelete(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	db := newSQLite(t, WithClock(clock.NewFake(now)))
+	ctx := context.Background()
+
+	_, err := db.Exec("CREATE TABLE orders (id INTEGER, deleted_at TIMESTAMP)")
+	require.NoError(t, err)
+
+	_, err = db.Exec("INSERT INTO orders (id) VALUES (1), (2), (3)")
+	require.NoError(t, err)
+
+	n, err := db.SoftDelete(ctx, "orders", "id IN (?)", []int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	// the rows which are already deleted are left as they are.
+	n, err = db.SoftDelete(ctx, "orders", "id = ?", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	var ids []int
+
+	require.NoError(t, Select("id").From("orders").NotDeleted().Load(ctx, db, &ids))
+	assert.Equal(t, []int{3}, ids)
+
+	var deletedAt time.Time
+
+	require.NoError(t, db.QueryRow("SELECT deleted_at FROM orders WHERE id = 1").Scan(&deletedAt))
+	assert.True(t, now.Equal(deletedAt), deletedAt)
+}
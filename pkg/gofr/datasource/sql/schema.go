@@ -0,0 +1,63 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const createTableIfNotExists = "CREATE TABLE IF NOT EXISTS "
+
+// ErrUnsupportedDialect is returned by the stores of gofr, like the jobs or the sagas, on the dialects which can not
+// update and delete their rows in place, like ClickHouse.
+var ErrUnsupportedDialect = errors.New("the stores of gofr do not support the dialect")
+
+// Execer executes the statements of a Schema, like the DB of gofr.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	Dialect() string
+}
+
+// Schema creates the tables of a store on first use, retrying a failed creation on the next use. It rejects ClickHouse.
+type Schema struct {
+	mu   sync.Mutex
+	done bool
+}
+
+// Create executes the statements, unless they succeeded already.
+func (s *Schema) Create(ctx context.Context, db Execer, statements ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.done {
+		return nil
+	}
+
+	if db.Dialect() == dialectClickHouse {
+		return fmt.Errorf("%w %s, which can not update and delete the rows in place", ErrUnsupportedDialect, db.Dialect())
+	}
+
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, CreateTable(db.Dialect(), statement)); err != nil {
+			return err
+		}
+	}
+
+	s.done = true
+
+	return nil
+}
+
+// CreateTable rewrites the "CREATE TABLE IF NOT EXISTS" of the statement for the dialect.
+func CreateTable(dialect, statement string) string {
+	if dialect != dialectMSSQL || !strings.HasPrefix(statement, createTableIfNotExists) {
+		return statement
+	}
+
+	table, _, _ := strings.Cut(strings.TrimPrefix(statement, createTableIfNotExists), " ")
+
+	return "IF OBJECT_ID('" + table + "', 'U') IS NULL CREATE TABLE " + strings.TrimPrefix(statement, createTableIfNotExists)
+}
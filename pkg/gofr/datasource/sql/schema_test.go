@@ -0,0 +1,97 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Create(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	const createTable = "CREATE TABLE IF NOT EXISTS gofr_jobs (id VARCHAR(36))"
+
+	errCanceled := errors.New("context canceled")
+
+	var s Schema
+
+	execer := dialectDB{DB: db, dialect: dialectMysql}
+
+	mock.ExpectExec(createTable).WillReturnError(errCanceled)
+	require.ErrorIs(t, s.Create(context.Background(), execer, createTable), errCanceled)
+
+	// the creation is tried again after a failure, and only once it succeeded.
+	mock.ExpectExec(createTable).WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, s.Create(context.Background(), execer, createTable))
+	require.NoError(t, s.Create(context.Background(), execer, createTable))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchema_Create_Dialects(t *testing.T) {
+	const createTable = "CREATE TABLE IF NOT EXISTS gofr_jobs (id VARCHAR(36))"
+
+	tests := []struct {
+		dialect string
+		query   string
+		err     error
+	}{
+		{dialectMysql, createTable, nil},
+		{dialectPostgres, createTable, nil},
+		{"sqlite", createTable, nil},
+		{dialectMSSQL, "IF OBJECT_ID('gofr_jobs', 'U') IS NULL CREATE TABLE gofr_jobs (id VARCHAR(36))", nil},
+		{dialectClickHouse, "", ErrUnsupportedDialect},
+	}
+
+	for i, tc := range tests {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		if tc.query != "" {
+			mock.ExpectExec(tc.query).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+
+		var s Schema
+
+		err = s.Create(context.Background(), dialectDB{DB: db, dialect: tc.dialect}, createTable)
+
+		require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.dialect)
+		require.NoError(t, mock.ExpectationsWereMet(), "TEST[%d], Failed.\n%s", i, tc.dialect)
+
+		db.Close()
+	}
+}
+
+func TestCreateTable(t *testing.T) {
+	const createTable = "CREATE TABLE IF NOT EXISTS gofr_jobs (id VARCHAR(36))"
+
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{dialectMysql, createTable},
+		{dialectPostgres, createTable},
+		{dialectMSSQL, "IF OBJECT_ID('gofr_jobs', 'U') IS NULL CREATE TABLE gofr_jobs (id VARCHAR(36))"},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.expected, CreateTable(tc.dialect, createTable), "TEST[%d], Failed.\n%s", i, tc.dialect)
+	}
+}
+
+type dialectDB struct {
+	*sql.DB
+	dialect string
+}
+
+func (d dialectDB) Dialect() string {
+	return d.dialect
+}
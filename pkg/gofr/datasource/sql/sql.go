@@ -2,15 +2,24 @@ package sql
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	_ "github.com/ClickHouse/clickhouse-go/v2" // used for concrete implementation of the database driver.
 	"github.com/XSAM/otelsql"
 	_ "github.com/lib/pq" // used for concrete implementation of the database driver.
+	_ "github.com/microsoft/go-mssqldb"
 	_ "modernc.org/sqlite"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/bulkhead"
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
 	"github.com/peter-stratton/gofr/pkg/gofr/config"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
 )
@@ -20,7 +29,13 @@ const (
 	defaultDBPort = 3306
 )
 
-var errUnsupportedDialect = fmt.Errorf("unsupported db dialect; supported dialects are - mysql, postgres, sqlite")
+var (
+	errUnsupportedDialect = fmt.Errorf("unsupported db dialect; supported dialects are - mysql, postgres, sqlite, " +
+		"mssql, clickhouse")
+	errInvalidSchema = errors.New("invalid schema; a schema is made of letters, digits, '-' and '_'")
+
+	validSchema = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
 
 // DBConfig has those members which are necessary variables while connecting to database.
 type DBConfig struct {
@@ -30,9 +45,35 @@ type DBConfig struct {
 	Password string
 	Port     string
 	Database string
+	// Schema is the schema of the connections to PostgreSQL, which is the default schema of the user if empty.
+	Schema string
+}
+
+// Option configures the DB created using NewSQL.
+type Option func(d *DB)
+
+// WithClock sets the clock which times the retries of the connection and the pushing of the metrics.
+func WithClock(c clock.Clock) Option {
+	return func(d *DB) {
+		d.clock = c
+	}
+}
+
+// WithBulkhead limits the concurrent queries of the DB, other than those of its transactions, with the bulkhead.
+func WithBulkhead(b *bulkhead.Bulkhead) Option {
+	return func(d *DB) {
+		d.bulkhead = b
+	}
+}
+
+// WithFaultInjector injects the faults of the injector into the queries of the DB.
+func WithFaultInjector(i *chaos.Injector) Option {
+	return func(d *DB) {
+		d.faults = i
+	}
 }
 
-func NewSQL(configs config.Config, logger datasource.Logger, metrics Metrics) *DB {
+func NewSQL(configs config.Config, logger datasource.Logger, metrics Metrics, opts ...Option) *DB {
 	dbConfig := getDBConfig(configs)
 
 	// if Hostname is not provided, we won't try to connect to DB
@@ -49,13 +90,17 @@ func NewSQL(configs config.Config, logger datasource.Logger, metrics Metrics) *D
 		return nil
 	}
 
-	otelRegisteredDialect, err := otelsql.Register(dbConfig.Dialect)
+	otelRegisteredDialect, err := otelsql.Register(driverName(dbConfig.Dialect))
 	if err != nil {
 		logger.Errorf("could not register sql dialect '%s' for traces, error: %s", dbConfig.Dialect, err)
 		return nil
 	}
 
-	database := &DB{config: dbConfig, logger: logger, metrics: metrics}
+	database := &DB{config: dbConfig, logger: logger, metrics: metrics, clock: clock.New(), driver: otelRegisteredDialect}
+
+	for _, o := range opts {
+		o(database)
+	}
 
 	database.DB, err = sql.Open(otelRegisteredDialect, dbConnectionString)
 	if err != nil {
@@ -69,11 +114,47 @@ func NewSQL(configs config.Config, logger datasource.Logger, metrics Metrics) *D
 
 	go retryConnection(database)
 
-	go pushDBMetrics(database.DB, metrics)
+	go pushDBMetrics(database.DB, metrics, database.clock)
 
 	return database
 }
 
+// WithSchema returns a DB of the schema on the same server as d, which must exist. It must be closed once unused.
+func (d *DB) WithSchema(schema string) (*DB, error) {
+	if !validSchema.MatchString(schema) {
+		return nil, errInvalidSchema
+	}
+
+	cfg := *d.config
+
+	switch cfg.Dialect {
+	case "postgres":
+		cfg.Schema = schema
+	case sqlite:
+		cfg.Database = filepath.Join(filepath.Dir(cfg.Database), schema)
+	default:
+		cfg.Database = schema
+	}
+
+	dsn, err := getDBConnectionString(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	driver := d.driver
+	if driver == "" {
+		driver = driverName(cfg.Dialect)
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DB{DB: db, logger: d.logger, config: &cfg, metrics: d.metrics, clock: d.clock, driver: driver,
+		bulkhead: d.bulkhead, faults: d.faults, guard: d.guard, keyring: d.keyring}, nil
+}
+
 func pingToTestConnection(database *DB) *DB {
 	if err := database.DB.Ping(); err != nil {
 		database.logger.Errorf("could not connect with '%s' user to '%s' database at '%s:%s', error: %v",
@@ -100,7 +181,7 @@ func retryConnection(database *DB) {
 					database.logger.Debugf("could not connect with '%s' user to database '%s:%s', error: %v",
 						database.config.User, database.config.HostName, database.config.Port, err)
 
-					time.Sleep(connRetryFrequencyInSeconds * time.Second)
+					database.clock.Sleep(connRetryFrequencyInSeconds * time.Second)
 				} else {
 					database.logger.Logf("connected to '%s' database at '%s:%s'", database.config.Database,
 						database.config.HostName, database.config.Port)
@@ -110,7 +191,7 @@ func retryConnection(database *DB) {
 			}
 		}
 
-		time.Sleep(connRetryFrequencyInSeconds * time.Second)
+		database.clock.Sleep(connRetryFrequencyInSeconds * time.Second)
 	}
 }
 
@@ -120,7 +201,7 @@ func getDBConfig(configs config.Config) *DBConfig {
 		HostName: configs.Get("DB_HOST"),
 		User:     configs.Get("DB_USER"),
 		Password: configs.Get("DB_PASSWORD"),
-		Port:     configs.GetOrDefault("DB_PORT", strconv.Itoa(defaultDBPort)),
+		Port:     configs.GetOrDefault("DB_PORT", defaultPort(configs.Get("DB_DIALECT"))),
 		Database: configs.Get("DB_NAME"),
 	}
 }
@@ -136,18 +217,57 @@ func getDBConnectionString(dbConfig *DBConfig) (string, error) {
 			dbConfig.Database,
 		), nil
 	case "postgres":
-		return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-			dbConfig.HostName, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.Database), nil
+		dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+			dbConfig.HostName, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.Database)
+
+		if dbConfig.Schema != "" {
+			// the schema is quoted so that it is not folded to lower case and may contain '-'.
+			dsn += fmt.Sprintf(` search_path='"%s"'`, dbConfig.Schema)
+		}
+
+		return dsn, nil
 	case sqlite:
 		s := strings.TrimSuffix(dbConfig.Database, ".db")
 
 		return fmt.Sprintf("file:%s.db", s), nil
+	case dialectMSSQL:
+		u := url.URL{Scheme: "sqlserver", User: url.UserPassword(dbConfig.User, dbConfig.Password),
+			Host: dbConfig.HostName + ":" + dbConfig.Port, RawQuery: url.Values{"database": {dbConfig.Database}}.Encode()}
+
+		return u.String(), nil
+	case dialectClickHouse:
+		u := url.URL{Scheme: "clickhouse", User: url.UserPassword(dbConfig.User, dbConfig.Password),
+			Host: dbConfig.HostName + ":" + dbConfig.Port, Path: "/" + dbConfig.Database}
+
+		return u.String(), nil
 	default:
 		return "", errUnsupportedDialect
 	}
 }
 
-func pushDBMetrics(db *sql.DB, metrics Metrics) {
+// driverName returns the name of the database/sql driver of the dialect.
+func driverName(dialect string) string {
+	if dialect == dialectMSSQL {
+		// the sqlserver driver binds the "@p1" bindvars, unlike the legacy mssql driver.
+		return "sqlserver"
+	}
+
+	return dialect
+}
+
+// defaultPort returns the port of the database server of the dialect, when DB_PORT is not set.
+func defaultPort(dialect string) string {
+	switch dialect {
+	case dialectMSSQL:
+		return "1433"
+	case dialectClickHouse:
+		return "9000"
+	default:
+		return strconv.Itoa(defaultDBPort)
+	}
+}
+
+func pushDBMetrics(db *sql.DB, metrics Metrics, c clock.Clock) {
 	const frequency = 10
 
 	for {
@@ -157,7 +277,7 @@ func pushDBMetrics(db *sql.DB, metrics Metrics) {
 			metrics.SetGauge("app_sql_open_connections", float64(stats.OpenConnections))
 			metrics.SetGauge("app_sql_inUse_connections", float64(stats.InUse))
 
-			time.Sleep(frequency * time.Second)
+			c.Sleep(frequency * time.Second)
 		}
 	}
 }
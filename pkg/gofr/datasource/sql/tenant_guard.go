@@ -0,0 +1,318 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+// defaultTenantColumn is the column of the tenant of the rows of the guarded tables, unless it is set.
+const defaultTenantColumn = "tenant_id"
+
+// ErrCrossTenant is the error of the queries rejected by the tenant guard.
+var ErrCrossTenant = errors.New("cross-tenant query")
+
+// TenantGuard rejects the queries of the tables shared by the tenants which cross the tenant of their context.
+type TenantGuard struct {
+	// Tables are the tables shared by the tenants.
+	Tables []string
+	// Column is the column of the tenant of the rows, which is "tenant_id" by default.
+	Column string
+	// Setting is the run-time parameter of PostgreSQL set to the tenant in each transaction, like "app.tenant". It is
+	// not set for the queries made outside of a transaction.
+	Setting string
+}
+
+// WithTenantGuard guards the queries of the DB, and of its transactions, on the tables of the guard:
+//
+//   - the queries of the builders, like Select and Update, are scoped to the tenant of their context, by a condition on
+//     the column of the tenant, and the rows they insert or update are set to the tenant;
+//   - the other queries on the tables, other than the statements of the schema like CREATE TABLE, must filter the rows
+//     by a condition of their WHERE clause comparing the column of the tenant to a bindvar bound to the tenant, like
+//     "tenant_id = ?", which is joined to the others by AND, and must insert the rows with the column bound to the
+//     tenant;
+//   - the statements prepared on the tables are rejected, as their arguments are bound when they are executed;
+//   - the transactions set the setting of PostgreSQL, if any, to the tenant, and their queries without a context are
+//     guarded with the tenant of the context of BeginTx. The queries made outside of a transaction run without it.
+//
+// The queries are rejected with a *TenantGuardError if their context has no tenant, if they are not scoped to the
+// tenant, or if they insert or update the rows of another tenant. The queries which must cross the tenants, like
+// those of the migrations or of the reports of the operators, are made with a context returned by BypassTenantGuard.
+func WithTenantGuard(g TenantGuard) Option {
+	return func(d *DB) {
+		d.guard = newTenantGuard(g)
+	}
+}
+
+// TenantGuardError is the error of a query rejected by the tenant guard, responded with 403 Forbidden.
+type TenantGuardError struct {
+	Table  string
+	Tenant string
+	Reason string
+}
+
+func (e *TenantGuardError) Error() string {
+	if e.Tenant == "" {
+		return fmt.Sprintf("the query on %s is rejected: %s", e.Table, e.Reason)
+	}
+
+	return fmt.Sprintf("the query on %s of tenant %s is rejected: %s", e.Table, e.Tenant, e.Reason)
+}
+
+func (*TenantGuardError) Unwrap() error {
+	return ErrCrossTenant
+}
+
+func (*TenantGuardError) StatusCode() int {
+	return http.StatusForbidden
+}
+
+type bypassKey struct{}
+
+// BypassTenantGuard returns a copy of ctx whose queries are not guarded, for the queries which must cross the tenants.
+func BypassTenantGuard(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+type tenantGuard struct {
+	tables  map[string]bool
+	column  string
+	setting string
+	// tablesPattern matches the guarded tables, columnPattern the column of the tenant, and predicatePattern its filter.
+	tablesPattern    *regexp.Regexp
+	columnPattern    *regexp.Regexp
+	predicatePattern *regexp.Regexp
+}
+
+func newTenantGuard(g TenantGuard) *tenantGuard {
+	if len(g.Tables) == 0 {
+		return nil
+	}
+
+	column := g.Column
+	if column == "" {
+		column = defaultTenantColumn
+	}
+
+	guard := &tenantGuard{tables: make(map[string]bool), column: column, setting: g.Setting}
+
+	names := make([]string, 0, len(g.Tables))
+
+	for _, t := range g.Tables {
+		guard.tables[strings.ToLower(t)] = true
+		names = append(names, regexp.QuoteMeta(t))
+	}
+
+	guard.tablesPattern = regexp.MustCompile(`(?i)\b(` + strings.Join(names, "|") + `)\b`)
+	guard.columnPattern = regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(column) + `\b`)
+	guard.predicatePattern = regexp.MustCompile("(?i)(?:([\\w`\"]+)\\.)?[`\"]?\\b" + regexp.QuoteMeta(column) +
+		"\\b[`\"]?\\s*=\\s*(" + bindVarPattern + ")")
+
+	return guard
+}
+
+// tenant returns the tenant of ctx, and whether the queries of ctx are guarded.
+func (g *tenantGuard) tenant(ctx context.Context) (id string, guarded bool) {
+	if g == nil {
+		return "", false
+	}
+
+	if bypass, _ := ctx.Value(bypassKey{}).(bool); bypass {
+		return "", false
+	}
+
+	return tenant.FromContext(ctx), true
+}
+
+// guards reports whether the table, which may be followed by its alias, is guarded.
+func (g *tenantGuard) guards(table string) bool {
+	name, _, _ := strings.Cut(strings.TrimSpace(table), " ")
+
+	return g.tables[strings.ToLower(strings.Trim(name, "`\""))]
+}
+
+// check rejects the query of ctx if it reads or writes a guarded table without being scoped to the tenant.
+func (g *tenantGuard) check(ctx context.Context, query string, args []interface{}) error {
+	id, guarded := g.tenant(ctx)
+	if !guarded {
+		return nil
+	}
+
+	table := g.table(query)
+	if table == "" {
+		return nil
+	}
+
+	if id == "" {
+		return &TenantGuardError{Table: table, Reason: tenant.ErrNoTenant.Error()}
+	}
+
+	if reason := g.unscoped(query, args, id); reason != "" {
+		return &TenantGuardError{Table: table, Tenant: id, Reason: reason}
+	}
+
+	return nil
+}
+
+// checkPrepared rejects the statements of ctx prepared on a guarded table, as their arguments can not be checked.
+func (g *tenantGuard) checkPrepared(ctx context.Context, query string) error {
+	id, guarded := g.tenant(ctx)
+	if !guarded {
+		return nil
+	}
+
+	table := g.table(query)
+	if table == "" {
+		return nil
+	}
+
+	if id == "" {
+		return &TenantGuardError{Table: table, Reason: tenant.ErrNoTenant.Error()}
+	}
+
+	return &TenantGuardError{Table: table, Tenant: id, Reason: "it is prepared, so its arguments can not be checked"}
+}
+
+// table returns the guarded table read or written by the query, or "" if it does not read or write one.
+func (g *tenantGuard) table(query string) string {
+	switch strings.ToUpper(getOperationType(query)) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "WITH", "REPLACE", "MERGE":
+		return g.tablesPattern.FindString(query)
+	default:
+		return ""
+	}
+}
+
+// alias returns the column of the tenant qualified by the alias of the table, like "o.tenant_id" for "orders o".
+func (g *tenantGuard) alias(table string) string {
+	fields := strings.Fields(table)
+	if len(fields) < 2 {
+		return g.column
+	}
+
+	return fields[len(fields)-1] + "." + g.column
+}
+
+// guardOf returns the tenant guard of the datasource, if it has one.
+func guardOf(db Querier) *tenantGuard {
+	if d, ok := db.(*DB); ok && d != nil {
+		return d.guard
+	}
+
+	return nil
+}
+
+// scope returns a copy of the query scoped to the tenant of ctx, if its table is guarded.
+func (s *SelectBuilder) scope(ctx context.Context, g *tenantGuard) (*SelectBuilder, error) {
+	id, guarded := g.tenant(ctx)
+	if !guarded || !g.guards(s.table) {
+		return s, nil
+	}
+
+	if id == "" {
+		return nil, &TenantGuardError{Table: s.table, Reason: tenant.ErrNoTenant.Error()}
+	}
+
+	scoped := *s
+	scoped.where = append(append(conditions(nil), s.where...), condition{expr: g.alias(s.table) + " = ?",
+		args: []interface{}{id}})
+
+	return &scoped, nil
+}
+
+// scope returns a copy of the query which inserts the rows of the tenant of ctx, if its table is guarded.
+func (i *InsertBuilder) scope(ctx context.Context, g *tenantGuard) (*InsertBuilder, error) {
+	id, guarded := g.tenant(ctx)
+	if !guarded || !g.guards(i.table) {
+		return i, nil
+	}
+
+	if id == "" {
+		return nil, &TenantGuardError{Table: i.table, Reason: tenant.ErrNoTenant.Error()}
+	}
+
+	for n, c := range i.columns {
+		if !strings.EqualFold(c, g.column) {
+			continue
+		}
+
+		for _, row := range i.rows {
+			if n < len(row) && fmt.Sprint(row[n]) != id {
+				return nil, &TenantGuardError{Table: i.table, Tenant: id,
+					Reason: fmt.Sprintf("it inserts a row of tenant %v", row[n])}
+			}
+		}
+
+		return i, nil
+	}
+
+	scoped := *i
+	scoped.columns = append(append([]string(nil), i.columns...), g.column)
+	scoped.rows = make([][]interface{}, len(i.rows))
+
+	for n, row := range i.rows {
+		scoped.rows[n] = append(append([]interface{}(nil), row...), id)
+	}
+
+	return &scoped, nil
+}
+
+// scope returns a copy of the query scoped to the tenant of ctx, if its table is guarded.
+func (u *UpdateBuilder) scope(ctx context.Context, g *tenantGuard) (*UpdateBuilder, error) {
+	id, guarded := g.tenant(ctx)
+	if !guarded || !g.guards(u.table) {
+		return u, nil
+	}
+
+	if id == "" {
+		return nil, &TenantGuardError{Table: u.table, Reason: tenant.ErrNoTenant.Error()}
+	}
+
+	for _, a := range u.set {
+		if strings.EqualFold(a.column, g.column) && fmt.Sprint(a.value) != id {
+			return nil, &TenantGuardError{Table: u.table, Tenant: id,
+				Reason: fmt.Sprintf("it moves the rows to tenant %v", a.value)}
+		}
+	}
+
+	scoped := *u
+	scoped.where = append(append(conditions(nil), u.where...), condition{expr: g.column + " = ?",
+		args: []interface{}{id}})
+
+	return &scoped, nil
+}
+
+// scope returns a copy of the query scoped to the tenant of ctx, if its table is guarded.
+func (d *DeleteBuilder) scope(ctx context.Context, g *tenantGuard) (*DeleteBuilder, error) {
+	id, guarded := g.tenant(ctx)
+	if !guarded || !g.guards(d.table) {
+		return d, nil
+	}
+
+	if id == "" {
+		return nil, &TenantGuardError{Table: d.table, Reason: tenant.ErrNoTenant.Error()}
+	}
+
+	scoped := *d
+	scoped.where = append(append(conditions(nil), d.where...), condition{expr: g.column + " = ?",
+		args: []interface{}{id}})
+
+	return &scoped, nil
+}
+
+// setTenant sets the setting of the guard to the tenant of ctx in the transaction.
+func (g *tenantGuard) setTenant(ctx context.Context, tx *Tx) error {
+	id, guarded := g.tenant(ctx)
+	if !guarded || g.setting == "" || id == "" || tx.config.Dialect != dialectPostgres {
+		return nil
+	}
+
+	_, err := tx.Tx.ExecContext(ctx, "SELECT set_config($1, $2, true)", g.setting, id)
+
+	return err
+}
@@ -0,0 +1,325 @@
+package sql
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+func newGuardedSQLite(t *testing.T) *DB {
+	t.Helper()
+
+	db := newSQLite(t, WithTenantGuard(TenantGuard{Tables: []string{"orders"}}))
+
+	_, err := db.Exec("CREATE TABLE orders (id INTEGER, tenant_id TEXT, status TEXT)")
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(BypassTenantGuard(context.Background()),
+		"INSERT INTO orders VALUES (1, 'acme', 'new'), (2, 'globex', 'new')")
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestTenantGuard_Builders(t *testing.T) {
+	db := newGuardedSQLite(t)
+	acme := tenant.NewContext(context.Background(), "acme")
+
+	_, err := Insert("orders").Columns("id", "status").Values(3, "new").Exec(acme, db)
+	require.NoError(t, err)
+
+	var ids []int
+
+	require.NoError(t, Select("id").From("orders").OrderBy("id").Load(acme, db, &ids))
+	assert.Equal(t, []int{1, 3}, ids)
+
+	res, err := Update("orders").Set("status", "paid").Where("status = ?", "new").Exec(acme, db)
+	require.NoError(t, err)
+
+	n, _ := res.RowsAffected()
+	assert.Equal(t, int64(2), n)
+
+	res, err = Delete("orders").Where("id > ?", 0).Exec(acme, db)
+	require.NoError(t, err)
+
+	n, _ = res.RowsAffected()
+	assert.Equal(t, int64(2), n)
+
+	var status string
+
+	require.NoError(t, db.QueryRowContext(BypassTenantGuard(acme), "SELECT status FROM orders WHERE id = 2").Scan(&status))
+	assert.Equal(t, "new", status, "the rows of another tenant must not be changed")
+}
+
+func TestTenantGuard_Rejected(t *testing.T) {
+	db := newGuardedSQLite(t)
+	acme := tenant.NewContext(context.Background(), "acme")
+
+	tests := []struct {
+		desc string
+		run  func() error
+		err  string
+	}{
+		{"query without a tenant", func() error {
+			var ids []int
+			return Select("id").From("orders").Load(context.Background(), db, &ids)
+		}, "the query on orders is rejected: context has no tenant"},
+		{"query not filtering by the tenant", func() error {
+			_, err := db.QueryContext(acme, "SELECT id FROM orders")
+			return err
+		}, "the query on orders of tenant acme is rejected: it does not filter the rows by tenant_id"},
+		{"query filtering by another tenant", func() error {
+			_, err := db.QueryContext(acme, "SELECT id FROM orders WHERE tenant_id = 'globex'")
+			return err
+		}, "the query on orders of tenant acme is rejected: it does not filter the rows by tenant_id"},
+		{"query bound to another tenant", func() error {
+			_, err := db.ExecContext(acme, "DELETE FROM orders WHERE tenant_id = ?", "globex")
+			return err
+		}, "the query on orders of tenant acme is rejected: it filters the rows of another tenant by tenant_id"},
+		{"transaction query bound to another tenant", func() error {
+			tx, err := db.BeginTx(acme, nil)
+			require.NoError(t, err)
+
+			defer tx.Rollback()
+
+			_, err = tx.Query("SELECT id FROM orders WHERE tenant_id = ?", "globex")
+
+			return err
+		}, "the query on orders of tenant acme is rejected: it filters the rows of another tenant by tenant_id"},
+		{"row of another tenant", func() error {
+			_, err := Insert("orders").Columns("id", "tenant_id").Values(3, "globex").Exec(acme, db)
+			return err
+		}, "the query on orders of tenant acme is rejected: it inserts a row of tenant globex"},
+		{"rows moved to another tenant", func() error {
+			_, err := Update("orders").Set("tenant_id", "globex").Where("id = ?", 1).Exec(acme, db)
+			return err
+		}, "the query on orders of tenant acme is rejected: it moves the rows to tenant globex"},
+		{"row query bound to another tenant", func() error {
+			var id int
+			return db.QueryRowContext(acme, "SELECT id FROM orders WHERE tenant_id = ?", "globex").Scan(&id)
+		}, "the query on orders of tenant acme is rejected: it filters the rows of another tenant by tenant_id"},
+		{"transaction row query without a tenant", func() error {
+			tx, err := db.BeginTx(context.Background(), nil)
+			require.NoError(t, err)
+
+			defer tx.Rollback()
+
+			var id int
+
+			return tx.QueryRow("SELECT id FROM orders").Scan(&id)
+		}, "the query on orders is rejected: context has no tenant"},
+		{"prepared statement", func() error {
+			_, err := db.PrepareContext(acme, "SELECT id FROM orders WHERE tenant_id = ?")
+			return err
+		}, "the query on orders of tenant acme is rejected: it is prepared, so its arguments can not be checked"},
+		{"prepared statement without a tenant", func() error {
+			_, err := db.Prepare("DELETE FROM orders WHERE tenant_id = ?")
+			return err
+		}, "the query on orders is rejected: context has no tenant"},
+		{"transaction prepared statement", func() error {
+			tx, err := db.BeginTx(acme, nil)
+			require.NoError(t, err)
+
+			defer tx.Rollback()
+
+			_, err = tx.Prepare("UPDATE orders SET status = ? WHERE tenant_id = ?")
+
+			return err
+		}, "the query on orders of tenant acme is rejected: it is prepared, so its arguments can not be checked"},
+		{"transaction without a tenant", func() error {
+			tx, err := db.BeginTx(context.Background(), nil)
+			require.NoError(t, err)
+
+			defer tx.Rollback()
+
+			_, err = tx.Exec("DELETE FROM orders WHERE tenant_id = 'acme'")
+
+			return err
+		}, "the query on orders is rejected: context has no tenant"},
+	}
+
+	for i, tc := range tests {
+		err := tc.run()
+
+		var guardErr *TenantGuardError
+
+		require.ErrorAs(t, err, &guardErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+		require.ErrorIs(t, err, ErrCrossTenant, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, http.StatusForbidden, guardErr.StatusCode(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.err, err.Error(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestTenantGuard_Allowed(t *testing.T) {
+	db := newGuardedSQLite(t)
+	acme := tenant.NewContext(context.Background(), "acme")
+
+	var count int
+
+	require.NoError(t, db.QueryRowContext(acme, "SELECT COUNT(*) FROM orders WHERE tenant_id = ?", "acme").Scan(&count))
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, db.QueryRowContext(BypassTenantGuard(context.Background()), "SELECT COUNT(*) FROM orders").Scan(&count))
+	assert.Equal(t, 2, count)
+
+	// the tables which are not guarded, and the statements of the schema, are not checked.
+	_, err := db.ExecContext(context.Background(), "CREATE TABLE users (id INTEGER)")
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(context.Background(), "INSERT INTO users VALUES (1)")
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(context.Background(), "ALTER TABLE orders ADD COLUMN total INTEGER")
+	require.NoError(t, err)
+
+	tx, err := db.BeginTx(BypassTenantGuard(context.Background()), nil)
+	require.NoError(t, err)
+
+	_, err = tx.Exec("UPDATE orders SET total = 0")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	// the statements prepared on the tables which are not guarded, or bypassing the guard, are not checked.
+	stmt, err := db.PrepareContext(acme, "SELECT id FROM users WHERE id = ?")
+	require.NoError(t, err)
+	require.NoError(t, stmt.Close())
+
+	stmt, err = db.PrepareContext(BypassTenantGuard(acme), "SELECT id FROM orders WHERE tenant_id = ?")
+	require.NoError(t, err)
+	require.NoError(t, stmt.Close())
+
+	// the queries of a transaction without a context are guarded with the tenant of the context of BeginTx.
+	tx, err = db.BeginTx(acme, nil)
+	require.NoError(t, err)
+
+	_, err = tx.Exec("INSERT INTO orders (id, tenant_id, status) VALUES (?, ?, 'new')", 3, "acme")
+	require.NoError(t, err)
+
+	rows, err := tx.Query("SELECT id FROM orders WHERE tenant_id = ? ORDER BY id", "acme")
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+	require.NoError(t, rows.Err())
+
+	require.NoError(t, tx.QueryRow("SELECT COUNT(*) FROM orders WHERE tenant_id = ?", "acme").Scan(&count))
+	assert.Equal(t, 2, count)
+	require.NoError(t, tx.Commit())
+}
+
+func TestTenantGuard_Setting(t *testing.T) {
+	db, mock, mockMetrics := NewSQLMocksWithConfig(t, &DBConfig{Dialect: dialectPostgres})
+	defer db.Close()
+
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", gomock.Any(), "hostname", gomock.Any(),
+		"database", gomock.Any(), "type", gomock.Any()).AnyTimes()
+
+	db.guard = newTenantGuard(TenantGuard{Tables: []string{"orders"}, Setting: "app.tenant"})
+	acme := tenant.NewContext(context.Background(), "acme")
+
+	// the transactions set the setting to the tenant.
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT set_config($1, $2, true)").WithArgs("app.tenant", "acme").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM orders WHERE tenant_id = $1").WithArgs("acme").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.BeginTx(acme, nil)
+	require.NoError(t, err)
+
+	_, err = tx.ExecContext(acme, "DELETE FROM orders WHERE tenant_id = $1", "acme")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	// the queries outside of a transaction run without it.
+	mock.ExpectExec("DELETE FROM orders WHERE tenant_id = $1").WithArgs("acme").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, err = db.ExecContext(acme, "DELETE FROM orders WHERE tenant_id = $1", "acme")
+	require.NoError(t, err)
+
+	// the transactions bypassing the guard do not set it.
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	tx, err = db.BeginTx(BypassTenantGuard(acme), nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback())
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantGuard_Unscoped(t *testing.T) {
+	g := newTenantGuard(TenantGuard{Tables: []string{"orders"}})
+
+	tests := []struct {
+		desc   string
+		query  string
+		args   []interface{}
+		reason string
+	}{
+		{"bound to the tenant", "SELECT * FROM orders WHERE tenant_id = ?", []interface{}{"acme"}, ""},
+		{"bindvar of postgres", "SELECT * FROM orders WHERE status = $1 AND tenant_id = $2", []interface{}{"new", "acme"},
+			""},
+		{"bindvar of mssql", "SELECT * FROM orders o WHERE (o.status = @p1) AND (o.tenant_id = @p2)",
+			[]interface{}{"new", "acme"}, ""},
+		{"grouped conditions", "SELECT tenant_id FROM orders WHERE (status = ? OR status = ?) AND tenant_id = ? ORDER BY id",
+			[]interface{}{"new", "paid", "acme"}, ""},
+		{"literal with a bindvar", "SELECT * FROM orders WHERE note = '?' AND tenant_id = ?", []interface{}{"acme"}, ""},
+		{"insert", "INSERT INTO orders (id, tenant_id) VALUES (?, ?), (?, ?)", []interface{}{1, "acme", 2, "acme"}, ""},
+		{"update of the tenant", "UPDATE orders SET status = ?, tenant_id = ? WHERE tenant_id = ?",
+			[]interface{}{"paid", "acme", "acme"}, ""},
+		{"literal tenant", "SELECT * FROM orders WHERE tenant_id = 'other'", nil,
+			"it does not filter the rows by tenant_id"},
+		{"null check", "SELECT * FROM orders WHERE tenant_id IS NOT NULL", nil, "it does not filter the rows by tenant_id"},
+		{"comparison to itself", "SELECT * FROM orders WHERE tenant_id = ? OR tenant_id = tenant_id", []interface{}{"acme"},
+			"it does not filter the rows by tenant_id"},
+		{"disjunction", "SELECT * FROM orders WHERE (tenant_id = ?) OR 1 = 1", []interface{}{"acme"},
+			"it does not filter the rows by tenant_id"},
+		{"negation", "SELECT * FROM orders WHERE NOT (tenant_id = ?)", []interface{}{"acme"},
+			"it does not filter the rows by tenant_id"},
+		{"expression of the bindvar", "SELECT * FROM orders WHERE tenant_id = ? || 'corp'", []interface{}{"acme"},
+			"it does not filter the rows by tenant_id"},
+		{"subquery", "SELECT * FROM orders WHERE EXISTS (SELECT 1 FROM orders o WHERE o.tenant_id = ?)",
+			[]interface{}{"acme"}, "it does not filter the rows by tenant_id"},
+		{"condition of a join", "SELECT * FROM orders o LEFT JOIN items i ON o.tenant_id = ?", []interface{}{"acme"},
+			"it does not filter the rows by tenant_id"},
+		{"union", "SELECT id FROM orders WHERE tenant_id = ? UNION SELECT id FROM orders", []interface{}{"acme"},
+			"it combines the rows of several queries"},
+		{"bound to another tenant", "SELECT * FROM orders WHERE tenant_id = ?", []interface{}{"globex"},
+			"it filters the rows of another tenant by tenant_id"},
+		{"missing arg", "SELECT * FROM orders WHERE tenant_id = ?", nil, "it filters the rows of another tenant by tenant_id"},
+		{"insert without the tenant", "INSERT INTO orders (id) VALUES (?)", []interface{}{1},
+			"it inserts the rows without binding tenant_id to the tenant"},
+		{"insert of another tenant", "INSERT INTO orders (id, tenant_id) VALUES (?, ?), (?, 'globex')",
+			[]interface{}{1, "acme", 2}, "it inserts the rows without binding tenant_id to the tenant"},
+		{"insert of a selection", "INSERT INTO orders (id, tenant_id) SELECT id, ? FROM orders", []interface{}{"acme"},
+			"it inserts the rows without binding tenant_id to the tenant"},
+		{"update to another tenant", "UPDATE orders SET tenant_id = 'globex' WHERE tenant_id = ?", []interface{}{"acme"},
+			"it moves the rows to another tenant"},
+		{"update bound to another tenant", "UPDATE orders SET tenant_id = ? WHERE tenant_id = ?",
+			[]interface{}{"globex", "acme"}, "it moves the rows to another tenant"},
+		{"update of all the rows to the tenant", "UPDATE orders SET tenant_id = ?", []interface{}{"acme"},
+			"it does not filter the rows by tenant_id"},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.reason, g.unscoped(tc.query, tc.args, "acme"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestNewTenantGuard(t *testing.T) {
+	assert.Nil(t, newTenantGuard(TenantGuard{}))
+
+	g := newTenantGuard(TenantGuard{Tables: []string{"Orders"}, Column: "org"})
+
+	assert.True(t, g.guards("orders o"))
+	assert.True(t, g.guards(`"orders"`))
+	assert.False(t, g.guards("users"))
+	assert.Equal(t, "o.org", g.alias("orders o"))
+	assert.Equal(t, "org", g.alias("orders"))
+	assert.NoError(t, g.check(context.Background(), "SELECT * FROM orders_archive", nil))
+}
@@ -0,0 +1,463 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// bindVarPattern matches the bindvars of the dialects, like "?", "$1" and "@p1".
+const bindVarPattern = `\?|\$\d+|@p\d+`
+
+var (
+	insertStatementPattern = regexp.MustCompile(`(?i)^\s*(INSERT|REPLACE)\b`)
+	insertPattern          = regexp.MustCompile("(?is)^\\s*(?:INSERT|REPLACE)\\s+(?:INTO\\s+)?[\\w.`\"]+\\s*\\(([^()]*)\\)\\s*VALUES\\s*")
+	clausePattern          = regexp.MustCompile(
+		`(?i)\b(SELECT|FROM|JOIN|ON|WHERE|GROUP|HAVING|ORDER|LIMIT|SET|VALUES|UPDATE|RETURNING|USING)\b`)
+	setOperatorPattern = regexp.MustCompile(`(?i)\b(UNION|INTERSECT|EXCEPT)\b`)
+	orPattern          = regexp.MustCompile(`(?i)\bOR\b`)
+	bindVarOnlyPattern = regexp.MustCompile(`^(` + bindVarPattern + `)$`)
+	aliasPattern       = regexp.MustCompile("^[`\"]?\\s*(?i:AS\\s+)?[`\"]?([A-Za-z_]\\w*)")
+
+	// filterEnds are the keywords which may follow a condition of the WHERE clause which filters the rows.
+	filterEnds = map[string]bool{"AND": true, "OR": true, "ORDER": true, "GROUP": true, "LIMIT": true, "OFFSET": true,
+		"RETURNING": true, "FOR": true, "FETCH": true, "HAVING": true, "WINDOW": true}
+
+	// notAliases are the keywords which may follow a table without being its alias.
+	notAliases = map[string]bool{"WHERE": true, "JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true, "FULL": true,
+		"CROSS": true, "NATURAL": true, "OUTER": true, "STRAIGHT_JOIN": true, "ON": true, "USING": true, "SET": true,
+		"ORDER": true, "GROUP": true, "HAVING": true, "LIMIT": true, "OFFSET": true, "FETCH": true, "FOR": true,
+		"WINDOW": true, "VALUES": true, "VALUE": true, "SELECT": true, "DEFAULT": true, "RETURNING": true, "OUTPUT": true,
+		"WITH": true, "UNION": true, "INTERSECT": true, "EXCEPT": true, "PARTITION": true}
+)
+
+// tableRef is a guarded table read or written by a query, with its alias, if any.
+type tableRef struct {
+	name  string
+	alias string
+}
+
+// scopedQuery is a query checked by the tenant guard, whose string literals are blanked.
+type scopedQuery struct {
+	text string
+	args []interface{}
+	// depth is the depth of the parentheses of each byte of the query.
+	depth []int
+	// clauses are the indexes of the keywords of the clauses of the query, out of any parentheses.
+	clauses [][]int
+}
+
+func newScopedQuery(query string, args []interface{}) *scopedQuery {
+	q := &scopedQuery{text: blankLiterals(query), args: args, depth: make([]int, len(query))}
+
+	d := 0
+
+	for i := 0; i < len(q.text); i++ {
+		if q.text[i] == ')' && d > 0 {
+			d--
+		}
+
+		q.depth[i] = d
+
+		if q.text[i] == '(' {
+			d++
+		}
+	}
+
+	for _, m := range clausePattern.FindAllStringIndex(q.text, -1) {
+		if q.depth[m[0]] == 0 {
+			q.clauses = append(q.clauses, m)
+		}
+	}
+
+	return q
+}
+
+// unscoped returns why the query is not scoped to the tenant id, or "" if it is. A query is scoped if:
+//
+//   - it inserts the rows with the column of the tenant bound to the tenant, like "INSERT INTO orders (id, tenant_id)
+//     VALUES (?, ?)";
+//   - or its WHERE clause compares the column of the tenant of each guarded table to a bindvar bound to the tenant, like
+//     "o.tenant_id = ?", and these conditions are joined to the others by AND. The column is qualified by the alias of
+//     the table, or by its name, unless the query reads a single table.
+//
+// The updates of the column of the tenant must set it to a bindvar bound to the tenant, and the queries combining the
+// rows of several queries, like with UNION, reading a guarded table more than once, or in a subquery, are not scoped.
+func (g *tenantGuard) unscoped(query string, args []interface{}, id string) string {
+	q := newScopedQuery(query, args)
+
+	for _, m := range setOperatorPattern.FindAllStringIndex(q.text, -1) {
+		if q.depth[m[0]] == 0 {
+			return "it combines the rows of several queries"
+		}
+	}
+
+	insert := insertStatementPattern.MatchString(q.text)
+	if insert && !q.insertsTenant(g.column, id) {
+		return "it inserts the rows without binding " + g.column + " to the tenant"
+	}
+
+	scoped := insert
+	// qualifiers are the qualifiers of the conditions scoping the rows to the tenant, "" being the unqualified column.
+	qualifiers := make(map[string]bool)
+	predicates := g.predicatePattern.FindAllStringSubmatchIndex(q.text, -1)
+
+	for _, m := range predicates {
+		bound := q.bound(m[4], m[5], id)
+
+		switch clause := q.clause(m[0]); {
+		case clause == "SET" || clause == "UPDATE":
+			if !bound {
+				return "it moves the rows to another tenant"
+			}
+		case clause != "WHERE" || !q.filters(m[0], m[1]):
+			// the comparisons in the subqueries, the joins or the disjunctions do not filter the rows.
+			continue
+		case !bound:
+			return "it filters the rows of another tenant by " + g.column
+		default:
+			scoped = true
+
+			if m[2] < 0 {
+				qualifiers[""] = true
+			} else {
+				qualifiers[strings.ToLower(strings.Trim(q.text[m[2]:m[3]], "`\""))] = true
+			}
+		}
+	}
+
+	for _, m := range g.columnPattern.FindAllStringIndex(q.text, -1) {
+		if clause := q.clause(m[0]); (clause == "SET" || clause == "UPDATE") && q.depth[m[0]] == 0 &&
+			!within(m[0], predicates) {
+			return "it moves the rows to another tenant"
+		}
+	}
+
+	if !scoped {
+		return "it does not filter the rows by " + g.column
+	}
+
+	refs, reason := g.references(q)
+	if reason != "" || insert {
+		return reason
+	}
+
+	joined := q.joins()
+
+	for _, r := range refs {
+		qualifier := r.name
+		if r.alias != "" {
+			qualifier = r.alias
+		}
+
+		if !qualifiers[strings.ToLower(qualifier)] && (joined || !qualifiers[""]) {
+			return "it does not filter the rows of " + r.name + " by " + qualifier + "." + g.column
+		}
+	}
+
+	return ""
+}
+
+// references returns the guarded tables of the query, or why they cannot be scoped.
+func (g *tenantGuard) references(q *scopedQuery) (refs []tableRef, reason string) {
+	seen := make(map[string]bool)
+
+	for _, m := range g.tablesPattern.FindAllStringIndex(q.text, -1) {
+		rest := strings.TrimLeft(q.text[m[1]:], "`\"")
+		if strings.HasPrefix(rest, ".") {
+			// the table qualifies a column, like "orders.tenant_id".
+			continue
+		}
+
+		name := strings.ToLower(q.text[m[0]:m[1]])
+
+		switch {
+		case q.depth[m[0]] > 0:
+			return nil, "it reads " + name + " in a subquery"
+		case seen[name]:
+			return nil, "it reads " + name + " more than once"
+		}
+
+		seen[name] = true
+		ref := tableRef{name: name}
+
+		if a := aliasPattern.FindStringSubmatch(q.text[m[1]:]); a != nil && !notAliases[strings.ToUpper(a[1])] {
+			ref.alias = a[1]
+		}
+
+		refs = append(refs, ref)
+	}
+
+	return refs, ""
+}
+
+// joins reports whether the query reads several tables, by a join, a USING clause, or a list of tables.
+func (q *scopedQuery) joins() bool {
+	for n, m := range q.clauses {
+		end := len(q.text)
+		if n+1 < len(q.clauses) {
+			end = q.clauses[n+1][0]
+		}
+
+		switch strings.ToUpper(q.text[m[0]:m[1]]) {
+		case "JOIN":
+			return true
+		case "USING":
+			if next := strings.TrimLeft(q.text[m[1]:], " \t\r\n"); next != "" && next[0] != '(' {
+				return true
+			}
+		case "FROM", "UPDATE":
+			for k := m[1]; k < end; k++ {
+				if q.text[k] == ',' && q.depth[k] == 0 {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// clause returns the keyword of the clause of the query which the byte at pos is in, like "WHERE".
+func (q *scopedQuery) clause(pos int) string {
+	clause := ""
+
+	for _, m := range q.clauses {
+		if m[0] >= pos {
+			break
+		}
+
+		clause = strings.ToUpper(q.text[m[0]:m[1]])
+	}
+
+	return clause
+}
+
+// filters reports whether the condition at [start, end) filters the rows of the query, out of any subquery or OR.
+func (q *scopedQuery) filters(start, end int) bool {
+	i, grouped := start, 0
+
+	for {
+		i = len(strings.TrimRight(q.text[:i], " \t\r\n"))
+		if i == 0 || q.text[i-1] != '(' {
+			break
+		}
+
+		i--
+		grouped++
+	}
+
+	if grouped != q.depth[start] {
+		return false
+	}
+
+	if before := strings.ToUpper(lastWord(q.text[:i])); before != "WHERE" && before != "AND" {
+		return false
+	}
+
+	j := end
+
+	for {
+		j = len(q.text) - len(strings.TrimLeft(q.text[j:], " \t\r\n"))
+		if j == len(q.text) || q.text[j] != ')' {
+			break
+		}
+
+		j++
+	}
+
+	if j < len(q.text) && q.text[j] != ';' && !filterEnds[strings.ToUpper(firstWord(q.text[j:]))] {
+		return false
+	}
+
+	return !q.disjoined(start)
+}
+
+// disjoined reports whether the condition at pos is in an OR.
+func (q *scopedQuery) disjoined(pos int) bool {
+	for _, m := range orPattern.FindAllStringIndex(q.text, -1) {
+		if q.depth[m[0]] > q.depth[pos] {
+			continue
+		}
+
+		lo, hi := min(m[0], pos), max(m[0], pos)
+		enclosing := true
+
+		for k := lo; k <= hi; k++ {
+			if q.depth[k] < q.depth[m[0]] {
+				enclosing = false
+				break
+			}
+		}
+
+		if enclosing {
+			return true
+		}
+	}
+
+	return false
+}
+
+// insertsTenant reports whether each row inserted by the INSERT query has the column bound to the tenant id.
+func (q *scopedQuery) insertsTenant(column, id string) bool {
+	m := insertPattern.FindStringSubmatchIndex(q.text)
+	if m == nil {
+		return false
+	}
+
+	position := -1
+
+	for n, c := range strings.Split(q.text[m[2]:m[3]], ",") {
+		if strings.EqualFold(strings.Trim(strings.TrimSpace(c), "`\""), column) {
+			position = n
+		}
+	}
+
+	if position < 0 {
+		return false
+	}
+
+	for next := m[1]; ; {
+		values, end, ok := q.tuple(next)
+		if !ok || position >= len(values) || !q.bound(values[position][0], values[position][1], id) {
+			return false
+		}
+
+		next = len(q.text) - len(strings.TrimLeft(q.text[end:], " \t\r\n"))
+		if next == len(q.text) || q.text[next] != ',' {
+			return true
+		}
+
+		next++
+	}
+}
+
+// tuple returns the indexes of the values of the tuple starting at pos, like "(?, ?)", and the index of its end.
+func (q *scopedQuery) tuple(pos int) (values [][]int, end int, ok bool) {
+	start := len(q.text) - len(strings.TrimLeft(q.text[pos:], " \t\r\n"))
+	if start == len(q.text) || q.text[start] != '(' {
+		return nil, 0, false
+	}
+
+	d, from := q.depth[start]+1, start+1
+
+	for k := from; k < len(q.text); k++ {
+		switch {
+		case q.text[k] == ',' && q.depth[k] == d:
+			values = append(values, []int{from, k})
+			from = k + 1
+		case q.text[k] == ')' && q.depth[k] == d-1:
+			return append(values, []int{from, k}), k + 1, true
+		}
+	}
+
+	return nil, 0, false
+}
+
+// bound reports whether the text at [start, end) is a bindvar bound to the tenant id.
+func (q *scopedQuery) bound(start, end int, id string) bool {
+	bindVar := strings.TrimSpace(q.text[start:end])
+	if !bindVarOnlyPattern.MatchString(bindVar) {
+		return false
+	}
+
+	var n int
+
+	switch {
+	case bindVar == "?":
+		n = strings.Count(q.text[:start], "?")
+	case strings.HasPrefix(bindVar, "$"):
+		n, _ = strconv.Atoi(bindVar[1:])
+		n--
+	default:
+		n, _ = strconv.Atoi(bindVar[2:])
+		n--
+	}
+
+	if n < 0 || n >= len(q.args) {
+		return false
+	}
+
+	arg := q.args[n]
+
+	if v, ok := arg.(driver.Valuer); ok {
+		arg, _ = v.Value()
+	}
+
+	return fmt.Sprint(arg) == id
+}
+
+// blankLiterals returns the query with the content of its string literals, and its comments, replaced by spaces.
+func blankLiterals(query string) string {
+	b := []byte(query)
+	inLiteral := false
+
+	for i := 0; i < len(b); i++ {
+		switch {
+		case !inLiteral && strings.HasPrefix(query[i:], "--"):
+			for ; i < len(b) && b[i] != '\n'; i++ {
+				b[i] = ' '
+			}
+		case !inLiteral && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(b)
+			} else {
+				end += i + 4
+			}
+
+			for ; i < end; i++ {
+				b[i] = ' '
+			}
+
+			i--
+		case !inLiteral:
+			inLiteral = b[i] == '\''
+		case b[i] == '\\' && i+1 < len(b):
+			b[i], b[i+1] = ' ', ' '
+			i++
+		case b[i] == '\'' && i+1 < len(b) && b[i+1] == '\'':
+			b[i], b[i+1] = ' ', ' '
+			i++
+		case b[i] == '\'':
+			inLiteral = false
+		default:
+			b[i] = ' '
+		}
+	}
+
+	return string(b)
+}
+
+func within(pos int, spans [][]int) bool {
+	for _, s := range spans {
+		if pos >= s[0] && pos < s[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lastWord(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return fields[len(fields)-1]
+}
+
+func firstWord(s string) string {
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z')
+	})
+
+	if end < 0 {
+		return s
+	}
+
+	return s[:end]
+}
@@ -0,0 +1,125 @@
+// Package sqladapter adapts the SQL datasource of gofr to the libraries of database/sql, like sqlc or GORM.
+package sqladapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+var (
+	errNoDatasource        = errors.New("SQL datasource not configured")
+	errNotBeginner         = errors.New("transactions cannot be started on the datasource, as it is a transaction")
+	errNotUnwrapper        = errors.New("the datasource does not expose its pool of connections")
+	errIncompatibleQueries = errors.New("the connection does not implement the DBTX interface of the queries")
+)
+
+// DB is the SQL datasource of gofr, like ctx.SQL, the datasource of a tenant or a *sql.Tx of gofr.
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	Prepare(query string) (*sql.Stmt, error)
+}
+
+// ConnPool is a connection of database/sql over a datasource, for sqlc and GORM.
+type ConnPool struct {
+	db DB
+}
+
+// Conn returns the connection of the datasource.
+//
+//	Usage:
+//	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqladapter.Conn(ctx.SQL)}), &gorm.Config{})
+func Conn(db DB) *ConnPool {
+	return &ConnPool{db: db}
+}
+
+func (c *ConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.db.ExecContext(ctx, query, args...)
+}
+
+func (c *ConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(ctx, query, args...)
+}
+
+func (c *ConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.db.QueryRowContext(ctx, query, args...)
+}
+
+// PrepareContext prepares the statement, with the context if the datasource supports it.
+func (c *ConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	if p, ok := c.db.(interface {
+		PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	}); ok {
+		return p.PrepareContext(ctx, query)
+	}
+
+	return c.db.Prepare(query)
+}
+
+// BeginTx starts a transaction on the datasource.
+func (c *ConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	b, ok := c.db.(interface {
+		BeginTx(ctx context.Context, opts *sql.TxOptions) (*gofrSQL.Tx, error)
+	})
+	if !ok {
+		return nil, errNotBeginner
+	}
+
+	tx, err := b.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.Tx, nil
+}
+
+// GetDBConn returns the pool of connections of the datasource, which must not be closed.
+func (c *ConnPool) GetDBConn() (*sql.DB, error) {
+	u, ok := c.db.(interface{ Unwrap() *sql.DB })
+	if !ok {
+		return nil, errNotUnwrapper
+	}
+
+	pool := u.Unwrap()
+	if pool == nil {
+		return nil, errNoDatasource
+	}
+
+	return pool, nil
+}
+
+// Queries returns the queries generated by sqlc over the datasource.
+//
+//	Usage:
+//	queries, err := sqladapter.Queries(ctx.SQL, orders.New)
+//	if err != nil {
+//		return nil, err
+//	}
+//
+//	return queries.ListOrders(ctx)
+func Queries[D, Q any](db DB, newQueries func(D) Q) (Q, error) {
+	var q Q
+
+	if isNil(db) {
+		return q, errNoDatasource
+	}
+
+	dbtx, ok := any(Conn(db)).(D)
+	if !ok {
+		return q, fmt.Errorf("%w, like with the pgx driver of sqlc", errIncompatibleQueries)
+	}
+
+	return newQueries(dbtx), nil
+}
+
+func isNil(i interface{}) bool {
+	val := reflect.ValueOf(i)
+
+	return !val.IsValid() || (val.Kind() == reflect.Pointer && val.IsNil())
+}
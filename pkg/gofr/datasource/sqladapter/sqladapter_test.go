@@ -0,0 +1,140 @@
+package sqladapter
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+// DBTX and orderQueries are like the code generated by sqlc for database/sql.
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+type orderQueries struct {
+	db DBTX
+}
+
+func newOrderQueries(db DBTX) *orderQueries {
+	return &orderQueries{db: db}
+}
+
+func (q *orderQueries) createOrder(ctx context.Context, id int) error {
+	_, err := q.db.ExecContext(ctx, "INSERT INTO orders (id) VALUES (?)", id)
+	return err
+}
+
+func (q *orderQueries) countOrders(ctx context.Context) (int, error) {
+	var n int
+	err := q.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM orders").Scan(&n)
+
+	return n, err
+}
+
+// pgxDBTX is like the DBTX generated by sqlc for pgx, which the connections of database/sql do not implement.
+type pgxDBTX interface {
+	SendBatch(ctx context.Context) error
+}
+
+func newSQLite(t *testing.T) *container.Container {
+	t.Helper()
+
+	c := container.NewContainer(config.NewMockConfig(map[string]string{
+		"DB_DIALECT": "sqlite",
+		"DB_NAME":    filepath.Join(t.TempDir(), "app.db"),
+	}))
+
+	t.Cleanup(func() { c.Close() })
+
+	_, err := c.SQL.Exec("CREATE TABLE orders (id INTEGER)")
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestQueries(t *testing.T) {
+	c := newSQLite(t)
+	ctx := context.Background()
+
+	queries, err := Queries(c.SQL, newOrderQueries)
+	require.NoError(t, err)
+	require.NoError(t, queries.createOrder(ctx, 1))
+
+	tx, err := c.SQL.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	txQueries, err := Queries(tx, newOrderQueries)
+	require.NoError(t, err)
+	require.NoError(t, txQueries.createOrder(ctx, 2))
+	require.NoError(t, tx.Rollback())
+
+	n, err := queries.countOrders(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestQueries_Errors(t *testing.T) {
+	c := newSQLite(t)
+
+	_, err := Queries(c.SQL, func(pgxDBTX) *orderQueries { return nil })
+	require.ErrorIs(t, err, errIncompatibleQueries)
+
+	var db *gofrSQL.DB
+
+	_, err = Queries(db, newOrderQueries)
+	require.ErrorIs(t, err, errNoDatasource)
+
+	_, err = Queries(nil, newOrderQueries)
+	require.ErrorIs(t, err, errNoDatasource)
+}
+
+func TestConnPool(t *testing.T) {
+	c := newSQLite(t)
+	ctx := context.Background()
+
+	conn := Conn(c.SQL)
+
+	stmt, err := conn.PrepareContext(ctx, "INSERT INTO orders (id) VALUES (?)")
+	require.NoError(t, err)
+
+	_, err = stmt.ExecContext(ctx, 1)
+	require.NoError(t, err)
+	require.NoError(t, stmt.Close())
+
+	tx, err := conn.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO orders (id) VALUES (2)")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	pool, err := conn.GetDBConn()
+	require.NoError(t, err)
+
+	var n int
+
+	require.NoError(t, pool.QueryRowContext(ctx, "SELECT COUNT(*) FROM orders").Scan(&n))
+	assert.Equal(t, 2, n)
+
+	gofrTx, err := c.SQL.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	defer gofrTx.Rollback()
+
+	_, err = Conn(gofrTx).BeginTx(ctx, nil)
+	require.ErrorIs(t, err, errNotBeginner)
+
+	_, err = Conn(gofrTx).GetDBConn()
+	require.ErrorIs(t, err, errNotUnwrapper)
+}
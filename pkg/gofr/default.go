@@ -1,7 +1,14 @@
 package gofr
 
+import "time"
+
 const (
 	defaultHTTPPort   = 8000
 	defaultGRPCPort   = 9000
 	defaultMetricPort = 2121
+
+	defaultLivenessPath  = "/.well-known/alive"
+	defaultReadinessPath = "/.well-known/ready"
+
+	defaultStartupWaitTimeout = time.Minute
 )
@@ -0,0 +1,225 @@
+// Package devmode rebuilds and restarts an application in development when its code or its configs change.
+package devmode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultInterval    = 500 * time.Millisecond
+	defaultStopTimeout = 10 * time.Second
+)
+
+// Output writes the status of the application, like its restarts and its build errors.
+type Output interface {
+	Info(format string, args ...interface{})
+	Error(format string, args ...interface{})
+}
+
+// Config configures the running application.
+type Config struct {
+	// Dir is the directory of the application, whose files are watched, and in which it is built and run.
+	Dir string
+	// Package is the package of the main function of the application, "." by default.
+	Package string
+	// Args are the arguments of the application.
+	Args []string
+	// Interval is the interval at which the files are checked for changes, 500ms by default.
+	Interval time.Duration
+	// StopTimeout is the time the application is given to shut down before it is killed, 10s by default.
+	StopTimeout time.Duration
+}
+
+// Run builds and runs the application, and rebuilds or restarts it on the changes until ctx is done.
+func Run(ctx context.Context, cfg Config, out Output) error {
+	cfg = withDefaults(cfg)
+
+	tmp, err := os.MkdirTemp("", "gofr-dev-")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(tmp)
+
+	r := &runner{cfg: cfg, out: out, binary: filepath.Join(tmp, "app")}
+	if runtime.GOOS == "windows" {
+		r.binary += ".exe"
+	}
+
+	defer r.stop()
+
+	files, err := snapshot(cfg.Dir)
+	if err != nil {
+		return err
+	}
+
+	r.apply(ctx, changeRebuild)
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := snapshot(cfg.Dir)
+		if err != nil {
+			out.Error("could not watch the files: %v", err)
+			continue
+		}
+
+		changed := diff(files, current)
+		files = current
+
+		if len(changed) > 0 {
+			r.apply(ctx, classifyAll(changed))
+		}
+	}
+}
+
+func withDefaults(cfg Config) Config {
+	if cfg.Dir == "" {
+		cfg.Dir = "."
+	}
+
+	if cfg.Package == "" {
+		cfg.Package = "."
+	}
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+
+	if cfg.StopTimeout <= 0 {
+		cfg.StopTimeout = defaultStopTimeout
+	}
+
+	return cfg
+}
+
+// runner builds and runs the application.
+type runner struct {
+	cfg    Config
+	out    Output
+	binary string
+
+	cmd    *exec.Cmd
+	exited chan struct{}
+	// built reports whether the application is built, so that it is not restarted from a build which failed.
+	built bool
+}
+
+// apply rebuilds and restarts the application, or restarts it, according to the change.
+func (r *runner) apply(ctx context.Context, c change) {
+	switch c {
+	case changeNone:
+		return
+	case changeRebuild:
+		r.out.Info("building the application")
+
+		if err := r.build(ctx); err != nil {
+			r.out.Error("could not build the application, the previous build keeps running:\n%v", err)
+			return
+		}
+
+		r.built = true
+	case changeRestart:
+		if !r.built {
+			return
+		}
+	}
+
+	r.stop()
+
+	if err := r.start(); err != nil {
+		r.out.Error("could not start the application: %v", err)
+		return
+	}
+
+	r.out.Info("application is running")
+}
+
+func (r *runner) build(ctx context.Context) error {
+	var output bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", r.binary, r.cfg.Package)
+	cmd.Dir = r.cfg.Dir
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		if output.Len() > 0 {
+			return errors.New(output.String())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *runner) start() error {
+	cmd := exec.Command(r.binary, r.cfg.Args...)
+	cmd.Dir = r.cfg.Dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+
+	if os.Getenv("APP_ENV") == "" {
+		cmd.Env = append(cmd.Env, "APP_ENV=dev")
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	exited := make(chan struct{})
+
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+
+	r.cmd, r.exited = cmd, exited
+
+	return nil
+}
+
+// stop stops the running application with SIGTERM, and kills it after the stop timeout.
+func (r *runner) stop() {
+	if r.cmd == nil {
+		return
+	}
+
+	defer func() { r.cmd, r.exited = nil, nil }()
+
+	select {
+	case <-r.exited:
+		return
+	default:
+	}
+
+	if err := r.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		_ = r.cmd.Process.Kill()
+	}
+
+	select {
+	case <-r.exited:
+	case <-time.After(r.cfg.StopTimeout):
+		r.out.Error("application did not stop within %v, killing it", r.cfg.StopTimeout)
+
+		_ = r.cmd.Process.Kill()
+		<-r.exited
+	}
+}
@@ -0,0 +1,118 @@
+package devmode
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testApp is an application which appends its version and its APP_ENV to the "starts" file when it starts, and runs
+// until it receives SIGTERM.
+const testApp = `package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	f, _ := os.OpenFile("starts", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	f.WriteString("%s " + os.Getenv("APP_ENV") + "\n")
+	f.Close()
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGTERM)
+	<-ch
+}
+`
+
+type testOutput struct {
+	mu     sync.Mutex
+	errors []string
+}
+
+func (*testOutput) Info(string, ...interface{}) {}
+
+func (o *testOutput) Error(format string, args ...interface{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.errors = append(o.errors, fmt.Sprintf(format, args...))
+}
+
+func (o *testOutput) count() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return len(o.errors)
+}
+
+func TestRun(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+
+	t.Setenv("APP_ENV", "")
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	starts := func() string {
+		b, _ := os.ReadFile(filepath.Join(dir, "starts"))
+		return string(b)
+	}
+
+	write("go.mod", "module devapp\n\ngo 1.21\n")
+	write("main.go", fmt.Sprintf(testApp, "v1"))
+	write("configs/.env", "A=1\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &testOutput{}
+	done := make(chan error, 1)
+
+	go func() {
+		done <- Run(ctx, Config{Dir: dir, Interval: 20 * time.Millisecond}, out)
+	}()
+
+	waitFor := func(expected string) {
+		require.Eventually(t, func() bool { return starts() == expected }, 60*time.Second, 20*time.Millisecond,
+			"starts: %q", starts())
+	}
+
+	waitFor("v1 dev\n")
+
+	// the source code is changed, which rebuilds the application.
+	write("main.go", fmt.Sprintf(testApp, "v2"))
+	waitFor("v1 dev\nv2 dev\n")
+
+	// a build error keeps the previous build running.
+	write("main.go", "package main\n\nfunc main() {")
+	require.Eventually(t, func() bool { return out.count() == 1 }, 60*time.Second, 20*time.Millisecond)
+
+	// the configs are changed, which restarts the application without rebuilding it.
+	write("configs/.env", "A=2\n")
+	waitFor("v1 dev\nv2 dev\nv2 dev\n")
+
+	// the templates are reloaded in place by the application.
+	write("templates/home.html", "<p></p>")
+	time.Sleep(100 * time.Millisecond)
+
+	cancel()
+
+	require.NoError(t, <-done)
+	assert.Equal(t, 3, strings.Count(starts(), "\n"))
+	assert.Contains(t, out.errors[0], "could not build the application")
+}
@@ -0,0 +1,125 @@
+package devmode
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// change is the kind of the changes of the files, which decides how they are applied to the running application.
+type change int
+
+const (
+	// changeNone is a change of the files which the application reloads in place, like its templates.
+	changeNone change = iota
+	// changeRestart is a change of the configs, which are read when the application starts.
+	changeRestart
+	// changeRebuild is a change of the source code.
+	changeRebuild
+)
+
+// fileState is the state of a watched file, which is changed when its modification time or its size is.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshot returns the states of the watched files of the directory.
+func snapshot(dir string) (map[string]fileState, error) {
+	files := make(map[string]fileState)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != dir && skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || classify(rel) == changeNone && !isTemplate(rel) {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		files[rel] = fileState{modTime: info.ModTime(), size: info.Size()}
+
+		return nil
+	})
+
+	return files, err
+}
+
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules" || name == "testdata"
+}
+
+// diff returns the files which are added, modified or removed between the snapshots.
+func diff(previous, current map[string]fileState) []string {
+	var changed []string
+
+	for name, state := range current {
+		if p, ok := previous[name]; !ok || p != state {
+			changed = append(changed, name)
+		}
+	}
+
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+
+	return changed
+}
+
+// classify returns the kind of the change of the file of the path, relative to the watched directory.
+func classify(path string) change {
+	base := filepath.Base(path)
+
+	switch {
+	case strings.HasSuffix(base, "_test.go"):
+		return changeNone
+	case strings.HasSuffix(base, ".go"), base == "go.mod", base == "go.sum":
+		return changeRebuild
+	case base == ".env" || strings.HasSuffix(base, ".env") || isConfigDir(path):
+		return changeRestart
+	}
+
+	return changeNone
+}
+
+// isConfigDir reports whether the file is in the configs directory of the application.
+func isConfigDir(path string) bool {
+	return strings.HasPrefix(filepath.ToSlash(path), "configs/")
+}
+
+// isTemplate reports whether the file is a template, which the application reloads in place in dev mode.
+func isTemplate(path string) bool {
+	ext := filepath.Ext(path)
+
+	return ext == ".html" || ext == ".tmpl" || ext == ".gohtml"
+}
+
+// classifyAll returns the strongest kind of the changes of the files.
+func classifyAll(paths []string) change {
+	c := changeNone
+
+	for _, p := range paths {
+		if k := classify(p); k > c {
+			c = k
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,72 @@
+package devmode
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	testCases := []struct {
+		path   string
+		change change
+	}{
+		{"main.go", changeRebuild},
+		{"handlers/order.go", changeRebuild},
+		{"go.mod", changeRebuild},
+		{"handlers/order_test.go", changeNone},
+		{"configs/.env", changeRestart},
+		{"configs/.dev.env", changeRestart},
+		{"configs/settings.yaml", changeRestart},
+		{"templates/home.html", changeNone},
+		{"README.md", changeNone},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.change, classify(tc.path), "TEST[%d], Failed.\n%s", i, tc.path)
+	}
+
+	assert.Equal(t, changeRebuild, classifyAll([]string{"configs/.env", "main.go", "templates/home.html"}))
+	assert.Equal(t, changeNone, classifyAll([]string{"templates/home.html"}))
+}
+
+func TestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"main.go", "configs/.env", "templates/home.html", "README.md", ".git/HEAD",
+		"vendor/lib/lib.go"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("v1"), 0600))
+	}
+
+	previous, err := snapshot(dir)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(previous))
+	for name := range previous {
+		names = append(names, filepath.ToSlash(name))
+	}
+
+	sort.Strings(names)
+
+	assert.Equal(t, []string{"configs/.env", "main.go", "templates/home.html"}, names)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("v2"), 0600))
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "main.go"), time.Now(), time.Now().Add(time.Second)))
+	require.NoError(t, os.Remove(filepath.Join(dir, "configs", ".env")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "handler.go"), []byte("v1"), 0600))
+
+	current, err := snapshot(dir)
+	require.NoError(t, err)
+
+	changed := diff(previous, current)
+	sort.Strings(changed)
+
+	assert.Equal(t, []string{filepath.Join("configs", ".env"), "handler.go", "main.go"}, changed)
+	assert.Empty(t, diff(current, current))
+}
@@ -0,0 +1,26 @@
+package gofr
+
+import (
+	"context"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/crypto"
+)
+
+// keyringUser is implemented by the SQL database, which encrypts the fields tagged with encrypt:"aes-gcm".
+type keyringUser interface {
+	UseKeyring(k *crypto.Keyring)
+}
+
+// UseKeyProvider loads the keys of the encrypted fields from the provider, like a KMS, instead of ENCRYPTION_KEYS.
+func (a *App) UseKeyProvider(p crypto.KeyProvider) {
+	keyring, err := crypto.Load(context.Background(), p)
+	if err != nil {
+		a.container.Errorf("could not load the encryption keys: %v", err)
+
+		return
+	}
+
+	if db, ok := a.container.SQL.(keyringUser); ok {
+		db.UseKeyring(keyring)
+	}
+}
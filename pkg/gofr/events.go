@@ -0,0 +1,70 @@
+package gofr
+
+import (
+	"fmt"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+)
+
+// Event is an event of an aggregate of the event store, whose data is read using Bind.
+type Event = container.Event
+
+// AnyVersion is the expected version with which the events are appended whatever the version of the aggregate is.
+const AnyVersion = container.AnyVersion
+
+// ErrVersionConflict is returned when events are appended to an aggregate whose version is not the expected one.
+var ErrVersionConflict = container.ErrVersionConflict
+
+// NewEvent returns an event of the type with the data marshalled as JSON.
+func NewEvent(eventType string, data interface{}) (Event, error) {
+	return container.NewEvent(eventType, data)
+}
+
+// Projection builds a read model from the events appended to the event store.
+type Projection func(ctx *Context, event Event) error
+
+type projection struct {
+	handle Projection
+	types  map[string]bool
+}
+
+// AddProjection adds a projection of the events of the types, or of all the events. The projections must be idempotent.
+func (a *App) AddProjection(handle Projection, eventTypes ...string) {
+	p := projection{handle: handle}
+
+	if len(eventTypes) > 0 {
+		p.types = make(map[string]bool, len(eventTypes))
+
+		for _, t := range eventTypes {
+			p.types[t] = true
+		}
+	}
+
+	if len(a.projections) == 0 {
+		a.Subscribe(a.Config.GetOrDefault("EVENTSTORE_TOPIC", container.DefaultEventTopic), a.project)
+	}
+
+	a.projections = append(a.projections, p)
+}
+
+// project runs the projections of the event of the message.
+func (a *App) project(ctx *Context) error {
+	var e Event
+
+	if err := ctx.Bind(&e); err != nil {
+		return err
+	}
+
+	for _, p := range a.projections {
+		if p.types != nil && !p.types[e.Type] {
+			continue
+		}
+
+		if err := p.handle(ctx, e); err != nil {
+			return fmt.Errorf("projection of event %s of version %d of aggregate %s failed: %w", e.Type, e.Version,
+				e.AggregateID, err)
+		}
+	}
+
+	return nil
+}
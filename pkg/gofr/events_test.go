@@ -0,0 +1,70 @@
+package gofr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+var errProjection = errors.New("read model unavailable")
+
+func TestApp_AddProjection(t *testing.T) {
+	conf := config.NewMockConfig(map[string]string{"PUBSUB_BACKEND": "MEMORY", "EVENTSTORE_TOPIC": "order-events"})
+	c := container.NewContainer(conf)
+
+	a := &App{Config: conf, container: c, subscriptionManager: newSubscriptionManager(c)}
+
+	var projected []string
+
+	project := func(name string, err error) Projection {
+		return func(_ *Context, e Event) error {
+			projected = append(projected, name+":"+e.Type)
+
+			return err
+		}
+	}
+
+	a.AddProjection(project("totals", nil), "OrderPaid")
+	a.AddProjection(project("history", nil))
+	a.AddProjection(project("search", errProjection), "OrderShipped")
+
+	assert.Contains(t, a.subscriptionManager.subscriptions, "order-events")
+
+	testCases := []struct {
+		eventType string
+		projected []string
+		err       error
+	}{
+		{"OrderPlaced", []string{"history:OrderPlaced"}, nil},
+		{"OrderPaid", []string{"totals:OrderPaid", "history:OrderPaid"}, nil},
+		{"OrderShipped", []string{"history:OrderShipped", "search:OrderShipped"}, errProjection},
+	}
+
+	for i, tc := range testCases {
+		projected = nil
+
+		msg := pubsub.NewMessage(context.Background())
+		msg.Value, _ = json.Marshal(Event{AggregateID: "order-1", Version: 1, Type: tc.eventType})
+
+		err := a.project(newContext(nil, msg, c))
+
+		assert.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.eventType)
+		assert.Equal(t, tc.projected, projected, "TEST[%d], Failed.\n%s", i, tc.eventType)
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	e, err := NewEvent("OrderPlaced", map[string]int{"amount": 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, "OrderPlaced", e.Type)
+	assert.JSONEq(t, `{"amount":10}`, string(e.Data))
+}
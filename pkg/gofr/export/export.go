@@ -0,0 +1,244 @@
+// Package export streams CSV, XLSX and PDF files in the responses of the handlers.
+package export
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/http/response"
+)
+
+// The content types of the files.
+const (
+	CSVContentType  = "text/csv; charset=utf-8"
+	XLSXContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	PDFContentType  = "application/pdf"
+)
+
+var errUnsupportedRows = errors.New("rows must be a slice or a channel of structs, or *sql.Rows")
+
+// CSV responds with the rows, a slice or a channel of structs, or *sql.Rows, as a CSV file.
+//
+//	Usage:
+//	rows, err := ctx.SQL.QueryContext(ctx, "SELECT id, name, total FROM orders")
+//	if err != nil {
+//		return nil, err
+//	}
+//
+//	return export.CSV("orders.csv", rows), nil
+func CSV(name string, rows interface{}) response.File {
+	return stream(name, CSVContentType, func(w io.Writer) error {
+		t, err := newTable(rows)
+		if err != nil {
+			return err
+		}
+
+		defer t.close()
+
+		return writeCSV(w, t)
+	})
+}
+
+// stream returns the file written by write while it is read.
+func stream(name, contentType string, write func(w io.Writer) error) response.File {
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(write(pw))
+	}()
+
+	return response.File{Reader: pr, ContentType: contentType, Name: name}
+}
+
+func writeCSV(w io.Writer, t *table) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(t.header); err != nil {
+		return err
+	}
+
+	row := make([]string, len(t.header))
+
+	for {
+		values, ok, err := t.next()
+		if err != nil || !ok {
+			cw.Flush()
+
+			if err != nil {
+				return err
+			}
+
+			return cw.Error()
+		}
+
+		for i, v := range values {
+			row[i] = formatValue(v)
+		}
+
+		if err = cw.Write(row); err != nil {
+			return err
+		}
+	}
+}
+
+// table iterates over the rows of the export.
+type table struct {
+	header []string
+	// next returns the values of the next row, and false once there are no more rows.
+	next  func() ([]interface{}, bool, error)
+	close func()
+}
+
+func newTable(rows interface{}) (*table, error) {
+	if r, ok := rows.(*sql.Rows); ok {
+		return newSQLTable(r)
+	}
+
+	v := reflect.ValueOf(rows)
+
+	switch v.Kind() { //nolint:exhaustive // the other kinds are not supported.
+	case reflect.Slice, reflect.Array:
+		i := 0
+
+		return newStructTable(v.Type().Elem(), func() (reflect.Value, bool) {
+			if i >= v.Len() {
+				return reflect.Value{}, false
+			}
+
+			i++
+
+			return v.Index(i - 1), true
+		})
+	case reflect.Chan:
+		return newStructTable(v.Type().Elem(), v.Recv)
+	default:
+		return nil, errUnsupportedRows
+	}
+}
+
+// newStructTable returns the table of the structs returned by next, whose columns are the exported fields of the type.
+func newStructTable(t reflect.Type, next func() (reflect.Value, bool)) (*table, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, errUnsupportedRows
+	}
+
+	var (
+		fields []int
+		header []string
+	)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, i)
+		header = append(header, name)
+	}
+
+	values := make([]interface{}, len(fields))
+
+	return &table{
+		header: header,
+		next: func() ([]interface{}, bool, error) {
+			v, ok := next()
+			if !ok {
+				return nil, false, nil
+			}
+
+			v = reflect.Indirect(v)
+
+			for i, f := range fields {
+				values[i] = nil
+
+				if v.IsValid() {
+					values[i] = v.Field(f).Interface()
+				}
+			}
+
+			return values, true, nil
+		},
+		close: func() {},
+	}, nil
+}
+
+func newSQLTable(rows *sql.Rows) (*table, error) {
+	header, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+
+		return nil, err
+	}
+
+	values := make([]interface{}, len(header))
+	dest := make([]interface{}, len(header))
+
+	for i := range dest {
+		dest[i] = &values[i]
+	}
+
+	return &table{
+		header: header,
+		next: func() ([]interface{}, bool, error) {
+			if !rows.Next() {
+				return nil, false, rows.Err()
+			}
+
+			if err := rows.Scan(dest...); err != nil {
+				return nil, false, err
+			}
+
+			return values, true, nil
+		},
+		close: func() { rows.Close() },
+	}, nil
+}
+
+// formatValue formats the value of a cell, the times in RFC 3339 and the nil values as empty cells.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+
+		return v.Format(time.RFC3339)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return ""
+		}
+
+		return formatValue(rv.Elem().Interface())
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,105 @@
+package export
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type order struct {
+	ID       int        `json:"id"`
+	Customer string     `json:"customer"`
+	Total    float64    `json:"total"`
+	Shipped  *time.Time `json:"shipped"`
+	internal string
+	Secret   string `json:"-"`
+}
+
+func testOrders() []order {
+	shipped := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	return []order{
+		{ID: 1, Customer: `Smith, "Jo"`, Total: 9.5, Shipped: &shipped, internal: "x", Secret: "y"},
+		{ID: 2, Customer: "Doe", Total: 20},
+	}
+}
+
+func TestCSV(t *testing.T) {
+	const expected = "id,customer,total,shipped\n1,\"Smith, \"\"Jo\"\"\",9.5,2024-01-02T03:04:05Z\n2,Doe,20,\n"
+
+	orders := testOrders()
+
+	ch := make(chan *order, len(orders))
+	for i := range orders {
+		ch <- &orders[i]
+	}
+
+	close(ch)
+
+	testCases := []struct {
+		desc string
+		rows interface{}
+	}{
+		{"slice of structs", testOrders()},
+		{"channel of pointers to structs", ch},
+	}
+
+	for i, tc := range testCases {
+		f := CSV("orders.csv", tc.rows)
+
+		content, err := io.ReadAll(f.Reader)
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, expected, string(content), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, CSVContentType, f.ContentType, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "orders.csv", f.Name, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestCSV_SQLRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM customers").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, []byte("Smith")).AddRow(2, nil))
+
+	rows, err := db.Query("SELECT id, name FROM customers")
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(CSV("customers.csv", rows).Reader)
+	require.NoError(t, err)
+
+	assert.Equal(t, "id,name\n1,Smith\n2,\n", string(content))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCSV_UnsupportedRows(t *testing.T) {
+	_, err := io.ReadAll(CSV("orders.csv", []int{1, 2}).Reader)
+
+	assert.ErrorIs(t, err, errUnsupportedRows)
+}
+
+func TestCSV_ClosedByClient(t *testing.T) {
+	rows := make(chan order)
+	f := CSV("orders.csv", rows)
+
+	require.NoError(t, f.Reader.(io.Closer).Close())
+
+	// the rows are written to the pipe once the buffer of the writer is full, which fails once the client closed it, so
+	// that the rows are no longer read.
+	for i := 0; i < 100000; i++ {
+		select {
+		case rows <- order{ID: i, Customer: "Smith"}:
+		case <-time.After(100 * time.Millisecond):
+			return
+		}
+	}
+
+	t.Error("the rows are read after the file is closed")
+}
@@ -0,0 +1,162 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/response"
+)
+
+const defaultWkhtmltopdfPath = "wkhtmltopdf"
+
+var errNoGotenbergURL = errors.New("GOTENBERG_URL is required by the gotenberg PDF_CONVERTER")
+
+// Converter converts the HTML of the pages to PDF.
+type Converter interface {
+	// Convert writes the PDF of the HTML to pdf while it is converted.
+	Convert(ctx context.Context, html io.Reader, pdf io.Writer) error
+}
+
+// PDF responds with the PDF file converted from the HTML by the converter.
+func PDF(ctx context.Context, name string, converter Converter, html io.Reader) response.File {
+	return stream(name, PDFContentType, func(w io.Writer) error {
+		return converter.Convert(ctx, html, w)
+	})
+}
+
+// Gotenberg converts the HTML to PDF with a Gotenberg server.
+type Gotenberg struct {
+	// URL is the URL of the server, like http://gotenberg:3000.
+	URL string
+	// Client is the client of the requests, which is http.DefaultClient by default.
+	Client *http.Client
+}
+
+// ErrorConversion is returned for the HTML which is not converted by the converter.
+type ErrorConversion struct {
+	Status  int
+	Message string
+}
+
+func (e ErrorConversion) Error() string {
+	return fmt.Sprintf("could not convert the HTML to PDF, status %d: %s", e.Status, e.Message)
+}
+
+func (ErrorConversion) StatusCode() int {
+	return http.StatusBadGateway
+}
+
+func (g Gotenberg) Convert(ctx context.Context, html io.Reader, pdf io.Writer) error {
+	body, contentType, err := multipartHTML(html)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(g.URL, "/")+"/forms/chromium/convert/html",
+		body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	client := g.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+
+		return ErrorConversion{Status: resp.StatusCode, Message: strings.TrimSpace(string(msg))}
+	}
+
+	_, err = io.Copy(pdf, resp.Body)
+
+	return err
+}
+
+// multipartHTML returns the form of the HTML as the index.html file converted by Gotenberg.
+func multipartHTML(html io.Reader) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	part, err := w.CreateFormFile("files", "index.html")
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err = io.Copy(part, html); err != nil {
+		return nil, "", err
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return body, w.FormDataContentType(), nil
+}
+
+// Command converts the HTML to PDF with a command, which is wkhtmltopdf by default.
+type Command struct {
+	// Path is the path of the command, which is wkhtmltopdf by default.
+	Path string
+	// Args are the arguments of the command, which are "--quiet - -" for wkhtmltopdf by default.
+	Args []string
+}
+
+func (c Command) Convert(ctx context.Context, html io.Reader, pdf io.Writer) error {
+	path, args := c.Path, c.Args
+	if path == "" {
+		path = defaultWkhtmltopdfPath
+	}
+
+	if args == nil {
+		args = []string{"--quiet", "-", "-"}
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = html, pdf, &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w: %s", path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// ConverterFromConfig returns the converter of PDF_CONVERTER, gotenberg or wkhtmltopdf, or nil.
+func ConverterFromConfig(conf config.Config) (Converter, error) {
+	switch c := conf.Get("PDF_CONVERTER"); c {
+	case "":
+		return nil, nil
+	case "gotenberg":
+		url := conf.Get("GOTENBERG_URL")
+		if url == "" {
+			return nil, errNoGotenbergURL
+		}
+
+		return Gotenberg{URL: url}, nil
+	case "wkhtmltopdf":
+		return Command{Path: conf.GetOrDefault("WKHTMLTOPDF_PATH", defaultWkhtmltopdfPath)}, nil
+	default:
+		return nil, fmt.Errorf("unknown PDF_CONVERTER %q, either gotenberg or wkhtmltopdf", c)
+	}
+}
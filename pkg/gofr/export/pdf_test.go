@@ -0,0 +1,85 @@
+package export
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+func TestPDF_Gotenberg(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/forms/chromium/convert/html", r.URL.Path)
+
+		file, header, err := r.FormFile("files")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		html, _ := io.ReadAll(file)
+
+		if string(html) == "invalid" {
+			http.Error(w, "Invalid HTML", http.StatusBadRequest)
+
+			return
+		}
+
+		assert.Equal(t, "index.html", header.Filename)
+
+		_, _ = w.Write([]byte("%PDF-" + string(html)))
+	}))
+	defer server.Close()
+
+	g := Gotenberg{URL: server.URL + "/"}
+
+	f := PDF(context.Background(), "invoice.pdf", g, strings.NewReader("<h1>Invoice</h1>"))
+
+	content, err := io.ReadAll(f.Reader)
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-<h1>Invoice</h1>", string(content))
+	assert.Equal(t, PDFContentType, f.ContentType)
+
+	_, err = io.ReadAll(PDF(context.Background(), "invoice.pdf", g, strings.NewReader("invalid")).Reader)
+	assert.Equal(t, ErrorConversion{Status: http.StatusBadRequest, Message: "Invalid HTML"}, err)
+}
+
+func TestPDF_Command(t *testing.T) {
+	content, err := io.ReadAll(PDF(context.Background(), "invoice.pdf", Command{Path: "cat", Args: []string{}},
+		strings.NewReader("<h1>Invoice</h1>")).Reader)
+
+	require.NoError(t, err)
+	assert.Equal(t, "<h1>Invoice</h1>", string(content))
+
+	_, err = io.ReadAll(PDF(context.Background(), "invoice.pdf", Command{Path: "false"}, strings.NewReader("")).Reader)
+	assert.Error(t, err)
+}
+
+func TestConverterFromConfig(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		configs   map[string]string
+		converter Converter
+		hasErr    bool
+	}{
+		{"not configured", nil, nil, false},
+		{"gotenberg", map[string]string{"PDF_CONVERTER": "gotenberg", "GOTENBERG_URL": "http://gotenberg:3000"},
+			Gotenberg{URL: "http://gotenberg:3000"}, false},
+		{"gotenberg without url", map[string]string{"PDF_CONVERTER": "gotenberg"}, nil, true},
+		{"wkhtmltopdf", map[string]string{"PDF_CONVERTER": "wkhtmltopdf"}, Command{Path: "wkhtmltopdf"}, false},
+		{"unknown converter", map[string]string{"PDF_CONVERTER": "chrome"}, nil, true},
+	}
+
+	for i, tc := range testCases {
+		converter, err := ConverterFromConfig(config.NewMockConfig(tc.configs))
+
+		assert.Equal(t, tc.converter, converter, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.hasErr, err != nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
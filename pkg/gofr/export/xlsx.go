@@ -0,0 +1,163 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/http/response"
+)
+
+// The parts of the workbook other than its sheet, which are the same for every export.
+const (
+	xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" ` +
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" ` +
+		`Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = xml.Header +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" ` +
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" ` +
+		`Target="worksheets/sheet1.xml"/></Relationships>`
+)
+
+// XLSX responds with the rows, like those of CSV, as an Excel workbook.
+func XLSX(name string, rows interface{}) response.File {
+	return stream(name, XLSXContentType, func(w io.Writer) error {
+		t, err := newTable(rows)
+		if err != nil {
+			return err
+		}
+
+		defer t.close()
+
+		return writeXLSX(w, t)
+	})
+}
+
+func writeXLSX(w io.Writer, t *table) error {
+	z := zip.NewWriter(w)
+
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	} {
+		f, err := z.Create(part.name)
+		if err != nil {
+			return err
+		}
+
+		if _, err = io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	f, err := z.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+
+	if err = writeSheet(f, t); err != nil {
+		return err
+	}
+
+	return z.Close()
+}
+
+// writeSheet writes the rows of the table as the sheet, buffering the cells so that each is not compressed apart.
+func writeSheet(w io.Writer, t *table) error {
+	b := bufio.NewWriter(w)
+
+	_, _ = b.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<sheetData>`)
+
+	header := make([]interface{}, len(t.header))
+	for i, h := range t.header {
+		header[i] = h
+	}
+
+	writeRow(b, 1, header)
+
+	for n := 2; ; n++ {
+		values, ok, err := t.next()
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			break
+		}
+
+		writeRow(b, n, values)
+	}
+
+	_, _ = b.WriteString(`</sheetData></worksheet>`)
+
+	return b.Flush()
+}
+
+// writeRow writes the row of the number, whose numbers are numeric cells and the other values inline strings.
+func writeRow(b *bufio.Writer, n int, values []interface{}) {
+	row := strconv.Itoa(n)
+
+	_, _ = b.WriteString(`<row r="` + row + `">`)
+
+	for i, v := range values {
+		ref := columnName(i) + row
+
+		if num, ok := number(v); ok {
+			_, _ = b.WriteString(`<c r="` + ref + `"><v>` + num + `</v></c>`)
+
+			continue
+		}
+
+		_, _ = b.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+		_ = xml.EscapeText(b, []byte(formatValue(v)))
+		_, _ = b.WriteString(`</t></is></c>`)
+	}
+
+	_, _ = b.WriteString(`</row>`)
+}
+
+// columnName returns the name of the column of the index, like "A", "Z" or "AA".
+func columnName(i int) string {
+	name := ""
+
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+
+	return name
+}
+
+// number returns the value formatted as a number, if it is a finite one.
+func number(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v), true
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return formatValue(v), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), !math.IsNaN(float64(v)) && !math.IsInf(float64(v), 0)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), !math.IsNaN(v) && !math.IsInf(v, 0)
+	default:
+		return "", false
+	}
+}
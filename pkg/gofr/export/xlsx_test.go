@@ -0,0 +1,61 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXLSX(t *testing.T) {
+	f := XLSX("orders.xlsx", testOrders())
+
+	content, err := io.ReadAll(f.Reader)
+	require.NoError(t, err)
+
+	assert.Equal(t, XLSXContentType, f.ContentType)
+
+	z, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(z.File))
+
+	var sheet []byte
+
+	for _, file := range z.File {
+		names = append(names, file.Name)
+
+		if file.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+
+		r, err := file.Open()
+		require.NoError(t, err)
+
+		sheet, err = io.ReadAll(r)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels",
+		"xl/worksheets/sheet1.xml"}, names)
+	assert.Contains(t, string(sheet), `<c r="D1" t="inlineStr"><is><t xml:space="preserve">shipped</t></is></c>`)
+	assert.Contains(t, string(sheet), `<c r="B2" t="inlineStr"><is><t xml:space="preserve">Smith, &#34;Jo&#34;</t></is></c>`)
+	assert.Contains(t, string(sheet), `<c r="C2"><v>9.5</v></c>`)
+	assert.Contains(t, string(sheet), `<row r="3"><c r="A3"><v>2</v></c>`)
+}
+
+func TestColumnName(t *testing.T) {
+	testCases := []struct {
+		index int
+		name  string
+	}{
+		{0, "A"}, {25, "Z"}, {26, "AA"}, {701, "ZZ"}, {702, "AAA"},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.name, columnName(tc.index), "TEST[%d], Failed.\n%d", i, tc.index)
+	}
+}
@@ -1,6 +1,21 @@
 package gofr
 
-import "github.com/peter-stratton/gofr/pkg/gofr/datasource"
+import (
+	"context"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+// loggerUser and metricsUser are implemented by the datasources which use the logger and the metrics of the app.
+type (
+	loggerUser interface {
+		UseLogger(logger interface{})
+	}
+
+	metricsUser interface {
+		UseMetrics(metrics interface{})
+	}
+)
 
 func (a *App) AddMongo(db datasource.MongoProvider) {
 	db.UseLogger(a.Logger())
@@ -16,3 +31,35 @@ func (a *App) AddMongo(db datasource.MongoProvider) {
 func (a *App) UseMongo(db datasource.Mongo) {
 	a.container.Mongo = db
 }
+
+// AddDatasource adds the datasource to the container with the name, to be retrieved with container.Get. The
+// datasources implementing datasource.Datasource are connected first, and their health is checked with the others.
+//
+//	Usage:
+//	app.AddDatasource("graph", neo4j.New())
+//
+//	func friends(ctx *gofr.Context) (interface{}, error) {
+//		graph, err := container.Get[*neo4j.Client](ctx.Container, "graph")
+//		...
+//	}
+func (a *App) AddDatasource(name string, ds interface{}) {
+	if err := a.container.AddDatasource(name, ds); err != nil {
+		a.container.Errorf("datasource %s is not added: %v", name, err)
+
+		return
+	}
+
+	if l, ok := ds.(loggerUser); ok {
+		l.UseLogger(a.Logger())
+	}
+
+	if m, ok := ds.(metricsUser); ok {
+		m.UseMetrics(a.Metrics())
+	}
+
+	if d, ok := ds.(datasource.Datasource); ok {
+		if err := d.Connect(context.Background(), a.Config); err != nil {
+			a.container.Errorf("could not connect to the datasource %s: %v", name, err)
+		}
+	}
+}
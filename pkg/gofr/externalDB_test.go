@@ -0,0 +1,75 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
+)
+
+var errGraphUnreachable = errors.New("graph is unreachable")
+
+type graphDatasource struct {
+	connectErr error
+
+	logger interface{}
+	host   string
+}
+
+func (g *graphDatasource) UseLogger(logger interface{}) { g.logger = logger }
+
+func (g *graphDatasource) Connect(_ context.Context, conf config.Config) error {
+	g.host = conf.Get("GRAPH_HOST")
+
+	return g.connectErr
+}
+
+func (g *graphDatasource) HealthCheck(context.Context) datasource.Health {
+	if g.connectErr != nil {
+		return datasource.Health{Status: datasource.StatusDown, Details: map[string]interface{}{"error": g.connectErr.Error()}}
+	}
+
+	return datasource.Health{Status: datasource.StatusUp}
+}
+
+func (*graphDatasource) Close() error { return nil }
+
+func TestApp_AddDatasource(t *testing.T) {
+	a := &App{
+		container: &container.Container{Logger: logging.NewMockLogger(logging.DEBUG)},
+		Config:    config.NewMockConfig(map[string]string{"GRAPH_HOST": "localhost:7687"}),
+	}
+
+	graph := &graphDatasource{}
+	a.AddDatasource("graph", graph)
+
+	ds, err := a.container.GetDataSource("graph")
+	require.NoError(t, err)
+
+	assert.Equal(t, graph, ds)
+	assert.Equal(t, "localhost:7687", graph.host)
+	assert.Equal(t, a.Logger(), graph.logger)
+
+	logs := testutil.StderrOutputForFunc(func() {
+		a.container.Logger = logging.NewMockLogger(logging.DEBUG)
+
+		a.AddDatasource("unreachable", &graphDatasource{connectErr: errGraphUnreachable})
+		a.AddDatasource("sql", &graphDatasource{})
+	})
+
+	assert.Contains(t, logs, "could not connect to the datasource unreachable: graph is unreachable")
+	assert.Contains(t, logs, "datasource sql is not added")
+
+	_, err = a.container.GetDataSource("unreachable")
+	require.NoError(t, err, "the datasources which do not connect are added, for their health to report why")
+
+	assert.Equal(t, []string{"unreachable"}, a.container.Ready(context.Background()).Unhealthy())
+}
@@ -0,0 +1,82 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidName is returned by LocalStore for the names which are outside of its directory.
+var ErrInvalidName = errors.New("invalid file name")
+
+// Store is a storage of files named by slash-separated paths.
+type Store interface {
+	Create(ctx context.Context, name string) (io.WriteCloser, error)
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	Remove(ctx context.Context, name string) error
+}
+
+// LocalStore is a Store of the files in a directory of the local file system.
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore returns a Store of the files in the directory, which is created if it does not exist.
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	return &LocalStore{dir: dir}, nil
+}
+
+// Create creates the file of the name, with its parent directories, or truncates it if it exists.
+func (s *LocalStore) Create(_ context.Context, name string) (io.WriteCloser, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	return os.Create(path)
+}
+
+// Open opens the file of the name for reading. The returned reader is an *os.File, which can seek.
+func (s *LocalStore) Open(_ context.Context, name string) (io.ReadCloser, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(path)
+}
+
+// Remove removes the file, or the directory with its files, of the name.
+func (s *LocalStore) Remove(_ context.Context, name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// path returns the path of the file of the name in the directory of the store.
+func (s *LocalStore) path(name string) (string, error) {
+	if !fs.ValidPath(name) || name == "." || strings.Contains(name, `\`) {
+		return "", ErrInvalidName
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(name)), nil
+}
@@ -0,0 +1,51 @@
+package file
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	w, err := store.Create(ctx, "uploads/a/b.txt")
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("content"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	r, err := store.Open(ctx, "uploads/a/b.txt")
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	assert.Equal(t, "content", string(content))
+
+	require.NoError(t, store.Remove(ctx, "uploads/a"))
+	require.NoError(t, store.Remove(ctx, "uploads/a"))
+
+	_, err = store.Open(ctx, "uploads/a/b.txt")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestLocalStore_InvalidName(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	for i, name := range []string{"../a", "/a", ".", `a\..\..\b`, "a/../../b"} {
+		_, err := store.Open(context.Background(), name)
+
+		assert.ErrorIs(t, err, ErrInvalidName, "TEST[%d], Failed.\n%s", i, name)
+	}
+}
@@ -2,9 +2,12 @@ package gofr
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html/template"
 	"net/http"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/zipkin"
 	"go.opentelemetry.io/otel/propagation"
@@ -21,16 +25,25 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/apm"
 	"github.com/peter-stratton/gofr/pkg/gofr/config"
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/gofrerr"
 	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
 	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+	"github.com/peter-stratton/gofr/pkg/gofr/k8s"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
 	"github.com/peter-stratton/gofr/pkg/gofr/metrics"
 	"github.com/peter-stratton/gofr/pkg/gofr/migration"
+	"github.com/peter-stratton/gofr/pkg/gofr/payment"
 	"github.com/peter-stratton/gofr/pkg/gofr/service"
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+	"github.com/peter-stratton/gofr/pkg/gofr/webhook"
 )
 
+const defaultIdempotencyTTL = 24 * time.Hour
+
 // App is the main application in the GoFr framework.
 type App struct {
 	// Config can be used by applications to fetch custom configurations from environment or file.
@@ -49,51 +62,180 @@ type App struct {
 
 	grpcRegistered bool
 	httpRegistered bool
+	// httpPrepared prepares the HTTP routes once, when they are served by Run or Handler.
+	httpPrepared sync.Once
+
+	// mode is the set of the components run by the application, from APP_MODE.
+	mode runMode
+
+	// scopes are the OAuth scopes required by the HTTP routes and the gRPC methods.
+	scopes middleware.Scopes
 
 	subscriptionManager SubscriptionManager
+
+	startupWait *startupWait
+
+	jobs *jobRunner
+
+	webhooks *webhook.Manager
+
+	// projections are the projections of the events of the event store, added by AddProjection.
+	projections []projection
+
+	startupTasks []*startupTask
+
+	// migrations are the migrations given to Migrate, which are reverted by MigrateDown.
+	migrations map[int64]migration.Migrate
+
+	// warmers are run on startup before the application is reported ready, added by AddWarmer.
+	warmers []warmer
+
+	templates *templates
+
+	// assets are the paths of the static files, resolved by the "asset" function of the templates.
+	assets *assets
+
+	// errorRegistry maps the errors returned by the handlers to the statuses and the bodies of the responses.
+	errorRegistry *gofrerr.Registry
+
+	// responseTransformer builds the envelope of the responses, if it is set by SetResponseTransformer.
+	responseTransformer *responseTransformer
+
+	// latencyObjectives are the latency objectives of the routes, recorded by the RED metrics.
+	latencyObjectives *latencyObjectives
+
+	// routePriorities are the priorities of the routes when the load is shed, if LOAD_SHED_* configs are set.
+	routePriorities *routePriorities
+
+	// responseFormats are the formats of the responses of the routes, the first being RESPONSE_FORMAT.
+	responseFormats []string
+
+	// responseTimeLayout is the layout of the times of the JSON responses, set by RESPONSE_TIME_FORMAT.
+	responseTimeLayout string
+
+	// leader elects the leader of the replicas of the application, if it is set by UseLeaderElection.
+	leader *leaderElection
+
+	// quotas returns the quotas of the principals, if they are enabled by EnableQuotas or EnableQuotasWithFunc.
+	quotas middleware.QuotaFunc
+
+	// stopped is closed once Shutdown completes, for Run to return.
+	stopped stopped
+}
+
+// startupWait holds the dependencies the application waits for on startup before reporting itself ready.
+type startupWait struct {
+	timeout      time.Duration
+	dependencies []string
 }
 
 // RegisterService adds a gRPC service to the GoFr application.
 func (a *App) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	a.RegisterGRPCService(desc, impl)
+}
+
+// RegisterGRPCService adds a gRPC service to the GoFr application, served on GRPC_PORT.
+func (a *App) RegisterGRPCService(desc *grpc.ServiceDesc, impl interface{}) {
 	a.container.Logger.Infof("registering GRPC Server: %s", desc.ServiceName)
 	a.grpcServer.server.RegisterService(desc, impl)
 	a.grpcRegistered = true
+
+	if a.grpcServer.transcoding {
+		a.addGRPCTranscoding(desc, impl)
+	}
 }
 
 // New creates an HTTP Server Application and returns that App.
-func New() *App {
-	app := &App{}
-	app.readConfig(false)
-	app.container = container.NewContainer(app.Config)
+//
+//	Usage:
+//	app := gofr.New(gofr.WithConfig(cfg), gofr.WithHTTPPort(9000), gofr.WithoutMetricsServer())
+func New(opts ...Option) *App {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	app := &App{Config: o.config}
+
+	if app.Config == nil {
+		app.readConfig(false)
+	}
+
+	if o.logger != nil {
+		app.container = container.NewContainer(nil)
+		app.container.Logger = o.logger
+		app.container.Create(app.Config)
+	} else {
+		app.container = container.NewContainer(app.Config)
+	}
+
+	setRuntimeLimits(app.Config, app.container.Logger, os.DirFS(cgroupRoot))
 
 	app.initTracer()
 
 	// Metrics Server
-	port, err := strconv.Atoi(app.Config.Get("METRICS_PORT"))
-	if err != nil || port <= 0 {
-		port = defaultMetricPort
+	if !o.withoutMetricsServer {
+		app.metricServer = newMetricServer(portFrom(o.metricsPort, app.Config, "METRICS_PORT", defaultMetricPort))
 	}
 
-	app.metricServer = newMetricServer(port)
-
 	// HTTP Server
-	port, err = strconv.Atoi(app.Config.Get("HTTP_PORT"))
-	if err != nil || port <= 0 {
-		port = defaultHTTPPort
+	var routerOptions []gofrHTTP.RouterOption
+
+	if app.Config.Get("HTTP_ENABLE_ROUTE_TREE") == "true" {
+		routerOptions = append(routerOptions, gofrHTTP.WithRouteTree())
 	}
 
-	app.httpServer = newHTTPServer(app.container, port, middleware.GetConfigs(app.Config))
+	app.httpServer = newHTTPServer(app.container, portFrom(o.httpPort, app.Config, "HTTP_PORT", defaultHTTPPort),
+		middleware.GetConfigs(app.Config), routerOptions...)
+	app.httpServer.listen = app.Config.Get("HTTP_LISTEN")
+
+	app.latencyObjectives = newLatencyObjectives(app.container,
+		app.Config.GetOrDefault("SLO_LATENCY_OBJECTIVE", defaultLatencyObjective.String()))
+	app.httpServer.router.Use(middleware.RED(app.container.Metrics(), app.container.GetAppName(),
+		app.latencyObjectives.objective))
+
+	app.useAdmissionControl()
+
+	if app.container.FaultInjector() != nil {
+		app.httpServer.router.Use(middleware.Chaos())
+	}
 
 	// GRPC Server
-	port, err = strconv.Atoi(app.Config.Get("GRPC_PORT"))
-	if err != nil || port <= 0 {
-		port = defaultGRPCPort
+	var grpcOpts []grpc.ServerOption
+
+	creds, err := grpcTLSCredentials(app.Config)
+	if creds != nil {
+		grpcOpts = append(grpcOpts, grpc.Creds(creds))
 	}
 
-	app.grpcServer = newGRPCServer(app.container, port)
+	app.grpcServer = newGRPCServer(app.container, portFrom(o.grpcPort, app.Config, "GRPC_PORT", defaultGRPCPort), grpcOpts...)
+	app.grpcServer.tlsErr = err
+	app.grpcServer.listen = app.Config.Get("GRPC_LISTEN")
+	app.grpcServer.reflection = app.Config.Get("GRPC_ENABLE_REFLECTION") == "true"
+	app.grpcServer.transcoding = app.Config.Get("GRPC_ENABLE_TRANSCODING") == "true"
+
+	if timeout, err := strconv.Atoi(app.Config.Get("GRPC_REQUEST_TIMEOUT")); err == nil {
+		app.grpcServer.requestTimeout = time.Duration(timeout) * time.Second
+	}
 
 	app.subscriptionManager = newSubscriptionManager(app.container)
 
+	app.responseFormats = responseFormats(app.Config.GetOrDefault("RESPONSE_FORMAT", gofrHTTP.FormatJSON), app.container)
+
+	app.responseTimeLayout = gofrHTTP.TimeLayout(app.Config.Get("RESPONSE_TIME_FORMAT"))
+
+	app.errorRegistry = gofrerr.NewRegistry()
+	app.responseTransformer = &responseTransformer{}
+
+	app.assets = &assets{}
+	app.templates = &templates{
+		funcs:  template.FuncMap{"asset": app.assets.resolve},
+		reload: app.Config.GetOrDefault("TEMPLATE_RELOAD", strconv.FormatBool(app.Config.Get("APP_ENV") == "dev")) == "true",
+	}
+
+	app.mode = app.parseRunMode(app.Config.Get("APP_MODE"))
+
 	return app
 }
 
@@ -103,10 +245,11 @@ func NewCMD() *App {
 	app.readConfig(true)
 
 	app.container = container.NewContainer(nil)
-	app.container.Logger = logging.NewFileLogger(app.Config.Get("CMD_LOGS_FILE"))
+	app.container.Logger = logging.NewFileLogger(app.Config.Get("CMD_LOGS_FILE"), container.LogOptions()...)
 	app.cmd = &cmd{}
 
 	app.container.Create(app.Config)
+	setRuntimeLimits(app.Config, app.container.Logger, os.DirFS(cgroupRoot))
 	app.initTracer()
 
 	return app
@@ -114,8 +257,51 @@ func NewCMD() *App {
 
 // Run starts the application. If it is an HTTP server, it will start the server.
 func (a *App) Run() {
+	if err := a.runStartupTasks(); err != nil {
+		a.container.Errorf("aborting the startup of the application, error: %v", err)
+
+		return
+	}
+
 	if a.cmd != nil {
-		a.cmd.Run(a.container)
+		if code := a.cmd.Run(a.container); code != 0 {
+			exit(code)
+
+			return
+		}
+	}
+
+	a.leader.start()
+
+	a.warmUp(a.waitForDependencies())
+
+	if a.cron != nil && !a.mode.runs(ModeCron) {
+		a.cron.ticker.Stop()
+	}
+
+	if !a.mode.runs(ModeHTTP) {
+		a.handleHealthOnMetricServer()
+	}
+
+	go a.container.MonitorHealth(context.Background())
+
+	a.metricServer.handle(http.MethodGet, "/jobs", jobsHandler(a.container))
+	a.metricServer.handle(http.MethodGet, "/sagas", sagasHandler(a.container))
+	a.metricServer.handle(http.MethodGet, "/sagas/{id}", sagasHandler(a.container))
+	a.metricServer.handle(http.MethodGet, "/audit", auditHandler(a.container))
+
+	if a.cron != nil {
+		a.metricServer.handle(http.MethodGet, "/cron", cronHandler(a.cron))
+		a.metricServer.handle(http.MethodPost, "/cron/{name}/run", cronTriggerHandler(a.cron))
+	}
+
+	if a.webhooks != nil {
+		a.metricServer.handle(http.MethodGet, "/webhooks", webhooksHandler(a.webhooks))
+		a.metricServer.handle(http.MethodPost, "/webhooks/{id}/redeliver", webhookRedeliverHandler(a.webhooks))
+	}
+
+	if a.quotas != nil {
+		a.metricServer.handle(http.MethodGet, "/quotas", quotasHandler(a.container, a.quotas))
 	}
 
 	wg := sync.WaitGroup{}
@@ -130,13 +316,57 @@ func (a *App) Run() {
 	}(a.metricServer)
 
 	// Start HTTP Server
-	if a.httpRegistered {
+	if a.httpRegistered && a.mode.runs(ModeHTTP) {
 		wg.Add(1)
 
+		a.prepareHTTP()
+
+		go func(s *httpServer) {
+			defer wg.Done()
+			s.Run(a.container)
+		}(a.httpServer)
+	}
+
+	// Start GRPC Server only if a service is registered
+	if a.grpcRegistered && a.mode.runs(ModeHTTP) {
+		wg.Add(1)
+
+		go func(s *grpcServer) {
+			defer wg.Done()
+			s.Run(a.container)
+		}(a.grpcServer)
+	}
+
+	// If subscriber is registered, block main go routine to wait for subscriber to receive messages
+	if (len(a.subscriptionManager.subscriptions) != 0 || len(a.subscriptionManager.keyspaceSubscriptions) != 0) &&
+		a.mode.runs(ModeWorker) {
+		a.subscriptionManager.start()
+
+		wg.Add(1)
+	}
+
+	// If jobs are registered, block main go routine to keep running them
+	if a.jobs != nil && a.mode.runs(ModeWorker) {
+		go a.jobs.run(context.Background(), a.container)
+
+		wg.Add(1)
+	}
+
+	go a.handleSignals()
+
+	a.wait(&wg)
+}
+
+// prepareHTTP adds the default routes once the routes of the application are added.
+func (a *App) prepareHTTP() {
+	a.httpPrepared.Do(func() {
 		// Add Default routes
 		a.add(http.MethodGet, "/.well-known/health", healthHandler)
-		a.add(http.MethodGet, "/.well-known/alive", liveHandler)
+		a.add(http.MethodGet, a.Config.GetOrDefault("HEALTH_LIVENESS_PATH", defaultLivenessPath), liveHandler)
+		a.add(http.MethodGet, a.Config.GetOrDefault("HEALTH_READINESS_PATH", defaultReadinessPath), readyHandler)
 		a.add(http.MethodGet, "/favicon.ico", faviconHandler)
+		a.addChaosRoutes()
+		a.httpServer.router.Handle(notificationCallbackPath, a.notificationCallbackHandler()).Methods(http.MethodPost)
 
 		if _, err := os.Stat("./static/openapi.json"); err == nil {
 			a.add(http.MethodGet, "/.well-known/openapi.json", OpenAPIHandler)
@@ -144,9 +374,16 @@ func (a *App) Run() {
 			a.add(http.MethodGet, "/.well-known/{name}", SwaggerUIHandler)
 		}
 
+		// the scopes are checked after the token is validated by the OAuth middleware, which is added before.
+		if len(a.scopes) > 0 {
+			a.httpServer.router.Use(middleware.ScopeAuthorization(a.scopes))
+		}
+
 		a.httpServer.router.PathPrefix("/").Handler(handler{
-			function:  catchAllHandler,
-			container: a.container,
+			function:       catchAllHandler,
+			container:      a.container,
+			errorRegistry:  a.errorRegistry,
+			appTransformer: a.responseTransformer,
 		})
 
 		var registeredMethods []string
@@ -163,34 +400,7 @@ func (a *App) Run() {
 		})
 
 		*a.httpServer.router.RegisteredRoutes = registeredMethods
-
-		go func(s *httpServer) {
-			defer wg.Done()
-			s.Run(a.container)
-		}(a.httpServer)
-	}
-
-	// Start GRPC Server only if a service is registered
-	if a.grpcRegistered {
-		wg.Add(1)
-
-		go func(s *grpcServer) {
-			defer wg.Done()
-			s.Run(a.container)
-		}(a.grpcServer)
-	}
-
-	// If subscriber is registered, block main go routine to wait for subscriber to receive messages
-	if len(a.subscriptionManager.subscriptions) != 0 {
-		// Start subscribers concurrently using go-routines
-		for topic, handler := range a.subscriptionManager.subscriptions {
-			go a.subscriptionManager.startSubscriber(topic, handler)
-		}
-
-		wg.Add(1)
-	}
-
-	wg.Wait()
+	})
 }
 
 // readConfig reads the configuration from the default location.
@@ -219,41 +429,104 @@ func (a *App) AddHTTPService(serviceName, serviceAddress string, options ...serv
 		a.container.Debugf("Service already registered Name: %v", serviceName)
 	}
 
+	// the faults are injected before the options, so that the retries and the circuit breaker handle them.
+	if faults := a.container.FaultInjector(); faults != nil {
+		options = append([]service.Options{&service.FaultInjectionConfig{Injector: faults, Name: serviceName}}, options...)
+	}
+
+	for i, o := range options {
+		if cb, ok := o.(*service.CircuitBreakerConfig); ok && cb.Clock == nil {
+			withClock := *cb
+			withClock.Clock = a.container.Clock()
+			options[i] = &withClock
+		}
+	}
+
 	a.container.Services[serviceName] = service.NewHTTPService(serviceAddress, a.container.Logger, a.container.Metrics(), options...)
 }
 
+// AddGRPCService registers the connection to a gRPC service in container.
+func (a *App) AddGRPCService(serviceName, target string, config *service.GRPCConfig) {
+	if a.container.GRPCServices == nil {
+		a.container.GRPCServices = make(map[string]service.GRPC)
+	}
+
+	if _, ok := a.container.GRPCServices[serviceName]; ok {
+		a.container.Debugf("Service already registered Name: %v", serviceName)
+	}
+
+	svc, err := service.NewGRPCService(target, a.container.Logger, a.container.Metrics(), config)
+	if err != nil {
+		a.container.Errorf("could not register gRPC service %v at %v: %v", serviceName, target, err)
+
+		return
+	}
+
+	a.container.GRPCServices[serviceName] = svc
+}
+
 // GET adds a Handler for HTTP GET method for a route pattern.
-func (a *App) GET(pattern string, handler Handler) {
-	a.add("GET", pattern, handler)
+func (a *App) GET(pattern string, handler Handler, opts ...RouteOption) {
+	a.add("GET", pattern, handler, opts...)
 }
 
 // PUT adds a Handler for HTTP PUT method for a route pattern.
-func (a *App) PUT(pattern string, handler Handler) {
-	a.add("PUT", pattern, handler)
+func (a *App) PUT(pattern string, handler Handler, opts ...RouteOption) {
+	a.add("PUT", pattern, handler, opts...)
 }
 
 // POST adds a Handler for HTTP POST method for a route pattern.
-func (a *App) POST(pattern string, handler Handler) {
-	a.add("POST", pattern, handler)
+func (a *App) POST(pattern string, handler Handler, opts ...RouteOption) {
+	a.add("POST", pattern, handler, opts...)
 }
 
 // DELETE adds a Handler for HTTP DELETE method for a route pattern.
-func (a *App) DELETE(pattern string, handler Handler) {
-	a.add("DELETE", pattern, handler)
+func (a *App) DELETE(pattern string, handler Handler, opts ...RouteOption) {
+	a.add("DELETE", pattern, handler, opts...)
 }
 
 // PATCH adds a Handler for HTTP PATCH method for a route pattern.
-func (a *App) PATCH(pattern string, handler Handler) {
-	a.add("PATCH", pattern, handler)
+func (a *App) PATCH(pattern string, handler Handler, opts ...RouteOption) {
+	a.add("PATCH", pattern, handler, opts...)
 }
 
-func (a *App) add(method, pattern string, h Handler) {
+func (a *App) add(method, pattern string, h Handler, opts ...RouteOption) {
 	a.httpRegistered = true
-	a.httpServer.router.Add(method, pattern, handler{
+
+	route := &handler{
 		function:       h,
 		container:      a.container,
 		requestTimeout: a.Config.GetOrDefault("REQUEST_TIMEOUT", "5"),
-	})
+		templates:      a.templates,
+		formats:        a.responseFormats,
+		timeLayout:     a.responseTimeLayout,
+		errorRegistry:  a.errorRegistry,
+		appTransformer: a.responseTransformer,
+		priority:       middleware.PriorityNormal,
+	}
+
+	for _, o := range opts {
+		o(route)
+	}
+
+	if a.routePriorities != nil {
+		a.routePriorities.set(method, pattern, route.priority)
+	}
+
+	if a.latencyObjectives != nil {
+		a.latencyObjectives.set(a.container, method, pattern, route.latencyObjective)
+	}
+
+	var routeHandler http.Handler = *route
+	if route.webhookVerifier != nil {
+		if c, ok := route.webhookVerifier.(payment.ClockUser); ok {
+			c.UseClock(a.container.Clock())
+		}
+
+		routeHandler = payment.VerifySignature(route.webhookVerifier)(routeHandler)
+	}
+
+	a.httpServer.router.Add(method, pattern, routeHandler)
 }
 
 func (a *App) Metrics() metrics.Manager {
@@ -264,34 +537,85 @@ func (a *App) Logger() logging.Logger {
 	return a.container.Logger
 }
 
+// ErrorRegistry returns the registry mapping the errors of the handlers to the responses.
+//
+//	gofrerr.RegisterType(app.ErrorRegistry(), http.StatusPaymentRequired, func(err *billing.DeclinedError) interface{} {
+//		return map[string]interface{}{"message": "payment declined", "reason": err.Reason}
+//	})
+func (a *App) ErrorRegistry() *gofrerr.Registry {
+	if a.errorRegistry == nil {
+		a.errorRegistry = gofrerr.NewRegistry()
+	}
+
+	return a.errorRegistry
+}
+
 // SubCommand adds a sub-command to the CLI application.
 // Can be used to create commands like "kubectl get" or "kubectl get ingress".
-func (a *App) SubCommand(pattern string, handler Handler) {
-	a.cmd.addRoute(pattern, handler)
+func (a *App) SubCommand(pattern string, handler Handler, opts ...CommandOption) {
+	a.cmd.addRoute(pattern, handler, opts...)
 }
 
 func (a *App) Migrate(migrationsMap map[int64]migration.Migrate) {
 	// TODO : Move panic recovery at central location which will manage for all the different cases.
 	defer panicRecovery(a.container.Logger)
 
+	a.migrations = migrationsMap
+
 	migration.Run(migrationsMap, a.container)
 }
 
+// MigrateDown reverts the migrations run after toVersion.
+//
+//	Usage:
+//	app.Migrate(migrations.All())
+//
+//	app.SubCommand("migrate down", func(ctx *gofr.Context) (interface{}, error) {
+//		app.MigrateDown(20240301120000)
+//
+//		return "reverted", nil
+//	})
+func (a *App) MigrateDown(toVersion int64) {
+	defer panicRecovery(a.container.Logger)
+
+	if a.migrations == nil {
+		a.container.Errorf("no migrations are reverted as Migrate was not called")
+
+		return
+	}
+
+	migration.Down(a.migrations, toVersion, a.container)
+}
+
 func (a *App) initTracer() {
 	traceExporter := a.Config.Get("TRACE_EXPORTER")
 	tracerHost := a.Config.Get("TRACER_HOST")
 	tracerPort := a.Config.GetOrDefault("TRACER_PORT", "9411")
 
+	attrs := append([]attribute.KeyValue{semconv.ServiceNameKey.String(a.container.GetAppName())},
+		k8s.CurrentPod().Attributes()...)
+
 	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(a.container.GetAppName()),
-		)),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)),
 	)
 	otel.SetTracerProvider(tp)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 	otel.SetErrorHandler(&otelErrorHandler{logger: a.container.Logger})
 
+	if vendor := a.Config.Get("TRACER_VENDOR"); vendor != "" {
+		adapter, err := apm.New(vendor, a.Config)
+		if err != nil {
+			a.container.Errorf("could not export traces to %v: %v", vendor, err)
+			return
+		}
+
+		a.container.Logf("Exporting traces to %v.", vendor)
+
+		tp.RegisterSpanProcessor(sdktrace.NewBatchSpanProcessor(apm.NewExporter(adapter)))
+
+		return
+	}
+
 	const traceExporterGoFr = "gofr"
 
 	if (traceExporter != "" && tracerHost != "") || traceExporter == traceExporterGoFr {
@@ -368,9 +692,49 @@ func (a *App) EnableOAuth(jwksEndpoint string, refreshInterval int) {
 	oauthOption := middleware.OauthConfigs{
 		Provider:        a.container.GetHTTPService("gofr_oauth"),
 		RefreshInterval: time.Second * time.Duration(refreshInterval),
+		Clock:           a.container.Clock(),
+	}
+
+	keys := middleware.NewOAuth(oauthOption)
+
+	a.httpServer.router.Use(middleware.OAuth(keys))
+
+	if a.grpcServer != nil {
+		a.grpcServer.oauthKeys = keys
 	}
+}
 
-	a.httpServer.router.Use(middleware.OAuth(middleware.NewOAuth(oauthOption)))
+// EnableTenancy resolves the tenant of each request with the first resolver resolving it.
+func (a *App) EnableTenancy(resolvers ...tenant.Resolver) {
+	a.httpServer.router.Use(middleware.Tenancy(a.container.Metrics(), resolvers...))
+}
+
+// ResolveTimezones resolves the time zone of the caller with the first resolver resolving it.
+func (a *App) ResolveTimezones(resolvers ...gofrHTTP.LocationResolver) {
+	a.httpServer.router.Use(middleware.Timezone(resolvers...))
+}
+
+// EnableIdempotency responds to the retries of the requests with an Idempotency-Key with their first response.
+func (a *App) EnableIdempotency(ttl time.Duration) {
+	store, err := a.container.IdempotencyStore()
+	if err != nil {
+		a.container.Errorf("could not enable idempotency: %v", err)
+		return
+	}
+
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+
+	a.httpServer.router.Use(middleware.Idempotency(store, ttl))
+}
+
+// MirrorRequests sends a copy of the percentage of the requests to the shadow upstream, and logs the differences.
+//
+//	Usage:
+//	app.MirrorRequests("http://orders-v2.internal:8000", 5)
+func (a *App) MirrorRequests(upstream string, percentage float64) {
+	a.httpServer.router.Use(middleware.Mirror(upstream, percentage, a.container.Logger))
 }
 
 func (a *App) Subscribe(topic string, handler SubscribeFunc) {
@@ -383,6 +747,125 @@ func (a *App) Subscribe(topic string, handler SubscribeFunc) {
 	a.subscriptionManager.subscriptions[topic] = handler
 }
 
+// SubscribeRedisKeyspace registers a handler for the Redis keyspace notifications matching the pattern.
+func (a *App) SubscribeRedisKeyspace(pattern string, handler SubscribeFunc) {
+	if isNil(a.container.Redis) {
+		a.container.Logger.Errorf("redis not initialized in the container")
+
+		return
+	}
+
+	a.subscriptionManager.keyspaceSubscriptions[pattern] = handler
+}
+
+// AddHealthCheck registers a custom health check, whose failure only degrades the application unless it is critical.
+func (a *App) AddHealthCheck(name string, check func(ctx context.Context) datasource.Health, critical bool) {
+	a.container.AddHealthCheck(name, check, critical)
+}
+
+// Shutdown stops the application gracefully, until ctx is done, and then closes the datasources.
+func (a *App) Shutdown(ctx context.Context) error {
+	a.container.BeginShutdown()
+	defer a.stopped.close()
+
+	var errs []error
+
+	if a.httpServer != nil {
+		errs = append(errs, a.httpServer.Shutdown(ctx))
+	}
+
+	if a.cron != nil {
+		errs = append(errs, a.cron.Stop(ctx))
+	}
+
+	errs = append(errs, a.leader.stop(ctx))
+
+	if a.jobs != nil {
+		errs = append(errs, a.jobs.shutdown(ctx))
+	}
+
+	if a.grpcRegistered {
+		errs = append(errs, a.grpcServer.Shutdown(ctx))
+	}
+
+	errs = append(errs, a.subscriptionManager.stop(ctx))
+
+	errs = append(errs, a.container.ShutdownWorkers(ctx))
+
+	for _, svc := range a.container.GRPCServices {
+		errs = append(errs, svc.Close())
+	}
+
+	errs = append(errs, a.container.Close())
+
+	errs = append(errs, a.metricServer.Shutdown(ctx))
+
+	return errors.Join(errs...)
+}
+
+// OnHealthChange registers a handler of the transitions of the dependencies between UP and DOWN.
+func (a *App) OnHealthChange(handler container.HealthChangeHandler) {
+	a.container.OnHealthChange(handler)
+}
+
+// WaitForDependencies reports the application as not ready until the given dependencies, or all of them, are UP.
+func (a *App) WaitForDependencies(timeout time.Duration, dependencies ...string) {
+	a.startupWait = &startupWait{timeout: timeout, dependencies: dependencies}
+}
+
+// waitForDependencies waits for the dependencies in the background.
+func (a *App) waitForDependencies() <-chan struct{} {
+	done := make(chan struct{})
+
+	if a.startupWait == nil {
+		a.startupWait = startupWaitFromConfig(a.Config)
+	}
+
+	if a.startupWait == nil {
+		close(done)
+
+		return done
+	}
+
+	go func(w *startupWait) {
+		defer close(done)
+
+		ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+		defer cancel()
+
+		if err := a.container.WaitForDependencies(ctx, w.dependencies...); err != nil {
+			a.container.Errorf("application started without its dependencies after %v, error: %v", w.timeout, err)
+
+			return
+		}
+
+		a.container.Infof("all the dependencies are available, application is ready")
+	}(a.startupWait)
+
+	return done
+}
+
+func startupWaitFromConfig(c config.Config) *startupWait {
+	if c == nil || c.Get("STARTUP_WAIT_FOR") == "" {
+		return nil
+	}
+
+	w := &startupWait{timeout: defaultStartupWaitTimeout}
+
+	if timeout, err := strconv.Atoi(c.Get("STARTUP_WAIT_TIMEOUT")); err == nil && timeout > 0 {
+		w.timeout = time.Duration(timeout) * time.Second
+	}
+
+	for _, name := range strings.Split(c.Get("STARTUP_WAIT_FOR"), ",") {
+		// "all" waits for every registered dependency, which is the same as not listing any.
+		if name = strings.TrimSpace(name); name != "" && name != "all" {
+			w.dependencies = append(w.dependencies, name)
+		}
+	}
+
+	return w
+}
+
 func (a *App) AddRESTHandlers(object interface{}) error {
 	cfg, err := scanEntity(object)
 	if err != nil {
@@ -401,18 +884,38 @@ func (a *App) UseMiddleware(middlewares ...gofrHTTP.Middleware) {
 	a.httpServer.router.UseMiddleware(middlewares...)
 }
 
-// AddCronJob registers a cron job to the cron table, the schedule is in * * * * * (6 part) format
-// denoting minutes, hours, days, months and day of week respectively.
-func (a *App) AddCronJob(schedule, jobName string, job CronFunc) {
+// AddCronJob registers a cron job to the cron table, the schedule is in * * * * * (5 part) format
+// denoting minutes, hours, days, months and day of week respectively, optionally preceded by seconds.
+func (a *App) AddCronJob(schedule, jobName string, job CronFunc, opts ...CronOption) {
 	if a.cron == nil {
-		a.cron = NewCron(a.container)
+		a.cron = a.newCron()
 	}
 
-	if err := a.cron.AddJob(schedule, jobName, job); err != nil {
+	if err := a.cron.AddJob(schedule, jobName, job, opts...); err != nil {
 		a.Logger().Errorf("error adding cron job, err : %v", err)
 	}
 }
 
+// AddIntervalJob registers a job run once in every interval, with the options of AddCronJob.
+func (a *App) AddIntervalJob(interval time.Duration, jobName string, job CronFunc, opts ...CronOption) {
+	if a.cron == nil {
+		a.cron = a.newCron()
+	}
+
+	if err := a.cron.AddIntervalJob(interval, jobName, job, opts...); err != nil {
+		a.Logger().Errorf("error adding interval job, err : %v", err)
+	}
+}
+
+// ScheduleOnce registers a job which is run once at the given time, or right away if the time has passed.
+func (a *App) ScheduleOnce(at time.Time, jobName string, job CronFunc, opts ...CronOption) {
+	if a.cron == nil {
+		a.cron = a.newCron()
+	}
+
+	a.cron.ScheduleOnce(at, jobName, job, opts...)
+}
+
 // contains is a helper function checking for duplicate entry in a slice.
 func contains(elems []string, v string) bool {
 	for _, s := range elems {
@@ -423,3 +926,10 @@ func contains(elems []string, v string) bool {
 
 	return false
 }
+
+// isNil checks whether the interface is unassigned or holds a nil pointer.
+func isNil(i interface{}) bool {
+	val := reflect.ValueOf(i)
+
+	return !val.IsValid() || val.IsNil()
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -14,24 +15,39 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/config"
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
 	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
 	"github.com/peter-stratton/gofr/pkg/gofr/migration"
+	"github.com/peter-stratton/gofr/pkg/gofr/service"
+	"github.com/peter-stratton/gofr/pkg/gofr/service/servicetest"
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
 	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
 )
 
 const helloWorld = "Hello World!"
 
 func TestNewCMD(t *testing.T) {
+	var code int
+
+	exit = func(c int) { code = c }
+
+	defer func() { exit = os.Exit }()
+
 	a := NewCMD()
 	// Without args we should get error on stderr.
 	outputWithoutArgs := testutil.StderrOutputForFunc(a.Run)
 	if outputWithoutArgs != "No Command Found!" {
 		t.Errorf("Stderr output mismatch. Got: %s ", outputWithoutArgs)
 	}
+
+	assert.Equal(t, exitCodeUsage, code)
 }
 
 func TestGofr_readConfig(t *testing.T) {
@@ -162,6 +178,21 @@ func Test_AddHTTPService(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func Test_AddHTTPService_Stub(t *testing.T) {
+	server := servicetest.Stub("test-service").On(http.MethodGet, "/test").Reply(http.StatusOK, nil).Start(t)
+
+	g := New()
+
+	g.AddHTTPService("test-service", server.URL())
+
+	resp, err := g.container.GetHTTPService("test-service").Get(context.Background(), "test", nil)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
 func Test_AddDuplicateHTTPService(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "DEBUG")
 
@@ -191,6 +222,33 @@ func TestApp_AddAndGetHTTPService(t *testing.T) {
 	assert.NotNil(t, svc)
 }
 
+func TestApp_AddGRPCService(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+
+	go func() { _ = server.Serve(listener) }()
+
+	defer server.Stop()
+
+	app := New()
+
+	app.AddGRPCService("test-service", listener.Addr().String(), &service.GRPCConfig{Timeout: time.Second})
+
+	svc := app.container.GetGRPCService("test-service")
+	require.NotNil(t, svc)
+
+	resp, err := grpc_health_v1.NewHealthClient(svc).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	assert.Contains(t, app.container.Ready(context.Background()).Checks, "test-service")
+
+	assert.NoError(t, app.Shutdown(context.Background()))
+}
+
 func TestApp_MigrateInvalidKeys(t *testing.T) {
 	logs := testutil.StderrOutputForFunc(func() {
 		app := New()
@@ -200,6 +258,15 @@ func TestApp_MigrateInvalidKeys(t *testing.T) {
 	assert.Contains(t, logs, "migration run failed! UP not defined for the following keys: [1]")
 }
 
+func TestApp_MigrateDownWithoutMigrate(t *testing.T) {
+	logs := testutil.StderrOutputForFunc(func() {
+		app := New()
+		app.MigrateDown(0)
+	})
+
+	assert.Contains(t, logs, "no migrations are reverted as Migrate was not called")
+}
+
 func TestApp_MigratePanicRecovery(t *testing.T) {
 	logs := testutil.StderrOutputForFunc(func() {
 		app := New()
@@ -225,6 +292,8 @@ func Test_otelErrorHandler(t *testing.T) {
 }
 
 func Test_addRoute(t *testing.T) {
+	os.Args = []string{"", "log"}
+
 	logs := testutil.StdoutOutputForFunc(func() {
 		a := NewCMD()
 
@@ -504,3 +573,60 @@ func Test_AddCronJob_Success(t *testing.T) {
 
 	assert.Truef(t, pass, "unable to add cron job to cron table")
 }
+
+func Test_startupWaitFromConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		configs  map[string]string
+		expected *startupWait
+	}{
+		{"not configured", map[string]string{}, nil},
+		{"default timeout", map[string]string{"STARTUP_WAIT_FOR": "sql, redis"},
+			&startupWait{timeout: time.Minute, dependencies: []string{"sql", "redis"}}},
+		{"all dependencies", map[string]string{"STARTUP_WAIT_FOR": "all", "STARTUP_WAIT_TIMEOUT": "30"},
+			&startupWait{timeout: 30 * time.Second}},
+	}
+
+	for i, tc := range testCases {
+		w := startupWaitFromConfig(config.NewMockConfig(tc.configs))
+
+		assert.Equal(t, tc.expected, w, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_WaitForDependencies(t *testing.T) {
+	app := New()
+
+	app.WaitForDependencies(time.Second, "sql")
+
+	assert.Equal(t, &startupWait{timeout: time.Second, dependencies: []string{"sql"}}, app.startupWait)
+}
+
+func TestApp_EnableTenancy(t *testing.T) {
+	app := New()
+	app.EnableTenancy(tenant.FromHeader("X-Tenant-ID"))
+
+	app.GET("/tenant", func(c *Context) (interface{}, error) {
+		return c.Tenant(), nil
+	})
+
+	testCases := []struct {
+		tenant     string
+		statusCode int
+		body       string
+	}{
+		{"acme", http.StatusOK, `{"data":"acme"}`},
+		{"", http.StatusBadRequest, `{"error":{"message":"tenant is required"}}`},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/tenant", http.NoBody)
+		r.Header.Set("X-Tenant-ID", tc.tenant)
+
+		w := httptest.NewRecorder()
+		app.httpServer.router.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.tenant)
+		assert.JSONEq(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.tenant)
+	}
+}
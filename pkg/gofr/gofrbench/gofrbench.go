@@ -0,0 +1,220 @@
+// Package gofrbench benchmarks GoFr applications with reproducible load scenarios, and compares two runs.
+//
+//	func BenchmarkSimpleGET(b *testing.B) {
+//		gofrbench.Run(b, gofrbench.SimpleGET())
+//	}
+//
+//	results, err := gofrbench.Measure(gofrbench.Scenarios()...)
+//	report := gofrbench.Compare(baseline, results, 0.1)
+//	if len(report.Regressions()) > 0 {
+//		fmt.Print(report)
+//	}
+package gofrbench
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/peter-stratton/gofr/pkg/gofr"
+	"github.com/peter-stratton/gofr/pkg/gofr/apptest"
+	"github.com/peter-stratton/gofr/pkg/gofr/migration"
+	"github.com/peter-stratton/gofr/pkg/gofr/version"
+)
+
+// Scenario is a request sent repeatedly to an application.
+type Scenario struct {
+	Name string
+
+	// Configs are the configs of the application, whose LOG_LEVEL is ERROR.
+	Configs map[string]string
+	// Setup adds the routes of the application, and the migrations of its data.
+	Setup func(app *gofr.App)
+
+	// Method, Path and Body are the request sent to the application, whose response must not be an error.
+	Method string
+	Path   string
+	Body   []byte
+}
+
+// Scenarios returns the scenarios of GoFr: SimpleGET, JSONPost and SQLRoute.
+func Scenarios() []Scenario {
+	return []Scenario{SimpleGET(), JSONPost(), SQLRoute()}
+}
+
+// SimpleGET is a GET request to a route returning a string.
+func SimpleGET() Scenario {
+	return Scenario{
+		Name: "simple-get",
+		Setup: func(app *gofr.App) {
+			app.GET("/hello", func(*gofr.Context) (interface{}, error) {
+				return "Hello World!", nil
+			})
+		},
+		Method: http.MethodGet,
+		Path:   "/hello",
+	}
+}
+
+type order struct {
+	ID       int      `json:"id"`
+	Item     string   `json:"item"`
+	Quantity int      `json:"quantity"`
+	Tags     []string `json:"tags"`
+}
+
+// JSONPost is a POST request with a JSON body, which is bound by the route and returned in its response.
+func JSONPost() Scenario {
+	return Scenario{
+		Name: "json-post",
+		Setup: func(app *gofr.App) {
+			app.POST("/orders", func(ctx *gofr.Context) (interface{}, error) {
+				var o order
+				if err := ctx.Bind(&o); err != nil {
+					return nil, err
+				}
+
+				return o, nil
+			})
+		},
+		Method: http.MethodPost,
+		Path:   "/orders",
+		Body:   []byte(`{"id":1,"item":"book","quantity":2,"tags":["gift","express"]}`),
+	}
+}
+
+// SQLRoute is a GET request to a route reading a row of an SQLite database, which is created in a temporary directory.
+func SQLRoute() Scenario {
+	return Scenario{
+		Name:    "sql-route",
+		Configs: map[string]string{"DB_DIALECT": "sqlite"},
+		Setup: func(app *gofr.App) {
+			app.Migrate(map[int64]migration.Migrate{
+				1: {UP: func(d migration.Datasource) error {
+					if _, err := d.SQL.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL)"); err != nil {
+						return err
+					}
+
+					_, err := d.SQL.Exec("INSERT INTO items (id, name) VALUES (1, 'book'), (2, 'pen'), (3, 'lamp')")
+
+					return err
+				}},
+			})
+
+			app.GET("/items/{id}", func(ctx *gofr.Context) (interface{}, error) {
+				var name string
+
+				err := ctx.SQL.QueryRowContext(ctx, "SELECT name FROM items WHERE id = ?", ctx.PathParam("id")).Scan(&name)
+				if err != nil {
+					return nil, err
+				}
+
+				return map[string]string{"name": name}, nil
+			})
+		},
+		Method: http.MethodGet,
+		Path:   "/items/2",
+	}
+}
+
+// Run benchmarks the scenario, sending its request b.N times to the application.
+func Run(b *testing.B, s Scenario) {
+	b.Helper()
+
+	send := start(b, s)
+
+	// the first request is not timed, as it sets up the connection and the lazily initialized parts of the application.
+	if err := send(); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := send(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Measure benchmarks the scenarios outside of go test, for as long as go test benchmarks a function by default.
+func Measure(scenarios ...Scenario) ([]Result, error) {
+	results := make([]Result, 0, len(scenarios))
+
+	for _, s := range scenarios {
+		r := testing.Benchmark(func(b *testing.B) { Run(b, s) })
+		if r.N == 0 {
+			return nil, fmt.Errorf("%w: %s", errScenarioFailed, s.Name)
+		}
+
+		results = append(results, Result{
+			Scenario:    s.Name,
+			Version:     version.Framework,
+			NsPerOp:     r.NsPerOp(),
+			AllocsPerOp: r.AllocsPerOp(),
+			BytesPerOp:  r.AllocedBytesPerOp(),
+		})
+	}
+
+	return results, nil
+}
+
+// start runs the application of the scenario, and returns the function sending its request.
+func start(tb testing.TB, s Scenario) func() error {
+	tb.Helper()
+
+	configs := map[string]string{"LOG_LEVEL": "ERROR"}
+
+	if s.Configs["DB_DIALECT"] == "sqlite" {
+		configs["DB_NAME"] = filepath.Join(tb.TempDir(), "gofrbench.db")
+	}
+
+	for k, v := range s.Configs {
+		configs[k] = v
+	}
+
+	srv := apptest.New(tb, func() *gofr.App {
+		app := gofr.New()
+		s.Setup(app)
+
+		return app
+	}, apptest.WithConfig(configs))
+
+	client := &http.Client{}
+	url := srv.URL + s.Path
+
+	// the cleanups run in the reverse order, so the idle connections are closed before the application shuts down.
+	tb.Cleanup(client.CloseIdleConnections)
+
+	return func() error {
+		req, err := http.NewRequestWithContext(context.Background(), s.Method, url, bytes.NewReader(s.Body))
+		if err != nil {
+			return err
+		}
+
+		if s.Body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		defer resp.Body.Close()
+
+		// the body is read for the connection to be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("%w: %s %s returned %d", errScenarioFailed, s.Method, s.Path, resp.StatusCode)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,83 @@
+package gofrbench
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarios(t *testing.T) {
+	for i, s := range Scenarios() {
+		send := start(t, s)
+
+		assert.NoError(t, send(), "TEST[%d], Failed.\n%s", i, s.Name)
+		assert.NoError(t, send(), "TEST[%d], Failed.\n%s", i, s.Name)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []Result{
+		{Scenario: "simple-get", Version: "v1.0.0", NsPerOp: 1000, AllocsPerOp: 50},
+		{Scenario: "json-post", Version: "v1.0.0", NsPerOp: 2000, AllocsPerOp: 80},
+		{Scenario: "sql-route", Version: "v1.0.0", NsPerOp: 3000, AllocsPerOp: 100},
+		{Scenario: "removed", Version: "v1.0.0", NsPerOp: 3000, AllocsPerOp: 100},
+	}
+
+	current := []Result{
+		{Scenario: "simple-get", Version: "v1.1.0", NsPerOp: 1050, AllocsPerOp: 50},
+		{Scenario: "json-post", Version: "v1.1.0", NsPerOp: 2500, AllocsPerOp: 80},
+		{Scenario: "sql-route", Version: "v1.1.0", NsPerOp: 2000, AllocsPerOp: 120},
+		{Scenario: "added", Version: "v1.1.0", NsPerOp: 2000, AllocsPerOp: 120},
+	}
+
+	report := Compare(baseline, current, 0.1)
+
+	require.Len(t, report.Comparisons, 3)
+
+	testCases := []struct {
+		scenario    string
+		timeDelta   float64
+		allocsDelta float64
+		regressed   bool
+	}{
+		{"simple-get", 0.05, 0, false},
+		{"json-post", 0.25, 0, true},
+		{"sql-route", -1.0 / 3, 0.2, true},
+	}
+
+	for i, tc := range testCases {
+		c := report.Comparisons[i]
+
+		assert.Equal(t, tc.scenario, c.Current.Scenario, "TEST[%d], Failed.\n%s", i, tc.scenario)
+		assert.InDelta(t, tc.timeDelta, c.TimeDelta, 0.001, "TEST[%d], Failed.\n%s", i, tc.scenario)
+		assert.InDelta(t, tc.allocsDelta, c.AllocsDelta, 0.001, "TEST[%d], Failed.\n%s", i, tc.scenario)
+		assert.Equal(t, tc.regressed, c.Regressed, "TEST[%d], Failed.\n%s", i, tc.scenario)
+	}
+
+	assert.Len(t, report.Regressions(), 2)
+	assert.Contains(t, report.String(), "json-post   v1.0.0    v1.1.0   2000 -> 2500 (+25.0%)")
+	assert.Contains(t, report.String(), "REGRESSED")
+}
+
+func TestWriteResults(t *testing.T) {
+	results := []Result{{Scenario: "simple-get", Version: "v1.0.0", NsPerOp: 1000, AllocsPerOp: 50, BytesPerOp: 4096}}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteResults(&buf, results))
+
+	read, err := ReadResults(&buf)
+
+	require.NoError(t, err)
+	assert.Equal(t, results, read)
+}
+
+func BenchmarkScenarios(b *testing.B) {
+	for _, s := range Scenarios() {
+		b.Run(s.Name, func(b *testing.B) {
+			Run(b, s)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package gofrbench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+var errScenarioFailed = errors.New("scenario failed")
+
+// Result is the measure of a scenario, which is written as JSON by WriteResults to be compared with later runs.
+type Result struct {
+	Scenario string `json:"scenario"`
+	// Version is the version of GoFr which was measured.
+	Version     string `json:"version"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	AllocsPerOp int64  `json:"allocs_per_op"`
+	BytesPerOp  int64  `json:"bytes_per_op"`
+}
+
+// WriteResults writes the results as JSON.
+func WriteResults(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(results)
+}
+
+// ReadResults reads the results written by WriteResults.
+func ReadResults(r io.Reader) ([]Result, error) {
+	var results []Result
+
+	err := json.NewDecoder(r).Decode(&results)
+
+	return results, err
+}
+
+// Comparison is the result of a scenario compared with its baseline.
+type Comparison struct {
+	Baseline Result
+	Current  Result
+
+	// TimeDelta and AllocsDelta are the changes relative to the baseline, like 0.1 for 10% more.
+	TimeDelta   float64
+	AllocsDelta float64
+
+	// Regressed is true if the time or the allocations of the scenario grew by more than the threshold.
+	Regressed bool
+}
+
+// Report is the comparison of the results of two runs.
+type Report struct {
+	Threshold   float64
+	Comparisons []Comparison
+}
+
+// Compare reports the scenarios whose time or allocations grew by more than threshold, like 0.1 for 10%.
+func Compare(baseline, current []Result, threshold float64) Report {
+	base := make(map[string]Result, len(baseline))
+	for _, r := range baseline {
+		base[r.Scenario] = r
+	}
+
+	report := Report{Threshold: threshold}
+
+	for _, r := range current {
+		b, ok := base[r.Scenario]
+		if !ok {
+			continue
+		}
+
+		c := Comparison{
+			Baseline:    b,
+			Current:     r,
+			TimeDelta:   delta(b.NsPerOp, r.NsPerOp),
+			AllocsDelta: delta(b.AllocsPerOp, r.AllocsPerOp),
+		}
+
+		c.Regressed = c.TimeDelta > threshold || c.AllocsDelta > threshold
+
+		report.Comparisons = append(report.Comparisons, c)
+	}
+
+	return report
+}
+
+// delta returns the change from base to current, relative to base.
+func delta(base, current int64) float64 {
+	if base == 0 {
+		if current == 0 {
+			return 0
+		}
+
+		return 1
+	}
+
+	return float64(current-base) / float64(base)
+}
+
+// Regressions returns the comparisons of the scenarios which regressed.
+func (r Report) Regressions() []Comparison {
+	var regressions []Comparison
+
+	for _, c := range r.Comparisons {
+		if c.Regressed {
+			regressions = append(regressions, c)
+		}
+	}
+
+	return regressions
+}
+
+// String returns the comparisons as a table, with the scenarios which regressed marked as REGRESSED.
+func (r Report) String() string {
+	var sb strings.Builder
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "SCENARIO\tBASELINE\tCURRENT\tNS/OP\tALLOCS/OP\t")
+
+	for _, c := range r.Comparisons {
+		status := ""
+		if c.Regressed {
+			status = "REGRESSED"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d -> %d (%+.1f%%)\t%d -> %d (%+.1f%%)\t%s\n", c.Current.Scenario,
+			c.Baseline.Version, c.Current.Version, c.Baseline.NsPerOp, c.Current.NsPerOp, c.TimeDelta*100,
+			c.Baseline.AllocsPerOp, c.Current.AllocsPerOp, c.AllocsDelta*100, status)
+	}
+
+	_ = w.Flush()
+
+	return sb.String()
+}
@@ -0,0 +1,149 @@
+// Package gofrerr provides the domain errors of an application, whose codes are translated to responses by a Registry.
+package gofrerr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Code identifies the kind of an Error, which determines its HTTP status.
+type Code string
+
+// The codes of the errors, which are mapped to the HTTP statuses of the same meaning by a Registry.
+const (
+	CodeInvalid            Code = "INVALID"
+	CodeUnauthenticated    Code = "UNAUTHENTICATED"
+	CodePermissionDenied   Code = "PERMISSION_DENIED"
+	CodeNotFound           Code = "NOT_FOUND"
+	CodeAlreadyExists      Code = "ALREADY_EXISTS"
+	CodeConflict           Code = "CONFLICT"
+	CodeFailedPrecondition Code = "FAILED_PRECONDITION"
+	CodeRateLimited        Code = "RATE_LIMITED"
+	CodeUnavailable        Code = "UNAVAILABLE"
+	CodeInternal           Code = "INTERNAL"
+)
+
+// Error is a domain error, with a code and a message for the clients. The error it wraps is not exposed.
+type Error struct {
+	Code    Code
+	Message string
+	// Fields are the messages of the invalid fields of an input, by the names of the fields.
+	Fields map[string]string
+	// Details are the data describing the error to the clients, like the resource and the ID which are not found.
+	Details map[string]interface{}
+
+	cause error
+}
+
+// New returns an error of the code with the message.
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Newf returns an error of the code with the message formatted according to the format.
+func Newf(code Code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap returns an error of the code with the message, caused by err, which is returned by errors.Unwrap.
+func Wrap(err error, code Code, message string) *Error {
+	return &Error{Code: code, Message: message, cause: err}
+}
+
+// NotFound returns the error of the resource of the id which is not found, like NotFound("order", 42).
+func NotFound(resource string, id interface{}) *Error {
+	return &Error{
+		Code:    CodeNotFound,
+		Message: fmt.Sprintf("%s %v not found", resource, id),
+		Details: map[string]interface{}{"resource": resource, "id": id},
+	}
+}
+
+// AlreadyExists returns the error of the resource of the id which cannot be created, as it exists already.
+func AlreadyExists(resource string, id interface{}) *Error {
+	return &Error{
+		Code:    CodeAlreadyExists,
+		Message: fmt.Sprintf("%s %v already exists", resource, id),
+		Details: map[string]interface{}{"resource": resource, "id": id},
+	}
+}
+
+// Invalid returns the error of an input with the messages of its invalid fields.
+func Invalid(fields map[string]string) *Error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return &Error{
+		Code:    CodeInvalid,
+		Message: "invalid fields: " + strings.Join(names, ", "),
+		Fields:  fields,
+	}
+}
+
+// Unauthenticated returns the error of a request whose credentials are missing or invalid.
+func Unauthenticated(message string) *Error {
+	return New(CodeUnauthenticated, message)
+}
+
+// PermissionDenied returns the error of a request whose caller is not allowed to take the action.
+func PermissionDenied(message string) *Error {
+	return New(CodePermissionDenied, message)
+}
+
+// Conflict returns the error of an action conflicting with the state of a resource, like a concurrent update of it.
+func Conflict(message string) *Error {
+	return New(CodeConflict, message)
+}
+
+// Unavailable returns the error of an action which cannot be taken for now, like when a dependency is down.
+func Unavailable(message string) *Error {
+	return New(CodeUnavailable, message)
+}
+
+// Error returns the message of the error, followed by the error which caused it.
+func (e *Error) Error() string {
+	if e.cause == nil {
+		return e.Message
+	}
+
+	return e.Message + ": " + e.cause.Error()
+}
+
+// Unwrap returns the error which caused the error.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether the target is an Error of the same code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+
+	return ok && t.Code == e.Code && (t.Message == "" || t.Message == e.Message)
+}
+
+// WithDetail returns the error with the detail set, for the data describing the error to the clients.
+func (e *Error) WithDetail(key string, value interface{}) *Error {
+	if e.Details == nil {
+		e.Details = make(map[string]interface{})
+	}
+
+	e.Details[key] = value
+
+	return e
+}
+
+// CodeOf returns the code of the first Error in the chain of err, or CodeInternal if there is none.
+func CodeOf(err error) Code {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+
+	return CodeInternal
+}
@@ -0,0 +1,112 @@
+package gofrerr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errDatabase = errors.New("connection refused")
+
+func TestErrors(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		err     *Error
+		code    Code
+		message string
+		status  int
+	}{
+		{"not found", NotFound("order", 42), CodeNotFound, "order 42 not found", http.StatusNotFound},
+		{"already exists", AlreadyExists("user", "a@b.c"), CodeAlreadyExists, "user a@b.c already exists",
+			http.StatusConflict},
+		{"invalid", Invalid(map[string]string{"name": "is required", "email": "is invalid"}), CodeInvalid,
+			"invalid fields: email, name", http.StatusBadRequest},
+		{"unauthenticated", Unauthenticated("token expired"), CodeUnauthenticated, "token expired",
+			http.StatusUnauthorized},
+		{"permission denied", PermissionDenied("not an admin"), CodePermissionDenied, "not an admin", http.StatusForbidden},
+		{"conflict", Conflict("order is paid"), CodeConflict, "order is paid", http.StatusConflict},
+		{"unavailable", Unavailable("try later"), CodeUnavailable, "try later", http.StatusServiceUnavailable},
+		{"formatted", Newf(CodeRateLimited, "limit of %d reached", 10), CodeRateLimited, "limit of 10 reached",
+			http.StatusTooManyRequests},
+		{"wrapped", Wrap(errDatabase, CodeUnavailable, "orders are unavailable"), CodeUnavailable,
+			"orders are unavailable: connection refused", http.StatusServiceUnavailable},
+		{"unknown code", New("TEAPOT", "short and stout"), "TEAPOT", "short and stout", http.StatusInternalServerError},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.code, tc.err.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.message, tc.err.Error(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.status, tc.err.StatusCode(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestError_Chain(t *testing.T) {
+	err := fmt.Errorf("loading order: %w", Wrap(errDatabase, CodeUnavailable, "orders are unavailable"))
+
+	assert.ErrorIs(t, err, errDatabase)
+	assert.ErrorIs(t, err, New(CodeUnavailable, ""))
+	assert.NotErrorIs(t, err, New(CodeNotFound, ""))
+	assert.Equal(t, CodeUnavailable, CodeOf(err))
+	assert.Equal(t, CodeInternal, CodeOf(errDatabase))
+
+	e := NotFound("order", 42).WithDetail("tenant", "acme")
+	assert.Equal(t, map[string]interface{}{"resource": "order", "id": 42, "tenant": "acme"}, e.Details)
+}
+
+type declinedError struct {
+	reason string
+}
+
+func (e *declinedError) Error() string {
+	return "payment declined: " + e.reason
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterCode(CodeConflict, http.StatusUnprocessableEntity)
+	r.RegisterCode("PAYMENT_REQUIRED", http.StatusPaymentRequired)
+
+	RegisterType(r, http.StatusPaymentRequired, func(e *declinedError) interface{} {
+		return map[string]interface{}{"message": "payment declined", "reason": e.reason}
+	})
+	RegisterType[*declinedError](r, http.StatusTeapot, nil) // shadowed by the first mapping of the type
+
+	testCases := []struct {
+		desc   string
+		err    error
+		status int
+		body   interface{}
+		ok     bool
+	}{
+		{"code", NotFound("order", 42), http.StatusNotFound, map[string]interface{}{"message": "order 42 not found",
+			"code": CodeNotFound, "details": map[string]interface{}{"resource": "order", "id": 42}}, true},
+		{"remapped code", Conflict("order is paid"), http.StatusUnprocessableEntity,
+			map[string]interface{}{"message": "order is paid", "code": CodeConflict}, true},
+		{"code of the application", New("PAYMENT_REQUIRED", "pay first"), http.StatusPaymentRequired,
+			map[string]interface{}{"message": "pay first", "code": Code("PAYMENT_REQUIRED")}, true},
+		{"fields", Invalid(map[string]string{"name": "is required"}), http.StatusBadRequest,
+			map[string]interface{}{"message": "invalid fields: name", "code": CodeInvalid,
+				"fields": map[string]string{"name": "is required"}}, true},
+		{"cause is not exposed", fmt.Errorf("saving: %w", Wrap(errDatabase, CodeInternal, "could not save")),
+			http.StatusInternalServerError, map[string]interface{}{"message": "could not save", "code": CodeInternal}, true},
+		{"registered type", fmt.Errorf("charging: %w", &declinedError{reason: "insufficient funds"}),
+			http.StatusPaymentRequired, map[string]interface{}{"message": "payment declined", "reason": "insufficient funds"},
+			true},
+		{"unregistered error", errDatabase, 0, nil, false},
+	}
+
+	for i, tc := range testCases {
+		status, body, ok := r.Resolve(tc.err)
+
+		assert.Equal(t, tc.status, status, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, body, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.ok, ok, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	// the registries are independent.
+	status, _, _ := NewRegistry().Resolve(Conflict("order is paid"))
+	assert.Equal(t, http.StatusConflict, status)
+}
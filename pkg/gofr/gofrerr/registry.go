@@ -0,0 +1,115 @@
+package gofrerr
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// Registry maps the errors of the handlers to the statuses and the bodies of the responses.
+type Registry struct {
+	mu    sync.RWMutex
+	codes map[Code]int
+	types []typeMapping
+}
+
+// typeMapping maps the errors of a type, returning false for the errors of the other types.
+type typeMapping func(err error) (status int, body interface{}, ok bool)
+
+//nolint:gochecknoglobals // the statuses of the codes of this package are constant.
+var codeStatuses = map[Code]int{
+	CodeInvalid:            http.StatusBadRequest,
+	CodeUnauthenticated:    http.StatusUnauthorized,
+	CodePermissionDenied:   http.StatusForbidden,
+	CodeNotFound:           http.StatusNotFound,
+	CodeAlreadyExists:      http.StatusConflict,
+	CodeConflict:           http.StatusConflict,
+	CodeFailedPrecondition: http.StatusPreconditionFailed,
+	CodeRateLimited:        http.StatusTooManyRequests,
+	CodeUnavailable:        http.StatusServiceUnavailable,
+	CodeInternal:           http.StatusInternalServerError,
+}
+
+// NewRegistry returns a registry with the codes of this package mapped to their HTTP statuses.
+func NewRegistry() *Registry {
+	codes := make(map[Code]int, len(codeStatuses))
+	for code, status := range codeStatuses {
+		codes[code] = status
+	}
+
+	return &Registry{codes: codes}
+}
+
+// RegisterCode maps the Errors of the code to the status.
+func (r *Registry) RegisterCode(code Code, status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.codes[code] = status
+}
+
+// RegisterType maps the errors of the type E to the status and to the body, which is {"message": err.Error()} if nil.
+func RegisterType[E error](r *Registry, status int, body func(err E) interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.types = append(r.types, func(err error) (int, interface{}, bool) {
+		var e E
+		if !errors.As(err, &e) {
+			return 0, nil, false
+		}
+
+		if body == nil {
+			return status, map[string]interface{}{"message": err.Error()}, true
+		}
+
+		return status, body(e), true
+	})
+}
+
+// Resolve returns the status and the body of the response of the error, or false if it is not mapped.
+func (r *Registry) Resolve(err error) (status int, body interface{}, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, m := range r.types {
+		if status, body, ok := m(err); ok {
+			return status, body, true
+		}
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		return 0, nil, false
+	}
+
+	status, ok = r.codes[e.Code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	return status, e.body(), true
+}
+
+// StatusCode returns the HTTP status of the code of the error.
+func (e *Error) StatusCode() int {
+	if status, ok := codeStatuses[e.Code]; ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
+
+func (e *Error) body() map[string]interface{} {
+	body := map[string]interface{}{"message": e.Message, "code": e.Code}
+
+	if len(e.Fields) > 0 {
+		body["fields"] = e.Fields
+	}
+
+	if len(e.Details) > 0 {
+		body["details"] = e.Details
+	}
+
+	return body
+}
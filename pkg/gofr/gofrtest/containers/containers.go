@@ -0,0 +1,251 @@
+// Package containers starts the datasources of an application in docker containers, for end-to-end tests.
+//
+//	func TestOrders(t *testing.T) {
+//		db := containers.Run(t, containers.Postgres())
+//		db.Migrate(t, migrations.All())
+//		db.Truncate(t, "orders")
+//
+//		srv := apptest.New(t, newApp, apptest.WithConfig(db.Config()))
+//		...
+//	}
+package containers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/migration"
+)
+
+const (
+	defaultStartTimeout = 2 * time.Minute
+	readyPollInterval   = 500 * time.Millisecond
+	host                = "127.0.0.1"
+)
+
+var (
+	// ErrDockerUnavailable is returned by Start when the docker CLI is not installed.
+	ErrDockerUnavailable = errors.New("docker is not available")
+
+	errTruncateNotSupported = errors.New("truncation is not supported by the datasource")
+)
+
+// Spec describes the container of a datasource.
+type Spec struct {
+	// Image is the docker image of the container.
+	Image string
+	// Port is the port which the datasource listens on in the container.
+	Port int
+	// StartTimeout is the time the datasource is given to be ready, which is 2 minutes by default.
+	StartTimeout time.Duration
+
+	// env returns the environment of the container, given the port of the host which Port is published on.
+	env func(hostPort int) []string
+	// config returns the configs of the application to connect to the datasource.
+	config func(hostPort int) map[string]string
+	// ready returns nil once the datasource accepts connections.
+	ready func(ctx context.Context, c *Container) error
+	// truncate empties the given tables, keys or topics, or all of them if none are given.
+	truncate func(ctx context.Context, c *Container, names []string) error
+}
+
+// Container is a running container of a datasource.
+type Container struct {
+	// ID is the ID of the docker container.
+	ID string
+	// Host and Port are the address on which the datasource is reachable.
+	Host string
+	Port int
+
+	spec Spec
+}
+
+// Run starts the container of spec until the test completes. The test is skipped if docker is not available.
+func Run(t testing.TB, spec Spec) *Container {
+	t.Helper()
+
+	c, err := Start(context.Background(), spec)
+	if errors.Is(err, ErrDockerUnavailable) {
+		t.Skip(err)
+	}
+
+	if err != nil {
+		t.Fatalf("could not start the %s container: %v", spec.Image, err)
+	}
+
+	t.Cleanup(func() {
+		if err := c.Terminate(); err != nil {
+			t.Errorf("could not remove the %s container: %v", spec.Image, err)
+		}
+	})
+
+	return c
+}
+
+// Start starts the container of spec, to be shared by the tests of a package and removed with Terminate.
+func Start(ctx context.Context, spec Spec) (*Container, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, ErrDockerUnavailable
+	}
+
+	hostPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := docker(ctx, runArgs(spec, hostPort)...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Container{ID: out, Host: host, Port: hostPort, spec: spec}
+
+	if err := c.waitReady(ctx); err != nil {
+		logs, _ := docker(context.Background(), "logs", "--tail", "20", c.ID)
+		_ = c.Terminate()
+
+		return nil, fmt.Errorf("%w, logs of the container:\n%s", err, logs)
+	}
+
+	return c, nil
+}
+
+func runArgs(spec Spec, hostPort int) []string {
+	args := []string{"run", "--detach", "--rm", "--publish", fmt.Sprintf("%s:%d:%d", host, hostPort, spec.Port)}
+
+	if spec.env != nil {
+		for _, e := range spec.env(hostPort) {
+			args = append(args, "--env", e)
+		}
+	}
+
+	return append(args, spec.Image)
+}
+
+func (c *Container) waitReady(ctx context.Context) error {
+	timeout := c.spec.StartTimeout
+	if timeout <= 0 {
+		timeout = defaultStartTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		err := c.spec.ready(ctx, c)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s is not ready after %v: %w", c.spec.Image, timeout, err)
+		case <-time.After(readyPollInterval):
+		}
+	}
+}
+
+// Terminate removes the container, with the data of the datasource.
+func (c *Container) Terminate() error {
+	_, err := docker(context.Background(), "rm", "--force", "--volumes", c.ID)
+
+	return err
+}
+
+// Address returns the host and port on which the datasource is reachable.
+func (c *Container) Address() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+// Config returns the configs of the application to connect to the datasource.
+func (c *Container) Config() map[string]string {
+	return c.spec.config(c.Port)
+}
+
+// Setenv sets the configs of the application to connect to the datasource as the environment of the test.
+func (c *Container) Setenv(t testing.TB) {
+	t.Helper()
+
+	for k, v := range c.Config() {
+		t.Setenv(k, v)
+	}
+}
+
+// Migrate runs the migrations against the datasource.
+func (c *Container) Migrate(t testing.TB, migrations map[int64]migration.Migrate) {
+	t.Helper()
+
+	cntnr := container.NewContainer(nil)
+	cntnr.Logger = testLogger{Logger: logging.NewLogger(logging.INFO), t: t}
+	cntnr.Create(config.NewMockConfig(c.Config()))
+
+	migration.Run(migrations, cntnr)
+}
+
+// Truncate empties the tables, keys or topics, or all of them, once the test completes.
+func (c *Container) Truncate(t testing.TB, names ...string) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		if err := c.TruncateNow(context.Background(), names...); err != nil {
+			t.Errorf("could not truncate %s: %v", c.spec.Image, err)
+		}
+	})
+}
+
+// TruncateNow empties the given tables, keys or topics of the datasource right away, or all of them if none are given.
+func (c *Container) TruncateNow(ctx context.Context, names ...string) error {
+	if c.spec.truncate == nil {
+		return errTruncateNotSupported
+	}
+
+	return c.spec.truncate(ctx, c, names)
+}
+
+// testLogger fails the test on the errors logged while running the migrations, as migration.Run only logs them.
+type testLogger struct {
+	logging.Logger
+
+	t testing.TB
+}
+
+func (l testLogger) Error(args ...interface{}) {
+	l.t.Helper()
+	l.t.Error(args...)
+}
+
+func (l testLogger) Errorf(format string, args ...interface{}) {
+	l.t.Helper()
+	l.t.Errorf(format, args...)
+}
+
+func docker(ctx context.Context, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "docker", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// freePort returns a free port of the host, chosen before the container is started.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", net.JoinHostPort(host, "0"))
+	if err != nil {
+		return 0, err
+	}
+
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,125 @@
+package containers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDocker puts a docker CLI on the PATH which prints an ID for "run" and records the arguments of the calls.
+func fakeDocker(t *testing.T) (calls func() []string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	log := filepath.Join(dir, "calls")
+
+	script := "#!/bin/sh\necho \"$@\" >> " + log + "\n[ \"$1\" = run ] && echo container-id\nexit 0\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0o700)) //nolint:gosec // must be executable
+
+	t.Setenv("PATH", dir)
+
+	return func() []string {
+		out, _ := os.ReadFile(log)
+		return strings.Split(strings.TrimSpace(string(out)), "\n")
+	}
+}
+
+func testSpec() Spec {
+	return Spec{
+		Image:  "datasource:1",
+		Port:   1234,
+		env:    func(int) []string { return []string{"PORT=1234"} },
+		config: func(int) map[string]string { return map[string]string{"DS_HOST": host} },
+		ready:  func(context.Context, *Container) error { return nil },
+	}
+}
+
+func TestStart(t *testing.T) {
+	calls := fakeDocker(t)
+
+	c, err := Start(context.Background(), testSpec())
+	require.NoError(t, err)
+
+	assert.Equal(t, "container-id", c.ID)
+	assert.Equal(t, host, c.Host)
+	assert.NotZero(t, c.Port)
+	assert.Equal(t, map[string]string{"DS_HOST": host}, c.Config())
+
+	require.NoError(t, c.Terminate())
+
+	assert.Equal(t, []string{
+		"run --detach --rm --publish " + c.Address() + ":1234 --env PORT=1234 datasource:1",
+		"rm --force --volumes container-id",
+	}, calls())
+}
+
+func TestStart_DockerUnavailable(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := Start(context.Background(), testSpec())
+
+	assert.ErrorIs(t, err, ErrDockerUnavailable)
+}
+
+func TestRun(t *testing.T) {
+	calls := fakeDocker(t)
+
+	t.Run("container", func(t *testing.T) {
+		c := Run(t, testSpec())
+		c.Setenv(t)
+
+		assert.Equal(t, host, os.Getenv("DS_HOST"))
+		assert.ErrorIs(t, c.TruncateNow(context.Background()), errTruncateNotSupported)
+	})
+
+	assert.Len(t, calls(), 2, "container should be removed once the test completes")
+}
+
+func TestSpecs_Config(t *testing.T) {
+	testCases := []struct {
+		spec      Spec
+		expConfig map[string]string
+	}{
+		{Postgres(), map[string]string{"DB_DIALECT": "postgres", "DB_HOST": host, "DB_PORT": "4321", "DB_USER": "gofr",
+			"DB_PASSWORD": "password", "DB_NAME": "test"}},
+		{MySQL(), map[string]string{"DB_DIALECT": "mysql", "DB_HOST": host, "DB_PORT": "4321", "DB_USER": "root",
+			"DB_PASSWORD": "password", "DB_NAME": "test"}},
+		{Redis(), map[string]string{"REDIS_HOST": host, "REDIS_PORT": "4321"}},
+		{Kafka(), map[string]string{"PUBSUB_BACKEND": "KAFKA", "PUBSUB_BROKER": host + ":4321", "CONSUMER_ID": "gofr-test",
+			"PUBSUB_OFFSET": "-2"}},
+	}
+
+	for i, tc := range testCases {
+		c := &Container{Host: host, Port: 4321, spec: tc.spec}
+
+		assert.Equal(t, tc.expConfig, c.Config(), "TEST[%d], Failed.\n%s", i, tc.spec.Image)
+	}
+
+	assert.Contains(t, runArgs(Kafka(), 4321), "KAFKA_CFG_ADVERTISED_LISTENERS=PLAINTEXT://127.0.0.1:4321",
+		"Kafka should advertise the address of the host")
+}
+
+func TestRedis(t *testing.T) {
+	c := Run(t, Redis())
+
+	ctx := context.Background()
+	r := redis.NewClient(&redis.Options{Addr: c.Address()})
+
+	defer r.Close()
+
+	require.NoError(t, r.Set(ctx, "gofr_migrations", "1", 0).Err())
+	require.NoError(t, r.Set(ctx, "order", "1", 0).Err())
+
+	require.NoError(t, c.TruncateNow(ctx))
+
+	keys, err := r.Keys(ctx, "*").Result()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"gofr_migrations"}, keys, "migrations should be kept on truncation")
+}
@@ -0,0 +1,270 @@
+package containers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	_ "github.com/go-sql-driver/mysql" // the driver of the MySQL container.
+	_ "github.com/lib/pq"              // the driver of the Postgres container.
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	testUser     = "gofr"
+	testPassword = "password"
+	testDatabase = "test"
+
+	// migrationsTable is the table, or the key, of the migrations, which is not truncated.
+	migrationsTable = "gofr_migrations"
+)
+
+// Postgres returns the spec of a PostgreSQL container, with the "test" database of the "gofr" user.
+func Postgres() Spec {
+	return Spec{
+		Image: "postgres:16-alpine",
+		Port:  5432,
+		env: func(int) []string {
+			return []string{"POSTGRES_USER=" + testUser, "POSTGRES_PASSWORD=" + testPassword, "POSTGRES_DB=" + testDatabase}
+		},
+		config: func(hostPort int) map[string]string {
+			return sqlConfig("postgres", testUser, hostPort)
+		},
+		ready: func(ctx context.Context, c *Container) error {
+			return pingSQL(ctx, c)
+		},
+		truncate: func(ctx context.Context, c *Container, tables []string) error {
+			return truncateSQL(ctx, c, tables,
+				"SELECT tablename FROM pg_tables WHERE schemaname = current_schema()",
+				"TRUNCATE TABLE %s RESTART IDENTITY CASCADE")
+		},
+	}
+}
+
+// MySQL returns the spec of a MySQL container, with the "test" database of the "root" user.
+func MySQL() Spec {
+	return Spec{
+		Image: "mysql:8.0",
+		Port:  3306,
+		env: func(int) []string {
+			return []string{"MYSQL_ROOT_PASSWORD=" + testPassword, "MYSQL_DATABASE=" + testDatabase}
+		},
+		config: func(hostPort int) map[string]string {
+			return sqlConfig("mysql", "root", hostPort)
+		},
+		ready: func(ctx context.Context, c *Container) error {
+			return pingSQL(ctx, c)
+		},
+		truncate: func(ctx context.Context, c *Container, tables []string) error {
+			return truncateSQL(ctx, c, tables,
+				"SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE()",
+				"TRUNCATE TABLE %s")
+		},
+	}
+}
+
+// Redis returns the spec of a Redis container.
+func Redis() Spec {
+	return Spec{
+		Image: "redis:7-alpine",
+		Port:  6379,
+		config: func(hostPort int) map[string]string {
+			return map[string]string{"REDIS_HOST": host, "REDIS_PORT": strconv.Itoa(hostPort)}
+		},
+		ready: func(ctx context.Context, c *Container) error {
+			return withRedis(c, func(r *redis.Client) error { return r.Ping(ctx).Err() })
+		},
+		truncate: func(ctx context.Context, c *Container, keys []string) error {
+			return withRedis(c, func(r *redis.Client) error {
+				if len(keys) == 0 {
+					all, err := r.Keys(ctx, "*").Result()
+					if err != nil {
+						return err
+					}
+
+					for _, k := range all {
+						if k != migrationsTable {
+							keys = append(keys, k)
+						}
+					}
+				}
+
+				if len(keys) == 0 {
+					return nil
+				}
+
+				return r.Del(ctx, keys...).Err()
+			})
+		},
+	}
+}
+
+// Kafka returns the spec of a single broker Kafka container.
+func Kafka() Spec {
+	return Spec{
+		Image: "bitnami/kafka:3.7",
+		Port:  9092,
+		env: func(hostPort int) []string {
+			return []string{
+				"KAFKA_CFG_NODE_ID=0",
+				"KAFKA_CFG_PROCESS_ROLES=controller,broker",
+				"KAFKA_CFG_CONTROLLER_QUORUM_VOTERS=0@localhost:9093",
+				"KAFKA_CFG_CONTROLLER_LISTENER_NAMES=CONTROLLER",
+				"KAFKA_CFG_LISTENERS=PLAINTEXT://:9092,CONTROLLER://:9093",
+				"KAFKA_CFG_LISTENER_SECURITY_PROTOCOL_MAP=CONTROLLER:PLAINTEXT,PLAINTEXT:PLAINTEXT",
+				fmt.Sprintf("KAFKA_CFG_ADVERTISED_LISTENERS=PLAINTEXT://%s:%d", host, hostPort),
+				"KAFKA_CFG_AUTO_CREATE_TOPICS_ENABLE=true",
+			}
+		},
+		config: func(hostPort int) map[string]string {
+			return map[string]string{
+				"PUBSUB_BACKEND": "KAFKA",
+				"PUBSUB_BROKER":  fmt.Sprintf("%s:%d", host, hostPort),
+				"CONSUMER_ID":    "gofr-test",
+				"PUBSUB_OFFSET":  "-2",
+			}
+		},
+		ready: func(ctx context.Context, c *Container) error {
+			conn, err := kafka.DialContext(ctx, "tcp", c.Address())
+			if err != nil {
+				return err
+			}
+
+			defer conn.Close()
+
+			_, err = conn.Brokers()
+
+			return err
+		},
+		truncate: func(ctx context.Context, c *Container, topics []string) error {
+			if len(topics) == 0 {
+				return nil
+			}
+
+			conn, err := kafka.DialContext(ctx, "tcp", c.Address())
+			if err != nil {
+				return err
+			}
+
+			defer conn.Close()
+
+			return conn.DeleteTopics(topics...)
+		},
+	}
+}
+
+func sqlConfig(dialect, user string, hostPort int) map[string]string {
+	return map[string]string{
+		"DB_DIALECT":  dialect,
+		"DB_HOST":     host,
+		"DB_PORT":     strconv.Itoa(hostPort),
+		"DB_USER":     user,
+		"DB_PASSWORD": testPassword,
+		"DB_NAME":     testDatabase,
+	}
+}
+
+func openSQL(c *Container) (*sql.DB, error) {
+	conf := c.Config()
+
+	if conf["DB_DIALECT"] == "postgres" {
+		return sql.Open("postgres", fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+			conf["DB_HOST"], conf["DB_PORT"], conf["DB_USER"], conf["DB_PASSWORD"], conf["DB_NAME"]))
+	}
+
+	return sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/%s",
+		conf["DB_USER"], conf["DB_PASSWORD"], conf["DB_HOST"], conf["DB_PORT"], conf["DB_NAME"]))
+}
+
+func pingSQL(ctx context.Context, c *Container) error {
+	db, err := openSQL(c)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	return db.PingContext(ctx)
+}
+
+// truncateSQL truncates the tables, or the tables listed by listQuery except the migrations table if none are given.
+func truncateSQL(ctx context.Context, c *Container, tables []string, listQuery, truncateQuery string) error {
+	db, err := openSQL(c)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	if len(tables) == 0 {
+		if tables, err = listTables(ctx, db, listQuery); err != nil {
+			return err
+		}
+	}
+
+	if len(tables) == 0 {
+		return nil
+	}
+
+	// the checks of the foreign keys are disabled on a single connection, as they are a setting of the session.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if c.Config()["DB_DIALECT"] == "mysql" {
+		if _, err := conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+			return err
+		}
+
+		for _, table := range tables {
+			if _, err := conn.ExecContext(ctx, fmt.Sprintf(truncateQuery, table)); err != nil {
+				return err
+			}
+		}
+
+		_, err = conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 1")
+
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf(truncateQuery, strings.Join(tables, ", ")))
+
+	return err
+}
+
+func listTables(ctx context.Context, db *sql.DB, query string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var tables []string
+
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, err
+		}
+
+		if table != migrationsTable {
+			tables = append(tables, table)
+		}
+	}
+
+	return tables, rows.Err()
+}
+
+func withRedis(c *Container, f func(r *redis.Client) error) error {
+	r := redis.NewClient(&redis.Options{Addr: c.Address()})
+	defer r.Close()
+
+	return f(r)
+}
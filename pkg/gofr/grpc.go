@@ -1,39 +1,179 @@
 package gofr
 
 import (
-	"net"
-	"strconv"
+	"context"
+	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc2 "github.com/peter-stratton/gofr/pkg/gofr/grpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+	"github.com/peter-stratton/gofr/pkg/gofr/metrics"
 )
 
 type grpcServer struct {
 	server *grpc.Server
 	port   int
+	// listen is GRPC_LISTEN, the unix socket or the inherited socket the server listens on instead of the port.
+	listen string
+	// reflection registers the reflection service, which lets clients like grpcurl discover the services.
+	reflection bool
+	// transcoding exposes the methods of the registered services as HTTP/JSON endpoints on the HTTP server.
+	transcoding bool
+
+	// unary is the chain of the unary interceptors, which also applies to the transcoded calls.
+	unary grpc.UnaryServerInterceptor
+
+	// oauthKeys validate the tokens of the calls if OAuth is enabled, and scopes are required by the methods.
+	oauthKeys middleware.PublicKeyProvider
+	scopes    middleware.Scopes
+
+	// requestTimeout is the deadline of the unary calls without one, if it is positive.
+	requestTimeout time.Duration
+
+	// tlsErr is the error in loading the TLS credentials, which prevents the server from starting without them.
+	tlsErr error
+
+	// the interceptors added by the application run after the ones of the framework, in the order they were added.
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
 }
 
-func newGRPCServer(c *container.Container, port int) *grpcServer {
-	return &grpcServer{
-		server: grpc.NewServer(
-			grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-				grpc_recovery.UnaryServerInterceptor(),
-				grpc2.LoggingInterceptor(c.Logger),
-			))),
-		port: port,
+func newGRPCServer(c *container.Container, port int, opts ...grpc.ServerOption) *grpcServer {
+	g := &grpcServer{port: port}
+
+	g.unary = grpc_middleware.ChainUnaryServer(
+		grpc_recovery.UnaryServerInterceptor(),
+		grpcContainerUnaryInterceptor(c),
+		g.deadlineUnaryInterceptor,
+		grpc2.LoggingInterceptor(c.Logger),
+		grpcMetricsUnaryInterceptor(c),
+		g.authUnaryInterceptor,
+		g.unaryInterceptor,
+	)
+
+	g.server = grpc.NewServer(append([]grpc.ServerOption{
+		grpc.UnaryInterceptor(g.unary),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			grpc_recovery.StreamServerInterceptor(),
+			grpcContainerStreamInterceptor(c),
+			grpc2.StreamLoggingInterceptor(c.Logger),
+			grpcMetricsStreamInterceptor(c),
+			g.authStreamInterceptor,
+			grpcStreamMessagesInterceptor(c),
+			g.streamInterceptor,
+		)),
+	}, opts...)...)
+
+	return g
+}
+
+// UseGRPCInterceptor adds interceptors to all the methods of the gRPC services. Either of them can be nil.
+func (a *App) UseGRPCInterceptor(unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) {
+	if unary != nil {
+		a.grpcServer.unaryInterceptors = append(a.grpcServer.unaryInterceptors, unary)
+	}
+
+	if stream != nil {
+		a.grpcServer.streamInterceptors = append(a.grpcServer.streamInterceptors, stream)
 	}
 }
 
+// unaryInterceptor chains the interceptors added by the application, which may be added after the server is created.
+func (g *grpcServer) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	return grpc_middleware.ChainUnaryServer(g.unaryInterceptors...)(ctx, req, info, handler)
+}
+
+// deadlineUnaryInterceptor sets the deadline of the calls without one.
+func (g *grpcServer) deadlineUnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if _, ok := ctx.Deadline(); ok || g.requestTimeout <= 0 {
+		return handler(ctx, req)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, g.requestTimeout)
+	defer cancel()
+
+	return handler(ctx, req)
+}
+
+func (g *grpcServer) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	return grpc_middleware.ChainStreamServer(g.streamInterceptors...)(srv, ss, info, handler)
+}
+
+func grpcMetricsUnaryInterceptor(c *container.Container) grpc.UnaryServerInterceptor {
+	responseTime := grpcResponseHistogram(c)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		recordGRPCMetrics(ctx, responseTime, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+func grpcMetricsStreamInterceptor(c *container.Container) grpc.StreamServerInterceptor {
+	responseTime := grpcResponseHistogram(c)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		recordGRPCMetrics(ss.Context(), responseTime, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// grpcResponseHistogram returns the handle to the histogram of the response time of the calls, or nil.
+func grpcResponseHistogram(c *container.Container) metrics.Histogram {
+	if c.Metrics() == nil {
+		return nil
+	}
+
+	return c.Metrics().Histogram("app_grpc_response")
+}
+
+func recordGRPCMetrics(ctx context.Context, responseTime metrics.Histogram, method string, start time.Time, err error) {
+	if responseTime == nil {
+		return
+	}
+
+	responseTime.Record(ctx, time.Since(start).Seconds(), "method", method, "code", status.Code(err).String())
+}
+
 func (g *grpcServer) Run(c *container.Container) {
-	addr := ":" + strconv.Itoa(g.port)
+	addr := listenAddress(g.listen, g.port)
+
+	if g.reflection {
+		reflection.Register(g.server)
+	}
+
+	// the health service of the application is not registered if the application registers its own.
+	if _, ok := g.server.GetServiceInfo()[grpc_health_v1.Health_ServiceDesc.ServiceName]; !ok {
+		grpc_health_v1.RegisterHealthServer(g.server, &grpcHealthServer{container: c, server: g.server, interval: grpcHealthWatchInterval})
+	}
 
 	c.Logger.Infof("starting gRPC server at %s", addr)
 
-	listener, err := net.Listen("tcp", addr)
+	if g.tlsErr != nil {
+		c.Logger.Errorf("error in starting gRPC server at %s: %s", addr, g.tlsErr)
+		return
+	}
+
+	listener, err := listen(g.listen, g.port)
 	if err != nil {
 		c.Logger.Errorf("error in starting gRPC server at %s: %s", addr, err)
 		return
@@ -44,3 +184,22 @@ func (g *grpcServer) Run(c *container.Container) {
 		return
 	}
 }
+
+// Shutdown stops accepting calls and waits for the pending ones, until ctx is done.
+func (g *grpcServer) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		g.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		g.server.Stop()
+
+		return ctx.Err()
+	}
+}
@@ -64,26 +64,8 @@ func LoggingInterceptor(logger Logger) grpc.UnaryServerInterceptor {
 		resp, err := handler(ctx, req)
 
 		defer func() {
-			l := RPCLog{
-				ID:           trace.SpanFromContext(ctx).SpanContext().TraceID().String(),
-				StartTime:    start.Format("2006-01-02T15:04:05.999999999-07:00"),
-				ResponseTime: time.Since(start).Milliseconds(),
-				Method:       info.FullMethod,
-			}
-
-			if err != nil {
-				// Check if the error is a gRPC status error
-				if statusErr, ok := status.FromError(err); ok {
-					// You can access the gRPC status code here
-					l.StatusCode = int32(statusErr.Code())
-				}
-			} else {
-				// If there was no error, you can access the response status code here
-				l.StatusCode = int32(codes.OK)
-			}
-
 			if logger != nil {
-				logger.Info(l)
+				logger.Info(newRPCLog(ctx, start, info.FullMethod, err))
 			}
 
 			span.End()
@@ -92,3 +74,54 @@ func LoggingInterceptor(logger Logger) grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+// StreamLoggingInterceptor traces and logs the streaming calls once the stream is closed.
+func StreamLoggingInterceptor(logger Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := otel.GetTracerProvider().Tracer("gofr",
+			trace.WithInstrumentationVersion("v0.1")).Start(ss.Context(), info.FullMethod)
+		defer span.End()
+
+		start := time.Now()
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+
+		if logger != nil {
+			logger.Info(newRPCLog(ctx, start, info.FullMethod, err))
+		}
+
+		return err
+	}
+}
+
+func newRPCLog(ctx context.Context, start time.Time, method string, err error) RPCLog {
+	l := RPCLog{
+		ID:           trace.SpanFromContext(ctx).SpanContext().TraceID().String(),
+		StartTime:    start.Format("2006-01-02T15:04:05.999999999-07:00"),
+		ResponseTime: time.Since(start).Milliseconds(),
+		Method:       method,
+	}
+
+	if err != nil {
+		// Check if the error is a gRPC status error
+		if statusErr, ok := status.FromError(err); ok {
+			// You can access the gRPC status code here
+			l.StatusCode = int32(statusErr.Code())
+		}
+	} else {
+		// If there was no error, you can access the response status code here
+		l.StatusCode = int32(codes.OK)
+	}
+
+	return l
+}
+
+// serverStream overrides the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
 	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
@@ -82,6 +84,31 @@ func TestLoggingInterceptor(t *testing.T) {
 	}
 }
 
+type testServerStream struct {
+	grpc.ServerStream
+}
+
+func (testServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestStreamLoggingInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/ExampleService/Chat"}
+
+	log := testutil.StdoutOutputForFunc(func() {
+		err := StreamLoggingInterceptor(logging.NewMockLogger(logging.INFO))(nil, testServerStream{}, info,
+			func(_ interface{}, ss grpc.ServerStream) error {
+				assert.NotEqual(t, context.Background(), ss.Context(), "stream should have the context of the span")
+
+				return status.Error(codes.Canceled, "cancelled")
+			})
+
+		assert.Equal(t, codes.Canceled, status.Code(err))
+	})
+
+	assert.Contains(t, log, `"method":"/ExampleService/Chat","statusCode":1`)
+}
+
 func Test_colorForGRPCCode(t *testing.T) {
 	testCases := []struct {
 		desc      string
@@ -0,0 +1,120 @@
+package gofr
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+)
+
+var errInvalidClientCA = errors.New("no certificates found in GRPC_TLS_CLIENT_CA_FILE")
+
+// grpcTLSCredentials returns the TLS credentials of GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE, or nil.
+func grpcTLSCredentials(conf config.Config) (credentials.TransportCredentials, error) {
+	certFile, keyFile := conf.Get("GRPC_TLS_CERT_FILE"), conf.Get("GRPC_TLS_KEY_FILE")
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if caFile := conf.Get("GRPC_TLS_CLIENT_CA_FILE"); caFile != "" {
+		ca, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errInvalidClientCA
+		}
+
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func (g *grpcServer) authUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := g.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (g *grpcServer) authStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	ctx, err := g.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &grpcServerStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorize validates the bearer token of the call and checks its scopes, if OAuth is enabled.
+func (g *grpcServer) authorize(ctx context.Context, method string) (context.Context, error) {
+	if len(g.scopes[method]) == 0 && (g.oauthKeys == nil || isGRPCFrameworkMethod(method)) {
+		return ctx, nil
+	}
+
+	if g.oauthKeys == nil {
+		return ctx, status.Errorf(codes.Unauthenticated, "OAuth is not enabled to authorize %s", method)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	claims, err := middleware.ParseToken(g.oauthKeys, grpcMetadataCarrier(md).Get("authorization"))
+	if err != nil {
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if missing := g.scopes.Missing(method, claims); len(missing) > 0 {
+		return ctx, status.Errorf(codes.PermissionDenied, "token is missing the scopes: %s", strings.Join(missing, " "))
+	}
+
+	return context.WithValue(ctx, middleware.JWTClaim("JWTClaims"), claims), nil
+}
+
+func isGRPCFrameworkMethod(method string) bool {
+	return strings.HasPrefix(method, "/"+grpc_health_v1.Health_ServiceDesc.ServiceName+"/") ||
+		strings.HasPrefix(method, "/grpc.reflection.")
+}
+
+// RequireScopes declares the OAuth scopes required to call an HTTP route, like "GET /customers/{id}", or a gRPC method,
+// like "/customer.CustomerService/GetCustomer".
+func (a *App) RequireScopes(route string, scopes ...string) {
+	if a.scopes == nil {
+		a.scopes = make(middleware.Scopes)
+	}
+
+	if a.grpcServer != nil {
+		a.grpcServer.scopes = a.scopes
+	}
+
+	a.scopes[route] = append(a.scopes[route], scopes...)
+}
@@ -0,0 +1,235 @@
+package gofr
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+type testPublicKeys struct {
+	key *rsa.PublicKey
+}
+
+func (k testPublicKeys) Get(kid string) *rsa.PublicKey {
+	if kid == "test" {
+		return k.key
+	}
+
+	return nil
+}
+
+func TestGRPCServer_Authorize(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	token := func(scope string) string {
+		jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "user", "scope": scope})
+		jwtToken.Header["kid"] = "test"
+
+		signed, err := jwtToken.SignedString(privateKey)
+		require.NoError(t, err)
+
+		return "Bearer " + signed
+	}
+
+	const method = "/customer.CustomerService/DeleteCustomer"
+
+	testCases := []struct {
+		desc          string
+		oauth         bool
+		method        string
+		authorization string
+		expCode       codes.Code
+	}{
+		{"OAuth disabled without scopes", false, method, "", codes.OK},
+		{"OAuth disabled with scopes", false, "/customer.CustomerService/GetCustomer", "", codes.Unauthenticated},
+		{"health service", true, "/grpc.health.v1.Health/Check", "", codes.OK},
+		{"missing token", true, method, "", codes.Unauthenticated},
+		{"invalid token", true, method, "Bearer invalid", codes.Unauthenticated},
+		{"missing scope", true, "/customer.CustomerService/GetCustomer", token("customers:write"), codes.PermissionDenied},
+		{"granted scope", true, "/customer.CustomerService/GetCustomer", token("customers:read"), codes.OK},
+		{"valid token without scopes required", true, method, token(""), codes.OK},
+	}
+
+	for i, tc := range testCases {
+		g := &grpcServer{scopes: middleware.Scopes{"/customer.CustomerService/GetCustomer": {"customers:read"}}}
+		if tc.oauth {
+			g.oauthKeys = testPublicKeys{key: &privateKey.PublicKey}
+		}
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", tc.authorization))
+
+		ctx, err := g.authorize(ctx, tc.method)
+
+		assert.Equal(t, tc.expCode, status.Code(err), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.expCode == codes.OK && tc.authorization != "" {
+			assert.NotNil(t, ctx.Value(middleware.JWTClaim("JWTClaims")), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestApp_RequireScopes(t *testing.T) {
+	a := New()
+
+	a.RequireScopes("GET /customers", "customers:read")
+	a.RequireScopes("GET /customers", "customers:list")
+
+	assert.Equal(t, []string{"customers:read", "customers:list"}, a.scopes["GET /customers"])
+	assert.Equal(t, a.scopes, a.grpcServer.scopes, "gRPC server should share the scopes of the app")
+}
+
+func TestGRPCTLSCredentials(t *testing.T) {
+	dir := t.TempDir()
+	caFile, certFile, keyFile := writeTestCertificates(t, dir)
+
+	invalidCA := filepath.Join(dir, "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidCA, []byte("invalid"), 0o600))
+
+	testCases := []struct {
+		desc    string
+		configs map[string]string
+		expNil  bool
+		expErr  error
+	}{
+		{"TLS disabled", map[string]string{}, true, nil},
+		{"TLS", map[string]string{"GRPC_TLS_CERT_FILE": certFile, "GRPC_TLS_KEY_FILE": keyFile}, false, nil},
+		{"mutual TLS", map[string]string{"GRPC_TLS_CERT_FILE": certFile, "GRPC_TLS_KEY_FILE": keyFile,
+			"GRPC_TLS_CLIENT_CA_FILE": caFile}, false, nil},
+		{"invalid client CA", map[string]string{"GRPC_TLS_CERT_FILE": certFile, "GRPC_TLS_KEY_FILE": keyFile,
+			"GRPC_TLS_CLIENT_CA_FILE": invalidCA}, true, errInvalidClientCA},
+	}
+
+	for i, tc := range testCases {
+		creds, err := grpcTLSCredentials(config.NewMockConfig(tc.configs))
+
+		assert.Equal(t, tc.expErr, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expNil, creds == nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	_, err := grpcTLSCredentials(config.NewMockConfig(map[string]string{"GRPC_TLS_CERT_FILE": "missing.pem"}))
+	assert.Error(t, err)
+}
+
+func TestGRPCServer_MutualTLS(t *testing.T) {
+	caFile, certFile, keyFile := writeTestCertificates(t, t.TempDir())
+
+	creds, err := grpcTLSCredentials(config.NewMockConfig(map[string]string{"GRPC_TLS_CERT_FILE": certFile,
+		"GRPC_TLS_KEY_FILE": keyFile, "GRPC_TLS_CLIENT_CA_FILE": caFile}))
+	require.NoError(t, err)
+
+	c := &container.Container{Logger: logging.NewLogger(logging.INFO)}
+	g := newGRPCServer(c, 9999, grpc.Creds(creds))
+	grpc_health_v1.RegisterHealthServer(g.server, &grpcHealthServer{container: c, server: g.server, interval: time.Second})
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	go func() { _ = g.server.Serve(listener) }()
+
+	defer g.server.Stop()
+
+	ca, err := os.ReadFile(caFile)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca)
+
+	// the certificate of the server is used as the client certificate, as it is signed by the same CA.
+	clientCert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	require.NoError(t, err)
+
+	check := func(certs ...tls.Certificate) error {
+		conn, err := grpc.NewClient("passthrough:///localhost",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+				RootCAs: pool, Certificates: certs, ServerName: "localhost", MinVersion: tls.VersionTLS12,
+			})))
+		require.NoError(t, err)
+
+		defer conn.Close()
+
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+		return err
+	}
+
+	assert.NoError(t, check(clientCert))
+	assert.Equal(t, codes.Unavailable, status.Code(check()), "client without certificate should be rejected")
+}
+
+// writeTestCertificates writes a CA, and a certificate for localhost signed by it which is valid for both servers and
+// clients, returning the paths of the CA, the certificate and its key.
+func writeTestCertificates(t *testing.T, dir string) (caFile, certFile, keyFile string) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gofr test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	certDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}, caTemplate, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	write := func(name, blockType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+
+		return path
+	}
+
+	return write("ca.pem", "CERTIFICATE", caDER), write("cert.pem", "CERTIFICATE", certDER),
+		write("key.pem", "EC PRIVATE KEY", keyDER)
+}
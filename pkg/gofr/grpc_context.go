@@ -0,0 +1,119 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+)
+
+var errGRPCBind = errors.New("gRPC requests are received as typed messages and cannot be bound")
+
+type grpcContainerKey struct{}
+
+// GRPCContext returns the Context of the gRPC call of ctx. It has no container if the call is not served by the app.
+func GRPCContext(ctx context.Context) *Context {
+	c, _ := ctx.Value(grpcContainerKey{}).(*container.Container)
+
+	return &Context{
+		Context:   ctx,
+		Request:   grpcRequest{ctx: ctx},
+		Container: c,
+	}
+}
+
+// grpcContainerUnaryInterceptor makes the container available through GRPCContext.
+func grpcContainerUnaryInterceptor(c *container.Container) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withGRPCContainer(ctx, c), req)
+	}
+}
+
+func grpcContainerStreamInterceptor(c *container.Container) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &grpcServerStream{ServerStream: ss, ctx: withGRPCContainer(ss.Context(), c)})
+	}
+}
+
+func withGRPCContainer(ctx context.Context, c *container.Container) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, grpcMetadataCarrier(md))
+	}
+
+	return context.WithValue(ctx, grpcContainerKey{}, c)
+}
+
+// grpcServerStream overrides the context of a stream.
+type grpcServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *grpcServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// grpcMetadataCarrier adapts the metadata of a call to propagate the trace.
+type grpcMetadataCarrier metadata.MD
+
+func (m grpcMetadataCarrier) Get(key string) string {
+	if v := metadata.MD(m).Get(key); len(v) > 0 {
+		return v[0]
+	}
+
+	return ""
+}
+
+func (m grpcMetadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+func (m grpcMetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// grpcRequest exposes the incoming metadata of a gRPC call as a Request.
+type grpcRequest struct {
+	ctx context.Context
+}
+
+func (r grpcRequest) Context() context.Context {
+	return r.ctx
+}
+
+func (r grpcRequest) Param(key string) string {
+	return r.GetHeader(key)
+}
+
+func (r grpcRequest) PathParam(key string) string {
+	return r.GetHeader(key)
+}
+
+func (r grpcRequest) Bind(interface{}) error {
+	return errGRPCBind
+}
+
+func (r grpcRequest) HostName() string {
+	if p, ok := peer.FromContext(r.ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+
+	return ""
+}
+
+func (r grpcRequest) GetHeader(key string) string {
+	md, _ := metadata.FromIncomingContext(r.ctx)
+
+	return grpcMetadataCarrier(md).Get(key)
+}
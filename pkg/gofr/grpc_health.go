@@ -0,0 +1,80 @@
+package gofr
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+const grpcHealthWatchInterval = 5 * time.Second
+
+// grpcHealthServer implements grpc.health.v1 with the readiness of the application and the health of its dependencies.
+type grpcHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	container *container.Container
+	server    *grpc.Server
+	interval  time.Duration
+}
+
+func (h *grpcHealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	s := h.status(ctx, req.GetService())
+	if s == grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, status.Errorf(codes.NotFound, "unknown service %s", req.GetService())
+	}
+
+	return &grpc_health_v1.HealthCheckResponse{Status: s}, nil
+}
+
+// Watch sends the status of the service right away and then whenever it changes, until the client cancels the call.
+func (h *grpcHealthServer) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	last := grpc_health_v1.HealthCheckResponse_ServingStatus(-1)
+
+	for {
+		if s := h.status(stream.Context(), req.GetService()); s != last {
+			if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: s}); err != nil {
+				return err
+			}
+
+			last = s
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *grpcHealthServer) status(ctx context.Context, service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	r := h.container.Ready(ctx)
+
+	if _, ok := h.server.GetServiceInfo()[service]; service == "" || ok {
+		if r.Status == datasource.StatusDown {
+			return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+
+	if _, ok := r.Checks[service]; !ok {
+		return grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+
+	if contains(r.Unhealthy(), service) {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
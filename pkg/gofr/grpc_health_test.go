@@ -0,0 +1,150 @@
+package gofr
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+// serveGRPC serves the server on an in-memory listener and returns a connection to it.
+func serveGRPC(t *testing.T, server *grpc.Server) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestGRPCHealthServer_Check(t *testing.T) {
+	c := &container.Container{Logger: logging.NewLogger(logging.INFO)}
+	c.AddHealthCheck("cache", func(context.Context) datasource.Health {
+		return datasource.Health{Status: datasource.StatusDown}
+	}, false)
+	c.AddHealthCheck("queue", func(context.Context) datasource.Health {
+		return datasource.Health{Status: datasource.StatusUp}
+	}, true)
+
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, &grpcHealthServer{container: c, server: server, interval: time.Second})
+
+	client := grpc_health_v1.NewHealthClient(serveGRPC(t, server))
+
+	testCases := []struct {
+		desc    string
+		service string
+		status  grpc_health_v1.HealthCheckResponse_ServingStatus
+	}{
+		{"application is degraded", "", grpc_health_v1.HealthCheckResponse_SERVING},
+		{"registered service", grpc_health_v1.Health_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING},
+		{"dependency down", "cache", grpc_health_v1.HealthCheckResponse_NOT_SERVING},
+		{"dependency up", "queue", grpc_health_v1.HealthCheckResponse_SERVING},
+	}
+
+	for i, tc := range testCases {
+		resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: tc.service})
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.status, resp.GetStatus(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCHealthServer_Watch(t *testing.T) {
+	var up atomic.Bool
+
+	c := &container.Container{Logger: logging.NewLogger(logging.INFO)}
+	c.AddHealthCheck("cache", func(context.Context) datasource.Health {
+		if up.Load() {
+			return datasource.Health{Status: datasource.StatusUp}
+		}
+
+		return datasource.Health{Status: datasource.StatusDown}
+	}, true)
+
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, &grpcHealthServer{container: c, server: server, interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := grpc_health_v1.NewHealthClient(serveGRPC(t, server)).Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+	up.Store(true)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+}
+
+func TestGRPCServer_HealthService(t *testing.T) {
+	c := &container.Container{Logger: logging.NewLogger(logging.INFO)}
+
+	g := newGRPCServer(c, 99999)
+
+	// the server fails to listen on the invalid port, after the health service is registered
+	g.Run(c)
+
+	_, ok := g.server.GetServiceInfo()[grpc_health_v1.Health_ServiceDesc.ServiceName]
+	assert.True(t, ok)
+}
+
+func TestApp_UseGRPCInterceptor(t *testing.T) {
+	c := &container.Container{Logger: logging.NewLogger(logging.INFO)}
+	a := &App{container: c, grpcServer: newGRPCServer(c, 9999)}
+
+	var calls []string
+
+	a.UseGRPCInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		calls = append(calls, "first")
+
+		return handler(ctx, req)
+	}, nil)
+
+	a.UseGRPCInterceptor(func(context.Context, interface{}, *grpc.UnaryServerInfo, grpc.UnaryHandler) (interface{}, error) {
+		calls = append(calls, "second")
+
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}, nil)
+
+	grpc_health_v1.RegisterHealthServer(a, &grpcHealthServer{container: c, server: a.grpcServer.server, interval: time.Second})
+
+	_, err := grpc_health_v1.NewHealthClient(serveGRPC(t, a.grpcServer.server)).
+		Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
@@ -0,0 +1,168 @@
+package gofr
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/metrics"
+)
+
+// GRPCStream gives the streaming methods typed access to the messages of a stream and the Context of the call.
+type GRPCStream[Req, Resp any] struct {
+	grpc.ServerStream
+
+	ctx *Context
+}
+
+// NewGRPCStream wraps the stream passed to a streaming method.
+func NewGRPCStream[Req, Resp any](stream grpc.ServerStream) *GRPCStream[Req, Resp] {
+	return &GRPCStream[Req, Resp]{ServerStream: stream, ctx: GRPCContext(stream.Context())}
+}
+
+// Ctx returns the Context of the call, which gives access to the logger, datasources and services of the application.
+func (s *GRPCStream[Req, Resp]) Ctx() *Context {
+	return s.ctx
+}
+
+// Recv receives the next message from the client. It returns io.EOF once the client has closed its side of the stream.
+func (s *GRPCStream[Req, Resp]) Recv() (*Req, error) {
+	m := new(Req)
+
+	if err := s.RecvMsg(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Send sends a message to the client, blocking while the flow control window of the stream is exhausted.
+func (s *GRPCStream[Req, Resp]) Send(m *Resp) error {
+	return s.SendMsg(m)
+}
+
+// Range calls f for every message received from the client, until the stream is closed or f returns an error.
+func (s *GRPCStream[Req, Resp]) Range(f func(*Req) error) error {
+	for {
+		m, err := s.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := f(m); err != nil {
+			return err
+		}
+	}
+}
+
+// SendAll sends the messages of ch to the client until ch is closed or the call is cancelled.
+func (s *GRPCStream[Req, Resp]) SendAll(ch <-chan *Resp) error {
+	for {
+		select {
+		case <-s.Context().Done():
+			return s.Context().Err()
+		case m, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := s.Send(m); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// grpcStreamMessagesInterceptor records the messages of the streams and the time their sending is blocked.
+func grpcStreamMessagesInterceptor(c *container.Container) grpc.StreamServerInterceptor {
+	var m *grpcStreamMetrics
+
+	if c.Metrics() != nil {
+		m = &grpcStreamMetrics{
+			sendWait: c.Metrics().Histogram("app_grpc_stream_send_wait"),
+			sent:     c.Metrics().Counter("app_grpc_stream_messages_sent"),
+			received: c.Metrics().Counter("app_grpc_stream_messages_received"),
+		}
+	}
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &grpcMonitoredStream{ServerStream: ss, metrics: m, method: info.FullMethod})
+	}
+}
+
+// grpcStreamMetrics are the handles to the metrics of the streams, which are resolved once for all the streams.
+type grpcStreamMetrics struct {
+	sendWait metrics.Histogram
+	sent     metrics.Counter
+	received metrics.Counter
+}
+
+type grpcMonitoredStream struct {
+	grpc.ServerStream
+
+	// metrics is nil if the container has no metrics.
+	metrics *grpcStreamMetrics
+	method  string
+
+	sent     atomic.Int64
+	received atomic.Int64
+}
+
+func (s *grpcMonitoredStream) SendMsg(m interface{}) error {
+	start := time.Now()
+
+	err := s.ServerStream.SendMsg(m)
+	if err != nil {
+		return err
+	}
+
+	s.recordMessage("sent", s.sent.Add(1), m)
+
+	if s.metrics != nil {
+		s.metrics.sendWait.Record(s.Context(), time.Since(start).Seconds(), "method", s.method)
+	}
+
+	return nil
+}
+
+func (s *grpcMonitoredStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+
+	s.recordMessage("received", s.received.Add(1), m)
+
+	return nil
+}
+
+func (s *grpcMonitoredStream) recordMessage(direction string, id int64, m interface{}) {
+	attrs := []attribute.KeyValue{attribute.Int64("message.id", id)}
+
+	if msg, ok := m.(proto.Message); ok {
+		attrs = append(attrs, attribute.Int("message.size", proto.Size(msg)))
+	}
+
+	trace.SpanFromContext(s.Context()).AddEvent("message "+direction, trace.WithAttributes(attrs...))
+
+	if s.metrics == nil {
+		return
+	}
+
+	if direction == "sent" {
+		s.metrics.sent.Increment(s.Context(), "method", s.method)
+	} else {
+		s.metrics.received.Increment(s.Context(), "method", s.method)
+	}
+}
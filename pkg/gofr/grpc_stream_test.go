@@ -0,0 +1,147 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
+)
+
+// testEchoService is a bidirectional streaming service, which sends back every message it receives in upper case.
+var testEchoService = grpc.ServiceDesc{
+	ServiceName: "gofr.test.Echo",
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Echo",
+		ServerStreams: true,
+		ClientStreams: true,
+		Handler: func(_ interface{}, stream grpc.ServerStream) error {
+			s := NewGRPCStream[wrapperspb.StringValue, wrapperspb.StringValue](stream)
+
+			if s.Ctx().Container == nil {
+				return errors.New("container is not available") //nolint:goerr113 // the error is only for the test
+			}
+
+			return s.Range(func(m *wrapperspb.StringValue) error {
+				return s.Send(wrapperspb.String(strings.ToUpper(m.GetValue())))
+			})
+		},
+	}},
+}
+
+func TestGRPCStream(t *testing.T) {
+	var received []string
+
+	recorder := tracetest.NewSpanRecorder()
+
+	logs := testutil.StdoutOutputForFunc(func() {
+		a := New()
+
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+		a.RegisterGRPCService(&testEchoService, struct{}{})
+
+		conn := serveGRPC(t, a.grpcServer.server)
+
+		stream, err := conn.NewStream(context.Background(), &testEchoService.Streams[0], "/gofr.test.Echo/Echo")
+		require.NoError(t, err)
+
+		for _, v := range []string{"hello", "world"} {
+			require.NoError(t, stream.SendMsg(wrapperspb.String(v)))
+		}
+
+		require.NoError(t, stream.CloseSend())
+
+		for {
+			m := &wrapperspb.StringValue{}
+
+			if err := stream.RecvMsg(m); err != nil {
+				assert.Equal(t, io.EOF, err)
+
+				break
+			}
+
+			received = append(received, m.GetValue())
+		}
+	})
+
+	assert.Equal(t, []string{"HELLO", "WORLD"}, received)
+	assert.Contains(t, logs, `"method":"/gofr.test.Echo/Echo","statusCode":0`)
+
+	require.Len(t, recorder.Ended(), 1)
+
+	var events []string
+
+	for _, e := range recorder.Ended()[0].Events() {
+		events = append(events, e.Name)
+	}
+
+	assert.Equal(t, []string{"message received", "message sent", "message received", "message sent"}, events)
+}
+
+func TestGRPCStream_SendAll(t *testing.T) {
+	desc := grpc.ServiceDesc{
+		ServiceName: "gofr.test.Counter",
+		HandlerType: (*interface{})(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "Count",
+			ServerStreams: true,
+			Handler: func(_ interface{}, stream grpc.ServerStream) error {
+				s := NewGRPCStream[wrapperspb.Int32Value, wrapperspb.Int32Value](stream)
+
+				from, err := s.Recv()
+				if err != nil {
+					return err
+				}
+
+				ch := make(chan *wrapperspb.Int32Value)
+
+				go func() {
+					defer close(ch)
+
+					for i := from.GetValue(); i < from.GetValue()+3; i++ {
+						ch <- wrapperspb.Int32(i)
+					}
+				}()
+
+				return s.SendAll(ch)
+			},
+		}},
+	}
+
+	a := New()
+
+	a.RegisterGRPCService(&desc, struct{}{})
+
+	conn := serveGRPC(t, a.grpcServer.server)
+
+	stream, err := conn.NewStream(context.Background(), &desc.Streams[0], "/gofr.test.Counter/Count")
+	require.NoError(t, err)
+
+	require.NoError(t, stream.SendMsg(wrapperspb.Int32(5)))
+	require.NoError(t, stream.CloseSend())
+
+	var received []int32
+
+	for {
+		m := &wrapperspb.Int32Value{}
+		if err := stream.RecvMsg(m); err != nil {
+			break
+		}
+
+		received = append(received, m.GetValue())
+	}
+
+	assert.Equal(t, []int32{5, 6, 7}, received)
+}
@@ -1,10 +1,17 @@
 package gofr
 
 import (
+	"context"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
@@ -28,8 +35,8 @@ func TestGRPC_ServerRun(t *testing.T) {
 		port       int
 		expLog     string
 	}{
-		{"net.Listen() error", nil, 99999, "error in starting gRPC server"},
-		{"server.Serve() error", new(grpc.Server), 10000, "error in starting gRPC server"},
+		{"net.Listen() error", grpc.NewServer(), 99999, "error in starting gRPC server"},
+		{"server.Serve() error", stoppedGRPCServer(), 10000, "error in starting gRPC server"},
 	}
 
 	for i, tc := range testCases {
@@ -51,3 +58,117 @@ func TestGRPC_ServerRun(t *testing.T) {
 		assert.Contains(t, out, tc.expLog, "TEST[%d], Failed.\n", i)
 	}
 }
+
+func stoppedGRPCServer() *grpc.Server {
+	s := grpc.NewServer()
+	s.Stop()
+
+	return s
+}
+
+type testHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	container chan *container.Container
+	tenant    chan string
+}
+
+func (s *testHealthServer) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	gctx := GRPCContext(ctx)
+
+	s.container <- gctx.Container
+	s.tenant <- gctx.Param("x-tenant")
+
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func TestApp_RegisterGRPCService(t *testing.T) {
+	c, _ := container.NewMockContainer(t)
+	a := &App{container: c, grpcServer: newGRPCServer(c, 9999)}
+
+	impl := &testHealthServer{container: make(chan *container.Container, 1), tenant: make(chan string, 1)}
+
+	grpc_health_v1.RegisterHealthServer(a, impl)
+
+	assert.True(t, a.grpcRegistered)
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	go func() { _ = a.grpcServer.server.Serve(listener) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-tenant", "acme")
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+	assert.Equal(t, c, <-impl.container, "service should access the container of the app")
+	assert.Equal(t, "acme", <-impl.tenant)
+
+	assert.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestGRPCServer_Reflection(t *testing.T) {
+	c := &container.Container{Logger: logging.NewLogger(logging.INFO)}
+
+	g := newGRPCServer(c, 99999)
+	g.reflection = true
+
+	// the server fails to listen on the invalid port, after the reflection service is registered
+	g.Run(c)
+
+	_, ok := g.server.GetServiceInfo()["grpc.reflection.v1.ServerReflection"]
+	assert.True(t, ok)
+}
+
+func TestGRPCContext_WithoutContainer(t *testing.T) {
+	ctx := GRPCContext(context.Background())
+
+	assert.Nil(t, ctx.Container)
+	assert.Empty(t, ctx.HostName())
+	assert.Empty(t, ctx.Param("x-tenant"))
+	assert.Equal(t, errGRPCBind, ctx.Bind(&struct{}{}))
+}
+
+func TestGRPCServer_DeadlineUnaryInterceptor(t *testing.T) {
+	g := &grpcServer{requestTimeout: time.Minute}
+
+	callerCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	callerDeadline, _ := callerCtx.Deadline()
+
+	testCases := []struct {
+		desc    string
+		ctx     context.Context
+		timeout time.Duration
+		check   func(deadline time.Time, ok bool) bool
+	}{
+		{"default deadline", context.Background(), time.Minute, func(deadline time.Time, ok bool) bool {
+			return ok && time.Until(deadline) > 50*time.Second
+		}},
+		{"deadline of the caller", callerCtx, time.Minute, func(deadline time.Time, ok bool) bool {
+			return ok && deadline.Equal(callerDeadline)
+		}},
+		{"no default deadline", context.Background(), 0, func(_ time.Time, ok bool) bool { return !ok }},
+	}
+
+	for i, tc := range testCases {
+		g.requestTimeout = tc.timeout
+
+		_, err := g.deadlineUnaryInterceptor(tc.ctx, nil, &grpc.UnaryServerInfo{},
+			func(ctx context.Context, _ interface{}) (interface{}, error) {
+				assert.True(t, tc.check(ctx.Deadline()), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+				return nil, nil
+			})
+
+		assert.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
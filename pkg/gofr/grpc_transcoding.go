@@ -0,0 +1,407 @@
+package gofr
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+)
+
+// statusClientClosedRequest is the non-standard HTTP status of the cancelled calls.
+const statusClientClosedRequest = 499
+
+// grpcPathVariable matches the variables of the path templates of google.api.http, like {id} or {name=shelves/*}.
+var grpcPathVariable = regexp.MustCompile(`{([^}=]+)(=([^}]*))?}`)
+
+// grpcTranscodingRule is the HTTP binding of a gRPC method.
+type grpcTranscodingRule struct {
+	method       string
+	pattern      string
+	body         string
+	responseBody string
+}
+
+// addGRPCTranscoding exposes the unary methods of a gRPC service as HTTP/JSON endpoints, as per google.api.http.
+func (a *App) addGRPCTranscoding(desc *grpc.ServiceDesc, impl interface{}) {
+	methods := grpcMethodDescriptors(desc.ServiceName)
+
+	for i := range desc.Methods {
+		m := &desc.Methods[i]
+
+		rules := grpcTranscodingRules(methods[m.MethodName])
+		if len(rules) == 0 {
+			rules = []grpcTranscodingRule{{
+				method:  http.MethodPost,
+				pattern: "/" + desc.ServiceName + "/" + m.MethodName,
+				body:    "*",
+			}}
+		}
+
+		for _, rule := range rules {
+			a.container.Logger.Infof("transcoding %s %s to gRPC method %s/%s", rule.method, rule.pattern,
+				desc.ServiceName, m.MethodName)
+
+			a.httpRegistered = true
+			a.httpServer.router.Add(rule.method, grpcRoutePattern(rule.pattern), grpcTranscodingHandler{
+				rule:        rule,
+				service:     impl,
+				method:      m,
+				fullMethod:  "/" + desc.ServiceName + "/" + m.MethodName,
+				interceptor: a.grpcServer.unary,
+				timeout:     a.Config.GetOrDefault("REQUEST_TIMEOUT", "5"),
+			})
+		}
+	}
+}
+
+// grpcMethodDescriptors returns the descriptors of the methods of the service by name, if its proto file is registered.
+func grpcMethodDescriptors(serviceName string) map[string]protoreflect.MethodDescriptor {
+	methods := make(map[string]protoreflect.MethodDescriptor)
+
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return methods
+	}
+
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return methods
+	}
+
+	for i := 0; i < sd.Methods().Len(); i++ {
+		md := sd.Methods().Get(i)
+		methods[string(md.Name())] = md
+	}
+
+	return methods
+}
+
+func grpcTranscodingRules(md protoreflect.MethodDescriptor) []grpcTranscodingRule {
+	if md == nil || md.Options() == nil {
+		return nil
+	}
+
+	httpRule, ok := proto.GetExtension(md.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if !ok || httpRule == nil {
+		return nil
+	}
+
+	rules := make([]grpcTranscodingRule, 0, 1+len(httpRule.GetAdditionalBindings()))
+
+	for _, r := range append([]*annotations.HttpRule{httpRule}, httpRule.GetAdditionalBindings()...) {
+		rule := grpcTranscodingRule{body: r.GetBody(), responseBody: r.GetResponseBody()}
+
+		switch p := r.GetPattern().(type) {
+		case *annotations.HttpRule_Get:
+			rule.method, rule.pattern = http.MethodGet, p.Get
+		case *annotations.HttpRule_Put:
+			rule.method, rule.pattern = http.MethodPut, p.Put
+		case *annotations.HttpRule_Post:
+			rule.method, rule.pattern = http.MethodPost, p.Post
+		case *annotations.HttpRule_Delete:
+			rule.method, rule.pattern = http.MethodDelete, p.Delete
+		case *annotations.HttpRule_Patch:
+			rule.method, rule.pattern = http.MethodPatch, p.Patch
+		case *annotations.HttpRule_Custom:
+			rule.method, rule.pattern = p.Custom.GetKind(), p.Custom.GetPath()
+		default:
+			continue
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// grpcRoutePattern converts a path template of google.api.http to a route, like {name=shelves/*}.
+func grpcRoutePattern(template string) string {
+	return grpcPathVariable.ReplaceAllStringFunc(template, func(v string) string {
+		m := grpcPathVariable.FindStringSubmatch(v)
+		if m[3] == "" {
+			return "{" + m[1] + "}"
+		}
+
+		segments := strings.Split(m[3], "/")
+		for i, s := range segments {
+			switch s {
+			case "*":
+				segments[i] = "[^/]+"
+			case "**":
+				segments[i] = ".+"
+			default:
+				segments[i] = regexp.QuoteMeta(s)
+			}
+		}
+
+		return "{" + m[1] + ":" + strings.Join(segments, "/") + "}"
+	})
+}
+
+type grpcTranscodingHandler struct {
+	rule        grpcTranscodingRule
+	service     interface{}
+	method      *grpc.MethodDesc
+	fullMethod  string
+	interceptor grpc.UnaryServerInterceptor
+	timeout     string
+}
+
+func (h grpcTranscodingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	responder := gofrHTTP.NewResponder(w, r.Method)
+
+	timeout, err := strconv.Atoi(h.timeout)
+	if err != nil || timeout < 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), gofrHTTP.RequestTimeout(r, time.Duration(timeout)*time.Second))
+	defer cancel()
+
+	// the headers are passed as the incoming metadata of the call, so that they can be read using GRPCContext.Param.
+	md := make(metadata.MD, len(r.Header))
+	for k, v := range r.Header {
+		md.Append(k, v...)
+	}
+
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	dec := func(in interface{}) error {
+		msg, ok := in.(proto.Message)
+		if !ok {
+			return status.Errorf(codes.Internal, "request of %s is not a proto message", h.fullMethod)
+		}
+
+		return h.decode(r, msg.ProtoReflect())
+	}
+
+	resp, err := h.method.Handler(h.service, ctx, dec, func(ctx context.Context, req interface{},
+		_ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return h.interceptor(ctx, req, &grpc.UnaryServerInfo{Server: h.service, FullMethod: h.fullMethod}, handler)
+	})
+	if err != nil {
+		responder.Respond(nil, grpcHTTPError{status.Convert(err)})
+
+		return
+	}
+
+	body, err := h.encode(resp)
+	if err != nil {
+		responder.Respond(nil, err)
+
+		return
+	}
+
+	// the response is written as is, with the JSON of the message marshaled using protojson and the status of the call.
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write(body)
+}
+
+// decode binds the body, the path variables and the query parameters of the request to msg.
+func (h grpcTranscodingHandler) decode(r *http.Request, msg protoreflect.Message) error {
+	if h.rule.body != "" {
+		if err := decodeGRPCBody(r, msg, h.rule.body); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+		}
+	}
+
+	for name, value := range mux.Vars(r) {
+		if err := setGRPCField(msg, name, value); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid path parameter %s: %v", name, err)
+		}
+	}
+
+	// all the fields are bound by the body if its rule is "*".
+	if h.rule.body == "*" {
+		return nil
+	}
+
+	for name, values := range r.URL.Query() {
+		for _, value := range values {
+			if err := setGRPCField(msg, name, value); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid query parameter %s: %v", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func decodeGRPCBody(r *http.Request, msg protoreflect.Message, field string) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil || len(body) == 0 {
+		return err
+	}
+
+	if field != "*" {
+		fd := grpcField(msg, field)
+		if fd == nil || fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			return fmt.Errorf("body field %s is not a message", field)
+		}
+
+		msg = msg.Mutable(fd).Message()
+	}
+
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, msg.Interface())
+}
+
+func (h grpcTranscodingHandler) encode(resp interface{}) ([]byte, error) {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("response of %s is not a proto message", h.fullMethod)
+	}
+
+	if h.rule.responseBody != "" {
+		if fd := grpcField(msg.ProtoReflect(), h.rule.responseBody); fd != nil && fd.Kind() == protoreflect.MessageKind &&
+			!fd.IsList() && !fd.IsMap() {
+			msg = msg.ProtoReflect().Get(fd).Message().Interface()
+		}
+	}
+
+	return protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(msg)
+}
+
+// setGRPCField sets the field of msg at the path, like "customer.id", to the value.
+func setGRPCField(msg protoreflect.Message, path, value string) error {
+	names := strings.Split(path, ".")
+
+	for _, name := range names[:len(names)-1] {
+		fd := grpcField(msg, name)
+		if fd == nil || fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			return fmt.Errorf("%s is not a message field", name)
+		}
+
+		msg = msg.Mutable(fd).Message()
+	}
+
+	fd := grpcField(msg, names[len(names)-1])
+	if fd == nil {
+		return fmt.Errorf("unknown field %s", path)
+	}
+
+	v, err := parseGRPCValue(fd, value)
+	if err != nil {
+		return err
+	}
+
+	if fd.IsList() {
+		msg.Mutable(fd).List().Append(v)
+
+		return nil
+	}
+
+	msg.Set(fd, v)
+
+	return nil
+}
+
+// grpcField returns the field of msg by its proto or JSON name.
+func grpcField(msg protoreflect.Message, name string) protoreflect.FieldDescriptor {
+	fields := msg.Descriptor().Fields()
+
+	if fd := fields.ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+
+	return fields.ByJSONName(name)
+}
+
+func parseGRPCValue(fd protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	//nolint:exhaustive // the message and group fields cannot be set from a string.
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(value)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := strconv.ParseInt(value, 10, 64)
+		return protoreflect.ValueOfInt64(i), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		u, err := strconv.ParseUint(value, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(u)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		u, err := strconv.ParseUint(value, 10, 64)
+		return protoreflect.ValueOfUint64(u), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(value, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(value, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.BytesKind:
+		b, err := base64.StdEncoding.DecodeString(value)
+		return protoreflect.ValueOfBytes(b), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(value)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+
+		n, err := strconv.ParseInt(value, 10, 32)
+
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), err
+	default:
+		return protoreflect.Value{}, fmt.Errorf("field %s of kind %s cannot be set from a string", fd.Name(), fd.Kind())
+	}
+}
+
+// grpcHTTPError responds to the failed transcoded calls with the HTTP status corresponding to the gRPC code.
+type grpcHTTPError struct {
+	status *status.Status
+}
+
+func (e grpcHTTPError) Error() string {
+	return e.status.Message()
+}
+
+func (e grpcHTTPError) StatusCode() int {
+	switch e.status.Code() { //nolint:exhaustive // the other codes are internal errors.
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return statusClientClosedRequest
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
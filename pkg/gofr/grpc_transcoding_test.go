@@ -0,0 +1,234 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// testCustomerService registers the descriptors of the service gofr.test.Customers, whose GetCustomer method is
+// annotated with google.api.http, and returns its ServiceDesc echoing the request.
+func testCustomerService(t *testing.T) *grpc.ServiceDesc {
+	t.Helper()
+
+	const serviceName = "gofr.test.Customers"
+
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(serviceName)
+	if err != nil {
+		d = registerTestCustomerFile(t).Services().Get(0)
+	}
+
+	md := d.(protoreflect.ServiceDescriptor).Methods().ByName("GetCustomer")
+
+	return &grpc.ServiceDesc{
+		ServiceName: serviceName,
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "GetCustomer",
+			Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error,
+				interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := dynamicpb.NewMessage(md.Input())
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+
+				return interceptor(ctx, in, nil, func(context.Context, interface{}) (interface{}, error) {
+					out := dynamicpb.NewMessage(md.Output())
+					out.Set(md.Output().Fields().ByName("customer"), protoreflect.ValueOfMessage(in))
+
+					return out, nil
+				})
+			},
+		}},
+	}
+}
+
+func registerTestCustomerFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+
+	options := &descriptorpb.MethodOptions{}
+	proto.SetExtension(options, annotations.E_Http, &annotations.HttpRule{
+		Pattern:      &annotations.HttpRule_Get{Get: "/v1/customers/{id}"},
+		ResponseBody: "customer",
+		AdditionalBindings: []*annotations.HttpRule{{
+			Pattern: &annotations.HttpRule_Post{Post: "/v1/{name=regions/*/customers}"},
+			Body:    "*",
+		}},
+	})
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type,
+		label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(number),
+			Type: typ.Enum(), Label: label.Enum(), JsonName: proto.String(name)}
+	}
+
+	optional, repeated := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+
+	customerField := field("customer", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional)
+	customerField.TypeName = proto.String(".gofr.test.Customer")
+
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("gofr/test/customers.proto"),
+		Package: proto.String("gofr.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Customer"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, optional),
+				field("name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional),
+				field("active", 3, descriptorpb.FieldDescriptorProto_TYPE_BOOL, optional),
+				field("tags", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, repeated),
+			}},
+			{Name: proto.String("CustomerResponse"), Field: []*descriptorpb.FieldDescriptorProto{customerField}},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Customers"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("GetCustomer"),
+				InputType:  proto.String(".gofr.test.Customer"),
+				OutputType: proto.String(".gofr.test.CustomerResponse"),
+				Options:    options,
+			}},
+		}},
+	}, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+
+	require.NoError(t, protoregistry.GlobalFiles.RegisterFile(fd))
+
+	return fd
+}
+
+func newTranscodingApp(t *testing.T) *App {
+	t.Helper()
+
+	t.Setenv("GRPC_ENABLE_TRANSCODING", "true")
+
+	return New()
+}
+
+func serveTranscoded(a *App, method, target, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	rec := httptest.NewRecorder()
+
+	a.httpServer.router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestGRPCTranscoding_Annotations(t *testing.T) {
+	a := newTranscodingApp(t)
+
+	a.RegisterGRPCService(testCustomerService(t), struct{}{})
+
+	testCases := []struct {
+		desc    string
+		method  string
+		target  string
+		body    string
+		expCode int
+		expBody string
+	}{
+		{"path and query parameters", http.MethodGet, "/v1/customers/42?active=true&tags=a&tags=b", "",
+			http.StatusOK, `{"id":"42", "name":"", "active":true, "tags":["a", "b"]}`},
+		{"body of additional binding", http.MethodPost, "/v1/regions/eu/customers", `{"id":"7","tags":["x"]}`,
+			http.StatusOK, `{"customer":{"id":"7", "name":"regions/eu/customers", "active":false, "tags":["x"]}}`},
+		{"invalid path parameter", http.MethodGet, "/v1/customers/abc", "",
+			http.StatusBadRequest, `{"error":{"message":"invalid path parameter id: strconv.ParseInt: parsing \"abc\": invalid syntax"}}`},
+		{"unknown query parameter", http.MethodGet, "/v1/customers/42?unknown=1", "",
+			http.StatusBadRequest, `{"error":{"message":"invalid query parameter unknown: unknown field unknown"}}`},
+	}
+
+	for i, tc := range testCases {
+		rec := serveTranscoded(a, tc.method, tc.target, tc.body, nil)
+
+		assert.Equal(t, tc.expCode, rec.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.JSONEq(t, tc.expBody, rec.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestGRPCTranscoding_UnannotatedMethod(t *testing.T) {
+	a := newTranscodingApp(t)
+
+	grpc_health_v1.RegisterHealthServer(a, health.NewServer())
+
+	rec := serveTranscoded(a, http.MethodPost, "/grpc.health.v1.Health/Check", `{"service":""}`, nil)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"SERVING"}`, rec.Body.String())
+
+	rec = serveTranscoded(a, http.MethodPost, "/grpc.health.v1.Health/Check", `{"service":"unknown"}`, nil)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGRPCTranscoding_Interceptors(t *testing.T) {
+	a := newTranscodingApp(t)
+
+	a.UseGRPCInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		assert.Equal(t, "/gofr.test.Customers/GetCustomer", info.FullMethod)
+
+		if GRPCContext(ctx).Param("authorization") != "token" {
+			return nil, status.Error(codes.Unauthenticated, "missing token")
+		}
+
+		return handler(ctx, req)
+	}, nil)
+
+	a.RegisterGRPCService(testCustomerService(t), struct{}{})
+
+	rec := serveTranscoded(a, http.MethodGet, "/v1/customers/42", "", nil)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"error":{"message":"missing token"}}`, rec.Body.String())
+
+	rec = serveTranscoded(a, http.MethodGet, "/v1/customers/42", "", map[string]string{"Authorization": "token"})
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGRPCTranscoding_Disabled(t *testing.T) {
+	a := New()
+
+	grpc_health_v1.RegisterHealthServer(a, health.NewServer())
+
+	rec := serveTranscoded(a, http.MethodPost, "/grpc.health.v1.Health/Check", `{}`, nil)
+
+	assert.NotEqual(t, http.StatusOK, rec.Code)
+}
+
+func Test_grpcRoutePattern(t *testing.T) {
+	testCases := []struct {
+		template string
+		expected string
+	}{
+		{"/v1/customers/{id}", "/v1/customers/{id}"},
+		{"/v1/{name=shelves/*/books/*}", "/v1/{name:shelves/[^/]+/books/[^/]+}"},
+		{"/v1/{path=files/**}:download", "/v1/{path:files/.+}:download"},
+		{"/v1/{customer.id}/orders", "/v1/{customer.id}/orders"},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.expected, grpcRoutePattern(tc.template), "TEST[%d], Failed.\n", i)
+	}
+}
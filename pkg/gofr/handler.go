@@ -8,8 +8,12 @@ import (
 	"time"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/gofrerr"
 	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
 	"github.com/peter-stratton/gofr/pkg/gofr/http/response"
+	"github.com/peter-stratton/gofr/pkg/gofr/payment"
 	"github.com/peter-stratton/gofr/pkg/gofr/static"
 
 	"net/http"
@@ -36,14 +40,59 @@ type handler struct {
 	function       Handler
 	container      *container.Container
 	requestTimeout string
+	templates      *templates
+	// formats are the formats of the responses, negotiated with the Accept header of the requests.
+	formats []string
+	// timeLayout is the layout of the times of the JSON responses, which are written as they are if it is empty.
+	timeLayout string
+	// errorRegistry maps the errors returned by the function to the statuses and the bodies of the responses.
+	errorRegistry *gofrerr.Registry
+	// transformer builds the envelope of the responses of the route, if it is set by TransformResponses.
+	transformer ResponseTransformer
+	// appTransformer builds the envelope of the responses of the routes without their own transformer.
+	appTransformer *responseTransformer
+	// coalescer collapses the concurrent identical GET requests, if they are coalesced by CoalesceRequests.
+	coalescer *coalescer
+	// cache caches the responses of the GET requests, if they are cached by CacheFor.
+	cache *responseCache
+	// latencyObjective is the latency objective of the route, if it is set by LatencyObjective.
+	latencyObjective time.Duration
+	// bodyValidator validates the JSON bodies bound by the function, if it is set by ValidateJSON.
+	bodyValidator gofrHTTP.BodyValidator
+	// priority is the priority of the requests of the route when the load is shed, set by Priority.
+	priority middleware.Priority
+	// webhookVerifier verifies the signatures of the payment webhooks of the route, if it is set by VerifyPaymentWebhook.
+	webhookVerifier payment.Verifier
 }
 
 func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	c := newContext(gofrHTTP.NewResponder(w, r.Method), gofrHTTP.NewRequest(r), h.container)
+	if h.cache != nil && r.Method == http.MethodGet {
+		h.cache.serve(w, r, h.serveUncached)
+		return
+	}
+
+	h.serveUncached(w, r)
+}
+
+func (h handler) serveUncached(w http.ResponseWriter, r *http.Request) {
+	if h.coalescer != nil && r.Method == http.MethodGet {
+		h.coalescer.serve(w, r, h.serve)
+		return
+	}
+
+	h.serve(w, r)
+}
+
+func (h handler) serve(w http.ResponseWriter, r *http.Request) {
+	responder := gofrHTTP.NewResponder(w, r.Method, gofrHTTP.WithFormats(r.Header.Get("Accept"), h.formats...),
+		gofrHTTP.WithRequest(r), gofrHTTP.WithErrorRegistry(h.errorRegistry),
+		gofrHTTP.WithResponseTransformer(h.responseTransformer()), gofrHTTP.WithTimeFormat(h.timeLayout))
+	c := newContext(responder, gofrHTTP.NewRequest(r, gofrHTTP.WithBodyValidator(h.bodyValidator)), h.container)
 
 	reqTimeout := h.setContextTimeout(h.requestTimeout)
 
-	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(reqTimeout)*time.Second)
+	// the deadline of the request is shortened by its X-Request-Timeout header.
+	ctx, cancel := context.WithTimeout(r.Context(), gofrHTTP.RequestTimeout(r, time.Duration(reqTimeout)*time.Second))
 	defer cancel()
 
 	c.Context = ctx
@@ -71,6 +120,16 @@ func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	case <-done:
 		// Handler function completed
+		if tmpl, ok := result.(response.Template); ok && err == nil {
+			h.respondTemplate(c, tmpl)
+			return
+		}
+
+		if pdf, ok := result.(response.PDF); ok && err == nil {
+			h.respondPDF(c, pdf)
+			return
+		}
+
 		c.responder.Respond(result, err)
 	}
 }
@@ -79,6 +138,17 @@ func healthHandler(c *Context) (interface{}, error) {
 	return c.Health(c), nil
 }
 
+func readyHandler(c *Context) (interface{}, error) {
+	r := c.Ready(c)
+
+	// a DEGRADED application can still serve requests, so it is only reported unavailable when a critical check fails.
+	if r.Status == datasource.StatusDown {
+		return r, gofrHTTP.ErrorServiceUnavailable{Dependencies: r.Unhealthy()}
+	}
+
+	return r, nil
+}
+
 func liveHandler(*Context) (interface{}, error) {
 	return struct {
 		Status string `json:"status"`
@@ -13,9 +13,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/gofrerr"
 	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
 	"github.com/peter-stratton/gofr/pkg/gofr/http/response"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/service/servicetest"
 )
 
 var (
@@ -79,6 +82,38 @@ func TestHandler_ServeHTTP_Timeout(t *testing.T) {
 	assert.Equal(t, "Request timed out\n", w.Body.String(), "TestHandler_ServeHTTP_Timeout Failed")
 }
 
+func TestHandler_ServeHTTP_RequestTimeoutHeader(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		header string
+		budget func(time.Duration) bool
+	}{
+		{"no header", "", func(b time.Duration) bool { return b > 55*time.Second }},
+		{"shorter timeout", "500ms", func(b time.Duration) bool { return b <= 500*time.Millisecond }},
+		{"longer timeout", "2m", func(b time.Duration) bool { return b > 55*time.Second && b <= time.Minute }},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		r.Header.Set(gofrHTTP.RequestTimeoutHeader, tc.header)
+
+		var budget time.Duration
+
+		handler{
+			requestTimeout: "60",
+			container:      &container.Container{Logger: logging.NewLogger(logging.FATAL)},
+			function: func(c *Context) (interface{}, error) {
+				budget = c.RemainingBudget()
+
+				return nil, nil
+			},
+		}.ServeHTTP(w, r)
+
+		assert.True(t, tc.budget(budget), "TEST[%d], Failed.\n%s: %v", i, tc.desc, budget)
+	}
+}
+
 func TestHandler_faviconHandlerError(t *testing.T) {
 	c := Context{
 		Context: context.Background(),
@@ -172,3 +207,102 @@ func TestHandler_healthHandler(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, h)
 }
+
+func TestHandler_healthHandler_Stub(t *testing.T) {
+	a := New()
+
+	server := servicetest.Stub("test-service").On(http.MethodGet, "/.well-known/alive").Reply(http.StatusOK, nil).Start(t)
+
+	a.AddHTTPService("test-service", server.URL())
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "", http.NoBody)
+
+	h, err := healthHandler(newContext(nil, gofrHTTP.NewRequest(req), a.container))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, h)
+}
+
+func TestHandler_readyHandler(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+
+	mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp})
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusUp})
+
+	resp, err := readyHandler(&Context{Context: context.Background(), Container: c})
+
+	assert.Nil(t, err)
+	assert.Equal(t, datasource.StatusUp, resp.(container.Readiness).Status)
+
+	mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusDown})
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusUp})
+
+	resp, err = readyHandler(&Context{Context: context.Background(), Container: c})
+
+	assert.Equal(t, gofrHTTP.ErrorServiceUnavailable{Dependencies: []string{"sql"}}, err)
+	assert.Equal(t, datasource.StatusDown, resp.(container.Readiness).Status)
+
+	c.AddHealthCheck("cache", func(context.Context) datasource.Health {
+		return datasource.Health{Status: datasource.StatusDown}
+	}, false)
+
+	mocks.SQL.EXPECT().HealthCheck().Return(&datasource.Health{Status: datasource.StatusUp})
+	mocks.Redis.EXPECT().HealthCheck().Return(datasource.Health{Status: datasource.StatusUp})
+
+	resp, err = readyHandler(&Context{Context: context.Background(), Container: c})
+
+	assert.Nil(t, err)
+	assert.Equal(t, datasource.StatusDegraded, resp.(container.Readiness).Status)
+}
+
+type paymentDeclinedError struct {
+	reason string
+}
+
+func (e paymentDeclinedError) Error() string {
+	return "payment declined: " + e.reason
+}
+
+func TestApp_ErrorRegistry(t *testing.T) {
+	app := New()
+
+	gofrerr.RegisterType(app.ErrorRegistry(), http.StatusPaymentRequired, func(e paymentDeclinedError) interface{} {
+		return map[string]string{"message": "payment declined", "reason": e.reason}
+	})
+	app.ErrorRegistry().RegisterCode("OUT_OF_STOCK", http.StatusUnprocessableEntity)
+
+	app.GET("/orders/{id}", func(*Context) (interface{}, error) {
+		return nil, gofrerr.NotFound("order", 42)
+	})
+	app.POST("/orders", func(*Context) (interface{}, error) {
+		return nil, fmt.Errorf("placing order: %w", gofrerr.New("OUT_OF_STOCK", "pen is out of stock"))
+	})
+	app.POST("/payments", func(*Context) (interface{}, error) {
+		return nil, paymentDeclinedError{reason: "insufficient funds"}
+	})
+
+	testCases := []struct {
+		method     string
+		path       string
+		statusCode int
+		body       string
+	}{
+		{http.MethodGet, "/orders/42", http.StatusNotFound,
+			`{"error":{"code":"NOT_FOUND","details":{"id":42,"resource":"order"},"message":"order 42 not found"}}`},
+		{http.MethodPost, "/orders", http.StatusUnprocessableEntity,
+			`{"error":{"code":"OUT_OF_STOCK","message":"pen is out of stock"}}`},
+		{http.MethodPost, "/payments", http.StatusPaymentRequired,
+			`{"error":{"message":"payment declined","reason":"insufficient funds"}}`},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+
+		app.httpServer.router.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, http.NoBody))
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.path)
+		assert.JSONEq(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.path)
+	}
+
+	assert.NotNil(t, (&App{}).ErrorRegistry())
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RequestTimeoutHeader is the header of the time within which the caller expects a response, like "250ms".
+const RequestTimeoutHeader = "X-Request-Timeout"
+
+var errInvalidTimeout = errors.New("the timeout must be a positive duration, like 250ms, or a number of seconds")
+
+// ParseTimeout parses the timeout of the X-Request-Timeout header, a duration or a number of seconds.
+func ParseTimeout(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, fErr := strconv.ParseFloat(value, 64)
+		if fErr != nil || math.IsNaN(seconds) || seconds > math.MaxInt64/float64(time.Second) {
+			return 0, errInvalidTimeout
+		}
+
+		d = time.Duration(seconds * float64(time.Second))
+	}
+
+	if d <= 0 {
+		return 0, errInvalidTimeout
+	}
+
+	return d, nil
+}
+
+// RequestTimeout returns the timeout of the request, shortened by its X-Request-Timeout header.
+func RequestTimeout(r *http.Request, defaultTimeout time.Duration) time.Duration {
+	timeout, err := ParseTimeout(r.Header.Get(RequestTimeoutHeader))
+	if err != nil || timeout > defaultTimeout {
+		return defaultTimeout
+	}
+
+	return timeout
+}
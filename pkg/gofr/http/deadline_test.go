@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeout(t *testing.T) {
+	testCases := []struct {
+		value   string
+		timeout time.Duration
+		err     error
+	}{
+		{"250ms", 250 * time.Millisecond, nil},
+		{"1.5s", 1500 * time.Millisecond, nil},
+		{"2", 2 * time.Second, nil},
+		{"0.5", 500 * time.Millisecond, nil},
+		{"0", 0, errInvalidTimeout},
+		{"-1s", 0, errInvalidTimeout},
+		{"NaN", 0, errInvalidTimeout},
+		{"1e300", 0, errInvalidTimeout},
+		{"soon", 0, errInvalidTimeout},
+		{"", 0, errInvalidTimeout},
+	}
+
+	for i, tc := range testCases {
+		timeout, err := ParseTimeout(tc.value)
+
+		assert.Equal(t, tc.timeout, timeout, "TEST[%d], Failed.\n%s", i, tc.value)
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.value)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		header  string
+		timeout time.Duration
+	}{
+		{"no header", "", 5 * time.Second},
+		{"shorter timeout", "300ms", 300 * time.Millisecond},
+		{"longer timeout", "1m", 5 * time.Second},
+		{"invalid timeout", "-5", 5 * time.Second},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		r.Header.Set(RequestTimeoutHeader, tc.header)
+
+		assert.Equal(t, tc.timeout, RequestTimeout(r, 5*time.Second), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
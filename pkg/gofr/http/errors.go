@@ -49,6 +49,19 @@ func (e ErrorInvalidParam) StatusCode() int {
 	return http.StatusBadRequest
 }
 
+// ErrorInvalidTime represents an error for a time which is neither in RFC 3339 nor a date and time without a time zone.
+type ErrorInvalidTime struct {
+	Value string `json:"value,omitempty"`
+}
+
+func (e ErrorInvalidTime) Error() string {
+	return fmt.Sprintf("invalid time %q, the times must be in RFC 3339, like 2006-01-02T15:04:05Z07:00", e.Value)
+}
+
+func (e ErrorInvalidTime) StatusCode() int {
+	return http.StatusBadRequest
+}
+
 // ErrorMissingParam represents an error for missing parameters in a request.
 type ErrorMissingParam struct {
 	Params []string `json:"param,omitempty"`
@@ -72,3 +85,20 @@ func (e ErrorInvalidRoute) Error() string {
 func (e ErrorInvalidRoute) StatusCode() int {
 	return http.StatusNotFound
 }
+
+// ErrorServiceUnavailable represents an error for when the application is not ready to serve requests.
+type ErrorServiceUnavailable struct {
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+func (e ErrorServiceUnavailable) Error() string {
+	if len(e.Dependencies) == 0 {
+		return "service unavailable"
+	}
+
+	return fmt.Sprintf("service unavailable, unhealthy dependencies: %s", strings.Join(e.Dependencies, ", "))
+}
+
+func (e ErrorServiceUnavailable) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
@@ -95,3 +95,21 @@ func TestErrorInvalidRoute(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, err.StatusCode(), "TEST Failed.\n")
 }
+
+func TestErrorServiceUnavailable(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		dependencies []string
+		expectedMsg  string
+	}{
+		{"no dependencies", nil, "service unavailable"},
+		{"multiple dependencies", []string{"redis", "sql"}, "service unavailable, unhealthy dependencies: redis, sql"},
+	}
+
+	for i, tc := range testCases {
+		err := ErrorServiceUnavailable{Dependencies: tc.dependencies}
+
+		assert.Equal(t, tc.expectedMsg, err.Error(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	resTypes "github.com/peter-stratton/gofr/pkg/gofr/http/response"
+)
+
+// serveFile streams the file of Path or of Reader, supporting the Range requests if it can seek.
+func (r Responder) serveFile(f resTypes.File, statusCode int, errorObj interface{}) {
+	if errorObj != nil {
+		r.writeFormatted(statusCode, nil, errorObj, false)
+		return
+	}
+
+	if f.Path != "" {
+		file, info, err := openFile(f.Path)
+		if err != nil {
+			r.Respond(nil, err)
+			return
+		}
+
+		f.Reader, f.ModTime = file, info.ModTime()
+
+		if f.Name == "" {
+			f.Name = filepath.Base(f.Path)
+		}
+	}
+
+	if c, ok := f.Reader.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	if f.Name != "" {
+		r.w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": f.Name}))
+	}
+
+	if f.ContentType != "" {
+		r.w.Header().Set("Content-Type", f.ContentType)
+	}
+
+	if rs, ok := f.Reader.(io.ReadSeeker); ok {
+		http.ServeContent(r.w, r.request(), f.Name, f.ModTime, rs)
+		return
+	}
+
+	if f.ContentType == "" {
+		r.w.Header().Set("Content-Type", "application/octet-stream")
+	}
+
+	r.w.WriteHeader(http.StatusOK)
+
+	_, _ = io.Copy(r.w, f.Reader)
+}
+
+// openFile opens the file of the path, which is not found if it is a directory.
+func openFile(path string) (*os.File, fs.FileInfo, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrorEntityNotFound{Name: "file", Value: filepath.Base(path)}
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := file.Stat()
+	if err == nil && info.IsDir() {
+		err = ErrorEntityNotFound{Name: "file", Value: filepath.Base(path)}
+	}
+
+	if err != nil {
+		file.Close()
+
+		return nil, nil, err
+	}
+
+	return file, info, nil
+}
+
+// request returns the request of the responder, or a request without headers if it is not set.
+func (r Responder) request() *http.Request {
+	if r.req != nil {
+		return r.req
+	}
+
+	return &http.Request{Method: r.method, Header: http.Header{}}
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resTypes "github.com/peter-stratton/gofr/pkg/gofr/http/response"
+)
+
+func TestResponder_RespondFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.txt")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789"), 0600))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	lastModified := info.ModTime().UTC().Format(http.TimeFormat)
+
+	testCases := []struct {
+		desc        string
+		file        resTypes.File
+		headers     map[string]string
+		statusCode  int
+		body        string
+		disposition string
+	}{
+		{"path", resTypes.File{Path: path}, nil, http.StatusOK, "0123456789", `attachment; filename=report.txt`},
+		{"range", resTypes.File{Path: path, Name: "a b.txt"}, map[string]string{"Range": "bytes=2-4"},
+			http.StatusPartialContent, "234", `attachment; filename="a b.txt"`},
+		{"if-range of the last modification", resTypes.File{Path: path},
+			map[string]string{"Range": "bytes=5-", "If-Range": lastModified}, http.StatusPartialContent, "56789",
+			`attachment; filename=report.txt`},
+		{"if-range of an old modification", resTypes.File{Path: path}, map[string]string{"Range": "bytes=5-",
+			"If-Range": time.Unix(0, 0).UTC().Format(http.TimeFormat)}, http.StatusOK, "0123456789",
+			`attachment; filename=report.txt`},
+		{"reader which can seek", resTypes.File{Reader: strings.NewReader("hello"), Name: "hello.txt"},
+			map[string]string{"Range": "bytes=1-"}, http.StatusPartialContent, "ello", `attachment; filename=hello.txt`},
+		{"reader", resTypes.File{Reader: io.NopCloser(strings.NewReader("hello"))},
+			map[string]string{"Range": "bytes=1-"}, http.StatusOK, "hello", ""},
+		{"missing path", resTypes.File{Path: filepath.Join(t.TempDir(), "missing.txt")}, nil, http.StatusNotFound,
+			`{"error":{"message":"No entity found with file: missing.txt"}}` + "\n", ""},
+	}
+
+	for i, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		for k, v := range tc.headers {
+			req.Header.Set(k, v)
+		}
+
+		w := httptest.NewRecorder()
+
+		NewResponder(w, http.MethodGet, WithRequest(req)).Respond(tc.file, nil)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.disposition, w.Header().Get("Content-Disposition"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestResponder_RespondFile_Reader(t *testing.T) {
+	reader := &closeRecorder{Reader: strings.NewReader("a,b\n")}
+	w := httptest.NewRecorder()
+
+	NewResponder(w, http.MethodGet).Respond(resTypes.File{Reader: reader, ContentType: "text/csv"}, nil)
+
+	assert.True(t, reader.closed)
+	assert.Equal(t, "a,b\n", w.Body.String())
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+}
+
+func TestResponder_RespondFile_Error(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	NewResponder(w, http.MethodGet).Respond(resTypes.File{Reader: strings.NewReader("partial")}, ErrorInvalidRoute{})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `{"error":{"message":"route not registered"}}`+"\n", w.Body.String())
+}
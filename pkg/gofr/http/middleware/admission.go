@@ -0,0 +1,249 @@
+package middleware
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// The metrics of the admission queue.
+const (
+	// AdmissionQueueDepth is the gauge of the requests waiting in the admission queue, by their priority.
+	AdmissionQueueDepth = "app_http_admission_queue_depth"
+	// AdmissionRejected counts the requests rejected by the admission queue, by priority and reason.
+	AdmissionRejected = "app_http_admission_rejected"
+)
+
+var (
+	errQueueFull    = errors.New("full")
+	errEvicted      = errors.New("evicted")
+	errQueueTimeout = errors.New("timeout")
+)
+
+// AdmissionConfig is the configuration of an admission queue.
+type AdmissionConfig struct {
+	// MaxConcurrent is the number of requests served concurrently.
+	MaxConcurrent int
+	// MaxQueue is the number of requests waiting to be served once MaxConcurrent requests are.
+	MaxQueue int
+	// QueueTimeout is the time a request waits. The requests wait until they are canceled if it is not positive.
+	QueueTimeout time.Duration
+}
+
+// AdmissionQueue admits the requests by their priority once the server is busy.
+type AdmissionQueue struct {
+	config  AdmissionConfig
+	metrics metrics
+
+	mu       sync.Mutex
+	inFlight int
+	waiting  waiters
+	// depths are the numbers of requests waiting by their priority.
+	depths [PriorityCritical]int
+	seq    uint64
+}
+
+// NewAdmissionQueue returns an admission queue, whose depth is recorded by the metrics.
+func NewAdmissionQueue(cfg AdmissionConfig, metrics metrics) *AdmissionQueue {
+	if cfg.MaxQueue < 0 {
+		cfg.MaxQueue = 0
+	}
+
+	return &AdmissionQueue{config: cfg, metrics: metrics}
+}
+
+// waiter is a request waiting to be admitted.
+type waiter struct {
+	priority Priority
+	seq      uint64
+	// admitted receives nil once the request is admitted, or the error of its eviction from the queue.
+	admitted chan error
+	// index is the index of the waiter in the queue, which is -1 once it left it.
+	index int
+}
+
+// waiters is a heap of the waiting requests, by priority and then arrival.
+type waiters []*waiter
+
+func (w waiters) Len() int { return len(w) }
+
+func (w waiters) Less(i, j int) bool {
+	if w[i].priority != w[j].priority {
+		return w[i].priority > w[j].priority
+	}
+
+	return w[i].seq < w[j].seq
+}
+
+func (w waiters) Swap(i, j int) {
+	w[i], w[j] = w[j], w[i]
+	w[i].index = i
+	w[j].index = j
+}
+
+func (w *waiters) Push(x interface{}) {
+	item := x.(*waiter)
+	item.index = len(*w)
+	*w = append(*w, item)
+}
+
+func (w *waiters) Pop() interface{} {
+	old := *w
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	item.index = -1
+	*w = old[:len(old)-1]
+
+	return item
+}
+
+// admit admits the request of the priority, waiting in the queue if MaxConcurrent requests are served.
+func (q *AdmissionQueue) admit(ctx context.Context, p Priority) error {
+	if p >= PriorityCritical {
+		return nil
+	}
+
+	q.mu.Lock()
+
+	if q.inFlight < q.config.MaxConcurrent && q.waiting.Len() == 0 {
+		q.inFlight++
+		q.mu.Unlock()
+
+		return nil
+	}
+
+	if q.waiting.Len() >= q.config.MaxQueue {
+		victim := q.lowest()
+		if victim == nil || victim.priority >= p {
+			q.mu.Unlock()
+
+			return errQueueFull
+		}
+
+		q.remove(victim)
+		victim.admitted <- errEvicted
+	}
+
+	q.seq++
+
+	w := &waiter{priority: p, seq: q.seq, admitted: make(chan error, 1)}
+	heap.Push(&q.waiting, w)
+	q.setDepth(p, 1)
+
+	q.mu.Unlock()
+
+	var timeout <-chan time.Time
+
+	if q.config.QueueTimeout > 0 {
+		timer := time.NewTimer(q.config.QueueTimeout)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	var err error
+
+	select {
+	case err = <-w.admitted:
+		return err
+	case <-timeout:
+		err = errQueueTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if w.index >= 0 {
+		q.remove(w)
+		return err
+	}
+
+	// the request was admitted, or evicted, while it stopped waiting.
+	return <-w.admitted
+}
+
+// release releases the slot of a request which was admitted, admitting the waiting request of the highest priority.
+func (q *AdmissionQueue) release(p Priority) {
+	if p >= PriorityCritical {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.inFlight--
+
+	for q.inFlight < q.config.MaxConcurrent && q.waiting.Len() > 0 {
+		w := heap.Pop(&q.waiting).(*waiter)
+		q.setDepth(w.priority, -1)
+
+		q.inFlight++
+		w.admitted <- nil
+	}
+}
+
+// lowest returns the waiting request of the lowest priority which arrived last, or nil if none is waiting.
+func (q *AdmissionQueue) lowest() *waiter {
+	var lowest *waiter
+
+	for _, w := range q.waiting {
+		if lowest == nil || w.priority < lowest.priority || (w.priority == lowest.priority && w.seq > lowest.seq) {
+			lowest = w
+		}
+	}
+
+	return lowest
+}
+
+func (q *AdmissionQueue) remove(w *waiter) {
+	heap.Remove(&q.waiting, w.index)
+	q.setDepth(w.priority, -1)
+}
+
+func (q *AdmissionQueue) setDepth(p Priority, delta int) {
+	q.depths[p] += delta
+
+	if q.metrics != nil {
+		q.metrics.SetGauge(AdmissionQueueDepth, float64(q.depths[p]), "priority", p.String())
+	}
+}
+
+// Depth returns the number of requests waiting to be admitted.
+func (q *AdmissionQueue) Depth() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.waiting.Len()
+}
+
+// Admission is a middleware which serves at most MaxConcurrent requests at once, admitting the others by priority.
+func Admission(q *AdmissionQueue, priority func(r *http.Request) Priority) func(inner http.Handler) http.Handler {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p := priority(r)
+			if p < PriorityLow {
+				p = PriorityLow
+			}
+
+			if err := q.admit(r.Context(), p); err != nil {
+				if q.metrics != nil && !errors.Is(err, r.Context().Err()) {
+					q.metrics.IncrementCounter(context.Background(), AdmissionRejected, "priority", p.String(),
+						"reason", err.Error())
+				}
+
+				http.Error(w, "Service Unavailable: the server is busy", http.StatusServiceUnavailable)
+
+				return
+			}
+
+			defer q.release(p)
+
+			inner.ServeHTTP(w, r)
+		})
+	}
+}
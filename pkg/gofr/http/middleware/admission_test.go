@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockAdmissionMetrics() *mockMetrics {
+	m := &mockMetrics{}
+	m.On("SetGauge", mock.Anything, mock.Anything).Return()
+	m.On("IncrementCounter", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	return m
+}
+
+// wait queues a request of the priority, and returns the channel of the result of its admission.
+func wait(ctx context.Context, t *testing.T, q *AdmissionQueue, p Priority) <-chan error {
+	t.Helper()
+
+	queued := func() uint64 {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		return q.seq
+	}
+
+	seq := queued()
+	result := make(chan error, 1)
+
+	go func() {
+		result <- q.admit(ctx, p)
+	}()
+
+	require.Eventually(t, func() bool { return queued() > seq || len(result) > 0 }, time.Second, time.Millisecond)
+
+	return result
+}
+
+func TestAdmissionQueue_AdmitsByPriority(t *testing.T) {
+	q := NewAdmissionQueue(AdmissionConfig{MaxConcurrent: 1, MaxQueue: 3}, newMockAdmissionMetrics())
+
+	require.NoError(t, q.admit(context.Background(), PriorityNormal))
+
+	low := wait(context.Background(), t, q, PriorityLow)
+	normal := wait(context.Background(), t, q, PriorityNormal)
+	high := wait(context.Background(), t, q, PriorityHigh)
+
+	assert.Equal(t, 3, q.Depth())
+
+	q.release(PriorityNormal)
+	require.NoError(t, <-high)
+
+	q.release(PriorityHigh)
+	require.NoError(t, <-normal)
+
+	q.release(PriorityNormal)
+	require.NoError(t, <-low)
+
+	assert.Zero(t, q.Depth())
+}
+
+func TestAdmissionQueue_CriticalBypassesQueue(t *testing.T) {
+	q := NewAdmissionQueue(AdmissionConfig{MaxConcurrent: 1}, nil)
+
+	require.NoError(t, q.admit(context.Background(), PriorityNormal))
+	require.ErrorIs(t, q.admit(context.Background(), PriorityHigh), errQueueFull)
+	require.NoError(t, q.admit(context.Background(), PriorityCritical))
+}
+
+func TestAdmissionQueue_Full(t *testing.T) {
+	q := NewAdmissionQueue(AdmissionConfig{MaxConcurrent: 1, MaxQueue: 1}, newMockAdmissionMetrics())
+
+	require.NoError(t, q.admit(context.Background(), PriorityNormal))
+
+	high := wait(context.Background(), t, q, PriorityHigh)
+
+	// the waiting request is not evicted by a request of a lower or the same priority.
+	require.ErrorIs(t, q.admit(context.Background(), PriorityHigh), errQueueFull)
+	require.ErrorIs(t, q.admit(context.Background(), PriorityLow), errQueueFull)
+
+	q.release(PriorityNormal)
+	require.NoError(t, <-high)
+}
+
+func TestAdmissionQueue_Evicts(t *testing.T) {
+	m := newMockAdmissionMetrics()
+	q := NewAdmissionQueue(AdmissionConfig{MaxConcurrent: 1, MaxQueue: 2}, m)
+
+	require.NoError(t, q.admit(context.Background(), PriorityNormal))
+
+	first := wait(context.Background(), t, q, PriorityLow)
+	second := wait(context.Background(), t, q, PriorityLow)
+	high := wait(context.Background(), t, q, PriorityHigh)
+
+	// the low priority request which arrived last is evicted.
+	require.ErrorIs(t, <-second, errEvicted)
+	assert.Equal(t, 2, q.Depth())
+
+	q.release(PriorityNormal)
+	require.NoError(t, <-high)
+
+	q.release(PriorityHigh)
+	require.NoError(t, <-first)
+
+	m.AssertCalled(t, "SetGauge", AdmissionQueueDepth, float64(2))
+	m.AssertCalled(t, "SetGauge", AdmissionQueueDepth, float64(0))
+}
+
+func TestAdmissionQueue_Timeout(t *testing.T) {
+	q := NewAdmissionQueue(AdmissionConfig{MaxConcurrent: 1, MaxQueue: 1, QueueTimeout: 10 * time.Millisecond}, nil)
+
+	require.NoError(t, q.admit(context.Background(), PriorityNormal))
+	require.ErrorIs(t, q.admit(context.Background(), PriorityNormal), errQueueTimeout)
+	assert.Zero(t, q.Depth())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := wait(ctx, t, q, PriorityNormal)
+
+	cancel()
+
+	require.ErrorIs(t, <-canceled, context.Canceled)
+	assert.Zero(t, q.Depth())
+}
+
+func TestAdmission(t *testing.T) {
+	m := newMockAdmissionMetrics()
+	q := NewAdmissionQueue(AdmissionConfig{MaxConcurrent: 1}, m)
+
+	served := make(chan struct{})
+	handler := Admission(q, func(r *http.Request) Priority {
+		if r.URL.Path == "/reports" {
+			return PriorityLow
+		}
+
+		return PriorityNormal
+	})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-served
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	first := httptest.NewRecorder()
+	done := make(chan struct{})
+
+	go func() {
+		handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/orders", http.NoBody))
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		return q.inFlight == 1
+	}, time.Second, time.Millisecond)
+
+	rejected := httptest.NewRecorder()
+	handler.ServeHTTP(rejected, httptest.NewRequest(http.MethodGet, "/reports", http.NoBody))
+
+	close(served)
+	<-done
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, rejected.Code)
+	m.AssertCalled(t, "IncrementCounter", mock.Anything, AdmissionRejected, []string{"priority", "low", "reason", "full"})
+	assert.Zero(t, q.inFlight)
+}
@@ -27,7 +27,7 @@ func APIKeyAuthMiddleware(validator func(apiKey string) bool, apiKeys ...string)
 				return
 			}
 
-			handler.ServeHTTP(w, r)
+			handler.ServeHTTP(w, withPrincipal(r, APIKeyPrincipal(authKey)))
 		})
 	}
 }
@@ -52,7 +52,7 @@ func BasicAuthMiddleware(basicAuthProvider BasicAuthProvider) func(handler http.
 				return
 			}
 
-			handler.ServeHTTP(w, r)
+			handler.ServeHTTP(w, withPrincipal(r, "user:"+credentials[0]))
 		})
 	}
 }
@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
+)
+
+// Chaos is a middleware which sets the method and the path of the requests in their context, for the faults.
+func Chaos() func(inner http.Handler) http.Handler {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			inner.ServeHTTP(w, r.WithContext(chaos.WithRequest(r.Context(), r.Method, r.URL.Path)))
+		})
+	}
+}
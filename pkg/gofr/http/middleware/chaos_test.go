@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
+)
+
+func TestChaos(t *testing.T) {
+	faults, err := chaos.New(nil, chaos.Fault{Target: chaos.TargetSQL, Request: "^POST /checkout$", Kind: chaos.KindReset,
+		Rate: 1})
+	require.NoError(t, err)
+
+	var injected error
+
+	handler := Chaos()(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		injected = faults.Inject(r.Context(), chaos.TargetSQL, "SELECT 1")
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/checkout", http.NoBody))
+	assert.ErrorIs(t, injected, syscall.ECONNRESET)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/checkout", http.NoBody))
+	assert.NoError(t, injected)
+}
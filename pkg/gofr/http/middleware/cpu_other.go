@@ -0,0 +1,10 @@
+//go:build !unix
+
+package middleware
+
+import "time"
+
+// processCPUTime does not return the CPU time of the process, which is not monitored on this platform.
+func processCPUTime() (time.Duration, bool) {
+	return 0, false
+}
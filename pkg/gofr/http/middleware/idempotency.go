@@ -0,0 +1,220 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+
+	// idempotencyLockTTL bounds the time the key of a request in progress stays reserved.
+	idempotencyLockTTL = time.Minute
+
+	// maxIdempotentBodySize is the size of the largest body read to be fingerprinted.
+	maxIdempotentBodySize = 1 << 20
+)
+
+// ErrorIdempotentRequestInProgress is responded to the requests whose idempotency key is used by a request in progress.
+type ErrorIdempotentRequestInProgress struct{}
+
+func (ErrorIdempotentRequestInProgress) Error() string {
+	return "a request with the same idempotency key is in progress"
+}
+
+func (ErrorIdempotentRequestInProgress) StatusCode() int {
+	return http.StatusConflict
+}
+
+// ErrorIdempotencyKeyReused is responded to the requests reusing the idempotency key of a request with another body.
+type ErrorIdempotencyKeyReused struct{}
+
+func (ErrorIdempotencyKeyReused) Error() string {
+	return "idempotency key is reused for a different request"
+}
+
+func (ErrorIdempotencyKeyReused) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// ErrorIdempotentRequestTooLarge is responded to the idempotent requests whose body is too large.
+type ErrorIdempotentRequestTooLarge struct{}
+
+func (ErrorIdempotentRequestTooLarge) Error() string {
+	return "the body of the request with an idempotency key is too large"
+}
+
+func (ErrorIdempotentRequestTooLarge) StatusCode() int {
+	return http.StatusRequestEntityTooLarge
+}
+
+// idempotencyStore persists the records of the requests with an idempotency key, like container.IdempotencyStore.
+type idempotencyStore interface {
+	Reserve(ctx context.Context, key string, record []byte, ttl time.Duration) ([]byte, error)
+	Save(ctx context.Context, key string, record []byte, ttl time.Duration) error
+	Release(ctx context.Context, key string) error
+}
+
+// idempotencyRecord is the record of a request with an idempotency key.
+type idempotencyRecord struct {
+	Fingerprint string      `json:"fingerprint"`
+	Completed   bool        `json:"completed"`
+	StatusCode  int         `json:"statusCode,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// Idempotency responds to the retries of the POST and PATCH requests with an Idempotency-Key with their first response.
+func Idempotency(store idempotencyStore, ttl time.Duration) func(inner http.Handler) http.Handler {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(idempotencyKeyHeader)
+			if key == "" || (r.Method != http.MethodPost && r.Method != http.MethodPatch) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+
+			var tooLarge *http.MaxBytesError
+
+			switch {
+			case errors.As(err, &tooLarge):
+				gofrHTTP.NewResponder(w, r.Method).Respond(nil, ErrorIdempotentRequestTooLarge{})
+				return
+			case err != nil:
+				gofrHTTP.NewResponder(w, r.Method).Respond(nil, err)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			key = idempotencyHash(tenant.FromContext(r.Context()), idempotencyCaller(r), r.Method, r.URL.Path, key)
+			fingerprint := idempotencyHash(string(body))
+
+			record, _ := json.Marshal(idempotencyRecord{Fingerprint: fingerprint})
+
+			existing, err := store.Reserve(r.Context(), key, record, min(ttl, idempotencyLockTTL))
+			if err != nil {
+				respondIdempotencyStoreError(w, r)
+				return
+			}
+
+			if existing != nil {
+				replay(w, r, existing, fingerprint)
+				return
+			}
+
+			rec := &idempotencyRecorder{ResponseWriter: w}
+
+			// the response is stored even if the request is cancelled once it is written.
+			ctx := context.WithoutCancel(r.Context())
+
+			defer func() {
+				if rec.status == 0 || rec.status >= http.StatusInternalServerError {
+					_ = store.Release(ctx, key)
+					return
+				}
+
+				record, _ := json.Marshal(idempotencyRecord{Fingerprint: fingerprint, Completed: true,
+					StatusCode: rec.status, Header: rec.header, Body: rec.body.Bytes()})
+
+				_ = store.Save(ctx, key, record, ttl)
+			}()
+
+			inner.ServeHTTP(rec, r)
+		})
+	}
+}
+
+// replay responds to a retried request with the response of its first request.
+func replay(w http.ResponseWriter, r *http.Request, data []byte, fingerprint string) {
+	var record idempotencyRecord
+
+	if err := json.Unmarshal(data, &record); err != nil {
+		respondIdempotencyStoreError(w, r)
+		return
+	}
+
+	switch {
+	case record.Fingerprint != fingerprint:
+		gofrHTTP.NewResponder(w, r.Method).Respond(nil, ErrorIdempotencyKeyReused{})
+	case !record.Completed:
+		gofrHTTP.NewResponder(w, r.Method).Respond(nil, ErrorIdempotentRequestInProgress{})
+	default:
+		// the headers of the request, like its correlation ID, are not replaced by those of the first request.
+		for name, values := range record.Header {
+			if _, ok := w.Header()[name]; !ok {
+				w.Header()[name] = values
+			}
+		}
+
+		w.Header().Set(idempotencyReplayedHeader, strconv.FormatBool(true))
+		w.WriteHeader(record.StatusCode)
+		_, _ = w.Write(record.Body)
+	}
+}
+
+func respondIdempotencyStoreError(w http.ResponseWriter, r *http.Request) {
+	gofrHTTP.NewResponder(w, r.Method).Respond(nil,
+		gofrHTTP.ErrorServiceUnavailable{Dependencies: []string{"idempotency store"}})
+}
+
+// idempotencyCaller returns the principal of the request, or its credentials.
+func idempotencyCaller(r *http.Request) string {
+	if principal := Principal(r); principal != "" {
+		return principal
+	}
+
+	return idempotencyHash(r.Header.Get("Authorization"), r.Header.Get("Cookie"), r.Header.Get("X-Api-Key"))
+}
+
+func idempotencyHash(values ...string) string {
+	h := sha256.New()
+
+	for _, v := range values {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyRecorder records the response written to the ResponseWriter.
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status int
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (w *idempotencyRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+		w.header = w.ResponseWriter.Header().Clone()
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *idempotencyRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	w.body.Write(b)
+
+	return w.ResponseWriter.Write(b)
+}
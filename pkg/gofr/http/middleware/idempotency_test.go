@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+var errStoreUnavailable = errors.New("store unavailable")
+
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string][]byte
+	err     error
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, key string, record []byte, _ time.Duration) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if existing, ok := s.records[key]; ok {
+		return existing, nil
+	}
+
+	s.records[key] = record
+
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Save(_ context.Context, key string, record []byte, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[key] = record
+
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+
+	return nil
+}
+
+func TestIdempotency(t *testing.T) {
+	store := &memoryIdempotencyStore{records: make(map[string][]byte)}
+
+	var calls int
+
+	handler := Idempotency(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if string(body) == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":` + string(body) + `}`))
+	}))
+
+	testCases := []struct {
+		desc       string
+		method     string
+		key        string
+		tenant     string
+		apiKey     string
+		body       string
+		statusCode int
+		response   string
+		replayed   bool
+		calls      int
+	}{
+		{"first request", http.MethodPost, "k1", "", "", "1", http.StatusCreated, `{"data":1}`, false, 1},
+		{"retry", http.MethodPost, "k1", "", "", "1", http.StatusCreated, `{"data":1}`, true, 1},
+		{"retry with another body", http.MethodPost, "k1", "", "", "2", http.StatusUnprocessableEntity,
+			`{"error":{"message":"idempotency key is reused for a different request"}}` + "\n", false, 1},
+		{"same key of another tenant", http.MethodPost, "k1", "acme", "", "1", http.StatusCreated, `{"data":1}`, false, 2},
+		{"same key of another method", http.MethodPatch, "k1", "", "", "1", http.StatusCreated, `{"data":1}`, false, 3},
+		{"no key", http.MethodPost, "", "", "", "1", http.StatusCreated, `{"data":1}`, false, 4},
+		{"no key again", http.MethodPost, "", "", "", "1", http.StatusCreated, `{"data":1}`, false, 5},
+		{"unsafe method only", http.MethodPut, "k1", "", "", "1", http.StatusCreated, `{"data":1}`, false, 6},
+		{"server error", http.MethodPost, "k2", "", "", "fail", http.StatusInternalServerError, "", false, 7},
+		{"retry of a server error", http.MethodPost, "k2", "", "", "fail", http.StatusInternalServerError, "", false, 8},
+		{"same key of a caller", http.MethodPost, "k3", "", "alice-key", "3", http.StatusCreated, `{"data":3}`, false, 9},
+		{"same key of another caller", http.MethodPost, "k3", "", "bob-key", "3", http.StatusCreated, `{"data":3}`, false, 10},
+		{"retry of the caller", http.MethodPost, "k3", "", "alice-key", "3", http.StatusCreated, `{"data":3}`, true, 10},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(tc.method, "/orders", strings.NewReader(tc.body))
+		r.Header.Set("Idempotency-Key", tc.key)
+
+		if tc.apiKey != "" {
+			r.Header.Set("X-API-KEY", tc.apiKey)
+		}
+
+		if tc.tenant != "" {
+			r = r.WithContext(tenant.NewContext(r.Context(), tc.tenant))
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.response, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.replayed, w.Header().Get("Idempotent-Replayed") == "true", "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.calls, calls, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	store := &memoryIdempotencyStore{records: make(map[string][]byte)}
+	started, release := make(chan struct{}), make(chan struct{})
+
+	handler := Idempotency(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("1"))
+		r.Header.Set("Idempotency-Key", "k1")
+
+		return r
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest())
+		close(done)
+	}()
+
+	<-started
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest())
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, `{"error":{"message":"a request with the same idempotency key is in progress"}}`+"\n", w.Body.String())
+
+	close(release)
+	<-done
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest())
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotency_StoreError(t *testing.T) {
+	store := &memoryIdempotencyStore{err: errStoreUnavailable}
+
+	handler := Idempotency(store, time.Hour)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("handler should not be called when the store is unavailable")
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("1"))
+	r.Header.Set("Idempotency-Key", "k1")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestIdempotency_BodyTooLarge(t *testing.T) {
+	store := &memoryIdempotencyStore{records: make(map[string][]byte)}
+
+	handler := Idempotency(store, time.Hour)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("handler should not be called when the body is too large")
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(strings.Repeat("a", maxIdempotentBodySize+1)))
+	r.Header.Set("Idempotency-Key", "k1")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Empty(t, store.records, "the key should not be reserved")
+}
+
+func TestIdempotencyCaller(t *testing.T) {
+	withCookie := func(cookie string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/orders", http.NoBody)
+		r.Header.Set("Cookie", cookie)
+
+		return r
+	}
+
+	assert.NotEqual(t, idempotencyCaller(withCookie("session=alice")), idempotencyCaller(withCookie("session=bob")),
+		"the callers of distinct sessions should be distinct")
+	assert.Equal(t, idempotencyCaller(withCookie("session=alice")), idempotencyCaller(withCookie("session=alice")))
+}
@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+)
+
+// ShedRequests is the counter of the requests rejected by the load shedder, by their route and their priority.
+const ShedRequests = "app_http_requests_shed"
+
+const (
+	// latencyWindow is the window over which the latency of the requests is averaged.
+	latencyWindow = time.Second
+	// cpuSampleInterval is the interval at which the CPU usage of the process is sampled.
+	cpuSampleInterval = 250 * time.Millisecond
+	// priorityStep is how much more overloaded the server must be for the requests of each higher priority to be shed.
+	priorityStep = 0.25
+)
+
+// Priority is the priority of the requests of a route, the requests of the lowest priorities being shed first.
+type Priority int
+
+const (
+	// PriorityLow is the priority of the requests which are shed as soon as the server is overloaded, like reports.
+	PriorityLow Priority = iota
+	// PriorityNormal is the default priority of the requests.
+	PriorityNormal
+	// PriorityHigh is the priority of the requests which are shed last, like checkouts.
+	PriorityHigh
+	// PriorityCritical is the priority of the requests which are never shed, like the health checks.
+	PriorityCritical
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	case PriorityCritical:
+		return "critical"
+	default:
+		return strconv.Itoa(int(p))
+	}
+}
+
+// LoadShedConfig has the thresholds of an overload. A threshold which is not positive is not monitored.
+type LoadShedConfig struct {
+	// MaxInFlight is the number of requests being served.
+	MaxInFlight int
+	// MaxLatency is the average duration of the requests served in the last second.
+	MaxLatency time.Duration
+	// MaxCPU is the share of the CPUs used by the process, between 0 and 1. It is only monitored on Unix.
+	MaxCPU float64
+	// RetryAfter is the Retry-After of the rejected requests, one second by default.
+	RetryAfter time.Duration
+}
+
+// LoadShedder reports how overloaded the server is.
+type LoadShedder struct {
+	config   LoadShedConfig
+	inFlight atomic.Int64
+
+	mu sync.Mutex
+	// window is the start of the current window of the latency, whose requests took total.
+	window        time.Time
+	total         time.Duration
+	count         int
+	lastLatency   time.Duration
+	lastCPUSample time.Time
+	lastCPUTime   time.Duration
+	cpu           float64
+
+	// cpuTime returns the CPU time used by the process, and whether it is supported.
+	cpuTime func() (time.Duration, bool)
+	now     func() time.Time
+}
+
+// NewLoadShedder returns a load shedder with the thresholds of the config.
+func NewLoadShedder(cfg LoadShedConfig) *LoadShedder {
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = time.Second
+	}
+
+	return &LoadShedder{config: cfg, cpuTime: processCPUTime, now: time.Now}
+}
+
+// Overload returns the highest ratio of the load of the server to its thresholds.
+func (s *LoadShedder) Overload() float64 {
+	var overload float64
+
+	if s.config.MaxInFlight > 0 {
+		overload = float64(s.inFlight.Load()) / float64(s.config.MaxInFlight)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	if s.config.MaxLatency > 0 {
+		overload = math.Max(overload, float64(s.latency(now))/float64(s.config.MaxLatency))
+	}
+
+	if s.config.MaxCPU > 0 {
+		overload = math.Max(overload, s.cpuUsage(now)/s.config.MaxCPU)
+	}
+
+	return overload
+}
+
+// shedAt reports whether the requests of the priority are shed at the overload.
+func (p Priority) shedAt(overload float64) bool {
+	return p < PriorityCritical && overload >= 1+priorityStep*float64(p)
+}
+
+// latency returns the average latency of the requests of the last complete window, rotating the windows.
+func (s *LoadShedder) latency(now time.Time) time.Duration {
+	s.rotate(now)
+
+	return s.lastLatency
+}
+
+func (s *LoadShedder) rotate(now time.Time) {
+	elapsed := now.Sub(s.window)
+	if elapsed < latencyWindow {
+		return
+	}
+
+	s.lastLatency = 0
+
+	// the latency of the window before is kept only if it just ended, so that an idle server is not overloaded.
+	if elapsed < 2*latencyWindow && s.count > 0 {
+		s.lastLatency = s.total / time.Duration(s.count)
+	}
+
+	s.window = now
+	s.total = 0
+	s.count = 0
+}
+
+// cpuUsage returns the share of the CPUs used by the process since the previous sample, sampling it if it is old.
+func (s *LoadShedder) cpuUsage(now time.Time) float64 {
+	elapsed := now.Sub(s.lastCPUSample)
+	if elapsed < cpuSampleInterval {
+		return s.cpu
+	}
+
+	cpuTime, ok := s.cpuTime()
+	if !ok {
+		return 0
+	}
+
+	if !s.lastCPUSample.IsZero() {
+		s.cpu = float64(cpuTime-s.lastCPUTime) / (float64(elapsed) * float64(runtime.GOMAXPROCS(0)))
+	}
+
+	s.lastCPUSample = now
+	s.lastCPUTime = cpuTime
+
+	return s.cpu
+}
+
+func (s *LoadShedder) observe(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rotate(s.now())
+
+	s.total += d
+	s.count++
+}
+
+// LoadShedding is a middleware which rejects the requests with 503 once the server is overloaded, by priority.
+func LoadShedding(s *LoadShedder, priority func(r *http.Request) Priority,
+	metrics metrics) func(inner http.Handler) http.Handler {
+	retryAfter := strconv.Itoa(int(math.Ceil(s.config.RetryAfter.Seconds())))
+
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p := priority(r)
+
+			if p.shedAt(s.Overload()) {
+				metrics.IncrementCounter(context.Background(), ShedRequests,
+					"route", strings.TrimSuffix(gofrHTTP.PathTemplate(r), "/"), "priority", p.String())
+
+				w.Header().Set("Retry-After", retryAfter)
+				http.Error(w, "Service Unavailable: the server is overloaded", http.StatusServiceUnavailable)
+
+				return
+			}
+
+			s.inFlight.Add(1)
+			start := time.Now()
+
+			defer func() {
+				s.inFlight.Add(-1)
+				s.observe(time.Since(start))
+			}()
+
+			inner.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPriority_ShedAt(t *testing.T) {
+	testCases := []struct {
+		priority Priority
+		overload float64
+		shed     bool
+	}{
+		{PriorityLow, 0.99, false},
+		{PriorityLow, 1, true},
+		{PriorityNormal, 1.2, false},
+		{PriorityNormal, 1.25, true},
+		{PriorityHigh, 1.4, false},
+		{PriorityHigh, 1.5, true},
+		{PriorityCritical, 100, false},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.shed, tc.priority.shedAt(tc.overload), "TEST[%d], Failed.\n%v at %v", i, tc.priority,
+			tc.overload)
+	}
+}
+
+func TestLoadShedder_Overload(t *testing.T) {
+	now := time.Now()
+
+	s := NewLoadShedder(LoadShedConfig{MaxInFlight: 10, MaxLatency: 100 * time.Millisecond, MaxCPU: 0.5})
+	s.now = func() time.Time { return now }
+
+	cpuTime := time.Duration(0)
+	s.cpuTime = func() (time.Duration, bool) { return cpuTime, true }
+
+	assert.Zero(t, s.Overload())
+
+	s.inFlight.Store(5)
+	assert.InDelta(t, 0.5, s.Overload(), 0.001)
+
+	// the latency is the average of the window which just ended.
+	s.observe(100 * time.Millisecond)
+	s.observe(200 * time.Millisecond)
+
+	now = now.Add(latencyWindow)
+	assert.InDelta(t, 1.5, s.Overload(), 0.001)
+
+	// the latency of an idle server is 0.
+	now = now.Add(2 * latencyWindow)
+	assert.InDelta(t, 0.5, s.Overload(), 0.001)
+
+	// the process used all its CPUs since the previous sample.
+	elapsed := time.Second
+	now = now.Add(elapsed)
+	cpuTime = elapsed * time.Duration(runtime.GOMAXPROCS(0))
+
+	assert.InDelta(t, 2, s.Overload(), 0.001)
+}
+
+func TestLoadShedder_CPUNotSupported(t *testing.T) {
+	s := NewLoadShedder(LoadShedConfig{MaxCPU: 0.5})
+	s.cpuTime = func() (time.Duration, bool) { return 0, false }
+
+	assert.Zero(t, s.Overload())
+}
+
+func TestLoadShedding(t *testing.T) {
+	m := &mockMetrics{}
+	m.On("IncrementCounter", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	s := NewLoadShedder(LoadShedConfig{MaxInFlight: 4, RetryAfter: 1500 * time.Millisecond})
+
+	priorities := map[string]Priority{"/reports": PriorityLow, "/orders": PriorityNormal, "/health": PriorityCritical}
+
+	router := mux.NewRouter()
+
+	for path := range priorities {
+		router.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+
+	router.Use(LoadShedding(s, func(r *http.Request) Priority {
+		return priorities[r.URL.Path]
+	}, m))
+
+	testCases := []struct {
+		inFlight int64
+		path     string
+		status   int
+	}{
+		{0, "/reports", http.StatusOK},
+		{4, "/reports", http.StatusServiceUnavailable},
+		{4, "/orders", http.StatusOK},
+		{5, "/orders", http.StatusServiceUnavailable},
+		{100, "/health", http.StatusOK},
+	}
+
+	for i, tc := range testCases {
+		s.inFlight.Store(tc.inFlight)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, http.NoBody))
+
+		assert.Equal(t, tc.status, w.Code, "TEST[%d], Failed.\n%s", i, tc.path)
+		assert.Equal(t, tc.inFlight, s.inFlight.Load(), "TEST[%d], Failed.\n%s", i, tc.path)
+
+		if tc.status == http.StatusServiceUnavailable {
+			assert.Equal(t, "2", w.Header().Get("Retry-After"), "TEST[%d], Failed.\n%s", i, tc.path)
+		}
+	}
+
+	m.AssertCalled(t, "IncrementCounter", mock.Anything, ShedRequests, []string{"route", "/reports", "priority", "low"})
+	m.AssertCalled(t, "IncrementCounter", mock.Anything, ShedRequests, []string{"route", "/orders", "priority", "normal"})
+}
@@ -7,7 +7,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/gorilla/mux"
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+	gofrMetrics "github.com/peter-stratton/gofr/pkg/gofr/metrics"
 )
 
 type metrics interface {
@@ -17,23 +18,39 @@ type metrics interface {
 	SetGauge(name string, value float64, labels ...string)
 }
 
+// histogramHandles is implemented by the metrics which return handles to their histograms, like metrics.Manager.
+type histogramHandles interface {
+	Histogram(name string) gofrMetrics.Histogram
+}
+
 // Metrics is a middleware that records request response time metrics using the provided metrics interface.
 func Metrics(metrics metrics) func(inner http.Handler) http.Handler {
+	var responseTime gofrMetrics.Histogram
+
+	// the histogram is resolved once for all the requests, if the metrics support handles.
+	if m, ok := metrics.(histogramHandles); ok {
+		responseTime = m.Histogram("app_http_response")
+	}
+
 	return func(inner http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
 			srw := &StatusResponseWriter{ResponseWriter: w}
 
-			path, _ := mux.CurrentRoute(r).GetPathTemplate()
-			path = strings.TrimSuffix(path, "/")
+			path := strings.TrimSuffix(gofrHTTP.PathTemplate(r), "/")
 
 			// this has to be called in the end so that status code is populated
 			defer func(res *StatusResponseWriter, req *http.Request) {
 				duration := time.Since(start)
 
-				metrics.RecordHistogram(context.Background(), "app_http_response", duration.Seconds(),
-					"path", path, "method", req.Method, "status", fmt.Sprintf("%d", res.status))
+				labels := []string{"path", path, "method", req.Method, "status", fmt.Sprintf("%d", res.status)}
+
+				if responseTime != nil {
+					responseTime.Record(context.Background(), duration.Seconds(), labels...)
+				} else {
+					metrics.RecordHistogram(context.Background(), "app_http_response", duration.Seconds(), labels...)
+				}
 			}(srw, r)
 
 			inner.ServeHTTP(srw, r)
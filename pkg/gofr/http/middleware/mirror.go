@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/mirror"
+)
+
+// mirrorTimeout bounds the time for which a request mirrored to the shadow upstream waits for its response.
+const mirrorTimeout = 30 * time.Second
+
+// Mirror sends a copy of the percentage of the requests to the shadow upstream, and logs the differences.
+func Mirror(upstream string, percentage float64, logger logger) func(inner http.Handler) http.Handler {
+	upstream = strings.TrimRight(upstream, "/")
+	client := &http.Client{}
+
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !mirror.Sampled(percentage) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			mw := &mirrorResponseWriter{ResponseWriter: w}
+
+			inner.ServeHTTP(mw, r)
+
+			req, cancel, err := mirror.Request(r.Context(), r.Method, upstream+r.URL.RequestURI(), body, r.Header.Clone(),
+				mirrorTimeout)
+			if err != nil {
+				logger.Error("could not mirror the request: ", err)
+				return
+			}
+
+			go func() {
+				defer cancel()
+
+				res := mirror.Do(client, req, mw.Status(), mw.Body())
+				if res.Match {
+					logger.Log(&res)
+				} else {
+					logger.Error(&res)
+				}
+			}()
+		})
+	}
+}
+
+// mirrorResponseWriter records the status of the response and its body, up to mirror.MaxBodySize, while it is written.
+type mirrorResponseWriter struct {
+	http.ResponseWriter
+	status    int
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *mirrorResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *mirrorResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	if !w.truncated {
+		if w.body.Len()+len(b) > mirror.MaxBodySize {
+			w.truncated = true
+			w.body = bytes.Buffer{}
+		} else {
+			w.body.Write(b)
+		}
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the writer of the response, for the http.ResponseController of the handlers.
+func (w *mirrorResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Status returns the status of the response, which is 200 if it is not written explicitly.
+func (w *mirrorResponseWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+
+	return w.status
+}
+
+// Body returns the body of the response, or nil if it is longer than mirror.MaxBodySize.
+func (w *mirrorResponseWriter) Body() []byte {
+	if w.truncated {
+		return nil
+	}
+
+	return append([]byte{}, w.body.Bytes()...)
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/mirror"
+)
+
+// mirrorLogger sends the logged mirror results to a channel.
+type mirrorLogger struct {
+	results chan *mirror.Result
+}
+
+func (l mirrorLogger) Log(args ...interface{}) {
+	if res, ok := args[0].(*mirror.Result); ok {
+		l.results <- res
+	}
+}
+
+func (l mirrorLogger) Error(args ...interface{}) {
+	l.Log(args...)
+}
+
+func TestMirror(t *testing.T) {
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, "true", r.Header.Get(mirror.Header))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		_, _ = w.Write([]byte(`{"data":{"path":"` + r.URL.RequestURI() + `","body":` + string(body) + `,"v":2}}`))
+	}))
+	defer shadow.Close()
+
+	logger := mirrorLogger{results: make(chan *mirror.Result, 1)}
+
+	handler := Mirror(shadow.URL+"/", 100, logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":{"path":"` + r.URL.RequestURI() + `","body":` + string(body) + `,"v":1}}`))
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/orders?page=1", strings.NewReader(`{"id":1}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `{"data":{"path":"/orders?page=1","body":{"id":1},"v":1}}`, w.Body.String())
+
+	select {
+	case res := <-logger.results:
+		assert.Equal(t, http.MethodPost, res.Method)
+		assert.Equal(t, []string{"status: 201 != 200", "data.v: 1 != 2"}, res.Diff)
+		assert.False(t, res.Match)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the request is not mirrored")
+	}
+}
+
+func TestMirror_NotSampled(t *testing.T) {
+	logger := mirrorLogger{results: make(chan *mirror.Result, 1)}
+
+	handler := Mirror("http://localhost:0", 0, logger)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	assert.Equal(t, "ok", w.Body.String())
+
+	select {
+	case <-logger.results:
+		assert.Fail(t, "the request should not be mirrored")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
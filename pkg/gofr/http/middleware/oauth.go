@@ -5,14 +5,24 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+//nolint:stylecheck,revive // the messages are sent as the response
+var (
+	errAuthorizationHeaderRequired = errors.New("Authorization header is required")
+	errAuthorizationHeaderFormat   = errors.New("Authorization header format must be Bearer {token}")
 )
 
 // JWTClaim represents a custom key used to store JWT claims within the request context.
@@ -20,6 +30,7 @@ type JWTClaim string
 
 // PublicKeys stores a map of public keys identified by their key ID (kid).
 type PublicKeys struct {
+	mu   sync.RWMutex
 	keys map[string]*rsa.PublicKey
 }
 
@@ -35,9 +46,19 @@ func (i JWKNotFound) Error() string {
 func (p *PublicKeys) Get(kid string) *rsa.PublicKey {
 	kid = strings.TrimSpace(kid)
 
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.keys[kid]
 }
 
+func (p *PublicKeys) set(keys map[string]*rsa.PublicKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.keys = keys
+}
+
 type JWKSProvider interface {
 	GetWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
 		headers map[string]string) (*http.Response, error)
@@ -47,23 +68,31 @@ type JWKSProvider interface {
 type OauthConfigs struct {
 	Provider        JWKSProvider
 	RefreshInterval time.Duration
+	// Clock times the refresh of the keys, and is the clock of the system if nil.
+	Clock clock.Clock
 }
 
 // NewOAuth creates a PublicKeyProvider that periodically fetches and updates public keys from a JWKS endpoint.
 func NewOAuth(config OauthConfigs) PublicKeyProvider {
 	var publicKeys PublicKeys
 
+	c := config.Clock
+	if c == nil {
+		c = clock.New()
+	}
+
+	ticker := c.NewTicker(config.RefreshInterval)
+
 	go func() {
-		ticker := time.NewTicker(config.RefreshInterval)
 		defer ticker.Stop()
 
-		for range ticker.C {
+		for range ticker.C() {
 			keys, err := updateKeys(config)
 			if err != nil || keys == nil {
 				continue
 			}
 
-			publicKeys = *keys
+			publicKeys.set(keys.keys)
 		}
 	}()
 
@@ -112,39 +141,20 @@ func OAuth(key PublicKeyProvider) func(inner http.Handler) http.Handler {
 				return
 			}
 
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				http.Error(w, "Authorization header is required", http.StatusUnauthorized)
-				return
-			}
+			claims, err := ParseToken(key, r.Header.Get("Authorization"))
 
-			headerParts := strings.Split(authHeader, " ")
-			if len(headerParts) != 2 || headerParts[0] != "Bearer" {
-				http.Error(w, "Authorization header format must be Bearer {token}", http.StatusUnauthorized)
+			switch {
+			case errors.Is(err, errAuthorizationHeaderRequired), errors.Is(err, errAuthorizationHeaderFormat):
+				http.Error(w, err.Error(), http.StatusUnauthorized)
 				return
-			}
-
-			tokenString := headerParts[1]
-
-			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-				kid := token.Header["kid"]
-
-				jwks := key.Get(fmt.Sprint(kid))
-				if jwks == nil {
-					return nil, JWKNotFound{}
-				}
-
-				return key.Get(fmt.Sprint(kid)), nil
-			})
-
-			if err != nil {
+			case err != nil:
 				w.WriteHeader(http.StatusUnauthorized)
 				_, _ = w.Write([]byte(err.Error()))
 
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), JWTClaim("JWTClaims"), token.Claims)
+			ctx := context.WithValue(r.Context(), JWTClaim("JWTClaims"), claims)
 			*r = *r.Clone(ctx)
 
 			inner.ServeHTTP(w, r)
@@ -152,6 +162,34 @@ func OAuth(key PublicKeyProvider) func(inner http.Handler) http.Handler {
 	}
 }
 
+// ParseToken validates the bearer token of the Authorization header using the public keys, and returns its claims.
+func ParseToken(key PublicKeyProvider, authHeader string) (jwt.Claims, error) {
+	if authHeader == "" {
+		return nil, errAuthorizationHeaderRequired
+	}
+
+	headerParts := strings.Split(authHeader, " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+		return nil, errAuthorizationHeaderFormat
+	}
+
+	token, err := jwt.Parse(headerParts[1], func(token *jwt.Token) (interface{}, error) {
+		kid := token.Header["kid"]
+
+		jwks := key.Get(fmt.Sprint(kid))
+		if jwks == nil {
+			return nil, JWKNotFound{}
+		}
+
+		return jwks, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return token.Claims, nil
+}
+
 // JWKS represents a JSON Web Key Set.
 type JWKS struct {
 	Keys []JSONWebKey `json:"keys"`
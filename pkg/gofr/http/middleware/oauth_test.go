@@ -9,9 +9,12 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
 )
 
 func TestOAuthSuccess(t *testing.T) {
@@ -265,6 +268,20 @@ func TestOAuthJSONUnmarshalError(t *testing.T) {
 	resp.Body.Close()
 }
 
+func TestNewOAuth_Refresh(t *testing.T) {
+	fakeClock := clock.NewFake(time.Now())
+	keys := NewOAuth(OauthConfigs{Provider: &MockProvider{}, RefreshInterval: time.Minute, Clock: fakeClock})
+
+	const kid = "ICnaYtL-H11rItZRxUYKTIsnnrnmpYJzpaVDuCEctRI="
+
+	fakeClock.Advance(59 * time.Second)
+	assert.Nil(t, keys.Get(kid), "keys should not be fetched before the refresh interval")
+
+	fakeClock.Advance(time.Second)
+	assert.Eventually(t, func() bool { return keys.Get(kid) != nil }, time.Second, time.Millisecond,
+		"keys should be fetched once the refresh interval has passed")
+}
+
 type MockProvider struct {
 }
 
@@ -0,0 +1,226 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+)
+
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+
+	// QuotaDaily is the period of the daily quotas, which restart at midnight UTC.
+	QuotaDaily = "daily"
+	// QuotaMonthly is the period of the monthly quotas, which restart on the first day of the month, at midnight UTC.
+	QuotaMonthly = "monthly"
+)
+
+// ErrorQuotaExceeded is responded to the requests of a principal which exceeded its quota.
+type ErrorQuotaExceeded struct {
+	Period string `json:"period"`
+}
+
+func (e ErrorQuotaExceeded) Error() string {
+	return e.Period + " quota exceeded"
+}
+
+func (ErrorQuotaExceeded) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+// Quota is the number of the requests a principal can make per day and per month, unlimited if not positive.
+type Quota struct {
+	Daily   int64
+	Monthly int64
+}
+
+// QuotaFunc returns the quota of a principal, like the quota of the plan of the customer of an API key.
+type QuotaFunc func(ctx context.Context, principal string) (Quota, error)
+
+// QuotaUsage is the number of the requests a principal has made in the current period of its quota.
+type QuotaUsage struct {
+	Period    string    `json:"period"`
+	Limit     int64     `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining int64     `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// quotaStore counts the requests of the principals in the periods of their quotas, like container.QuotaStore.
+type quotaStore interface {
+	Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error)
+	Count(ctx context.Context, key string) (int64, error)
+}
+
+// quotaPeriod is the current period of a limit of a quota, whose requests are counted with key until reset.
+type quotaPeriod struct {
+	name  string
+	limit int64
+	key   string
+	reset time.Time
+}
+
+// authPrincipal is the context key of the principal set by the authentication middlewares.
+type authPrincipal string
+
+const principalKey authPrincipal = "principal"
+
+// withPrincipal returns the request authenticated as the principal.
+func withPrincipal(r *http.Request, principal string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), principalKey, principal))
+}
+
+// Principal returns the verified principal of the request, or "" for the anonymous requests.
+func Principal(r *http.Request) string {
+	if claims, ok := r.Context().Value(JWTClaim("JWTClaims")).(jwt.Claims); ok {
+		if sub, _ := claims.GetSubject(); sub != "" {
+			return "user:" + sub
+		}
+	}
+
+	principal, _ := r.Context().Value(principalKey).(string)
+
+	return principal
+}
+
+// APIKeyPrincipal returns the principal of the API key, "key:" followed by its hash.
+func APIKeyPrincipal(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return "key:" + hex.EncodeToString(sum[:8])
+}
+
+// Quotas responds with 429 to the requests of the principals exceeding their quotas. It must be used after the
+// authentication middlewares.
+func Quotas(store quotaStore, quotas QuotaFunc, clk clock.Clock) func(inner http.Handler) http.Handler {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := Principal(r)
+			if principal == "" || isWellKnown(r.URL.Path) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			quota, err := quotas(r.Context(), principal)
+			if err != nil {
+				gofrHTTP.NewResponder(w, r.Method).Respond(nil, err)
+				return
+			}
+
+			now := clk.Now()
+			periods := quotaPeriods(principal, quota, now)
+
+			// the limits are all checked before the request is counted, so that a request rejected by one of them is
+			// not counted by the others.
+			for _, p := range periods {
+				used, countErr := store.Count(r.Context(), p.key)
+				if countErr != nil {
+					respondQuotaStoreUnavailable(w, r)
+					return
+				}
+
+				if used >= p.limit {
+					respondQuotaExceeded(w, r, p, used, now)
+					return
+				}
+			}
+
+			var fewest *QuotaUsage
+
+			for _, p := range periods {
+				used, incrementErr := store.Increment(r.Context(), p.key, p.reset)
+				if incrementErr != nil {
+					respondQuotaStoreUnavailable(w, r)
+					return
+				}
+
+				// the concurrent requests of the principal may have used the quota since it was checked.
+				if used > p.limit {
+					respondQuotaExceeded(w, r, p, used, now)
+					return
+				}
+
+				if usage := p.usage(used); fewest == nil || usage.Remaining < fewest.Remaining {
+					fewest = &usage
+				}
+			}
+
+			if fewest != nil {
+				setRateLimitHeaders(w, fewest)
+			}
+
+			inner.ServeHTTP(w, r)
+		})
+	}
+}
+
+func respondQuotaStoreUnavailable(w http.ResponseWriter, r *http.Request) {
+	gofrHTTP.NewResponder(w, r.Method).Respond(nil, gofrHTTP.ErrorServiceUnavailable{Dependencies: []string{"quota store"}})
+}
+
+func respondQuotaExceeded(w http.ResponseWriter, r *http.Request, p quotaPeriod, used int64, now time.Time) {
+	usage := p.usage(used)
+
+	setRateLimitHeaders(w, &usage)
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(p.reset.Sub(now).Seconds()))))
+	gofrHTTP.NewResponder(w, r.Method).Respond(nil, ErrorQuotaExceeded{Period: p.name})
+}
+
+// CountQuotaUsage returns the usage of the quota of the principal at now.
+func CountQuotaUsage(ctx context.Context, store quotaStore, principal string, quota Quota, now time.Time) ([]QuotaUsage, error) {
+	usage := []QuotaUsage{}
+
+	for _, p := range quotaPeriods(principal, quota, now) {
+		used, err := store.Count(ctx, p.key)
+		if err != nil {
+			return nil, err
+		}
+
+		usage = append(usage, p.usage(used))
+	}
+
+	return usage, nil
+}
+
+// quotaPeriods returns the current periods of the limits of the quota which are not unlimited, the day before the month.
+func quotaPeriods(principal string, quota Quota, now time.Time) []quotaPeriod {
+	now = now.UTC()
+
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var periods []quotaPeriod
+
+	if quota.Daily > 0 {
+		periods = append(periods, quotaPeriod{name: QuotaDaily, limit: quota.Daily,
+			key: principal + ":day:" + day.Format(time.DateOnly), reset: day.AddDate(0, 0, 1)})
+	}
+
+	if quota.Monthly > 0 {
+		periods = append(periods, quotaPeriod{name: QuotaMonthly, limit: quota.Monthly,
+			key: principal + ":month:" + month.Format("2006-01"), reset: month.AddDate(0, 1, 0)})
+	}
+
+	return periods
+}
+
+func (p quotaPeriod) usage(used int64) QuotaUsage {
+	return QuotaUsage{Period: p.name, Limit: p.limit, Used: used, Remaining: max(p.limit-used, 0), Reset: p.reset}
+}
+
+func setRateLimitHeaders(w http.ResponseWriter, usage *QuotaUsage) {
+	w.Header().Set(rateLimitLimitHeader, strconv.FormatInt(usage.Limit, 10))
+	w.Header().Set(rateLimitRemainingHeader, strconv.FormatInt(usage.Remaining, 10))
+	w.Header().Set(rateLimitResetHeader, strconv.FormatInt(usage.Reset.Unix(), 10))
+}
@@ -0,0 +1,216 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+type memoryQuotaStore struct {
+	mu     sync.Mutex
+	counts map[string]int64
+	err    error
+}
+
+func (s *memoryQuotaStore) Increment(_ context.Context, key string, _ time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	s.counts[key]++
+
+	return s.counts[key], nil
+}
+
+func (s *memoryQuotaStore) Count(_ context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.counts[key], s.err
+}
+
+func TestQuotas(t *testing.T) {
+	store := &memoryQuotaStore{counts: make(map[string]int64)}
+	clk := clock.NewFake(time.Date(2026, 10, 30, 23, 59, 0, 0, time.UTC))
+
+	quotas := func(_ context.Context, principal string) (Quota, error) {
+		if principal == "user:alice" {
+			return Quota{Daily: 2, Monthly: 3}, nil
+		}
+
+		return Quota{Daily: 10}, nil
+	}
+
+	handler := Quotas(store, quotas, clk)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	endOfDay, endOfMonth := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc       string
+		advance    time.Duration
+		user       string
+		apiKey     string
+		statusCode int
+		limit      string
+		remaining  string
+		reset      time.Time
+		retryAfter string
+	}{
+		{"first request", 0, "alice", "", http.StatusOK, "2", "1", endOfDay, ""},
+		{"last request of the day", 0, "alice", "", http.StatusOK, "2", "0", endOfDay, ""},
+		{"daily quota exceeded", 0, "alice", "", http.StatusTooManyRequests, "2", "0", endOfDay, "60"},
+		{"another principal", 0, "", "secret", http.StatusOK, "10", "9", endOfDay, ""},
+		{"next day", time.Minute, "alice", "", http.StatusOK, "3", "0", endOfMonth, ""},
+		{"monthly quota exceeded", 0, "alice", "", http.StatusTooManyRequests, "3", "0", endOfMonth, "86400"},
+		{"anonymous request", 0, "", "", http.StatusOK, "", "", time.Time{}, ""},
+	}
+
+	for i, tc := range testCases {
+		clk.Advance(tc.advance)
+
+		r := httptest.NewRequest(http.MethodGet, "/orders", http.NoBody)
+
+		if tc.user != "" {
+			r = withPrincipal(r, "user:"+tc.user)
+		}
+
+		if tc.apiKey != "" {
+			r = withPrincipal(r, APIKeyPrincipal(tc.apiKey))
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		reset := ""
+		if !tc.reset.IsZero() {
+			reset = strconv.FormatInt(tc.reset.Unix(), 10)
+		}
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.limit, w.Header().Get("X-RateLimit-Limit"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.remaining, w.Header().Get("X-RateLimit-Remaining"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, reset, w.Header().Get("X-RateLimit-Reset"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.retryAfter, w.Header().Get("Retry-After"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	usage, err := CountQuotaUsage(context.Background(), store, "user:alice", Quota{Daily: 2, Monthly: 3}, clk.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, []QuotaUsage{
+		{Period: QuotaDaily, Limit: 2, Used: 1, Remaining: 1, Reset: endOfDay.AddDate(0, 0, 1)},
+		{Period: QuotaMonthly, Limit: 3, Used: 3, Remaining: 0, Reset: endOfMonth},
+	}, usage)
+}
+
+func TestQuotas_MonthlyQuotaExceeded(t *testing.T) {
+	now := time.Date(2026, 10, 30, 12, 0, 0, 0, time.UTC)
+	store := &memoryQuotaStore{counts: map[string]int64{"user:alice:month:2026-10": 3}}
+
+	quotas := func(context.Context, string) (Quota, error) {
+		return Quota{Daily: 2, Monthly: 3}, nil
+	}
+
+	handler := Quotas(store, quotas, clock.NewFake(now))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("handler should not be called")
+	}))
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, withPrincipal(httptest.NewRequest(http.MethodGet, "/orders", http.NoBody), "user:alice"))
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code, "TEST[%d], Failed.\nmonthly quota exceeded", i)
+	}
+
+	usage, err := CountQuotaUsage(context.Background(), store, "user:alice", Quota{Daily: 2, Monthly: 3}, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(0), usage[0].Used, "the rejected requests should not use the daily quota")
+	assert.Equal(t, int64(3), usage[1].Used)
+}
+
+func TestQuotas_Errors(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		storeErr   error
+		quotaErr   error
+		statusCode int
+	}{
+		{"store unavailable", errStoreUnavailable, nil, http.StatusServiceUnavailable},
+		{"quota not found", nil, errStoreUnavailable, http.StatusInternalServerError},
+	}
+
+	for i, tc := range testCases {
+		store := &memoryQuotaStore{counts: make(map[string]int64), err: tc.storeErr}
+
+		quotas := func(context.Context, string) (Quota, error) {
+			return Quota{Daily: 1}, tc.quotaErr
+		}
+
+		handler := Quotas(store, quotas, clock.New())(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			t.Errorf("TEST[%d], Failed.\nhandler should not be called", i)
+		}))
+
+		r := withPrincipal(httptest.NewRequest(http.MethodGet, "/orders", http.NoBody), APIKeyPrincipal("secret"))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestPrincipal(t *testing.T) {
+	withClaims := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	withClaims = withClaims.WithContext(context.WithValue(withClaims.Context(), JWTClaim("JWTClaims"),
+		jwt.MapClaims{"sub": "bob"}))
+	withClaims.Header.Set("X-API-KEY", "secret")
+
+	withKey := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	withKey.Header.Set("X-API-KEY", "secret")
+
+	withBasicAuth := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	withBasicAuth.SetBasicAuth("alice", "password")
+
+	var authenticated []string
+
+	// the principals are only those verified by the authentication middlewares, not those of the headers.
+	recordPrincipal := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		authenticated = append(authenticated, Principal(r))
+	})
+
+	APIKeyAuthMiddleware(nil, "secret")(recordPrincipal).ServeHTTP(httptest.NewRecorder(), withKey)
+	BasicAuthMiddleware(BasicAuthProvider{Users: map[string]string{"alice": "password"}})(recordPrincipal).
+		ServeHTTP(httptest.NewRecorder(), withBasicAuth)
+
+	assert.Equal(t, []string{"key:2bb80d537b1da3e3", "user:alice"}, authenticated)
+
+	testCases := []struct {
+		desc      string
+		request   *http.Request
+		principal string
+	}{
+		{"subject of the JWT", withClaims, "user:bob"},
+		{"unverified API key", withKey, ""},
+		{"unverified basic authentication", withBasicAuth, ""},
+		{"anonymous", httptest.NewRequest(http.MethodGet, "/", http.NoBody), ""},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.principal, Principal(tc.request), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/mirror"
+	"github.com/peter-stratton/gofr/pkg/gofr/recording"
+)
+
+// Recording records the percentage of the requests, and their responses, with the recorder.
+func Recording(recorder *recording.Recorder, percentage float64, logger logger) func(inner http.Handler) http.Handler {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(recording.ReplayedHeader) != "" || !mirror.Sampled(percentage) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			rw := &mirrorResponseWriter{ResponseWriter: w}
+			start := time.Now()
+
+			inner.ServeHTTP(rw, r)
+
+			if err := recorder.Record(r, body, rw.Status(), rw.Body(), time.Since(start)); err != nil {
+				logger.Error("could not record the request: ", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/recording"
+)
+
+func TestRecording(t *testing.T) {
+	dir := t.TempDir()
+
+	recorder, err := recording.NewRecorder(dir, recording.Sanitizer{})
+	require.NoError(t, err)
+
+	defer recorder.Close()
+
+	handler := Recording(recorder, 100, mirrorLogger{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	}))
+
+	testCases := []struct {
+		desc   string
+		header string
+	}{
+		{"recorded request", ""},
+		{"replayed request", "true"},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"id":1,"password":"p"}`))
+		r.Header.Set(recording.ReplayedHeader, tc.header)
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusCreated, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, `{"id":1,"password":"p"}`, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	var records []*recording.Record
+
+	require.NoError(t, recording.Read(filepath.Clean(dir), func(rec *recording.Record) error {
+		records = append(records, rec)
+		return nil
+	}))
+
+	require.Len(t, records, 1)
+	assert.Equal(t, "/orders", records[0].URI)
+	assert.Equal(t, http.StatusCreated, records[0].Status)
+	assert.JSONEq(t, `{"id":1,"password":"<redacted>"}`, records[0].Body)
+	assert.JSONEq(t, `{"id":1,"password":"<redacted>"}`, records[0].ResponseBody)
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+)
+
+// The names of the RED metrics, of the rate, the errors and the duration of the requests.
+const (
+	REDRequests         = "app_http_requests"
+	REDErrors           = "app_http_request_errors"
+	REDDuration         = "app_http_request_duration_seconds"
+	REDOverObjective    = "app_http_requests_over_objective"
+	REDLatencyObjective = "app_http_latency_objective_seconds"
+)
+
+// RED is a middleware which records the RED metrics of the requests.
+func RED(metrics metrics, service string,
+	objective func(method, route string) time.Duration) func(inner http.Handler) http.Handler {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			srw := &StatusResponseWriter{ResponseWriter: w}
+
+			inner.ServeHTTP(srw, r)
+
+			duration := time.Since(start)
+			route := strings.TrimSuffix(gofrHTTP.PathTemplate(r), "/")
+			status := srw.Status()
+			ctx := context.Background()
+
+			metrics.IncrementCounter(ctx, REDRequests, "service", service, "route", route, "method", r.Method,
+				"code", strconv.Itoa(status))
+
+			labels := []string{"service", service, "route", route, "method", r.Method}
+
+			if status >= http.StatusInternalServerError {
+				metrics.IncrementCounter(ctx, REDErrors, labels...)
+			}
+
+			metrics.RecordHistogram(ctx, REDDuration, duration.Seconds(), labels...)
+
+			if o := objective(r.Method, route); o > 0 && duration > o {
+				metrics.IncrementCounter(ctx, REDOverObjective, labels...)
+			}
+		})
+	}
+}
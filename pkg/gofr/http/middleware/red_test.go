@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRED(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		status        int
+		delay         time.Duration
+		error         bool
+		overObjective bool
+	}{
+		{"success", http.StatusOK, 0, false, false},
+		{"client error", http.StatusNotFound, 0, false, false},
+		{"server error", http.StatusServiceUnavailable, 0, true, false},
+		{"slow", http.StatusOK, 20 * time.Millisecond, false, true},
+	}
+
+	for _, tc := range testCases {
+		m := &mockMetrics{}
+		m.On("IncrementCounter", mock.Anything, mock.Anything, mock.Anything).Return()
+		m.On("RecordHistogram", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+		router := mux.NewRouter()
+		router.HandleFunc("/orders/{id}", func(w http.ResponseWriter, _ *http.Request) {
+			time.Sleep(tc.delay)
+			w.WriteHeader(tc.status)
+		}).Methods(http.MethodGet)
+
+		router.Use(RED(m, "orders", func(method, route string) time.Duration {
+			if method == http.MethodGet && route == "/orders/{id}" {
+				return 10 * time.Millisecond
+			}
+
+			return time.Second
+		}))
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/1", http.NoBody))
+
+		labels := []string{"service", "orders", "route", "/orders/{id}", "method", "GET"}
+
+		m.AssertCalled(t, "IncrementCounter", mock.Anything, REDRequests,
+			append(labels, "code", strconv.Itoa(tc.status)))
+		m.AssertCalled(t, "RecordHistogram", mock.Anything, REDDuration, mock.Anything, labels)
+
+		if tc.error {
+			m.AssertCalled(t, "IncrementCounter", mock.Anything, REDErrors, labels)
+		} else {
+			m.AssertNotCalled(t, "IncrementCounter", mock.Anything, REDErrors, labels)
+		}
+
+		if tc.overObjective {
+			m.AssertCalled(t, "IncrementCounter", mock.Anything, REDOverObjective, labels)
+		} else {
+			m.AssertNotCalled(t, "IncrementCounter", mock.Anything, REDOverObjective, labels)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+)
+
+// Scopes maps the routes, like "GET /customers/{id}", to the OAuth scopes they require.
+type Scopes map[string][]string
+
+// Missing returns the scopes required by the route which are not granted by the claims.
+func (s Scopes) Missing(route string, claims jwt.Claims) []string {
+	required := s[route]
+	if len(required) == 0 {
+		return nil
+	}
+
+	granted := grantedScopes(claims)
+
+	var missing []string
+
+	for _, scope := range required {
+		if !granted[scope] {
+			missing = append(missing, scope)
+		}
+	}
+
+	return missing
+}
+
+func grantedScopes(claims jwt.Claims) map[string]bool {
+	granted := make(map[string]bool)
+
+	mapClaims, ok := claims.(jwt.MapClaims)
+	if !ok {
+		return granted
+	}
+
+	if scope, ok := mapClaims["scope"].(string); ok {
+		for _, s := range strings.Fields(scope) {
+			granted[s] = true
+		}
+	}
+
+	switch scp := mapClaims["scp"].(type) {
+	case string:
+		for _, s := range strings.Fields(scp) {
+			granted[s] = true
+		}
+	case []interface{}:
+		for _, s := range scp {
+			granted[fmt.Sprint(s)] = true
+		}
+	}
+
+	return granted
+}
+
+// ScopeAuthorization is a middleware which rejects the requests without the scopes of their route.
+func ScopeAuthorization(scopes Scopes) func(inner http.Handler) http.Handler {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			template := gofrHTTP.PathTemplate(r)
+			if template == "" {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			key := r.Method + " " + template
+
+			if len(scopes[key]) == 0 {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			claims, ok := r.Context().Value(JWTClaim("JWTClaims")).(jwt.Claims)
+			if !ok {
+				http.Error(w, "Authorization is required", http.StatusUnauthorized)
+				return
+			}
+
+			if missing := scopes.Missing(key, claims); len(missing) > 0 {
+				http.Error(w, "Token is missing the scopes: "+strings.Join(missing, " "), http.StatusForbidden)
+				return
+			}
+
+			inner.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopes_Missing(t *testing.T) {
+	scopes := Scopes{"GET /customers": {"customers:read", "customers:list"}}
+
+	testCases := []struct {
+		desc    string
+		route   string
+		claims  jwt.Claims
+		missing []string
+	}{
+		{"no scopes required", "POST /customers", jwt.MapClaims{}, nil},
+		{"scope claim", "GET /customers", jwt.MapClaims{"scope": "customers:read customers:list"}, nil},
+		{"scp claim as list", "GET /customers", jwt.MapClaims{"scp": []interface{}{"customers:read"}},
+			[]string{"customers:list"}},
+		{"scp claim as string", "GET /customers", jwt.MapClaims{"scp": "customers:list"}, []string{"customers:read"}},
+		{"claims without scopes", "GET /customers", &jwt.RegisteredClaims{}, []string{"customers:read", "customers:list"}},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.missing, scopes.Missing(tc.route, tc.claims), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestScopeAuthorization(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/customers/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods(http.MethodGet, http.MethodDelete)
+
+	// the claims of the token are set by the OAuth middleware, from the scope header in this test.
+	router.Use(func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if scope := r.Header.Get("X-Scope"); scope != "" {
+				r = r.WithContext(context.WithValue(r.Context(), JWTClaim("JWTClaims"), jwt.MapClaims{"scope": scope}))
+			}
+
+			inner.ServeHTTP(w, r)
+		})
+	}, ScopeAuthorization(Scopes{"DELETE /customers/{id}": {"customers:delete"}}))
+
+	testCases := []struct {
+		desc    string
+		method  string
+		scope   string
+		expCode int
+	}{
+		{"route without scopes", http.MethodGet, "", http.StatusOK},
+		{"request without token", http.MethodDelete, "", http.StatusUnauthorized},
+		{"token without scope", http.MethodDelete, "customers:read", http.StatusForbidden},
+		{"token with scope", http.MethodDelete, "customers:read customers:delete", http.StatusOK},
+	}
+
+	for i, tc := range testCases {
+		req := httptest.NewRequest(tc.method, "/customers/1", http.NoBody)
+		req.Header.Set("X-Scope", tc.scope)
+
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.expCode, rec.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+// ErrorTenantRequired is responded to the requests whose tenant is not resolved, or is not valid.
+type ErrorTenantRequired struct{}
+
+func (ErrorTenantRequired) Error() string {
+	return "tenant is required"
+}
+
+func (ErrorTenantRequired) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// TenantFromClaim resolves the tenant from the claim of the JWT.
+func TenantFromClaim(claim string) tenant.Resolver {
+	return func(r *http.Request) string {
+		claims, ok := r.Context().Value(JWTClaim("JWTClaims")).(jwt.MapClaims)
+		if !ok {
+			return ""
+		}
+
+		id, _ := claims[claim].(string)
+
+		return id
+	}
+}
+
+// Tenancy resolves the tenant of each request with the first resolver resolving it, and responds with 400 without one.
+func Tenancy(metrics metrics, resolvers ...tenant.Resolver) func(inner http.Handler) http.Handler {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/.well-known/") {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			id := resolveTenant(r, resolvers)
+			if !tenant.ValidID(id) {
+				gofrHTTP.NewResponder(w, r.Method).Respond(nil, ErrorTenantRequired{})
+				return
+			}
+
+			start := time.Now()
+			srw := &StatusResponseWriter{ResponseWriter: w}
+
+			defer func() {
+				metrics.RecordHistogram(context.Background(), "app_tenant_http_response", time.Since(start).Seconds(),
+					"tenant", id, "path", strings.TrimSuffix(gofrHTTP.PathTemplate(r), "/"), "method", r.Method,
+					"status", strconv.Itoa(srw.Status()))
+			}()
+
+			inner.ServeHTTP(srw, r.WithContext(tenant.NewContext(r.Context(), id)))
+		})
+	}
+}
+
+func resolveTenant(r *http.Request, resolvers []tenant.Resolver) string {
+	for _, resolve := range resolvers {
+		if id := resolve(r); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+func TestTenantFromClaim(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		claims interface{}
+		tenant string
+	}{
+		{"claim", jwt.MapClaims{"tenant": "acme"}, "acme"},
+		{"no claim", jwt.MapClaims{"sub": "user"}, ""},
+		{"claim of another type", jwt.MapClaims{"tenant": 1}, ""},
+		{"no claims", nil, ""},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/orders", http.NoBody)
+		r = r.WithContext(context.WithValue(r.Context(), JWTClaim("JWTClaims"), tc.claims))
+
+		assert.Equal(t, tc.tenant, TenantFromClaim("tenant")(r), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestTenancy(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		host       string
+		path       string
+		header     string
+		statusCode int
+		body       string
+	}{
+		{"tenant from the header", "example.com", "/orders", "acme", http.StatusOK, "acme"},
+		{"tenant from the subdomain", "globex.example.com", "/orders", "", http.StatusOK, "globex"},
+		{"invalid tenant", "example.com", "/orders", "acme corp", http.StatusBadRequest,
+			`{"error":{"message":"tenant is required"}}` + "\n"},
+		{"no tenant", "example.com", "/orders", "", http.StatusBadRequest,
+			`{"error":{"message":"tenant is required"}}` + "\n"},
+		{"well-known endpoint", "example.com", "/.well-known/health", "", http.StatusOK, ""},
+	}
+
+	for i, tc := range testCases {
+		metrics := &mockMetrics{}
+		metrics.On("RecordHistogram", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		router := mux.NewRouter()
+		router.Use(Tenancy(metrics, tenant.FromHeader("X-Tenant-ID"), tenant.FromSubdomain("example.com")))
+		router.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(tenant.FromContext(r.Context())))
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "http://"+tc.host+tc.path, http.NoBody)
+		r.Header.Set("X-Tenant-ID", tc.header)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.body == "" || tc.statusCode != http.StatusOK {
+			metrics.AssertNotCalled(t, "RecordHistogram", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			continue
+		}
+
+		metrics.AssertCalled(t, "RecordHistogram", mock.Anything, "app_tenant_http_response", mock.Anything,
+			[]string{"tenant", tc.body, "path", "", "method", http.MethodGet, "status", "200"})
+	}
+}
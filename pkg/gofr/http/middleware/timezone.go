@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+)
+
+// TimezoneFromClaim resolves the time zone of the caller from the claim of the JWT, like "zoneinfo".
+func TimezoneFromClaim(claim string) gofrHTTP.LocationResolver {
+	return func(r *http.Request) *time.Location {
+		claims, ok := r.Context().Value(JWTClaim("JWTClaims")).(jwt.MapClaims)
+		if !ok {
+			return nil
+		}
+
+		name, _ := claims[claim].(string)
+		if name == "" || name == "Local" {
+			return nil
+		}
+
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil
+		}
+
+		return loc
+	}
+}
+
+// Timezone resolves the time zone of the caller of each request with the first resolver resolving it.
+func Timezone(resolvers ...gofrHTTP.LocationResolver) func(inner http.Handler) http.Handler {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, resolve := range resolvers {
+				if loc := resolve(r); loc != nil {
+					r = r.WithContext(gofrHTTP.NewLocationContext(r.Context(), loc))
+					break
+				}
+			}
+
+			inner.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+)
+
+func TestTimezone(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		claims   interface{}
+		header   string
+		location string
+	}{
+		{"time zone of the claim", jwt.MapClaims{"zoneinfo": "Asia/Tokyo"}, "Europe/Paris", "Asia/Tokyo"},
+		{"unknown time zone of the claim", jwt.MapClaims{"zoneinfo": "Mars/Olympus"}, "Europe/Paris", "Europe/Paris"},
+		{"no claims", nil, "Europe/Paris", "Europe/Paris"},
+		{"no time zone", jwt.MapClaims{"sub": "user"}, "", ""},
+	}
+
+	for i, tc := range testCases {
+		var location string
+
+		handler := Timezone(TimezoneFromClaim("zoneinfo"), gofrHTTP.LocationFromHeader(gofrHTTP.TimezoneHeader))(
+			http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				if loc := gofrHTTP.LocationFromContext(r.Context()); loc != nil {
+					location = loc.String()
+				}
+			}))
+
+		r := httptest.NewRequest(http.MethodGet, "/orders", http.NoBody)
+		r = r.WithContext(context.WithValue(r.Context(), JWTClaim("JWTClaims"), tc.claims))
+		r.Header.Set(gofrHTTP.TimezoneHeader, tc.header)
+
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+
+		assert.Equal(t, tc.location, location, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
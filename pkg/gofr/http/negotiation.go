@@ -0,0 +1,318 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The formats in which the responses can be written.
+const (
+	FormatJSON = "json"
+	FormatXML  = "xml"
+	FormatYAML = "yaml"
+	FormatCSV  = "csv"
+)
+
+//nolint:gochecknoglobals // the media types of the formats are constant.
+var (
+	formatMediaTypes = map[string]string{
+		"application/json":   FormatJSON,
+		"application/xml":    FormatXML,
+		"text/xml":           FormatXML,
+		"application/yaml":   FormatYAML,
+		"application/x-yaml": FormatYAML,
+		"text/yaml":          FormatYAML,
+		"text/csv":           FormatCSV,
+	}
+
+	formatContentTypes = map[string]string{
+		FormatJSON: "application/json",
+		FormatXML:  "application/xml",
+		FormatYAML: "application/yaml",
+		FormatCSV:  "text/csv; charset=utf-8",
+	}
+)
+
+// IsFormat reports whether the format is one in which the responses can be written.
+func IsFormat(format string) bool {
+	_, ok := formatContentTypes[format]
+	return ok
+}
+
+// WithFormats writes the responses in the format preferred by the accept header among the formats, or the first one.
+func WithFormats(accept string, formats ...string) ResponderOption {
+	return func(r *Responder) {
+		r.format = negotiateFormat(accept, formats)
+	}
+}
+
+// negotiateFormat returns the format preferred by the accept header among the formats, or the first format.
+func negotiateFormat(accept string, formats []string) string {
+	if len(formats) == 0 {
+		return FormatJSON
+	}
+
+	if accept == "" || strings.Contains(accept, "text/html") {
+		return formats[0]
+	}
+
+	format, quality := formats[0], 0.0
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, q := parseMediaRange(mediaRange)
+
+		f, ok := formatMediaTypes[mediaType]
+		if !ok || q <= quality || !contains(formats, f) {
+			continue
+		}
+
+		format, quality = f, q
+	}
+
+	return format
+}
+
+// parseMediaRange returns the media type of a media range of an Accept header, and its quality.
+func parseMediaRange(mediaRange string) (mediaType string, quality float64) {
+	params := strings.Split(mediaRange, ";")
+	quality = 1
+
+	for _, p := range params[1:] {
+		name, value, _ := strings.Cut(strings.TrimSpace(p), "=")
+		if name == "q" {
+			if q, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = q
+			}
+		}
+	}
+
+	return strings.ToLower(strings.TrimSpace(params[0])), quality
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// encodeXML writes the data in XML under the root element, with the names of its JSON encoding.
+func encodeXML(buf *bytes.Buffer, root string, data interface{}) error {
+	var jsonBuf bytes.Buffer
+
+	if err := json.NewEncoder(&jsonBuf).Encode(data); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(&jsonBuf)
+	dec.UseNumber()
+
+	buf.WriteString(xml.Header)
+
+	if err := writeXMLElement(buf, dec, root); err != nil {
+		return err
+	}
+
+	buf.WriteString("\n")
+
+	return nil
+}
+
+// writeXMLElement writes the next JSON value of the decoder as the element of the name.
+func writeXMLElement(buf *bytes.Buffer, dec *json.Decoder, name string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	name = xmlName(name)
+
+	buf.WriteString("<" + name + ">")
+
+	switch v := tok.(type) {
+	case json.Delim:
+		if err := writeXMLContent(buf, dec, v); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		if err := xml.EscapeText(buf, []byte(fmt.Sprint(v))); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString("</" + name + ">")
+
+	return nil
+}
+
+// writeXMLContent writes the fields of an object, or the values of an array, until its closing delimiter.
+func writeXMLContent(buf *bytes.Buffer, dec *json.Decoder, delim json.Delim) error {
+	for dec.More() {
+		name := "item"
+
+		if delim == '{' {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+
+			name = fmt.Sprint(tok)
+		}
+
+		if err := writeXMLElement(buf, dec, name); err != nil {
+			return err
+		}
+	}
+
+	// the closing delimiter.
+	_, err := dec.Token()
+
+	return err
+}
+
+// xmlName returns the name with the characters which are not valid in the names of the XML elements replaced by "_".
+func xmlName(name string) string {
+	valid := func(i int, r rune) bool {
+		return r == '_' || unicode.IsLetter(r) || (i > 0 && (r == '-' || r == '.' || unicode.IsDigit(r)))
+	}
+
+	var sb strings.Builder
+
+	for i, r := range name {
+		if !valid(i, r) {
+			r = '_'
+		}
+
+		sb.WriteRune(r)
+	}
+
+	if sb.Len() == 0 {
+		return "_"
+	}
+
+	return sb.String()
+}
+
+// encodeYAML writes the data in YAML with the names of its JSON encoding.
+func encodeYAML(buf *bytes.Buffer, data interface{}) error {
+	var jsonBuf bytes.Buffer
+
+	if err := json.NewEncoder(&jsonBuf).Encode(data); err != nil {
+		return err
+	}
+
+	var node yaml.Node
+
+	if err := yaml.Unmarshal(jsonBuf.Bytes(), &node); err != nil {
+		return err
+	}
+
+	clearStyle(&node)
+
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, n := range node.Content {
+		clearStyle(n)
+	}
+}
+
+// isCSVData reports whether the data is a slice of structs, or of pointers to structs, which is written as CSV.
+func isCSVData(data interface{}) bool {
+	t := reflect.TypeOf(data)
+	if t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array) {
+		return false
+	}
+
+	t = t.Elem()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct
+}
+
+// encodeCSV writes a slice of structs as CSV, with a header of their JSON names.
+func encodeCSV(buf *bytes.Buffer, data interface{}) error {
+	v := reflect.ValueOf(data)
+	t := v.Type().Elem()
+
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var (
+		fields []int
+		header []string
+	)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, i)
+		header = append(header, name)
+	}
+
+	w := csv.NewWriter(buf)
+
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	row := make([]string, len(fields))
+
+	for i := 0; i < v.Len(); i++ {
+		elem := reflect.Indirect(v.Index(i))
+
+		for j, f := range fields {
+			row[j] = ""
+
+			if elem.IsValid() {
+				row[j] = fmt.Sprint(elem.Field(f).Interface())
+			}
+		}
+
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	resTypes "github.com/peter-stratton/gofr/pkg/gofr/http/response"
+)
+
+type negotiationItem struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Secret string `json:"-"`
+	Note   string
+}
+
+func TestNegotiateFormat(t *testing.T) {
+	all := []string{FormatJSON, FormatXML, FormatYAML, FormatCSV}
+
+	testCases := []struct {
+		accept  string
+		formats []string
+		format  string
+	}{
+		{"", all, FormatJSON},
+		{"*/*", all, FormatJSON},
+		{"application/xml", all, FormatXML},
+		{"text/csv", all, FormatCSV},
+		{"application/yaml;q=0.5, application/xml;q=0.8", all, FormatXML},
+		{"application/x-yaml, application/json;q=0.9", all, FormatYAML},
+		{"application/pdf", all, FormatJSON},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", all, FormatJSON},
+		{"application/xml", []string{FormatCSV, FormatJSON}, FormatCSV},
+		{"application/json", []string{FormatCSV, FormatJSON}, FormatJSON},
+		{"application/xml", nil, FormatJSON},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.format, negotiateFormat(tc.accept, tc.formats), "TEST[%d], Failed.\n%s", i, tc.accept)
+	}
+}
+
+func TestResponder_RespondFormats(t *testing.T) {
+	items := []negotiationItem{{ID: 1, Name: "book", Secret: "s", Note: "a, b"}, {ID: 2, Name: "pen"}}
+
+	testCases := []struct {
+		desc        string
+		accept      string
+		data        interface{}
+		err         error
+		statusCode  int
+		contentType string
+		body        string
+	}{
+		{"xml", "application/xml", items[:1], nil, http.StatusOK, "application/xml",
+			`<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<response><data><item><id>1</id><name>book</name><Note>a, b</Note></item></data></response>` + "\n"},
+		{"xml of a map", "application/xml", map[string]interface{}{"a b": "<x>", "1": nil}, nil, http.StatusOK,
+			"application/xml", `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<response><data><_></_><a_b>&lt;x&gt;</a_b></data></response>` + "\n"},
+		{"xml raw", "application/xml", resTypes.Raw{Data: []int{1, 2}}, nil, http.StatusOK, "application/xml",
+			`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<data><item>1</item><item>2</item></data>` + "\n"},
+		{"xml error", "application/xml", nil, ErrorInvalidRoute{}, http.StatusNotFound, "application/xml",
+			`<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<response><error><message>route not registered</message></error></response>` + "\n"},
+		{"xml encoding error", "application/xml", map[string]interface{}{"ch": make(chan int)}, nil,
+			http.StatusInternalServerError, "application/xml", `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<response><error><message>json: unsupported type: chan int</message></error></response>` + "\n"},
+		{"yaml", "application/yaml", items[:1], nil, http.StatusOK, "application/yaml",
+			"data:\n  - id: 1\n    name: book\n    Note: a, b\n"},
+		{"yaml raw", "application/yaml", resTypes.Raw{Data: map[string]int{"count": 2}}, nil, http.StatusOK,
+			"application/yaml", "count: 2\n"},
+		{"csv", "text/csv", items, nil, http.StatusOK, "text/csv; charset=utf-8",
+			"id,name,Note\n1,book,\"a, b\"\n2,pen,\n"},
+		{"csv of pointers", "text/csv", []*negotiationItem{&items[1], nil}, nil, http.StatusOK, "text/csv; charset=utf-8",
+			"id,name,Note\n2,pen,\n,,\n"},
+		{"csv of no slice", "text/csv", negotiationItem{ID: 1}, nil, http.StatusOK, "application/json",
+			`{"data":{"id":1,"name":"","Note":""}}` + "\n"},
+		{"csv error", "text/csv", items, ErrorInvalidRoute{}, http.StatusNotFound, "application/json",
+			`{"error":{"message":"route not registered"},"data":[{"id":1,"name":"book","Note":"a, b"},` +
+				`{"id":2,"name":"pen","Note":""}]}` + "\n"},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+
+		NewResponder(w, http.MethodGet, WithFormats(tc.accept, FormatJSON, FormatXML, FormatYAML, FormatCSV)).
+			Respond(tc.data, tc.err)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
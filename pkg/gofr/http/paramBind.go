@@ -0,0 +1,182 @@
+package http
+
+import (
+	"encoding"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// The tags of the fields bound from the parts of the request other than its body.
+const (
+	pathTag   = "path"
+	queryTag  = "query"
+	headerTag = "header"
+)
+
+//nolint:gochecknoglobals // the types are constant.
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// bindParams binds the path, query and header parameters of the request to the fields of the struct i points to
+// with a path, query or header tag, like `path:"id"`.
+func (r *Request) bindParams(i interface{}) error {
+	v := reflect.ValueOf(i)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	b := paramBinder{req: r, query: r.req.URL.Query()}
+
+	b.bindStruct(v.Elem())
+
+	if len(b.invalid) > 0 {
+		return ErrorInvalidParam{Params: b.invalid}
+	}
+
+	return nil
+}
+
+type paramBinder struct {
+	req   *Request
+	query url.Values
+	// invalid are the names of the parameters which cannot be parsed.
+	invalid []string
+}
+
+func (b *paramBinder) bindStruct(v reflect.Value) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			b.bindStruct(v.Field(i))
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		name, values := b.values(&sf)
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := setParam(v.Field(i), values); err != nil {
+			b.invalid = append(b.invalid, name)
+		}
+	}
+}
+
+// values returns the name of the parameter of the field, and its values in the request.
+func (b *paramBinder) values(sf *reflect.StructField) (name string, values []string) {
+	if name = sf.Tag.Get(pathTag); name != "" {
+		if v, ok := b.req.pathParams[name]; ok {
+			return name, []string{v}
+		}
+
+		return name, nil
+	}
+
+	if name = sf.Tag.Get(queryTag); name != "" {
+		return name, b.query[name]
+	}
+
+	if name = sf.Tag.Get(headerTag); name != "" {
+		return name, b.req.req.Header.Values(name)
+	}
+
+	return "", nil
+}
+
+// setParam sets the field to the values, or to the first one unless the field is a slice.
+func setParam(f reflect.Value, values []string) error {
+	if f.Kind() == reflect.Slice && !f.Type().Implements(textUnmarshalerType) &&
+		!reflect.PointerTo(f.Type()).Implements(textUnmarshalerType) {
+		s := reflect.MakeSlice(f.Type(), len(values), len(values))
+
+		for i, v := range values {
+			if err := setParamValue(s.Index(i), v); err != nil {
+				return err
+			}
+		}
+
+		f.Set(s)
+
+		return nil
+	}
+
+	return setParamValue(f, values[0])
+}
+
+func setParamValue(f reflect.Value, v string) error {
+	if f.Kind() == reflect.Pointer {
+		p := reflect.New(f.Type().Elem())
+
+		if err := setParamValue(p.Elem(), v); err != nil {
+			return err
+		}
+
+		f.Set(p)
+
+		return nil
+	}
+
+	if u, ok := f.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(v))
+	}
+
+	if f.Type() == durationType {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+
+		f.SetInt(int64(d))
+
+		return nil
+	}
+
+	//nolint:exhaustive // the other kinds are not supported
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(v)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(v, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(v, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(v, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		f.SetFloat(n)
+	default:
+		return errUnsupportedParamType
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pagination struct {
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
+}
+
+type updateOrderRequest struct {
+	pagination
+
+	ID       string        `path:"id" json:"-"`
+	Tenant   string        `header:"X-Tenant" json:"-"`
+	Tags     []string      `query:"tag" json:"-"`
+	Draft    *bool         `query:"draft" json:"-"`
+	Timeout  time.Duration `query:"timeout" json:"-"`
+	Since    time.Time     `query:"since" json:"-"`
+	Price    float64       `json:"price"`
+	Note     string        `json:"note"`
+	internal string        `query:"internal"`
+}
+
+func newParamRequest(t *testing.T, target, body string) *Request {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodPut, target, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Tenant", "acme")
+
+	return &Request{req: r, pathParams: map[string]string{"id": "42"}}
+}
+
+func TestBind_Params(t *testing.T) {
+	req := newParamRequest(t, "/orders/42?limit=10&tag=a&tag=b&draft=true&timeout=1m&since=2024-05-01T00:00:00Z"+
+		"&internal=x", `{"price": 9.5, "note": "gift"}`)
+
+	var got updateOrderRequest
+
+	require.NoError(t, req.Bind(&got))
+
+	draft := true
+
+	assert.Equal(t, updateOrderRequest{
+		pagination: pagination{Limit: 10},
+		ID:         "42",
+		Tenant:     "acme",
+		Tags:       []string{"a", "b"},
+		Draft:      &draft,
+		Timeout:    time.Minute,
+		Since:      time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Price:      9.5,
+		Note:       "gift",
+	}, got)
+}
+
+func TestBind_InvalidParams(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		target string
+		params []string
+	}{
+		{"invalid number", "/orders/42?limit=ten", []string{"limit"}},
+		{"invalid values", "/orders/42?draft=maybe&timeout=soon&since=today", []string{"draft", "timeout", "since"}},
+	}
+
+	for i, tc := range testCases {
+		var got updateOrderRequest
+
+		err := newParamRequest(t, tc.target, `{}`).Bind(&got)
+
+		assert.Equal(t, ErrorInvalidParam{Params: tc.params}, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestBind_ParamsWithoutBody(t *testing.T) {
+	router := mux.NewRouter()
+
+	var got struct {
+		ID    int    `path:"id"`
+		Sort  string `query:"sort"`
+		Empty string `query:"empty"`
+		Ratio struct {
+			Value float32 `query:"ratio"`
+		}
+	}
+
+	got.Empty = "default"
+
+	router.HandleFunc("/orders/{id}", func(_ http.ResponseWriter, r *http.Request) {
+		require.NoError(t, NewRequest(r).Bind(&got))
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/7?sort=asc&ratio=0.5", http.NoBody))
+
+	assert.Equal(t, 7, got.ID)
+	assert.Equal(t, "asc", got.Sort)
+	assert.Equal(t, "default", got.Empty, "the fields of the missing parameters are unchanged")
+	assert.Zero(t, got.Ratio.Value, "the fields of the nested structs which are not embedded are not bound")
+
+	var unsupported struct {
+		Filter map[string]string `query:"filter"`
+	}
+
+	err := newParamRequest(t, "/orders?filter=x", `{}`).Bind(&unsupported)
+	assert.Equal(t, ErrorInvalidParam{Params: []string{"filter"}}, err)
+}
@@ -9,9 +9,12 @@ import (
 	"io"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/gorilla/mux"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/bufferpool"
 )
 
 const (
@@ -21,6 +24,8 @@ const (
 var (
 	errNoFileFound    = errors.New("no files were bounded")
 	errNonPointerBind = errors.New("bind error, cannot bind to a non pointer type")
+
+	errUnsupportedParamType = errors.New("the type of the field cannot be bound from a parameter")
 )
 
 // Request is an abstraction over the underlying http.Request. This abstraction is useful because it allows us
@@ -28,14 +33,37 @@ var (
 type Request struct {
 	req        *http.Request
 	pathParams map[string]string
+	// validator validates the JSON bodies before they are bound, if it is set by WithBodyValidator.
+	validator BodyValidator
 }
 
 // NewRequest creates a new GoFr Request instance from the given http.Request.
-func NewRequest(r *http.Request) *Request {
-	return &Request{
+func NewRequest(r *http.Request, opts ...RequestOption) *Request {
+	req := &Request{
 		req:        r,
 		pathParams: mux.Vars(r),
 	}
+
+	for _, o := range opts {
+		o(req)
+	}
+
+	return req
+}
+
+// RequestOption configures the Request created by NewRequest.
+type RequestOption func(r *Request)
+
+// BodyValidator validates the JSON bodies of the requests, like against a JSON Schema, before they are bound.
+type BodyValidator interface {
+	ValidateBody(body []byte) error
+}
+
+// WithBodyValidator validates the JSON bodies bound by Bind with the validator.
+func WithBodyValidator(v BodyValidator) RequestOption {
+	return func(r *Request) {
+		r.validator = v
+	}
 }
 
 // Param returns the query parameter with the given key.
@@ -53,8 +81,16 @@ func (r *Request) PathParam(key string) string {
 	return r.pathParams[key]
 }
 
-// Bind parses the request body and binds it to the provided interface.
+// Bind parses the request body and binds it to the provided interface, along with the path, query and header params.
 func (r *Request) Bind(i interface{}) error {
+	if err := r.bindBody(i); err != nil {
+		return err
+	}
+
+	return r.bindParams(i)
+}
+
+func (r *Request) bindBody(i interface{}) error {
 	v := r.req.Header.Get("content-type")
 	contentType := strings.Split(v, ";")[0]
 
@@ -65,6 +101,18 @@ func (r *Request) Bind(i interface{}) error {
 			return err
 		}
 
+		if r.validator != nil {
+			if err := r.validator.ValidateBody(body); err != nil {
+				return err
+			}
+		}
+
+		if invalid := invalidTimes(reflect.TypeOf(i), body, "", nil); len(invalid) > 0 {
+			sort.Strings(invalid)
+
+			return ErrorInvalidParam{Params: invalid}
+		}
+
 		return json.Unmarshal(body, &i)
 	case "multipart/form-data":
 		return r.bindMultipart(i)
@@ -73,6 +121,11 @@ func (r *Request) Bind(i interface{}) error {
 	return nil
 }
 
+// Body returns the body of the request, to be read as a stream.
+func (r *Request) Body() io.Reader {
+	return r.req.Body
+}
+
 // HostName retrieves the hostname from the request.
 func (r *Request) HostName() string {
 	proto := r.req.Header.Get("X-forwarded-proto")
@@ -84,12 +137,17 @@ func (r *Request) HostName() string {
 }
 
 func (r *Request) body() ([]byte, error) {
-	bodyBytes, err := io.ReadAll(r.req.Body)
-	if err != nil {
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	if _, err := buf.ReadFrom(r.req.Body); err != nil {
 		return nil, err
 	}
 
-	r.req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	// the body is read in a buffer of the pool, which grows to the size of the bodies, and copied once to its size.
+	bodyBytes := bytes.Clone(buf.Bytes())
+
+	r.req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 
 	return bodyBytes, nil
 }
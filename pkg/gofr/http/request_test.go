@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/file"
 )
@@ -40,6 +41,19 @@ func TestBind(t *testing.T) {
 	}
 }
 
+func TestBind_Twice(t *testing.T) {
+	r := httptest.NewRequest("POST", "/abc", strings.NewReader(`{"a": "b"}`))
+	r.Header.Set("content-type", "application/json")
+	req := NewRequest(r)
+
+	var first, second map[string]string
+
+	require.NoError(t, req.Bind(&first))
+	require.NoError(t, req.Bind(&second), "body should be read again after being bound")
+
+	assert.Equal(t, first, second)
+}
+
 func TestBind_FileSuccess(t *testing.T) {
 	r := NewRequest(generateMultipartrequestZip(t))
 	x := struct {
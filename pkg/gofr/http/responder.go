@@ -1,21 +1,78 @@
 package http
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
+	"sync"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/bufferpool"
+	"github.com/peter-stratton/gofr/pkg/gofr/gofrerr"
 	resTypes "github.com/peter-stratton/gofr/pkg/gofr/http/response"
 )
 
+// maxPooledBufferSize is the size above which the buffers are not returned to the pool.
+const maxPooledBufferSize = 64 << 10
+
+// jsonEncoder is a JSON encoder writing to its buffer, which is pooled.
+type jsonEncoder struct {
+	buf  bytes.Buffer
+	enc  *json.Encoder
+	resp response
+}
+
+//nolint:gochecknoglobals // the pool is shared by the responders.
+var encoderPool = sync.Pool{
+	New: func() interface{} {
+		e := &jsonEncoder{}
+		e.enc = json.NewEncoder(&e.buf)
+
+		return e
+	},
+}
+
 // NewResponder creates a new Responder instance from the given http.ResponseWriter..
-func NewResponder(w http.ResponseWriter, method string) *Responder {
-	return &Responder{w: w, method: method}
+func NewResponder(w http.ResponseWriter, method string, opts ...ResponderOption) *Responder {
+	r := &Responder{w: w, method: method, format: FormatJSON}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	return r
 }
 
+// ResponderOption configures the Responder created by NewResponder.
+type ResponderOption func(r *Responder)
+
 // Responder encapsulates an http.ResponseWriter and is responsible for crafting structured responses.
 type Responder struct {
 	w      http.ResponseWriter
 	method string
+	// format is the format of the responses, which is JSON unless set by WithFormats.
+	format string
+	// req is the request, whose headers are used to respond with a part of a file, if it is set by WithRequest.
+	req *http.Request
+	// errors maps the errors to their statuses and bodies, if it is set by WithErrorRegistry.
+	errors *gofrerr.Registry
+	// transformer builds the envelope of the responses, if it is set by WithResponseTransformer.
+	transformer ResponseTransformer
+	// timeLayout is the layout of the times of the JSON responses, if it is set by WithTimeFormat.
+	timeLayout string
+}
+
+// WithErrorRegistry maps the errors to the responses using the registry.
+func WithErrorRegistry(registry *gofrerr.Registry) ResponderOption {
+	return func(r *Responder) {
+		r.errors = registry
+	}
+}
+
+// WithRequest sets the request to which the responder responds.
+func WithRequest(req *http.Request) ResponderOption {
+	return func(r *Responder) {
+		r.req = req
+	}
 }
 
 // Respond sends a response with the given data and handles potential errors, setting appropriate
@@ -23,29 +80,136 @@ type Responder struct {
 func (r Responder) Respond(data interface{}, err error) {
 	statusCode, errorObj := r.HTTPStatusFromError(err)
 
-	var resp interface{}
 	switch v := data.(type) {
 	case resTypes.Raw:
-		resp = v.Data
+		r.writeFormatted(statusCode, v.Data, nil, true)
+	case resTypes.Stream:
+		r.w.Header().Set("Content-Type", "application/json")
+		r.w.WriteHeader(statusCode)
+
+		_ = json.NewEncoder(r.w).Encode(r.envelope(statusCode, v.Data, errorObj))
 	case resTypes.File:
+		if v.Path != "" || v.Reader != nil {
+			r.serveFile(v, statusCode, errorObj)
+			return
+		}
+
 		r.w.Header().Set("Content-Type", v.ContentType)
 		r.w.WriteHeader(statusCode)
 
 		_, _ = r.w.Write(v.Content)
+	default:
+		r.writeFormatted(statusCode, v, errorObj, false)
+	}
+}
+
+// writeFormatted writes the data in the format of the responder.
+func (r Responder) writeFormatted(statusCode int, data, errorObj interface{}, raw bool) {
+	format := r.format
+	if format == FormatCSV && (errorObj != nil || !isCSVData(data)) {
+		format = FormatJSON
+	}
+
+	if format == FormatJSON {
+		r.writeJSON(statusCode, func(e *jsonEncoder) error {
+			if raw {
+				return e.enc.Encode(data)
+			}
+
+			if r.transformer != nil {
+				return e.enc.Encode(r.transformer.Transform(r.req, statusCode, data, errorObj))
+			}
+
+			e.resp = response{Data: data, Error: errorObj}
+
+			return e.enc.Encode(&e.resp)
+		})
 
 		return
+	}
+
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	var envelope interface{}
+	if !raw {
+		envelope = r.envelope(statusCode, data, errorObj)
+	}
+
+	if err := encodeFormat(buf, format, data, envelope, raw); err != nil {
+		statusCode = http.StatusInternalServerError
+
+		if format == FormatCSV {
+			format = FormatJSON
+		}
+
+		buf.Reset()
+		errorObj = map[string]interface{}{"message": err.Error()}
+		_ = encodeFormat(buf, format, nil, r.envelope(statusCode, nil, errorObj), false)
+	}
+
+	r.w.Header().Set("Content-Type", formatContentTypes[format])
+	r.w.WriteHeader(statusCode)
+
+	_, _ = r.w.Write(buf.Bytes())
+}
+
+// encodeFormat writes the data in the format if it is raw, or the envelope of the data otherwise.
+func encodeFormat(buf *bytes.Buffer, format string, data, envelope interface{}, raw bool) error {
+	switch format {
+	case FormatXML:
+		if raw {
+			return encodeXML(buf, "data", data)
+		}
+
+		return encodeXML(buf, "response", envelope)
+	case FormatYAML:
+		if raw {
+			return encodeYAML(buf, data)
+		}
+
+		return encodeYAML(buf, envelope)
+	case FormatCSV:
+		return encodeCSV(buf, data)
 	default:
-		resp = response{
-			Data:  v,
-			Error: errorObj,
+		return json.NewEncoder(buf).Encode(envelope)
+	}
+}
+
+// writeJSON encodes the response in a pooled buffer before writing it.
+func (r Responder) writeJSON(statusCode int, encode func(e *jsonEncoder) error) {
+	e, _ := encoderPool.Get().(*jsonEncoder)
+
+	defer func() {
+		e.resp = response{}
+
+		if e.buf.Cap() <= maxPooledBufferSize {
+			e.buf.Reset()
+			encoderPool.Put(e)
 		}
+	}()
+
+	if err := encode(e); err != nil {
+		statusCode = http.StatusInternalServerError
+
+		e.buf.Reset()
+		_ = e.enc.Encode(r.envelope(statusCode, nil, map[string]interface{}{"message": err.Error()}))
 	}
 
 	r.w.Header().Set("Content-Type", "application/json")
-
 	r.w.WriteHeader(statusCode)
 
-	_ = json.NewEncoder(r.w).Encode(resp)
+	if r.timeLayout == "" {
+		_, _ = r.w.Write(e.buf.Bytes())
+		return
+	}
+
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	formatTimes(buf, e.buf.Bytes(), r.timeLayout, requestLocation(r.req))
+
+	_, _ = r.w.Write(buf.Bytes())
 }
 
 // HTTPStatusFromError maps errors to HTTP status codes.
@@ -61,6 +225,12 @@ func (r Responder) HTTPStatusFromError(err error) (status int, errObj interface{
 		}
 	}
 
+	if r.errors != nil {
+		if status, body, ok := r.errors.Resolve(err); ok {
+			return status, body
+		}
+	}
+
 	e, ok := err.(statusCodeResponder)
 	if ok {
 		return e.StatusCode(), map[string]interface{}{
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/gofrerr"
 	resTypes "github.com/peter-stratton/gofr/pkg/gofr/http/response"
 )
 
@@ -31,6 +32,31 @@ func TestResponder_Respond(t *testing.T) {
 	}
 }
 
+func TestResponder_Respond_Body(t *testing.T) {
+	tests := []struct {
+		desc       string
+		data       interface{}
+		statusCode int
+		body       string
+	}{
+		{"data", map[string]int{"id": 1}, http.StatusOK, `{"data":{"id":1}}` + "\n"},
+		{"raw", resTypes.Raw{Data: []int{1, 2}}, http.StatusOK, `[1,2]` + "\n"},
+		{"stream", resTypes.Stream{Data: []int{1, 2}}, http.StatusOK, `{"data":[1,2]}` + "\n"},
+		{"encoding error", map[string]interface{}{"ch": make(chan int)}, http.StatusInternalServerError,
+			`{"error":{"message":"json: unsupported type: chan int"}}` + "\n"},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+
+		NewResponder(w, http.MethodGet).Respond(tc.data, nil)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
 func TestResponder_HTTPStatusFromError(t *testing.T) {
 	r := NewResponder(httptest.NewRecorder(), http.MethodGet)
 	errInvalidParam := ErrorInvalidParam{Params: []string{"name"}}
@@ -58,3 +84,57 @@ func TestResponder_HTTPStatusFromError(t *testing.T) {
 		assert.Equal(t, tc.errObj, errObj, "TEST[%d], Failed.\n%s", i, tc.desc)
 	}
 }
+
+func TestResponder_WithErrorRegistry(t *testing.T) {
+	registry := gofrerr.NewRegistry()
+	registry.RegisterCode(gofrerr.CodeNotFound, http.StatusGone)
+
+	tests := []struct {
+		desc       string
+		registry   *gofrerr.Registry
+		err        error
+		statusCode int
+		body       string
+	}{
+		{"mapped by the registry", registry, gofrerr.NotFound("order", 42), http.StatusGone,
+			`{"error":{"code":"NOT_FOUND","details":{"id":42,"resource":"order"},"message":"order 42 not found"}}`},
+		{"not in the registry", registry, ErrorInvalidRoute{}, http.StatusNotFound,
+			`{"error":{"message":"route not registered"}}`},
+		{"without a registry", nil, gofrerr.NotFound("order", 42), http.StatusNotFound,
+			`{"error":{"message":"order 42 not found"}}`},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+
+		NewResponder(w, http.MethodGet, WithErrorRegistry(tc.registry)).Respond(nil, tc.err)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.JSONEq(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+// discardResponseWriter discards the responses, so that the benchmarks measure the allocations of the responder only.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (*discardResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (*discardResponseWriter) WriteHeader(int) {}
+
+func BenchmarkResponder_Respond(b *testing.B) {
+	w := &discardResponseWriter{header: make(http.Header)}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		NewResponder(w, http.MethodGet).Respond("Hello World!", nil)
+	}
+}
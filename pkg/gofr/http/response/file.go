@@ -1,6 +1,25 @@
 package response
 
+import (
+	"io"
+	"time"
+)
+
+// File responds with a file, whose content is either Content, the file at Path, or read from Reader.
+//
+// The files of Path and Reader are streamed to the client instead of being read in memory, and are downloaded as
+// Name, or as the name of the file of Path. If Reader is an io.ReadSeeker, like the file of Path, the Range and
+// If-Range headers of the requests are supported, so that the downloads can be resumed. Reader is closed once it is
+// responded if it is an io.Closer.
 type File struct {
 	Content     []byte
 	ContentType string
+
+	Path   string
+	Reader io.Reader
+
+	// Name is the name of the file which is downloaded by the client, set in the Content-Disposition header.
+	Name string
+	// ModTime is the time the content of Reader was last modified.
+	ModTime time.Time
 }
@@ -0,0 +1,7 @@
+package response
+
+// PDF responds with the PDF file converted from the HTML rendered by the Template.
+type PDF struct {
+	Name     string
+	Template Template
+}
@@ -0,0 +1,6 @@
+package response
+
+// Stream responds with Data encoded in JSON while it is encoded, without buffering it.
+type Stream struct {
+	Data interface{}
+}
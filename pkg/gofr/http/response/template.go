@@ -0,0 +1,7 @@
+package response
+
+// Template responds with the HTML rendered by the template of the Name, like "orders/list.html", with Data.
+type Template struct {
+	Name string
+	Data interface{}
+}
@@ -1,22 +1,45 @@
 package http
 
 import (
+	"context"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/gorilla/mux"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+// maxTreeParams is the number of path parameters which are matched by the tree without allocating.
+const maxTreeParams = 8
+
 // Router is responsible for routing HTTP request.
 type Router struct {
 	mux.Router
 	RegisteredRoutes *[]string
+
+	// useTree is set by WithRouteTree for the routes to be matched by a routeTree.
+	useTree     bool
+	routes      []*treeRoute
+	middlewares []mux.MiddlewareFunc
+	tree        *routeTree
+	treeMu      sync.RWMutex
 }
 
 type Middleware func(handler http.Handler) http.Handler
 
+// RouterOption configures the Router created by NewRouter.
+type RouterOption func(r *Router)
+
+// WithRouteTree matches the routes using a tree of their path segments, for the services with many routes.
+func WithRouteTree() RouterOption {
+	return func(r *Router) {
+		r.useTree = true
+	}
+}
+
 // NewRouter creates a new Router instance.
-func NewRouter() *Router {
+func NewRouter(opts ...RouterOption) *Router {
 	muxRouter := mux.NewRouter().StrictSlash(false)
 	routes := make([]string, 0)
 	r := &Router{
@@ -26,6 +49,10 @@ func NewRouter() *Router {
 
 	r.Router = *muxRouter
 
+	for _, o := range opts {
+		o(r)
+	}
+
 	return r
 }
 
@@ -33,6 +60,33 @@ func NewRouter() *Router {
 func (rou *Router) Add(method, pattern string, handler http.Handler) {
 	h := otelhttp.NewHandler(handler, "gofr-router")
 	rou.Router.NewRoute().Methods(method).Path(pattern).Handler(h)
+
+	if !rou.useTree || !supportsPattern(pattern) {
+		return
+	}
+
+	route := &treeRoute{method: method, pattern: pattern, handler: h}
+
+	for _, segment := range strings.Split(pattern[1:], "/") {
+		if name, _ := treeParam(segment); name != "" {
+			route.params = append(route.params, name)
+		}
+	}
+
+	rou.treeMu.Lock()
+	rou.routes = append(rou.routes, route)
+	rou.tree = nil
+	rou.treeMu.Unlock()
+}
+
+// Use registers middlewares to the router, like mux.Router.Use.
+func (rou *Router) Use(mwf ...mux.MiddlewareFunc) {
+	rou.Router.Use(mwf...)
+
+	rou.treeMu.Lock()
+	rou.middlewares = append(rou.middlewares, mwf...)
+	rou.tree = nil
+	rou.treeMu.Unlock()
 }
 
 // UseMiddleware registers middlewares to the router.
@@ -44,3 +98,81 @@ func (rou *Router) UseMiddleware(mws ...Middleware) {
 
 	rou.Use(middlewares...)
 }
+
+// ServeHTTP dispatches the request to the handler of the route matching it.
+func (rou *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !rou.useTree {
+		rou.Router.ServeHTTP(w, r)
+		return
+	}
+
+	var buf [maxTreeParams]string
+
+	route, values := rou.routeTree().match(r.Method, r.URL.Path, buf[:0])
+	if route == nil {
+		rou.Router.ServeHTTP(w, r)
+		return
+	}
+
+	vars := make(map[string]string, len(values))
+	for i, v := range values {
+		vars[route.params[i]] = v
+	}
+
+	r = mux.SetURLVars(r, vars)
+	r = r.WithContext(context.WithValue(r.Context(), routeKey{}, route))
+
+	route.handler.ServeHTTP(w, r)
+}
+
+// routeTree returns the tree of the routes, building it if routes or middlewares were added since it was last built.
+func (rou *Router) routeTree() *routeTree {
+	rou.treeMu.RLock()
+	t := rou.tree
+	rou.treeMu.RUnlock()
+
+	if t != nil {
+		return t
+	}
+
+	rou.treeMu.Lock()
+	defer rou.treeMu.Unlock()
+
+	if rou.tree != nil {
+		return rou.tree
+	}
+
+	routes := make([]*treeRoute, 0, len(rou.routes))
+
+	for _, r := range rou.routes {
+		h := r.handler
+
+		// the middlewares are applied like by mux, the first one being the outermost.
+		for i := len(rou.middlewares) - 1; i >= 0; i-- {
+			h = rou.middlewares[i].Middleware(h)
+		}
+
+		routes = append(routes, &treeRoute{method: r.method, pattern: r.pattern, params: r.params, handler: h})
+	}
+
+	rou.tree = newRouteTree(routes)
+
+	return rou.tree
+}
+
+type routeKey struct{}
+
+// PathTemplate returns the pattern of the route which matched the request, like "/customers/{id}", or "".
+func PathTemplate(r *http.Request) string {
+	if route, ok := r.Context().Value(routeKey{}).(*treeRoute); ok {
+		return route.pattern
+	}
+
+	if route := mux.CurrentRoute(r); route != nil {
+		template, _ := route.GetPathTemplate()
+
+		return template
+	}
+
+	return ""
+}
@@ -1,10 +1,12 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/config"
@@ -66,3 +68,58 @@ func TestRouterWithMiddleware(t *testing.T) {
 	testHeaderValue := rec.Header().Get("X-Test-Middleware")
 	assert.Equal(t, "applied", testHeaderValue, "Test_UseMiddleware Failed! header value mismatch.")
 }
+
+func TestRouter_RouteTree(t *testing.T) {
+	router := NewRouter(WithRouteTree())
+
+	var order []string
+
+	router.UseMiddleware(func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "first")
+			inner.ServeHTTP(w, r)
+		})
+	}, func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "second")
+			inner.ServeHTTP(w, r)
+		})
+	})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%s %v", PathTemplate(r), mux.Vars(r))
+	})
+
+	router.Add(http.MethodGet, "/users/{id}", handler)
+	router.Add(http.MethodGet, "/users/me", handler)
+	router.Add(http.MethodGet, "/orders/{id:[0-9]+}", handler)
+
+	testCases := []struct {
+		desc   string
+		method string
+		path   string
+		status int
+		body   string
+	}{
+		{"path parameter", http.MethodGet, "/users/12", http.StatusOK, "/users/{id} map[id:12]"},
+		{"static segment added after the parameter", http.MethodGet, "/users/me", http.StatusOK, "/users/me map[]"},
+		{"parameter with a pattern matched by mux", http.MethodGet, "/orders/3", http.StatusOK,
+			"/orders/{id:[0-9]+} map[id:3]"},
+		{"method not allowed", http.MethodPost, "/users/12", http.StatusMethodNotAllowed, ""},
+		{"not found", http.MethodGet, "/customers", http.StatusNotFound, "404 page not found\n"},
+	}
+
+	for i, tc := range testCases {
+		order = nil
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, http.NoBody))
+
+		assert.Equal(t, tc.status, rec.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, rec.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.status == http.StatusOK {
+			assert.Equal(t, []string{"first", "second"}, order, "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimezoneHeader is the header of the requests with the IANA time zone of the caller, like "Europe/Paris".
+const TimezoneHeader = "X-Timezone"
+
+var errLocalTimezone = errors.New("the local time zone of the server is not a time zone of the callers")
+
+//nolint:gochecknoglobals // the layouts and the types are constant.
+var (
+	// rfc3339 matches the times in RFC 3339, which Go parses leniently, like "2024-01-02T3:04:05Z".
+	rfc3339 = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`)
+
+	// localLayouts are the layouts of the times without a time zone, which are parsed in the time zone of the caller.
+	localLayouts = []string{"2006-01-02T15:04:05", "2006-01-02 15:04:05", time.DateOnly}
+
+	// timeLayouts are the layouts of the times of the responses, by their name in the time package.
+	timeLayouts = map[string]string{
+		"RFC3339":     time.RFC3339,
+		"RFC3339Nano": time.RFC3339Nano,
+		"RFC1123":     time.RFC1123,
+		"RFC1123Z":    time.RFC1123Z,
+		"RFC822":      time.RFC822,
+		"RFC822Z":     time.RFC822Z,
+		"DateTime":    time.DateTime,
+		"DateOnly":    time.DateOnly,
+		"Kitchen":     time.Kitchen,
+	}
+
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonUnmarshalType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// timeFields caches whether the types have time.Time fields, which are checked when they are bound.
+//
+//nolint:gochecknoglobals // the cache is shared by all the requests, as whether a type has time fields never changes.
+var timeFields sync.Map
+
+type locationKey struct{}
+
+// NewLocationContext returns a copy of ctx carrying the time zone of the caller.
+func NewLocationContext(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, locationKey{}, loc)
+}
+
+// LocationFromContext returns the time zone of the caller carried by ctx, or nil if it has none.
+func LocationFromContext(ctx context.Context) *time.Location {
+	loc, _ := ctx.Value(locationKey{}).(*time.Location)
+	return loc
+}
+
+// LocationResolver returns the time zone of the caller of a request, or nil if it does not resolve it.
+type LocationResolver func(r *http.Request) *time.Location
+
+// LocationFromHeader resolves the time zone of the caller from the IANA time zone of the header, like "Europe/Paris".
+func LocationFromHeader(name string) LocationResolver {
+	return func(r *http.Request) *time.Location {
+		loc, err := loadLocation(r.Header.Get(name))
+		if err != nil {
+			return nil
+		}
+
+		return loc
+	}
+}
+
+// TimeLayout returns the layout of the name of a layout of the time package, like "RFC1123", or the name itself.
+func TimeLayout(name string) string {
+	if layout, ok := timeLayouts[name]; ok {
+		return layout
+	}
+
+	return name
+}
+
+// Location returns the time zone of the caller, or UTC if the request has none.
+func (r *Request) Location() (*time.Location, error) {
+	if loc := LocationFromContext(r.req.Context()); loc != nil {
+		return loc, nil
+	}
+
+	loc, err := loadLocation(r.req.Header.Get(TimezoneHeader))
+	if err != nil {
+		return nil, ErrorInvalidParam{Params: []string{TimezoneHeader}}
+	}
+
+	if loc == nil {
+		return time.UTC, nil
+	}
+
+	return loc, nil
+}
+
+// ParseTime parses the time in RFC 3339, in the time zone of the caller if it has none.
+func (r *Request) ParseTime(value string) (time.Time, error) {
+	loc, err := r.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return ParseTime(value, loc)
+}
+
+// ParseTime parses the time in RFC 3339, or, if it has no time zone, in loc, and returns it in loc.
+func ParseTime(value string, loc *time.Location) (time.Time, error) {
+	if t, ok := parseRFC3339(value); ok {
+		return t.In(loc), nil
+	}
+
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, ErrorInvalidTime{Value: value}
+}
+
+// loadLocation returns the IANA time zone of the name, or nil if the name is empty.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	if name == "Local" {
+		return nil, errLocalTimezone
+	}
+
+	return time.LoadLocation(name)
+}
+
+// parseRFC3339 parses the time if it is strictly in RFC 3339.
+func parseRFC3339(value string) (time.Time, bool) {
+	if !rfc3339.MatchString(value) {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+
+	return t, err == nil
+}
+
+// invalidTimes returns the names of the time.Time fields of the JSON body which are not strictly in RFC 3339.
+func invalidTimes(t reflect.Type, data json.RawMessage, name string, invalid []string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) || !hasTimeFields(t) {
+		return invalid
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t != timeType && reflect.PointerTo(t).Implements(jsonUnmarshalType) {
+			// the types decoding their own JSON may not decode their times from the fields of the object.
+			return invalid
+		}
+
+		if t == timeType {
+			var s string
+			if err := json.Unmarshal(data, &s); err != nil {
+				return append(invalid, name)
+			}
+
+			if _, ok := parseRFC3339(s); !ok {
+				return append(invalid, name)
+			}
+
+			return invalid
+		}
+
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return invalid
+		}
+
+		for key, value := range object {
+			if f, ok := jsonField(t, key); ok {
+				invalid = invalidTimes(f.Type, value, joinName(name, key), invalid)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		var values []json.RawMessage
+		if err := json.Unmarshal(data, &values); err != nil {
+			return invalid
+		}
+
+		for i, value := range values {
+			invalid = invalidTimes(t.Elem(), value, name+"["+strconv.Itoa(i)+"]", invalid)
+		}
+	case reflect.Map:
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return invalid
+		}
+
+		for key, value := range object {
+			invalid = invalidTimes(t.Elem(), value, joinName(name, key), invalid)
+		}
+	default:
+	}
+
+	return invalid
+}
+
+func joinName(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+
+	return parent + "." + name
+}
+
+// jsonField returns the field of the struct decoded from the key of a JSON object.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				if embedded, ok := jsonField(ft, key); ok {
+					return embedded, true
+				}
+
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// hasTimeFields reports whether the values of the type have a time.Time, in their fields or their elements.
+func hasTimeFields(t reflect.Type) bool {
+	if has, ok := timeFields.Load(t); ok {
+		return has.(bool)
+	}
+
+	// the recursive types are assumed to have none while their fields are checked.
+	timeFields.Store(t, false)
+
+	has := false
+
+	switch t.Kind() {
+	case reflect.Struct:
+		has = t == timeType
+
+		for i := 0; i < t.NumField() && !has; i++ {
+			has = hasTimeFields(t.Field(i).Type)
+		}
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		has = hasTimeFields(t.Elem())
+	default:
+	}
+
+	timeFields.Store(t, has)
+
+	return has
+}
+
+// WithTimeFormat writes the times of the JSON responses in the layout and the time zone of the caller.
+func WithTimeFormat(layout string) ResponderOption {
+	return func(r *Responder) {
+		r.timeLayout = layout
+	}
+}
+
+// formatTimes writes the JSON to dst with its strings in RFC 3339 in the layout, and in loc unless it is nil.
+func formatTimes(dst *bytes.Buffer, src []byte, layout string, loc *time.Location) {
+	for i := 0; i < len(src); i++ {
+		if src[i] != '"' {
+			dst.WriteByte(src[i])
+			continue
+		}
+
+		end := stringEnd(src, i)
+
+		t, ok := parseRFC3339(string(src[i+1 : end]))
+		if !ok {
+			dst.Write(src[i : end+1])
+
+			i = end
+
+			continue
+		}
+
+		if loc != nil {
+			t = t.In(loc)
+		}
+
+		b, _ := json.Marshal(t.Format(layout))
+		dst.Write(b)
+
+		i = end
+	}
+}
+
+// stringEnd returns the index of the quote ending the JSON string starting at the index.
+func stringEnd(src []byte, start int) int {
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return len(src) - 1
+}
+
+// requestLocation returns the time zone of the caller of the request, or nil if it has none.
+func requestLocation(req *http.Request) *time.Location {
+	if req == nil {
+		return nil
+	}
+
+	if loc := LocationFromContext(req.Context()); loc != nil {
+		return loc
+	}
+
+	return LocationFromHeader(TimezoneHeader)(req)
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_Location(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc    string
+		header  string
+		context *time.Location
+		loc     *time.Location
+		err     error
+	}{
+		{"no time zone", "", nil, time.UTC, nil},
+		{"time zone of the header", "Europe/Paris", nil, paris, nil},
+		{"time zone of the context", "Europe/Paris", tokyo, tokyo, nil},
+		{"unknown time zone", "Mars/Olympus", nil, nil, ErrorInvalidParam{Params: []string{TimezoneHeader}}},
+		{"local time zone", "Local", nil, nil, ErrorInvalidParam{Params: []string{TimezoneHeader}}},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/orders", http.NoBody)
+		r.Header.Set(TimezoneHeader, tc.header)
+
+		if tc.context != nil {
+			r = r.WithContext(NewLocationContext(r.Context(), tc.context))
+		}
+
+		loc, err := NewRequest(r).Location()
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.loc, loc, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc  string
+		value string
+		time  time.Time
+		err   error
+	}{
+		{"RFC 3339", "2024-01-02T15:04:05Z", time.Date(2024, 1, 2, 16, 4, 5, 0, paris), nil},
+		{"RFC 3339 with an offset", "2024-01-02T15:04:05.5+02:00", time.Date(2024, 1, 2, 14, 4, 5, 5e8, paris), nil},
+		{"local date and time", "2024-07-02T15:04:05", time.Date(2024, 7, 2, 15, 4, 5, 0, paris), nil},
+		{"local date", "2024-07-02", time.Date(2024, 7, 2, 0, 0, 0, 0, paris), nil},
+		{"single digit hour", "2024-01-02T3:04:05Z", time.Time{}, ErrorInvalidTime{Value: "2024-01-02T3:04:05Z"}},
+		{"other layout", "02/01/2024", time.Time{}, ErrorInvalidTime{Value: "02/01/2024"}},
+	}
+
+	for i, tc := range testCases {
+		parsed, err := ParseTime(tc.value, paris)
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.True(t, tc.time.Equal(parsed), "TEST[%d], Failed.\n%s: %v", i, tc.desc, parsed)
+
+		if err == nil {
+			assert.Equal(t, paris, parsed.Location(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestBind_StrictTimes(t *testing.T) {
+	type item struct {
+		At time.Time `json:"at"`
+	}
+
+	type order struct {
+		item
+		Created *time.Time         `json:"created"`
+		Items   []item             `json:"items"`
+		Events  map[string]item    `json:"events"`
+		Notes   map[string]string  `json:"notes"`
+		Due     map[string]*string `json:"-"`
+	}
+
+	testCases := []struct {
+		desc string
+		body string
+		err  error
+	}{
+		{"valid times", `{"at":"2024-01-02T15:04:05Z","created":null,"items":[{"at":"2024-01-02T15:04:05+01:00"}]}`, nil},
+		{"lenient times", `{"AT":"2024-01-02T3:04:05Z","created":"2024-01-02","items":[{"at":"2024-01-02T15:04:05Z"},` +
+			`{"at":"2024-01-02 15:04:05Z"}],"events":{"paid":{"at":5}},"notes":{"at":"tomorrow"}}`,
+			ErrorInvalidParam{Params: []string{"AT", "created", "events.paid.at", "items[1].at"}}},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tc.body))
+		r.Header.Set("Content-Type", "application/json")
+
+		var o order
+
+		assert.Equal(t, tc.err, NewRequest(r).Bind(&o), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestResponder_WithTimeFormat(t *testing.T) {
+	data := map[string]interface{}{
+		"at":     time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+		"name":   `"2024-01-02T15:04:05Z"`,
+		"events": []time.Time{time.Date(2024, 7, 2, 15, 4, 5, 0, time.FixedZone("", 3600))},
+	}
+
+	testCases := []struct {
+		desc   string
+		layout string
+		header string
+		body   string
+	}{
+		{"no layout", "", "Europe/Paris", `{"data":{"at":"2024-01-02T15:04:05Z","events":["2024-07-02T15:04:05+01:00"],` +
+			`"name":"\"2024-01-02T15:04:05Z\""}}`},
+		{"layout in the time zone of the caller", TimeLayout("DateTime"), "Europe/Paris",
+			`{"data":{"at":"2024-01-02 16:04:05","events":["2024-07-02 16:04:05"],"name":"\"2024-01-02T15:04:05Z\""}}`},
+		{"layout without a time zone", "02/01/2006 15:04 MST", "",
+			`{"data":{"at":"02/01/2024 15:04 UTC","events":["02/07/2024 15:04 +0100"],"name":"\"2024-01-02T15:04:05Z\""}}`},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/orders", http.NoBody)
+		r.Header.Set(TimezoneHeader, tc.header)
+
+		w := httptest.NewRecorder()
+
+		NewResponder(w, http.MethodGet, WithRequest(r), WithTimeFormat(tc.layout)).Respond(data, nil)
+
+		assert.JSONEq(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,33 @@
+package http
+
+import "net/http"
+
+// ResponseTransformer builds the envelope of the responses, instead of {"data": ..., "error": ...}.
+type ResponseTransformer interface {
+	// Transform returns the body of the response. The request is nil unless the responder is created with WithRequest.
+	Transform(req *http.Request, statusCode int, data, errorObj interface{}) interface{}
+}
+
+// ResponseTransformerFunc is a function used as a ResponseTransformer.
+type ResponseTransformerFunc func(req *http.Request, statusCode int, data, errorObj interface{}) interface{}
+
+// Transform calls f.
+func (f ResponseTransformerFunc) Transform(req *http.Request, statusCode int, data, errorObj interface{}) interface{} {
+	return f(req, statusCode, data, errorObj)
+}
+
+// WithResponseTransformer writes the responses in the envelope built by the transformer, if it is not nil.
+func WithResponseTransformer(t ResponseTransformer) ResponderOption {
+	return func(r *Responder) {
+		r.transformer = t
+	}
+}
+
+// envelope returns the body of the response of the data and the error object.
+func (r Responder) envelope(statusCode int, data, errorObj interface{}) interface{} {
+	if r.transformer != nil {
+		return r.transformer.Transform(r.req, statusCode, data, errorObj)
+	}
+
+	return response{Data: data, Error: errorObj}
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	resTypes "github.com/peter-stratton/gofr/pkg/gofr/http/response"
+)
+
+// jsonAPI writes the data under "data" with a "meta" of the status, and the errors in a list under "errors".
+func jsonAPI(req *http.Request, statusCode int, data, errorObj interface{}) interface{} {
+	if errorObj != nil {
+		return map[string]interface{}{"errors": []interface{}{errorObj}}
+	}
+
+	meta := map[string]interface{}{"status": statusCode}
+	if req != nil {
+		meta["path"] = req.URL.Path
+	}
+
+	return map[string]interface{}{"data": data, "meta": meta}
+}
+
+func TestResponder_Transformer(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		accept      string
+		data        interface{}
+		err         error
+		statusCode  int
+		contentType string
+		body        string
+	}{
+		{"json", "", map[string]int{"id": 1}, nil, http.StatusOK, "application/json",
+			`{"data":{"id":1},"meta":{"path":"/orders","status":200}}` + "\n"},
+		{"json error", "", nil, ErrorEntityNotFound{Name: "id", Value: "2"}, http.StatusNotFound, "application/json",
+			`{"errors":[{"message":"No entity found with id: 2"}]}` + "\n"},
+		{"json encoding error", "", map[string]interface{}{"ch": make(chan int)}, nil, http.StatusInternalServerError,
+			"application/json", `{"errors":[{"message":"json: unsupported type: chan int"}]}` + "\n"},
+		{"xml", "application/xml", []int{1}, nil, http.StatusOK, "application/xml",
+			`<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<response><data><item>1</item></data><meta><path>/orders</path><status>200</status></meta></response>` + "\n"},
+		{"yaml", "application/yaml", []int{1}, nil, http.StatusOK, "application/yaml",
+			"data:\n  - 1\nmeta:\n  path: /orders\n  status: 200\n"},
+		{"raw is not transformed", "", resTypes.Raw{Data: []int{1}}, nil, http.StatusOK, "application/json", "[1]\n"},
+		{"stream", "", resTypes.Stream{Data: "event"}, nil, http.StatusOK, "application/json",
+			`{"data":"event","meta":{"path":"/orders","status":200}}` + "\n"},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/orders", http.NoBody)
+
+		NewResponder(w, http.MethodGet, WithFormats(tc.accept, FormatJSON, FormatXML, FormatYAML),
+			WithRequest(r), WithResponseTransformer(ResponseTransformerFunc(jsonAPI))).Respond(tc.data, tc.err)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestResponder_NilTransformer(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	NewResponder(w, http.MethodGet, WithResponseTransformer(nil)).Respond(nil, errors.New("failed"))
+
+	var body map[string]interface{}
+
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, map[string]interface{}{"error": map[string]interface{}{"message": "failed"}}, body)
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+)
+
+// routeTree matches the routes by walking the segments of the path. Static segments take precedence over parameters.
+type routeTree struct {
+	roots map[string]*treeNode
+}
+
+type treeNode struct {
+	static map[string]*treeNode
+	param  *treeNode
+	route  *treeRoute
+}
+
+// treeRoute is a route of the tree, with its handler wrapped in the middlewares of the router.
+type treeRoute struct {
+	method  string
+	pattern string
+	params  []string
+	handler http.Handler
+}
+
+// treeParam is the name of the path parameter of a segment, or "". ok is false for the unsupported segments.
+func treeParam(segment string) (name string, ok bool) {
+	if !strings.ContainsAny(segment, "{}") {
+		return "", true
+	}
+
+	if len(segment) < 3 || segment[0] != '{' || segment[len(segment)-1] != '}' ||
+		strings.ContainsAny(segment[1:len(segment)-1], "{}:") {
+		return "", false
+	}
+
+	return segment[1 : len(segment)-1], true
+}
+
+// supportsPattern reports whether the route of the pattern can be matched by the tree.
+func supportsPattern(pattern string) bool {
+	if !strings.HasPrefix(pattern, "/") {
+		return false
+	}
+
+	for _, segment := range strings.Split(pattern[1:], "/") {
+		if _, ok := treeParam(segment); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRouteTree(routes []*treeRoute) *routeTree {
+	t := &routeTree{roots: make(map[string]*treeNode)}
+
+	for _, r := range routes {
+		n := t.roots[r.method]
+		if n == nil {
+			n = &treeNode{}
+			t.roots[r.method] = n
+		}
+
+		for _, segment := range strings.Split(r.pattern[1:], "/") {
+			n = n.child(segment)
+		}
+
+		// the first of the routes with the same pattern is matched, like by mux.
+		if n.route == nil {
+			n.route = r
+		}
+	}
+
+	return t
+}
+
+func (n *treeNode) child(segment string) *treeNode {
+	if name, _ := treeParam(segment); name != "" {
+		if n.param == nil {
+			n.param = &treeNode{}
+		}
+
+		return n.param
+	}
+
+	if n.static == nil {
+		n.static = make(map[string]*treeNode)
+	}
+
+	c := n.static[segment]
+	if c == nil {
+		c = &treeNode{}
+		n.static[segment] = c
+	}
+
+	return c
+}
+
+// match returns the route of the method matching the path, appending its parameters to values.
+func (t *routeTree) match(method, path string, values []string) (*treeRoute, []string) {
+	root := t.roots[method]
+	if root == nil || !strings.HasPrefix(path, "/") {
+		return nil, values
+	}
+
+	return root.match(path[1:], values)
+}
+
+func (n *treeNode) match(path string, values []string) (*treeRoute, []string) {
+	segment, rest, last := path, "", true
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		segment, rest, last = path[:i], path[i+1:], false
+	}
+
+	// the paths which are not clean are left to mux, which redirects them to the clean path.
+	if segment == "." || segment == ".." || (segment == "" && !last) {
+		return nil, values
+	}
+
+	if c := n.static[segment]; c != nil {
+		if r, v := c.next(rest, last, values); r != nil {
+			return r, v
+		}
+	}
+
+	if n.param != nil && segment != "" {
+		if r, v := n.param.next(rest, last, append(values, segment)); r != nil {
+			return r, v
+		}
+	}
+
+	return nil, values
+}
+
+func (n *treeNode) next(rest string, last bool, values []string) (*treeRoute, []string) {
+	if last {
+		return n.route, values
+	}
+
+	return n.match(rest, values)
+}
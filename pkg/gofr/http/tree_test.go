@@ -0,0 +1,148 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testTree() *routeTree {
+	patterns := []string{"/", "/users", "/users/", "/users/me", "/users/{id}", "/users/{id}/orders/{order}",
+		"/users/me/orders/latest"}
+
+	routes := make([]*treeRoute, 0, len(patterns))
+	for _, p := range patterns {
+		routes = append(routes, &treeRoute{method: http.MethodGet, pattern: p})
+	}
+
+	return newRouteTree(routes)
+}
+
+func TestRouteTree_Match(t *testing.T) {
+	tree := testTree()
+
+	testCases := []struct {
+		method  string
+		path    string
+		pattern string
+		values  []string
+	}{
+		{http.MethodGet, "/", "/", nil},
+		{http.MethodGet, "/users", "/users", nil},
+		{http.MethodGet, "/users/", "/users/", nil},
+		{http.MethodGet, "/users/me", "/users/me", nil},
+		{http.MethodGet, "/users/12", "/users/{id}", []string{"12"}},
+		{http.MethodGet, "/users/12/orders/3", "/users/{id}/orders/{order}", []string{"12", "3"}},
+		// the static segment "me" is backtracked from, as "/users/me/orders/3" only matches the parameter.
+		{http.MethodGet, "/users/me/orders/3", "/users/{id}/orders/{order}", []string{"me", "3"}},
+		{http.MethodGet, "/users/me/orders/latest", "/users/me/orders/latest", nil},
+		{http.MethodGet, "/users/12/orders", "", nil},
+		{http.MethodGet, "/users//orders/3", "", nil},
+		{http.MethodGet, "/users/../users", "", nil},
+		{http.MethodGet, "users", "", nil},
+		{http.MethodPost, "/users", "", nil},
+	}
+
+	for i, tc := range testCases {
+		route, values := tree.match(tc.method, tc.path, nil)
+
+		if tc.pattern == "" {
+			assert.Nil(t, route, "TEST[%d], Failed.\n%s", i, tc.path)
+			continue
+		}
+
+		if assert.NotNil(t, route, "TEST[%d], Failed.\n%s", i, tc.path) {
+			assert.Equal(t, tc.pattern, route.pattern, "TEST[%d], Failed.\n%s", i, tc.path)
+			assert.Equal(t, tc.values, values, "TEST[%d], Failed.\n%s", i, tc.path)
+		}
+	}
+}
+
+func TestRouteTree_MatchAllocations(t *testing.T) {
+	tree := testTree()
+	values := make([]string, 0, maxTreeParams)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		tree.match(http.MethodGet, "/users/12/orders/3", values[:0])
+	})
+
+	assert.Zero(t, allocs)
+}
+
+func TestSupportsPattern(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		expected bool
+	}{
+		{"/users/{id}", true},
+		{"/", true},
+		{"/users/{id:[0-9]+}", false},
+		{"/files/{name}.json", false},
+		{"/users/{}", false},
+		{"users", false},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.expected, supportsPattern(tc.pattern), "TEST[%d], Failed.\n%s", i, tc.pattern)
+	}
+}
+
+// benchmarkRoutes returns the patterns of a service with hundreds of routes, and the path of a request to its last
+// one, which mux matches after all the others.
+func benchmarkRoutes() (patterns []string, path string) {
+	for i := 0; i < 100; i++ {
+		patterns = append(patterns, fmt.Sprintf("/resource%d", i), fmt.Sprintf("/resource%d/{id}", i),
+			fmt.Sprintf("/resource%d/{id}/items/{item}", i))
+	}
+
+	return patterns, "/resource99/12/items/3"
+}
+
+func BenchmarkRouter(b *testing.B) {
+	patterns, path := benchmarkRoutes()
+	handler := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+
+	for _, bc := range []struct {
+		name   string
+		router *Router
+	}{
+		{"mux", NewRouter()},
+		{"tree", NewRouter(WithRouteTree())},
+	} {
+		for _, p := range patterns {
+			bc.router.Add(http.MethodGet, p, handler)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		w := httptest.NewRecorder()
+
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				bc.router.ServeHTTP(w, req)
+			}
+		})
+	}
+}
+
+func BenchmarkRouteTree_Match(b *testing.B) {
+	patterns, path := benchmarkRoutes()
+
+	routes := make([]*treeRoute, 0, len(patterns))
+	for _, p := range patterns {
+		routes = append(routes, &treeRoute{method: http.MethodGet, pattern: p})
+	}
+
+	tree := newRouteTree(routes)
+	values := make([]string, 0, maxTreeParams)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		tree.match(http.MethodGet, path, values[:0])
+	}
+}
@@ -1,8 +1,11 @@
 package gofr
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
@@ -14,10 +17,18 @@ import (
 type httpServer struct {
 	router *gofrHTTP.Router
 	port   int
+	// listen is HTTP_LISTEN, the unix socket or the inherited socket the server listens on instead of the port.
+	listen string
+
+	// srv is the running server, which is guarded by mu as it is shut down from another goroutine.
+	srv    *http.Server
+	closed bool
+	mu     sync.Mutex
 }
 
-func newHTTPServer(c *container.Container, port int, middlewareConfigs map[string]string) *httpServer {
-	r := gofrHTTP.NewRouter()
+func newHTTPServer(c *container.Container, port int, middlewareConfigs map[string]string,
+	opts ...gofrHTTP.RouterOption) *httpServer {
+	r := gofrHTTP.NewRouter(opts...)
 
 	r.Use(
 		middleware.Tracer,
@@ -33,15 +44,47 @@ func newHTTPServer(c *container.Container, port int, middlewareConfigs map[strin
 }
 
 func (s *httpServer) Run(c *container.Container) {
-	var srv *http.Server
+	s.mu.Lock()
+
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+
+	c.Logf("Starting server on %s", listenAddress(s.listen, s.port))
 
-	c.Logf("Starting server on port: %d", s.port)
+	listener, err := listen(s.listen, s.port)
+	if err != nil {
+		s.mu.Unlock()
+		c.Error(err)
 
-	srv = &http.Server{
+		return
+	}
+
+	s.srv = &http.Server{
 		Addr:              fmt.Sprintf(":%d", s.port),
 		Handler:           s.router,
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
-	c.Error(srv.ListenAndServe())
+	srv := s.srv
+	s.mu.Unlock()
+
+	if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		c.Error(err)
+	}
+}
+
+// Shutdown stops the server gracefully, until ctx is done.
+func (s *httpServer) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+
+	if s.srv == nil {
+		return nil
+	}
+
+	return s.srv.Shutdown(ctx)
 }
@@ -51,3 +51,34 @@ func TestRun_ServerStartsListening(t *testing.T) {
 
 	resp.Body.Close()
 }
+
+func TestHTTPServer_Shutdown(t *testing.T) {
+	c := &container.Container{Logger: logging.NewLogger(logging.INFO)}
+
+	server := &httpServer{router: gofrHTTP.NewRouter(), port: 8085}
+
+	done := make(chan struct{})
+
+	go func() {
+		server.Run(c)
+		close(done)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	assert.NoError(t, server.Shutdown(context.Background()))
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("server should stop running once it is shut down")
+	}
+
+	// a server shut down before it runs does not start.
+	stopped := &httpServer{router: gofrHTTP.NewRouter(), port: 8085}
+
+	assert.NoError(t, stopped.Shutdown(context.Background()))
+
+	stopped.Run(c)
+	assert.Nil(t, stopped.srv)
+}
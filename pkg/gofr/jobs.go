@@ -0,0 +1,324 @@
+package gofr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+const (
+	defaultJobWorkers      = 5
+	defaultJobPollInterval = time.Second
+	defaultJobLease        = 5 * time.Minute
+
+	jobBaseBackoff = time.Second
+	jobMaxBackoff  = time.Hour
+
+	defaultJobListLimit = 100
+	maxJobListLimit     = 1000
+)
+
+var (
+	errJobNotRegistered = errors.New("no handler is registered for the job")
+	errJobPanicked      = errors.New("panicked")
+	errInvalidJobStatus = errors.New("status must be one of PENDING, RUNNING or DEAD")
+)
+
+// JobFunc handles the jobs enqueued with the name it is registered for, which are retried if it fails.
+type JobFunc func(ctx *Context) error
+
+// JobOptions configure when and how many times an enqueued job is run.
+type JobOptions = container.JobOptions
+
+// jobRunner polls the job store for due jobs and runs them on a bounded number of workers.
+type jobRunner struct {
+	handlers     map[string]JobFunc
+	workers      int
+	pollInterval time.Duration
+	lease        time.Duration
+
+	// stop is closed to stop polling for jobs, after which the running jobs are waited for using running.
+	stop     chan struct{}
+	stopOnce sync.Once
+	running  sync.WaitGroup
+}
+
+func newJobRunner(conf config.Config) *jobRunner {
+	r := &jobRunner{
+		handlers:     make(map[string]JobFunc),
+		workers:      defaultJobWorkers,
+		pollInterval: defaultJobPollInterval,
+		lease:        defaultJobLease,
+		stop:         make(chan struct{}),
+	}
+
+	if conf == nil {
+		return r
+	}
+
+	if workers, err := strconv.Atoi(conf.Get("JOB_WORKERS")); err == nil && workers > 0 {
+		r.workers = workers
+	}
+
+	if interval, err := strconv.Atoi(conf.Get("JOB_POLL_INTERVAL")); err == nil && interval > 0 {
+		r.pollInterval = time.Duration(interval) * time.Second
+	}
+
+	if lease, err := strconv.Atoi(conf.Get("JOB_LEASE")); err == nil && lease > 0 {
+		r.lease = time.Duration(lease) * time.Second
+	}
+
+	return r
+}
+
+// RegisterJob registers the handler for the jobs enqueued with the name.
+func (a *App) RegisterJob(name string, handler JobFunc) {
+	if a.jobs == nil {
+		a.jobs = newJobRunner(a.Config)
+	}
+
+	a.jobs.handlers[name] = handler
+}
+
+// EnqueueJob persists a job run in the background by the handler of the name, and returns its ID.
+func (c *Context) EnqueueJob(name string, payload interface{}, opts ...JobOptions) (string, error) {
+	return c.Container.EnqueueJob(c.Context, name, payload, opts...)
+}
+
+func (r *jobRunner) run(ctx context.Context, c *container.Container) {
+	store, err := c.JobStore()
+	if err != nil {
+		c.Errorf("could not start the job runner, error: %v", err)
+
+		return
+	}
+
+	c.Logf("starting the job runner with %d workers", r.workers)
+
+	busy := make(chan struct{}, r.workers)
+
+	ticker := c.Clock().NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.poll(ctx, c, store, busy)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stop:
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// shutdown stops polling for jobs and waits for the running ones, or for ctx to be done.
+func (r *jobRunner) shutdown(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stop) })
+
+	done := make(chan struct{})
+
+	go func() {
+		r.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// poll claims due jobs for as long as there are idle workers to run them. A slot in busy is held by each running job.
+func (r *jobRunner) poll(ctx context.Context, c *container.Container, store container.JobStore, busy chan struct{}) {
+	for {
+		select {
+		case busy <- struct{}{}:
+		default:
+			return
+		}
+
+		job, err := store.Claim(ctx, r.lease)
+		if err != nil {
+			c.Errorf("could not claim a job, error: %v", err)
+		}
+
+		if job == nil {
+			<-busy
+
+			return
+		}
+
+		r.running.Add(1)
+
+		go func() {
+			defer func() {
+				<-busy
+
+				r.running.Done()
+			}()
+
+			r.runJob(ctx, c, store, job)
+		}()
+	}
+}
+
+func (r *jobRunner) runJob(ctx context.Context, c *container.Container, store container.JobStore, job *container.Job) {
+	ctx, span := otel.GetTracerProvider().Tracer("gofr-job").Start(ctx, job.Name)
+	defer span.End()
+
+	// the store is updated using ctx, which unlike the context of the job is not cancelled on shutdown.
+	jobCtx, cancel := c.JobContext(ctx, 0)
+	defer cancel()
+
+	start := c.Clock().Now()
+
+	err := r.handle(jobCtx, c, job)
+
+	if m := c.Metrics(); m != nil {
+		m.RecordHistogram(ctx, "app_job_duration", c.Clock().Since(start).Seconds(), "job", job.Name)
+
+		if err != nil {
+			m.IncrementCounter(ctx, "app_job_failures", "job", job.Name)
+		}
+	}
+
+	if err == nil {
+		if err := store.Complete(ctx, job); err != nil {
+			c.Errorf("could not complete job %s with ID %s, error: %v", job.Name, job.ID, err)
+		}
+
+		return
+	}
+
+	if errors.Is(context.Cause(jobCtx), container.ErrShuttingDown) {
+		c.Warnf("job %s with ID %s was interrupted by the shutdown and will be run again", job.Name, job.ID)
+
+		if err := store.Retry(ctx, job, c.Clock().Now(), err); err != nil {
+			c.Errorf("could not retry job %s with ID %s, error: %v", job.Name, job.ID, err)
+		}
+
+		return
+	}
+
+	c.Errorf("job %s with ID %s failed on attempt %d of %d, error: %v", job.Name, job.ID, job.Attempts, job.MaxAttempts, err)
+
+	if job.Attempts >= job.MaxAttempts {
+		if err := store.Bury(ctx, job, err); err != nil {
+			c.Errorf("could not move job %s with ID %s to the DEAD state, error: %v", job.Name, job.ID, err)
+		}
+
+		if m := c.Metrics(); m != nil {
+			m.IncrementCounter(ctx, "app_job_dead", "job", job.Name)
+		}
+
+		return
+	}
+
+	if err := store.Retry(ctx, job, c.Clock().Now().Add(jobBackoff(job.Attempts)), err); err != nil {
+		c.Errorf("could not retry job %s with ID %s, error: %v", job.Name, job.ID, err)
+	}
+}
+
+func (r *jobRunner) handle(ctx context.Context, c *container.Container, job *container.Job) (err error) {
+	handler, ok := r.handlers[job.Name]
+	if !ok {
+		return fmt.Errorf("%w: %s", errJobNotRegistered, job.Name)
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("%w: %v", errJobPanicked, rec)
+		}
+	}()
+
+	// the job is exposed as a message, so that the payload is bound the same way as in the subscribers.
+	msg := pubsub.NewMessage(ctx)
+	msg.Topic = job.Name
+	msg.Value = job.Payload
+	msg.MetaData = job
+
+	return handler(newContext(nil, msg, c))
+}
+
+// jobBackoff returns the delay before the next attempt of a job that has failed the given number of attempts.
+func jobBackoff(attempts int) time.Duration {
+	backoff := jobBaseBackoff
+
+	for i := 1; i < attempts && backoff < jobMaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > jobMaxBackoff {
+		return jobMaxBackoff
+	}
+
+	return backoff
+}
+
+// jobsHandler lists the jobs in the state given by the status query parameter, PENDING by default, up to limit jobs.
+func jobsHandler(c *container.Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := strings.ToUpper(r.URL.Query().Get("status"))
+		if status == "" {
+			status = container.JobPending
+		}
+
+		if status != container.JobPending && status != container.JobRunning && status != container.JobDead {
+			writeAdminError(w, http.StatusBadRequest, errInvalidJobStatus)
+
+			return
+		}
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = defaultJobListLimit
+		}
+
+		if limit > maxJobListLimit {
+			limit = maxJobListLimit
+		}
+
+		store, err := c.JobStore()
+		if err != nil {
+			writeAdminError(w, http.StatusInternalServerError, err)
+
+			return
+		}
+
+		jobs, err := store.List(r.Context(), status, limit)
+		if err != nil {
+			writeAdminError(w, http.StatusInternalServerError, err)
+
+			return
+		}
+
+		writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": jobs})
+	})
+}
+
+func writeAdminJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeAdminError(w http.ResponseWriter, statusCode int, err error) {
+	writeAdminJSON(w, statusCode, map[string]interface{}{"error": map[string]string{"message": err.Error()}})
+}
@@ -0,0 +1,234 @@
+package gofr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+)
+
+var errSendEmail = errors.New("smtp unavailable")
+
+func TestApp_RegisterJob(t *testing.T) {
+	a := &App{Config: config.NewMockConfig(map[string]string{"JOB_WORKERS": "2", "JOB_POLL_INTERVAL": "3", "JOB_LEASE": "abc"})}
+
+	a.RegisterJob("send-email", func(*Context) error { return nil })
+
+	assert.Len(t, a.jobs.handlers, 1)
+	assert.Equal(t, 2, a.jobs.workers)
+	assert.Equal(t, 3*time.Second, a.jobs.pollInterval)
+	assert.Equal(t, defaultJobLease, a.jobs.lease)
+}
+
+func TestJobRunner_poll(t *testing.T) {
+	c, _ := newRedisLockContainer(t)
+	ctx := context.Background()
+
+	type email struct {
+		To string `json:"to"`
+	}
+
+	received := make(chan email, 1)
+
+	r := newJobRunner(nil)
+	r.handlers["send-email"] = func(ctx *Context) error {
+		var e email
+
+		err := ctx.Bind(&e)
+		received <- e
+
+		return err
+	}
+
+	_, err := c.EnqueueJob(ctx, "send-email", email{To: "a@b.c"})
+	assert.NoError(t, err)
+
+	store, _ := c.JobStore()
+	busy := make(chan struct{}, r.workers)
+
+	r.poll(ctx, c, store, busy)
+
+	select {
+	case e := <-received:
+		assert.Equal(t, "a@b.c", e.To)
+	case <-time.After(time.Second):
+		t.Fatal("job was not run")
+	}
+
+	assert.Eventually(t, func() bool {
+		pending, _ := store.List(ctx, container.JobPending, 10)
+		running, _ := store.List(ctx, container.JobRunning, 10)
+
+		return len(pending)+len(running) == 0 && len(busy) == 0
+	}, time.Second, 10*time.Millisecond, "completed job should be removed from the store")
+}
+
+func TestJobRunner_runJob_RetryAndBury(t *testing.T) {
+	c, _ := newRedisLockContainer(t)
+	ctx := context.Background()
+
+	r := newJobRunner(nil)
+	r.handlers["send-email"] = func(*Context) error { return errSendEmail }
+	r.handlers["panics"] = func(*Context) error { panic("boom") }
+
+	store, _ := c.JobStore()
+
+	_, err := c.EnqueueJob(ctx, "send-email", nil, JobOptions{MaxAttempts: 2})
+	assert.NoError(t, err)
+
+	job, _ := store.Claim(ctx, time.Minute)
+	r.runJob(ctx, c, store, job)
+
+	pending, _ := store.List(ctx, container.JobPending, 10)
+
+	if assert.Len(t, pending, 1) {
+		assert.Equal(t, errSendEmail.Error(), pending[0].LastError)
+		assert.WithinDuration(t, time.Now().Add(jobBaseBackoff), pending[0].RunAt, 500*time.Millisecond)
+	}
+
+	// the backoff is skipped so that the job is due right away
+	assert.NoError(t, store.Retry(ctx, job, time.Now(), errSendEmail))
+
+	job, _ = store.Claim(ctx, time.Minute)
+	r.runJob(ctx, c, store, job)
+
+	dead, _ := store.List(ctx, container.JobDead, 10)
+	assert.Len(t, dead, 1, "job should be dead after exhausting its attempts")
+
+	for _, name := range []string{"panics", "unregistered"} {
+		_, err = c.EnqueueJob(ctx, name, nil, JobOptions{MaxAttempts: 1})
+		assert.NoError(t, err)
+
+		job, _ = store.Claim(ctx, time.Minute)
+		r.runJob(ctx, c, store, job)
+	}
+
+	dead, _ = store.List(ctx, container.JobDead, 10)
+
+	errs := make(map[string]string)
+
+	for _, j := range dead {
+		errs[j.Name] = j.LastError
+	}
+
+	assert.Equal(t, "panicked: boom", errs["panics"])
+	assert.Equal(t, errJobNotRegistered.Error()+": unregistered", errs["unregistered"])
+}
+
+func TestJobBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, jobBackoff(1))
+	assert.Equal(t, 2*time.Second, jobBackoff(2))
+	assert.Equal(t, 8*time.Second, jobBackoff(4))
+	assert.Equal(t, jobMaxBackoff, jobBackoff(40))
+}
+
+func TestJobsHandler(t *testing.T) {
+	c, _ := newRedisLockContainer(t)
+
+	_, err := c.EnqueueJob(context.Background(), "send-email", nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc       string
+		container  *container.Container
+		query      string
+		statusCode int
+		jobs       int
+	}{
+		{"pending jobs by default", c, "", http.StatusOK, 1},
+		{"dead jobs", c, "?status=dead&limit=5", http.StatusOK, 0},
+		{"invalid status", c, "?status=done", http.StatusBadRequest, 0},
+		{"store not configured", &container.Container{}, "", http.StatusInternalServerError, 0},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/jobs"+tc.query, http.NoBody)
+
+		jobsHandler(tc.container).ServeHTTP(w, req)
+
+		var body struct {
+			Data []container.Job `json:"data"`
+		}
+
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&body), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Len(t, body.Data, tc.jobs, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestMetricServer_handler(t *testing.T) {
+	c, _ := newRedisLockContainer(t)
+
+	m := newMetricServer(defaultMetricPort)
+	m.handle(http.MethodGet, "/jobs", jobsHandler(c))
+
+	h := m.handler(c)
+
+	for path, code := range map[string]int{"/jobs": http.StatusOK, "/unknown": http.StatusNotFound} {
+		w := httptest.NewRecorder()
+
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+		assert.Equal(t, code, w.Code, path)
+	}
+
+	// the metrics are still served alongside the admin endpoints
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+	assert.NotEqual(t, http.StatusNotFound, w.Code)
+
+	// a nil metric server, as in CMD applications, ignores the routes
+	var nilServer *metricServer
+
+	nilServer.handle(http.MethodGet, "/jobs", jobsHandler(c))
+}
+
+func TestJobRunner_InterruptedByShutdown(t *testing.T) {
+	_, s := newRedisLockContainer(t)
+	c := container.NewContainer(config.NewMockConfig(map[string]string{
+		"REDIS_HOST":       s.Host(),
+		"REDIS_PORT":       s.Port(),
+		"JOB_GRACE_PERIOD": "1",
+	}))
+	ctx := context.Background()
+
+	r := newJobRunner(nil)
+	r.handlers["export"] = func(ctx *Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}
+
+	_, err := c.EnqueueJob(ctx, "export", nil, JobOptions{MaxAttempts: 1})
+	assert.NoError(t, err)
+
+	store, _ := c.JobStore()
+
+	r.poll(ctx, c, store, make(chan struct{}, 1))
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	assert.NoError(t, (&App{container: c, jobs: r}).Shutdown(shutdownCtx))
+
+	// the datasources are closed by the shutdown, so the jobs are listed by another instance.
+	store, _ = container.NewContainer(config.NewMockConfig(map[string]string{
+		"REDIS_HOST": s.Host(),
+		"REDIS_PORT": s.Port(),
+	})).JobStore()
+
+	// the interrupted job is run again, even though it has exhausted its attempts
+	pending, _ := store.List(ctx, container.JobPending, 10)
+	assert.Len(t, pending, 1)
+}
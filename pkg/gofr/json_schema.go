@@ -0,0 +1,85 @@
+package gofr
+
+import (
+	"errors"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/gofrerr"
+	"github.com/peter-stratton/gofr/pkg/gofr/jsonschema"
+)
+
+// ValidateJSON validates the JSON bodies bound in the handler of the route against the JSON Schema.
+//
+//	Usage:
+//	app.POST("/orders", createOrder, gofr.ValidateJSON(schema))
+func ValidateJSON(schema *jsonschema.Schema) RouteOption {
+	return func(h *handler) {
+		h.bodyValidator = schemaValidator{schema: schema}
+	}
+}
+
+// ValidateJSONFile validates the JSON bodies bound in the handler of the route against the JSON Schema of the file.
+//
+//	Usage:
+//	app.POST("/orders", createOrder, gofr.ValidateJSONFile("./schemas/order.json"))
+func ValidateJSONFile(path string) RouteOption {
+	return func(h *handler) {
+		schema, err := jsonschema.Load(path)
+		if err != nil {
+			h.container.Errorf("could not load the JSON schema %s: %v", path, err)
+
+			h.bodyValidator = schemaValidator{
+				err: gofrerr.Wrap(err, gofrerr.CodeInternal, "the JSON schema of the route could not be loaded"),
+			}
+
+			return
+		}
+
+		h.bodyValidator = schemaValidator{schema: schema}
+	}
+}
+
+// ValidateJSONOf validates the JSON bodies bound in the handler of the route against the JSON Schema of the type of v.
+//
+//	Usage:
+//	app.POST("/orders", createOrder, gofr.ValidateJSONOf(Order{}))
+func ValidateJSONOf(v interface{}) RouteOption {
+	return ValidateJSON(jsonschema.Generate(v))
+}
+
+// schemaValidator validates the JSON bodies of the requests against a JSON Schema.
+type schemaValidator struct {
+	schema *jsonschema.Schema
+	// err is the error of the schema which could not be loaded.
+	err error
+}
+
+func (v schemaValidator) ValidateBody(body []byte) error {
+	if v.err != nil {
+		return v.err
+	}
+
+	err := v.schema.Validate(body)
+
+	var invalid *jsonschema.ValidationError
+	if !errors.As(err, &invalid) {
+		return err
+	}
+
+	fields := make(map[string]string, len(invalid.Violations))
+
+	for _, violation := range invalid.Violations {
+		path := violation.Path
+		if path == "" {
+			path = "/"
+		}
+
+		if message, ok := fields[path]; ok {
+			fields[path] = message + "; " + violation.Message
+			continue
+		}
+
+		fields[path] = violation.Message
+	}
+
+	return gofrerr.Invalid(fields)
+}
@@ -0,0 +1,71 @@
+package gofr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaOrder struct {
+	Email    string `json:"email" jsonschema:"format=email"`
+	Quantity int    `json:"quantity" jsonschema:"minimum=1"`
+}
+
+func TestValidateJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "order.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"type":"object","required":["email"]}`), 0o600))
+
+	app := New()
+
+	create := func(ctx *Context) (interface{}, error) {
+		var o schemaOrder
+
+		if err := ctx.Bind(&o); err != nil {
+			return nil, err
+		}
+
+		return o, nil
+	}
+
+	app.POST("/orders", create, ValidateJSONOf(schemaOrder{}))
+	app.POST("/file/orders", create, ValidateJSONFile(path))
+	app.POST("/missing/orders", create, ValidateJSONFile(filepath.Join(t.TempDir(), "missing.json")))
+
+	testCases := []struct {
+		desc       string
+		path       string
+		body       string
+		statusCode int
+		response   string
+	}{
+		{"valid body", "/orders", `{"email":"a@example.com","quantity":1}`, http.StatusCreated,
+			`{"data":{"email":"a@example.com","quantity":1}}`},
+		{"invalid body", "/orders", `{"email":"a","quantity":0,"note":"x"}`, http.StatusBadRequest,
+			`{"error":{"code":"INVALID","message":"invalid fields: /email, /note, /quantity","fields":{` +
+				`"/email":"must be a valid email","/note":"is not an allowed property",` +
+				`"/quantity":"must be greater than or equal to 1"}}}`},
+		{"invalid JSON", "/orders", `{"email":`, http.StatusBadRequest,
+			`{"error":{"code":"INVALID","message":"invalid fields: /","fields":{"/":"is not valid JSON: unexpected EOF"}}}`},
+		{"schema of a file", "/file/orders", `{"quantity":1}`, http.StatusBadRequest,
+			`{"error":{"code":"INVALID","message":"invalid fields: /email","fields":{"/email":"is required"}}}`},
+		{"missing schema file", "/missing/orders", `{"email":"a@example.com"}`, http.StatusInternalServerError,
+			`{"error":{"code":"INTERNAL","message":"the JSON schema of the route could not be loaded"}}`},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+		r.Header.Set("Content-Type", "application/json")
+
+		app.httpServer.router.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.JSONEq(t, tc.response, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,281 @@
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Draft is the version of JSON Schema of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+//nolint:gochecknoglobals // the types are constant.
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	rawMessageType      = reflect.TypeOf(json.RawMessage{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// Generate returns the JSON Schema of the type of v. The keywords of the fields are set by their jsonschema tag.
+//
+//	type Order struct {
+//		Email    string `json:"email" jsonschema:"format=email"`
+//		Quantity int    `json:"quantity" jsonschema:"minimum=1,maximum=100"`
+//		Status   string `json:"status,omitempty" jsonschema:"enum=pending|paid"`
+//	}
+func Generate(v interface{}) *Schema {
+	g := generator{defs: make(map[string]*Schema), names: make(map[reflect.Type]string)}
+
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil {
+		return &Schema{Schema: Draft}
+	}
+
+	s := g.schema(t, true)
+	s.Schema = Draft
+
+	if len(g.defs) > 0 {
+		s.Defs = g.defs
+	}
+
+	_ = s.compile(s)
+
+	return s
+}
+
+type generator struct {
+	defs map[string]*Schema
+	// names are the names of the definitions of the named structs.
+	names map[reflect.Type]string
+}
+
+// schema returns the schema of the type, the named structs other than the root being referenced from $defs.
+func (g *generator) schema(t reflect.Type, root bool) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case t == rawMessageType, t.Kind() == reflect.Interface:
+		return &Schema{}
+	case reflect.PointerTo(t).Implements(jsonUnmarshalerType):
+		// the types decoding their own JSON can be decoded from any value.
+		return &Schema{}
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return &Schema{Type: Types{"string"}}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := g.schema(t.Elem(), false)
+
+		if len(s.Type) > 0 {
+			s.Type = append(s.Type, "null")
+			return s
+		}
+
+		if s.Ref == "" {
+			return s
+		}
+
+		return &Schema{AnyOf: []*Schema{s, {Type: Types{"null"}}}}
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		zero := 0.0
+		return &Schema{Type: Types{"integer"}, Minimum: &zero}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// the bytes are encoded in base64.
+			return &Schema{Type: Types{"string"}}
+		}
+
+		s := &Schema{Type: Types{"array"}, Items: g.schema(t.Elem(), false)}
+		if t.Kind() == reflect.Slice {
+			s.Type = append(s.Type, "null")
+		}
+
+		return s
+	case reflect.Map:
+		return &Schema{Type: Types{"object", "null"}, AdditionalProperties: g.schema(t.Elem(), false)}
+	case reflect.Struct:
+		if root || t.Name() == "" {
+			return g.object(t)
+		}
+
+		return &Schema{Ref: "#/$defs/" + g.define(t)}
+	default:
+		return &Schema{}
+	}
+}
+
+// define defines the named struct in $defs, and returns the name of its definition.
+func (g *generator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	for i := 2; g.defs[name] != nil; i++ {
+		name = t.Name() + strconv.Itoa(i)
+	}
+
+	// the name is reserved before the fields are generated, for the recursive types.
+	g.names[t] = name
+	g.defs[name] = &Schema{}
+	g.defs[name] = g.object(t)
+
+	return name
+}
+
+// object returns the schema of the struct, whose properties are its fields.
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:                 Types{"object"},
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: &Schema{boolean: new(bool)},
+	}
+
+	g.addFields(s, t)
+
+	return s
+}
+
+// addFields adds the fields of the struct, and those of its embedded structs, to the properties of the schema.
+func (g *generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft)
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := g.schema(f.Type, false)
+		required := f.Type.Kind() != reflect.Pointer && !strings.Contains(","+opts+",", ",omitempty,")
+
+		if tag := f.Tag.Get("jsonschema"); tag != "" {
+			prop, required = applyTag(prop, tag, required)
+		}
+
+		s.Properties[name] = prop
+
+		if required {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// applyTag sets the keywords of the jsonschema tag of a field on its schema.
+func applyTag(s *Schema, tag string, required bool) (*Schema, bool) {
+	if s.Ref != "" {
+		// the keywords of a field of a named struct do not change its definition.
+		s = &Schema{AllOf: []*Schema{s}}
+	}
+
+	for _, pair := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+
+		switch key {
+		case "required":
+			required = true
+		case "optional":
+			required = false
+		case "uniqueItems":
+			s.UniqueItems = true
+		case "description":
+			s.Description = value
+		case "pattern":
+			s.Pattern = value
+		case "format":
+			s.Format = value
+		case "enum":
+			for _, e := range strings.Split(value, "|") {
+				s.Enum = append(s.Enum, enumValue(s.Type, e))
+			}
+		case "minLength":
+			s.MinLength = intValue(value)
+		case "maxLength":
+			s.MaxLength = intValue(value)
+		case "minItems":
+			s.MinItems = intValue(value)
+		case "maxItems":
+			s.MaxItems = intValue(value)
+		case "minimum":
+			s.Minimum = floatValue(value)
+		case "maximum":
+			s.Maximum = floatValue(value)
+		case "exclusiveMinimum":
+			s.ExclusiveMinimum = floatValue(value)
+		case "exclusiveMaximum":
+			s.ExclusiveMaximum = floatValue(value)
+		case "multipleOf":
+			s.MultipleOf = floatValue(value)
+		}
+	}
+
+	return s, required
+}
+
+// enumValue returns the value of the enum of a tag, which is a number for the numeric types, and a string otherwise.
+func enumValue(types Types, value string) interface{} {
+	for _, t := range types {
+		if t == "integer" || t == "number" {
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				return n
+			}
+		}
+	}
+
+	return value
+}
+
+func intValue(value string) *int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil
+	}
+
+	return &n
+}
+
+func floatValue(value string) *float64 {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &n
+}
@@ -0,0 +1,109 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string `json:"city" jsonschema:"minLength=1"`
+}
+
+type node struct {
+	Name     string  `json:"name"`
+	Children []*node `json:"children,omitempty"`
+}
+
+type base struct {
+	ID int `json:"id"`
+}
+
+type order struct {
+	base
+	Email    string            `json:"email" jsonschema:"format=email,description=the email of the buyer"`
+	Quantity uint              `json:"quantity" jsonschema:"maximum=10"`
+	Status   string            `json:"status,omitempty" jsonschema:"enum=pending|paid"`
+	Priority int               `json:"priority,omitempty" jsonschema:"enum=1|2"`
+	Placed   time.Time         `json:"placed"`
+	Note     *string           `json:"note"`
+	Shipping *address          `json:"shipping" jsonschema:"required"`
+	Billing  address           `json:"billing"`
+	Tags     []string          `json:"tags,omitempty" jsonschema:"uniqueItems"`
+	Meta     map[string]string `json:"meta,omitempty"`
+	Tree     node              `json:"tree,omitempty"`
+	Secret   string            `json:"-"`
+	internal string
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(&order{})
+
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["id", "email", "quantity", "placed", "shipping", "billing"],
+		"properties": {
+			"id": {"type": "integer"},
+			"email": {"type": "string", "format": "email", "description": "the email of the buyer"},
+			"quantity": {"type": "integer", "minimum": 0, "maximum": 10},
+			"status": {"type": "string", "enum": ["pending", "paid"]},
+			"priority": {"type": "integer", "enum": [1, 2]},
+			"placed": {"type": "string", "format": "date-time"},
+			"note": {"type": ["string", "null"]},
+			"shipping": {"anyOf": [{"$ref": "#/$defs/address"}, {"type": "null"}]},
+			"billing": {"$ref": "#/$defs/address"},
+			"tags": {"type": ["array", "null"], "items": {"type": "string"}, "uniqueItems": true},
+			"meta": {"type": ["object", "null"], "additionalProperties": {"type": "string"}},
+			"tree": {"$ref": "#/$defs/node"}
+		},
+		"$defs": {
+			"address": {
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["city"],
+				"properties": {"city": {"type": "string", "minLength": 1}}
+			},
+			"node": {
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["name"],
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": ["array", "null"], "items": {"anyOf": [{"$ref": "#/$defs/node"}, {"type": "null"}]}}
+				}
+			}
+		}
+	}`, string(b))
+}
+
+func TestGenerate_Validate(t *testing.T) {
+	s := Generate(order{})
+
+	valid := `{"id":1,"email":"a@example.com","quantity":2,"priority":2,"placed":"2024-01-02T15:04:05Z","note":null,` +
+		`"shipping":null,"billing":{"city":"Paris"},"tree":{"name":"a","children":[{"name":"b"}]}}`
+
+	require.NoError(t, s.Validate([]byte(valid)))
+
+	err := s.Validate([]byte(`{"id":1,"email":"a@example.com","quantity":-1,"priority":3,"placed":"now",` +
+		`"shipping":{"city":""},"billing":{"city":"Paris"},"tags":["a","a"],"tree":{"children":[{"name":"b"}]}}`))
+
+	var invalid *ValidationError
+
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []Violation{
+		{"/placed", "must be a valid date-time"},
+		{"/priority", "must be one of 1, 2"},
+		{"/quantity", "must be greater than or equal to 0"},
+		{"/shipping", "must match at least one of the schemas of anyOf"},
+		{"/tags/1", "must be unique, but equals the item 0"},
+		{"/tree/name", "is required"},
+	}, invalid.Violations)
+}
@@ -0,0 +1,277 @@
+// Package jsonschema validates JSON documents against a JSON Schema, parsed from a document or generated from a struct.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var errRefNotFound = errors.New("reference not found")
+
+// Schema is a JSON Schema, or a subschema of one.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+
+	Type  Types           `json:"type,omitempty"`
+	Enum  []interface{}   `json:"enum,omitempty"`
+	Const json.RawMessage `json:"const,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+
+	Items       *Schema `json:"items,omitempty"`
+	MinItems    *int    `json:"minItems,omitempty"`
+	MaxItems    *int    `json:"maxItems,omitempty"`
+	UniqueItems bool    `json:"uniqueItems,omitempty"`
+
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64 `json:"multipleOf,omitempty"`
+
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	Format    string `json:"format,omitempty"`
+
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+
+	// boolean is the value of the schemas which are true, accepting any value, or false, accepting none.
+	boolean *bool
+	pattern *regexp.Regexp
+	// root is the schema in which the references are resolved.
+	root *Schema
+}
+
+// Types are the types of the values accepted by a schema, which is a single type or an array of types in JSON.
+type Types []string
+
+// UnmarshalJSON decodes a single type or an array of types.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		return err
+	}
+
+	*t = types
+
+	return nil
+}
+
+// MarshalJSON encodes a single type as a string, and the other types as an array.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// schemaFields has the fields of a Schema without its methods, for its default encoding.
+type schemaFields Schema
+
+// UnmarshalJSON decodes the schema, which is either an object or a boolean.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		*s = Schema{boolean: &b}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*schemaFields)(s))
+}
+
+// MarshalJSON encodes the schema, which is either an object or a boolean.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.boolean != nil {
+		return json.Marshal(*s.boolean)
+	}
+
+	return json.Marshal((*schemaFields)(s))
+}
+
+// Parse parses the JSON Schema of the document, and compiles its patterns.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("could not parse the JSON schema: %w", err)
+	}
+
+	if err := s.compile(&s); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Load parses the JSON Schema of the file.
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// compile compiles the patterns of the schema and of its subschemas.
+func (s *Schema) compile(root *Schema) error {
+	if s == nil {
+		return nil
+	}
+
+	s.root = root
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+
+		s.pattern = re
+	}
+
+	for _, sub := range s.subschemas() {
+		if err := sub.compile(root); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) subschemas() []*Schema {
+	subs := make([]*Schema, 0, len(s.Properties)+len(s.Defs)+len(s.Definitions)+len(s.AllOf)+len(s.AnyOf)+len(s.OneOf)+3)
+
+	for _, m := range []map[string]*Schema{s.Properties, s.Defs, s.Definitions} {
+		for _, sub := range m {
+			subs = append(subs, sub)
+		}
+	}
+
+	subs = append(subs, s.AllOf...)
+	subs = append(subs, s.AnyOf...)
+	subs = append(subs, s.OneOf...)
+
+	return append(subs, s.AdditionalProperties, s.Items, s.Not)
+}
+
+// resolve returns the schema of the reference, like "#", "#/$defs/Address" or "#/definitions/Address".
+func (s *Schema) resolve(ref string) (*Schema, error) {
+	root := s.root
+	if root == nil {
+		root = s
+	}
+
+	if ref == "#" {
+		return root, nil
+	}
+
+	for prefix, defs := range map[string]map[string]*Schema{"#/$defs/": root.Defs, "#/definitions/": root.Definitions} {
+		if name, ok := strings.CutPrefix(ref, prefix); ok {
+			if def, ok := defs[unescapePointer(name)]; ok {
+				return def, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errRefNotFound, ref)
+}
+
+// Violation is a value which does not satisfy a keyword of the schema.
+type Violation struct {
+	// Path is the JSON pointer of the value, like "/items/0/quantity", which is "" for the whole document.
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ValidationError is the error of a document which does not satisfy its schema, with the violations of its values.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+
+	for i, v := range e.Violations {
+		path := v.Path
+		if path == "" {
+			path = "/"
+		}
+
+		messages[i] = path + ": " + v.Message
+	}
+
+	return "the document does not satisfy its JSON schema: " + strings.Join(messages, "; ")
+}
+
+// Validate validates the JSON document against the schema, returning a *ValidationError.
+func (s *Schema) Validate(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc interface{}
+
+	if err := dec.Decode(&doc); err != nil {
+		return &ValidationError{Violations: []Violation{{Message: "is not valid JSON: " + err.Error()}}}
+	}
+
+	return s.ValidateValue(doc)
+}
+
+// ValidateValue validates the value decoded from JSON, whose numbers are float64 or json.Number, against the schema.
+func (s *Schema) ValidateValue(value interface{}) error {
+	v := validator{}
+
+	v.validate(s, value, "")
+
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(v.violations, func(i, j int) bool {
+		return v.violations[i].Path < v.violations[j].Path
+	})
+
+	return &ValidationError{Violations: v.violations}
+}
+
+// escapePointer escapes the name of a property for a JSON pointer.
+func escapePointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
+
+func unescapePointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,119 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["email", "items"],
+	"additionalProperties": false,
+	"properties": {
+		"email": {"type": "string", "format": "email"},
+		"status": {"enum": ["pending", "paid"]},
+		"note": {"type": ["string", "null"], "maxLength": 5},
+		"code": {"type": "string", "pattern": "^[A-Z]{3}$"},
+		"placed": {"type": "string", "format": "date-time"},
+		"items": {"type": "array", "minItems": 1, "items": {"$ref": "#/$defs/item"}},
+		"discount": {"oneOf": [{"type": "integer"}, {"const": "free"}]}
+	},
+	"$defs": {
+		"item": {
+			"type": "object",
+			"required": ["sku"],
+			"properties": {
+				"sku": {"type": "string", "minLength": 1},
+				"quantity": {"type": "integer", "minimum": 1, "maximum": 10, "multipleOf": 1}
+			}
+		}
+	}
+}`
+
+func TestSchema_Validate(t *testing.T) {
+	s, err := Parse([]byte(orderSchema))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc       string
+		doc        string
+		violations []Violation
+	}{
+		{"valid document", `{"email":"a@example.com","status":"paid","note":null,"code":"ABC",` +
+			`"placed":"2024-01-02T15:04:05Z","items":[{"sku":"x","quantity":2}],"discount":"free"}`, nil},
+		{"missing properties", `{}`, []Violation{{"/email", "is required"}, {"/items", "is required"}}},
+		{"invalid values", `{"email":"not an email","status":"lost","note":"too long","code":"abc",` +
+			`"placed":"2024-01-02","items":[{"sku":"","quantity":1.5},{"quantity":11}],"discount":1.5,"other":1}`,
+			[]Violation{
+				{"/code", "must match the pattern ^[A-Z]{3}$"},
+				{"/discount", "must match exactly one of the schemas of oneOf, not 0"},
+				{"/email", "must be a valid email"},
+				{"/items/0/quantity", "must be of type integer, not number"},
+				{"/items/0/sku", "must be at least 1 characters long"},
+				{"/items/1/quantity", "must be less than or equal to 10"},
+				{"/items/1/sku", "is required"},
+				{"/note", "must be at most 5 characters long"},
+				{"/other", "is not an allowed property"},
+				{"/placed", "must be a valid date-time"},
+				{"/status", `must be one of "pending", "paid"`},
+			}},
+		{"wrong type", `[]`, []Violation{{"", "must be of type object, not array"}}},
+		{"invalid JSON", `{"email":`, []Violation{{"", "is not valid JSON: unexpected EOF"}}},
+	}
+
+	for i, tc := range testCases {
+		err := s.Validate([]byte(tc.doc))
+
+		if tc.violations == nil {
+			assert.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+			continue
+		}
+
+		var invalid *ValidationError
+
+		require.ErrorAs(t, err, &invalid, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.violations, invalid.Violations, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse([]byte(`{"type": 5}`))
+	require.ErrorContains(t, err, "could not parse the JSON schema")
+
+	_, err = Parse([]byte(`{"properties": {"code": {"pattern": "("}}}`))
+	require.ErrorContains(t, err, "invalid pattern")
+
+	s, err := Parse([]byte(`{"$ref": "#/$defs/missing"}`))
+	require.NoError(t, err)
+	require.ErrorContains(t, s.Validate([]byte(`{}`)), "reference not found: #/$defs/missing")
+}
+
+func TestSchema_BooleanSchemas(t *testing.T) {
+	s, err := Parse([]byte(`{"properties": {"any": true, "none": false}}`))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Validate([]byte(`{"any": [1]}`)))
+	require.ErrorContains(t, s.Validate([]byte(`{"none": 1}`)), "/none: is not allowed")
+
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"properties": {"any": true, "none": false}}`, string(b))
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "order.json")
+	require.NoError(t, os.WriteFile(path, []byte(orderSchema), 0o600))
+
+	s, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"email", "items"}, s.Required)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
@@ -0,0 +1,420 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+//nolint:gochecknoglobals // the formats are constant.
+var (
+	uuidFormat     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	dateTimeFormat = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}[Tt]\d{2}:\d{2}:\d{2}(\.\d+)?([Zz]|[+-]\d{2}:\d{2})$`)
+
+	formats = map[string]func(s string) bool{
+		"date-time": func(s string) bool {
+			if !dateTimeFormat.MatchString(s) {
+				return false
+			}
+
+			_, err := time.Parse(time.RFC3339Nano, strings.ToUpper(s))
+
+			return err == nil
+		},
+		"date": func(s string) bool {
+			_, err := time.Parse(time.DateOnly, s)
+			return err == nil
+		},
+		"email": func(s string) bool {
+			addr, err := mail.ParseAddress(s)
+			return err == nil && addr.Address == s
+		},
+		"uuid": uuidFormat.MatchString,
+		"uri": func(s string) bool {
+			u, err := url.Parse(s)
+			return err == nil && u.Scheme != ""
+		},
+		"ipv4": func(s string) bool {
+			ip := net.ParseIP(s)
+			return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+		},
+		"ipv6": func(s string) bool {
+			ip := net.ParseIP(s)
+			return ip != nil && strings.Contains(s, ":")
+		},
+	}
+)
+
+// maxRefDepth bounds the references followed while validating a value.
+const maxRefDepth = 64
+
+type validator struct {
+	root       *Schema
+	violations []Violation
+	refDepth   int
+}
+
+func (v *validator) fail(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// valid reports whether the value satisfies the schema, without recording its violations.
+func (v *validator) valid(s *Schema, value interface{}, path string) bool {
+	sub := validator{root: v.root, refDepth: v.refDepth}
+
+	sub.validate(s, value, path)
+
+	return len(sub.violations) == 0
+}
+
+func (v *validator) validate(s *Schema, value interface{}, path string) {
+	if s == nil {
+		return
+	}
+
+	if v.root == nil {
+		v.root = s
+	}
+
+	if s.boolean != nil {
+		if !*s.boolean {
+			v.fail(path, "is not allowed")
+		}
+
+		return
+	}
+
+	if s.Ref != "" {
+		v.validateRef(s, value, path)
+	}
+
+	if len(s.Type) > 0 && !hasType(s.Type, value) {
+		v.fail(path, "must be of type %s, not %s", strings.Join(s.Type, " or "), typeOf(value))
+		return
+	}
+
+	v.validateEnum(s, value, path)
+	v.validateComposition(s, value, path)
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(s, val, path)
+	case []interface{}:
+		v.validateArray(s, val, path)
+	case string:
+		v.validateString(s, val, path)
+	case json.Number, float64:
+		v.validateNumber(s, toFloat(val), path)
+	}
+}
+
+func (v *validator) validateRef(s *Schema, value interface{}, path string) {
+	if v.refDepth >= maxRefDepth {
+		v.fail(path, "references %s too deeply", s.Ref)
+		return
+	}
+
+	root := s.root
+	if root == nil {
+		root = v.root
+	}
+
+	target, err := root.resolve(s.Ref)
+	if err != nil {
+		v.fail(path, "%v", err)
+		return
+	}
+
+	v.refDepth++
+	v.validate(target, value, path)
+	v.refDepth--
+}
+
+func (v *validator) validateEnum(s *Schema, value interface{}, path string) {
+	if len(s.Const) > 0 {
+		var c interface{}
+		if err := json.Unmarshal(s.Const, &c); err == nil && !equal(c, value) {
+			v.fail(path, "must be %s", s.Const)
+		}
+	}
+
+	if len(s.Enum) == 0 {
+		return
+	}
+
+	for _, e := range s.Enum {
+		if equal(e, value) {
+			return
+		}
+	}
+
+	values := make([]string, len(s.Enum))
+
+	for i, e := range s.Enum {
+		b, _ := json.Marshal(e)
+		values[i] = string(b)
+	}
+
+	v.fail(path, "must be one of %s", strings.Join(values, ", "))
+}
+
+func (v *validator) validateComposition(s *Schema, value interface{}, path string) {
+	for _, sub := range s.AllOf {
+		v.validate(sub, value, path)
+	}
+
+	if len(s.AnyOf) > 0 {
+		matched := false
+
+		for _, sub := range s.AnyOf {
+			if v.valid(sub, value, path) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			v.fail(path, "must match at least one of the schemas of anyOf")
+		}
+	}
+
+	if len(s.OneOf) > 0 {
+		matched := 0
+
+		for _, sub := range s.OneOf {
+			if v.valid(sub, value, path) {
+				matched++
+			}
+		}
+
+		if matched != 1 {
+			v.fail(path, "must match exactly one of the schemas of oneOf, not %d", matched)
+		}
+	}
+
+	if s.Not != nil && v.valid(s.Not, value, path) {
+		v.fail(path, "must not match the schema of not")
+	}
+}
+
+func (v *validator) validateObject(s *Schema, obj map[string]interface{}, path string) {
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			v.fail(path+"/"+escapePointer(name), "is required")
+		}
+	}
+
+	if s.MinProperties != nil && len(obj) < *s.MinProperties {
+		v.fail(path, "must have at least %d properties", *s.MinProperties)
+	}
+
+	if s.MaxProperties != nil && len(obj) > *s.MaxProperties {
+		v.fail(path, "must have at most %d properties", *s.MaxProperties)
+	}
+
+	for name, value := range obj {
+		propertyPath := path + "/" + escapePointer(name)
+
+		if prop, ok := s.Properties[name]; ok {
+			v.validate(prop, value, propertyPath)
+			continue
+		}
+
+		if s.AdditionalProperties != nil {
+			if b := s.AdditionalProperties.boolean; b != nil && !*b {
+				v.fail(propertyPath, "is not an allowed property")
+				continue
+			}
+
+			v.validate(s.AdditionalProperties, value, propertyPath)
+		}
+	}
+}
+
+func (v *validator) validateArray(s *Schema, items []interface{}, path string) {
+	if s.MinItems != nil && len(items) < *s.MinItems {
+		v.fail(path, "must have at least %d items", *s.MinItems)
+	}
+
+	if s.MaxItems != nil && len(items) > *s.MaxItems {
+		v.fail(path, "must have at most %d items", *s.MaxItems)
+	}
+
+	if s.UniqueItems {
+		for i := range items {
+			for j := 0; j < i; j++ {
+				if equal(items[i], items[j]) {
+					v.fail(path+"/"+strconv.Itoa(i), "must be unique, but equals the item %d", j)
+					break
+				}
+			}
+		}
+	}
+
+	for i, item := range items {
+		v.validate(s.Items, item, path+"/"+strconv.Itoa(i))
+	}
+}
+
+func (v *validator) validateString(s *Schema, str, path string) {
+	length := utf8.RuneCountInString(str)
+
+	if s.MinLength != nil && length < *s.MinLength {
+		v.fail(path, "must be at least %d characters long", *s.MinLength)
+	}
+
+	if s.MaxLength != nil && length > *s.MaxLength {
+		v.fail(path, "must be at most %d characters long", *s.MaxLength)
+	}
+
+	if s.Pattern != "" {
+		re := s.pattern
+		if re == nil {
+			var err error
+
+			if re, err = regexp.Compile(s.Pattern); err != nil {
+				v.fail(path, "cannot be matched with the invalid pattern %q", s.Pattern)
+				return
+			}
+		}
+
+		if !re.MatchString(str) {
+			v.fail(path, "must match the pattern %s", s.Pattern)
+		}
+	}
+
+	if check, ok := formats[s.Format]; ok && !check(str) {
+		v.fail(path, "must be a valid %s", s.Format)
+	}
+}
+
+func (v *validator) validateNumber(s *Schema, n float64, path string) {
+	if s.Minimum != nil && n < *s.Minimum {
+		v.fail(path, "must be greater than or equal to %v", *s.Minimum)
+	}
+
+	if s.Maximum != nil && n > *s.Maximum {
+		v.fail(path, "must be less than or equal to %v", *s.Maximum)
+	}
+
+	if s.ExclusiveMinimum != nil && n <= *s.ExclusiveMinimum {
+		v.fail(path, "must be greater than %v", *s.ExclusiveMinimum)
+	}
+
+	if s.ExclusiveMaximum != nil && n >= *s.ExclusiveMaximum {
+		v.fail(path, "must be less than %v", *s.ExclusiveMaximum)
+	}
+
+	if s.MultipleOf != nil && *s.MultipleOf > 0 {
+		q := n / *s.MultipleOf
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			v.fail(path, "must be a multiple of %v", *s.MultipleOf)
+		}
+	}
+}
+
+// hasType reports whether the value is of one of the types, an integer being a number too.
+func hasType(types Types, value interface{}) bool {
+	actual := typeOf(value)
+
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// typeOf returns the JSON Schema type of the value decoded from JSON.
+func typeOf(value interface{}) string {
+	switch val := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case json.Number, float64:
+		if f := toFloat(val); f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return "integer"
+		}
+
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// toFloat returns the value of a number, or NaN.
+func toFloat(value interface{}) float64 {
+	if n, ok := value.(json.Number); ok {
+		f, _ := n.Float64()
+		return f
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		return math.NaN()
+	}
+}
+
+// equal reports whether the values decoded from JSON are equal, the numbers being compared by their values.
+func equal(a, b interface{}) bool {
+	if x, y := toFloat(a), toFloat(b); !math.IsNaN(x) || !math.IsNaN(y) {
+		return x == y
+	}
+
+	switch x := a.(type) {
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+
+		return true
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+
+		for k, xv := range x {
+			yv, ok := y[k]
+			if !ok || !equal(xv, yv) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	tokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	caFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+
+	requestTimeout = 10 * time.Second
+)
+
+var (
+	// ErrNotInCluster is returned by InClusterClient outside of a pod of Kubernetes.
+	ErrNotInCluster = errors.New("not running in a Kubernetes cluster")
+
+	errNotFound = errors.New("not found")
+	errConflict = errors.New("conflict")
+)
+
+// Client calls the API server of Kubernetes, authenticated by a bearer token.
+type Client struct {
+	baseURL string
+	http    *http.Client
+	// token returns the token of the requests, which is read from its file in the cluster.
+	token func() (string, error)
+}
+
+// NewClient returns a client of the API server at baseURL, authenticated by the token.
+func NewClient(baseURL, token string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		http:    httpClient,
+		token:   func() (string, error) { return token, nil },
+	}
+}
+
+// InClusterClient returns a client of the API server of the pod. It returns ErrNotInCluster outside of a pod.
+func InClusterClient() (*Client, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, ErrNotInCluster
+	}
+
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotInCluster, err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca)
+
+	return &Client{
+		baseURL: "https://" + net.JoinHostPort(host, port),
+		http: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+		},
+		token: func() (string, error) {
+			token, err := os.ReadFile(tokenFile)
+
+			return strings.TrimSpace(string(token)), err
+		},
+	}, nil
+}
+
+// do calls the API server and decodes the response into out.
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+
+	token, err := c.token()
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errNotFound
+	case resp.StatusCode == http.StatusConflict:
+		return errConflict
+	case resp.StatusCode >= http.StatusBadRequest:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, bytes.TrimSpace(msg))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
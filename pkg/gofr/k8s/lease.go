@@ -0,0 +1,278 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+
+	// microTime is the layout of the times of the leases.
+	microTime = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+var errNoNamespace = errors.New("the namespace of the lease is not set, and the pod has none")
+
+// Logger logs the changes of the leadership and the errors of the elections.
+type Logger interface {
+	Infof(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// LeaderElectionConfig is the configuration of the election of a leader among the replicas of an application.
+type LeaderElectionConfig struct {
+	// Name is the name of the Lease, which is shared by the replicas electing a leader.
+	Name string
+	// Namespace is the namespace of the Lease, which is the one of the pod by default.
+	Namespace string
+	// Identity identifies the replica in the Lease, which is the name of the pod, or the hostname, by default.
+	Identity string
+	// LeaseDuration is the time the leader holds the Lease without renewing it, 15 seconds by default.
+	LeaseDuration time.Duration
+	// RetryPeriod is the interval at which the Lease is renewed or taken over, 2 seconds by default.
+	RetryPeriod time.Duration
+}
+
+// lease is a Lease of the coordination.k8s.io/v1 API.
+type lease struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Metadata   leaseMetadata `json:"metadata"`
+	Spec       leaseSpec     `json:"spec"`
+}
+
+type leaseMetadata struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type leaseSpec struct {
+	HolderIdentity       string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int    `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int    `json:"leaseTransitions"`
+}
+
+// LeaderElector elects one of the replicas as the leader, holding a Lease. The service account of the pods must be
+// allowed to get, create and update the leases.
+type LeaderElector struct {
+	client *Client
+	config LeaderElectionConfig
+	logger Logger
+
+	leader atomic.Bool
+	// renewed is the time at which the Lease was last held, which is only used by Run.
+	renewed time.Time
+	now     func() time.Time
+}
+
+// NewLeaderElector returns the elector of the leader holding the Lease of the config.
+func NewLeaderElector(client *Client, cfg LeaderElectionConfig, logger Logger) *LeaderElector {
+	pod := CurrentPod()
+
+	if cfg.Namespace == "" {
+		cfg.Namespace = pod.Namespace
+	}
+
+	if cfg.Identity == "" {
+		cfg.Identity = pod.Name
+	}
+
+	if cfg.Identity == "" {
+		cfg.Identity, _ = os.Hostname()
+	}
+
+	if cfg.LeaseDuration <= 0 {
+		cfg.LeaseDuration = defaultLeaseDuration
+	}
+
+	if cfg.RetryPeriod <= 0 {
+		cfg.RetryPeriod = defaultRetryPeriod
+	}
+
+	return &LeaderElector{client: client, config: cfg, logger: logger, now: time.Now}
+}
+
+// IsLeader reports whether the replica is the leader.
+func (e *LeaderElector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Run takes part in the election until ctx is done, and then releases the Lease.
+func (e *LeaderElector) Run(ctx context.Context) {
+	if e.config.Namespace == "" {
+		e.errorf("could not elect the leader of %s: %v", e.config.Name, errNoNamespace)
+
+		return
+	}
+
+	ticker := time.NewTicker(e.config.RetryPeriod)
+	defer ticker.Stop()
+
+	for {
+		e.elect(ctx)
+
+		select {
+		case <-ctx.Done():
+			e.release()
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// elect tries to take the Lease over, or to renew it, updating the leadership of the replica.
+func (e *LeaderElector) elect(ctx context.Context) {
+	held, err := e.tryAcquire(ctx)
+
+	switch {
+	case ctx.Err() != nil:
+		return
+	case err != nil:
+		e.errorf("could not renew the lease %s: %v", e.config.Name, err)
+
+		// the leader steps down before the Lease expires, for the other replicas not to take it over while it leads.
+		if e.IsLeader() && e.now().Sub(e.renewed) >= e.config.LeaseDuration*2/3 {
+			e.setLeader(false)
+		}
+	case held:
+		e.renewed = e.now()
+		e.setLeader(true)
+	default:
+		e.setLeader(false)
+	}
+}
+
+func (e *LeaderElector) setLeader(leader bool) {
+	if e.leader.Swap(leader) == leader {
+		return
+	}
+
+	if e.logger == nil {
+		return
+	}
+
+	if leader {
+		e.logger.Infof("%s is the leader of %s", e.config.Identity, e.config.Name)
+	} else {
+		e.logger.Infof("%s is no longer the leader of %s", e.config.Identity, e.config.Name)
+	}
+}
+
+// tryAcquire creates or updates the Lease, and returns whether the replica holds it.
+func (e *LeaderElector) tryAcquire(ctx context.Context) (bool, error) {
+	now := e.now()
+
+	var current lease
+
+	err := e.client.do(ctx, http.MethodGet, e.path(), nil, &current)
+
+	switch {
+	case errors.Is(err, errNotFound):
+		l := e.lease(now)
+
+		err = e.client.do(ctx, http.MethodPost, "/apis/coordination.k8s.io/v1/namespaces/"+e.config.Namespace+"/leases",
+			l, nil)
+		if errors.Is(err, errConflict) {
+			return false, nil
+		}
+
+		return err == nil, err
+	case err != nil:
+		return false, err
+	}
+
+	holder := current.Spec.HolderIdentity
+	if holder != "" && holder != e.config.Identity && !expired(current.Spec, now) {
+		return false, nil
+	}
+
+	l := e.lease(now)
+	l.Metadata.ResourceVersion = current.Metadata.ResourceVersion
+	l.Spec.LeaseTransitions = current.Spec.LeaseTransitions
+
+	if holder == e.config.Identity {
+		l.Spec.AcquireTime = current.Spec.AcquireTime
+	} else {
+		l.Spec.LeaseTransitions++
+	}
+
+	// the update is rejected with a conflict if another replica updated the Lease since it was read.
+	err = e.client.do(ctx, http.MethodPut, e.path(), l, nil)
+	if errors.Is(err, errConflict) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// release gives the Lease up if the replica holds it, by making it expire.
+func (e *LeaderElector) release() {
+	if !e.IsLeader() {
+		return
+	}
+
+	e.setLeader(false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	var current lease
+
+	if err := e.client.do(ctx, http.MethodGet, e.path(), nil, &current); err != nil ||
+		current.Spec.HolderIdentity != e.config.Identity {
+		return
+	}
+
+	current.Spec.HolderIdentity = ""
+	current.Spec.LeaseDurationSeconds = 1
+
+	if err := e.client.do(ctx, http.MethodPut, e.path(), &current, nil); err != nil {
+		e.errorf("could not release the lease %s: %v", e.config.Name, err)
+	}
+}
+
+func (e *LeaderElector) lease(now time.Time) *lease {
+	t := now.UTC().Format(microTime)
+
+	return &lease{
+		APIVersion: "coordination.k8s.io/v1",
+		Kind:       "Lease",
+		Metadata:   leaseMetadata{Name: e.config.Name, Namespace: e.config.Namespace},
+		Spec: leaseSpec{
+			HolderIdentity:       e.config.Identity,
+			LeaseDurationSeconds: int(e.config.LeaseDuration.Seconds()),
+			AcquireTime:          t,
+			RenewTime:            t,
+		},
+	}
+}
+
+func (e *LeaderElector) path() string {
+	return "/apis/coordination.k8s.io/v1/namespaces/" + e.config.Namespace + "/leases/" + e.config.Name
+}
+
+func (e *LeaderElector) errorf(format string, args ...interface{}) {
+	if e.logger != nil {
+		e.logger.Errorf(format, args...)
+	}
+}
+
+// expired reports whether the Lease was not renewed for its duration at now.
+func expired(spec leaseSpec, now time.Time) bool {
+	renewed, err := time.Parse(time.RFC3339Nano, spec.RenewTime)
+	if err != nil {
+		return true
+	}
+
+	return now.After(renewed.Add(time.Duration(spec.LeaseDurationSeconds) * time.Second))
+}
@@ -0,0 +1,204 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const leasePath = "/apis/coordination.k8s.io/v1/namespaces/shop/leases/orders-cron"
+
+// fakeAPIServer serves a Lease, rejecting the updates of a stale version with a conflict like the API server.
+type fakeAPIServer struct {
+	mu      sync.Mutex
+	lease   *lease
+	version int
+	down    bool
+}
+
+func (f *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.down || r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var l lease
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == leasePath:
+		if f.lease == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(f.lease)
+
+		return
+	case r.Method == http.MethodPost && r.URL.Path == "/apis/coordination.k8s.io/v1/namespaces/shop/leases":
+		if f.lease != nil {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+	case r.Method == http.MethodPut && r.URL.Path == leasePath:
+		if f.lease == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	_ = json.NewDecoder(r.Body).Decode(&l)
+
+	if f.lease != nil && l.Metadata.ResourceVersion != f.lease.Metadata.ResourceVersion {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	f.version++
+	l.Metadata.ResourceVersion = strconv.Itoa(f.version)
+	f.lease = &l
+
+	_ = json.NewEncoder(w).Encode(f.lease)
+}
+
+func (f *fakeAPIServer) holder() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.lease == nil {
+		return ""
+	}
+
+	return f.lease.Spec.HolderIdentity
+}
+
+func newElector(t *testing.T, api *fakeAPIServer, identity string) *LeaderElector {
+	t.Helper()
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	return NewLeaderElector(NewClient(server.URL, "token", server.Client()), LeaderElectionConfig{Name: "orders-cron",
+		Namespace: "shop", Identity: identity, LeaseDuration: 15 * time.Second}, nil)
+}
+
+func TestLeaderElector_Elect(t *testing.T) {
+	api := &fakeAPIServer{}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	a, b := newElector(t, api, "pod-a"), newElector(t, api, "pod-b")
+	a.now = func() time.Time { return now }
+	b.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	a.elect(ctx)
+	b.elect(ctx)
+
+	assert.True(t, a.IsLeader())
+	assert.False(t, b.IsLeader())
+	assert.Equal(t, "pod-a", api.holder())
+
+	// the leader renews the lease, which the other replica cannot take over until it expires.
+	now = now.Add(10 * time.Second)
+
+	a.elect(ctx)
+	b.elect(ctx)
+
+	assert.True(t, a.IsLeader())
+	assert.False(t, b.IsLeader())
+
+	now = now.Add(16 * time.Second)
+
+	b.elect(ctx)
+
+	assert.True(t, b.IsLeader())
+	assert.Equal(t, "pod-b", api.holder())
+	assert.Equal(t, 1, api.lease.Spec.LeaseTransitions)
+
+	a.elect(ctx)
+	assert.False(t, a.IsLeader())
+}
+
+func TestLeaderElector_StepsDown(t *testing.T) {
+	api := &fakeAPIServer{}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	e := newElector(t, api, "pod-a")
+	e.now = func() time.Time { return now }
+
+	e.elect(context.Background())
+	require.True(t, e.IsLeader())
+
+	api.down = true
+
+	// the leader keeps leading while it can still renew the lease before it expires.
+	now = now.Add(5 * time.Second)
+	e.elect(context.Background())
+	assert.True(t, e.IsLeader())
+
+	now = now.Add(5 * time.Second)
+	e.elect(context.Background())
+	assert.False(t, e.IsLeader())
+}
+
+func TestLeaderElector_Run(t *testing.T) {
+	api := &fakeAPIServer{}
+
+	e := newElector(t, api, "pod-a")
+	e.config.RetryPeriod = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		e.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, e.IsLeader, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+
+	assert.False(t, e.IsLeader())
+	assert.Empty(t, api.holder(), "the lease must be released")
+
+	// another replica takes the released lease over right away.
+	b := newElector(t, api, "pod-b")
+	b.elect(context.Background())
+
+	assert.True(t, b.IsLeader())
+}
+
+func TestLeaderElector_NoNamespace(t *testing.T) {
+	e := NewLeaderElector(NewClient("http://localhost", "token", nil), LeaderElectionConfig{Name: "orders-cron"}, nil)
+
+	e.Run(context.Background())
+
+	assert.False(t, e.IsLeader())
+	assert.Equal(t, defaultLeaseDuration, e.config.LeaseDuration)
+	assert.Equal(t, defaultRetryPeriod, e.config.RetryPeriod)
+	assert.NotEmpty(t, e.config.Identity)
+}
+
+func TestInClusterClient_NotInCluster(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	_, err := InClusterClient()
+
+	assert.ErrorIs(t, err, ErrNotInCluster)
+}
@@ -0,0 +1,78 @@
+// Package k8s labels the telemetry with the pod of the application, and elects a leader among its replicas.
+package k8s
+
+import (
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// namespaceFile is the file of the namespace of the pod, mounted with the token of its service account.
+const namespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// Pod is the pod of the application, from the POD_NAME, POD_NAMESPACE and NODE_NAME of the downward API.
+//
+//	env:
+//	  - name: POD_NAME
+//	    valueFrom: {fieldRef: {fieldPath: metadata.name}}
+//	  - name: POD_NAMESPACE
+//	    valueFrom: {fieldRef: {fieldPath: metadata.namespace}}
+//	  - name: NODE_NAME
+//	    valueFrom: {fieldRef: {fieldPath: spec.nodeName}}
+type Pod struct {
+	Name      string
+	Namespace string
+	Node      string
+}
+
+// CurrentPod returns the pod of the application, whose fields are empty outside of Kubernetes.
+func CurrentPod() Pod {
+	pod := Pod{Name: os.Getenv("POD_NAME"), Namespace: os.Getenv("POD_NAMESPACE"), Node: os.Getenv("NODE_NAME")}
+
+	if pod.Namespace == "" {
+		if ns, err := os.ReadFile(namespaceFile); err == nil {
+			pod.Namespace = strings.TrimSpace(string(ns))
+		}
+	}
+
+	return pod
+}
+
+// Known reports whether the pod is known, which it is once its name is exposed by the downward API.
+func (p Pod) Known() bool {
+	return p.Name != ""
+}
+
+// Labels returns the labels of the pod, for the logs and the metrics, without those which are not known.
+func (p Pod) Labels() map[string]string {
+	labels := make(map[string]string)
+
+	for key, value := range map[string]string{"k8s_pod": p.Name, "k8s_namespace": p.Namespace, "k8s_node": p.Node} {
+		if value != "" {
+			labels[key] = value
+		}
+	}
+
+	return labels
+}
+
+// Attributes returns the attributes of the resource of the traces of the pod, without those which are not known.
+func (p Pod) Attributes() []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+
+	if p.Name != "" {
+		attrs = append(attrs, semconv.K8SPodNameKey.String(p.Name))
+	}
+
+	if p.Namespace != "" {
+		attrs = append(attrs, semconv.K8SNamespaceNameKey.String(p.Namespace))
+	}
+
+	if p.Node != "" {
+		attrs = append(attrs, semconv.K8SNodeNameKey.String(p.Node))
+	}
+
+	return attrs
+}
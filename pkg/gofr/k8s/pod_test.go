@@ -0,0 +1,32 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestCurrentPod(t *testing.T) {
+	t.Setenv("POD_NAME", "orders-5d8f7")
+	t.Setenv("POD_NAMESPACE", "shop")
+	t.Setenv("NODE_NAME", "")
+
+	pod := CurrentPod()
+
+	assert.True(t, pod.Known())
+	assert.Equal(t, Pod{Name: "orders-5d8f7", Namespace: "shop"}, pod)
+	assert.Equal(t, map[string]string{"k8s_pod": "orders-5d8f7", "k8s_namespace": "shop"}, pod.Labels())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("k8s.pod.name", "orders-5d8f7"),
+		attribute.String("k8s.namespace.name", "shop")}, pod.Attributes())
+}
+
+func TestCurrentPod_NotInCluster(t *testing.T) {
+	t.Setenv("POD_NAME", "")
+
+	pod := CurrentPod()
+
+	assert.False(t, pod.Known())
+	assert.Empty(t, Pod{}.Labels())
+	assert.Empty(t, Pod{}.Attributes())
+}
@@ -0,0 +1,103 @@
+package gofr
+
+import (
+	"context"
+	"sync"
+)
+
+// LeaderElector elects one of the replicas of the application as the leader, like k8s.LeaderElector.
+type LeaderElector interface {
+	// Run takes part in the election until ctx is done.
+	Run(ctx context.Context)
+	// IsLeader reports whether the replica is the leader.
+	IsLeader() bool
+}
+
+// leaderElection runs the elector of the application from Run until Shutdown.
+type leaderElection struct {
+	le LeaderElector
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	// done is closed once the elector stopped running.
+	done chan struct{}
+}
+
+// UseLeaderElection runs the cron jobs only on the leader elected by the elector.
+//
+//	Usage:
+//	client, err := k8s.InClusterClient()
+//	if err != nil {
+//		app.Logger().Fatal(err)
+//	}
+//
+//	app.UseLeaderElection(k8s.NewLeaderElector(client, k8s.LeaderElectionConfig{Name: "orders-cron"}, app.Logger()))
+func (a *App) UseLeaderElection(le LeaderElector) {
+	a.leader = &leaderElection{le: le}
+
+	if a.cron != nil {
+		a.cron.mu.Lock()
+		a.cron.leader = le
+		a.cron.mu.Unlock()
+	}
+}
+
+func (l *leaderElection) elector() LeaderElector {
+	if l == nil {
+		return nil
+	}
+
+	return l.le
+}
+
+// start runs the elector until stop is called.
+func (l *leaderElection) start() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.done != nil {
+		return
+	}
+
+	var ctx context.Context
+
+	ctx, l.cancel = context.WithCancel(context.Background())
+	l.done = make(chan struct{})
+
+	go func() {
+		defer close(l.done)
+
+		l.le.Run(ctx)
+	}()
+}
+
+// stop stops the elector, waiting for it to give up the leadership until ctx is done.
+func (l *leaderElection) stop(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	done := l.done
+
+	if done != nil {
+		l.cancel()
+	}
+
+	l.mu.Unlock()
+
+	if done == nil {
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,73 @@
+package gofr
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockElector struct {
+	leader  atomic.Bool
+	running atomic.Bool
+}
+
+func (m *mockElector) Run(ctx context.Context) {
+	m.running.Store(true)
+	<-ctx.Done()
+	m.running.Store(false)
+}
+
+func (m *mockElector) IsLeader() bool {
+	return m.leader.Load()
+}
+
+func TestCronTab_runScheduled_OnlyOnLeader(t *testing.T) {
+	var runs int32
+
+	j, _ := parseSchedule("* * * * *")
+	j.fn = func(*Context) { atomic.AddInt32(&runs, 1) }
+
+	elector := &mockElector{}
+
+	c := NewCron(nil)
+	c.ticker.Stop()
+	c.jobs = []*job{j}
+	c.leader = elector
+
+	start := time.Date(2024, 1, 1, 1, 1, 0, 0, time.Local)
+
+	c.runScheduled(start)
+
+	elector.leader.Store(true)
+	c.runScheduled(start.Add(time.Minute))
+
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs), "the job must only run on the leader")
+}
+
+func TestApp_UseLeaderElection(t *testing.T) {
+	app := New()
+	elector := &mockElector{}
+
+	app.AddCronJob("* * * * *", "report", func(*Context) {})
+	app.UseLeaderElection(elector)
+
+	assert.Equal(t, LeaderElector(elector), app.cron.leader)
+	assert.Equal(t, LeaderElector(elector), app.newCron().leader)
+
+	app.leader.start()
+	app.leader.start()
+
+	assert.Eventually(t, elector.running.Load, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, app.Shutdown(context.Background()))
+	assert.False(t, elector.running.Load())
+
+	// an application without an elector is not elected.
+	assert.NoError(t, New().leader.stop(context.Background()))
+}
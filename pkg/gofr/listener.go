@@ -0,0 +1,122 @@
+package gofr
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	unixListenPrefix    = "unix:"
+	fdListenPrefix      = "fd:"
+	systemdListen       = "systemd"
+	systemdListenPrefix = "systemd:"
+
+	// systemdFirstFD is the first file descriptor passed by systemd, after stdin, stdout and stderr.
+	systemdFirstFD = 3
+)
+
+var (
+	errNoSystemdSockets   = errors.New("no sockets are passed by systemd for this process")
+	errSystemdSocketName  = errors.New("no socket of the name is passed by systemd")
+	errInvalidListenFD    = errors.New("the file descriptor to listen on must be a non-negative integer")
+	errSocketPathNotEmpty = errors.New("the path of the unix socket exists and is not a socket")
+)
+
+// listen returns the listener of a server, from its listen config, like HTTP_LISTEN, or the TCP port:
+//
+//   - unix:/run/app.sock, a unix domain socket at the path.
+//   - systemd, or systemd:NAME, a socket passed by systemd socket activation.
+//   - fd:N, the socket of the inherited file descriptor N.
+func listen(config string, port int) (net.Listener, error) {
+	switch {
+	case config == "":
+		return net.Listen("tcp", ":"+strconv.Itoa(port))
+	case strings.HasPrefix(config, unixListenPrefix):
+		return listenUnix(strings.TrimPrefix(config, unixListenPrefix))
+	case config == systemdListen || strings.HasPrefix(config, systemdListenPrefix):
+		fd, err := systemdFD(strings.TrimPrefix(strings.TrimPrefix(config, systemdListen), ":"), os.Getenv)
+		if err != nil {
+			return nil, err
+		}
+
+		return listenFD(fd)
+	case strings.HasPrefix(config, fdListenPrefix):
+		fd, err := strconv.Atoi(strings.TrimPrefix(config, fdListenPrefix))
+		if err != nil || fd < 0 {
+			return nil, errInvalidListenFD
+		}
+
+		return listenFD(fd)
+	default:
+		return nil, fmt.Errorf("%q is not a listen address, which is one of unix:PATH, systemd[:NAME] or fd:N", config)
+	}
+}
+
+// listenAddress describes the address a server listens on in its logs.
+func listenAddress(config string, port int) string {
+	if config == "" {
+		return "port " + strconv.Itoa(port)
+	}
+
+	return config
+}
+
+func listenUnix(path string) (net.Listener, error) {
+	info, err := os.Stat(path)
+
+	switch {
+	case err == nil && info.Mode().Type() != iofs.ModeSocket:
+		return nil, errSocketPathNotEmpty
+	case err == nil:
+		// the socket is left by a previous run which did not remove it, and no longer accepts connections.
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, iofs.ErrNotExist):
+		return nil, err
+	}
+
+	return net.Listen("unix", path)
+}
+
+func listenFD(fd int) (net.Listener, error) {
+	f := os.NewFile(uintptr(fd), "listener-fd-"+strconv.Itoa(fd))
+	if f == nil {
+		return nil, errInvalidListenFD
+	}
+
+	// the listener uses a duplicate of the file descriptor, so that the file can be closed.
+	defer f.Close()
+
+	return net.FileListener(f)
+}
+
+// systemdFD returns the file descriptor of the socket of the name passed by systemd, or of the first one.
+func systemdFD(name string, getenv func(string) string) (int, error) {
+	pid, err := strconv.Atoi(getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return 0, errNoSystemdSockets
+	}
+
+	count, err := strconv.Atoi(getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return 0, errNoSystemdSockets
+	}
+
+	if name == "" {
+		return systemdFirstFD, nil
+	}
+
+	for i, n := range strings.Split(getenv("LISTEN_FDNAMES"), ":") {
+		if n == name && i < count {
+			return systemdFirstFD + i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", errSystemdSocketName, name)
+}
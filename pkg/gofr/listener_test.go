@@ -0,0 +1,152 @@
+package gofr
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+func TestHTTPServer_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+
+	// a socket left by a previous run is replaced.
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	router := gofrHTTP.NewRouter()
+	router.Add(http.MethodGet, "/", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	server := &httpServer{router: router, listen: "unix:" + path}
+
+	go server.Run(&container.Container{Logger: logging.NewLogger(logging.FATAL)})
+
+	defer server.Shutdown(context.Background())
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+
+	require.Eventually(t, func() bool {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://app/", http.NoBody)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return false
+		}
+
+		resp.Body.Close()
+
+		return resp.StatusCode == http.StatusTeapot
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGRPCServer_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grpc.sock")
+	c := &container.Container{Logger: logging.NewLogger(logging.FATAL)}
+
+	g := newGRPCServer(c, 0)
+	g.listen = "unix:" + path
+
+	go g.Run(c)
+
+	defer g.server.Stop()
+
+	conn, err := grpc.NewClient("unix://"+path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	require.Eventually(t, func() bool {
+		_, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestListen(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer tcp.Close()
+
+	f, err := tcp.(*net.TCPListener).File()
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	testCases := []struct {
+		desc   string
+		config string
+		err    bool
+	}{
+		{"unix socket", "unix:" + filepath.Join(dir, "app.sock"), false},
+		{"unix socket path is a file", "unix:" + file, true},
+		{"inherited file descriptor", "fd:" + strconv.Itoa(int(f.Fd())), false},
+		{"invalid file descriptor", "fd:-1", true},
+		{"no systemd sockets", "systemd", true},
+		{"unknown address", "tcp://:8000", true},
+	}
+
+	for i, tc := range testCases {
+		l, err := listen(tc.config, 0)
+		if l != nil {
+			l.Close()
+		}
+
+		assert.Equal(t, tc.err, err != nil, "TEST[%d], Failed.\n%s: %v", i, tc.desc, err)
+	}
+}
+
+func TestSystemdFD(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+
+	testCases := []struct {
+		desc string
+		name string
+		env  map[string]string
+		fd   int
+		err  bool
+	}{
+		{"first socket", "", map[string]string{"LISTEN_PID": pid, "LISTEN_FDS": "2"}, 3, false},
+		{"named socket", "grpc", map[string]string{"LISTEN_PID": pid, "LISTEN_FDS": "2", "LISTEN_FDNAMES": "http:grpc"},
+			4, false},
+		{"unknown name", "admin", map[string]string{"LISTEN_PID": pid, "LISTEN_FDS": "2", "LISTEN_FDNAMES": "http:grpc"},
+			0, true},
+		{"sockets of another process", "", map[string]string{"LISTEN_PID": "1", "LISTEN_FDS": "1"}, 0, true},
+		{"no sockets", "", map[string]string{"LISTEN_PID": pid, "LISTEN_FDS": "0"}, 0, true},
+	}
+
+	for i, tc := range testCases {
+		fd, err := systemdFD(tc.name, func(key string) string { return tc.env[key] })
+
+		assert.Equal(t, tc.fd, fd, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.err, err != nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,102 @@
+package gofr
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+)
+
+// The priorities of the requests of the routes, the lowest being shed first.
+const (
+	PriorityLow      = middleware.PriorityLow
+	PriorityNormal   = middleware.PriorityNormal
+	PriorityHigh     = middleware.PriorityHigh
+	PriorityCritical = middleware.PriorityCritical
+)
+
+// Priority sets the priority of the requests of the route, PriorityNormal by default.
+//
+//	Usage:
+//	app.GET("/reports", getReports, gofr.Priority(gofr.PriorityLow))
+//	app.POST("/checkout", checkout, gofr.Priority(gofr.PriorityHigh))
+func Priority(p middleware.Priority) RouteOption {
+	return func(h *handler) {
+		h.priority = p
+	}
+}
+
+// routePriorities are the priorities of the routes, by their method and their path.
+type routePriorities struct {
+	routes sync.Map
+}
+
+func (p *routePriorities) set(method, pattern string, priority middleware.Priority) {
+	p.routes.Store(method+" "+strings.TrimSuffix(pattern, "/"), priority)
+}
+
+// priority returns the priority of the route of the request.
+func (p *routePriorities) priority(r *http.Request) middleware.Priority {
+	if strings.HasPrefix(r.URL.Path, "/.well-known/") {
+		return middleware.PriorityCritical
+	}
+
+	route := strings.TrimSuffix(gofrHTTP.PathTemplate(r), "/")
+
+	if priority, ok := p.routes.Load(r.Method + " " + route); ok {
+		return priority.(middleware.Priority)
+	}
+
+	return middleware.PriorityNormal
+}
+
+// loadShedConfig returns the thresholds of the LOAD_SHED_* configs, and whether one is set.
+func loadShedConfig(c config.Config, cont *container.Container) (middleware.LoadShedConfig, bool) {
+	var cfg middleware.LoadShedConfig
+
+	if v := c.Get("LOAD_SHED_MAX_IN_FLIGHT"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			cont.Errorf("invalid LOAD_SHED_MAX_IN_FLIGHT %q, the requests in flight are not monitored", v)
+		}
+
+		cfg.MaxInFlight = n
+	}
+
+	if v := c.Get("LOAD_SHED_MAX_LATENCY"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			cont.Errorf("invalid LOAD_SHED_MAX_LATENCY %q, the latency of the requests is not monitored", v)
+		}
+
+		cfg.MaxLatency = d
+	}
+
+	if v := c.Get("LOAD_SHED_MAX_CPU"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 || f > 1 {
+			cont.Errorf("invalid LOAD_SHED_MAX_CPU %q, it is a share of the CPUs between 0 and 1", v)
+
+			f = 0
+		}
+
+		cfg.MaxCPU = f
+	}
+
+	if v := c.Get("LOAD_SHED_RETRY_AFTER"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			cont.Errorf("invalid LOAD_SHED_RETRY_AFTER %q, the requests are retried after a second", v)
+		}
+
+		cfg.RetryAfter = d
+	}
+
+	return cfg, cfg.MaxInFlight > 0 || cfg.MaxLatency > 0 || cfg.MaxCPU > 0
+}
@@ -0,0 +1,102 @@
+package gofr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+)
+
+func TestPriority(t *testing.T) {
+	t.Setenv("LOAD_SHED_MAX_IN_FLIGHT", "100")
+
+	app := New()
+
+	handler := func(*Context) (interface{}, error) {
+		return nil, nil
+	}
+
+	app.GET("/reports/{id}/", handler, Priority(PriorityLow))
+	app.POST("/checkout", handler, Priority(PriorityHigh))
+	app.GET("/orders", handler)
+
+	testCases := []struct {
+		route    string
+		priority middleware.Priority
+	}{
+		{"GET /reports/{id}", PriorityLow},
+		{"POST /checkout", PriorityHigh},
+		{"GET /orders", PriorityNormal},
+	}
+
+	for i, tc := range testCases {
+		priority, ok := app.routePriorities.routes.Load(tc.route)
+
+		assert.True(t, ok, "TEST[%d], Failed.\n%s", i, tc.route)
+		assert.Equal(t, tc.priority, priority, "TEST[%d], Failed.\n%s", i, tc.route)
+	}
+}
+
+func TestRoutePriorities_Priority(t *testing.T) {
+	p := &routePriorities{}
+	p.set(http.MethodGet, "/reports/{id}", PriorityLow)
+	p.set(http.MethodGet, "/.well-known/alive", PriorityNormal)
+
+	var priority middleware.Priority
+
+	router := gofrHTTP.NewRouter()
+
+	for _, pattern := range []string{"/reports/{id}", "/orders", "/.well-known/alive"} {
+		router.Add(http.MethodGet, pattern, http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			priority = p.priority(r)
+		}))
+	}
+
+	testCases := []struct {
+		path     string
+		priority middleware.Priority
+	}{
+		{"/reports/1", PriorityLow},
+		{"/orders", PriorityNormal},
+		{"/.well-known/alive", PriorityCritical},
+	}
+
+	for i, tc := range testCases {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tc.path, http.NoBody))
+
+		assert.Equal(t, tc.priority, priority, "TEST[%d], Failed.\n%s", i, tc.path)
+	}
+}
+
+func TestLoadShedConfig(t *testing.T) {
+	c := container.NewContainer(config.NewMockConfig(nil))
+
+	testCases := []struct {
+		configs map[string]string
+		config  middleware.LoadShedConfig
+		enabled bool
+	}{
+		{map[string]string{}, middleware.LoadShedConfig{}, false},
+		{map[string]string{"LOAD_SHED_MAX_IN_FLIGHT": "500", "LOAD_SHED_MAX_LATENCY": "300ms",
+			"LOAD_SHED_MAX_CPU": "0.8", "LOAD_SHED_RETRY_AFTER": "5s"},
+			middleware.LoadShedConfig{MaxInFlight: 500, MaxLatency: 300 * time.Millisecond, MaxCPU: 0.8,
+				RetryAfter: 5 * time.Second}, true},
+		{map[string]string{"LOAD_SHED_MAX_CPU": "80"}, middleware.LoadShedConfig{}, false},
+		{map[string]string{"LOAD_SHED_MAX_IN_FLIGHT": "many", "LOAD_SHED_MAX_LATENCY": "slow"},
+			middleware.LoadShedConfig{}, false},
+	}
+
+	for i, tc := range testCases {
+		cfg, enabled := loadShedConfig(config.NewMockConfig(tc.configs), c)
+
+		assert.Equal(t, tc.config, cfg, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, tc.enabled, enabled, "TEST[%d], Failed.\n", i)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"golang.org/x/term"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/bufferpool"
 	"github.com/peter-stratton/gofr/pkg/gofr/version"
 )
 
@@ -43,13 +44,27 @@ type logger struct {
 	errorOut   io.Writer
 	isTerminal bool
 	lock       chan struct{}
+
+	// labels are added to the logs, like the pod of the application.
+	labels map[string]string
+}
+
+// Option configures the logger created by NewLogger or NewFileLogger.
+type Option func(l *logger)
+
+// WithLabels adds the labels to the logs of the logger, like the pod of the application.
+func WithLabels(labels map[string]string) Option {
+	return func(l *logger) {
+		l.labels = labels
+	}
 }
 
 type logEntry struct {
-	Level       Level       `json:"level"`
-	Time        time.Time   `json:"time"`
-	Message     interface{} `json:"message"`
-	GofrVersion string      `json:"gofrVersion"`
+	Level       Level             `json:"level"`
+	Time        time.Time         `json:"time"`
+	Message     interface{}       `json:"message"`
+	GofrVersion string            `json:"gofrVersion"`
+	Labels      map[string]string `json:"labels,omitempty"`
 }
 
 func (l *logger) logf(level Level, format string, args ...interface{}) {
@@ -66,6 +81,7 @@ func (l *logger) logf(level Level, format string, args ...interface{}) {
 		Level:       level,
 		Time:        time.Now(),
 		GofrVersion: version.Framework,
+		Labels:      l.labels,
 	}
 
 	switch {
@@ -77,11 +93,26 @@ func (l *logger) logf(level Level, format string, args ...interface{}) {
 		entry.Message = fmt.Sprintf(format+"", args...) // TODO - this is stupid. We should not need empty string.
 	}
 
-	if l.isTerminal {
-		l.prettyPrint(entry, out)
-	} else {
-		_ = json.NewEncoder(out).Encode(entry)
+	// the log is formatted in a buffer of the pool, so that it is written at once.
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	if !l.isTerminal {
+		_ = json.NewEncoder(buf).Encode(entry)
+		_, _ = out.Write(buf.Bytes())
+
+		return
 	}
+
+	l.prettyPrint(entry, buf)
+
+	// Note: we need to lock the pretty print as printing to standard output is not concurrency safe.
+	l.lock <- struct{}{} // Acquire the channel's lock
+	defer func() {
+		<-l.lock // Release the channel's token
+	}()
+
+	_, _ = out.Write(buf.Bytes())
 }
 
 func (l *logger) Debug(args ...interface{}) {
@@ -145,14 +176,6 @@ func (l *logger) Fatalf(format string, args ...interface{}) {
 }
 
 func (l *logger) prettyPrint(e logEntry, out io.Writer) {
-	// Note: we need to lock the pretty print as printing to stdandard output not concurency safe
-	// the logs when printed in go routines were getting missaligned since we are achieveing
-	// a single line of log, in 2 separate statements which caused the missalignment.
-	l.lock <- struct{}{} // Acquire the channel's lock
-	defer func() {
-		<-l.lock // Release the channel's token
-	}()
-
 	// Pretty printing if the message interface defines a method PrettyPrint else print the log message
 	// This decouples the logger implementation from its usage
 	if fn, ok := e.Message.(PrettyPrint); ok {
@@ -169,7 +192,7 @@ func (l *logger) prettyPrint(e logEntry, out io.Writer) {
 }
 
 // NewLogger creates a new logger instance with the specified logging level.
-func NewLogger(level Level) Logger {
+func NewLogger(level Level, opts ...Option) Logger {
 	l := &logger{
 		normalOut: os.Stdout,
 		errorOut:  os.Stderr,
@@ -178,18 +201,26 @@ func NewLogger(level Level) Logger {
 
 	l.level = level
 
+	for _, o := range opts {
+		o(l)
+	}
+
 	l.isTerminal = checkIfTerminal(l.normalOut)
 
 	return l
 }
 
 // NewFileLogger creates a new logger instance with logging to a file.
-func NewFileLogger(path string) Logger {
+func NewFileLogger(path string, opts ...Option) Logger {
 	l := &logger{
 		normalOut: io.Discard,
 		errorOut:  io.Discard,
 	}
 
+	for _, o := range opts {
+		o(l)
+	}
+
 	if path == "" {
 		return l
 	}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/term"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
@@ -214,3 +215,27 @@ func TestPrettyPrint(t *testing.T) {
 		assert.Contains(t, outputLog, v)
 	}
 }
+
+func TestNewLogger_WithLabels(t *testing.T) {
+	var buf bytes.Buffer
+
+	labelled := NewLogger(INFO, WithLabels(map[string]string{"k8s_pod": "orders-5d8f7"})).(*logger)
+	labelled.normalOut = &buf
+
+	unlabelled := NewLogger(INFO).(*logger)
+	unlabelled.normalOut = &buf
+
+	labelled.Info("Test Info Log")
+	unlabelled.Info("Test Info Log")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var entry struct {
+		Labels map[string]string `json:"labels"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, map[string]string{"k8s_pod": "orders-5d8f7"}, entry.Labels)
+	assert.NotContains(t, lines[1], "labels")
+}
@@ -20,7 +20,7 @@ New creates a new RemoteLogger instance with the provided level, remote configur
 The remote configuration URL is expected to be a JSON endpoint that returns the desired log level for the service.
 The level fetch interval determines how often the logger checks for updates to the remote configuration.
 */
-func New(level logging.Level, remoteConfigURL, loggerFetchInterval string) logging.Logger {
+func New(level logging.Level, remoteConfigURL, loggerFetchInterval string, opts ...logging.Option) logging.Logger {
 	interval, err := strconv.Atoi(loggerFetchInterval)
 	if err != nil {
 		interval = 15
@@ -28,7 +28,7 @@ func New(level logging.Level, remoteConfigURL, loggerFetchInterval string) loggi
 
 	l := remoteLogger{
 		remoteURL:          remoteConfigURL,
-		Logger:             logging.NewLogger(level),
+		Logger:             logging.NewLogger(level, opts...),
 		levelFetchInterval: interval,
 		currentLevel:       level,
 	}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// asyncBufferSize is the size of the ring buffer, a power of 2.
+	asyncBufferSize = 1 << 14
+
+	// asyncDrainInterval is the interval at which the buffered samples are recorded.
+	asyncDrainInterval = 100 * time.Millisecond
+)
+
+// ManagerOption configures the Manager created by NewMetricsManager.
+type ManagerOption func(m *metricsManager)
+
+// WithAsyncHistograms records the values of the histograms in the background, at most 100ms later.
+func WithAsyncHistograms() ManagerOption {
+	return func(m *metricsManager) {
+		m.async = newAsyncRecorder(asyncBufferSize)
+
+		go m.async.run(asyncDrainInterval)
+	}
+}
+
+// sample is a value of a histogram, waiting to be recorded.
+type sample struct {
+	ctx        context.Context
+	histogram  metric.Float64Histogram
+	value      float64
+	attributes []attribute.KeyValue
+}
+
+// asyncRecorder records the samples of the histograms from a lock-free ring buffer.
+type asyncRecorder struct {
+	slots []ringSlot
+	mask  uint64
+
+	// tail is the position at which the next sample is added.
+	tail atomic.Uint64
+
+	// wake is signaled each time half of the buffer is filled, so that the buffer is drained before it is full.
+	wake chan struct{}
+
+	// mu guards head, the position of the next sample to take.
+	mu   sync.Mutex
+	head uint64
+}
+
+// ringSlot is a slot of the ring buffer, whose sequence tells whether it can be written or read.
+type ringSlot struct {
+	sequence atomic.Uint64
+	sample   sample
+}
+
+func newAsyncRecorder(size int) *asyncRecorder {
+	r := &asyncRecorder{slots: make([]ringSlot, size), mask: uint64(size - 1), wake: make(chan struct{}, 1)}
+
+	for i := range r.slots {
+		r.slots[i].sequence.Store(uint64(i))
+	}
+
+	return r
+}
+
+// record adds the sample to the buffer, and returns false if the buffer is full.
+func (r *asyncRecorder) record(s sample) bool {
+	for {
+		pos := r.tail.Load()
+		slot := &r.slots[pos&r.mask]
+
+		switch seq := slot.sequence.Load(); {
+		case seq == pos:
+			if r.tail.CompareAndSwap(pos, pos+1) {
+				slot.sample = s
+				slot.sequence.Store(pos + 1)
+
+				if (pos+1)&(r.mask>>1) == 0 {
+					select {
+					case r.wake <- struct{}{}:
+					default:
+					}
+				}
+
+				return true
+			}
+		case seq < pos:
+			// the slot has not been read since the buffer last went round.
+			return false
+		}
+	}
+}
+
+// drain records the samples of the buffer.
+func (r *asyncRecorder) drain() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for {
+		slot := &r.slots[r.head&r.mask]
+		if slot.sequence.Load() != r.head+1 {
+			return
+		}
+
+		s := slot.sample
+		slot.sample = sample{}
+		slot.sequence.Store(r.head + uint64(len(r.slots)))
+		r.head++
+
+		s.histogram.Record(s.ctx, s.value, metric.WithAttributes(s.attributes...))
+	}
+}
+
+func (r *asyncRecorder) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-r.wake:
+		}
+
+		r.drain()
+	}
+}
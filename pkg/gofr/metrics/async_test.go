@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/metrics/exporters"
+)
+
+// sumHistogram is a histogram which sums the values recorded in it.
+type sumHistogram struct {
+	embedded.Float64Histogram
+
+	mu    sync.Mutex
+	sum   float64
+	count int
+}
+
+func (h *sumHistogram) Record(_ context.Context, value float64, _ ...metric.RecordOption) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sum += value
+	h.count++
+}
+
+func TestAsyncRecorder(t *testing.T) {
+	r := newAsyncRecorder(4)
+	h := &sumHistogram{}
+
+	for i := 1; i <= 4; i++ {
+		assert.True(t, r.record(sample{ctx: context.Background(), histogram: h, value: float64(i)}))
+	}
+
+	// the buffer is full until it is drained.
+	assert.False(t, r.record(sample{ctx: context.Background(), histogram: h, value: 5}))
+	assert.Zero(t, h.count)
+
+	r.drain()
+
+	assert.Equal(t, 4, h.count)
+	assert.Equal(t, float64(10), h.sum)
+
+	// the buffer is used again once it is drained.
+	assert.True(t, r.record(sample{ctx: context.Background(), histogram: h, value: 5}))
+	r.drain()
+
+	assert.Equal(t, 5, h.count)
+	assert.Equal(t, float64(15), h.sum)
+}
+
+func TestAsyncRecorder_Concurrent(t *testing.T) {
+	r := newAsyncRecorder(64)
+	h := &sumHistogram{}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				// the sample is recorded synchronously when the buffer is full, like by the histogram handles.
+				if !r.record(sample{ctx: context.Background(), histogram: h, value: 1}) {
+					h.Record(context.Background(), 1)
+				}
+
+				if j%100 == 0 {
+					r.drain()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	r.drain()
+
+	assert.Equal(t, 8000, h.count)
+}
+
+func TestWithAsyncHistograms(t *testing.T) {
+	m := NewMetricsManager(exporters.Prometheus("async-app", "v1.0.0"), logging.NewMockLogger(logging.INFO),
+		WithAsyncHistograms())
+
+	m.NewHistogram("async-histogram", "this is metric to test histogram", 1, 10)
+	m.RecordHistogram(context.Background(), "async-histogram", 5, "path", "/orders")
+	m.Histogram("async-histogram").Record(context.Background(), 20, "path", "/orders")
+
+	// the buffered values are recorded when the metrics are exported.
+	body := scrape(t, m)
+
+	assert.Contains(t, body,
+		`async_histogram_bucket{otel_scope_name="async-app",otel_scope_version="v1.0.0",path="/orders",le="10"} 1`)
+	assert.Contains(t, body, `async_histogram_count{otel_scope_name="async-app",otel_scope_version="v1.0.0",path="/orders"} 2`)
+}
+
+func BenchmarkHistogram_Async(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []ManagerOption
+	}{
+		{"sync", nil},
+		{"async", []ManagerOption{WithAsyncHistograms()}},
+	} {
+		m := NewMetricsManager(exporters.Prometheus("async-"+bc.name, "v1.0.0"), logging.NewMockLogger(logging.INFO),
+			bc.opts...)
+		m.NewHistogram("async-histogram", "this is metric to test histogram")
+
+		histogram := m.Histogram("async-histogram")
+
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					histogram.Record(context.Background(), 1, "path", "/orders")
+				}
+			})
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package exporters
 
 import (
+	promclient "github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
@@ -11,8 +12,15 @@ import (
 	"github.com/peter-stratton/gofr/pkg/gofr/version"
 )
 
+// Prometheus returns a meter whose metrics are exported by the default registry of Prometheus.
 func Prometheus(appName, appVersion string) metric.Meter {
-	exporter, err := prometheus.New(prometheus.WithoutTargetInfo())
+	return PrometheusRegistry(appName, appVersion, promclient.DefaultRegisterer)
+}
+
+// PrometheusRegistry returns a meter whose metrics are exported by the registry, so that the applications of a process
+// do not share their metrics.
+func PrometheusRegistry(appName, appVersion string, registry promclient.Registerer) metric.Meter {
+	exporter, err := prometheus.New(prometheus.WithoutTargetInfo(), prometheus.WithRegisterer(registry))
 	if err != nil {
 		return nil
 	}
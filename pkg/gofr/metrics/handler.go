@@ -5,19 +5,35 @@ import (
 	"runtime"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/bufferpool"
 )
 
 // GetHandler creates a new HTTP handler that serves metrics collected by the provided metrics manager to '/metrics' route`.
 func GetHandler(m Manager) http.Handler {
 	var router = mux.NewRouter()
 
+	exporter := promhttp.Handler()
+
+	if mm, ok := m.(*metricsManager); ok && mm.registry != nil {
+		exporter = promhttp.InstrumentMetricHandler(mm.registry, promhttp.HandlerFor(mm.registry, promhttp.HandlerOpts{}))
+	}
+
 	// Prometheus
-	router.NewRoute().Methods(http.MethodGet).Path("/metrics").Handler(systemMetricsHandler(m, promhttp.Handler()))
+	router.NewRoute().Methods(http.MethodGet).Path("/metrics").Handler(systemMetricsHandler(m, exporter))
 
 	return router
 }
 
+// WithRegistry serves the metrics of the registry on /metrics, instead of those of the default registry of Prometheus.
+func WithRegistry(registry *prometheus.Registry) ManagerOption {
+	return func(m *metricsManager) {
+		m.registry = registry
+	}
+}
+
 func systemMetricsHandler(m Manager, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var stats runtime.MemStats
@@ -30,6 +46,16 @@ func systemMetricsHandler(m Manager, next http.Handler) http.Handler {
 		m.SetGauge("app_go_numGC", float64(stats.NumGC))
 		m.SetGauge("app_go_sys", float64(stats.Sys))
 
+		pool := bufferpool.ReadStats()
+
+		m.SetGauge("app_buffer_pool_in_use", float64(pool.InUse()))
+		m.SetGauge("app_buffer_pool_discarded", float64(pool.Discarded))
+
+		// the buffered values of the histograms are recorded before they are exported.
+		if mm, ok := m.(*metricsManager); ok && mm.async != nil {
+			mm.async.drain()
+		}
+
 		next.ServeHTTP(w, r)
 	})
 }
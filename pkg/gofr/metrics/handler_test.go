@@ -48,6 +48,8 @@ func Test_MetricsGetHandler_SystemMetricsRegistered(t *testing.T) {
 	manager.NewGauge("app_sys_total_alloc", "Number of cumulative bytes allocated for heap objects.")
 	manager.NewGauge("app_go_numGC", "Number of completed Garbage Collector cycles.")
 	manager.NewGauge("app_go_sys", "Number of total bytes of memory.")
+	manager.NewGauge("app_buffer_pool_in_use", "Number of buffers taken from the buffer pool which are not returned.")
+	manager.NewGauge("app_buffer_pool_discarded", "Number of buffers discarded by the buffer pool for their size.")
 
 	handler := GetHandler(manager)
 
@@ -69,4 +71,5 @@ func Test_MetricsGetHandler_SystemMetricsRegistered(t *testing.T) {
 	assert.Contains(t, bodyString, `app_sys_total_alloc{otel_scope_name="test-app",otel_scope_version="v1.0.0"}`)
 	assert.Contains(t, bodyString, `app_sys_total_alloc{otel_scope_name="test-app",otel_scope_version="v1.0.0"}`)
 	assert.Contains(t, bodyString, `app_go_numGC{otel_scope_name="test-app",otel_scope_version="v1.0.0"}`)
+	assert.Contains(t, bodyString, `app_buffer_pool_in_use{otel_scope_name="test-app",otel_scope_version="v1.0.0"}`)
 }
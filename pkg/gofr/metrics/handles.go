@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Counter is a handle to a counter, returned by Manager.Counter.
+type Counter interface {
+	// Increment increases the counter by 1, like Manager.IncrementCounter.
+	Increment(ctx context.Context, labels ...string)
+}
+
+// UpDownCounter is a handle to an up-down counter, returned by Manager.UpDownCounter.
+type UpDownCounter interface {
+	// Delta increases or decreases the counter by value, like Manager.DeltaUpDownCounter.
+	Delta(ctx context.Context, value float64, labels ...string)
+}
+
+// Histogram is a handle to a histogram, returned by Manager.Histogram.
+type Histogram interface {
+	// Record records the value in the histogram, like Manager.RecordHistogram.
+	Record(ctx context.Context, value float64, labels ...string)
+}
+
+// Gauge is a handle to a gauge, returned by Manager.Gauge.
+type Gauge interface {
+	// Set sets the gauge to value, like Manager.SetGauge.
+	Set(value float64, labels ...string)
+}
+
+// handle looks up the metric of its name once it is registered.
+type handle[T any] struct {
+	m        *metricsManager
+	name     string
+	lookup   func(name string) (T, error)
+	resolved atomic.Pointer[T]
+}
+
+// handleKey is the key of a handle in the handles of the manager, as a counter and a gauge can have the same name.
+type handleKey struct {
+	kind string
+	name string
+}
+
+// newHandle returns the handle of the manager to the metric of the kind and name, creating it on its first use.
+func newHandle[T any](m *metricsManager, kind, name string, lookup func(name string) (T, error)) *handle[T] {
+	key := handleKey{kind: kind, name: name}
+
+	if h, ok := m.handles.Load(key); ok {
+		return h.(*handle[T])
+	}
+
+	h, _ := m.handles.LoadOrStore(key, &handle[T]{m: m, name: name, lookup: lookup})
+
+	return h.(*handle[T])
+}
+
+func (h *handle[T]) get() (value T, ok bool) {
+	if p := h.resolved.Load(); p != nil {
+		return *p, true
+	}
+
+	value, err := h.lookup(h.name)
+	if err != nil {
+		h.m.logger.Error(err)
+
+		return value, false
+	}
+
+	h.resolved.Store(&value)
+
+	return value, true
+}
+
+// Counter returns a handle to the counter of the name.
+//
+//	Usage:
+//	requests := m.Counter("requests_total")
+//	requests.Increment(ctx, "path", "/orders")
+func (m *metricsManager) Counter(name string) Counter {
+	return counterHandle{newHandle(m, "counter", name, m.store.getCounter)}
+}
+
+// UpDownCounter returns a handle to the up-down counter of the name.
+func (m *metricsManager) UpDownCounter(name string) UpDownCounter {
+	return upDownCounterHandle{newHandle(m, "upDownCounter", name, m.store.getUpDownCounter)}
+}
+
+// Histogram returns a handle to the histogram of the name.
+//
+//	Usage:
+//	latency := m.Histogram("api_request_latency")
+//	latency.Record(ctx, 25.5, "path", "/orders")
+func (m *metricsManager) Histogram(name string) Histogram {
+	return histogramHandle{newHandle(m, "histogram", name, m.store.getHistogram)}
+}
+
+// Gauge returns a handle to the gauge of the name.
+func (m *metricsManager) Gauge(name string) Gauge {
+	return gaugeHandle{newHandle(m, "gauge", name, m.store.getGauge)}
+}
+
+type counterHandle struct {
+	*handle[metric.Int64Counter]
+}
+
+func (h counterHandle) Increment(ctx context.Context, labels ...string) {
+	if counter, ok := h.get(); ok {
+		counter.Add(ctx, 1, metric.WithAttributes(h.m.getAttributes(h.name, labels...)...))
+	}
+}
+
+type upDownCounterHandle struct {
+	*handle[metric.Float64UpDownCounter]
+}
+
+func (h upDownCounterHandle) Delta(ctx context.Context, value float64, labels ...string) {
+	if upDownCounter, ok := h.get(); ok {
+		upDownCounter.Add(ctx, value, metric.WithAttributes(h.m.getAttributes(h.name, labels...)...))
+	}
+}
+
+type histogramHandle struct {
+	*handle[metric.Float64Histogram]
+}
+
+func (h histogramHandle) Record(ctx context.Context, value float64, labels ...string) {
+	histogram, ok := h.get()
+	if !ok {
+		return
+	}
+
+	attributes := h.m.getAttributes(h.name, labels...)
+
+	// the value is recorded synchronously if the buffer of the async recorder is full.
+	if h.m.async != nil && h.m.async.record(sample{ctx: ctx, histogram: histogram, value: value, attributes: attributes}) {
+		return
+	}
+
+	histogram.Record(ctx, value, metric.WithAttributes(attributes...))
+}
+
+type gaugeHandle struct {
+	*handle[float64Gauge]
+}
+
+func (h gaugeHandle) Set(value float64, labels ...string) {
+	if gauge, ok := h.get(); ok {
+		gauge.set(value, attribute.NewSet(h.m.getAttributes(h.name, labels...)...))
+	}
+}
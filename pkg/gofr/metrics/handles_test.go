@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/metrics/exporters"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil/logtest"
+)
+
+func scrape(t *testing.T, m Manager) string {
+	t.Helper()
+
+	server := httptest.NewServer(GetHandler(m))
+	defer server.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/metrics", http.NoBody)
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	return string(body)
+}
+
+func TestHandles(t *testing.T) {
+	m := NewMetricsManager(exporters.Prometheus("handles-app", "v1.0.0"), logging.NewMockLogger(logging.INFO))
+
+	// the handles are created before their metrics are registered.
+	counter := m.Counter("handle-counter")
+	histogram := m.Histogram("handle-histogram")
+
+	m.NewCounter("handle-counter", "this is metric to test counter")
+	m.NewUpDownCounter("handle-up-down-counter", "this is metric to test up-down-counter")
+	m.NewHistogram("handle-histogram", "this is metric to test histogram", 1, 10)
+	m.NewGauge("handle-gauge", "this is metric to test gauge")
+
+	counter.Increment(context.Background(), "path", "/orders")
+	m.IncrementCounter(context.Background(), "handle-counter", "path", "/orders")
+	m.UpDownCounter("handle-up-down-counter").Delta(context.Background(), 10)
+	histogram.Record(context.Background(), 5)
+	m.Gauge("handle-gauge").Set(50)
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `handle_counter_total{otel_scope_name="handles-app",otel_scope_version="v1.0.0",path="/orders"} 2`)
+	assert.Contains(t, body, `handle_up_down_counter{otel_scope_name="handles-app",otel_scope_version="v1.0.0"} 10`)
+	assert.Contains(t, body, `handle_histogram_bucket{otel_scope_name="handles-app",otel_scope_version="v1.0.0",le="10"} 1`)
+	assert.Contains(t, body, `handle_gauge{otel_scope_name="handles-app",otel_scope_version="v1.0.0"} 50`)
+}
+
+func TestHandles_Cached(t *testing.T) {
+	m := NewMetricsManager(exporters.Prometheus("handles-app", "v1.0.0"), logging.NewMockLogger(logging.INFO))
+
+	m.NewCounter("handle-metric", "this is metric to test counter")
+	m.NewGauge("handle-metric", "this is metric to test gauge")
+
+	assert.Same(t, m.Counter("handle-metric").(counterHandle).handle, m.Counter("handle-metric").(counterHandle).handle)
+	assert.NotSame(t, m.Counter("handle-other").(counterHandle).handle, m.Counter("handle-metric").(counterHandle).handle)
+	assert.Same(t, m.Gauge("handle-metric").(gaugeHandle).handle, m.Gauge("handle-metric").(gaugeHandle).handle)
+}
+
+func TestHandles_NotRegistered(t *testing.T) {
+	logs := logtest.NewLogRecorder()
+	m := NewMetricsManager(exporters.Prometheus("handles-app", "v1.0.0"), logs)
+
+	m.Counter("handle-counter").Increment(context.Background())
+	m.UpDownCounter("handle-up-down-counter").Delta(context.Background(), 10)
+	m.Histogram("handle-histogram").Record(context.Background(), 1)
+	m.Gauge("handle-gauge").Set(50)
+
+	logs.AssertContains(t, logging.ERROR, "Metrics handle-counter is not registered")
+	logs.AssertContains(t, logging.ERROR, "Metrics handle-up-down-counter is not registered")
+	logs.AssertContains(t, logging.ERROR, "Metrics handle-histogram is not registered")
+	logs.AssertContains(t, logging.ERROR, "Metrics handle-gauge is not registered")
+}
+
+func BenchmarkRecordHistogram(b *testing.B) {
+	m := NewMetricsManager(exporters.Prometheus("handles-app", "v1.0.0"), logging.NewMockLogger(logging.INFO))
+	m.NewHistogram("handle-histogram", "this is metric to test histogram")
+
+	histogram := m.Histogram("handle-histogram")
+
+	b.Run("name", func(b *testing.B) {
+		b.ReportAllocs()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				m.RecordHistogram(context.Background(), "handle-histogram", 1, "path", "/orders")
+			}
+		})
+	})
+
+	b.Run("handle", func(b *testing.B) {
+		b.ReportAllocs()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				histogram.Record(context.Background(), 1, "path", "/orders")
+			}
+		})
+	})
+}
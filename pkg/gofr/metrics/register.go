@@ -2,7 +2,9 @@ package metrics
 
 import (
 	"context"
+	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
@@ -22,6 +24,12 @@ type Manager interface {
 	DeltaUpDownCounter(ctx context.Context, name string, value float64, labels ...string)
 	RecordHistogram(ctx context.Context, name string, value float64, labels ...string)
 	SetGauge(name string, value float64, labels ...string)
+
+	// Counter, UpDownCounter, Histogram and Gauge return a handle to the metric of the name, for the hot paths.
+	Counter(name string) Counter
+	UpDownCounter(name string) UpDownCounter
+	Histogram(name string) Histogram
+	Gauge(name string) Gauge
 }
 
 // Logger defines a simple interface for logging messages at different log levels.
@@ -36,22 +44,39 @@ type metricsManager struct {
 	meter  metric.Meter
 	store  Store
 	logger Logger
+
+	// handles are the handles to the metrics by their kind and name.
+	handles sync.Map
+
+	// async records the values of the histograms in the background if it is set by WithAsyncHistograms.
+	async *asyncRecorder
+
+	// registry is the registry whose metrics are served on /metrics if it is set by WithRegistry.
+	registry *prometheus.Registry
 }
 
 // Developer Note: float64Gauge is used instead of metric.Float64ObservableGauge because we need a synchronous gauge metric
 // and otel/metric supports only asynchronous gauge (Float64ObservableGauge).
 // And if we use the otel/metric, we would not be able to have support for labels, Hence created a custom type to implement it.
 type float64Gauge struct {
+	// mu guards the observations, which are set while they are observed by the exporter.
+	mu           *sync.RWMutex
 	observations map[attribute.Set]float64
 }
 
 // NewMetricsManager creates a new metrics manager instance with the provided metric  meter and logger.
-func NewMetricsManager(meter metric.Meter, logger Logger) Manager {
-	return &metricsManager{
+func NewMetricsManager(meter metric.Meter, logger Logger, opts ...ManagerOption) Manager {
+	m := &metricsManager{
 		meter:  meter,
 		store:  newOtelStore(),
 		logger: logger,
 	}
+
+	for _, o := range opts {
+		o(m)
+	}
+
+	return m
 }
 
 // Developer Note : we are not checking the name or desc parameter because the OTEL
@@ -127,7 +152,7 @@ func (m *metricsManager) NewHistogram(name, desc string, buckets ...float64) {
 //	Usage:
 //	m.NewGauge("memory_usage", "Current memory usage in bytes")
 func (m *metricsManager) NewGauge(name, desc string) {
-	gauge := float64Gauge{observations: make(map[attribute.Set]float64)}
+	gauge := float64Gauge{mu: &sync.RWMutex{}, observations: make(map[attribute.Set]float64)}
 
 	_, err := m.meter.Float64ObservableGauge(name, metric.WithDescription(desc), metric.WithFloat64Callback(gauge.callbackFunc))
 	if err != nil {
@@ -145,6 +170,9 @@ func (m *metricsManager) NewGauge(name, desc string) {
 // callbackFunc implements the callback function for the underlying asynchronous gauge
 // it observes the current state of all previous set() calls.
 func (f *float64Gauge) callbackFunc(_ context.Context, o metric.Float64Observer) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
 	for attrs, val := range f.observations {
 		o.Observe(val, metric.WithAttributeSet(attrs))
 	}
@@ -168,14 +196,7 @@ func (f *float64Gauge) callbackFunc(_ context.Context, o metric.Float64Observer)
 // For example, "label1", "value1", "label2", "value2". Labels allow you to segment and filter your metrics
 // based on different dimensions.
 func (m *metricsManager) IncrementCounter(ctx context.Context, name string, labels ...string) {
-	counter, err := m.store.getCounter(name)
-	if err != nil {
-		m.logger.Error(err)
-
-		return
-	}
-
-	counter.Add(ctx, 1, metric.WithAttributes(m.getAttributes(name, labels...)...))
+	m.Counter(name).Increment(ctx, labels...)
 }
 
 // DeltaUpDownCounter increases or decreases the last value with the value specified.
@@ -193,14 +214,7 @@ func (m *metricsManager) IncrementCounter(ctx context.Context, name string, labe
 // successful login attempts. Labels can provide additional context, such as the method and endpoint of the request,
 // allowing you to analyze metrics based on different dimensions.
 func (m *metricsManager) DeltaUpDownCounter(ctx context.Context, name string, value float64, labels ...string) {
-	upDownCounter, err := m.store.getUpDownCounter(name)
-	if err != nil {
-		m.logger.Error(err)
-
-		return
-	}
-
-	upDownCounter.Add(ctx, value, metric.WithAttributes(m.getAttributes(name, labels...)...))
+	m.UpDownCounter(name).Delta(ctx, value, labels...)
 }
 
 // RecordHistogram records the specified value in the respective buckets of the histogram metric.
@@ -213,14 +227,7 @@ func (m *metricsManager) DeltaUpDownCounter(ctx context.Context, name string, va
 //	    // Record the latency of an API request with labels.
 //	 2. m.RecordHistogram(ctx, "api_request_latency", 25.5, "label1", "value1", "label2", "value2")
 func (m *metricsManager) RecordHistogram(ctx context.Context, name string, value float64, labels ...string) {
-	histogram, err := m.store.getHistogram(name)
-	if err != nil {
-		m.logger.Error(err)
-
-		return
-	}
-
-	histogram.Record(ctx, value, metric.WithAttributes(m.getAttributes(name, labels...)...))
+	m.Histogram(name).Record(ctx, value, labels...)
 }
 
 // SetGauge gets the value and sets the metric to the specified value.
@@ -231,17 +238,13 @@ func (m *metricsManager) RecordHistogram(ctx context.Context, name string, value
 //	manager.SetGauge("memory_usage", 1024*1024*100)
 //	// Set memory usage to 100 MB
 func (m *metricsManager) SetGauge(name string, value float64, labels ...string) {
-	gauge, err := m.store.getGauge(name)
-	if err != nil {
-		m.logger.Error(err)
-
-		return
-	}
-
-	gauge.set(value, attribute.NewSet(m.getAttributes(name, labels...)...))
+	m.Gauge(name).Set(value, labels...)
 }
 
 func (f *float64Gauge) set(val float64, attrs attribute.Set) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	f.observations[attrs] = val
 }
 
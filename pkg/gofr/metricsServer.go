@@ -1,34 +1,109 @@
 package gofr
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/gorilla/mux"
+
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
 	"github.com/peter-stratton/gofr/pkg/gofr/metrics"
 )
 
 type metricServer struct {
 	port int
+
+	// routes are the admin endpoints served on the metrics port alongside the metrics.
+	routes []adminRoute
+
+	// srv is the running server, which is guarded by mu as it is shut down from another goroutine.
+	srv    *http.Server
+	closed bool
+	mu     sync.Mutex
+}
+
+type adminRoute struct {
+	method  string
+	path    string
+	handler http.Handler
 }
 
 func newMetricServer(port int) *metricServer {
 	return &metricServer{port: port}
 }
 
+// handle serves the admin endpoint on the given method and path.
+func (m *metricServer) handle(method, path string, h http.Handler) {
+	if m == nil {
+		return
+	}
+
+	m.routes = append(m.routes, adminRoute{method: method, path: path, handler: h})
+}
+
 func (m *metricServer) Run(c *container.Container) {
-	var srv *http.Server
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+
+	c.Logf("Starting metrics server on port: %d", m.port)
 
-	if m != nil {
-		c.Logf("Starting metrics server on port: %d", m.port)
+	m.srv = &http.Server{
+		Addr:              fmt.Sprintf(":%d", m.port),
+		Handler:           m.handler(c),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
 
-		srv = &http.Server{
-			Addr:              fmt.Sprintf(":%d", m.port),
-			Handler:           metrics.GetHandler(c.Metrics()),
-			ReadHeaderTimeout: 5 * time.Second,
-		}
+	srv := m.srv
+	m.mu.Unlock()
 
-		c.Error(srv.ListenAndServe())
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		c.Error(err)
 	}
 }
+
+// Shutdown stops the server gracefully, until ctx is done.
+func (m *metricServer) Shutdown(ctx context.Context) error {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+
+	if m.srv == nil {
+		return nil
+	}
+
+	return m.srv.Shutdown(ctx)
+}
+
+func (m *metricServer) handler(c *container.Container) http.Handler {
+	handler := metrics.GetHandler(c.Metrics())
+	if len(m.routes) == 0 {
+		return handler
+	}
+
+	router := mux.NewRouter()
+
+	for _, r := range m.routes {
+		router.NewRoute().Methods(r.method).Path(r.path).Handler(r.handler)
+	}
+
+	router.NotFoundHandler = handler
+
+	return router
+}
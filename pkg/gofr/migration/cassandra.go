@@ -0,0 +1,97 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+const (
+	createCassandraGoFrMigrationsTable = `CREATE TABLE IF NOT EXISTS gofr_migrations (version bigint PRIMARY KEY, method text,
+start_time timestamp, duration bigint)`
+
+	getCassandraGoFrMigrations = "SELECT version, method FROM gofr_migrations"
+
+	insertCassandraGoFrMigrationRow = "INSERT INTO gofr_migrations (version, method, start_time, duration) VALUES (?, ?, ?, ?)"
+)
+
+type cassandraMigration struct {
+	Version int64  `cql:"version"`
+	Method  string `cql:"method"`
+}
+
+type cassandraMigratorObject struct {
+	datasource.Cassandra
+}
+
+type cassandraMigrator struct {
+	datasource.Cassandra
+
+	Migrator
+}
+
+func (s cassandraMigratorObject) apply(m Migrator) Migrator {
+	return cassandraMigrator{
+		Cassandra: s.Cassandra,
+		Migrator:  m,
+	}
+}
+
+func (d cassandraMigrator) checkAndCreateMigrationTable(c *container.Container) error {
+	if err := d.Cassandra.Exec(context.Background(), createCassandraGoFrMigrationsTable); err != nil {
+		return err
+	}
+
+	return d.Migrator.checkAndCreateMigrationTable(c)
+}
+
+func (d cassandraMigrator) getLastMigration(c *container.Container) int64 {
+	var (
+		lastMigration int64
+		migrations    []cassandraMigration
+	)
+
+	err := d.Cassandra.Query(context.Background(), &migrations, getCassandraGoFrMigrations)
+	if err != nil {
+		c.Logger.Errorf("failed to get migration record from Cassandra. err: %v", err)
+
+		return -1
+	}
+
+	for _, m := range migrations {
+		// the migrations which were reverted are run again.
+		if m.Method != methodDOWN && m.Version > lastMigration {
+			lastMigration = m.Version
+		}
+	}
+
+	c.Debugf("Cassandra last migration fetched value is: %v", lastMigration)
+
+	last := d.Migrator.getLastMigration(c)
+	if last > lastMigration {
+		return last
+	}
+
+	return lastMigration
+}
+
+// commitMigration records the migration in gofr_migrations. Cassandra has no transactions.
+func (d cassandraMigrator) commitMigration(c *container.Container, data migrationData) error {
+	err := d.Cassandra.Exec(context.Background(), insertCassandraGoFrMigrationRow, data.MigrationNumber, data.Method,
+		data.StartTime, time.Since(data.StartTime).Milliseconds())
+	if err != nil {
+		c.Logger.Errorf("migration %v for Cassandra failed with err: %v", data.MigrationNumber, err)
+
+		return err
+	}
+
+	return d.Migrator.commitMigration(c, data)
+}
+
+func (d cassandraMigrator) rollback(c *container.Container, data migrationData) {
+	c.Errorf("migration %v failed, the changes made to Cassandra are not rolled back", data.MigrationNumber)
+
+	d.Migrator.rollback(c, data)
+}
@@ -0,0 +1,60 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil/logtest"
+)
+
+var errCassandra = errors.New("no hosts available in the pool")
+
+func TestCassandraMigrator(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+	c.SQL, c.Redis = nil, nil
+
+	mocks.Cassandra.EXPECT().Exec(gomock.Any(), createCassandraGoFrMigrationsTable).Return(nil)
+	mocks.Cassandra.EXPECT().Query(gomock.Any(), gomock.Any(), getCassandraGoFrMigrations).
+		DoAndReturn(func(_ context.Context, dest interface{}, _ string, _ ...interface{}) error {
+			*dest.(*[]cassandraMigration) = []cassandraMigration{{Version: 1, Method: methodUP}, {Version: 2, Method: methodDOWN}}
+
+			return nil
+		})
+	mocks.Cassandra.EXPECT().Exec(gomock.Any(), "CREATE TABLE orders (id uuid PRIMARY KEY)").Return(nil)
+	mocks.Cassandra.EXPECT().Exec(gomock.Any(), insertCassandraGoFrMigrationRow, int64(2), methodUP, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	Run(map[int64]Migrate{
+		1: {UP: func(Datasource) error { return errCassandra }},
+		2: {UP: func(d Datasource) error {
+			return d.Cassandra.Exec(context.Background(), "CREATE TABLE orders (id uuid PRIMARY KEY)")
+		}},
+	}, c)
+}
+
+func TestCassandraMigrator_Errors(t *testing.T) {
+	logs := logtest.NewLogRecorder()
+
+	c, mocks := container.NewMockContainer(t)
+	c.Logger = logs
+
+	mocks.Cassandra.EXPECT().Query(gomock.Any(), gomock.Any(), getCassandraGoFrMigrations).Return(errCassandra)
+	mocks.Cassandra.EXPECT().Exec(gomock.Any(), insertCassandraGoFrMigrationRow, gomock.Any()).Return(errCassandra)
+
+	mg := cassandraMigratorObject{mocks.Cassandra}.apply(Datasource{})
+
+	assert.Equal(t, int64(-1), mg.getLastMigration(c))
+	assert.Equal(t, errCassandra, mg.commitMigration(c, migrationData{MigrationNumber: 4, Method: methodUP}))
+
+	mg.rollback(c, migrationData{MigrationNumber: 4})
+
+	logs.AssertContains(t, logging.ERROR, "failed to get migration record from Cassandra. err: no hosts available in the pool")
+	logs.AssertContains(t, logging.ERROR, "migration 4 for Cassandra failed with err: no hosts available in the pool")
+	logs.AssertContains(t, logging.ERROR, "migration 4 failed, the changes made to Cassandra are not rolled back")
+}
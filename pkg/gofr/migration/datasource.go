@@ -6,15 +6,18 @@ import (
 	goRedis "github.com/redis/go-redis/v9"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
 	gofrSql "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
 )
 
 type Datasource struct {
 	Logger
 
-	SQL    db
-	Redis  commands
-	PubSub client
+	SQL       db
+	Redis     commands
+	Mongo     mongoDB
+	Cassandra datasource.Cassandra
+	PubSub    client
 }
 
 type Migrator interface {
@@ -44,6 +47,12 @@ func (d Datasource) beginTransaction(*container.Container) migrationData {
 }
 
 func (d Datasource) commitMigration(c *container.Container, data migrationData) error {
+	if data.Method == methodDOWN {
+		c.Infof("Migration %v reverted successfully", data.MigrationNumber)
+
+		return nil
+	}
+
 	c.Infof("Migration %v ran successfully", data.MigrationNumber)
 
 	return nil
@@ -54,6 +63,8 @@ func (d Datasource) rollback(*container.Container, migrationData) {}
 type migrationData struct {
 	StartTime       time.Time
 	MigrationNumber int64
+	// Method is the direction of the migration, UP or DOWN.
+	Method string
 
 	SQLTx   *gofrSql.Tx
 	RedisTx goRedis.Pipeliner
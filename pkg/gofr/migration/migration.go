@@ -13,8 +13,16 @@ type MigrateFunc func(d Datasource) error
 
 type Migrate struct {
 	UP MigrateFunc
+	// DOWN reverses the changes of UP. It is optional, and run by Down and migrationtest.Run.
+	DOWN MigrateFunc
 }
 
+// The directions of the migrations recorded in gofr_migrations.
+const (
+	methodUP   = "UP"
+	methodDOWN = "DOWN"
+)
+
 func Run(migrationsMap map[int64]Migrate, c *container.Container) {
 	invalidKeys, keys := getKeys(migrationsMap)
 	if len(invalidKeys) > 0 {
@@ -51,33 +59,97 @@ func Run(migrationsMap map[int64]Migrate, c *container.Container) {
 
 		c.Logger.Debugf("running migration %v", currentMigration)
 
-		transactionsObjects := mg.beginTransaction(c)
+		if !runMigration(c, ds, mg, currentMigration, methodUP, migrationsMap[currentMigration].UP) {
+			return
+		}
+	}
+}
 
-		ds.SQL = newMysql(transactionsObjects.SQLTx)
-		ds.Redis = newRedis(transactionsObjects.RedisTx)
-		ds.PubSub = newPubSub(c.PubSub)
+// Down reverts the migrations run after toVersion, from the latest to the first, each in its own transaction.
+func Down(migrationsMap map[int64]Migrate, toVersion int64, c *container.Container) {
+	ds, mg, ok := getMigrator(c)
+	if !ok {
+		c.Errorf("no migrations are reverted as datasources are not initialized")
 
-		transactionsObjects.StartTime = time.Now()
-		transactionsObjects.MigrationNumber = currentMigration
+		return
+	}
 
-		err = migrationsMap[currentMigration].UP(ds)
-		if err != nil {
-			mg.rollback(c, transactionsObjects)
+	err := mg.checkAndCreateMigrationTable(c)
+	if err != nil {
+		c.Errorf("failed to create gofr_migration table, err: %v", err)
 
-			return
+		return
+	}
+
+	lastMigration := mg.getLastMigration(c)
+
+	keys := make([]int64, 0, len(migrationsMap))
+	invalidKeys := make([]int64, 0, len(migrationsMap))
+
+	for k, v := range migrationsMap {
+		if k <= toVersion || k > lastMigration {
+			continue
 		}
 
-		err = mg.commitMigration(c, transactionsObjects)
-		if err != nil {
-			c.Errorf("failed to commit migration, err: %v", err)
+		if v.DOWN == nil {
+			invalidKeys = append(invalidKeys, k)
+
+			continue
+		}
+
+		keys = append(keys, k)
+	}
+
+	if len(invalidKeys) > 0 {
+		sortkeys.Int64s(invalidKeys)
+
+		c.Errorf("migration rollback failed! DOWN not defined for the following keys: %v", invalidKeys)
+
+		return
+	}
+
+	sortkeys.Int64s(keys)
 
-			mg.rollback(c, transactionsObjects)
+	for i := len(keys) - 1; i >= 0; i-- {
+		c.Logger.Debugf("reverting migration %v", keys[i])
 
+		if !runMigration(c, ds, mg, keys[i], methodDOWN, migrationsMap[keys[i]].DOWN) {
 			return
 		}
 	}
 }
 
+// runMigration runs the UP or the DOWN of a migration in a transaction, and returns whether it was committed.
+func runMigration(c *container.Container, ds Datasource, mg Migrator, version int64, method string, f MigrateFunc) bool {
+	transactionsObjects := mg.beginTransaction(c)
+
+	ds.SQL = newMysql(transactionsObjects.SQLTx)
+	ds.Redis = newRedis(transactionsObjects.RedisTx)
+	ds.PubSub = newPubSub(c.PubSub)
+
+	transactionsObjects.StartTime = time.Now()
+	transactionsObjects.MigrationNumber = version
+	transactionsObjects.Method = method
+
+	err := f(ds)
+	if err != nil {
+		mg.rollback(c, transactionsObjects)
+
+		return false
+	}
+
+	err = mg.commitMigration(c, transactionsObjects)
+	if err != nil {
+		c.Errorf("failed to commit migration, err: %v", err)
+
+		mg.rollback(c, transactionsObjects)
+
+		return false
+	}
+
+	return true
+}
+
 func getKeys(migrationsMap map[int64]Migrate) (invalidKey, keys []int64) {
 	invalidKey = make([]int64, 0, len(migrationsMap))
 	keys = make([]int64, 0, len(migrationsMap))
@@ -118,6 +190,22 @@ func getMigrator(c *container.Container) (Datasource, Migrator, bool) {
 		mg = redisMigratorObject{ds.Redis}.apply(mg)
 	}
 
+	if m, isMongo := c.Mongo.(mongoDB); isMongo && !isNil(c.Mongo) {
+		ok = true
+
+		ds.Mongo = m
+
+		mg = mongoMigratorObject{ds.Mongo}.apply(mg)
+	}
+
+	if !isNil(c.Cassandra) {
+		ok = true
+
+		ds.Cassandra = c.Cassandra
+
+		mg = cassandraMigratorObject{ds.Cassandra}.apply(mg)
+	}
+
 	if c.PubSub != nil {
 		ok = true
 	}
@@ -1,13 +1,21 @@
 package migration
 
 import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
 	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil/logtest"
 )
 
 func TestMigration_InvalidKeys(t *testing.T) {
@@ -49,6 +57,7 @@ func Test_getMigratorDBInitialisation(t *testing.T) {
 
 	assert.NotNil(t, datasource.SQL, "TEST Failed \nSQL not initialized, but should have been initialized")
 	assert.NotNil(t, datasource.Redis, "TEST Failed \nRedis not initialized, but should have been initialized")
+	assert.NotNil(t, datasource.Cassandra, "TEST Failed \nCassandra not initialized, but should have been initialized")
 	assert.Equal(t, true, isInitialised, "TEST Failed \nNo datastores are Initialized")
 }
 
@@ -57,6 +66,8 @@ func Test_getMigratorDatastoreNotInitialised(t *testing.T) {
 		container, _ := container.NewMockContainer(t)
 		container.SQL = nil
 		container.Redis = nil
+		container.Cassandra = nil
+		container.PubSub = nil
 
 		datasource, _, isInitialised := getMigrator(container)
 
@@ -72,3 +83,133 @@ func Test_getMigratorDatastoreNotInitialised(t *testing.T) {
 
 	assert.Contains(t, logs, "Migration 0 ran successfully", "TEST Failed")
 }
+
+func TestDown(t *testing.T) {
+	c := container.NewContainer(nil)
+	c.Logger = logging.NewMockLogger(logging.ERROR)
+	c.Create(config.NewMockConfig(map[string]string{
+		"DB_DIALECT": "sqlite",
+		"DB_NAME":    filepath.Join(t.TempDir(), "migrations"),
+	}))
+
+	require.NotNil(t, c.SQL)
+
+	table := func(name string) Migrate {
+		return Migrate{
+			UP: func(d Datasource) error {
+				_, err := d.SQL.Exec("CREATE TABLE " + name + " (id INTEGER)")
+				return err
+			},
+			DOWN: func(d Datasource) error {
+				_, err := d.SQL.Exec("DROP TABLE " + name)
+				return err
+			},
+		}
+	}
+
+	migrations := map[int64]Migrate{1: table("orders"), 2: table("payments"), 3: table("refunds")}
+
+	Run(migrations, c)
+	Down(migrations, 1, c)
+
+	assert.Equal(t, []string{"1 UP", "2 DOWN", "3 DOWN"}, recordedMigrations(t, c))
+	assert.Equal(t, []string{"orders"}, tables(t, c))
+
+	Run(migrations, c)
+
+	assert.Equal(t, []string{"1 UP", "2 UP", "3 UP"}, recordedMigrations(t, c))
+	assert.Equal(t, []string{"orders", "payments", "refunds"}, tables(t, c))
+}
+
+func TestDown_NoDOWN(t *testing.T) {
+	logs := logtest.NewLogRecorder()
+
+	c, mocks := container.NewMockContainer(t)
+	c.Redis = nil
+	c.Cassandra = nil
+	c.Logger = logs
+
+	mocks.SQL.EXPECT().Dialect().Return("mysql")
+	mocks.SQL.EXPECT().Exec(createSQLGoFrMigrationsTable).Return(nil, nil)
+	mocks.SQL.EXPECT().QueryRowContext(gomock.Any(), getLastSQLGoFrMigration).Return(lastMigrationRow(t, 3))
+
+	up := func(Datasource) error { return nil }
+
+	Down(map[int64]Migrate{1: {UP: up}, 2: {UP: up}, 3: {UP: up, DOWN: up}}, 0, c)
+
+	logs.AssertContains(t, logging.ERROR, "migration rollback failed! DOWN not defined for the following keys: [1 2]")
+}
+
+func TestDown_NoDatasource(t *testing.T) {
+	logs := logtest.NewLogRecorder()
+
+	c := container.NewContainer(nil)
+	c.Logger = logs
+
+	Down(map[int64]Migrate{}, 0, c)
+
+	logs.AssertContains(t, logging.ERROR, "no migrations are reverted")
+}
+
+// lastMigrationRow returns a row with the version of the last migration.
+func lastMigrationRow(t *testing.T, version int64) *sql.Row {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	t.Cleanup(func() { db.Close() })
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+
+	return db.QueryRow("SELECT")
+}
+
+func recordedMigrations(t *testing.T, c *container.Container) []string {
+	t.Helper()
+
+	rows, err := c.SQL.Query("SELECT version, method FROM gofr_migrations ORDER BY version")
+	require.NoError(t, err)
+
+	defer rows.Close()
+
+	var recorded []string
+
+	for rows.Next() {
+		var (
+			version int64
+			method  string
+		)
+
+		require.NoError(t, rows.Scan(&version, &method))
+
+		recorded = append(recorded, fmt.Sprintf("%d %s", version, method))
+	}
+
+	require.NoError(t, rows.Err())
+
+	return recorded
+}
+
+func tables(t *testing.T, c *container.Container) []string {
+	t.Helper()
+
+	rows, err := c.SQL.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name != 'gofr_migrations' ORDER BY name")
+	require.NoError(t, err)
+
+	defer rows.Close()
+
+	var names []string
+
+	for rows.Next() {
+		var name string
+
+		require.NoError(t, rows.Scan(&name))
+
+		names = append(names, name)
+	}
+
+	require.NoError(t, rows.Err())
+
+	return names
+}
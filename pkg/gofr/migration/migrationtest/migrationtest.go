@@ -0,0 +1,249 @@
+// Package migrationtest verifies that the migrations of an application run, and that their DOWN reverse their UP.
+//
+//	func TestMigrations(t *testing.T) {
+//		migrationtest.Run(t, migrations.All(), migrationtest.SQLite)
+//	}
+package migrationtest
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/gofrtest/containers"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/migration"
+)
+
+// The dialects of the test databases. Postgres and MySQL run in docker, and are skipped without it.
+const (
+	SQLite   = "sqlite"
+	Postgres = "postgres"
+	MySQL    = "mysql"
+)
+
+// schemaQueries list the columns and the indexes of the tables of each dialect.
+//
+//nolint:gochecknoglobals // the queries are constant, but a map can not be declared as a constant.
+var schemaQueries = map[string]string{
+	SQLite: `SELECT type || ' ' || name || ' ' || COALESCE(sql, '') FROM sqlite_master
+		WHERE name NOT LIKE 'sqlite_%' AND tbl_name != 'gofr_migrations'`,
+	Postgres: `SELECT 'column ' || table_name || '.' || column_name || ' ' || data_type || ' ' || is_nullable || ' ' ||
+		COALESCE(column_default, '') FROM information_schema.columns
+		WHERE table_schema = 'public' AND table_name != 'gofr_migrations'
+		UNION ALL SELECT 'index ' || tablename || ' ' || indexdef FROM pg_indexes
+		WHERE schemaname = 'public' AND tablename != 'gofr_migrations'`,
+	MySQL: `SELECT CONCAT('column ', table_name, '.', column_name, ' ', column_type, ' ', is_nullable, ' ',
+		COALESCE(column_default, '')) FROM information_schema.columns
+		WHERE table_schema = DATABASE() AND table_name != 'gofr_migrations'
+		UNION ALL SELECT CONCAT('index ', table_name, ' ', index_name, ' ', column_name, ' ', seq_in_index)
+		FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name != 'gofr_migrations'`,
+}
+
+// Run runs the migrations against an empty database of the dialect, and checks that each DOWN restores the schema.
+func Run(t testing.TB, migrations map[int64]migration.Migrate, dialect string) {
+	t.Helper()
+
+	if _, ok := schemaQueries[dialect]; !ok {
+		t.Fatalf("unsupported dialect %q; supported dialects are - %s, %s, %s", dialect, SQLite, Postgres, MySQL)
+	}
+
+	c := newContainer(t, dialect)
+
+	initial := schema(t, c)
+
+	migration.Run(migrations, c)
+
+	if t.Failed() {
+		return
+	}
+
+	versions := make([]int64, 0, len(migrations))
+	for v := range migrations {
+		versions = append(versions, v)
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	assertRecorded(t, c, versions)
+	assertReversible(t, c, migrations, versions, initial)
+}
+
+func newContainer(t testing.TB, dialect string) *container.Container {
+	t.Helper()
+
+	conf := map[string]string{"DB_DIALECT": SQLite, "DB_NAME": filepath.Join(t.TempDir(), "migrationtest")}
+
+	switch dialect {
+	case Postgres:
+		conf = containers.Run(t, containers.Postgres()).Config()
+	case MySQL:
+		conf = containers.Run(t, containers.MySQL()).Config()
+	}
+
+	c := container.NewContainer(nil)
+	c.Logger = testLogger{Logger: logging.NewLogger(logging.INFO), t: t}
+	c.Create(config.NewMockConfig(conf))
+
+	if c.SQL == nil {
+		t.Fatalf("could not connect to the %s database", dialect)
+	}
+
+	if db, ok := c.SQL.(io.Closer); ok {
+		t.Cleanup(func() { _ = db.Close() })
+	}
+
+	return c
+}
+
+// assertRecorded checks that each migration is recorded once in gofr_migrations, as run UP.
+func assertRecorded(t testing.TB, c *container.Container, versions []int64) {
+	t.Helper()
+
+	rows, err := c.SQL.Query("SELECT version, method FROM gofr_migrations ORDER BY version")
+	if err != nil {
+		t.Errorf("could not read gofr_migrations: %v", err)
+		return
+	}
+
+	defer rows.Close()
+
+	expected := make([]string, 0, len(versions))
+	for _, v := range versions {
+		expected = append(expected, fmt.Sprintf("%d UP", v))
+	}
+
+	recorded := make([]string, 0, len(versions))
+
+	for rows.Next() {
+		var (
+			version int64
+			method  string
+		)
+
+		if err := rows.Scan(&version, &method); err != nil {
+			t.Errorf("could not read gofr_migrations: %v", err)
+			return
+		}
+
+		recorded = append(recorded, fmt.Sprintf("%d %s", version, method))
+	}
+
+	if !reflect.DeepEqual(expected, recorded) {
+		t.Errorf("gofr_migrations should record the migrations\nexpected: %v\nrecorded: %v", expected, recorded)
+	}
+}
+
+// assertReversible checks that the DOWN of each migration restores the schema before its UP.
+func assertReversible(t testing.TB, c *container.Container, migrations map[int64]migration.Migrate, versions []int64,
+	initial []string) {
+	t.Helper()
+
+	migrated := schema(t, c)
+	before := make(map[int64][]string, len(versions))
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		v := versions[i]
+
+		if migrations[v].DOWN == nil {
+			t.Errorf("migration %d has no DOWN to revert it", v)
+			return
+		}
+
+		if err := runInTx(c, migrations[v].DOWN); err != nil {
+			t.Errorf("DOWN of migration %d failed: %v", v, err)
+			return
+		}
+
+		before[v] = schema(t, c)
+	}
+
+	// the schema before the UP of a migration is the one after the UP of the previous one.
+	beforeUP := initial
+
+	for _, v := range versions {
+		if !reflect.DeepEqual(beforeUP, before[v]) {
+			t.Errorf("DOWN of migration %d should revert the schema to before its UP\nexpected: %v\nactual: %v",
+				v, beforeUP, before[v])
+		}
+
+		if err := runInTx(c, migrations[v].UP); err != nil {
+			t.Errorf("UP of migration %d failed after its DOWN: %v", v, err)
+			return
+		}
+
+		beforeUP = schema(t, c)
+	}
+
+	if current := schema(t, c); !reflect.DeepEqual(migrated, current) {
+		t.Errorf("migrations should have the same schema when run again after their DOWN\nexpected: %v\nactual: %v",
+			migrated, current)
+	}
+}
+
+// runInTx runs the UP or DOWN of a migration in a transaction, like migration.Run does.
+func runInTx(c *container.Container, f migration.MigrateFunc) error {
+	tx, err := c.SQL.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := f(migration.Datasource{Logger: c.Logger, SQL: tx}); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// schema returns the columns and indexes of the tables of the database, sorted.
+func schema(t testing.TB, c *container.Container) []string {
+	t.Helper()
+
+	rows, err := c.SQL.Query(schemaQueries[c.SQL.Dialect()])
+	if err != nil {
+		t.Fatalf("could not read the schema of the database: %v", err)
+	}
+
+	defer rows.Close()
+
+	var s []string
+
+	for rows.Next() {
+		var entry string
+
+		if err := rows.Scan(&entry); err != nil {
+			t.Fatalf("could not read the schema of the database: %v", err)
+		}
+
+		s = append(s, strings.Join(strings.Fields(entry), " "))
+	}
+
+	sort.Strings(s)
+
+	return s
+}
+
+// testLogger fails the test on the errors logged while running the migrations, as migration.Run only logs them.
+type testLogger struct {
+	logging.Logger
+
+	t testing.TB
+}
+
+func (l testLogger) Error(args ...interface{}) {
+	l.t.Helper()
+	l.t.Error(args...)
+}
+
+func (l testLogger) Errorf(format string, args ...interface{}) {
+	l.t.Helper()
+	l.t.Errorf(format, args...)
+}
@@ -0,0 +1,85 @@
+package migrationtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/migration"
+)
+
+// errorRecorder records the errors of Run, instead of failing the test.
+type errorRecorder struct {
+	testing.TB
+
+	errors []string
+}
+
+func (r *errorRecorder) Error(args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func (r *errorRecorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *errorRecorder) Failed() bool {
+	return len(r.errors) > 0
+}
+
+func exec(query string) migration.MigrateFunc {
+	return func(d migration.Datasource) error {
+		_, err := d.SQL.Exec(query)
+
+		return err
+	}
+}
+
+func TestRun(t *testing.T) {
+	createOrders := migration.Migrate{
+		UP:   exec("CREATE TABLE orders (id INTEGER PRIMARY KEY, amount INTEGER)"),
+		DOWN: exec("DROP TABLE orders"),
+	}
+
+	testCases := []struct {
+		desc       string
+		migrations map[int64]migration.Migrate
+		expErr     string
+	}{
+		{"reversible migrations", map[int64]migration.Migrate{
+			1: createOrders,
+			2: {
+				UP:   exec("CREATE INDEX orders_amount ON orders (amount)"),
+				DOWN: exec("DROP INDEX orders_amount"),
+			},
+		}, ""},
+		{"failing UP", map[int64]migration.Migrate{
+			1: {UP: exec("CREATE TABLE"), DOWN: exec("DROP TABLE orders")},
+		}, "failed and rolled back"},
+		{"missing DOWN", map[int64]migration.Migrate{
+			1: {UP: createOrders.UP},
+		}, "migration 1 has no DOWN"},
+		{"failing DOWN", map[int64]migration.Migrate{
+			1: {UP: createOrders.UP, DOWN: exec("DROP TABLE customers")},
+		}, "DOWN of migration 1 failed"},
+		{"DOWN not reverting UP", map[int64]migration.Migrate{
+			1: createOrders,
+			2: {UP: exec("CREATE INDEX orders_amount ON orders (amount)"), DOWN: exec("SELECT 1")},
+		}, "DOWN of migration 2 should revert the schema"},
+	}
+
+	for i, tc := range testCases {
+		r := &errorRecorder{TB: t}
+
+		Run(r, tc.migrations, SQLite)
+
+		if tc.expErr == "" {
+			assert.Empty(t, r.errors, "TEST[%d], Failed.\n%s", i, tc.desc)
+			continue
+		}
+
+		assert.Contains(t, strings.Join(r.errors, "\n"), tc.expErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,106 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+)
+
+const mongoMigrationCollection = "gofr_migrations"
+
+// mongoDB is the Mongo of the migrations, which can create the collections and the indexes.
+type mongoDB interface {
+	datasource.Mongo
+
+	CreateCollection(ctx context.Context, name string) error
+	CreateIndex(ctx context.Context, collection, name string, keys interface{}, unique bool) error
+	DropIndex(ctx context.Context, collection, name string) error
+}
+
+type mongoMigration struct {
+	Version   int64     `bson:"version"`
+	Method    string    `bson:"method"`
+	StartTime time.Time `bson:"start_time"`
+	Duration  int64     `bson:"duration"`
+}
+
+type mongoMigratorObject struct {
+	mongoDB
+}
+
+type mongoMigrator struct {
+	mongoDB
+
+	Migrator
+}
+
+func (s mongoMigratorObject) apply(m Migrator) Migrator {
+	return mongoMigrator{
+		mongoDB:  s.mongoDB,
+		Migrator: m,
+	}
+}
+
+func (d mongoMigrator) getLastMigration(c *container.Container) int64 {
+	var (
+		lastMigration int64
+		migrations    []mongoMigration
+	)
+
+	// the migrations which were reverted are run again.
+	err := d.mongoDB.Find(context.Background(), mongoMigrationCollection, map[string]interface{}{"method": methodUP}, &migrations)
+	if err != nil {
+		c.Logger.Errorf("failed to get migration record from Mongo. err: %v", err)
+
+		return -1
+	}
+
+	for _, m := range migrations {
+		if m.Version > lastMigration {
+			lastMigration = m.Version
+		}
+	}
+
+	c.Debugf("Mongo last migration fetched value is: %v", lastMigration)
+
+	last := d.Migrator.getLastMigration(c)
+	if last > lastMigration {
+		return last
+	}
+
+	return lastMigration
+}
+
+// commitMigration records the migration in gofr_migrations. Mongo has no transactions on a standalone server.
+func (d mongoMigrator) commitMigration(c *container.Container, data migrationData) error {
+	ctx := context.Background()
+
+	_, err := d.mongoDB.DeleteMany(ctx, mongoMigrationCollection, map[string]interface{}{"version": data.MigrationNumber})
+	if err != nil {
+		c.Logger.Errorf("migration %v for Mongo failed with err: %v", data.MigrationNumber, err)
+
+		return err
+	}
+
+	_, err = d.mongoDB.InsertOne(ctx, mongoMigrationCollection, mongoMigration{
+		Version:   data.MigrationNumber,
+		Method:    data.Method,
+		StartTime: data.StartTime,
+		Duration:  time.Since(data.StartTime).Milliseconds(),
+	})
+	if err != nil {
+		c.Logger.Errorf("migration %v for Mongo failed with err: %v", data.MigrationNumber, err)
+
+		return err
+	}
+
+	return d.Migrator.commitMigration(c, data)
+}
+
+func (d mongoMigrator) rollback(c *container.Container, data migrationData) {
+	c.Errorf("migration %v failed, the changes made to Mongo are not rolled back", data.MigrationNumber)
+
+	d.Migrator.rollback(c, data)
+}
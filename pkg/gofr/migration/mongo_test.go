@@ -0,0 +1,130 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil/logtest"
+)
+
+var errMongo = errors.New("mongo is down")
+
+// fakeMongo keeps the records of gofr_migrations and the collections created by the migrations.
+type fakeMongo struct {
+	datasource.Mongo
+
+	migrations  []mongoMigration
+	collections []string
+	err         error
+}
+
+func (m *fakeMongo) Find(_ context.Context, _ string, filter, results interface{}) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	method := filter.(map[string]interface{})["method"]
+
+	for _, r := range m.migrations {
+		if r.Method == method {
+			*results.(*[]mongoMigration) = append(*results.(*[]mongoMigration), r)
+		}
+	}
+
+	return nil
+}
+
+func (m *fakeMongo) DeleteMany(_ context.Context, _ string, filter interface{}) (int64, error) {
+	version := filter.(map[string]interface{})["version"]
+
+	kept := m.migrations[:0]
+
+	for _, r := range m.migrations {
+		if r.Version != version {
+			kept = append(kept, r)
+		}
+	}
+
+	deleted := int64(len(m.migrations) - len(kept))
+	m.migrations = kept
+
+	return deleted, nil
+}
+
+func (m *fakeMongo) InsertOne(_ context.Context, _ string, document interface{}) (interface{}, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	m.migrations = append(m.migrations, document.(mongoMigration))
+
+	return nil, nil
+}
+
+func (m *fakeMongo) CreateCollection(_ context.Context, name string) error {
+	m.collections = append(m.collections, name)
+
+	return nil
+}
+
+func (*fakeMongo) CreateIndex(context.Context, string, string, interface{}, bool) error {
+	return nil
+}
+
+func (*fakeMongo) DropIndex(context.Context, string, string) error {
+	return nil
+}
+
+func TestMongoMigrator(t *testing.T) {
+	db := &fakeMongo{migrations: []mongoMigration{{Version: 1, Method: methodUP}, {Version: 3, Method: methodDOWN}}}
+
+	c := container.NewContainer(nil)
+	c.Logger = logtest.NewLogRecorder()
+	c.Mongo = db
+
+	migrations := map[int64]Migrate{
+		1: {UP: func(Datasource) error { return errMongo }},
+		2: {UP: func(d Datasource) error { return d.Mongo.CreateCollection(context.Background(), "orders") }},
+		3: {UP: func(d Datasource) error { return d.Mongo.CreateCollection(context.Background(), "customers") }},
+	}
+
+	Run(migrations, c)
+
+	assert.Equal(t, []string{"orders", "customers"}, db.collections)
+	require.Len(t, db.migrations, 3)
+
+	for _, r := range db.migrations {
+		assert.Equal(t, methodUP, r.Method, "TEST Failed.\nmigration %d", r.Version)
+	}
+
+	// the migrations already run are not run again.
+	Run(migrations, c)
+
+	assert.Len(t, db.collections, 2)
+}
+
+func TestMongoMigrator_Errors(t *testing.T) {
+	logs := logtest.NewLogRecorder()
+
+	c := container.NewContainer(nil)
+	c.Logger = logs
+
+	db := &fakeMongo{err: errMongo}
+	mg := mongoMigratorObject{db}.apply(Datasource{})
+
+	assert.Equal(t, int64(-1), mg.getLastMigration(c))
+	assert.Equal(t, errMongo, mg.commitMigration(c, migrationData{MigrationNumber: 4, Method: methodUP}))
+
+	mg.rollback(c, migrationData{MigrationNumber: 4})
+
+	logs.AssertContains(t, logging.ERROR, "failed to get migration record from Mongo. err: mongo is down")
+	logs.AssertContains(t, logging.ERROR, "migration 4 for Mongo failed with err: mongo is down")
+	logs.AssertContains(t, logging.ERROR, "migration 4 failed, the changes made to Mongo are not rolled back")
+}
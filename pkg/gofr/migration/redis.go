@@ -82,10 +82,6 @@ func (d redisMigrator) getLastMigration(c *container.Container) int64 {
 	for key, value := range table {
 		integerValue, _ := strconv.ParseInt(key, 10, 64)
 
-		if integerValue > lastMigration {
-			lastMigration = integerValue
-		}
-
 		d := []byte(value)
 
 		var data migration
@@ -97,6 +93,11 @@ func (d redisMigrator) getLastMigration(c *container.Container) int64 {
 			return -1
 		}
 
+		// the migrations which were reverted are run again.
+		if data.Method != methodDOWN && integerValue > lastMigration {
+			lastMigration = integerValue
+		}
+
 		val[integerValue] = data
 	}
 
@@ -126,7 +127,7 @@ func (d redisMigrator) commitMigration(c *container.Container, data migrationDat
 	migrationVersion := strconv.FormatInt(data.MigrationNumber, 10)
 
 	jsonData, err := json.Marshal(migration{
-		Method:    "UP",
+		Method:    data.Method,
 		StartTime: data.StartTime,
 		Duration:  time.Since(data.StartTime).Milliseconds(),
 	})
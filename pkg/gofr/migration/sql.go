@@ -18,11 +18,35 @@ const (
     constraint primary_key primary key (version, method)
 );`
 
-	getLastSQLGoFrMigration = `SELECT COALESCE(MAX(version), 0) FROM gofr_migrations;`
+	createSQLGoFrMigrationsTableMSSQL = `IF OBJECT_ID('gofr_migrations', 'U') IS NULL CREATE TABLE gofr_migrations (
+    version BIGINT not null ,
+    method VARCHAR(4) not null ,
+    start_time DATETIME2 not null ,
+    duration BIGINT,
+    constraint primary_key primary key (version, method)
+);`
+
+	// ClickHouse has no primary key constraint, the primary key being the sorting key of the table.
+	createSQLGoFrMigrationsTableClickHouse = `CREATE TABLE IF NOT EXISTS gofr_migrations (
+    version Int64,
+    method String,
+    start_time DateTime64(3),
+    duration Nullable(Int64)
+) ENGINE = MergeTree ORDER BY (version, method);`
+
+	getLastSQLGoFrMigration = `SELECT COALESCE(MAX(version), 0) FROM gofr_migrations WHERE method = 'UP';`
+
+	deleteGoFrMigrationRowsMySQL = `DELETE FROM gofr_migrations WHERE version = ?;`
+
+	deleteGoFrMigrationRowsPostgres = `DELETE FROM gofr_migrations WHERE version = $1;`
 
 	insertGoFrMigrationRowMySQL = `INSERT INTO gofr_migrations (version, method, start_time,duration) VALUES (?, ?, ?, ?);`
 
 	insertGoFrMigrationRowPostgres = `INSERT INTO gofr_migrations (version, method, start_time,duration) VALUES ($1, $2, $3, $4);`
+
+	deleteGoFrMigrationRowsMSSQL = `DELETE FROM gofr_migrations WHERE version = @p1;`
+
+	insertGoFrMigrationRowMSSQL = `INSERT INTO gofr_migrations (version, method, start_time,duration) VALUES (@p1, @p2, @p3, @p4);`
 )
 
 type db interface {
@@ -61,8 +85,13 @@ func (s *sqlDB) ExecContext(ctx context.Context, query string, args ...interface
 	return s.db.ExecContext(ctx, query, args...)
 }
 
-func insertMigrationRecord(tx *gofrSql.Tx, query string, version int64, startTime time.Time) error {
-	_, err := tx.Exec(query, version, "UP", startTime, time.Since(startTime).Milliseconds())
+// insertMigrationRecord records the last run of the migration, replacing its previous record.
+func insertMigrationRecord(tx *gofrSql.Tx, deleteQuery, insertQuery string, data migrationData) error {
+	if _, err := tx.Exec(deleteQuery, data.MigrationNumber); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(insertQuery, data.MigrationNumber, data.Method, data.StartTime, time.Since(data.StartTime).Milliseconds())
 
 	return err
 }
@@ -85,7 +114,16 @@ func (s sqlMigratorObject) apply(m Migrator) Migrator {
 }
 
 func (d sqlMigrator) checkAndCreateMigrationTable(c *container.Container) error {
-	if _, err := c.SQL.Exec(createSQLGoFrMigrationsTable); err != nil {
+	query := createSQLGoFrMigrationsTable
+
+	switch c.SQL.Dialect() {
+	case "mssql":
+		query = createSQLGoFrMigrationsTableMSSQL
+	case "clickhouse":
+		query = createSQLGoFrMigrationsTableClickHouse
+	}
+
+	if _, err := c.SQL.Exec(query); err != nil {
 		return err
 	}
 
@@ -113,14 +151,20 @@ func (d sqlMigrator) getLastMigration(c *container.Container) int64 {
 
 func (d sqlMigrator) commitMigration(c *container.Container, data migrationData) error {
 	switch c.SQL.Dialect() {
-	case "mysql", "sqlite":
-		err := insertMigrationRecord(data.SQLTx, insertGoFrMigrationRowMySQL, data.MigrationNumber, data.StartTime)
+	case "mysql", "sqlite", "clickhouse":
+		err := insertMigrationRecord(data.SQLTx, deleteGoFrMigrationRowsMySQL, insertGoFrMigrationRowMySQL, data)
 		if err != nil {
 			return err
 		}
 
 	case "postgres":
-		err := insertMigrationRecord(data.SQLTx, insertGoFrMigrationRowPostgres, data.MigrationNumber, data.StartTime)
+		err := insertMigrationRecord(data.SQLTx, deleteGoFrMigrationRowsPostgres, insertGoFrMigrationRowPostgres, data)
+		if err != nil {
+			return err
+		}
+
+	case "mssql":
+		err := insertMigrationRecord(data.SQLTx, deleteGoFrMigrationRowsMSSQL, insertGoFrMigrationRowMSSQL, data)
 		if err != nil {
 			return err
 		}
@@ -135,7 +179,8 @@ func (d sqlMigrator) commitMigration(c *container.Container, data migrationData)
 }
 
 func (d sqlMigrator) beginTransaction(c *container.Container) migrationData {
-	sqlTx, err := c.SQL.Begin()
+	// the migrations cross the tenants, so their queries are not guarded.
+	sqlTx, err := c.SQL.BeginTx(gofrSql.BypassTenantGuard(context.Background()), nil)
 	if err != nil {
 		c.Errorf("unable to begin transaction: %v", err)
 
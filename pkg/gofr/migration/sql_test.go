@@ -174,18 +174,26 @@ func TestCheckAndCreateMigrationTableSuccess(t *testing.T) {
 	mockMigrator := NewMockMigrator(ctrl)
 	mockContainer, mocks := container.NewMockContainer(t)
 
-	mockMigrator.EXPECT().checkAndCreateMigrationTable(mockContainer)
-	mocks.SQL.EXPECT().Exec(createSQLGoFrMigrationsTable).Return(nil, nil)
-
-	migrator := sqlMigrator{
-		db:       mockDB,
-		Migrator: mockMigrator,
-	}
+	mockMigrator.EXPECT().checkAndCreateMigrationTable(mockContainer).Times(3)
+
+	for dialect, query := range map[string]string{
+		"mysql":      createSQLGoFrMigrationsTable,
+		"mssql":      createSQLGoFrMigrationsTableMSSQL,
+		"clickhouse": createSQLGoFrMigrationsTableClickHouse,
+	} {
+		mocks.SQL.EXPECT().Dialect().Return(dialect)
+		mocks.SQL.EXPECT().Exec(query).Return(nil, nil)
+
+		migrator := sqlMigrator{
+			db:       mockDB,
+			Migrator: mockMigrator,
+		}
 
-	err := migrator.checkAndCreateMigrationTable(mockContainer)
+		err := migrator.checkAndCreateMigrationTable(mockContainer)
 
-	if err != nil {
-		t.Errorf("checkAndCreateMigrationTable should return no error, got: %v", err)
+		if err != nil {
+			t.Errorf("checkAndCreateMigrationTable should return no error for %s, got: %v", dialect, err)
+		}
 	}
 }
 
@@ -196,6 +204,7 @@ func TestCheckAndCreateMigrationTableExecError(t *testing.T) {
 	mockContainer, mocks := container.NewMockContainer(t)
 	expectedErr := sql.ErrNoRows
 
+	mocks.SQL.EXPECT().Dialect().Return("mysql")
 	mocks.SQL.EXPECT().Exec(createSQLGoFrMigrationsTable).Return(nil, expectedErr)
 
 	migrator := sqlMigrator{
@@ -221,7 +230,7 @@ func TestBeginTransactionSuccess(t *testing.T) {
 	mockContainer, mocks := container.NewMockContainer(t)
 	expectedMigrationData := migrationData{}
 
-	mocks.SQL.EXPECT().Begin()
+	mocks.SQL.EXPECT().BeginTx(gomock.Any(), nil)
 	mockMigrator.EXPECT().beginTransaction(mockContainer)
 
 	migrator := sqlMigrator{
@@ -245,7 +254,7 @@ func TestBeginTransactionDBError(t *testing.T) {
 	mockMigrator := NewMockMigrator(ctrl)
 	mockContainer, mocks := container.NewMockContainer(t)
 
-	mocks.SQL.EXPECT().Begin().Return(nil, errBeginTx)
+	mocks.SQL.EXPECT().BeginTx(gomock.Any(), nil).Return(nil, errBeginTx)
 
 	migrator := sqlMigrator{
 		db:       mockDB,
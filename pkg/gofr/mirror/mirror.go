@@ -0,0 +1,207 @@
+// Package mirror compares the responses of the requests with those of a shadow upstream.
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxBodySize is the size of the compared bodies. The longer ones are compared by their status only.
+	MaxBodySize = 1 << 20
+
+	// Header is set on the mirrored requests, so that the shadow upstream can tell them apart.
+	Header = "X-Gofr-Mirrored"
+
+	// maxDiffs is the number of the differences of a result.
+	maxDiffs = 20
+)
+
+// Result is the comparison of a response with the response of the shadow upstream to its mirrored request.
+type Result struct {
+	Method        string   `json:"method"`
+	URI           string   `json:"uri"`
+	PrimaryStatus int      `json:"primaryStatus"`
+	ShadowStatus  int      `json:"shadowStatus,omitempty"`
+	ShadowLatency int64    `json:"shadowLatency"`
+	Match         bool     `json:"match"`
+	Diff          []string `json:"diff,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+func (r *Result) PrettyPrint(writer io.Writer) {
+	outcome := "\u001B[38;5;34mMATCH\u001B[0m"
+	if !r.Match {
+		outcome = "\u001B[38;5;202mDIFF\u001B[0m "
+	}
+
+	fmt.Fprintf(writer, "\u001B[38;5;8mmirror\u001B[0m %s %d -> %d %8d\u001B[38;5;8mµs\u001B[0m %s %s %s\n", outcome,
+		r.PrimaryStatus, r.ShadowStatus, r.ShadowLatency, r.Method, r.URI, strings.Join(r.Diff, "; ")+r.Error)
+}
+
+// Sampled reports whether a request is mirrored, for the percentage of the requests which are.
+func Sampled(percentage float64) bool {
+	//nolint:gosec // the sampling of the requests does not need a secure random number.
+	return percentage >= 100 || rand.Float64()*100 < percentage
+}
+
+// Do sends the request to the shadow upstream, and compares its response with the primary one.
+func Do(client *http.Client, req *http.Request, primaryStatus int, primaryBody []byte) Result {
+	res := Result{Method: req.Method, URI: req.URL.RequestURI(), PrimaryStatus: primaryStatus}
+
+	req.Header.Set(Header, "true")
+
+	start := time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		res.ShadowLatency = time.Since(start).Microseconds()
+		res.Error = err.Error()
+
+		return res
+	}
+
+	defer resp.Body.Close()
+
+	shadowBody, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodySize+1))
+
+	res.ShadowLatency = time.Since(start).Microseconds()
+	res.ShadowStatus = resp.StatusCode
+
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	if primaryBody == nil || len(shadowBody) > MaxBodySize {
+		primaryBody, shadowBody = nil, nil
+	}
+
+	res.Diff = Compare(primaryStatus, primaryBody, resp.StatusCode, shadowBody)
+	res.Match = len(res.Diff) == 0
+
+	return res
+}
+
+// Compare returns the differences between the primary response and the shadow one, like "status: 200 != 500".
+func Compare(primaryStatus int, primaryBody []byte, shadowStatus int, shadowBody []byte) []string {
+	var diff []string
+
+	if primaryStatus != shadowStatus {
+		diff = append(diff, fmt.Sprintf("status: %d != %d", primaryStatus, shadowStatus))
+	}
+
+	var primary, shadow interface{}
+
+	if json.Unmarshal(primaryBody, &primary) != nil || json.Unmarshal(shadowBody, &shadow) != nil {
+		if !bytes.Equal(bytes.TrimSpace(primaryBody), bytes.TrimSpace(shadowBody)) {
+			diff = append(diff, fmt.Sprintf("body: %d bytes != %d bytes", len(primaryBody), len(shadowBody)))
+		}
+
+		return diff
+	}
+
+	return compareJSON(diff, "", primary, shadow)
+}
+
+// compareJSON appends the differences of the JSON values at the path to diff, up to maxDiffs differences.
+func compareJSON(diff []string, path string, primary, shadow interface{}) []string {
+	if len(diff) >= maxDiffs {
+		return diff
+	}
+
+	switch p := primary.(type) {
+	case map[string]interface{}:
+		s, ok := shadow.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(p)+len(s))
+
+		for k := range p {
+			keys = append(keys, k)
+		}
+
+		for k := range s {
+			if _, ok := p[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			diff = compareJSON(diff, joinPath(path, k), p[k], s[k])
+		}
+
+		return diff
+	case []interface{}:
+		s, ok := shadow.([]interface{})
+		if !ok || len(p) != len(s) {
+			break
+		}
+
+		for i := range p {
+			diff = compareJSON(diff, joinPath(path, strconv.Itoa(i)), p[i], s[i])
+		}
+
+		return diff
+	}
+
+	if reflect.DeepEqual(primary, shadow) {
+		return diff
+	}
+
+	if path == "" {
+		path = "body"
+	}
+
+	return append(diff, fmt.Sprintf("%s: %s != %s", path, formatJSON(primary), formatJSON(shadow)))
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+// formatJSON returns the JSON of the value, shortened to a length which can be logged.
+func formatJSON(v interface{}) string {
+	const maxLength = 64
+
+	b, _ := json.Marshal(v)
+	if len(b) > maxLength {
+		return string(b[:maxLength]) + "..."
+	}
+
+	return string(b)
+}
+
+// Request returns the request to the shadow upstream, which is not canceled with the primary request.
+func Request(ctx context.Context, method, url string, body []byte, header http.Header,
+	timeout time.Duration) (*http.Request, context.CancelFunc, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	req.Header = header
+
+	return req, cancel, nil
+}
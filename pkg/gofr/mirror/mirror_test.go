@@ -0,0 +1,70 @@
+package mirror
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		primaryStatus int
+		primaryBody   string
+		shadowStatus  int
+		shadowBody    string
+		diff          []string
+	}{
+		{"same", 200, `{"data":{"id":1,"tags":["a"]}}`, 200, `{"data":{"tags":["a"],"id":1}}`, nil},
+		{"status", 200, `{"data":1}`, 500, `{"data":1}`, []string{"status: 200 != 500"}},
+		{"fields", 200, `{"data":{"id":1,"price":10,"tags":["a","b"]}}`, 200,
+			`{"data":{"id":1,"price":12,"tags":["a","c"],"new":true}}`,
+			[]string{"data.new: null != true", "data.price: 10 != 12", "data.tags.1: \"b\" != \"c\""}},
+		{"array length", 200, `{"data":[1,2]}`, 200, `{"data":[1]}`, []string{"data: [1,2] != [1]"}},
+		{"not json", 200, "ok\n", 200, "ok", nil},
+		{"not json differs", 200, "ok", 200, "fail", []string{"body: 2 bytes != 4 bytes"}},
+		{"empty bodies", 204, "", 204, "", nil},
+	}
+
+	for i, tc := range testCases {
+		diff := Compare(tc.primaryStatus, []byte(tc.primaryBody), tc.shadowStatus, []byte(tc.shadowBody))
+
+		assert.Equal(t, tc.diff, diff, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestDo(t *testing.T) {
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get(Header))
+
+		_, _ = w.Write([]byte(`{"data":2}`))
+	}))
+	defer shadow.Close()
+
+	req, cancel, err := Request(context.Background(), http.MethodGet, shadow.URL+"/orders?page=1", nil, http.Header{},
+		time.Second)
+	require.NoError(t, err)
+
+	defer cancel()
+
+	res := Do(shadow.Client(), req, http.StatusOK, []byte(`{"data":1}`))
+
+	assert.False(t, res.Match)
+	assert.Equal(t, "/orders?page=1", res.URI)
+	assert.Equal(t, http.StatusOK, res.ShadowStatus)
+	assert.Equal(t, []string{"data: 1 != 2"}, res.Diff)
+
+	res = Do(shadow.Client(), req.Clone(req.Context()), http.StatusOK, nil)
+
+	assert.True(t, res.Match, "the bodies should not be compared if the primary one is too long")
+}
+
+func TestSampled(t *testing.T) {
+	assert.True(t, Sampled(100))
+	assert.False(t, Sampled(0))
+}
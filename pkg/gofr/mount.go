@@ -0,0 +1,57 @@
+package gofr
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Handler returns the handler of the HTTP routes of the application, to be served by another server.
+// It must be called once the routes are added.
+//
+//	Usage:
+//	app := gofr.New()
+//	app.GET("/orders", listOrders)
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/api/", http.StripPrefix("/api", app.Handler()))
+func (a *App) Handler() http.Handler {
+	a.prepareHTTP()
+
+	return a.httpServer.router
+}
+
+// Mount serves the handler for the requests under the prefix, which is removed from their path.
+//
+//	Usage:
+//	app.Mount("/legacy", legacyMux)
+func (a *App) Mount(prefix string, h http.Handler) {
+	prefix = "/" + strings.Trim(prefix, "/")
+	mounted := stripPrefix(strings.TrimSuffix(prefix, "/"), h)
+
+	a.httpRegistered = true
+
+	if prefix == "/" {
+		a.httpServer.router.PathPrefix("/").Handler(mounted)
+
+		return
+	}
+
+	a.httpServer.router.Path(prefix).Handler(mounted)
+	a.httpServer.router.PathPrefix(prefix + "/").Handler(mounted)
+}
+
+// stripPrefix removes the prefix from the path of the requests served by h, the path of the prefix being "/".
+func stripPrefix(prefix string, h http.Handler) http.Handler {
+	if prefix == "" {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r2 := r.Clone(r.Context())
+
+		r2.URL.Path = "/" + strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+		r2.URL.RawPath = ""
+
+		h.ServeHTTP(w, r2)
+	})
+}
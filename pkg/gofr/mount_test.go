@@ -0,0 +1,82 @@
+package gofr
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_Handler(t *testing.T) {
+	app := New()
+
+	app.GET("/hello", func(*Context) (interface{}, error) {
+		return "Hello World!", nil
+	})
+
+	server := httptest.NewServer(app.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/hello", http.StatusOK},
+		{"/.well-known/alive", http.StatusOK},
+		{"/unknown", http.StatusNotFound},
+	}
+
+	for i, tc := range tests {
+		resp, err := http.Get(server.URL + tc.path)
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.path)
+
+		resp.Body.Close()
+
+		assert.Equal(t, tc.status, resp.StatusCode, "TEST[%d], Failed.\n%s", i, tc.path)
+	}
+
+	assert.Same(t, app.Handler(), app.Handler())
+}
+
+func TestApp_Mount(t *testing.T) {
+	app := New()
+
+	legacy := http.NewServeMux()
+	legacy.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "legacy "+r.URL.Path)
+	})
+
+	app.Mount("/legacy/", legacy)
+
+	app.GET("/hello", func(*Context) (interface{}, error) {
+		return "Hello World!", nil
+	})
+
+	assert.True(t, app.httpRegistered)
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/legacy", "legacy /"},
+		{"/legacy/", "legacy /"},
+		{"/legacy/orders/1", "legacy /orders/1"},
+		{"/hello", `{"data":"Hello World!"}` + "\n"},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+		app.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, http.NoBody))
+
+		assert.Equal(t, http.StatusOK, w.Code, "TEST[%d], Failed.\n%s", i, tc.path)
+		assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.path)
+	}
+
+	w := httptest.NewRecorder()
+	app.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/legacyorders", http.NoBody))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
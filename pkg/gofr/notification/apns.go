@@ -0,0 +1,148 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	apnsProductionURL  = "https://api.push.apple.com"
+	apnsDevelopmentURL = "https://api.sandbox.push.apple.com"
+
+	// apnsTokenTTL is the time a token of APNs is used, which must be renewed within an hour.
+	apnsTokenTTL = 50 * time.Minute
+)
+
+// APNsConfig is the configuration of the Apple Push Notification service.
+type APNsConfig struct {
+	// KeyID is the ID of the key, and TeamID the ID of the team owning it.
+	KeyID  string
+	TeamID string
+	// PrivateKey is the private key of the .p8 file of the key, which can be parsed with jwt.ParseECPrivateKeyFromPEM.
+	PrivateKey *ecdsa.PrivateKey
+	// Topic is the bundle ID of the application.
+	Topic string
+	// Development sends the notifications to the development environment of APNs instead of the production one.
+	Development bool
+	// BaseURL is the URL of APNs, which is the one of the environment by default.
+	BaseURL string
+	// Client is the client of the requests, which is http.DefaultClient by default.
+	Client *http.Client
+}
+
+// APNs sends the push notifications to the Apple devices.
+type APNs struct {
+	config APNsConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	token    string
+	signedAt time.Time
+}
+
+// NewAPNs returns the provider of the push notifications sent with the Apple Push Notification service.
+func NewAPNs(cfg APNsConfig) *APNs {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = apnsProductionURL
+
+		if cfg.Development {
+			cfg.BaseURL = apnsDevelopmentURL
+		}
+	}
+
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	return &APNs{config: cfg, now: time.Now}
+}
+
+func (*APNs) Name() string {
+	return "apns"
+}
+
+func (*APNs) Channel() Channel {
+	return Push
+}
+
+func (a *APNs) Send(ctx context.Context, msg *Message) (string, error) {
+	payload := map[string]interface{}{
+		"aps": map[string]interface{}{"alert": map[string]string{"title": msg.Title, "body": msg.Body}},
+	}
+
+	for k, v := range msg.Payload {
+		payload[k] = v
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := a.authToken()
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.config.BaseURL+"/3/device/"+url.PathEscape(msg.To),
+		bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("Apns-Topic", a.config.Topic)
+	req.Header.Set("Apns-Push-Type", "alert")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.config.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var result struct {
+			Reason string `json:"reason"`
+		}
+
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+
+		return "", ErrorProvider{Provider: a.Name(), Status: resp.StatusCode, Message: result.Reason}
+	}
+
+	return resp.Header.Get("Apns-Id"), nil
+}
+
+// authToken returns the token authenticating the requests, which is signed again once it is older than apnsTokenTTL.
+func (a *APNs) authToken() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+
+	if a.token != "" && now.Sub(a.signedAt) < apnsTokenTTL {
+		return a.token, nil
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{"iss": a.config.TeamID, "iat": now.Unix()})
+	t.Header["kid"] = a.config.KeyID
+
+	token, err := t.SignedString(a.config.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	a.token, a.signedAt = token, now
+
+	return token, nil
+}
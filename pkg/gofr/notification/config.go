@@ -0,0 +1,126 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2/google"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+)
+
+const defaultRateWindow = time.Hour
+
+// FromConfig returns the Notifier of the NOTIFICATION_* configs, or nil if no provider is configured.
+func FromConfig(conf config.Config, c clock.Clock) (*Notifier, error) {
+	opts := []Option{WithClock(c)}
+
+	switch p := conf.Get("NOTIFICATION_SMS_PROVIDER"); p {
+	case "":
+	case "twilio":
+		opts = append(opts, WithProvider(NewTwilio(TwilioConfig{
+			AccountSID:        conf.Get("TWILIO_ACCOUNT_SID"),
+			AuthToken:         conf.Get("TWILIO_AUTH_TOKEN"),
+			From:              conf.Get("TWILIO_FROM"),
+			StatusCallbackURL: conf.Get("TWILIO_STATUS_CALLBACK_URL"),
+		})))
+	case "sns":
+		opts = append(opts, WithProvider(NewSNS(SNSConfig{
+			Region:          conf.Get("AWS_REGION"),
+			AccessKeyID:     conf.Get("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: conf.Get("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    conf.Get("AWS_SESSION_TOKEN"),
+			SenderID:        conf.Get("SNS_SENDER_ID"),
+		})))
+	default:
+		return nil, fmt.Errorf("unknown NOTIFICATION_SMS_PROVIDER %q, either twilio or sns", p)
+	}
+
+	switch p := conf.Get("NOTIFICATION_PUSH_PROVIDER"); p {
+	case "":
+	case "fcm":
+		fcm, err := fcmFromConfig(conf)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, WithProvider(fcm))
+	case "apns":
+		apns, err := apnsFromConfig(conf)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, WithProvider(apns))
+	default:
+		return nil, fmt.Errorf("unknown NOTIFICATION_PUSH_PROVIDER %q, either fcm or apns", p)
+	}
+
+	if len(opts) == 1 {
+		return nil, nil
+	}
+
+	if limit, err := strconv.Atoi(conf.Get("NOTIFICATION_RATE_LIMIT")); err == nil && limit > 0 {
+		window, err := time.ParseDuration(conf.Get("NOTIFICATION_RATE_WINDOW"))
+		if err != nil || window <= 0 {
+			window = defaultRateWindow
+		}
+
+		opts = append(opts, WithRateLimit(limit, window))
+	}
+
+	return New(opts...), nil
+}
+
+// fcmFromConfig returns the FCM provider of FCM_CREDENTIALS_FILE, or of the default credentials.
+func fcmFromConfig(conf config.Config) (*FCM, error) {
+	var (
+		creds *google.Credentials
+		err   error
+	)
+
+	if file := conf.Get("FCM_CREDENTIALS_FILE"); file != "" {
+		data, readErr := os.ReadFile(file)
+		if readErr != nil {
+			return nil, fmt.Errorf("could not read FCM_CREDENTIALS_FILE: %w", readErr)
+		}
+
+		creds, err = google.CredentialsFromJSON(context.Background(), data, FCMScope)
+	} else {
+		creds, err = google.FindDefaultCredentials(context.Background(), FCMScope)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials of FCM: %w", err)
+	}
+
+	project := conf.GetOrDefault("FCM_PROJECT_ID", creds.ProjectID)
+
+	return NewFCM(FCMConfig{ProjectID: project, TokenSource: creds.TokenSource}), nil
+}
+
+// apnsFromConfig returns the APNs provider authenticated by the key of APNS_KEY_FILE.
+func apnsFromConfig(conf config.Config) (*APNs, error) {
+	pem, err := os.ReadFile(conf.Get("APNS_KEY_FILE"))
+	if err != nil {
+		return nil, fmt.Errorf("could not read APNS_KEY_FILE: %w", err)
+	}
+
+	key, err := jwt.ParseECPrivateKeyFromPEM(pem)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key of APNS_KEY_FILE: %w", err)
+	}
+
+	return NewAPNs(APNsConfig{
+		KeyID:       conf.Get("APNS_KEY_ID"),
+		TeamID:      conf.Get("APNS_TEAM_ID"),
+		PrivateKey:  key,
+		Topic:       conf.Get("APNS_TOPIC"),
+		Development: conf.Get("APNS_DEVELOPMENT") == "true",
+	}), nil
+}
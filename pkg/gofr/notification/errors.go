@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrNoRecipient is returned for the notifications which have no recipient.
+	ErrNoRecipient = errors.New("the notification has no recipient")
+	// ErrInvalidSignature is returned for the callbacks whose signature does not match their content.
+	ErrInvalidSignature = errors.New("invalid signature of the callback")
+)
+
+// ErrorNoProvider is returned for the notifications of a channel which has no provider.
+type ErrorNoProvider struct {
+	Channel Channel
+}
+
+func (e ErrorNoProvider) Error() string {
+	return fmt.Sprintf("no provider sends the %s notifications", e.Channel)
+}
+
+func (ErrorNoProvider) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+// ErrorRateLimited is returned for the notifications to a recipient beyond the rate limit.
+type ErrorRateLimited struct {
+	To string
+}
+
+func (e ErrorRateLimited) Error() string {
+	return fmt.Sprintf("too many notifications are sent to %s", e.To)
+}
+
+func (ErrorRateLimited) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+// ErrorTemplateNotFound is returned for the notifications whose template is not added.
+type ErrorTemplateNotFound struct {
+	Name string
+}
+
+func (e ErrorTemplateNotFound) Error() string {
+	return fmt.Sprintf("notification template %q is not found", e.Name)
+}
+
+func (ErrorTemplateNotFound) StatusCode() int {
+	return http.StatusInternalServerError
+}
+
+// ErrorProvider is returned for the notifications which are rejected by their provider.
+type ErrorProvider struct {
+	Provider string
+	// Status is the status of the response of the provider.
+	Status  int
+	Message string
+}
+
+func (e ErrorProvider) Error() string {
+	return fmt.Sprintf("%s rejected the notification with status %d: %s", e.Provider, e.Status, e.Message)
+}
+
+func (ErrorProvider) StatusCode() int {
+	return http.StatusBadGateway
+}
@@ -0,0 +1,117 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	fcmBaseURL = "https://fcm.googleapis.com"
+
+	// FCMScope is the OAuth scope of the tokens of FCM.
+	FCMScope = "https://www.googleapis.com/auth/firebase.messaging"
+)
+
+// FCMConfig is the configuration of the push notifications sent with Firebase Cloud Messaging.
+type FCMConfig struct {
+	ProjectID string
+	// TokenSource returns the OAuth tokens of the requests, like the tokens of a service account with the FCMScope.
+	TokenSource oauth2.TokenSource
+	// BaseURL is the URL of the API of FCM, which is https://fcm.googleapis.com by default.
+	BaseURL string
+	// Client is the client of the requests, which is http.DefaultClient by default.
+	Client *http.Client
+}
+
+// FCM sends the push notifications with Firebase Cloud Messaging.
+type FCM struct {
+	config FCMConfig
+}
+
+// NewFCM returns the provider of the push notifications sent with Firebase Cloud Messaging.
+func NewFCM(cfg FCMConfig) *FCM {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = fcmBaseURL
+	}
+
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	return &FCM{config: cfg}
+}
+
+func (*FCM) Name() string {
+	return "fcm"
+}
+
+func (*FCM) Channel() Channel {
+	return Push
+}
+
+type fcmMessage struct {
+	Message struct {
+		Token        string            `json:"token"`
+		Notification fcmNotification   `json:"notification"`
+		Data         map[string]string `json:"data,omitempty"`
+	} `json:"message"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+func (f *FCM) Send(ctx context.Context, msg *Message) (string, error) {
+	var m fcmMessage
+
+	m.Message.Token = msg.To
+	m.Message.Notification = fcmNotification{Title: msg.Title, Body: msg.Body}
+	m.Message.Data = msg.Payload
+
+	body, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := f.config.TokenSource.Token()
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		f.config.BaseURL+"/v1/projects/"+url.PathEscape(f.config.ProjectID)+"/messages:send", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	token.SetAuthHeader(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.config.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	var result struct {
+		Name  string `json:"name"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", ErrorProvider{Provider: f.Name(), Status: resp.StatusCode, Message: result.Error.Message}
+	}
+
+	return result.Name, nil
+}
@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// MockProvider records the notifications instead of sending them. Its zero value is ready to use.
+type MockProvider struct {
+	// ProviderName is the name of the provider, which is "mock" by default.
+	ProviderName string
+	// ProviderChannel is the channel of the provider, which is SMS by default.
+	ProviderChannel Channel
+
+	mu   sync.Mutex
+	sent []Message
+	err  error
+}
+
+// NewMockProvider returns a MockProvider of the channel.
+func NewMockProvider(channel Channel) *MockProvider {
+	return &MockProvider{ProviderChannel: channel}
+}
+
+func (m *MockProvider) Name() string {
+	if m.ProviderName == "" {
+		return "mock"
+	}
+
+	return m.ProviderName
+}
+
+func (m *MockProvider) Channel() Channel {
+	if m.ProviderChannel == "" {
+		return SMS
+	}
+
+	return m.ProviderChannel
+}
+
+// Send records the notification, or returns the error set by Fail.
+func (m *MockProvider) Send(_ context.Context, msg *Message) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return "", m.err
+	}
+
+	m.sent = append(m.sent, *msg)
+
+	return m.Name() + "-" + strconv.Itoa(len(m.sent)), nil
+}
+
+// Fail makes Send return the error, or succeed again if it is nil.
+func (m *MockProvider) Fail(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.err = err
+}
+
+// Sent returns the notifications sent, rendered by their templates, in the order they were sent.
+func (m *MockProvider) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.sent...)
+}
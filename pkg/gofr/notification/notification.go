@@ -0,0 +1,281 @@
+// Package notification sends the SMS and the push notifications of the application through their providers.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+// Channel is the channel of a notification.
+type Channel string
+
+// The channels of the notifications.
+const (
+	SMS  Channel = "sms"
+	Push Channel = "push"
+)
+
+// State is the state of the delivery of a notification.
+type State string
+
+// The states of the deliveries of the notifications.
+const (
+	StateSent        State = "SENT"
+	StateDelivered   State = "DELIVERED"
+	StateUndelivered State = "UNDELIVERED"
+	StateFailed      State = "FAILED"
+)
+
+// Message is an SMS to a phone number in the E.164 format, or a push notification to a device token.
+type Message struct {
+	Channel Channel
+	To      string
+	// Title is the title of a push notification, which SMS do not have.
+	Title string
+	Body  string
+	// Template is the name of the template rendering the title and the body with Data, instead of Title and Body.
+	Template string
+	Data     interface{}
+	// Payload is the custom data of a push notification, delivered to the application of the device.
+	Payload map[string]string
+}
+
+// Status is the status of the delivery of a notification, reported to the handlers registered with OnStatus.
+type Status struct {
+	// ID is the ID of the notification given by its provider, which is returned by Send.
+	ID       string    `json:"id"`
+	Provider string    `json:"provider"`
+	Channel  Channel   `json:"channel"`
+	To       string    `json:"to,omitempty"`
+	State    State     `json:"state"`
+	Error    string    `json:"error,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// Provider sends the notifications of a channel.
+type Provider interface {
+	// Name is the name of the provider, like "twilio", which is the last segment of the path of its callbacks.
+	Name() string
+	Channel() Channel
+	// Send sends the notification, returning its ID given by the provider.
+	Send(ctx context.Context, msg *Message) (string, error)
+}
+
+// CallbackProvider is implemented by the providers calling back with the statuses of the deliveries, like Twilio.
+type CallbackProvider interface {
+	Provider
+	// ParseCallback verifies the callback of the provider, and returns the status it reports.
+	ParseCallback(r *http.Request) (Status, error)
+}
+
+// Template renders the title and the body of the notifications with their data, using text/template.
+type Template struct {
+	Title string
+	Body  string
+}
+
+// Notifier sends the notifications with the provider of their channel.
+type Notifier struct {
+	providers map[Channel]Provider
+	limiter   *limiter
+	clock     clock.Clock
+
+	mu        sync.RWMutex
+	templates map[string]*template.Template
+	handlers  []func(ctx context.Context, s Status)
+}
+
+// Option configures a Notifier.
+type Option func(n *Notifier)
+
+// WithProvider sends the notifications of the channel of the provider with it.
+func WithProvider(p Provider) Option {
+	return func(n *Notifier) {
+		n.providers[p.Channel()] = p
+	}
+}
+
+// WithRateLimit limits the notifications sent to each recipient to limit per window.
+func WithRateLimit(limit int, window time.Duration) Option {
+	return func(n *Notifier) {
+		if limit > 0 && window > 0 {
+			n.limiter = &limiter{limit: limit, window: window, sent: make(map[string]*sentWindow)}
+		}
+	}
+}
+
+// WithClock sets the clock of the rate limit and the times of the statuses.
+func WithClock(c clock.Clock) Option {
+	return func(n *Notifier) {
+		n.clock = c
+	}
+}
+
+// New returns a Notifier sending the notifications with the providers.
+func New(opts ...Option) *Notifier {
+	n := &Notifier{
+		providers: make(map[Channel]Provider),
+		clock:     clock.New(),
+		templates: make(map[string]*template.Template),
+	}
+
+	for _, o := range opts {
+		o(n)
+	}
+
+	return n
+}
+
+// AddTemplate adds the template of the name, which renders the notifications whose Template is the name.
+func (n *Notifier) AddTemplate(name string, t Template) error {
+	tmpl := template.New(name).Option("missingkey=error")
+
+	if _, err := tmpl.New("title").Parse(t.Title); err != nil {
+		return fmt.Errorf("invalid title of the template %q: %w", name, err)
+	}
+
+	if _, err := tmpl.New("body").Parse(t.Body); err != nil {
+		return fmt.Errorf("invalid body of the template %q: %w", name, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.templates[name] = tmpl
+
+	return nil
+}
+
+// OnStatus registers the handler of the statuses of the notifications.
+func (n *Notifier) OnStatus(handler func(ctx context.Context, s Status)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.handlers = append(n.handlers, handler)
+}
+
+// Providers returns the providers of the notifier.
+func (n *Notifier) Providers() []Provider {
+	providers := make([]Provider, 0, len(n.providers))
+
+	for _, p := range n.providers {
+		providers = append(providers, p)
+	}
+
+	return providers
+}
+
+// Send renders the notification and sends it with the provider of its channel, returning its ID.
+func (n *Notifier) Send(ctx context.Context, msg *Message) (string, error) {
+	p, ok := n.providers[msg.Channel]
+	if !ok {
+		return "", ErrorNoProvider{Channel: msg.Channel}
+	}
+
+	if msg.To == "" {
+		return "", ErrNoRecipient
+	}
+
+	rendered, err := n.render(msg)
+	if err != nil {
+		return "", err
+	}
+
+	if n.limiter != nil && !n.limiter.allow(msg.Channel, msg.To, n.clock.Now()) {
+		return "", ErrorRateLimited{To: msg.To}
+	}
+
+	id, err := p.Send(ctx, rendered)
+
+	s := Status{ID: id, Provider: p.Name(), Channel: msg.Channel, To: msg.To, State: StateSent, Time: n.clock.Now()}
+
+	if err != nil {
+		s.State, s.Error = StateFailed, err.Error()
+	}
+
+	n.report(ctx, s)
+
+	return id, err
+}
+
+// CallbackHandler returns the handler of the callbacks of the provider of the name.
+func (n *Notifier) CallbackHandler(provider string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var cp CallbackProvider
+
+		for _, p := range n.providers {
+			if c, ok := p.(CallbackProvider); ok && p.Name() == provider {
+				cp = c
+			}
+		}
+
+		if cp == nil {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		s, err := cp.ParseCallback(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if s.Time.IsZero() {
+			s.Time = n.clock.Now()
+		}
+
+		n.report(r.Context(), s)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// render returns a copy of the message whose title and body are rendered by its template.
+func (n *Notifier) render(msg *Message) (*Message, error) {
+	rendered := *msg
+
+	if msg.Template == "" {
+		return &rendered, nil
+	}
+
+	n.mu.RLock()
+	tmpl, ok := n.templates[msg.Template]
+	n.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrorTemplateNotFound{Name: msg.Template}
+	}
+
+	var title, body bytes.Buffer
+
+	if err := tmpl.ExecuteTemplate(&title, "title", msg.Data); err != nil {
+		return nil, err
+	}
+
+	if err := tmpl.ExecuteTemplate(&body, "body", msg.Data); err != nil {
+		return nil, err
+	}
+
+	rendered.Title, rendered.Body = title.String(), body.String()
+
+	return &rendered, nil
+}
+
+func (n *Notifier) report(ctx context.Context, s Status) {
+	n.mu.RLock()
+	handlers := n.handlers
+	n.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(ctx, s)
+	}
+}
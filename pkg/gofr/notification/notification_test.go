@@ -0,0 +1,149 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+var errProvider = errors.New("provider is down")
+
+func TestNotifier_Send(t *testing.T) {
+	sms := NewMockProvider(SMS)
+	n := New(WithProvider(sms))
+
+	require.NoError(t, n.AddTemplate("otp", Template{Body: "Your code is {{.Code}}"}))
+
+	testCases := []struct {
+		desc string
+		msg  *Message
+		id   string
+		body string
+		err  error
+	}{
+		{"body", &Message{Channel: SMS, To: "+14155550100", Body: "hello"}, "mock-1", "hello", nil},
+		{"template", &Message{Channel: SMS, To: "+14155550100", Template: "otp", Data: map[string]string{"Code": "1234"}},
+			"mock-2", "Your code is 1234", nil},
+		{"unknown template", &Message{Channel: SMS, To: "+14155550100", Template: "welcome"}, "", "",
+			ErrorTemplateNotFound{Name: "welcome"}},
+		{"no recipient", &Message{Channel: SMS, Body: "hello"}, "", "", ErrNoRecipient},
+		{"no provider", &Message{Channel: Push, To: "token", Body: "hello"}, "", "", ErrorNoProvider{Channel: Push}},
+	}
+
+	for i, tc := range testCases {
+		id, err := n.Send(context.Background(), tc.msg)
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.id, id, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if err == nil {
+			sent := sms.Sent()
+			assert.Equal(t, tc.body, sent[len(sent)-1].Body, "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestNotifier_AddTemplate_Invalid(t *testing.T) {
+	n := New()
+
+	assert.Error(t, n.AddTemplate("otp", Template{Body: "{{.Code"}))
+	assert.Error(t, n.AddTemplate("otp", Template{Title: "{{end}}"}))
+}
+
+func TestNotifier_RateLimit(t *testing.T) {
+	c := clock.NewFake(time.Now())
+	n := New(WithProvider(NewMockProvider(SMS)), WithRateLimit(2, time.Hour), WithClock(c))
+	msg := &Message{Channel: SMS, To: "+14155550100", Body: "hello"}
+
+	for i := 0; i < 2; i++ {
+		_, err := n.Send(context.Background(), msg)
+		require.NoError(t, err)
+	}
+
+	_, err := n.Send(context.Background(), msg)
+	assert.Equal(t, ErrorRateLimited{To: "+14155550100"}, err)
+
+	_, err = n.Send(context.Background(), &Message{Channel: SMS, To: "+14155550101", Body: "hello"})
+	require.NoError(t, err, "the other recipients are not limited")
+
+	c.Advance(time.Hour)
+
+	_, err = n.Send(context.Background(), msg)
+	assert.NoError(t, err, "the limit is reset once the window is over")
+}
+
+func TestNotifier_OnStatus(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sms := NewMockProvider(SMS)
+	n := New(WithProvider(sms), WithClock(clock.NewFake(now)))
+
+	var statuses []Status
+
+	n.OnStatus(func(_ context.Context, s Status) {
+		statuses = append(statuses, s)
+	})
+
+	_, err := n.Send(context.Background(), &Message{Channel: SMS, To: "+14155550100", Body: "hello"})
+	require.NoError(t, err)
+
+	sms.Fail(errProvider)
+
+	_, err = n.Send(context.Background(), &Message{Channel: SMS, To: "+14155550100", Body: "hello"})
+	require.ErrorIs(t, err, errProvider)
+
+	assert.Equal(t, []Status{
+		{ID: "mock-1", Provider: "mock", Channel: SMS, To: "+14155550100", State: StateSent, Time: now},
+		{Provider: "mock", Channel: SMS, To: "+14155550100", State: StateFailed, Error: errProvider.Error(), Time: now},
+	}, statuses)
+}
+
+func TestNotifier_CallbackHandler(t *testing.T) {
+	const callbackURL = "https://example.com/.well-known/notifications/twilio"
+
+	n := New(WithProvider(NewTwilio(TwilioConfig{AuthToken: "secret", StatusCallbackURL: callbackURL})))
+
+	var statuses []Status
+
+	n.OnStatus(func(_ context.Context, s Status) {
+		statuses = append(statuses, s)
+	})
+
+	form := url.Values{"MessageSid": {"SM1"}, "MessageStatus": {"delivered"}, "To": {"+14155550100"}}
+
+	testCases := []struct {
+		desc      string
+		provider  string
+		signature string
+		status    int
+	}{
+		{"valid signature", "twilio", twilioSignature("secret", callbackURL, form), http.StatusNoContent},
+		{"invalid signature", "twilio", "invalid", http.StatusBadRequest},
+		{"provider without callbacks", "mock", "", http.StatusNotFound},
+	}
+
+	for i, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, callbackURL, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-Twilio-Signature", tc.signature)
+
+		w := httptest.NewRecorder()
+
+		n.CallbackHandler(tc.provider).ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "SM1", statuses[0].ID)
+	assert.Equal(t, StateDelivered, statuses[0].State)
+}
@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestFCM_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var m fcmMessage
+
+		assert.Equal(t, "/v1/projects/app/messages:send", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&m))
+
+		if m.Message.Token == "unregistered" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"message":"Requested entity was not found."}}`))
+
+			return
+		}
+
+		assert.Equal(t, "Hello", m.Message.Notification.Title)
+		assert.Equal(t, map[string]string{"order": "1"}, m.Message.Data)
+
+		_, _ = w.Write([]byte(`{"name":"projects/app/messages/1"}`))
+	}))
+	defer server.Close()
+
+	fcm := NewFCM(FCMConfig{ProjectID: "app", TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		BaseURL: server.URL})
+
+	id, err := fcm.Send(context.Background(), &Message{To: "device", Title: "Hello", Body: "World",
+		Payload: map[string]string{"order": "1"}})
+	require.NoError(t, err)
+	assert.Equal(t, "projects/app/messages/1", id)
+
+	_, err = fcm.Send(context.Background(), &Message{To: "unregistered"})
+	assert.Equal(t, ErrorProvider{Provider: "fcm", Status: http.StatusNotFound, Message: "Requested entity was not found."}, err)
+}
+
+func TestAPNs_Send(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := jwt.Parse(strings.TrimPrefix(r.Header.Get("Authorization"), "bearer "),
+			func(*jwt.Token) (interface{}, error) { return &key.PublicKey, nil })
+
+		assert.NoError(t, err)
+		assert.Equal(t, "KEY1", token.Header["kid"])
+		assert.Equal(t, "com.example.app", r.Header.Get("Apns-Topic"))
+
+		if r.URL.Path != "/3/device/device" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"reason":"BadDeviceToken"}`))
+
+			return
+		}
+
+		w.Header().Set("Apns-Id", "apns-1")
+	}))
+	defer server.Close()
+
+	apns := NewAPNs(APNsConfig{KeyID: "KEY1", TeamID: "TEAM1", PrivateKey: key, Topic: "com.example.app", BaseURL: server.URL})
+
+	id, err := apns.Send(context.Background(), &Message{To: "device", Title: "Hello", Body: "World"})
+	require.NoError(t, err)
+	assert.Equal(t, "apns-1", id)
+
+	_, err = apns.Send(context.Background(), &Message{To: "invalid"})
+	assert.Equal(t, ErrorProvider{Provider: "apns", Status: http.StatusBadRequest, Message: "BadDeviceToken"}, err)
+}
+
+func TestSNS_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get("Authorization"), "Credential=AKID/")
+		assert.Equal(t, "Publish", r.FormValue("Action"))
+		assert.Equal(t, "+14155550100", r.FormValue("PhoneNumber"))
+
+		_, _ = w.Write([]byte(`<PublishResponse><PublishResult><MessageId>msg-1</MessageId></PublishResult></PublishResponse>`))
+	}))
+	defer server.Close()
+
+	sns := NewSNS(SNSConfig{Region: "us-east-1", AccessKeyID: "AKID", SecretAccessKey: "secret", BaseURL: server.URL})
+
+	id, err := sns.Send(context.Background(), &Message{To: "+14155550100", Body: "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "msg-1", id)
+}
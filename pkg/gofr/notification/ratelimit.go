@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"sync"
+	"time"
+)
+
+// maxTrackedRecipients is the number of the tracked recipients above which the windows which are over are removed.
+const maxTrackedRecipients = 10000
+
+// limiter limits the notifications sent to each recipient of a channel in fixed windows.
+type limiter struct {
+	limit  int
+	window time.Duration
+
+	mu   sync.Mutex
+	sent map[string]*sentWindow
+}
+
+type sentWindow struct {
+	start time.Time
+	count int
+}
+
+// allow reports whether a notification can be sent to the recipient at now, counting it if it can.
+func (l *limiter) allow(channel Channel, to string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := string(channel) + ":" + to
+
+	w, ok := l.sent[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		if !ok && len(l.sent) >= maxTrackedRecipients {
+			l.removeOver(now)
+		}
+
+		w = &sentWindow{start: now}
+		l.sent[key] = w
+	}
+
+	if w.count >= l.limit {
+		return false
+	}
+
+	w.count++
+
+	return true
+}
+
+// removeOver removes the windows which are over at now.
+func (l *limiter) removeOver(now time.Time) {
+	for key, w := range l.sent {
+		if now.Sub(w.start) >= l.window {
+			delete(l.sent, key)
+		}
+	}
+}
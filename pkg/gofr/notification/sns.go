@@ -0,0 +1,146 @@
+package notification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SNSConfig is the configuration of the SMS sent with Amazon SNS.
+type SNSConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken is the token of the temporary credentials, if any.
+	SessionToken string
+	// SenderID is the alphanumeric ID shown as the sender of the SMS, in the countries which support it.
+	SenderID string
+	// BaseURL is the URL of the API of SNS, which is https://sns.<region>.amazonaws.com by default.
+	BaseURL string
+	// Client is the client of the requests, which is http.DefaultClient by default.
+	Client *http.Client
+}
+
+// SNS sends the SMS with Amazon SNS, as transactional messages.
+type SNS struct {
+	config SNSConfig
+	now    func() time.Time
+}
+
+// NewSNS returns the provider of the SMS sent with Amazon SNS.
+func NewSNS(cfg SNSConfig) *SNS {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://sns." + cfg.Region + ".amazonaws.com"
+	}
+
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	return &SNS{config: cfg, now: time.Now}
+}
+
+func (*SNS) Name() string {
+	return "sns"
+}
+
+func (*SNS) Channel() Channel {
+	return SMS
+}
+
+func (s *SNS) Send(ctx context.Context, msg *Message) (string, error) {
+	form := url.Values{
+		"Action":                         {"Publish"},
+		"Version":                        {"2010-03-31"},
+		"PhoneNumber":                    {msg.To},
+		"Message":                        {msg.Body},
+		"MessageAttributes.entry.1.Name": {"AWS.SNS.SMS.SMSType"},
+		"MessageAttributes.entry.1.Value.DataType":    {"String"},
+		"MessageAttributes.entry.1.Value.StringValue": {"Transactional"},
+	}
+
+	if s.config.SenderID != "" {
+		form.Set("MessageAttributes.entry.2.Name", "AWS.SNS.SMS.SenderID")
+		form.Set("MessageAttributes.entry.2.Value.DataType", "String")
+		form.Set("MessageAttributes.entry.2.Value.StringValue", s.config.SenderID)
+	}
+
+	body := form.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.BaseURL+"/", strings.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	s.sign(req, body)
+
+	resp, err := s.config.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	var result struct {
+		MessageID string `xml:"PublishResult>MessageId"`
+		Error     string `xml:"Error>Message"`
+	}
+
+	_ = xml.NewDecoder(resp.Body).Decode(&result)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", ErrorProvider{Provider: s.Name(), Status: resp.StatusCode, Message: result.Error}
+	}
+
+	return result.MessageID, nil
+}
+
+// sign signs the request with the version 4 of the signatures of AWS.
+func (s *SNS) sign(req *http.Request, body string) {
+	now := s.now().UTC()
+	amzDate, date := now.Format("20060102T150405Z"), now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := "content-type;host;x-amz-date"
+	headers := "content-type:" + req.Header.Get("Content-Type") + "\nhost:" + req.URL.Host + "\nx-amz-date:" + amzDate + "\n"
+
+	if s.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.config.SessionToken)
+
+		signedHeaders += ";x-amz-security-token"
+		headers += "x-amz-security-token:" + s.config.SessionToken + "\n"
+	}
+
+	canonicalRequest := strings.Join([]string{req.Method, "/", "", headers, signedHeaders, sha256Hex(body)}, "\n")
+	scope := date + "/" + s.config.Region + "/sns/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex(canonicalRequest)
+
+	key := []byte("AWS4" + s.config.SecretAccessKey)
+	for _, part := range []string{date, s.config.Region, "sns", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.config.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+hex.EncodeToString(hmacSHA256(key, stringToSign)))
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
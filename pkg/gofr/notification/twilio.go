@@ -0,0 +1,157 @@
+package notification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // Twilio signs the callbacks with HMAC-SHA1.
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const twilioBaseURL = "https://api.twilio.com"
+
+// TwilioConfig is the configuration of the SMS sent with Twilio.
+type TwilioConfig struct {
+	AccountSID string
+	AuthToken  string
+	// From is the phone number, or the ID of the messaging service, like "MG...", sending the SMS.
+	From string
+	// StatusCallbackURL is the URL of the /.well-known/notifications/twilio route, called back by Twilio.
+	StatusCallbackURL string
+	// BaseURL is the URL of the API of Twilio, which is https://api.twilio.com by default.
+	BaseURL string
+	// Client is the client of the requests, which is http.DefaultClient by default.
+	Client *http.Client
+}
+
+// Twilio sends the SMS with Twilio, which calls back the application with the statuses of their delivery.
+type Twilio struct {
+	config TwilioConfig
+}
+
+// NewTwilio returns the provider of the SMS sent with Twilio.
+func NewTwilio(cfg TwilioConfig) *Twilio {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = twilioBaseURL
+	}
+
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	return &Twilio{config: cfg}
+}
+
+func (*Twilio) Name() string {
+	return "twilio"
+}
+
+func (*Twilio) Channel() Channel {
+	return SMS
+}
+
+func (t *Twilio) Send(ctx context.Context, msg *Message) (string, error) {
+	form := url.Values{"To": {msg.To}, "Body": {msg.Body}}
+
+	if strings.HasPrefix(t.config.From, "MG") {
+		form.Set("MessagingServiceSid", t.config.From)
+	} else {
+		form.Set("From", t.config.From)
+	}
+
+	if t.config.StatusCallbackURL != "" {
+		form.Set("StatusCallback", t.config.StatusCallbackURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		t.config.BaseURL+"/2010-04-01/Accounts/"+url.PathEscape(t.config.AccountSID)+"/Messages.json",
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	req.SetBasicAuth(t.config.AccountSID, t.config.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.config.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	var body struct {
+		SID     string `json:"sid"`
+		Message string `json:"message"`
+	}
+
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", ErrorProvider{Provider: t.Name(), Status: resp.StatusCode, Message: body.Message}
+	}
+
+	return body.SID, nil
+}
+
+// ParseCallback verifies the X-Twilio-Signature of the callback and returns the status it reports.
+func (t *Twilio) ParseCallback(r *http.Request) (Status, error) {
+	if err := r.ParseForm(); err != nil {
+		return Status{}, err
+	}
+
+	if !t.validSignature(r.Header.Get("X-Twilio-Signature"), r.PostForm) {
+		return Status{}, ErrInvalidSignature
+	}
+
+	s := Status{ID: r.PostForm.Get("MessageSid"), Provider: t.Name(), Channel: SMS, To: r.PostForm.Get("To")}
+
+	switch r.PostForm.Get("MessageStatus") {
+	case "delivered":
+		s.State = StateDelivered
+	case "undelivered":
+		s.State = StateUndelivered
+	case "failed":
+		s.State = StateFailed
+	default:
+		s.State = StateSent
+	}
+
+	if code := r.PostForm.Get("ErrorCode"); code != "" {
+		s.Error = "twilio error " + code
+	}
+
+	return s, nil
+}
+
+// validSignature reports whether the signature of the callback is valid.
+func (t *Twilio) validSignature(signature string, params url.Values) bool {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var b strings.Builder
+
+	b.WriteString(t.config.StatusCallbackURL)
+
+	for _, k := range keys {
+		for _, v := range params[k] {
+			b.WriteString(k)
+			b.WriteString(v)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(t.config.AuthToken))
+	mac.Write([]byte(b.String()))
+
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // Twilio signs the callbacks with HMAC-SHA1.
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func twilioSignature(token, callbackURL string, form url.Values) string {
+	keys := make([]string, 0, len(form))
+	for k := range form {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	s := callbackURL
+	for _, k := range keys {
+		s += k + form.Get(k)
+	}
+
+	mac := hmac.New(sha1.New, []byte(token))
+	mac.Write([]byte(s))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestTwilio_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+
+		assert.Equal(t, "/2010-04-01/Accounts/AC1/Messages.json", r.URL.Path)
+		assert.Equal(t, "AC1", user)
+		assert.Equal(t, "secret", pass)
+		assert.Equal(t, "+14155550100", r.FormValue("To"))
+		assert.Equal(t, "+14155550199", r.FormValue("From"))
+		assert.Equal(t, "https://example.com/callback", r.FormValue("StatusCallback"))
+
+		if r.FormValue("Body") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"A 'Body' is required"}`))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"sid":"SM1"}`))
+	}))
+	defer server.Close()
+
+	tw := NewTwilio(TwilioConfig{AccountSID: "AC1", AuthToken: "secret", From: "+14155550199",
+		StatusCallbackURL: "https://example.com/callback", BaseURL: server.URL})
+
+	id, err := tw.Send(context.Background(), &Message{To: "+14155550100", Body: "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "SM1", id)
+
+	_, err = tw.Send(context.Background(), &Message{To: "+14155550100"})
+	assert.Equal(t, ErrorProvider{Provider: "twilio", Status: http.StatusBadRequest, Message: "A 'Body' is required"}, err)
+}
@@ -0,0 +1,73 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/notification"
+)
+
+// notificationCallbackPath is the path of the callbacks of the providers, which are signed instead of authenticated.
+const notificationCallbackPath = "/.well-known/notifications/{provider}"
+
+// Notify sends the SMS or the push notification with the notifier of the application, returning its ID.
+//
+//	Usage:
+//	id, err := ctx.Notify(&notification.Message{
+//		Channel:  notification.SMS,
+//		To:       user.Phone,
+//		Template: "otp",
+//		Data:     map[string]string{"Code": code},
+//	})
+func (c *Context) Notify(msg *notification.Message) (string, error) {
+	return c.Container.Notify(c.Context, msg)
+}
+
+// SetNotifier replaces the notifier of the NOTIFICATION_* configs.
+func (a *App) SetNotifier(n *notification.Notifier) {
+	a.container.SetNotifier(n)
+}
+
+// AddNotificationTemplate adds the template of the title and the body of the notifications of the name.
+func (a *App) AddNotificationTemplate(name string, t notification.Template) {
+	n := a.container.Notifier()
+	if n == nil {
+		a.container.Errorf("notification template %s is not added, as notifications are not configured", name)
+
+		return
+	}
+
+	if err := n.AddTemplate(name, t); err != nil {
+		a.container.Errorf("notification template %s is not added: %v", name, err)
+	}
+}
+
+// OnNotificationStatus registers the handler of the statuses of the notifications.
+func (a *App) OnNotificationStatus(handler func(ctx *Context, s notification.Status)) {
+	n := a.container.Notifier()
+	if n == nil {
+		a.container.Errorf("notification status handler is not added, as notifications are not configured")
+
+		return
+	}
+
+	n.OnStatus(func(ctx context.Context, s notification.Status) {
+		handler(&Context{Context: ctx, Container: a.container, Request: noopRequest{}}, s)
+	})
+}
+
+// notificationCallbackHandler serves the callbacks of the providers of the notifier of the container.
+func (a *App) notificationCallbackHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := a.container.Notifier()
+		if n == nil {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		n.CallbackHandler(mux.Vars(r)["provider"]).ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,61 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/notification"
+)
+
+func TestContext_Notify(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+	a := &App{container: c}
+
+	a.AddNotificationTemplate("otp", notification.Template{Body: "Your code is {{.Code}}"})
+
+	var statuses []notification.Status
+
+	a.OnNotificationStatus(func(_ *Context, s notification.Status) {
+		statuses = append(statuses, s)
+	})
+
+	ctx := &Context{Context: context.Background(), Container: c}
+
+	id, err := ctx.Notify(&notification.Message{Channel: notification.SMS, To: "+14155550100", Template: "otp",
+		Data: map[string]string{"Code": "1234"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "mock-1", id)
+	assert.Equal(t, "Your code is 1234", mocks.SMS.Sent()[0].Body)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, notification.StateSent, statuses[0].State)
+}
+
+func TestApp_NotificationsNotConfigured(t *testing.T) {
+	a := &App{container: &container.Container{}}
+
+	_, err := (&Context{Context: context.Background(), Container: a.container}).Notify(&notification.Message{})
+	require.Error(t, err)
+
+	router := mux.NewRouter()
+	router.Handle(notificationCallbackPath, a.notificationCallbackHandler())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/.well-known/notifications/twilio", http.NoBody))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	a.SetNotifier(notification.New(notification.WithProvider(notification.NewTwilio(notification.TwilioConfig{}))))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/.well-known/notifications/twilio", http.NoBody))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "the callbacks which are not signed are rejected")
+}
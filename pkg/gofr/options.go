@@ -0,0 +1,77 @@
+package gofr
+
+import (
+	"strconv"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+// Option configures the application created by New, taking precedence over its configs.
+type Option func(o *options)
+
+type options struct {
+	config config.Config
+	logger logging.Logger
+
+	httpPort    int
+	metricsPort int
+	grpcPort    int
+
+	withoutMetricsServer bool
+}
+
+// WithConfig makes the application read its configs from cfg.
+func WithConfig(cfg config.Config) Option {
+	return func(o *options) {
+		o.config = cfg
+	}
+}
+
+// WithLogger makes the application log with l.
+func WithLogger(l logging.Logger) Option {
+	return func(o *options) {
+		o.logger = l
+	}
+}
+
+// WithHTTPPort serves the HTTP routes on the port, instead of the one of the HTTP_PORT config.
+func WithHTTPPort(port int) Option {
+	return func(o *options) {
+		o.httpPort = port
+	}
+}
+
+// WithMetricsPort serves the metrics on the port, instead of METRICS_PORT.
+func WithMetricsPort(port int) Option {
+	return func(o *options) {
+		o.metricsPort = port
+	}
+}
+
+// WithGRPCPort serves the gRPC services on the port, instead of the one of the GRPC_PORT config.
+func WithGRPCPort(port int) Option {
+	return func(o *options) {
+		o.grpcPort = port
+	}
+}
+
+// WithoutMetricsServer does not run the metrics server. The metrics are still recorded.
+func WithoutMetricsServer() Option {
+	return func(o *options) {
+		o.withoutMetricsServer = true
+	}
+}
+
+// portFrom returns the port set by an option, or the one of the config, or the default port if neither is positive.
+func portFrom(option int, c config.Config, key string, defaultPort int) int {
+	if option > 0 {
+		return option
+	}
+
+	if p, err := strconv.Atoi(c.Get(key)); err == nil && p > 0 {
+		return p
+	}
+
+	return defaultPort
+}
@@ -0,0 +1,66 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+func TestNew_Options(t *testing.T) {
+	logger := logging.NewLogger(logging.ERROR)
+
+	app := New(WithConfig(config.NewMockConfig(map[string]string{"APP_NAME": "orders", "HTTP_PORT": "8001",
+		"GRPC_PORT": "9001"})), WithLogger(logger), WithHTTPPort(9000), WithoutMetricsServer())
+
+	assert.Equal(t, "orders", app.Config.Get("APP_NAME"))
+	assert.Equal(t, "orders", app.container.GetAppName())
+	assert.Same(t, logger, app.container.Logger)
+	assert.Equal(t, 9000, app.httpServer.port)
+	assert.Equal(t, 9001, app.grpcServer.port)
+	assert.Nil(t, app.metricServer)
+
+	app.GET("/hello", func(*Context) (interface{}, error) {
+		return "Hello World!", nil
+	})
+
+	w := httptest.NewRecorder()
+	app.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hello", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, app.Shutdown(context.Background()))
+}
+
+func TestNew_WithMetricsPort(t *testing.T) {
+	app := New(WithConfig(config.NewMockConfig(map[string]string{"METRICS_PORT": "2200"})), WithMetricsPort(2300),
+		WithGRPCPort(9100))
+
+	assert.Equal(t, 2300, app.metricServer.port)
+	assert.Equal(t, 9100, app.grpcServer.port)
+	assert.Equal(t, defaultHTTPPort, app.httpServer.port)
+}
+
+func TestPortFrom(t *testing.T) {
+	c := config.NewMockConfig(map[string]string{"HTTP_PORT": "8001", "GRPC_PORT": "-1", "METRICS_PORT": "none"})
+
+	tests := []struct {
+		option int
+		key    string
+		port   int
+	}{
+		{9000, "HTTP_PORT", 9000},
+		{0, "HTTP_PORT", 8001},
+		{0, "GRPC_PORT", 8000},
+		{0, "METRICS_PORT", 8000},
+		{-1, "UNSET_PORT", 8000},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.port, portFrom(tc.option, c, tc.key, 8000), "TEST[%d], Failed.\n%s", i, tc.key)
+	}
+}
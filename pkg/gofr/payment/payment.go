@@ -0,0 +1,81 @@
+// Package payment verifies the signatures of the webhooks of Stripe, PayPal and Razorpay.
+package payment
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+const (
+	// maxBodySize is the size of the largest webhook read to be verified.
+	maxBodySize = 1 << 20
+
+	defaultTolerance = 5 * time.Minute
+)
+
+var (
+	// ErrInvalidSignature is returned for the webhooks whose signature is missing, invalid or too old.
+	ErrInvalidSignature = errors.New("invalid payment webhook signature")
+
+	errBodyTooLarge  = errors.New("payment webhook body is too large")
+	errMissingEntity = errors.New("missing entity")
+)
+
+// Verifier verifies the signature of the webhooks of a payment provider.
+type Verifier interface {
+	// Provider is the name of the provider, like "stripe".
+	Provider() string
+	// Verify returns ErrInvalidSignature if the webhook of the headers and the body is not signed by the provider.
+	Verify(header http.Header, body []byte) error
+}
+
+// VerifySignature is the middleware rejecting with 401 the webhooks which are not signed by the provider.
+//
+//	Usage:
+//	router.Handle("/webhooks/stripe", payment.VerifySignature(payment.NewStripe(cfg))(handler))
+func VerifySignature(v Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+			if err != nil {
+				http.Error(w, "Bad Request: the webhook could not be read", http.StatusBadRequest)
+				return
+			}
+
+			if len(body) > maxBodySize {
+				http.Error(w, errBodyTooLarge.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			if err = v.Verify(r.Header, body); err != nil {
+				http.Error(w, "Unauthorized: invalid "+v.Provider()+" webhook signature", http.StatusUnauthorized)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClockUser is implemented by the verifiers checking the time of the webhooks.
+type ClockUser interface {
+	// UseClock sets the clock of the verifier, unless one is configured.
+	UseClock(c clock.Clock)
+}
+
+// withinTolerance reports whether the webhook sent at the time is recent enough not to be a replay.
+func withinTolerance(c clock.Clock, sent time.Time, tolerance time.Duration) bool {
+	if tolerance <= 0 {
+		tolerance = defaultTolerance
+	}
+
+	return c.Since(sent).Abs() <= tolerance
+}
@@ -0,0 +1,177 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+const stripeEvent = `{"id":"evt_1","type":"payment_intent.succeeded","created":1714000000,"livemode":false,
+"data":{"object":{"id":"pi_1","amount":2000,"currency":"usd"}}}`
+
+func hmacHex(secret, message string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func stripeSignature(secret string, t time.Time, body string) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+
+	return fmt.Sprintf("t=%s,v1=%s", ts, hmacHex(secret, ts+"."+body))
+}
+
+func TestStripe_Verify(t *testing.T) {
+	now := time.Now()
+	stripe := NewStripe(StripeConfig{Secret: "whsec_test"})
+
+	testCases := []struct {
+		desc      string
+		signature string
+		err       error
+	}{
+		{"valid", stripeSignature("whsec_test", now, stripeEvent), nil},
+		{"rolled secret", stripeSignature("whsec_old", now, stripeEvent) + ",v1=" +
+			hmacHex("whsec_test", strconv.FormatInt(now.Unix(), 10)+"."+stripeEvent), nil},
+		{"wrong secret", stripeSignature("whsec_other", now, stripeEvent), ErrInvalidSignature},
+		{"too old", stripeSignature("whsec_test", now.Add(-time.Hour), stripeEvent), ErrInvalidSignature},
+		{"missing", "", ErrInvalidSignature},
+		{"not hex", "t=" + strconv.FormatInt(now.Unix(), 10) + ",v1=zz", ErrInvalidSignature},
+	}
+
+	for i, tc := range testCases {
+		header := http.Header{StripeSignatureHeader: {tc.signature}}
+
+		assert.Equal(t, tc.err, stripe.Verify(header, []byte(stripeEvent)), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestStripe_VerifyWithClock(t *testing.T) {
+	sent := time.Date(2024, 4, 25, 10, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(sent)
+	stripe := NewStripe(StripeConfig{Secret: "whsec_test", Clock: fake})
+	header := http.Header{StripeSignatureHeader: {stripeSignature("whsec_test", sent, stripeEvent)}}
+
+	require.NoError(t, stripe.Verify(header, []byte(stripeEvent)))
+
+	stripe.UseClock(clock.NewFake(sent.Add(time.Hour)))
+	require.NoError(t, stripe.Verify(header, []byte(stripeEvent)), "TEST Failed.\nthe configured clock is replaced")
+
+	fake.Advance(time.Hour)
+	assert.Equal(t, ErrInvalidSignature, stripe.Verify(header, []byte(stripeEvent)))
+}
+
+func TestVerify_EmptySecret(t *testing.T) {
+	now := time.Now()
+	body := []byte(stripeEvent)
+
+	stripe := NewStripe(StripeConfig{})
+	razorpay := NewRazorpay(RazorpayConfig{})
+
+	assert.Equal(t, ErrInvalidSignature, stripe.Verify(http.Header{StripeSignatureHeader: {stripeSignature("", now, stripeEvent)}},
+		body))
+	assert.Equal(t, ErrInvalidSignature, razorpay.Verify(http.Header{RazorpaySignatureHeader: {hmacHex("", stripeEvent)}},
+		body))
+}
+
+func TestStripeEvent_BindObject(t *testing.T) {
+	var (
+		event  StripeEvent
+		intent struct {
+			ID     string `json:"id"`
+			Amount int    `json:"amount"`
+		}
+	)
+
+	require.NoError(t, json.Unmarshal([]byte(stripeEvent), &event))
+	require.NoError(t, event.BindObject(&intent))
+
+	assert.Equal(t, "payment_intent.succeeded", event.Type)
+	assert.Equal(t, "pi_1", intent.ID)
+	assert.Equal(t, 2000, intent.Amount)
+}
+
+func TestRazorpay_Verify(t *testing.T) {
+	body := `{"entity":"event","event":"payment.captured","contains":["payment"],` +
+		`"payload":{"payment":{"entity":{"id":"pay_1","amount":5000}}},"created_at":1714000000}`
+	razorpay := NewRazorpay(RazorpayConfig{Secret: "secret"})
+
+	testCases := []struct {
+		desc      string
+		signature string
+		err       error
+	}{
+		{"valid", hmacHex("secret", body), nil},
+		{"wrong secret", hmacHex("other", body), ErrInvalidSignature},
+		{"missing", "", ErrInvalidSignature},
+	}
+
+	for i, tc := range testCases {
+		header := http.Header{RazorpaySignatureHeader: {tc.signature}}
+
+		assert.Equal(t, tc.err, razorpay.Verify(header, []byte(body)), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	var (
+		event   RazorpayEvent
+		payment struct {
+			ID     string `json:"id"`
+			Amount int    `json:"amount"`
+		}
+	)
+
+	require.NoError(t, json.Unmarshal([]byte(body), &event))
+	require.NoError(t, event.BindEntity("payment", &payment))
+
+	assert.Equal(t, "pay_1", payment.ID)
+	assert.ErrorIs(t, event.BindEntity("order", &payment), errMissingEntity)
+}
+
+func TestVerifySignature(t *testing.T) {
+	handler := VerifySignature(NewStripe(StripeConfig{Secret: "whsec_test"}))(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		}))
+
+	testCases := []struct {
+		desc      string
+		body      string
+		signature string
+		status    int
+	}{
+		{"verified", stripeEvent, stripeSignature("whsec_test", time.Now(), stripeEvent), http.StatusOK},
+		{"not signed", stripeEvent, "", http.StatusUnauthorized},
+		{"too large", strings.Repeat("a", maxBodySize+1), "", http.StatusRequestEntityTooLarge},
+	}
+
+	for i, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/stripe", strings.NewReader(tc.body))
+		req.Header.Set(StripeSignatureHeader, tc.signature)
+
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.status == http.StatusOK {
+			assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
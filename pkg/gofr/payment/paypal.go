@@ -0,0 +1,205 @@
+package payment
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+// The headers of the signature of the webhooks of PayPal.
+const (
+	PayPalTransmissionIDHeader   = "Paypal-Transmission-Id"
+	PayPalTransmissionTimeHeader = "Paypal-Transmission-Time"
+	PayPalTransmissionSigHeader  = "Paypal-Transmission-Sig"
+	PayPalCertURLHeader          = "Paypal-Cert-Url"
+	PayPalAuthAlgoHeader         = "Paypal-Auth-Algo"
+
+	maxCertSize = 64 << 10
+	// maxCachedCerts is the number of certificates cached, PayPal signing with a few certificates at a time.
+	maxCachedCerts = 16
+)
+
+var errInvalidCertificate = errors.New("invalid paypal certificate")
+
+// PayPalConfig is the configuration of the verification of the webhooks of PayPal.
+type PayPalConfig struct {
+	// WebhookID is the ID of the webhook registered on PayPal, which is signed with the webhooks.
+	WebhookID string
+	// Tolerance is the age of the oldest webhook accepted, which is 5 minutes by default.
+	Tolerance time.Duration
+	// Client is the client downloading the certificates of PayPal, which is http.DefaultClient by default.
+	Client *http.Client
+	// Clock tells the age of the webhooks, and is the clock of the app, or of the system, if nil.
+	Clock clock.Clock
+}
+
+// PayPal verifies the webhooks of PayPal, signed by the certificate of their Paypal-Cert-Url.
+type PayPal struct {
+	config PayPalConfig
+	clock  clock.Clock
+
+	// trustedCertURL reports whether the certificates can be downloaded from the URL.
+	trustedCertURL func(u *url.URL) bool
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+// NewPayPal returns the verifier of the webhooks of PayPal.
+func NewPayPal(cfg PayPalConfig) *PayPal {
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	p := &PayPal{config: cfg, clock: cfg.Clock, trustedCertURL: isPayPalURL, certs: make(map[string]*x509.Certificate)}
+	if p.clock == nil {
+		p.clock = clock.New()
+	}
+
+	return p
+}
+
+func (p *PayPal) UseClock(c clock.Clock) {
+	if p.config.Clock == nil {
+		p.clock = c
+	}
+}
+
+func (*PayPal) Provider() string {
+	return "paypal"
+}
+
+// Verify checks the signature of the webhook.
+func (p *PayPal) Verify(header http.Header, body []byte) error {
+	if header.Get(PayPalAuthAlgoHeader) != "SHA256withRSA" {
+		return ErrInvalidSignature
+	}
+
+	sent, err := time.Parse(time.RFC3339, header.Get(PayPalTransmissionTimeHeader))
+	if err != nil || !withinTolerance(p.clock, sent, p.config.Tolerance) {
+		return ErrInvalidSignature
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(header.Get(PayPalTransmissionSigHeader))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	cert, err := p.certificate(header.Get(PayPalCertURLHeader))
+	if err != nil {
+		return err
+	}
+
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	message := fmt.Sprintf("%s|%s|%s|%d", header.Get(PayPalTransmissionIDHeader), header.Get(PayPalTransmissionTimeHeader),
+		p.config.WebhookID, crc32.ChecksumIEEE(body))
+	digest := sha256.Sum256([]byte(message))
+
+	if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) != nil {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// certificate returns the valid certificate of the PayPal URL, downloaded unless it is cached.
+func (p *PayPal) certificate(rawURL string) (*x509.Certificate, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || !p.trustedCertURL(u) {
+		return nil, ErrInvalidSignature
+	}
+
+	certURL := (&url.URL{Scheme: strings.ToLower(u.Scheme), Host: strings.ToLower(u.Host), Path: u.Path}).String()
+
+	p.mu.Lock()
+	cert, ok := p.certs[certURL]
+	p.mu.Unlock()
+
+	if !ok {
+		if cert, err = p.download(certURL); err != nil {
+			return nil, err
+		}
+
+		p.mu.Lock()
+		if len(p.certs) >= maxCachedCerts {
+			clear(p.certs)
+		}
+
+		p.certs[certURL] = cert
+		p.mu.Unlock()
+	}
+
+	if now := p.clock.Now(); now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, ErrInvalidSignature
+	}
+
+	return cert, nil
+}
+
+func (p *PayPal) download(certURL string) (*x509.Certificate, error) {
+	resp, err := p.config.Client.Get(certURL) //nolint:noctx // the certificates are cached, whatever the request.
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s responded with %d", errInvalidCertificate, certURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCertSize))
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %s is not a PEM certificate", errInvalidCertificate, certURL)
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// isPayPalURL reports whether the URL is an HTTPS URL of paypal.com.
+func isPayPalURL(u *url.URL) bool {
+	host := u.Hostname()
+
+	return u.Scheme == "https" && (host == "paypal.com" || strings.HasSuffix(host, ".paypal.com"))
+}
+
+// PayPalEvent is the event of a webhook of PayPal, like "PAYMENT.CAPTURE.COMPLETED".
+type PayPalEvent struct {
+	ID           string    `json:"id"`
+	EventType    string    `json:"event_type"`
+	EventVersion string    `json:"event_version"`
+	ResourceType string    `json:"resource_type"`
+	Summary      string    `json:"summary"`
+	CreateTime   time.Time `json:"create_time"`
+	// Resource is the resource of the event, like the capture, which is bound by BindResource.
+	Resource json.RawMessage `json:"resource"`
+}
+
+// BindResource binds the resource of the event, like the capture of the "PAYMENT.CAPTURE.*" events.
+func (e *PayPalEvent) BindResource(v interface{}) error {
+	return json.Unmarshal(e.Resource, v)
+}
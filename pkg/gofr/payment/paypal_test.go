@@ -0,0 +1,150 @@
+package payment
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"hash/crc32"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const payPalEvent = `{"id":"WH-1","event_type":"PAYMENT.CAPTURE.COMPLETED","resource_type":"capture",
+"create_time":"2024-04-25T10:00:00Z","resource":{"id":"CAP-1","status":"COMPLETED"}}`
+
+// payPalServer serves the certificate of the key, and counts its downloads.
+func payPalServer(t *testing.T, key *rsa.PrivateKey) (server *httptest.Server, downloads *atomic.Int32) {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "messageverificationcerts.paypal.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	downloads = &atomic.Int32{}
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		downloads.Add(1)
+
+		_ = pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, downloads
+}
+
+func payPalHeader(t *testing.T, key *rsa.PrivateKey, certURL, webhookID string, sent time.Time) http.Header {
+	t.Helper()
+
+	transmissionTime := sent.UTC().Format(time.RFC3339)
+	message := fmt.Sprintf("%s|%s|%s|%d", "tx-1", transmissionTime, webhookID, crc32.ChecksumIEEE([]byte(payPalEvent)))
+	digest := sha256.Sum256([]byte(message))
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return http.Header{
+		PayPalTransmissionIDHeader:   {"tx-1"},
+		PayPalTransmissionTimeHeader: {transmissionTime},
+		PayPalTransmissionSigHeader:  {base64.StdEncoding.EncodeToString(sig)},
+		PayPalCertURLHeader:          {certURL},
+		PayPalAuthAlgoHeader:         {"SHA256withRSA"},
+	}
+}
+
+func TestPayPal_Verify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server, downloads := payPalServer(t, key)
+
+	paypal := NewPayPal(PayPalConfig{WebhookID: "WH-ID"})
+	paypal.trustedCertURL = func(u *url.URL) bool { return u.Host == server.Listener.Addr().String() }
+
+	certURL := server.URL + "/cert.pem"
+	now := time.Now()
+
+	testCases := []struct {
+		desc   string
+		header http.Header
+		err    error
+	}{
+		{"valid", payPalHeader(t, key, certURL, "WH-ID", now), nil},
+		{"other webhook", payPalHeader(t, key, certURL, "WH-OTHER", now), ErrInvalidSignature},
+		{"too old", payPalHeader(t, key, certURL, "WH-ID", now.Add(-time.Hour)), ErrInvalidSignature},
+		{"untrusted certificate", payPalHeader(t, key, "https://example.com/cert.pem", "WH-ID", now), ErrInvalidSignature},
+		{"missing", http.Header{}, ErrInvalidSignature},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.err, paypal.Verify(tc.header, []byte(payPalEvent)), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	assert.Equal(t, int32(1), downloads.Load(), "TEST Failed.\nthe certificate is not cached")
+
+	for i := 0; i < 2*maxCachedCerts; i++ {
+		header := payPalHeader(t, key, fmt.Sprintf("%s/cert-%d.pem?v=%d#f", server.URL, i%(maxCachedCerts+1), i), "WH-ID", now)
+
+		require.NoError(t, paypal.Verify(header, []byte(payPalEvent)))
+	}
+
+	assert.LessOrEqual(t, len(paypal.certs), maxCachedCerts, "TEST Failed.\nthe cache of the certificates is not bounded")
+	assert.NotContains(t, paypal.certs, server.URL+"/cert-0.pem?v=0#f")
+}
+
+func TestIsPayPalURL(t *testing.T) {
+	testCases := []struct {
+		url     string
+		trusted bool
+	}{
+		{"https://api.paypal.com/v1/notifications/certs/CERT-360caa42", true},
+		{"https://api.sandbox.paypal.com/v1/notifications/certs/CERT-360caa42", true},
+		{"http://api.paypal.com/cert", false},
+		{"https://paypal.com.example.com/cert", false},
+		{"https://evilpaypal.com/cert", false},
+	}
+
+	for i, tc := range testCases {
+		u, err := url.Parse(tc.url)
+		require.NoError(t, err)
+
+		assert.Equal(t, tc.trusted, isPayPalURL(u), "TEST[%d], Failed.\n%s", i, tc.url)
+	}
+}
+
+func TestPayPalEvent_BindResource(t *testing.T) {
+	var (
+		event   PayPalEvent
+		capture struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		}
+	)
+
+	require.NoError(t, json.Unmarshal([]byte(payPalEvent), &event))
+	require.NoError(t, event.BindResource(&capture))
+
+	assert.Equal(t, "PAYMENT.CAPTURE.COMPLETED", event.EventType)
+	assert.Equal(t, "CAP-1", capture.ID)
+}
@@ -0,0 +1,78 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// RazorpaySignatureHeader is the header of the signature of the webhooks of Razorpay.
+const RazorpaySignatureHeader = "X-Razorpay-Signature"
+
+// RazorpayConfig is the configuration of the verification of the webhooks of Razorpay.
+type RazorpayConfig struct {
+	// Secret is the secret of the webhook set on the dashboard of Razorpay.
+	Secret string
+}
+
+// Razorpay verifies the webhooks of Razorpay, signed with the HMAC-SHA256 of their body.
+type Razorpay struct {
+	config RazorpayConfig
+}
+
+// NewRazorpay returns the verifier of the webhooks of Razorpay. Without a secret, it rejects all the webhooks.
+func NewRazorpay(cfg RazorpayConfig) *Razorpay {
+	return &Razorpay{config: cfg}
+}
+
+func (*Razorpay) Provider() string {
+	return "razorpay"
+}
+
+func (r *Razorpay) Verify(header http.Header, body []byte) error {
+	if r.config.Secret == "" {
+		return ErrInvalidSignature
+	}
+
+	decoded, err := hex.DecodeString(header.Get(RazorpaySignatureHeader))
+	if err != nil || len(decoded) == 0 {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(r.config.Secret))
+	mac.Write(body)
+
+	if !hmac.Equal(decoded, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// RazorpayEvent is the event of a webhook of Razorpay, like "payment.captured".
+type RazorpayEvent struct {
+	Entity    string `json:"entity"`
+	AccountID string `json:"account_id"`
+	Event     string `json:"event"`
+	// Contains are the names of the entities of the payload, like "payment" and "order".
+	Contains []string `json:"contains"`
+	// Payload are the entities of the event by their names, which are bound by BindEntity.
+	Payload map[string]struct {
+		Entity json.RawMessage `json:"entity"`
+	} `json:"payload"`
+	// CreatedAt is the time of the event in seconds since the Unix epoch.
+	CreatedAt int64 `json:"created_at"`
+}
+
+// BindEntity binds the entity of the payload of the name, like "payment", or returns an error if the event has none.
+func (e *RazorpayEvent) BindEntity(name string, v interface{}) error {
+	p, ok := e.Payload[name]
+	if !ok {
+		return fmt.Errorf("%w: razorpay event %s has no %s entity", errMissingEntity, e.Event, name)
+	}
+
+	return json.Unmarshal(p.Entity, v)
+}
@@ -0,0 +1,115 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
+)
+
+// StripeSignatureHeader is the header of the signature of the webhooks of Stripe.
+const StripeSignatureHeader = "Stripe-Signature"
+
+// StripeConfig is the configuration of the verification of the webhooks of Stripe.
+type StripeConfig struct {
+	// Secret is the signing secret of the endpoint of the webhooks, like "whsec_...".
+	Secret string
+	// Tolerance is the age of the oldest webhook accepted, which is 5 minutes by default.
+	Tolerance time.Duration
+	// Clock tells the age of the webhooks, and is the clock of the app, or of the system, if nil.
+	Clock clock.Clock
+}
+
+// Stripe verifies the webhooks of Stripe, which are signed with the HMAC-SHA256 of their time and their body.
+type Stripe struct {
+	config StripeConfig
+	clock  clock.Clock
+}
+
+// NewStripe returns the verifier of the webhooks of Stripe. Without a secret, it rejects all the webhooks.
+func NewStripe(cfg StripeConfig) *Stripe {
+	s := &Stripe{config: cfg, clock: cfg.Clock}
+	if s.clock == nil {
+		s.clock = clock.New()
+	}
+
+	return s
+}
+
+func (s *Stripe) UseClock(c clock.Clock) {
+	if s.config.Clock == nil {
+		s.clock = c
+	}
+}
+
+func (*Stripe) Provider() string {
+	return "stripe"
+}
+
+// Verify checks the Stripe-Signature header, "t=<unix time>,v1=<signature>".
+func (s *Stripe) Verify(header http.Header, body []byte) error {
+	if s.config.Secret == "" {
+		return ErrInvalidSignature
+	}
+
+	var (
+		ts         string
+		signatures []string
+	)
+
+	for _, part := range strings.Split(header.Get(StripeSignatureHeader), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+
+		switch name {
+		case "t":
+			ts = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || !withinTolerance(s.clock, time.Unix(unix, 0), s.config.Tolerance) {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.config.Secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	for _, sig := range signatures {
+		if decoded, decodeErr := hex.DecodeString(sig); decodeErr == nil && hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+// StripeEvent is the event of a webhook of Stripe, like "payment_intent.succeeded".
+type StripeEvent struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	APIVersion string `json:"api_version"`
+	// Created is the time of the event in seconds since the Unix epoch.
+	Created  int64 `json:"created"`
+	Livemode bool  `json:"livemode"`
+	Data     struct {
+		// Object is the object of the event, like the PaymentIntent, which is bound by BindObject.
+		Object json.RawMessage `json:"object"`
+		// PreviousAttributes are the values of the attributes changed by the "*.updated" events.
+		PreviousAttributes json.RawMessage `json:"previous_attributes,omitempty"`
+	} `json:"data"`
+}
+
+// BindObject binds the object of the event, like the PaymentIntent of the "payment_intent.*" events.
+func (e *StripeEvent) BindObject(v interface{}) error {
+	return json.Unmarshal(e.Data.Object, v)
+}
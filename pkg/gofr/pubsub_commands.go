@@ -0,0 +1,134 @@
+package gofr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+var (
+	errOffsetsNotSupported = errors.New("the pubsub backend does not support seeking and replaying the topics")
+	errTopicRequired       = errors.New("-topic is required")
+)
+
+// AddPubSubCommands adds the "pubsub seek" and "pubsub replay" sub-commands to the CLI application.
+//
+//	./admin pubsub seek -topic=orders -time=2024-05-01T10:00:00Z
+//	./admin pubsub replay -topic=orders -from=1200 -to=1300
+func (a *App) AddPubSubCommands(handlers map[string]SubscribeFunc) {
+	a.SubCommand("pubsub seek", seekTopic,
+		AddDescription("Moves the consumer group to an offset or a time of a topic"),
+		AddFlag("topic", "", "topic whose consumer group is moved"),
+		AddFlag("offset", "0", "offset in each partition of the topic"),
+		AddFlag("time", "", "time of the first message to consume, in RFC 3339, used instead of the offset"),
+	)
+
+	a.SubCommand("pubsub replay", replayTopic(handlers),
+		AddDescription("Replays a range of a topic into the handler of the topic"),
+		AddFlag("topic", "", "topic which is replayed"),
+		AddFlag("from", "0", "offset of the first message replayed"),
+		AddFlag("to", "0", "offset after the last message replayed, the end of the topic if 0"),
+		AddFlag("from-time", "", "time of the first message replayed, in RFC 3339, used instead of -from"),
+		AddFlag("to-time", "", "time after the last message replayed, in RFC 3339, used instead of -to"),
+	)
+}
+
+func seekTopic(c *Context) (interface{}, error) {
+	m, topic, err := offsetManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := position(c, "offset", "time")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.Seek(c, topic, pos); err != nil {
+		return nil, err
+	}
+
+	return fmt.Sprintf("moved the consumer group of topic %s to %s", topic, formatPosition(pos)), nil
+}
+
+func replayTopic(handlers map[string]SubscribeFunc) Handler {
+	return func(c *Context) (interface{}, error) {
+		m, topic, err := offsetManager(c)
+		if err != nil {
+			return nil, err
+		}
+
+		handler, ok := handlers[topic]
+		if !ok {
+			return nil, fmt.Errorf("no handler is added for topic %s", topic)
+		}
+
+		from, err := position(c, "from", "from-time")
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := position(c, "to", "to-time")
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := m.Replay(c, topic, from, to, func(msg *pubsub.Message) error {
+			ctx := newContext(nil, msg, c.Container)
+
+			defer panicRecovery(ctx.Logger)
+
+			return handler(ctx)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("replay of topic %s stopped after %d messages: %w", topic, n, err)
+		}
+
+		return fmt.Sprintf("replayed %d messages of topic %s", n, topic), nil
+	}
+}
+
+// offsetManager returns the pubsub client, if it supports seeking and replaying, and the topic of the command.
+func offsetManager(c *Context) (pubsub.OffsetManager, string, error) {
+	m, ok := c.Container.PubSub.(pubsub.OffsetManager)
+	if !ok || isNil(m) {
+		return nil, "", errOffsetsNotSupported
+	}
+
+	topic := c.Param("topic")
+	if topic == "" {
+		return nil, "", errTopicRequired
+	}
+
+	return m, topic, nil
+}
+
+// position returns the position of the flags of an offset and a time, the time being used if it is passed.
+func position(c *Context, offsetFlag, timeFlag string) (pubsub.Position, error) {
+	if t := c.Param(timeFlag); t != "" {
+		at, err := time.Parse(time.RFC3339, t)
+		if err != nil {
+			return pubsub.Position{}, fmt.Errorf("invalid -%s: %w", timeFlag, err)
+		}
+
+		return pubsub.Position{Time: at}, nil
+	}
+
+	offset, err := strconv.ParseInt(c.Param(offsetFlag), 10, 64)
+	if err != nil {
+		return pubsub.Position{}, fmt.Errorf("invalid -%s: %w", offsetFlag, err)
+	}
+
+	return pubsub.Position{Offset: offset}, nil
+}
+
+func formatPosition(pos pubsub.Position) string {
+	if !pos.Time.IsZero() {
+		return pos.Time.Format(time.RFC3339)
+	}
+
+	return "offset " + strconv.FormatInt(pos.Offset, 10)
+}
@@ -0,0 +1,101 @@
+package gofr
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
+)
+
+func TestApp_AddPubSubCommands(t *testing.T) {
+	c := container.NewContainer(config.NewMockConfig(map[string]string{
+		"PUBSUB_BACKEND": "MEMORY", "CONSUMER_ID": "pubsub-commands"}))
+
+	for _, v := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, c.PubSub.Publish(context.Background(), "commands-orders", []byte(v)))
+	}
+
+	var replayed []string
+
+	a := &App{cmd: &cmd{}, container: c}
+	a.AddPubSubCommands(map[string]SubscribeFunc{
+		"commands-orders": func(ctx *Context) error {
+			var v string
+
+			if err := ctx.Bind(&v); err != nil {
+				return err
+			}
+
+			replayed = append(replayed, v)
+
+			return nil
+		},
+	})
+
+	testCases := []struct {
+		desc     string
+		args     []string
+		code     int
+		output   string
+		replayed []string
+	}{
+		{"seek to an offset", []string{"pubsub", "seek", "-topic=commands-orders", "-offset=2"}, 0,
+			"moved the consumer group of topic commands-orders to offset 2", nil},
+		{"replay a range", []string{"pubsub", "replay", "-topic=commands-orders", "-from=1", "-to=3"}, 0,
+			"replayed 2 messages of topic commands-orders", []string{"b", "c"}},
+		{"topic is required", []string{"pubsub", "seek"}, exitCodeError, errTopicRequired.Error(), nil},
+		{"invalid time", []string{"pubsub", "replay", "-topic=commands-orders", "-from-time=yesterday"},
+			exitCodeError, "invalid -from-time", nil},
+		{"no handler", []string{"pubsub", "replay", "-topic=payments"}, exitCodeError,
+			"no handler is added for topic payments", nil},
+	}
+
+	for i, tc := range testCases {
+		replayed = nil
+		os.Args = append([]string{""}, tc.args...)
+
+		var (
+			code   int
+			stderr string
+		)
+
+		stdout := testutil.StdoutOutputForFunc(func() {
+			stderr = testutil.StderrOutputForFunc(func() {
+				code = a.cmd.Run(c)
+			})
+		})
+
+		assert.Equal(t, tc.code, code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Contains(t, stdout+stderr, tc.output, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.replayed, replayed, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	msg, err := c.PubSub.Subscribe(context.Background(), "commands-orders")
+
+	require.NoError(t, err)
+	assert.Equal(t, "c", string(msg.Value))
+}
+
+func TestApp_AddPubSubCommands_NotSupported(t *testing.T) {
+	c := container.NewContainer(config.NewMockConfig(nil))
+
+	a := &App{cmd: &cmd{}, container: c}
+	a.AddPubSubCommands(nil)
+
+	os.Args = []string{"", "pubsub", "seek", "-topic=orders"}
+
+	var code int
+
+	output := testutil.StderrOutputForFunc(func() {
+		code = a.cmd.Run(c)
+	})
+
+	assert.Equal(t, exitCodeError, code)
+	assert.Contains(t, output, errOffsetsNotSupported.Error())
+}
@@ -0,0 +1,70 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+)
+
+var errMissingQuotaPrincipal = errors.New("principal is required, like user:alice or key:2bb80d537b1da3e3")
+
+// EnableQuotas limits the number of the requests each principal can make per day and per month to the quota.
+// It must be enabled after the authentication.
+func (a *App) EnableQuotas(quota middleware.Quota) {
+	a.EnableQuotasWithFunc(func(context.Context, string) (middleware.Quota, error) {
+		return quota, nil
+	})
+}
+
+// EnableQuotasWithFunc is like EnableQuotas, with the quota of each principal returned by quotas.
+func (a *App) EnableQuotasWithFunc(quotas middleware.QuotaFunc) {
+	store, err := a.container.QuotaStore()
+	if err != nil {
+		a.container.Errorf("could not enable quotas: %v", err)
+		return
+	}
+
+	a.quotas = quotas
+	a.httpServer.router.Use(middleware.Quotas(store, quotas, a.container.Clock()))
+}
+
+// quotasHandler reports the usage of the quota of the principal query parameter in the current day and month.
+func quotasHandler(c *container.Container, quotas middleware.QuotaFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := r.URL.Query().Get("principal")
+		if principal == "" {
+			writeAdminError(w, http.StatusBadRequest, errMissingQuotaPrincipal)
+
+			return
+		}
+
+		quota, err := quotas(r.Context(), principal)
+		if err != nil {
+			writeAdminError(w, http.StatusInternalServerError, err)
+
+			return
+		}
+
+		store, err := c.QuotaStore()
+		if err != nil {
+			writeAdminError(w, http.StatusInternalServerError, err)
+
+			return
+		}
+
+		usage, err := middleware.CountQuotaUsage(r.Context(), store, principal, quota, c.Clock().Now())
+		if err != nil {
+			writeAdminError(w, http.StatusServiceUnavailable, err)
+
+			return
+		}
+
+		writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+			"principal": principal,
+			"usage":     usage,
+		}})
+	})
+}
@@ -0,0 +1,75 @@
+package gofr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+)
+
+func TestApp_EnableQuotas(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+
+	t.Cleanup(s.Close)
+
+	c := container.NewContainer(config.NewMockConfig(map[string]string{
+		"REDIS_HOST": s.Host(),
+		"REDIS_PORT": s.Port(),
+	}))
+
+	a := &App{container: c, httpServer: &httpServer{router: gofrHTTP.NewRouter()}}
+
+	a.EnableAPIKeyAuth("secret")
+	a.EnableQuotas(middleware.Quota{Daily: 1, Monthly: 5})
+
+	a.httpServer.router.Handle("/orders", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i, statusCode := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		r := httptest.NewRequest(http.MethodGet, "/orders", http.NoBody)
+		r.Header.Set("X-API-KEY", "secret")
+
+		w := httptest.NewRecorder()
+		a.httpServer.router.ServeHTTP(w, r)
+
+		assert.Equal(t, statusCode, w.Code, "TEST[%d], Failed.\nrequest %d", i, i+1)
+	}
+
+	tests := []struct {
+		desc       string
+		query      string
+		statusCode int
+		response   string
+	}{
+		{"usage of the principal", "?principal=" + middleware.APIKeyPrincipal("secret"), http.StatusOK,
+			`"usage":[{"period":"daily","limit":1,"used":1,"remaining":0,`},
+		{"missing principal", "", http.StatusBadRequest, "principal is required"},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+
+		quotasHandler(c, a.quotas).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/quotas"+tc.query, http.NoBody))
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Contains(t, w.Body.String(), tc.response, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_EnableQuotas_NoStore(t *testing.T) {
+	a := &App{container: container.NewContainer(config.NewMockConfig(nil)), httpServer: &httpServer{router: gofrHTTP.NewRouter()}}
+
+	a.EnableQuotas(middleware.Quota{Daily: 1})
+
+	assert.Nil(t, a.quotas, "quotas should not be enabled without a store")
+}
@@ -0,0 +1,119 @@
+package gofr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+	"github.com/peter-stratton/gofr/pkg/gofr/recording"
+)
+
+var (
+	errReplayFlagsRequired = errors.New("-file and -target are required")
+	errReplayDiffered      = errors.New("the responses of the target differ")
+)
+
+// RecordRequests records the percentage of the HTTP requests, and their responses, sanitized, to the directory.
+//
+//	Usage:
+//	app.RecordRequests("/var/lib/orders/recordings", 1, recording.Sanitizer{Fields: []string{"email"}})
+func (a *App) RecordRequests(dir string, percentage float64, sanitizer recording.Sanitizer) {
+	recorder, err := recording.NewRecorder(dir, sanitizer)
+	if err != nil {
+		a.container.Errorf("the requests are not recorded: %v", err)
+
+		return
+	}
+
+	a.httpServer.router.Use(middleware.Recording(recorder, percentage, a.container.Logger))
+}
+
+// AddReplayCommand adds the "replay" sub-command, which replays the recorded requests against a target.
+//
+//	./admin replay -file=recordings/requests-2024-05-01.jsonl -target=https://orders.staging.internal \
+//		-header="Authorization: Bearer $TOKEN" -rate=20
+func (a *App) AddReplayCommand(sanitizer recording.Sanitizer) {
+	a.SubCommand("replay", replayRequests(sanitizer),
+		AddDescription("Replays the recorded requests against a target and compares its responses"),
+		AddFlag("file", "", "file of the recorded requests, or directory of the files"),
+		AddFlag("target", "", "base URL of the target, like https://orders.staging.internal"),
+		AddFlag("header", "", `headers set on the replayed requests, separated by ";", like "Authorization: Bearer x"`),
+		AddFlag("rate", "0", "maximum number of requests replayed per second, unlimited if 0"),
+	)
+}
+
+func replayRequests(sanitizer recording.Sanitizer) Handler {
+	return func(c *Context) (interface{}, error) {
+		file, target := c.Param("file"), c.Param("target")
+		if file == "" || target == "" {
+			return nil, errReplayFlagsRequired
+		}
+
+		rate, err := strconv.ParseFloat(c.Param("rate"), 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid -rate %q", c.Param("rate"))
+		}
+
+		var interval time.Duration
+		if rate > 0 {
+			interval = time.Duration(float64(time.Second) / rate)
+		}
+
+		replayer := &recording.Replayer{Target: target, Header: replayHeader(c.Param("header")), Sanitizer: sanitizer}
+
+		var replayed, differed int
+
+		err = recording.Read(file, func(rec *recording.Record) error {
+			if replayed > 0 && interval > 0 {
+				select {
+				case <-c.Done():
+					return c.Err()
+				case <-time.After(interval):
+				}
+			}
+
+			res := replayer.Replay(c, rec)
+			replayed++
+
+			if res.Match {
+				c.Logger.Log(&res)
+				return nil
+			}
+
+			differed++
+
+			c.Logger.Error(&res)
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("replay stopped after %d requests: %w", replayed, err)
+		}
+
+		if differed > 0 {
+			return nil, fmt.Errorf("%w: %d of the %d replayed requests", errReplayDiffered, differed, replayed)
+		}
+
+		return fmt.Sprintf("replayed %d requests, whose responses match", replayed), nil
+	}
+}
+
+// replayHeader parses the headers of the -header flag, like "Authorization: Bearer x; X-Tenant: acme".
+func replayHeader(flag string) http.Header {
+	header := http.Header{}
+
+	for _, h := range strings.Split(flag, ";") {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok {
+			continue
+		}
+
+		header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	return header
+}
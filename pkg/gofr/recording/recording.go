@@ -0,0 +1,257 @@
+// Package recording records the requests of an application and replays them against another environment.
+package recording
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Redacted replaces the values of the sanitized headers, query parameters and JSON fields.
+const Redacted = "<redacted>"
+
+// base64Encoding is the encoding of the bodies which are not valid UTF-8.
+const base64Encoding = "base64"
+
+// Record is a recorded request and its response.
+type Record struct {
+	Time                 time.Time   `json:"time"`
+	Method               string      `json:"method"`
+	URI                  string      `json:"uri"`
+	Header               http.Header `json:"header,omitempty"`
+	Body                 string      `json:"body,omitempty"`
+	BodyEncoding         string      `json:"bodyEncoding,omitempty"`
+	Status               int         `json:"status"`
+	ResponseBody         string      `json:"responseBody,omitempty"`
+	ResponseBodyEncoding string      `json:"responseBodyEncoding,omitempty"`
+	// ResponseTruncated is true if the response body was too long to be recorded.
+	ResponseTruncated bool `json:"responseTruncated,omitempty"`
+	// Latency is the duration of the request in microseconds.
+	Latency int64 `json:"latency"`
+}
+
+// RequestBody returns the body of the request, decoded from base64 if it is not valid UTF-8.
+func (r *Record) RequestBody() ([]byte, error) {
+	return decodeBody(r.Body, r.BodyEncoding)
+}
+
+// Response returns the body of the response, decoded from base64 if it is not valid UTF-8, or nil if it was truncated.
+func (r *Record) Response() ([]byte, error) {
+	if r.ResponseTruncated {
+		return nil, nil
+	}
+
+	return decodeBody(r.ResponseBody, r.ResponseBodyEncoding)
+}
+
+func encodeBody(b []byte) (body, encoding string) {
+	if utf8.Valid(b) {
+		return string(b), ""
+	}
+
+	return base64.StdEncoding.EncodeToString(b), base64Encoding
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	if encoding == base64Encoding {
+		return base64.StdEncoding.DecodeString(body)
+	}
+
+	return []byte(body), nil
+}
+
+// Sanitizer redacts the credentials and the secrets of the requests and the responses.
+type Sanitizer struct {
+	// Headers are the names of the redacted headers, in addition to the credentials.
+	Headers []string
+	// Fields are the names of the redacted query parameters and JSON fields, in addition to the secrets.
+	Fields []string
+}
+
+//nolint:gochecknoglobals // the defaults are constant.
+var (
+	defaultHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+	defaultFields  = []string{"password", "secret", "token", "access_token", "refresh_token", "api_key",
+		"client_secret", "card_number", "cvv"}
+)
+
+// sanitizer is a Sanitizer whose names are normalized.
+type sanitizer struct {
+	headers map[string]bool
+	fields  map[string]bool
+}
+
+func (s Sanitizer) compile() sanitizer {
+	c := sanitizer{headers: make(map[string]bool), fields: make(map[string]bool)}
+
+	for _, h := range append(defaultHeaders, s.Headers...) {
+		c.headers[http.CanonicalHeaderKey(h)] = true
+	}
+
+	for _, f := range append(defaultFields, s.Fields...) {
+		c.fields[strings.ToLower(f)] = true
+	}
+
+	return c
+}
+
+// header returns a copy of the header whose sanitized headers are redacted.
+func (s sanitizer) header(h http.Header) http.Header {
+	out := h.Clone()
+
+	for name := range out {
+		if s.headers[http.CanonicalHeaderKey(name)] {
+			out[name] = []string{Redacted}
+		}
+	}
+
+	return out
+}
+
+// uri returns the URI whose sanitized query parameters are redacted.
+func (s sanitizer) uri(uri string) string {
+	path, rawQuery, ok := strings.Cut(uri, "?")
+	if !ok {
+		return uri
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return path
+	}
+
+	for name, values := range query {
+		if s.fields[strings.ToLower(name)] {
+			for i := range values {
+				values[i] = Redacted
+			}
+		}
+	}
+
+	return path + "?" + query.Encode()
+}
+
+// body returns the JSON body whose sanitized fields are redacted. The bodies which are not JSON are not changed.
+func (s sanitizer) body(b []byte) []byte {
+	var v interface{}
+
+	if len(b) == 0 || json.Unmarshal(b, &v) != nil {
+		return b
+	}
+
+	out, err := json.Marshal(s.value(v))
+	if err != nil {
+		return b
+	}
+
+	return out
+}
+
+func (s sanitizer) value(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if s.fields[strings.ToLower(k)] && child != nil {
+				val[k] = Redacted
+				continue
+			}
+
+			val[k] = s.value(child)
+		}
+	case []interface{}:
+		for i, child := range val {
+			val[i] = s.value(child)
+		}
+	}
+
+	return v
+}
+
+// Recorder writes the records to a file of JSON lines per day, like "requests-2024-05-01.jsonl".
+type Recorder struct {
+	dir       string
+	sanitizer sanitizer
+
+	mu   sync.Mutex
+	day  string
+	file *os.File
+}
+
+// NewRecorder returns a recorder writing to the directory, which is created if it does not exist.
+func NewRecorder(dir string, s Sanitizer) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &Recorder{dir: dir, sanitizer: s.compile()}, nil
+}
+
+// Record sanitizes and writes the record of the request and of its response.
+func (r *Recorder) Record(req *http.Request, body []byte, status int, responseBody []byte, latency time.Duration) error {
+	rec := Record{
+		Time:              time.Now().UTC(),
+		Method:            req.Method,
+		URI:               r.sanitizer.uri(req.URL.RequestURI()),
+		Header:            r.sanitizer.header(req.Header),
+		Status:            status,
+		ResponseTruncated: responseBody == nil,
+		Latency:           latency.Microseconds(),
+	}
+
+	rec.Body, rec.BodyEncoding = encodeBody(r.sanitizer.body(body))
+	rec.ResponseBody, rec.ResponseBodyEncoding = encodeBody(r.sanitizer.body(responseBody))
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	return r.write(rec.Time, append(line, '\n'))
+}
+
+func (r *Recorder) write(at time.Time, line []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if day := at.Format(time.DateOnly); day != r.day || r.file == nil {
+		if r.file != nil {
+			_ = r.file.Close()
+		}
+
+		f, err := os.OpenFile(filepath.Join(r.dir, fmt.Sprintf("requests-%s.jsonl", day)),
+			os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			r.file = nil
+			return err
+		}
+
+		r.day, r.file = day, f
+	}
+
+	_, err := r.file.Write(line)
+
+	return err
+}
+
+// Close closes the file of the recorder.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+
+	return err
+}
@@ -0,0 +1,128 @@
+package recording
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_Record(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "recordings")
+
+	recorder, err := NewRecorder(dir, Sanitizer{Headers: []string{"X-Session"}, Fields: []string{"email"}})
+	require.NoError(t, err)
+
+	defer recorder.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/users?token=abc&page=2", http.NoBody)
+	req.Header.Set("Authorization", "Bearer abc")
+	req.Header.Set("X-Session", "s1")
+	req.Header.Set("Content-Type", "application/json")
+
+	require.NoError(t, recorder.Record(req, []byte(`{"name":"ann","Password":"p","profile":{"email":"a@b.c"}}`),
+		http.StatusCreated, []byte(`{"data":{"id":1,"access_token":"t"}}`), 1500*time.Microsecond))
+	require.NoError(t, recorder.Record(httptest.NewRequest(http.MethodGet, "/files/1", http.NoBody), nil,
+		http.StatusOK, []byte{0xff, 0xfe}, time.Millisecond))
+	require.NoError(t, recorder.Record(httptest.NewRequest(http.MethodGet, "/export", http.NoBody), nil,
+		http.StatusOK, nil, time.Millisecond))
+
+	var records []*Record
+
+	require.NoError(t, Read(dir, func(rec *Record) error {
+		records = append(records, rec)
+		return nil
+	}))
+
+	require.Len(t, records, 3)
+
+	assert.Equal(t, "/users?page=2&token=%3Credacted%3E", records[0].URI)
+	assert.Equal(t, []string{Redacted}, records[0].Header["Authorization"])
+	assert.Equal(t, []string{Redacted}, records[0].Header["X-Session"])
+	assert.Equal(t, []string{"application/json"}, records[0].Header["Content-Type"])
+	assert.JSONEq(t, `{"name":"ann","Password":"<redacted>","profile":{"email":"<redacted>"}}`, records[0].Body)
+	assert.JSONEq(t, `{"data":{"id":1,"access_token":"<redacted>"}}`, records[0].ResponseBody)
+	assert.Equal(t, http.StatusCreated, records[0].Status)
+	assert.Equal(t, int64(1500), records[0].Latency)
+
+	body, err := records[1].Response()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0xfe}, body)
+	assert.Equal(t, base64Encoding, records[1].ResponseBodyEncoding)
+
+	body, err = records[2].Response()
+	require.NoError(t, err)
+	assert.Nil(t, body)
+	assert.True(t, records[2].ResponseTruncated)
+}
+
+func TestRead_Invalid(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requests.jsonl")
+	require.NoError(t, os.WriteFile(file, []byte(`{"method":"GET","uri":"/"}`+"\n{"), 0o600))
+
+	n := 0
+
+	err := Read(file, func(*Record) error {
+		n++
+		return nil
+	})
+
+	require.ErrorContains(t, err, "invalid record")
+	assert.Equal(t, 1, n)
+
+	require.Error(t, Read(filepath.Join(t.TempDir(), "missing.jsonl"), func(*Record) error { return nil }))
+}
+
+func TestReplayer_Replay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get(ReplayedHeader))
+		assert.Equal(t, "Bearer staging", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/users":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"id":1,"access_token":"other"}}`))
+		case "/orders":
+			_, _ = w.Write([]byte(`{"data":{"total":12}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	replayer := &Replayer{Target: server.URL + "/", Header: http.Header{"Authorization": {"Bearer staging"}}}
+
+	testCases := []struct {
+		rec   Record
+		match bool
+		diff  []string
+	}{
+		// the redacted fields are not differences.
+		{Record{Method: http.MethodPost, URI: "/users", Header: http.Header{"Authorization": {Redacted}},
+			Body: `{"name":"ann"}`, Status: http.StatusCreated,
+			ResponseBody: `{"data":{"access_token":"<redacted>","id":1}}`}, true, nil},
+		{Record{Method: http.MethodGet, URI: "/orders", Status: http.StatusOK, ResponseBody: `{"data":{"total":10}}`},
+			false, []string{"data.total: 10 != 12"}},
+		{Record{Method: http.MethodGet, URI: "/export", Status: http.StatusOK, ResponseTruncated: true}, false,
+			[]string{"status: 200 != 404"}},
+	}
+
+	for i, tc := range testCases {
+		res := replayer.Replay(context.Background(), &tc.rec)
+
+		assert.Equal(t, tc.match, res.Match, "TEST[%d], Failed.\n%s", i, tc.rec.URI)
+		assert.Equal(t, tc.diff, res.Diff, "TEST[%d], Failed.\n%s", i, tc.rec.URI)
+		assert.Empty(t, res.Error, "TEST[%d], Failed.\n%s", i, tc.rec.URI)
+	}
+
+	res := (&Replayer{Target: "http://127.0.0.1:1"}).Replay(context.Background(), &Record{Method: http.MethodGet,
+		URI: "/"})
+	assert.True(t, strings.Contains(res.Error, "connection refused"), res.Error)
+}
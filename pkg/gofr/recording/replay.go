@@ -0,0 +1,165 @@
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/mirror"
+)
+
+// ReplayedHeader is set on the replayed requests, so that the target can tell them apart.
+const ReplayedHeader = "X-Gofr-Replayed"
+
+// Read passes the records of the file, or of the .jsonl files of the directory, to fn.
+func Read(path string, fn func(rec *Record) error) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	files := []string{path}
+
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.jsonl")); err != nil {
+			return err
+		}
+
+		sort.Strings(files)
+	}
+
+	for _, name := range files {
+		if err := readFile(name, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readFile(name string, fn func(rec *Record) error) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+
+	for {
+		var rec Record
+
+		if err := dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("invalid record in %s: %w", name, err)
+		}
+
+		if err := fn(&rec); err != nil {
+			return err
+		}
+	}
+}
+
+// Replayer replays the recorded requests against a target, and compares the responses.
+type Replayer struct {
+	// Target is the base URL of the environment, like "https://orders.staging.internal".
+	Target string
+	// Header is set on the replayed requests, like an Authorization valid on the target.
+	Header http.Header
+	// Sanitizer redacts the responses of the target. It must be the sanitizer of the recorder.
+	Sanitizer Sanitizer
+	// Timeout is the timeout of each request, 30 seconds by default.
+	Timeout time.Duration
+	Client  *http.Client
+}
+
+// Replay replays the recorded request against the target, and compares the responses.
+func (p *Replayer) Replay(ctx context.Context, rec *Record) mirror.Result {
+	res := mirror.Result{Method: rec.Method, URI: rec.URI, PrimaryStatus: rec.Status}
+
+	body, err := rec.RequestBody()
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	header := rec.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	for name, values := range p.Header {
+		header[name] = values
+	}
+
+	header.Set(ReplayedHeader, "true")
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	req, cancel, err := mirror.Request(ctx, rec.Method, strings.TrimRight(p.Target, "/")+rec.URI, body, header, timeout)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	defer cancel()
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	start := time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		res.ShadowLatency = time.Since(start).Microseconds()
+		res.Error = err.Error()
+
+		return res
+	}
+
+	defer resp.Body.Close()
+
+	replayed, err := io.ReadAll(io.LimitReader(resp.Body, mirror.MaxBodySize+1))
+
+	res.ShadowLatency = time.Since(start).Microseconds()
+	res.ShadowStatus = resp.StatusCode
+
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	recorded, err := rec.Response()
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	if recorded == nil || len(replayed) > mirror.MaxBodySize {
+		recorded, replayed = nil, nil
+	} else {
+		replayed = p.Sanitizer.compile().body(replayed)
+	}
+
+	res.Diff = mirror.Compare(rec.Status, recorded, resp.StatusCode, replayed)
+	res.Match = len(res.Diff) == 0
+
+	return res
+}
@@ -0,0 +1,81 @@
+package gofr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/recording"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
+)
+
+func TestApp_AddReplayCommand(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer staging", r.Header.Get("Authorization"))
+		assert.Equal(t, "acme", r.Header.Get("X-Tenant"))
+
+		_, _ = w.Write([]byte(`{"data":{"path":"` + r.URL.Path + `","token":"t2"}}`))
+	}))
+	defer target.Close()
+
+	dir := t.TempDir()
+	matching := filepath.Join(dir, "matching.jsonl")
+	differing := filepath.Join(dir, "differing.jsonl")
+
+	require.NoError(t, os.WriteFile(matching, []byte(
+		`{"method":"GET","uri":"/orders/1","status":200,"responseBody":"{\"data\":{\"path\":\"/orders/1\",\"token\":\"<redacted>\"}}"}`+"\n"+
+			`{"method":"GET","uri":"/orders/2","status":200,"responseBody":"{\"data\":{\"path\":\"/orders/2\",\"token\":\"<redacted>\"}}"}`+"\n"),
+		0o600))
+	require.NoError(t, os.WriteFile(differing, []byte(
+		`{"method":"GET","uri":"/orders/3","status":201,"responseBody":"{\"data\":{\"path\":\"/orders/3\"}}"}`+"\n"),
+		0o600))
+
+	c := container.NewContainer(config.NewMockConfig(nil))
+
+	a := &App{cmd: &cmd{}, container: c}
+	a.AddReplayCommand(recording.Sanitizer{})
+
+	header := "-header=Authorization: Bearer staging; X-Tenant: acme"
+
+	testCases := []struct {
+		desc   string
+		args   []string
+		code   int
+		output string
+	}{
+		{"matching responses", []string{"replay", "-file=" + matching, "-target=" + target.URL, header, "-rate=100"}, 0,
+			"replayed 2 requests, whose responses match"},
+		{"differing responses", []string{"replay", "-file=" + differing, "-target=" + target.URL, header}, exitCodeError,
+			errReplayDiffered.Error() + ": 1 of the 1 replayed requests"},
+		{"flags are required", []string{"replay", "-file=" + matching}, exitCodeError, errReplayFlagsRequired.Error()},
+		{"invalid rate", []string{"replay", "-file=" + matching, "-target=" + target.URL, "-rate=fast"}, exitCodeError,
+			`invalid -rate "fast"`},
+		{"missing file", []string{"replay", "-file=" + filepath.Join(dir, "missing"), "-target=" + target.URL},
+			exitCodeError, "replay stopped after 0 requests"},
+	}
+
+	for i, tc := range testCases {
+		os.Args = append([]string{""}, tc.args...)
+
+		var (
+			code   int
+			stderr string
+		)
+
+		stdout := testutil.StdoutOutputForFunc(func() {
+			stderr = testutil.StderrOutputForFunc(func() {
+				code = a.cmd.Run(c)
+			})
+		})
+
+		assert.Equal(t, tc.code, code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Contains(t, stdout+stderr, tc.output, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,152 @@
+package gofr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/tenant"
+)
+
+// responseCachePrefix is the prefix of the keys of the cached responses in the cache of the container.
+const responseCachePrefix = "response:"
+
+//nolint:gochecknoglobals // the headers on which any cached response may depend are constant.
+var cacheVaryHeaders = []string{"Accept", "Accept-Language", "Authorization", "Cookie", "X-Api-Key"}
+
+// responseCache caches the responses of the GET requests of a route in the cache of the container.
+type responseCache struct {
+	ttl       time.Duration
+	headers   []string
+	container *container.Container
+}
+
+// cachedResponse is a response stored in the cache.
+type cachedResponse struct {
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"storedAt"`
+}
+
+func newResponseCache(ttl time.Duration, headers []string, c *container.Container) *responseCache {
+	rc := &responseCache{ttl: ttl, headers: append([]string{}, cacheVaryHeaders...), container: c}
+
+	for _, h := range headers {
+		rc.headers = append(rc.headers, http.CanonicalHeaderKey(h))
+	}
+
+	return rc
+}
+
+// serve responds to the request with its cached response, or caches the response of next.
+func (rc *responseCache) serve(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	store, err := rc.container.Cache()
+	if err != nil {
+		rc.container.Errorf("could not cache the response of %v: %v", r.URL.Path, err)
+		next(w, r)
+
+		return
+	}
+
+	key := rc.key(r)
+
+	value, ok, err := store.Get(r.Context(), key)
+	if err != nil {
+		rc.container.Errorf("could not get the cached response of %v: %v", r.URL.Path, err)
+	}
+
+	var cached cachedResponse
+
+	if ok && json.Unmarshal(value, &cached) == nil {
+		rc.writeCached(w, &cached)
+		return
+	}
+
+	rec := &coalescedResponse{header: make(http.Header)}
+	next(rec, r)
+
+	if rec.status == http.StatusOK && !strings.Contains(rec.header.Get("Cache-Control"), "no-store") {
+		if rec.header.Get("Cache-Control") == "" {
+			rec.header.Set("Cache-Control", rc.cacheControl(r))
+		}
+
+		rc.store(r, store, key, rec)
+	}
+
+	rec.writeTo(w)
+}
+
+func (rc *responseCache) store(r *http.Request, store container.Cache, key string, rec *coalescedResponse) {
+	value, err := json.Marshal(cachedResponse{Status: rec.status, Header: rec.header, Body: rec.body.Bytes(),
+		StoredAt: rc.container.Clock().Now()})
+	if err != nil {
+		return
+	}
+
+	if err := store.Set(r.Context(), key, value, rc.ttl); err != nil {
+		rc.container.Errorf("could not cache the response of %v: %v", r.URL.Path, err)
+	}
+}
+
+// writeCached writes the cached response with an Age header of the seconds since it is cached.
+func (rc *responseCache) writeCached(w http.ResponseWriter, cached *cachedResponse) {
+	for name, values := range cached.Header {
+		w.Header()[name] = values
+	}
+
+	age := int(rc.container.Clock().Since(cached.StoredAt) / time.Second)
+	w.Header().Set("Age", strconv.Itoa(max(age, 0)))
+
+	w.WriteHeader(cached.Status)
+	_, _ = w.Write(cached.Body)
+}
+
+// cacheControl returns the Cache-Control header of the cached responses.
+func (rc *responseCache) cacheControl(r *http.Request) string {
+	visibility := "public"
+	if r.Header.Get("Authorization") != "" || r.Header.Get("Cookie") != "" || r.Header.Get("X-Api-Key") != "" {
+		visibility = "private"
+	}
+
+	return visibility + ", max-age=" + strconv.Itoa(int(rc.ttl/time.Second))
+}
+
+// key returns the key of the response of the request, its path and its sorted query, and a hash of its variants.
+func (rc *responseCache) key(r *http.Request) string {
+	h := sha256.New()
+	h.Write([]byte(tenant.FromContext(r.Context())))
+
+	for _, name := range rc.headers {
+		h.Write([]byte("\n" + strings.Join(r.Header.Values(name), ",")))
+	}
+
+	key := r.URL.Path
+	if q := r.URL.Query().Encode(); q != "" {
+		key += "?" + q
+	}
+
+	return responseCachePrefix + key + "#" + hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// InvalidateCache removes the cached responses whose path and query match the pattern, like "/orders*".
+func (c *Context) InvalidateCache(pattern string) error {
+	store, err := c.Container.Cache()
+	if err != nil {
+		return err
+	}
+
+	// the hash of the headers of the responses.
+	if !strings.HasSuffix(pattern, "*") {
+		pattern += "#*"
+	}
+
+	_, err = store.Delete(c, responseCachePrefix+pattern)
+
+	return err
+}
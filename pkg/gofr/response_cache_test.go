@@ -0,0 +1,98 @@
+package gofr
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheFor(t *testing.T) {
+	app := New()
+
+	calls := 0
+
+	product := func(c *Context) (interface{}, error) {
+		calls++
+
+		if c.PathParam("id") == "missing" {
+			return nil, errors.New("not found")
+		}
+
+		return "product " + c.PathParam("id") + " #" + strconv.Itoa(calls), nil
+	}
+
+	app.GET("/products/{id}", product, CacheFor(30*time.Second, "X-Region"))
+	app.GET("/uncached/{id}", product)
+
+	requests := []struct {
+		path         string
+		region       string
+		auth         string
+		body         string
+		cacheControl string
+		age          bool
+	}{
+		{"/products/1?b=2&a=1", "eu", "", `{"data":"product 1 #1"}`, "public, max-age=30", false},
+		{"/products/1?a=1&b=2", "eu", "", `{"data":"product 1 #1"}`, "public, max-age=30", true},
+		{"/products/1?a=1&b=2", "us", "", `{"data":"product 1 #2"}`, "public, max-age=30", false},
+		{"/products/1", "eu", "Bearer token", `{"data":"product 1 #3"}`, "private, max-age=30", false},
+		{"/products/missing", "eu", "", `{"error":{"message":"not found"}}`, "", false},
+		{"/products/missing", "eu", "", `{"error":{"message":"not found"}}`, "", false},
+		{"/uncached/1", "eu", "", `{"data":"product 1 #6"}`, "", false},
+		{"/uncached/1", "eu", "", `{"data":"product 1 #7"}`, "", false},
+	}
+
+	for i, req := range requests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, req.path, http.NoBody)
+		r.Header.Set("X-Region", req.region)
+
+		if req.auth != "" {
+			r.Header.Set("Authorization", req.auth)
+		}
+
+		app.httpServer.router.ServeHTTP(w, r)
+
+		assert.JSONEq(t, req.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, req.path)
+		assert.Equal(t, req.cacheControl, w.Header().Get("Cache-Control"), "TEST[%d], Failed.\n%s", i, req.path)
+		assert.Equal(t, req.age, w.Header().Get("Age") != "", "TEST[%d], Failed.\n%s", i, req.path)
+	}
+}
+
+func TestContext_InvalidateCache(t *testing.T) {
+	app := New()
+
+	calls := 0
+
+	app.GET("/products/{id}", func(c *Context) (interface{}, error) {
+		calls++
+		return calls, nil
+	}, CacheFor(time.Minute))
+
+	get := func(path string) string {
+		w := httptest.NewRecorder()
+		app.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+		return w.Body.String()
+	}
+
+	get("/products/1")
+	get("/products/2")
+	get("/products/2?page=2")
+
+	ctx := &Context{Context: httptest.NewRequest(http.MethodGet, "/", http.NoBody).Context(), Container: app.container}
+
+	require.NoError(t, ctx.InvalidateCache("/products/1"))
+	assert.JSONEq(t, `{"data":4}`, get("/products/1"), "the invalidated response should be served again")
+	assert.JSONEq(t, `{"data":2}`, get("/products/2"), "the response not matching the pattern should be cached")
+
+	require.NoError(t, ctx.InvalidateCache("/products/2*"))
+	assert.JSONEq(t, `{"data":5}`, get("/products/2"))
+	assert.JSONEq(t, `{"data":6}`, get("/products/2?page=2"))
+}
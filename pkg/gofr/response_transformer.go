@@ -0,0 +1,44 @@
+package gofr
+
+import (
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+)
+
+// ResponseTransformer builds the envelope of the responses instead of the default {"data": ..., "error": ...}.
+type ResponseTransformer = gofrHTTP.ResponseTransformer
+
+// ResponseTransformerFunc is a function used as a ResponseTransformer.
+type ResponseTransformerFunc = gofrHTTP.ResponseTransformerFunc
+
+// responseTransformer is the transformer of the responses of the application, shared by its routes.
+type responseTransformer struct {
+	transformer ResponseTransformer
+}
+
+// SetResponseTransformer writes the responses of all the routes in the envelope built by the transformer.
+//
+//	Usage:
+//	app.SetResponseTransformer(gofr.ResponseTransformerFunc(
+//		func(_ *http.Request, _ int, data, errorObj interface{}) interface{} {
+//			if errorObj != nil {
+//				return map[string]interface{}{"errors": []interface{}{errorObj}}
+//			}
+//
+//			return map[string]interface{}{"data": data, "meta": map[string]interface{}{}}
+//		}))
+func (a *App) SetResponseTransformer(t ResponseTransformer) {
+	a.responseTransformer.transformer = t
+}
+
+// responseTransformer returns the transformer of the route, or the one of the application.
+func (h handler) responseTransformer() ResponseTransformer {
+	if h.transformer != nil {
+		return h.transformer
+	}
+
+	if h.appTransformer != nil {
+		return h.appTransformer.transformer
+	}
+
+	return nil
+}
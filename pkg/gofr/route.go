@@ -0,0 +1,100 @@
+package gofr
+
+import (
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+	"github.com/peter-stratton/gofr/pkg/gofr/payment"
+)
+
+// RouteOption configures a route added by GET, PUT, POST, DELETE or PATCH.
+type RouteOption func(h *handler)
+
+// ResponseFormats sets the formats, among "json", "xml", "yaml" and "csv", negotiated with the Accept header of the route.
+//
+//	Usage:
+//	app.GET("/orders/export", exportOrders, gofr.ResponseFormats("csv", "json"))
+func ResponseFormats(formats ...string) RouteOption {
+	return func(h *handler) {
+		h.formats = nil
+
+		for _, f := range formats {
+			if !gofrHTTP.IsFormat(f) {
+				h.container.Errorf("unknown response format %q, the formats are json, xml, yaml and csv", f)
+				continue
+			}
+
+			h.formats = append(h.formats, f)
+		}
+	}
+}
+
+// responseFormats returns the formats of the responses, starting with the default format.
+func responseFormats(defaultFormat string, c *container.Container) []string {
+	if !gofrHTTP.IsFormat(defaultFormat) {
+		c.Errorf("unknown RESPONSE_FORMAT %q, the responses are written in JSON by default", defaultFormat)
+
+		defaultFormat = gofrHTTP.FormatJSON
+	}
+
+	formats := []string{defaultFormat}
+
+	for _, f := range []string{gofrHTTP.FormatJSON, gofrHTTP.FormatXML, gofrHTTP.FormatYAML, gofrHTTP.FormatCSV} {
+		if f != defaultFormat {
+			formats = append(formats, f)
+		}
+	}
+
+	return formats
+}
+
+// CoalesceRequests collapses the concurrent identical GET requests of the route, varying by the headers given.
+//
+//	Usage:
+//	app.GET("/reports/{id}", getReport, gofr.CoalesceRequests("X-Region"))
+func CoalesceRequests(headers ...string) RouteOption {
+	return func(h *handler) {
+		h.coalescer = newCoalescer(headers)
+	}
+}
+
+// TransformResponses writes the responses of the route in the envelope built by the transformer.
+//
+//	Usage:
+//	app.GET("/v2/orders", listOrders, gofr.TransformResponses(jsonAPI))
+func TransformResponses(t ResponseTransformer) RouteOption {
+	return func(h *handler) {
+		h.transformer = t
+	}
+}
+
+// CacheFor caches the 200 responses of the GET requests of the route for the ttl, varying by the headers given.
+//
+//	Usage:
+//	app.GET("/products", listProducts, gofr.CacheFor(30*time.Second, "X-Region"))
+func CacheFor(ttl time.Duration, headers ...string) RouteOption {
+	return func(h *handler) {
+		h.cache = newResponseCache(ttl, headers, h.container)
+	}
+}
+
+// LatencyObjective sets the latency objective of the route, instead of SLO_LATENCY_OBJECTIVE.
+//
+//	Usage:
+//	app.GET("/orders/{id}", getOrder, gofr.LatencyObjective(100*time.Millisecond))
+func LatencyObjective(d time.Duration) RouteOption {
+	return func(h *handler) {
+		h.latencyObjective = d
+	}
+}
+
+// VerifyPaymentWebhook rejects with 401 the requests of the route which are not signed by the payment provider.
+//
+//	Usage:
+//	app.POST("/webhooks/stripe", stripeEvents, gofr.VerifyPaymentWebhook(payment.NewStripe(payment.StripeConfig{Secret: secret})))
+func VerifyPaymentWebhook(v payment.Verifier) RouteOption {
+	return func(h *handler) {
+		h.webhookVerifier = v
+	}
+}
@@ -0,0 +1,143 @@
+package gofr
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/payment"
+)
+
+type routeItem struct {
+	ID int `json:"id"`
+}
+
+func TestResponseFormats(t *testing.T) {
+	t.Setenv("RESPONSE_FORMAT", "yaml")
+
+	app := New()
+
+	items := func(*Context) (interface{}, error) {
+		return []routeItem{{ID: 1}}, nil
+	}
+
+	app.GET("/items", items)
+	app.GET("/items/export", items, ResponseFormats("csv", "json", "pdf"))
+
+	testCases := []struct {
+		path        string
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"/items", "", "application/yaml", "data:\n  - id: 1\n"},
+		{"/items", "application/json", "application/json", `{"data":[{"id":1}]}` + "\n"},
+		{"/items/export", "", "text/csv; charset=utf-8", "id\n1\n"},
+		{"/items/export", "application/json", "application/json", `{"data":[{"id":1}]}` + "\n"},
+		{"/items/export", "application/xml", "text/csv; charset=utf-8", "id\n1\n"},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, tc.path, http.NoBody)
+		r.Header.Set("Accept", tc.accept)
+
+		app.httpServer.router.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s %s", i, tc.path, tc.accept)
+		assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s %s", i, tc.path, tc.accept)
+	}
+}
+
+func TestResponseFormats_UnknownDefault(t *testing.T) {
+	c := &container.Container{Logger: logging.NewMockLogger(logging.ERROR)}
+
+	assert.Equal(t, []string{"json", "xml", "yaml", "csv"}, responseFormats("pdf", c))
+	assert.Equal(t, []string{"csv", "json", "xml", "yaml"}, responseFormats("csv", c))
+}
+
+func TestTransformResponses(t *testing.T) {
+	app := New()
+
+	envelope := func(name string) ResponseTransformer {
+		return ResponseTransformerFunc(func(_ *http.Request, _ int, data, errorObj interface{}) interface{} {
+			return map[string]interface{}{name: data, "errors": errorObj}
+		})
+	}
+
+	item := func(*Context) (interface{}, error) {
+		return routeItem{ID: 1}, nil
+	}
+
+	app.GET("/items", item)
+	app.GET("/v2/items", item, TransformResponses(envelope("result")))
+
+	app.SetResponseTransformer(envelope("payload"))
+
+	testCases := []struct {
+		path string
+		body string
+	}{
+		{"/items", `{"errors":null,"payload":{"id":1}}`},
+		{"/v2/items", `{"errors":null,"result":{"id":1}}`},
+		{"/missing", `{"errors":{"message":"route not registered"},"payload":null}`},
+	}
+
+	// the catch-all route is added by Run.
+	app.httpServer.router.PathPrefix("/").Handler(handler{function: catchAllHandler, container: app.container,
+		appTransformer: app.responseTransformer})
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+
+		app.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, http.NoBody))
+
+		assert.JSONEq(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.path)
+	}
+}
+
+func TestVerifyPaymentWebhook(t *testing.T) {
+	app := New()
+
+	app.POST("/webhooks/razorpay", func(c *Context) (interface{}, error) {
+		var event payment.RazorpayEvent
+
+		if err := c.Bind(&event); err != nil {
+			return nil, err
+		}
+
+		return event.Event, nil
+	}, VerifyPaymentWebhook(payment.NewRazorpay(payment.RazorpayConfig{Secret: "secret"})))
+
+	body := `{"entity":"event","event":"payment.captured","payload":{}}`
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+
+	testCases := []struct {
+		signature string
+		status    int
+	}{
+		{hex.EncodeToString(mac.Sum(nil)), http.StatusCreated},
+		{"", http.StatusUnauthorized},
+	}
+
+	for i, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/razorpay", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(payment.RazorpaySignatureHeader, tc.signature)
+
+		w := httptest.NewRecorder()
+
+		app.httpServer.router.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, "TEST[%d], Failed.\n%s", i, w.Body.String())
+	}
+}
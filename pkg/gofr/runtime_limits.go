@@ -0,0 +1,113 @@
+package gofr
+
+import (
+	"errors"
+	iofs "io/fs"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+// cgroupRoot is where the cgroup of the application is mounted in its container.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// memoryLimitRatio is the part of the memory limit of the cgroup set as the soft memory limit.
+const memoryLimitRatio = 0.9
+
+var errNoLimit = errors.New("no limit")
+
+// setRuntimeLimits sets GOMAXPROCS and the soft memory limit to the limits of the cgroup, unless they are set.
+func setRuntimeLimits(cfg config.Config, logger logging.Logger, cgroup iofs.FS) {
+	if cfg.GetOrDefault("AUTO_GOMAXPROCS", "true") != "false" && os.Getenv("GOMAXPROCS") == "" {
+		if quota, err := cpuQuota(cgroup); err == nil {
+			procs := int(math.Max(1, math.Floor(quota)))
+
+			if procs < runtime.GOMAXPROCS(0) {
+				runtime.GOMAXPROCS(procs)
+				logger.Infof("GOMAXPROCS set to %d to match the CPU quota of the container", procs)
+			}
+		}
+	}
+
+	if cfg.GetOrDefault("AUTO_GOMEMLIMIT", "true") != "false" && os.Getenv("GOMEMLIMIT") == "" {
+		if limit, err := memoryLimit(cgroup); err == nil {
+			memLimit := int64(float64(limit) * memoryLimitRatio)
+
+			debug.SetMemoryLimit(memLimit)
+			logger.Infof("GOMEMLIMIT set to %d bytes to match the memory limit of the container", memLimit)
+		}
+	}
+}
+
+// cpuQuota returns the number of CPUs the cgroup can use, for cgroup v2 or v1.
+func cpuQuota(cgroup iofs.FS) (float64, error) {
+	if content, err := readCgroupFile(cgroup, "cpu.max"); err == nil {
+		fields := strings.Fields(content)
+		if len(fields) != 2 {
+			return 0, errNoLimit
+		}
+
+		return quotaOf(fields[0], fields[1])
+	}
+
+	quota, err := readCgroupFile(cgroup, "cpu/cpu.cfs_quota_us")
+	if err != nil {
+		return 0, err
+	}
+
+	period, err := readCgroupFile(cgroup, "cpu/cpu.cfs_period_us")
+	if err != nil {
+		return 0, err
+	}
+
+	return quotaOf(quota, period)
+}
+
+func quotaOf(quota, period string) (float64, error) {
+	q, err := strconv.ParseInt(quota, 10, 64)
+	if err != nil || q <= 0 {
+		// "max" for cgroup v2 and -1 for cgroup v1 are no quota.
+		return 0, errNoLimit
+	}
+
+	p, err := strconv.ParseInt(period, 10, 64)
+	if err != nil || p <= 0 {
+		return 0, errNoLimit
+	}
+
+	return float64(q) / float64(p), nil
+}
+
+// memoryLimit returns the memory limit of the cgroup in bytes, for cgroup v2 or v1.
+func memoryLimit(cgroup iofs.FS) (int64, error) {
+	content, err := readCgroupFile(cgroup, "memory.max")
+	if err != nil {
+		content, err = readCgroupFile(cgroup, "memory/memory.limit_in_bytes")
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	limit, err := strconv.ParseInt(content, 10, 64)
+	// "max" for cgroup v2, and a number close to the maximum for cgroup v1, are no limit.
+	if err != nil || limit <= 0 || limit >= math.MaxInt64/2 {
+		return 0, errNoLimit
+	}
+
+	return limit, nil
+}
+
+func readCgroupFile(cgroup iofs.FS, name string) (string, error) {
+	content, err := iofs.ReadFile(cgroup, name)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,113 @@
+package gofr
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil/logtest"
+)
+
+func cgroupFS(files map[string]string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for name, content := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(content + "\n")}
+	}
+
+	return fsys
+}
+
+func TestCPUQuota(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		files  map[string]string
+		quota  float64
+		hasErr bool
+	}{
+		{"cgroup v2", map[string]string{"cpu.max": "150000 100000"}, 1.5, false},
+		{"cgroup v2 without quota", map[string]string{"cpu.max": "max 100000"}, 0, true},
+		{"cgroup v1", map[string]string{"cpu/cpu.cfs_quota_us": "200000", "cpu/cpu.cfs_period_us": "100000"}, 2, false},
+		{"cgroup v1 without quota", map[string]string{"cpu/cpu.cfs_quota_us": "-1", "cpu/cpu.cfs_period_us": "100000"}, 0, true},
+		{"no cgroup", nil, 0, true},
+	}
+
+	for i, tc := range testCases {
+		quota, err := cpuQuota(cgroupFS(tc.files))
+
+		assert.Equal(t, tc.quota, quota, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.hasErr, err != nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestMemoryLimit(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		files  map[string]string
+		limit  int64
+		hasErr bool
+	}{
+		{"cgroup v2", map[string]string{"memory.max": "536870912"}, 536870912, false},
+		{"cgroup v2 without limit", map[string]string{"memory.max": "max"}, 0, true},
+		{"cgroup v1", map[string]string{"memory/memory.limit_in_bytes": "268435456"}, 268435456, false},
+		{"cgroup v1 without limit", map[string]string{"memory/memory.limit_in_bytes": "9223372036854771712"}, 0, true},
+		{"no cgroup", nil, 0, true},
+	}
+
+	for i, tc := range testCases {
+		limit, err := memoryLimit(cgroupFS(tc.files))
+
+		assert.Equal(t, tc.limit, limit, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.hasErr, err != nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestSetRuntimeLimits(t *testing.T) {
+	t.Setenv("GOMAXPROCS", "")
+	t.Setenv("GOMEMLIMIT", "")
+
+	procs := runtime.GOMAXPROCS(0)
+	memLimit := debug.SetMemoryLimit(-1)
+
+	defer func() {
+		runtime.GOMAXPROCS(procs)
+		debug.SetMemoryLimit(memLimit)
+	}()
+
+	runtime.GOMAXPROCS(4)
+
+	logs := logtest.NewLogRecorder()
+	cgroup := cgroupFS(map[string]string{"cpu.max": "250000 100000", "memory.max": "1000000000"})
+
+	setRuntimeLimits(config.NewMockConfig(nil), logs, cgroup)
+
+	assert.Equal(t, 2, runtime.GOMAXPROCS(0))
+	assert.Equal(t, int64(900000000), debug.SetMemoryLimit(-1))
+	logs.AssertContains(t, logging.INFO, "GOMAXPROCS set to 2")
+	logs.AssertContains(t, logging.INFO, "GOMEMLIMIT set to 900000000 bytes")
+}
+
+func TestSetRuntimeLimits_Disabled(t *testing.T) {
+	t.Setenv("GOMEMLIMIT", "")
+
+	procs := runtime.GOMAXPROCS(4)
+	defer runtime.GOMAXPROCS(procs)
+
+	memLimit := debug.SetMemoryLimit(-1)
+
+	logs := logtest.NewLogRecorder()
+	cgroup := cgroupFS(map[string]string{"cpu.max": "100000 100000", "memory.max": "1000000000"})
+
+	// GOMAXPROCS is kept as it is set by its environment variable.
+	t.Setenv("GOMAXPROCS", "4")
+
+	setRuntimeLimits(config.NewMockConfig(map[string]string{"AUTO_GOMEMLIMIT": "false"}), logs, cgroup)
+
+	assert.Equal(t, 4, runtime.GOMAXPROCS(0))
+	assert.Equal(t, memLimit, debug.SetMemoryLimit(-1))
+	assert.Empty(t, logs.Entries())
+}
@@ -0,0 +1,232 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+)
+
+const defaultSagaStepAttempts = 3
+
+var errInvalidSagaStatus = errors.New("status must be one of RUNNING, COMPENSATING, COMPLETED, COMPENSATED or FAILED")
+
+// Saga is the state of a saga, shared by its steps and compensations.
+type Saga = container.Saga
+
+// SagaFunc runs a step, or the compensation of a step, of a saga.
+type SagaFunc func(ctx *Context, saga *Saga) error
+
+// SagaStep is a step of a saga. Compensate undoes Action when a later step fails, and may be nil.
+type SagaStep struct {
+	Name        string
+	Action      SagaFunc
+	Compensate  SagaFunc
+	MaxAttempts int
+}
+
+// RegisterSaga registers the steps of the sagas of the name. The steps must be idempotent, as they can run again.
+func (a *App) RegisterSaga(name string, steps ...SagaStep) {
+	a.RegisterJob(container.SagaJobName(name), sagaRunner{steps: steps}.run)
+}
+
+// StartSaga persists a saga and enqueues the job running its steps, and returns its ID.
+func (c *Context) StartSaga(name string, payload interface{}) (string, error) {
+	return c.Container.StartSaga(c.Context, name, payload)
+}
+
+// SagaStatus returns the state of the saga of the ID.
+func (c *Context) SagaStatus(id string) (*Saga, error) {
+	store, err := c.Container.SagaStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return store.Get(c.Context, id)
+}
+
+type sagaRunner struct {
+	steps []SagaStep
+}
+
+// run is the handler of the job of a saga, which runs or compensates its steps.
+func (r sagaRunner) run(ctx *Context) error {
+	var payload container.SagaJobPayload
+
+	if err := ctx.Bind(&payload); err != nil {
+		return err
+	}
+
+	store, err := ctx.Container.SagaStore()
+	if err != nil {
+		return err
+	}
+
+	saga, err := store.Get(ctx, payload.SagaID)
+	if err != nil {
+		return err
+	}
+
+	for {
+		switch {
+		case saga.Status == container.SagaRunning && saga.Step >= len(r.steps):
+			saga.Status = container.SagaCompleted
+		case saga.Status == container.SagaCompensating && saga.Step < 0:
+			saga.Status = container.SagaCompensated
+		case saga.Status == container.SagaRunning || saga.Status == container.SagaCompensating:
+			if err := r.runStep(ctx, store, saga, r.steps[saga.Step]); err != nil {
+				return err
+			}
+
+			continue
+		default:
+			// the saga has ended, like when its job is run again after a crash.
+			return nil
+		}
+
+		ctx.Logf("saga %s with ID %s is %s", saga.Name, saga.ID, saga.Status)
+
+		return store.Update(ctx, saga)
+	}
+}
+
+// runStep runs the step, or its compensation, and persists the next state of the saga.
+func (sagaRunner) runStep(ctx *Context, store container.SagaStore, saga *Saga, step SagaStep) error {
+	compensating := saga.Status == container.SagaCompensating
+
+	f := step.Action
+	if compensating {
+		f = step.Compensate
+	}
+
+	err := runSagaFunc(ctx, saga, f)
+	if err == nil {
+		saga.Attempts = 0
+
+		if compensating {
+			saga.Step--
+		} else {
+			saga.Step++
+		}
+
+		return store.Update(ctx, saga)
+	}
+
+	saga.Attempts++
+	saga.LastError = fmt.Sprintf("step %s: %v", step.Name, err)
+
+	maxAttempts := step.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultSagaStepAttempts
+	}
+
+	switch {
+	case saga.Attempts < maxAttempts:
+		if err := store.Update(ctx, saga); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("saga %s with ID %s failed on attempt %d of %d of step %s: %w", saga.Name, saga.ID,
+			saga.Attempts, maxAttempts, step.Name, err)
+	case compensating:
+		ctx.Errorf("saga %s with ID %s could not compensate step %s, error: %v", saga.Name, saga.ID, step.Name, err)
+
+		saga.Status = container.SagaFailed
+	default:
+		ctx.Errorf("saga %s with ID %s failed at step %s and is compensated, error: %v", saga.Name, saga.ID, step.Name, err)
+
+		// the failed step is not compensated, as its action did not succeed.
+		saga.Status = container.SagaCompensating
+		saga.Step--
+	}
+
+	saga.Attempts = 0
+
+	return store.Update(ctx, saga)
+}
+
+func runSagaFunc(ctx *Context, saga *Saga, f SagaFunc) (err error) {
+	if f == nil {
+		return nil
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("%w: %v", errJobPanicked, rec)
+		}
+	}()
+
+	return f(ctx, saga)
+}
+
+// sagasHandler returns the saga of the id, or lists the sagas of the status, FAILED by default.
+func sagasHandler(c *container.Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		store, err := c.SagaStore()
+		if err != nil {
+			writeAdminError(w, http.StatusInternalServerError, err)
+
+			return
+		}
+
+		if id := mux.Vars(r)["id"]; id != "" {
+			writeSaga(r.Context(), w, store, id)
+
+			return
+		}
+
+		status := strings.ToUpper(r.URL.Query().Get("status"))
+		if status == "" {
+			status = container.SagaFailed
+		}
+
+		if !isSagaStatus(status) {
+			writeAdminError(w, http.StatusBadRequest, errInvalidSagaStatus)
+
+			return
+		}
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = defaultJobListLimit
+		}
+
+		sagas, err := store.List(r.Context(), status, min(limit, maxJobListLimit))
+		if err != nil {
+			writeAdminError(w, http.StatusInternalServerError, err)
+
+			return
+		}
+
+		writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": sagas})
+	})
+}
+
+func writeSaga(ctx context.Context, w http.ResponseWriter, store container.SagaStore, id string) {
+	saga, err := store.Get(ctx, id)
+
+	switch {
+	case errors.Is(err, container.ErrSagaNotFound):
+		writeAdminError(w, http.StatusNotFound, err)
+	case err != nil:
+		writeAdminError(w, http.StatusInternalServerError, err)
+	default:
+		writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": saga})
+	}
+}
+
+func isSagaStatus(status string) bool {
+	switch status {
+	case container.SagaRunning, container.SagaCompensating, container.SagaCompleted, container.SagaCompensated,
+		container.SagaFailed:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,194 @@
+package gofr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+)
+
+var (
+	errPaymentDeclined = errors.New("payment declined")
+	errRefund          = errors.New("refund failed")
+)
+
+func newSagaContainer(t *testing.T) *container.Container {
+	t.Helper()
+
+	return container.NewContainer(config.NewMockConfig(map[string]string{
+		"DB_DIALECT": "sqlite",
+		"DB_NAME":    filepath.Join(t.TempDir(), "sagas"),
+	}))
+}
+
+// runSaga runs the job of the saga until it succeeds, like the job runner retrying it, and returns the number of the
+// failed runs.
+func runSaga(t *testing.T, c *container.Container, steps []SagaStep, id string) int {
+	t.Helper()
+
+	failures := 0
+
+	for ; failures < 10; failures++ {
+		msg := pubsub.NewMessage(context.Background())
+		msg.Value, _ = json.Marshal(container.SagaJobPayload{SagaID: id})
+
+		if err := (sagaRunner{steps: steps}).run(newContext(nil, msg, c)); err == nil {
+			return failures
+		}
+	}
+
+	t.Fatal("saga did not end")
+
+	return failures
+}
+
+func TestSagaRunner(t *testing.T) {
+	var calls []string
+
+	step := func(name string, err error) SagaFunc {
+		return func(_ *Context, saga *Saga) error {
+			calls = append(calls, name)
+
+			if err != nil {
+				return err
+			}
+
+			return saga.Set(name, true)
+		}
+	}
+
+	testCases := []struct {
+		desc      string
+		steps     []SagaStep
+		status    string
+		calls     []string
+		failures  int
+		lastError string
+	}{
+		{"all the steps succeed", []SagaStep{
+			{Name: "reserve", Action: step("reserve", nil), Compensate: step("release", nil)},
+			{Name: "charge", Action: step("charge", nil)},
+		}, container.SagaCompleted, []string{"reserve", "charge"}, 0, ""},
+		{"a step fails and the previous ones are compensated", []SagaStep{
+			{Name: "reserve", Action: step("reserve", nil), Compensate: step("release", nil)},
+			{Name: "notify", Action: step("notify", nil)},
+			{Name: "charge", Action: step("charge", errPaymentDeclined), Compensate: step("refund", nil), MaxAttempts: 2},
+		}, container.SagaCompensated, []string{"reserve", "notify", "charge", "charge", "release"}, 1,
+			"step charge: payment declined"},
+		{"a compensation fails", []SagaStep{
+			{Name: "charge", Action: step("charge", nil), Compensate: step("refund", errRefund), MaxAttempts: 1},
+			{Name: "ship", Action: step("ship", errPaymentDeclined), MaxAttempts: 1},
+		}, container.SagaFailed, []string{"charge", "ship", "refund"}, 0, "step charge: refund failed"},
+	}
+
+	for i, tc := range testCases {
+		calls = nil
+		c := newSagaContainer(t)
+
+		id, err := c.StartSaga(context.Background(), "place-order", nil)
+		require.NoError(t, err)
+
+		failures := runSaga(t, c, tc.steps, id)
+
+		store, _ := c.SagaStore()
+		saga, err := store.Get(context.Background(), id)
+		require.NoError(t, err)
+
+		assert.Equal(t, tc.status, saga.Status, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.calls, calls, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.failures, failures, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.lastError, saga.LastError, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		// the job of an ended saga does nothing if it is run again.
+		assert.Zero(t, runSaga(t, c, tc.steps, id), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.calls, calls, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestSagaRunner_DataAndPayload(t *testing.T) {
+	c := newSagaContainer(t)
+
+	var charged int
+
+	steps := []SagaStep{
+		{Name: "reserve", Action: func(_ *Context, saga *Saga) error {
+			var order struct {
+				Amount int `json:"amount"`
+			}
+
+			if err := saga.Bind(&order); err != nil {
+				return err
+			}
+
+			return saga.Set("amount", order.Amount)
+		}},
+		{Name: "charge", Action: func(_ *Context, saga *Saga) error {
+			_, err := saga.Get("amount", &charged)
+			return err
+		}},
+	}
+
+	ctx := &Context{Context: context.Background(), Container: c}
+
+	id, err := ctx.StartSaga("place-order", map[string]int{"amount": 42})
+	require.NoError(t, err)
+
+	runSaga(t, c, steps, id)
+
+	saga, err := ctx.SagaStatus(id)
+	require.NoError(t, err)
+
+	assert.Equal(t, container.SagaCompleted, saga.Status)
+	assert.Equal(t, 42, charged)
+}
+
+func TestApp_RegisterSaga(t *testing.T) {
+	a := &App{}
+
+	a.RegisterSaga("place-order", SagaStep{Name: "reserve", Action: func(*Context, *Saga) error { return nil }})
+
+	assert.Contains(t, a.jobs.handlers, container.SagaJobName("place-order"))
+}
+
+func TestSagasHandler(t *testing.T) {
+	c := newSagaContainer(t)
+
+	id, err := c.StartSaga(context.Background(), "place-order", nil)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.Handle("/sagas", sagasHandler(c))
+	router.Handle("/sagas/{id}", sagasHandler(c))
+
+	testCases := []struct {
+		target     string
+		statusCode int
+		body       string
+	}{
+		{"/sagas/" + id, http.StatusOK, `"status":"RUNNING"`},
+		{"/sagas/missing", http.StatusNotFound, container.ErrSagaNotFound.Error()},
+		{"/sagas?status=running", http.StatusOK, `"id":"` + id + `"`},
+		{"/sagas", http.StatusOK, `{"data":[]}`},
+		{"/sagas?status=unknown", http.StatusBadRequest, errInvalidSagaStatus.Error()},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.target, http.NoBody))
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.target)
+		assert.Contains(t, w.Body.String(), tc.body, "TEST[%d], Failed.\n%s", i, tc.target)
+	}
+}
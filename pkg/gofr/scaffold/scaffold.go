@@ -0,0 +1,338 @@
+// Package scaffold generates new services, handlers and migrations for the gofr command.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+var (
+	ErrNameRequired = errors.New("name is required")
+	ErrInvalidName  = errors.New("name must start with a letter and contain only letters, digits, '-' and '_'")
+	ErrInvalidPath  = errors.New("path must start with '/'")
+)
+
+// ErrFileExists is returned when a file to generate already exists, as the generated files never overwrite the code.
+type ErrFileExists struct {
+	Path string
+}
+
+func (e ErrFileExists) Error() string {
+	return fmt.Sprintf("%s already exists", e.Path)
+}
+
+// ErrMigrationExists is returned when a migration with the same name already exists, as their functions would clash.
+type ErrMigrationExists struct {
+	Name string
+}
+
+func (e ErrMigrationExists) Error() string {
+	return fmt.Sprintf("migration %s already exists", e.Name)
+}
+
+const migrationVersionLayout = "20060102150405"
+
+var (
+	validName     = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_\- ]*$`)
+	migrationFile = regexp.MustCompile(`^(\d{14})_(\w+)\.go$`)
+)
+
+// NewService creates the service of the module in dir/name, and returns the paths of its files.
+func NewService(dir, name, module string) ([]string, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	if module == "" {
+		module = name
+	}
+
+	root := filepath.Join(dir, name)
+
+	if entries, err := os.ReadDir(root); err == nil && len(entries) > 0 {
+		return nil, ErrFileExists{Path: root}
+	}
+
+	data := struct{ Name, Module string }{Name: name, Module: module}
+
+	var created []string
+
+	for file, tmpl := range map[string]string{
+		"go.mod":       "go.mod.tmpl",
+		"main.go":      "main.go.tmpl",
+		"configs/.env": "env.tmpl",
+	} {
+		path := filepath.Join(root, file)
+		if err := writeFile(path, tmpl, data); err != nil {
+			return created, err
+		}
+
+		created = append(created, path)
+	}
+
+	handlerFiles, err := AddHandler(root, "hello", "GET", "/hello")
+
+	created = append(created, handlerFiles...)
+	sort.Strings(created)
+
+	return created, err
+}
+
+// AddHandler creates a handler with its structs and its test, and returns the paths of its files.
+func AddHandler(dir, name, method, path string) ([]string, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	words := splitWords(name)
+
+	method = strings.ToUpper(method)
+	if method == "" {
+		method = "GET"
+	}
+
+	if path == "" {
+		path = "/" + strings.ToLower(strings.Join(words, "-"))
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return nil, ErrInvalidPath
+	}
+
+	data := struct{ Name, Method, MethodName, Path string }{
+		Name:       exportedName(words),
+		Method:     method,
+		MethodName: exportedName([]string{strings.ToLower(method)}),
+		Path:       path,
+	}
+
+	file := filepath.Join(dir, "handler", strings.ToLower(strings.Join(words, "_")))
+
+	if err := checkNotExists(file+".go", file+"_test.go"); err != nil {
+		return nil, err
+	}
+
+	if err := writeFile(file+".go", "handler.go.tmpl", data); err != nil {
+		return nil, err
+	}
+
+	if err := writeFile(file+"_test.go", "handler_test.go.tmpl", data); err != nil {
+		return []string{file + ".go"}, err
+	}
+
+	return []string{file + ".go", file + "_test.go"}, nil
+}
+
+// AddMigration creates a migration and regenerates the All function, and returns the paths of the files.
+func AddMigration(dir, name string, now time.Time) ([]string, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	words := splitWords(name)
+	snake := strings.ToLower(strings.Join(words, "_"))
+	migrationsDir := filepath.Join(dir, "migrations")
+
+	migrations, err := listMigrations(migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range migrations {
+		if m.name == snake {
+			return nil, ErrMigrationExists{Name: snake}
+		}
+	}
+
+	version := now.UTC().Format(migrationVersionLayout)
+	path := filepath.Join(migrationsDir, version+"_"+snake+".go")
+
+	// the function is named from the name of the file, as the All function is generated from the names of the files.
+	m := migration{version: version, name: snake}
+
+	if err := writeFile(path, "migration.go.tmpl", m); err != nil {
+		return nil, err
+	}
+
+	migrations = append(migrations, m)
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+
+	all := filepath.Join(migrationsDir, "all.go")
+	if err := os.Remove(all); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return []string{path}, err
+	}
+
+	return []string{path, all}, writeFile(all, "migrations_all.go.tmpl", migrations)
+}
+
+type migration struct {
+	version string
+	name    string
+}
+
+// Version and Func are used by the template of the All function.
+func (m migration) Version() string { return m.version }
+
+func (m migration) Func() string { return unexportedName(splitWords(m.name)) }
+
+// listMigrations returns the migrations in dir, from the names of their files.
+func listMigrations(dir string) ([]migration, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []migration
+
+	for _, e := range entries {
+		if m := migrationFile.FindStringSubmatch(e.Name()); m != nil && !strings.HasSuffix(m[2], "_test") {
+			migrations = append(migrations, migration{version: m[1], name: m[2]})
+		}
+	}
+
+	return migrations, nil
+}
+
+func validateName(name string) error {
+	if name == "" {
+		return ErrNameRequired
+	}
+
+	if !validName.MatchString(name) {
+		return ErrInvalidName
+	}
+
+	return nil
+}
+
+// splitWords splits a name in kebab, snake or camel case into its words.
+func splitWords(name string) []string {
+	var (
+		words   []string
+		current []rune
+	)
+
+	runes := []rune(name)
+
+	for i, r := range runes {
+		if r == '-' || r == '_' || r == ' ' {
+			if len(current) > 0 {
+				words = append(words, string(current))
+			}
+
+			current = nil
+
+			continue
+		}
+
+		// a word starts at an upper case letter following a lower case one, or ending an acronym like in "HTTPClient".
+		if unicode.IsUpper(r) && len(current) > 0 && (!unicode.IsUpper(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, string(current))
+			current = nil
+		}
+
+		current = append(current, r)
+	}
+
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+
+	return words
+}
+
+func exportedName(words []string) string {
+	var b strings.Builder
+
+	for _, w := range words {
+		r := []rune(w)
+		b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+	}
+
+	return b.String()
+}
+
+func unexportedName(words []string) string {
+	name := []rune(exportedName(words))
+
+	// the leading acronym is lowered entirely, like "HTTPClient" to "httpClient".
+	for i := 0; i < len(name) && unicode.IsUpper(name[i]); i++ {
+		if i > 0 && i+1 < len(name) && unicode.IsLower(name[i+1]) {
+			break
+		}
+
+		name[i] = unicode.ToLower(name[i])
+	}
+
+	return string(name)
+}
+
+func checkNotExists(paths ...string) error {
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			return ErrFileExists{Path: p}
+		}
+	}
+
+	return nil
+}
+
+// writeFile creates the file at path from the template, formatting it if it is Go code. It fails if the file exists.
+func writeFile(path, tmpl string, data interface{}) error {
+	t, err := template.ParseFS(templates, "templates/"+tmpl)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	if err = t.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	content := buf.Bytes()
+
+	if strings.HasSuffix(path, ".go") {
+		if content, err = format.Source(content); err != nil {
+			return err
+		}
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644) //nolint:gosec // generated code is not secret.
+	if errors.Is(err, os.ErrExist) {
+		return ErrFileExists{Path: path}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	_, err = f.Write(content)
+
+	return err
+}
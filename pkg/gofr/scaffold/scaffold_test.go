@@ -0,0 +1,138 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewService(t *testing.T) {
+	dir := t.TempDir()
+
+	files, err := NewService(dir, "orders", "github.com/acme/orders")
+	require.NoError(t, err)
+
+	root := filepath.Join(dir, "orders")
+
+	assert.Equal(t, []string{
+		filepath.Join(root, "configs/.env"),
+		filepath.Join(root, "go.mod"),
+		filepath.Join(root, "handler/hello.go"),
+		filepath.Join(root, "handler/hello_test.go"),
+		filepath.Join(root, "main.go"),
+	}, files)
+
+	assert.Contains(t, readFile(t, root, "go.mod"), "module github.com/acme/orders\n")
+	assert.Contains(t, readFile(t, root, "main.go"), `"github.com/acme/orders/handler"`)
+	assert.Contains(t, readFile(t, root, "configs/.env"), "APP_NAME=orders\n")
+
+	_, err = NewService(dir, "orders", "")
+	assert.Equal(t, ErrFileExists{Path: root}, err, "existing service should not be overwritten")
+}
+
+func TestNewService_DefaultModule(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewService(dir, "orders", "")
+	require.NoError(t, err)
+
+	assert.Contains(t, readFile(t, filepath.Join(dir, "orders"), "go.mod"), "module orders\n")
+}
+
+func TestAddHandler(t *testing.T) {
+	dir := t.TempDir()
+
+	files, err := AddHandler(dir, "create-order", "post", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{filepath.Join(dir, "handler/create_order.go"),
+		filepath.Join(dir, "handler/create_order_test.go")}, files)
+
+	handler := readFile(t, dir, "handler/create_order.go")
+
+	assert.Contains(t, handler, "type CreateOrderRequest struct")
+	assert.Contains(t, handler, "type CreateOrderResponse struct")
+	assert.Contains(t, handler, "// CreateOrder handles POST /create-order.")
+	assert.Contains(t, readFile(t, dir, "handler/create_order_test.go"),
+		`httptest.NewRequest(http.MethodPost, "/create-order", strings.NewReader(`+"`{}`))")
+
+	_, err = AddHandler(dir, "CreateOrder", "", "/orders")
+	assert.Equal(t, ErrFileExists{Path: filepath.Join(dir, "handler/create_order.go")}, err)
+}
+
+func TestAddHandler_Errors(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		name   string
+		path   string
+		expErr error
+	}{
+		{"missing name", "", "", ErrNameRequired},
+		{"invalid name", "1order", "", ErrInvalidName},
+		{"invalid characters", "order/item", "", ErrInvalidName},
+		{"invalid path", "order", "orders", ErrInvalidPath},
+	}
+
+	for i, tc := range testCases {
+		_, err := AddHandler(t.TempDir(), tc.name, "", tc.path)
+
+		assert.Equal(t, tc.expErr, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestAddMigration(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 2, 10, 30, 0, 0, time.UTC)
+
+	_, err := AddMigration(dir, "seed orders", now)
+	require.NoError(t, err)
+
+	files, err := AddMigration(dir, "create_orders_table", now.Add(-24*time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{filepath.Join(dir, "migrations/20240501103000_create_orders_table.go"),
+		filepath.Join(dir, "migrations/all.go")}, files)
+
+	assert.Contains(t, readFile(t, dir, "migrations/20240501103000_create_orders_table.go"),
+		"func createOrdersTable() migration.Migrate {")
+	assert.Contains(t, readFile(t, dir, "migrations/all.go"),
+		"\t\t20240501103000: createOrdersTable(),\n\t\t20240502103000: seedOrders(),\n")
+
+	_, err = AddMigration(dir, "SeedOrders", now.Add(time.Hour))
+	assert.Equal(t, ErrMigrationExists{Name: "seed_orders"}, err)
+}
+
+func TestNames(t *testing.T) {
+	testCases := []struct {
+		name       string
+		exported   string
+		unexported string
+	}{
+		{"create-order", "CreateOrder", "createOrder"},
+		{"create_order", "CreateOrder", "createOrder"},
+		{"CreateOrder", "CreateOrder", "createOrder"},
+		{"create order", "CreateOrder", "createOrder"},
+		{"HTTPClientSeed", "HTTPClientSeed", "httpClientSeed"},
+		{"getUserID", "GetUserID", "getUserID"},
+	}
+
+	for i, tc := range testCases {
+		words := splitWords(tc.name)
+
+		assert.Equal(t, tc.exported, exportedName(words), "TEST[%d], Failed.\n%s", i, tc.name)
+		assert.Equal(t, tc.unexported, unexportedName(words), "TEST[%d], Failed.\n%s", i, tc.name)
+	}
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	require.NoError(t, err)
+
+	return string(b)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/bulkhead"
+)
+
+// BulkheadConfig limits the concurrent requests to the service, rejecting the ones beyond the queue.
+type BulkheadConfig struct {
+	MaxConcurrent int
+	MaxQueue      int
+	QueueTimeout  time.Duration
+}
+
+func (b *BulkheadConfig) AddOption(h HTTP) HTTP {
+	return b.addMetricOption(h, "", nil)
+}
+
+func (b *BulkheadConfig) addMetricOption(h HTTP, address string, metrics Metrics) HTTP {
+	cfg := bulkhead.Config{MaxConcurrent: b.MaxConcurrent, MaxQueue: b.MaxQueue, QueueTimeout: b.QueueTimeout}
+
+	// the rejections are counted if the metrics of the service have counters, like those of the container.
+	counter, _ := metrics.(bulkhead.Metrics)
+
+	return &bulkheadService{bulkhead: bulkhead.New(address, cfg, counter), HTTP: h}
+}
+
+type bulkheadService struct {
+	bulkhead *bulkhead.Bulkhead
+
+	HTTP
+}
+
+func (b *bulkheadService) do(ctx context.Context, call func() (*http.Response, error)) (*http.Response, error) {
+	release, err := b.bulkhead.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	return call()
+}
+
+func (b *bulkheadService) Get(ctx context.Context, path string, queryParams map[string]interface{}) (*http.Response, error) {
+	return b.GetWithHeaders(ctx, path, queryParams, nil)
+}
+
+func (b *bulkheadService) GetWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) {
+		return b.HTTP.GetWithHeaders(ctx, path, queryParams, headers)
+	})
+}
+
+func (b *bulkheadService) Post(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte) (*http.Response, error) {
+	return b.PostWithHeaders(ctx, path, queryParams, body, nil)
+}
+
+func (b *bulkheadService) PostWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte, headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) {
+		return b.HTTP.PostWithHeaders(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (b *bulkheadService) Put(ctx context.Context, path string, queryParams map[string]interface{}, body []byte) (
+	*http.Response, error) {
+	return b.PutWithHeaders(ctx, path, queryParams, body, nil)
+}
+
+func (b *bulkheadService) PutWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte, headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) {
+		return b.HTTP.PutWithHeaders(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (b *bulkheadService) Patch(ctx context.Context, path string, queryParams map[string]interface{}, body []byte) (
+	*http.Response, error) {
+	return b.PatchWithHeaders(ctx, path, queryParams, body, nil)
+}
+
+func (b *bulkheadService) PatchWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte, headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) {
+		return b.HTTP.PatchWithHeaders(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (b *bulkheadService) Delete(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	return b.DeleteWithHeaders(ctx, path, body, nil)
+}
+
+func (b *bulkheadService) DeleteWithHeaders(ctx context.Context, path string, body []byte, headers map[string]string) (
+	*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) {
+		return b.HTTP.DeleteWithHeaders(ctx, path, body, headers)
+	})
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/bulkhead"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+type countingMetrics struct {
+	rejected []string
+}
+
+func (*countingMetrics) RecordHistogram(context.Context, string, float64, ...string) {}
+
+func (m *countingMetrics) IncrementCounter(_ context.Context, name string, labels ...string) {
+	m.rejected = append(m.rejected, name+" "+labels[1]+" "+labels[3])
+}
+
+func TestBulkheadConfig_AddOption(t *testing.T) {
+	unblock := make(chan struct{})
+	started := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			started <- struct{}{}
+			<-unblock
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metrics := &countingMetrics{}
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.FATAL), metrics,
+		&BulkheadConfig{MaxConcurrent: 1, MaxQueue: 1, QueueTimeout: 20 * time.Millisecond})
+
+	done := make(chan error)
+
+	go func() {
+		resp, err := svc.Get(context.Background(), "slow", nil)
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		done <- err
+	}()
+
+	<-started
+
+	calls := []func() (*http.Response, error){
+		func() (*http.Response, error) { return svc.Get(context.Background(), "fast", nil) },
+		func() (*http.Response, error) { return svc.Post(context.Background(), "fast", nil, nil) },
+		func() (*http.Response, error) { return svc.Put(context.Background(), "fast", nil, nil) },
+		func() (*http.Response, error) { return svc.Patch(context.Background(), "fast", nil, nil) },
+		func() (*http.Response, error) { return svc.Delete(context.Background(), "fast", nil) },
+	}
+
+	for i, call := range calls {
+		resp, err := call()
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		assert.ErrorIs(t, err, bulkhead.ErrQueueTimeout, "TEST[%d], Failed.\n", i)
+	}
+
+	assert.Len(t, metrics.rejected, len(calls))
+	assert.Equal(t, bulkhead.RejectedMetric+" "+server.URL+" timeout", metrics.rejected[0])
+
+	close(unblock)
+	require.NoError(t, <-done)
+
+	for i, call := range calls {
+		resp, err := call()
+		require.NoError(t, err, "TEST[%d], Failed.\n", i)
+
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
+)
+
+// FaultInjectionConfig injects the faults of Injector into the requests to the service.
+type FaultInjectionConfig struct {
+	Injector *chaos.Injector
+	Name     string
+}
+
+func (f *FaultInjectionConfig) AddOption(h HTTP) HTTP {
+	return &faultService{injector: f.Injector, name: f.Name, HTTP: h}
+}
+
+type faultService struct {
+	injector *chaos.Injector
+	name     string
+
+	HTTP
+}
+
+func (f *faultService) do(ctx context.Context, method, path string, call func() (*http.Response, error)) (
+	*http.Response, error) {
+	status, err := f.injector.InjectResponse(ctx, f.name, method+" /"+strings.TrimPrefix(path, "/"))
+	if status != 0 {
+		return &http.Response{
+			Status:     strconv.Itoa(status) + " " + http.StatusText(status),
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"injected fault"}}`)),
+		}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return call()
+}
+
+func (f *faultService) Get(ctx context.Context, path string, queryParams map[string]interface{}) (*http.Response, error) {
+	return f.GetWithHeaders(ctx, path, queryParams, nil)
+}
+
+func (f *faultService) GetWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	headers map[string]string) (*http.Response, error) {
+	return f.do(ctx, http.MethodGet, path, func() (*http.Response, error) {
+		return f.HTTP.GetWithHeaders(ctx, path, queryParams, headers)
+	})
+}
+
+func (f *faultService) Post(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte) (*http.Response, error) {
+	return f.PostWithHeaders(ctx, path, queryParams, body, nil)
+}
+
+func (f *faultService) PostWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte, headers map[string]string) (*http.Response, error) {
+	return f.do(ctx, http.MethodPost, path, func() (*http.Response, error) {
+		return f.HTTP.PostWithHeaders(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (f *faultService) Put(ctx context.Context, path string, queryParams map[string]interface{}, body []byte) (
+	*http.Response, error) {
+	return f.PutWithHeaders(ctx, path, queryParams, body, nil)
+}
+
+func (f *faultService) PutWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte, headers map[string]string) (*http.Response, error) {
+	return f.do(ctx, http.MethodPut, path, func() (*http.Response, error) {
+		return f.HTTP.PutWithHeaders(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (f *faultService) Patch(ctx context.Context, path string, queryParams map[string]interface{}, body []byte) (
+	*http.Response, error) {
+	return f.PatchWithHeaders(ctx, path, queryParams, body, nil)
+}
+
+func (f *faultService) PatchWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte, headers map[string]string) (*http.Response, error) {
+	return f.do(ctx, http.MethodPatch, path, func() (*http.Response, error) {
+		return f.HTTP.PatchWithHeaders(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (f *faultService) Delete(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	return f.DeleteWithHeaders(ctx, path, body, nil)
+}
+
+func (f *faultService) DeleteWithHeaders(ctx context.Context, path string, body []byte, headers map[string]string) (
+	*http.Response, error) {
+	return f.do(ctx, http.MethodDelete, path, func() (*http.Response, error) {
+		return f.HTTP.DeleteWithHeaders(ctx, path, body, headers)
+	})
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/chaos"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+func TestFaultInjectionConfig_AddOption(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	faults, err := chaos.New(nil,
+		chaos.Fault{Target: "orders", Operation: "^GET /orders", Kind: chaos.KindError, Rate: 1, Status: 500},
+		chaos.Fault{Target: "orders", Operation: "^DELETE ", Kind: chaos.KindReset, Rate: 1},
+		chaos.Fault{Target: "payments", Kind: chaos.KindError, Rate: 1})
+	require.NoError(t, err)
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.FATAL), nil,
+		&FaultInjectionConfig{Injector: faults, Name: "orders"})
+
+	resp, err := svc.Get(context.Background(), "orders/1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "500 Internal Server Error", resp.Status)
+	resp.Body.Close()
+
+	_, err = svc.Delete(context.Background(), "/orders/1", nil)
+	require.ErrorIs(t, err, syscall.ECONNRESET)
+
+	resp, err = svc.Post(context.Background(), "orders", nil, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	assert.Equal(t, 1, requests, "the faulted requests are not sent")
+}
+
+func TestFaultInjectionConfig_OpensCircuit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	faults, err := chaos.New(nil, chaos.Fault{Target: "orders", Kind: chaos.KindReset, Rate: 1})
+	require.NoError(t, err)
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.FATAL), nil,
+		&FaultInjectionConfig{Injector: faults, Name: "orders"},
+		&CircuitBreakerConfig{Threshold: 1, Interval: time.Hour})
+
+	for i := 0; i < 2; i++ {
+		_, err = svc.Get(context.Background(), "orders", nil)
+		require.Error(t, err)
+	}
+
+	_, err = svc.Get(context.Background(), "orders", nil)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
 )
 
 // circuitBreaker states.
@@ -24,6 +26,8 @@ var (
 type CircuitBreakerConfig struct {
 	Threshold int           // Threshold represents the max no of retry before switching the circuit breaker state.
 	Interval  time.Duration // Interval represents the time interval duration between hitting the HealthURL
+	// Clock times the health checks of the open circuit, and is the clock of the app, or of the system, if nil.
+	Clock clock.Clock
 }
 
 // circuitBreaker represents a circuit breaker implementation.
@@ -34,6 +38,7 @@ type circuitBreaker struct {
 	threshold    int
 	interval     time.Duration
 	lastChecked  time.Time
+	clock        clock.Clock
 
 	HTTP
 }
@@ -46,9 +51,14 @@ func NewCircuitBreaker(config CircuitBreakerConfig, h HTTP) *circuitBreaker {
 		state:     ClosedState,
 		threshold: config.Threshold,
 		interval:  config.Interval,
+		clock:     config.Clock,
 		HTTP:      h,
 	}
 
+	if cb.clock == nil {
+		cb.clock = clock.New()
+	}
+
 	// Perform asynchronous health checks
 	go cb.startHealthChecks()
 
@@ -62,7 +72,7 @@ func (cb *circuitBreaker) executeWithCircuitBreaker(ctx context.Context, f func(
 	defer cb.mu.Unlock()
 
 	if cb.state == OpenState {
-		if time.Since(cb.lastChecked) > cb.interval {
+		if cb.clock.Since(cb.lastChecked) > cb.interval {
 			// Check health before potentially closing the circuit
 			if cb.healthCheck(ctx) {
 				cb.resetCircuit()
@@ -106,9 +116,9 @@ func (cb *circuitBreaker) healthCheck(ctx context.Context) bool {
 
 // startHealthChecks initiates periodic health checks.
 func (cb *circuitBreaker) startHealthChecks() {
-	ticker := time.NewTicker(cb.interval)
+	ticker := cb.clock.NewTicker(cb.interval)
 
-	for range ticker.C {
+	for range ticker.C() {
 		if cb.isOpen() {
 			go func() {
 				if cb.healthCheck(context.TODO()) {
@@ -122,7 +132,7 @@ func (cb *circuitBreaker) startHealthChecks() {
 // openCircuit transitions the circuit breaker to the open state.
 func (cb *circuitBreaker) openCircuit() {
 	cb.state = OpenState
-	cb.lastChecked = time.Now()
+	cb.lastChecked = cb.clock.Now()
 }
 
 // resetCircuit transitions the circuit breaker to the closed state.
@@ -149,7 +159,7 @@ func (cb *CircuitBreakerConfig) AddOption(h HTTP) HTTP {
 }
 
 func (cb *circuitBreaker) tryCircuitRecovery() bool {
-	if time.Since(cb.lastChecked) > cb.interval && cb.healthCheck(context.TODO()) {
+	if cb.clock.Since(cb.lastChecked) > cb.interval && cb.healthCheck(context.TODO()) {
 		cb.resetCircuit()
 		return true
 	}
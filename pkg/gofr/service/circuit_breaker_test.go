@@ -8,11 +8,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.opentelemetry.io/otel"
 
+	"github.com/peter-stratton/gofr/pkg/gofr/clock"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
 	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
 )
@@ -125,6 +127,38 @@ func TestHttpService_GetCBOpenRequests(t *testing.T) {
 	}
 }
 
+func TestHttpService_CBRecoversOnClock(t *testing.T) {
+	server := testServer()
+	defer server.Close()
+
+	fake := clock.NewFake(time.Now())
+
+	cbConfig := CircuitBreakerConfig{Threshold: 1, Interval: time.Minute, Clock: fake}
+
+	service := cbConfig.AddOption(&httpService{
+		Client: &http.Client{Transport: &customTransport{}},
+		url:    server.URL,
+		Tracer: otel.Tracer("gofr-http-client"),
+		Logger: logging.NewMockLogger(logging.DEBUG),
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := service.Get(context.Background(), "invalid", nil)
+		assert.Error(t, err, "TEST[%d], Failed.\n", i)
+	}
+
+	// the circuit stays open until the interval has passed on the clock.
+	_, err := service.Get(context.Background(), "success", nil)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	fake.Advance(2 * time.Minute)
+
+	resp, err := service.Get(context.Background(), "success", nil)
+	if assert.NoError(t, err) {
+		_ = resp.Body.Close()
+	}
+}
+
 func TestHttpService_GetWithHeaderCBOpenRequests(t *testing.T) {
 	server, service := setupHTTPServiceTestServerForCircuitBreaker()
 	defer server.Close()
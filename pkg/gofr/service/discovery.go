@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// discoveryRefreshInterval is the interval at which the addresses of a discovered service are resolved again.
+	discoveryRefreshInterval = 30 * time.Second
+	defaultConsulAddress     = "http://127.0.0.1:8500"
+)
+
+var (
+	// ErrNoInstances is returned for the requests to a discovered service which has no instance.
+	ErrNoInstances = errors.New("no instance of the service is discovered")
+)
+
+// Resolver resolves the name of a service to the addresses of its instances, like "10.0.0.7:8080".
+type Resolver interface {
+	Resolve(ctx context.Context, name string) ([]string, error)
+}
+
+// Resolvers is an option of the service, with the resolvers of the addresses of other schemes than "consul" and
+// "dns+srv", like "eureka".
+type Resolvers map[string]Resolver
+
+// AddOption returns the service as it is, as its address is resolved by NewHTTPService.
+func (Resolvers) AddOption(h HTTP) HTTP {
+	return h
+}
+
+// resolverOf returns the resolver of the scheme, or nil if it has none.
+func (r Resolvers) resolverOf(scheme string) Resolver {
+	if resolver, ok := r[scheme]; ok {
+		return resolver
+	}
+
+	switch scheme {
+	case "consul":
+		return &ConsulResolver{}
+	case "dns+srv":
+		return &DNSSRVResolver{}
+	default:
+		return nil
+	}
+}
+
+// DNSSRVResolver resolves a service to the targets and ports of its SRV records.
+type DNSSRVResolver struct {
+	// LookupSRV looks the SRV records of the name up, which is net.DefaultResolver.LookupSRV by default.
+	LookupSRV func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+func (r *DNSSRVResolver) Resolve(ctx context.Context, name string) ([]string, error) {
+	lookup := r.LookupSRV
+	if lookup == nil {
+		lookup = net.DefaultResolver.LookupSRV
+	}
+
+	_, records, err := lookup(ctx, "", "", name)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]string, 0, len(records))
+
+	for _, srv := range records {
+		addrs = append(addrs, net.JoinHostPort(strings.TrimSuffix(srv.Target, "."), strconv.Itoa(int(srv.Port))))
+	}
+
+	return addrs, nil
+}
+
+// ConsulResolver resolves a service to its healthy instances registered in Consul.
+type ConsulResolver struct {
+	// Address is the address of the HTTP API of Consul, which is CONSUL_HTTP_ADDR, or http://127.0.0.1:8500, by default.
+	Address string
+	// Token is the ACL token of the requests, which is CONSUL_HTTP_TOKEN by default.
+	Token string
+	// Client is the client of the requests, which is http.DefaultClient by default.
+	Client *http.Client
+}
+
+type consulEntry struct {
+	Node struct {
+		Address string
+	}
+	Service struct {
+		Address string
+		Port    int
+	}
+}
+
+func (r *ConsulResolver) Resolve(ctx context.Context, name string) ([]string, error) {
+	address, token, client := r.Address, r.Token, r.Client
+
+	if address == "" {
+		address = os.Getenv("CONSUL_HTTP_ADDR")
+	}
+
+	if address == "" {
+		address = defaultConsulAddress
+	}
+
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+
+	if token == "" {
+		token = os.Getenv("CONSUL_HTTP_TOKEN")
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(address, "/")+"/v1/health/service/"+url.PathEscape(name)+"?passing=true", http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		req.Header.Set("X-Consul-Token", token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("consul responded %s to the lookup of %s", resp.Status, name)
+	}
+
+	var entries []consulEntry
+
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	addrs := make([]string, 0, len(entries))
+
+	for _, e := range entries {
+		host := e.Service.Address
+		if host == "" {
+			host = e.Node.Address
+		}
+
+		addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(e.Service.Port)))
+	}
+
+	return addrs, nil
+}
+
+// balancer sends the requests to the instances of a discovered service in turn.
+type balancer struct {
+	name      string
+	resolver  Resolver
+	transport http.RoundTripper
+	logger    Logger
+
+	addrs      atomic.Pointer[[]string]
+	next       atomic.Uint64
+	resolvedAt atomic.Int64
+	refreshing atomic.Bool
+
+	// mu makes the requests wait for the first resolution.
+	mu sync.Mutex
+}
+
+// newBalancer returns the balancer and the URL of a discovered service, or nil if its scheme has no resolver.
+func newBalancer(address string, logger Logger, resolvers Resolvers) (b *balancer, serviceURL string) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, ""
+	}
+
+	resolver := resolvers.resolverOf(u.Scheme)
+	if resolver == nil {
+		return nil, ""
+	}
+
+	b = &balancer{name: u.Host, resolver: resolver, transport: http.DefaultTransport, logger: logger}
+
+	return b, "http://" + u.Host + strings.TrimSuffix(u.Path, "/")
+}
+
+func (b *balancer) RoundTrip(req *http.Request) (*http.Response, error) {
+	addr, err := b.pick(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	r := req.Clone(req.Context())
+	r.URL.Host = addr
+	r.Host = ""
+
+	return b.transport.RoundTrip(r)
+}
+
+// pick returns the address of the next instance of the service.
+func (b *balancer) pick(ctx context.Context) (string, error) {
+	addrs := b.addrs.Load()
+
+	switch {
+	case addrs == nil:
+		if err := b.resolveFirst(ctx); err != nil {
+			return "", err
+		}
+
+		addrs = b.addrs.Load()
+	case time.Since(time.Unix(0, b.resolvedAt.Load())) >= discoveryRefreshInterval && b.refreshing.CompareAndSwap(false, true):
+		go func() {
+			defer b.refreshing.Store(false)
+
+			ctx, cancel := context.WithTimeout(context.Background(), discoveryRefreshInterval)
+			defer cancel()
+
+			_ = b.resolve(ctx)
+		}()
+	}
+
+	if len(*addrs) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrNoInstances, b.name)
+	}
+
+	return (*addrs)[(b.next.Add(1)-1)%uint64(len(*addrs))], nil
+}
+
+// resolveFirst resolves the addresses, unless another request did while it waited.
+func (b *balancer) resolveFirst(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.addrs.Load() != nil {
+		return nil
+	}
+
+	return b.resolve(ctx)
+}
+
+func (b *balancer) resolve(ctx context.Context) error {
+	addrs, err := b.resolver.Resolve(ctx, b.name)
+	if err != nil {
+		if b.logger != nil {
+			b.logger.Log(fmt.Sprintf("could not discover the instances of %s: %v", b.name, err))
+		}
+
+		return err
+	}
+
+	b.addrs.Store(&addrs)
+	b.resolvedAt.Store(time.Now().UnixNano())
+
+	return nil
+}
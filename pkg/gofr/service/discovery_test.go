@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+var errLookup = errors.New("lookup failed")
+
+type staticResolver struct {
+	addrs []string
+	err   error
+	calls int
+}
+
+func (r *staticResolver) Resolve(context.Context, string) ([]string, error) {
+	r.calls++
+
+	return r.addrs, r.err
+}
+
+func TestDNSSRVResolver_Resolve(t *testing.T) {
+	r := &DNSSRVResolver{LookupSRV: func(_ context.Context, _, _, name string) (string, []*net.SRV, error) {
+		assert.Equal(t, "_http._tcp.payments.internal", name)
+
+		return "", []*net.SRV{{Target: "a.payments.internal.", Port: 8080}, {Target: "b.payments.internal.", Port: 8081}}, nil
+	}}
+
+	addrs, err := r.Resolve(context.Background(), "_http._tcp.payments.internal")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.payments.internal:8080", "b.payments.internal:8081"}, addrs)
+}
+
+func TestConsulResolver_Resolve(t *testing.T) {
+	consul := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/health/service/payments", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("passing"))
+		assert.Equal(t, "secret", r.Header.Get("X-Consul-Token"))
+
+		_, _ = w.Write([]byte(`[{"Node":{"Address":"10.0.0.1"},"Service":{"Address":"10.0.1.1","Port":8080}},
+			{"Node":{"Address":"10.0.0.2"},"Service":{"Address":"","Port":8081}}]`))
+	}))
+	defer consul.Close()
+
+	r := &ConsulResolver{Address: consul.URL, Token: "secret"}
+
+	addrs, err := r.Resolve(context.Background(), "payments")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.1.1:8080", "10.0.0.2:8081"}, addrs)
+}
+
+func TestConsulResolver_ResolveError(t *testing.T) {
+	consul := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer consul.Close()
+
+	t.Setenv("CONSUL_HTTP_ADDR", strings.TrimPrefix(consul.URL, "http://"))
+
+	_, err := (&ConsulResolver{}).Resolve(context.Background(), "payments")
+
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestNewHTTPService_Discovery(t *testing.T) {
+	var served []string
+
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/orders", r.URL.Path)
+
+			served = append(served, name)
+
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+
+	first, second := newServer("first"), newServer("second")
+	defer first.Close()
+	defer second.Close()
+
+	resolver := &staticResolver{addrs: []string{first.Listener.Addr().String(), second.Listener.Addr().String()}}
+
+	metrics := NewMockMetrics(gomock.NewController(t))
+	metrics.EXPECT().RecordHistogram(gomock.Any(), "app_http_service_response", gomock.Any(), "path", "http://payments/api",
+		"method", http.MethodGet, "status", strconv.Itoa(http.StatusOK)).Times(3)
+
+	svc := NewHTTPService("test://payments/api/", logging.NewMockLogger(logging.INFO), metrics, Resolvers{"test": resolver})
+
+	for i := 0; i < 3; i++ {
+		resp, err := svc.Get(context.Background(), "orders", nil)
+		require.NoError(t, err)
+
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, []string{"first", "second", "first"}, served)
+	assert.Equal(t, 1, resolver.calls)
+}
+
+func TestResolvers_ResolverOf(t *testing.T) {
+	eureka := &staticResolver{}
+	resolvers := Resolvers{"eureka": eureka}
+
+	tests := []struct {
+		scheme   string
+		resolver Resolver
+	}{
+		{"eureka", eureka},
+		{"consul", &ConsulResolver{}},
+		{"dns+srv", &DNSSRVResolver{}},
+		{"https", nil},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.resolver, resolvers.resolverOf(tc.scheme), "TEST[%d], Failed.\n%s", i, tc.scheme)
+	}
+
+	assert.Nil(t, Resolvers(nil).resolverOf("eureka"))
+}
+
+func TestBalancer_NoInstances(t *testing.T) {
+	tests := []struct {
+		desc     string
+		resolver *staticResolver
+		err      error
+	}{
+		{"resolution fails", &staticResolver{err: errLookup}, errLookup},
+		{"no instance", &staticResolver{addrs: []string{}}, ErrNoInstances},
+	}
+
+	for i, tc := range tests {
+		b := &balancer{name: "payments", resolver: tc.resolver, transport: http.DefaultTransport}
+
+		_, err := b.pick(context.Background())
+
+		assert.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestBalancer_RefreshKeepsAddresses(t *testing.T) {
+	b := &balancer{name: "payments", resolver: &staticResolver{err: errLookup}, transport: http.DefaultTransport}
+
+	addrs := []string{"10.0.0.1:8080"}
+	b.addrs.Store(&addrs)
+	b.resolvedAt.Store(time.Now().Add(-2 * discoveryRefreshInterval).UnixNano())
+
+	addr, err := b.pick(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:8080", addr)
+
+	// the failed refresh keeps the addresses which were resolved last.
+	assert.Eventually(t, func() bool { return !b.refreshing.Load() }, time.Second, 10*time.Millisecond)
+
+	addr, err = b.pick(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:8080", addr)
+}
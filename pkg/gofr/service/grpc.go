@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health" // This is required to enable the client side health checking
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const defaultGRPCMaxAttempts = 3
+
+// GRPC is a connection to a gRPC service, e.g. customer.NewCustomerServiceClient(ctx.GetGRPCService("customer")).
+type GRPC interface {
+	grpc.ClientConnInterface
+
+	// HealthCheck to get the service health using the grpc.health.v1 service and report it to the current application
+	HealthCheck(ctx context.Context) *Health
+	// Close closes the connection to the service.
+	Close() error
+}
+
+// GRPCConfig configures the connection to a gRPC service.
+type GRPCConfig struct {
+	// MaxAttempts is the number of attempts of a call failing with UNAVAILABLE, 3 by default.
+	MaxAttempts int
+	// Timeout is the deadline of the unary calls whose context has none.
+	Timeout time.Duration
+	// HealthService is the name of the service checked by HealthCheck and by the load balancer.
+	HealthService string
+	// DialOptions are applied after the defaults, e.g. grpc.WithTransportCredentials.
+	DialOptions []grpc.DialOption
+}
+
+type grpcService struct {
+	*grpc.ClientConn
+
+	target        string
+	timeout       time.Duration
+	healthService string
+
+	Logger
+	Metrics
+}
+
+// NewGRPCService creates a load balanced connection to the gRPC service at target, e.g. "dns:///customer:9000".
+func NewGRPCService(target string, logger Logger, metrics Metrics, config *GRPCConfig) (GRPC, error) {
+	if config == nil {
+		config = &GRPCConfig{}
+	}
+
+	g := &grpcService{
+		target:        target,
+		timeout:       config.Timeout,
+		healthService: config.HealthService,
+		Logger:        logger,
+		Metrics:       metrics,
+	}
+
+	serviceConfig, err := grpcServiceConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(g.unaryInterceptor),
+	}, config.DialOptions...)
+
+	g.ClientConn, err = grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// grpcServiceConfig enables the round-robin load balancing and the retries of the calls.
+func grpcServiceConfig(config *GRPCConfig) (string, error) {
+	serviceConfig := map[string]interface{}{
+		"loadBalancingConfig": []interface{}{map[string]interface{}{"round_robin": map[string]interface{}{}}},
+		"healthCheckConfig":   map[string]interface{}{"serviceName": config.HealthService},
+	}
+
+	maxAttempts := config.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultGRPCMaxAttempts
+	}
+
+	if maxAttempts > 1 {
+		serviceConfig["methodConfig"] = []interface{}{map[string]interface{}{
+			// the empty name applies the policy to all the methods.
+			"name": []interface{}{map[string]interface{}{}},
+			"retryPolicy": map[string]interface{}{
+				"maxAttempts":          maxAttempts,
+				"initialBackoff":       "0.1s",
+				"maxBackoff":           "1s",
+				"backoffMultiplier":    2,
+				"retryableStatusCodes": []string{"UNAVAILABLE"},
+			},
+		}}
+	}
+
+	b, err := json.Marshal(serviceConfig)
+
+	return string(b), err
+}
+
+func (g *grpcService) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && g.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+
+	respTime := time.Since(start)
+	code := status.Code(err)
+
+	if g.Metrics != nil {
+		g.RecordHistogram(ctx, "app_grpc_service_response", respTime.Seconds(), "target", g.target, "method", method,
+			"code", code.String())
+	}
+
+	log := &GRPCLog{
+		Timestamp:     start,
+		ResponseTime:  respTime.Microseconds(),
+		CorrelationID: trace.SpanFromContext(ctx).SpanContext().TraceID().String(),
+		Target:        g.target,
+		Method:        method,
+		StatusCode:    int32(code),
+	}
+
+	if g.Logger != nil {
+		if err != nil {
+			log.ErrorMessage = err.Error()
+		}
+
+		g.Log(log)
+	}
+
+	return err
+}
+
+func (g *grpcService) HealthCheck(ctx context.Context) *Health {
+	health := Health{
+		Details: map[string]interface{}{"host": g.target},
+	}
+
+	resp, err := grpc_health_v1.NewHealthClient(g).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: g.healthService})
+
+	switch {
+	case err != nil:
+		health.Status = serviceDown
+		health.Details["error"] = err.Error()
+	case resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING:
+		health.Status = serviceDown
+		health.Details["error"] = "service " + resp.GetStatus().String()
+	default:
+		health.Status = serviceUp
+	}
+
+	return &health
+}
+
+// GRPCLog is the log of a call to a gRPC service.
+type GRPCLog struct {
+	Timestamp     time.Time `json:"timestamp"`
+	ResponseTime  int64     `json:"latency"`
+	CorrelationID string    `json:"correlationId"`
+	Target        string    `json:"target"`
+	Method        string    `json:"method"`
+	StatusCode    int32     `json:"statusCode"`
+	ErrorMessage  string    `json:"errorMessage,omitempty"`
+}
+
+func (l *GRPCLog) PrettyPrint(writer io.Writer) {
+	fmt.Fprintf(writer, "\u001B[38;5;8m%s \u001B[38;5;%dm%-6d\u001B[0m %8d\u001B[38;5;8mµs\u001B[0m %s %s \n",
+		l.CorrelationID, colorForGRPCCode(l.StatusCode), l.StatusCode, l.ResponseTime, l.Target, l.Method)
+}
+
+func colorForGRPCCode(code int32) int {
+	const (
+		blue = 34
+		red  = 202
+	)
+
+	if code == 0 {
+		return blue
+	}
+
+	return red
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+// testHealthServer fails the calls with UNAVAILABLE until it has been called failures times.
+type testHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	failures int32
+	calls    atomic.Int32
+	// timeout receives the time left before the deadline of the calls, when they reach the server.
+	timeout chan time.Duration
+}
+
+func (s *testHealthServer) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if s.timeout != nil {
+		d, _ := ctx.Deadline()
+		s.timeout <- time.Until(d)
+	}
+
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "service unavailable")
+	}
+
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+// newTestGRPCService serves the health server on an in-memory listener and returns a service connected to it.
+func newTestGRPCService(t *testing.T, health *testHealthServer, metrics Metrics, config *GRPCConfig) GRPC {
+	t.Helper()
+
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, health)
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	config.DialOptions = append(config.DialOptions,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }))
+
+	svc, err := NewGRPCService("passthrough:///bufnet", logging.NewMockLogger(logging.DEBUG), metrics, config)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = svc.Close() })
+
+	return svc
+}
+
+func TestNewGRPCService(t *testing.T) {
+	svc, err := NewGRPCService("dns:///customer:9000", nil, nil, nil)
+
+	require.NoError(t, err)
+	assert.NotNil(t, svc)
+	assert.NoError(t, svc.Close())
+}
+
+func TestGRPCService_Retries(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		maxAttempts int
+		failures    int32
+		expCode     codes.Code
+		expCalls    int32
+	}{
+		{"retried until success", 0, 2, codes.OK, 3},
+		{"attempts exhausted", 2, 2, codes.Unavailable, 2},
+		{"retries disabled", 1, 1, codes.Unavailable, 1},
+	}
+
+	for i, tc := range testCases {
+		health := &testHealthServer{failures: tc.failures}
+		svc := newTestGRPCService(t, health, nil, &GRPCConfig{MaxAttempts: tc.maxAttempts})
+
+		_, err := grpc_health_v1.NewHealthClient(svc).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+		assert.Equal(t, tc.expCode, status.Code(err), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expCalls, health.calls.Load(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestGRPCService_Deadline(t *testing.T) {
+	health := &testHealthServer{timeout: make(chan time.Duration, 2)}
+	svc := newTestGRPCService(t, health, nil, &GRPCConfig{Timeout: time.Minute})
+
+	client := grpc_health_v1.NewHealthClient(svc)
+
+	start := time.Now()
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	// the time left to the server is shortened by the time the call takes to reach it, which includes connecting.
+	timeout := <-health.timeout
+	assert.True(t, timeout <= time.Minute && timeout >= time.Minute-time.Since(start),
+		"timeout should apply when the context has no deadline, got %v", timeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	deadline, _ := ctx.Deadline()
+	start = time.Now()
+	left := deadline.Sub(start)
+
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	// the deadline is sent as the time left before it, from which the server sets its own deadline, so the time left
+	// to the server is compared with the time left to the client when the call was made.
+	timeout = <-health.timeout
+	assert.True(t, timeout <= left && timeout >= left-time.Since(start),
+		"deadline of the context should be propagated, got %v for %v", timeout, left)
+}
+
+func TestGRPCService_Metrics(t *testing.T) {
+	metrics := NewMockMetrics(gomock.NewController(t))
+	svc := newTestGRPCService(t, &testHealthServer{}, metrics, &GRPCConfig{})
+
+	metrics.EXPECT().RecordHistogram(gomock.Any(), "app_grpc_service_response", gomock.Any(), "target",
+		"passthrough:///bufnet", "method", "/grpc.health.v1.Health/Check", "code", "OK")
+
+	_, err := grpc_health_v1.NewHealthClient(svc).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+}
+
+func TestGRPCService_HealthCheck(t *testing.T) {
+	up := newTestGRPCService(t, &testHealthServer{}, nil, &GRPCConfig{}).HealthCheck(context.Background())
+
+	assert.Equal(t, &Health{Status: serviceUp, Details: map[string]interface{}{"host": "passthrough:///bufnet"}}, up)
+
+	down := newTestGRPCService(t, &testHealthServer{failures: 1}, nil, &GRPCConfig{MaxAttempts: 1}).
+		HealthCheck(context.Background())
+
+	assert.Equal(t, serviceDown, down.Status)
+	assert.Contains(t, down.Details["error"], "service unavailable")
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/mirror"
+)
+
+// MirrorConfig sends a copy of the Percentage of the requests to the shadow service at Address, and logs their differences.
+type MirrorConfig struct {
+	Address    string
+	Percentage float64
+	// Timeout bounds the time for which a mirrored request waits for its response, 30 seconds by default.
+	Timeout time.Duration
+}
+
+// AddOption mirrors the requests of the service without logging the comparisons.
+func (m *MirrorConfig) AddOption(h HTTP) HTTP {
+	return m.addLoggedOption(h, nil)
+}
+
+func (m *MirrorConfig) addLoggedOption(h HTTP, logger Logger) HTTP {
+	const defaultTimeout = 30 * time.Second
+
+	timeout := m.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &mirrored{
+		HTTP:       h,
+		address:    strings.TrimRight(m.Address, "/"),
+		percentage: m.Percentage,
+		timeout:    timeout,
+		client:     &http.Client{},
+		logger:     logger,
+	}
+}
+
+type mirrored struct {
+	HTTP
+
+	address    string
+	percentage float64
+	timeout    time.Duration
+	client     *http.Client
+	logger     Logger
+}
+
+func (m *mirrored) Get(ctx context.Context, path string, queryParams map[string]interface{}) (*http.Response, error) {
+	return m.GetWithHeaders(ctx, path, queryParams, nil)
+}
+
+func (m *mirrored) GetWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	headers map[string]string) (*http.Response, error) {
+	resp, err := m.HTTP.GetWithHeaders(ctx, path, queryParams, headers)
+
+	return m.mirror(ctx, http.MethodGet, path, queryParams, nil, headers, resp, err)
+}
+
+func (m *mirrored) Post(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte) (*http.Response, error) {
+	return m.PostWithHeaders(ctx, path, queryParams, body, nil)
+}
+
+func (m *mirrored) PostWithHeaders(ctx context.Context, path string, queryParams map[string]interface{}, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	resp, err := m.HTTP.PostWithHeaders(ctx, path, queryParams, body, headers)
+
+	return m.mirror(ctx, http.MethodPost, path, queryParams, body, headers, resp, err)
+}
+
+func (m *mirrored) Put(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte) (*http.Response, error) {
+	return m.PutWithHeaders(ctx, path, queryParams, body, nil)
+}
+
+func (m *mirrored) PutWithHeaders(ctx context.Context, path string, queryParams map[string]interface{}, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	resp, err := m.HTTP.PutWithHeaders(ctx, path, queryParams, body, headers)
+
+	return m.mirror(ctx, http.MethodPut, path, queryParams, body, headers, resp, err)
+}
+
+func (m *mirrored) Patch(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte) (*http.Response, error) {
+	return m.PatchWithHeaders(ctx, path, queryParams, body, nil)
+}
+
+func (m *mirrored) PatchWithHeaders(ctx context.Context, path string, queryParams map[string]interface{}, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	resp, err := m.HTTP.PatchWithHeaders(ctx, path, queryParams, body, headers)
+
+	return m.mirror(ctx, http.MethodPatch, path, queryParams, body, headers, resp, err)
+}
+
+func (m *mirrored) Delete(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	return m.DeleteWithHeaders(ctx, path, body, nil)
+}
+
+func (m *mirrored) DeleteWithHeaders(ctx context.Context, path string, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	resp, err := m.HTTP.DeleteWithHeaders(ctx, path, body, headers)
+
+	return m.mirror(ctx, http.MethodDelete, path, nil, body, headers, resp, err)
+}
+
+// mirror sends the request to the shadow service if it is sampled.
+func (m *mirrored) mirror(ctx context.Context, method, path string, queryParams map[string]interface{}, body []byte,
+	headers map[string]string, resp *http.Response, err error) (*http.Response, error) {
+	if err != nil || !mirror.Sampled(m.percentage) {
+		return resp, err
+	}
+
+	primaryBody, err := io.ReadAll(io.LimitReader(resp.Body, mirror.MaxBodySize+1))
+	if err != nil {
+		return resp, nil
+	}
+
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(primaryBody), resp.Body), resp.Body}
+
+	if len(primaryBody) > mirror.MaxBodySize {
+		primaryBody = nil
+	}
+
+	header := make(http.Header, len(headers))
+	for k, v := range headers {
+		header.Set(k, v)
+	}
+
+	req, cancel, err := mirror.Request(ctx, method, strings.TrimRight(m.address+"/"+path, "/"), body, header, m.timeout)
+	if err != nil {
+		return resp, nil
+	}
+
+	encodeQueryParameters(req, queryParams)
+
+	go func() {
+		defer cancel()
+
+		res := mirror.Do(m.client, req, resp.StatusCode, primaryBody)
+		if m.logger != nil {
+			m.logger.Log(&res)
+		}
+	}()
+
+	return resp, nil
+}
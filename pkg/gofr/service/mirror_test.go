@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/mirror"
+)
+
+// mirrorLogger sends the logged mirror results to a channel.
+type mirrorLogger struct {
+	results chan *mirror.Result
+}
+
+func (l mirrorLogger) Log(args ...interface{}) {
+	if res, ok := args[0].(*mirror.Result); ok {
+		l.results <- res
+	}
+}
+
+func versionServer(version string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"uri":"` + r.URL.RequestURI() + `","region":"` + r.Header.Get("X-Region") +
+			`","version":"` + version + `"}}`))
+	}))
+}
+
+func TestMirrorConfig(t *testing.T) {
+	primary, shadow := versionServer("v1"), versionServer("v2")
+	defer primary.Close()
+	defer shadow.Close()
+
+	logger := mirrorLogger{results: make(chan *mirror.Result, 1)}
+
+	svc := NewHTTPService(primary.URL, logger, nil, &MirrorConfig{Address: shadow.URL, Percentage: 100})
+
+	resp, err := svc.GetWithHeaders(context.Background(), "orders", map[string]interface{}{"page": 1},
+		map[string]string{"X-Region": "eu"})
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":{"uri":"/orders?page=1","region":"eu","version":"v1"}}`, string(body),
+		"the primary response should be read by the caller")
+
+	select {
+	case res := <-logger.results:
+		assert.Equal(t, "/orders?page=1", res.URI)
+		assert.Equal(t, []string{`data.version: "v1" != "v2"`}, res.Diff)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the request is not mirrored")
+	}
+}
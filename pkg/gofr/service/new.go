@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptrace"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +17,9 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// requestTimeoutHeader is the header with the time left before the deadline of the caller.
+const requestTimeoutHeader = "X-Request-Timeout"
+
 type httpService struct {
 	*http.Client
 	trace.Tracer
@@ -65,6 +70,7 @@ type httpClient interface {
 
 // NewHTTPService function creates a new instance of the httpService struct, which implements the HTTP interface.
 // It initializes the http.Client, url, Tracer, and Logger fields of the httpService struct with the provided values.
+// The address can also name a discovered service, like "consul://payments".
 func NewHTTPService(serviceAddress string, logger Logger, metrics Metrics, options ...Options) HTTP {
 	h := &httpService{
 		// using default HTTP client to do HTTP communication
@@ -75,11 +81,34 @@ func NewHTTPService(serviceAddress string, logger Logger, metrics Metrics, optio
 		Metrics: metrics,
 	}
 
+	var resolvers Resolvers
+
+	for _, o := range options {
+		if r, ok := o.(Resolvers); ok {
+			resolvers = r
+		}
+	}
+
+	if b, serviceURL := newBalancer(serviceAddress, logger, resolvers); b != nil {
+		h.url = serviceURL
+		h.Client.Transport = b
+	}
+
 	var svc HTTP
 	svc = h
 
 	// if options are given, then add them to the httpService struct
 	for _, o := range options {
+		if lo, ok := o.(loggedOption); ok {
+			svc = lo.addLoggedOption(svc, logger)
+			continue
+		}
+
+		if mo, ok := o.(metricOption); ok {
+			svc = mo.addMetricOption(svc, serviceAddress, metrics)
+			continue
+		}
+
 		svc = o.AddOption(svc)
 	}
 
@@ -151,6 +180,8 @@ func (h *httpService) createAndSendRequest(ctx context.Context, method string, p
 		req.Header.Set(k, v)
 	}
 
+	setRequestTimeout(ctx, req.Header)
+
 	// encode the query parameters on the request
 	encodeQueryParameters(req, queryParams)
 
@@ -196,6 +227,12 @@ func (h *httpService) createAndSendRequest(ctx context.Context, method string, p
 func encodeQueryParameters(req *http.Request, queryParams map[string]interface{}) {
 	q := req.URL.Query()
 
+	addQueryParameters(q, queryParams)
+
+	req.URL.RawQuery = q.Encode()
+}
+
+func addQueryParameters(q url.Values, queryParams map[string]interface{}) {
 	for k, v := range queryParams {
 		switch vt := v.(type) {
 		case []string:
@@ -206,6 +243,19 @@ func encodeQueryParameters(req *http.Request, queryParams map[string]interface{}
 			q.Set(k, fmt.Sprintf("%v", v))
 		}
 	}
+}
 
-	req.URL.RawQuery = q.Encode()
+// setRequestTimeout sets the X-Request-Timeout header to the time left before the deadline of ctx, if any.
+func setRequestTimeout(ctx context.Context, header http.Header) {
+	deadline, ok := ctx.Deadline()
+	if !ok || header.Get(requestTimeoutHeader) != "" {
+		return
+	}
+
+	remaining := time.Until(deadline).Milliseconds()
+	if remaining < 1 {
+		remaining = 1
+	}
+
+	header.Set(requestTimeoutHeader, strconv.FormatInt(remaining, 10)+"ms")
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/otel"
@@ -514,3 +515,50 @@ func TestHTTPService_createAndSendRequestServerError(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Nil(t, resp, "TEST[%d], Failed.\n%s")
 }
+
+func TestHTTPService_RequestTimeoutHeader(t *testing.T) {
+	var header string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get(requestTimeoutHeader)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	service := &httpService{
+		Client: http.DefaultClient,
+		url:    server.URL,
+		Tracer: otel.Tracer("gofr-http-client"),
+		Logger: logging.NewMockLogger(logging.INFO),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	testCases := []struct {
+		desc    string
+		ctx     context.Context
+		headers map[string]string
+		check   func(header string) bool
+	}{
+		{"no deadline", context.Background(), nil, func(header string) bool { return header == "" }},
+		{"deadline", ctx, nil, func(header string) bool {
+			timeout, err := time.ParseDuration(header)
+
+			return err == nil && timeout > 50*time.Second && timeout <= time.Minute
+		}},
+		{"header set by the caller", ctx, map[string]string{requestTimeoutHeader: "2s"},
+			func(header string) bool { return header == "2s" }},
+	}
+
+	for i, tc := range testCases {
+		resp, err := service.GetWithHeaders(tc.ctx, "test-path", nil, tc.headers)
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		assert.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.True(t, tc.check(header), "TEST[%d], Failed.\n%s: %q", i, tc.desc, header)
+	}
+}
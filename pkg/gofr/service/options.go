@@ -3,3 +3,13 @@ package service
 type Options interface {
 	AddOption(h HTTP) HTTP
 }
+
+// loggedOption is an option which logs with the logger of the service, given to it by NewHTTPService.
+type loggedOption interface {
+	addLoggedOption(h HTTP, logger Logger) HTTP
+}
+
+// metricOption is an option which records metrics of the service, by its address, given to it by NewHTTPService.
+type metricOption interface {
+	addMetricOption(h HTTP, address string, metrics Metrics) HTTP
+}
@@ -0,0 +1,221 @@
+// Package servicetest provides stub servers for the HTTP services of an application.
+//
+//	payments := servicetest.Stub("payments").
+//		On(http.MethodPost, "/charges").Reply(http.StatusCreated, charge).
+//		On(http.MethodGet, "/charges/{id}").Reply(http.StatusOK, charge).
+//		Start(t)
+//
+//	app.AddHTTPService("payments", payments.URL())
+package servicetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Server is a stub server of an HTTP service, which records the requests it receives.
+type Server struct {
+	name   string
+	routes []*Route
+
+	mu       sync.Mutex
+	requests []Request
+	server   *httptest.Server
+}
+
+// Route is a route of the stub server, which replies to the requests with its method and path.
+type Route struct {
+	server *Server
+	method string
+	path   string
+
+	status  int
+	body    []byte
+	headers http.Header
+	calls   int
+}
+
+// Request is a request received by the stub server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+	// Matched is false for the requests which did not match any route, and were replied with 404 Not Found.
+	Matched bool
+}
+
+// Stub returns a stub server of the named service, whose routes are added using On and which is started using Start.
+func Stub(name string) *Server {
+	return &Server{name: name}
+}
+
+// On adds a route for the method and path, like "/charges/{id}".
+func (s *Server) On(method, path string) *Route {
+	r := &Route{server: s, method: method, path: path, status: http.StatusOK, headers: make(http.Header)}
+	s.routes = append(s.routes, r)
+
+	return r
+}
+
+// Reply sets the status and the body of the response of the route. The body is written as JSON unless it is text.
+func (r *Route) Reply(status int, body interface{}) *Server {
+	r.status = status
+
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		r.body = b
+	case string:
+		r.body = []byte(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			panic(fmt.Sprintf("servicetest: could not marshal the reply of %s %s: %v", r.method, r.path, err))
+		}
+
+		r.body = data
+		r.headers.Set("Content-Type", "application/json")
+	}
+
+	return r.server
+}
+
+// WithHeader sets a header of the response of the route.
+func (r *Route) WithHeader(key, value string) *Route {
+	r.headers.Set(key, value)
+
+	return r
+}
+
+// Start starts the stub server, which is closed and verified once the test completes.
+func (s *Server) Start(t testing.TB) *Server {
+	t.Helper()
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	t.Cleanup(func() {
+		s.server.Close()
+		s.Verify(t)
+	})
+
+	return s
+}
+
+// URL returns the address of the started server, with which the service is added to the application.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Requests returns the requests received by the server, in the order in which they were received.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the requests received by the route with the method and path, which is written as in On.
+func (s *Server) RequestsTo(method, path string) []Request {
+	var requests []Request
+
+	for _, r := range s.Requests() {
+		if r.Method == method && matchPath(path, r.Path) {
+			requests = append(requests, r)
+		}
+	}
+
+	return requests
+}
+
+// Verify fails the test if a route has not been called, or if a request did not match any route.
+func (s *Server) Verify(t testing.TB) {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.routes {
+		if r.calls == 0 {
+			t.Errorf("%s: %s %s was not called", s.name, r.method, r.path)
+		}
+	}
+
+	for _, r := range s.requests {
+		if !r.Matched {
+			t.Errorf("%s: unexpected request %s %s", s.name, r.Method, r.Path)
+		}
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	request := Request{Method: req.Method, Path: req.URL.Path, Query: req.URL.Query(), Header: req.Header.Clone(),
+		Body: body}
+
+	s.mu.Lock()
+
+	route := s.route(req.Method, req.URL.Path)
+	if route != nil {
+		route.calls++
+		request.Matched = true
+	}
+
+	s.requests = append(s.requests, request)
+
+	s.mu.Unlock()
+
+	if route == nil {
+		http.Error(w, fmt.Sprintf("%s: no stub for %s %s", s.name, req.Method, req.URL.Path), http.StatusNotFound)
+
+		return
+	}
+
+	for k, v := range route.headers {
+		w.Header()[k] = v
+	}
+
+	w.WriteHeader(route.status)
+	_, _ = w.Write(route.body)
+}
+
+func (s *Server) route(method, path string) *Route {
+	for _, r := range s.routes {
+		if r.method == method && matchPath(r.path, path) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// matchPath reports whether the path matches the pattern, whose segments written as {name} match any value.
+func matchPath(pattern, path string) bool {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+
+	for i, p := range patternSegments {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			continue
+		}
+
+		if p != pathSegments[i] {
+			return false
+		}
+	}
+
+	return true
+}
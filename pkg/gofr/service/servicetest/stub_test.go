@@ -0,0 +1,118 @@
+package servicetest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/service"
+)
+
+// errorRecorder records the errors of the test instead of failing it.
+type errorRecorder struct {
+	testing.TB
+
+	errors []string
+}
+
+func (r *errorRecorder) Helper() {}
+
+func (r *errorRecorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestServer(t *testing.T) {
+	payments := Stub("payments").
+		On(http.MethodPost, "/charges").Reply(http.StatusCreated, map[string]string{"id": "ch_1"}).
+		On(http.MethodGet, "/charges/{id}").WithHeader("X-Version", "2").Reply(http.StatusOK, "charge").
+		Start(t)
+
+	svc := service.NewHTTPService(payments.URL(), logging.NewMockLogger(logging.ERROR), nil)
+
+	testCases := []struct {
+		desc      string
+		call      func() (*http.Response, error)
+		expStatus int
+		expBody   string
+	}{
+		{"JSON reply", func() (*http.Response, error) {
+			return svc.Post(context.Background(), "charges", nil, []byte(`{"amount":100}`))
+		}, http.StatusCreated, `{"id":"ch_1"}`},
+		{"path parameter", func() (*http.Response, error) {
+			return svc.Get(context.Background(), "charges/ch_1", map[string]interface{}{"expand": "customer"})
+		}, http.StatusOK, "charge"},
+	}
+
+	for i, tc := range testCases {
+		resp, err := tc.call()
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		assert.Equal(t, tc.expStatus, resp.StatusCode, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expBody, string(body), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	charges := payments.RequestsTo(http.MethodPost, "/charges")
+
+	require.Len(t, charges, 1)
+	assert.JSONEq(t, `{"amount":100}`, string(charges[0].Body))
+
+	get := payments.RequestsTo(http.MethodGet, "/charges/{id}")
+
+	require.Len(t, get, 1)
+	assert.Equal(t, "customer", get[0].Query.Get("expand"))
+	assert.Len(t, payments.Requests(), 2)
+}
+
+func TestServer_Verify(t *testing.T) {
+	payments := Stub("payments").
+		On(http.MethodPost, "/charges").Reply(http.StatusCreated, nil).
+		On(http.MethodPost, "/refunds").Reply(http.StatusCreated, nil).
+		Start(t)
+
+	for _, path := range []string{"/charges", "/payouts"} {
+		resp, err := http.Post(payments.URL()+path, "application/json", http.NoBody) //nolint:noctx // test request
+		require.NoError(t, err)
+
+		resp.Body.Close()
+
+		if path == "/payouts" {
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode, "unmatched request should be replied with 404")
+		}
+	}
+
+	recorder := &errorRecorder{TB: t}
+	payments.Verify(recorder)
+
+	assert.Equal(t, []string{"payments: POST /refunds was not called", "payments: unexpected request POST /payouts"},
+		recorder.errors)
+
+	// the unverified stub would fail the test once it completes
+	payments.routes, payments.requests = nil, nil
+}
+
+func TestMatchPath(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		exp     bool
+	}{
+		{"/charges", "/charges", true},
+		{"/charges", "charges/", true},
+		{"/charges/{id}", "/charges/ch_1", true},
+		{"/charges/{id}", "/charges", false},
+		{"/charges/{id}/refunds", "/charges/ch_1/payouts", false},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.exp, matchPath(tc.pattern, tc.path), "TEST[%d], Failed.\n%s %s", i, tc.pattern, tc.path)
+	}
+}
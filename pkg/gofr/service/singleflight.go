@@ -0,0 +1,98 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/singleflight"
+)
+
+// Singleflight collapses the concurrent identical GET requests of the service into a single request.
+type Singleflight struct{}
+
+func (*Singleflight) AddOption(h HTTP) HTTP {
+	return &singleflightService{HTTP: h}
+}
+
+type singleflightService struct {
+	group singleflight.Group[*sharedResponse]
+
+	HTTP
+}
+
+// sharedResponse is a response whose body is read, so that a copy of it can be returned to every request sharing it.
+type sharedResponse struct {
+	resp *http.Response
+	body []byte
+}
+
+func (s *singleflightService) Get(ctx context.Context, path string, queryParams map[string]interface{}) (*http.Response, error) {
+	return s.GetWithHeaders(ctx, path, queryParams, nil)
+}
+
+func (s *singleflightService) GetWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	headers map[string]string) (*http.Response, error) {
+	shared, _, err := s.group.Do(ctx, requestKey(path, queryParams, headers), func(ctx context.Context) (*sharedResponse, error) {
+		resp, err := s.HTTP.GetWithHeaders(ctx, path, queryParams, headers)
+		if err != nil {
+			return nil, err
+		}
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return &sharedResponse{resp: resp, body: body}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shared.response(), nil
+}
+
+// response returns a copy of the response, with its own headers and body.
+func (r *sharedResponse) response() *http.Response {
+	resp := *r.resp
+	resp.Header = r.resp.Header.Clone()
+	resp.Trailer = r.resp.Trailer.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(r.body))
+
+	return &resp
+}
+
+// requestKey identifies the identical GET requests.
+func requestKey(path string, queryParams map[string]interface{}, headers map[string]string) string {
+	q := url.Values{}
+	addQueryParameters(q, queryParams)
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var key strings.Builder
+
+	key.WriteString(path)
+	key.WriteString("?")
+	key.WriteString(q.Encode())
+
+	for _, name := range names {
+		key.WriteString("\n")
+		key.WriteString(http.CanonicalHeaderKey(name))
+		key.WriteString(": ")
+		key.WriteString(headers[name])
+	}
+
+	return key.String()
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+func TestSingleflight_Get(t *testing.T) {
+	var requests atomic.Int32
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(r.URL.RawQuery))
+	}))
+	defer server.Close()
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil, &Singleflight{})
+
+	var wg sync.WaitGroup
+
+	bodies := make([]string, 4)
+
+	for i := range bodies {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			resp, err := svc.Get(context.Background(), "users", map[string]interface{}{"id": 1, "fields": "name"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer resp.Body.Close()
+
+			resp.Header.Set("Content-Type", "modified")
+
+			body, _ := io.ReadAll(resp.Body)
+			bodies[i] = string(body)
+		}(i)
+	}
+
+	// the requests wait for the first one, which is answered once they are all sent.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), requests.Load())
+	assert.Equal(t, []string{"fields=name&id=1", "fields=name&id=1", "fields=name&id=1", "fields=name&id=1"}, bodies)
+}
+
+func TestSingleflight_DifferentRequests(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(r.Header.Get("X-Tenant")))
+	}))
+	defer server.Close()
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil, &Singleflight{})
+
+	for _, tenant := range []string{"a", "b"} {
+		resp, err := svc.GetWithHeaders(context.Background(), "users", nil, map[string]string{"X-Tenant": tenant})
+		require.NoError(t, err)
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		assert.Equal(t, tenant, string(body))
+	}
+
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestRequestKey(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		params  map[string]interface{}
+		headers map[string]string
+		key     string
+	}{
+		{"no parameters", nil, nil, "users?"},
+		{"sorted parameters", map[string]interface{}{"b": 2, "a": []string{"1"}}, nil, "users?a=1&b=2"},
+		{"sorted headers", nil, map[string]string{"x-b": "2", "X-A": "1"}, "users?\nX-A: 1\nX-B: 2"},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.key, requestKey("users", tc.params, tc.headers), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
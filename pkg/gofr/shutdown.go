@@ -0,0 +1,137 @@
+package gofr
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultShutdownGracePeriod = 30 * time.Second
+	defaultShutdownSignals     = "SIGTERM,SIGINT"
+)
+
+//nolint:gochecknoglobals // the names of the signals are constant.
+var shutdownSignalNames = map[string]os.Signal{
+	"SIGTERM": syscall.SIGTERM,
+	"SIGINT":  syscall.SIGINT,
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGQUIT": syscall.SIGQUIT,
+}
+
+// stopped is closed once the shutdown of the application is complete.
+type stopped struct {
+	mu   sync.Mutex
+	done chan struct{}
+}
+
+func (s *stopped) channel() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.done == nil {
+		s.done = make(chan struct{})
+	}
+
+	return s.done
+}
+
+func (s *stopped) close() {
+	done := s.channel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-done:
+	default:
+		close(done)
+	}
+}
+
+// shutdownSignals returns the signals of SHUTDOWN_SIGNALS, ignoring the unknown ones.
+func (a *App) shutdownSignals() []os.Signal {
+	var signals []os.Signal
+
+	for _, name := range strings.Split(a.Config.GetOrDefault("SHUTDOWN_SIGNALS", defaultShutdownSignals), ",") {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		sig, ok := shutdownSignalNames[name]
+		if !ok {
+			a.container.Errorf("unknown shutdown signal %v, it is ignored", name)
+			continue
+		}
+
+		signals = append(signals, sig)
+	}
+
+	return signals
+}
+
+// shutdownGracePeriod returns the time of SHUTDOWN_GRACE_PERIOD, in seconds.
+func (a *App) shutdownGracePeriod() time.Duration {
+	seconds, err := strconv.Atoi(a.Config.Get("SHUTDOWN_GRACE_PERIOD"))
+	if err != nil || seconds <= 0 {
+		return defaultShutdownGracePeriod
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// handleSignals shuts down the application once a shutdown signal is received, and returns once it is stopped.
+func (a *App) handleSignals() {
+	signals := a.shutdownSignals()
+	if len(signals) == 0 {
+		return
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+
+	select {
+	case <-a.stopped.channel():
+		signal.Stop(ch)
+		return
+	case sig := <-ch:
+		// a second signal terminates the application without waiting for the shutdown.
+		signal.Stop(ch)
+		a.container.Logf("received %v, shutting down", sig)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.shutdownGracePeriod())
+	defer cancel()
+
+	if err := a.Shutdown(ctx); err != nil {
+		a.container.Errorf("could not shut down gracefully, error: %v", err)
+	}
+}
+
+// wait blocks until the components run by Run are stopped, or until the shutdown completes.
+func (a *App) wait(wg *sync.WaitGroup) {
+	done := make(chan struct{})
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-a.stopped.channel():
+		return
+	}
+
+	select {
+	case <-a.container.ShuttingDown():
+		<-a.stopped.channel()
+	default:
+	}
+}
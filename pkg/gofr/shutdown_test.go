@@ -0,0 +1,152 @@
+package gofr
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+func freeTestPort(t *testing.T) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// runSlowApp runs an application with a route which takes 300ms to respond, and returns the channels of its response
+// and of the return of Run, once the request is in progress.
+func runSlowApp(t *testing.T, configs map[string]string) (app *App, response <-chan int, stopped <-chan struct{}) {
+	t.Helper()
+
+	port := freeTestPort(t)
+
+	t.Setenv("HTTP_PORT", strconv.Itoa(port))
+	t.Setenv("METRICS_PORT", strconv.Itoa(freeTestPort(t)))
+	t.Setenv("LOG_LEVEL", "ERROR")
+
+	for k, v := range configs {
+		t.Setenv(k, v)
+	}
+
+	app = New()
+
+	started := make(chan struct{})
+
+	app.GET("/slow", func(*Context) (interface{}, error) {
+		close(started)
+		time.Sleep(300 * time.Millisecond)
+
+		return "done", nil
+	})
+
+	runDone := make(chan struct{})
+
+	go func() {
+		app.Run()
+		close(runDone)
+	}()
+
+	statusCodes := make(chan int, 1)
+
+	go func() {
+		url := fmt.Sprintf("http://localhost:%d/slow", port)
+
+		for i := 0; i < 50; i++ {
+			resp, err := http.Get(url) //nolint:noctx // the request is not cancelled in the test.
+			if err == nil {
+				resp.Body.Close()
+				statusCodes <- resp.StatusCode
+
+				return
+			}
+
+			time.Sleep(20 * time.Millisecond)
+		}
+
+		statusCodes <- 0
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request did not start")
+	}
+
+	return app, statusCodes, runDone
+}
+
+func TestApp_Shutdown_DrainsRequests(t *testing.T) {
+	app, response, stopped := runSlowApp(t, nil)
+
+	require.NoError(t, app.Shutdown(context.Background()))
+
+	assert.Equal(t, http.StatusOK, <-response)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the shutdown")
+	}
+}
+
+func TestApp_Shutdown_Signal(t *testing.T) {
+	// the signal is also delivered to this channel, so that it does not terminate the tests if it is not handled.
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+
+	defer signal.Stop(ch)
+
+	_, response, stopped := runSlowApp(t, map[string]string{"SHUTDOWN_SIGNALS": "SIGHUP"})
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	assert.Equal(t, http.StatusOK, <-response)
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the signal")
+	}
+}
+
+func TestApp_ShutdownConfigs(t *testing.T) {
+	testCases := []struct {
+		configs     map[string]string
+		signals     []os.Signal
+		gracePeriod time.Duration
+	}{
+		{map[string]string{}, []os.Signal{syscall.SIGTERM, syscall.SIGINT}, defaultShutdownGracePeriod},
+		{map[string]string{"SHUTDOWN_SIGNALS": "sigquit, SIGUSR9,", "SHUTDOWN_GRACE_PERIOD": "5"},
+			[]os.Signal{syscall.SIGQUIT}, 5 * time.Second},
+		{map[string]string{"SHUTDOWN_GRACE_PERIOD": "-1"},
+			[]os.Signal{syscall.SIGTERM, syscall.SIGINT}, defaultShutdownGracePeriod},
+	}
+
+	for i, tc := range testCases {
+		app := &App{
+			Config:    config.NewMockConfig(tc.configs),
+			container: &container.Container{Logger: logging.NewLogger(logging.FATAL)},
+		}
+
+		assert.Equal(t, tc.signals, app.shutdownSignals(), "TEST[%d], Failed.\n%v", i, tc.configs)
+		assert.Equal(t, tc.gracePeriod, app.shutdownGracePeriod(), "TEST[%d], Failed.\n%v", i, tc.configs)
+	}
+}
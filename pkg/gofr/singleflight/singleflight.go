@@ -0,0 +1,45 @@
+// Package singleflight collapses the concurrent calls for the same key into a single call.
+package singleflight
+
+import (
+	"context"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Group collapses the concurrent calls of Do with the same key. The zero value is ready to use.
+type Group[T any] struct {
+	group singleflight.Group
+}
+
+// Do calls fn for the key, unless a call for the key is in flight, whose result it returns then.
+// The callers must not modify a shared result.
+//
+//	Usage:
+//	user, _, err := users.Do(ctx, id, func(ctx context.Context) (User, error) {
+//		return loadUser(ctx, id)
+//	})
+func (g *Group[T]) Do(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (
+	value T, shared bool, err error) {
+	callCtx := context.WithoutCancel(ctx)
+
+	ch := g.group.DoChan(key, func() (interface{}, error) {
+		return fn(callCtx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return value, false, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return value, res.Shared, res.Err
+		}
+
+		return res.Val.(T), res.Shared, nil
+	}
+}
+
+// Forget makes the next call of Do for the key call fn, instead of waiting for the call in flight.
+func (g *Group[T]) Forget(key string) {
+	g.group.Forget(key)
+}
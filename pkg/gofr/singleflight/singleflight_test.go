@@ -0,0 +1,129 @@
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errLoad = errors.New("load failed")
+
+func TestGroup_Do(t *testing.T) {
+	var (
+		g       Group[string]
+		calls   atomic.Int32
+		release = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+
+	load := func(context.Context) (string, error) {
+		calls.Add(1)
+		<-release
+
+		return "value", nil
+	}
+
+	results := make([]string, 5)
+	shared := make([]bool, 5)
+
+	for i := range results {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			results[i], shared[i], _ = g.Do(context.Background(), "key", load)
+		}(i)
+	}
+
+	// the callers wait for the first call, which is released once they all called Do.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, []string{"value", "value", "value", "value", "value"}, results)
+	assert.Equal(t, []bool{true, true, true, true, true}, shared)
+}
+
+func TestGroup_DoError(t *testing.T) {
+	var g Group[int]
+
+	value, shared, err := g.Do(context.Background(), "key", func(context.Context) (int, error) {
+		return 0, errLoad
+	})
+
+	assert.Equal(t, 0, value)
+	assert.False(t, shared)
+	assert.Equal(t, errLoad, err)
+
+	// the failed call is not kept, so the next call loads again.
+	value, _, err = g.Do(context.Background(), "key", func(context.Context) (int, error) {
+		return 1, nil
+	})
+
+	assert.Equal(t, 1, value)
+	assert.NoError(t, err)
+}
+
+func TestGroup_DoCanceled(t *testing.T) {
+	var g Group[string]
+
+	release := make(chan struct{})
+	done := make(chan error, 1)
+
+	// the call goes on after its caller is canceled, with a context which is not canceled.
+	go func() {
+		_, _, err := g.Do(context.Background(), "key", func(ctx context.Context) (string, error) {
+			<-release
+			return "value", ctx.Err()
+		})
+
+		done <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := g.Do(ctx, "key", func(context.Context) (string, error) {
+		return "", errLoad
+	})
+
+	assert.Equal(t, context.Canceled, err)
+
+	close(release)
+	assert.NoError(t, <-done)
+}
+
+func TestGroup_Forget(t *testing.T) {
+	var g Group[string]
+
+	release := make(chan struct{})
+
+	go func() {
+		_, _, _ = g.Do(context.Background(), "key", func(context.Context) (string, error) {
+			<-release
+			return "old", nil
+		})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	g.Forget("key")
+
+	value, _, err := g.Do(context.Background(), "key", func(context.Context) (string, error) {
+		return "new", nil
+	})
+
+	close(release)
+
+	assert.Equal(t, "new", value)
+	assert.NoError(t, err)
+}
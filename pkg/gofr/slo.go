@@ -0,0 +1,84 @@
+package gofr
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/middleware"
+)
+
+const defaultLatencyObjective = 500 * time.Millisecond
+
+// latencyObjectives are the latency objectives of the routes.
+type latencyObjectives struct {
+	defaultObjective time.Duration
+	// routes are the objectives of the routes by their method and their path.
+	routes sync.Map
+}
+
+func newLatencyObjectives(c *container.Container, objective string) *latencyObjectives {
+	d, err := time.ParseDuration(objective)
+	if err != nil || d <= 0 {
+		c.Errorf("invalid SLO_LATENCY_OBJECTIVE %q, the latency objective is %v", objective, defaultLatencyObjective)
+
+		d = defaultLatencyObjective
+	}
+
+	registerREDMetrics(c, d)
+
+	return &latencyObjectives{defaultObjective: d}
+}
+
+// registerREDMetrics registers the RED metrics of the HTTP requests.
+func registerREDMetrics(c *container.Container, objective time.Duration) {
+	buckets := []float64{.001, .003, .005, .01, .02, .03, .05, .1, .2, .3, .5, .75, 1, 2, 3, 5, 10, 30}
+
+	if !containsFloat(buckets, objective.Seconds()) {
+		buckets = append(buckets, objective.Seconds())
+		sort.Float64s(buckets)
+	}
+
+	m := c.Metrics()
+	m.NewCounter(middleware.REDRequests, "Number of HTTP requests by service, route, method and code.")
+	m.NewCounter(middleware.REDErrors, "Number of HTTP requests responded with a 5xx status by service, route and method.")
+	m.NewHistogram(middleware.REDDuration, "Duration of HTTP requests in seconds by service, route and method.", buckets...)
+	m.NewCounter(middleware.REDOverObjective, "Number of HTTP requests responded after the latency objective of "+
+		"their route.")
+	m.NewGauge(middleware.REDLatencyObjective, "Latency objective of the HTTP routes in seconds.")
+}
+
+// set sets the objective of the route, the default one if d is not positive.
+func (o *latencyObjectives) set(c *container.Container, method, pattern string, d time.Duration) {
+	route := strings.TrimSuffix(pattern, "/")
+
+	if d > 0 {
+		o.routes.Store(method+" "+route, d)
+	} else {
+		d = o.defaultObjective
+	}
+
+	c.Metrics().SetGauge(middleware.REDLatencyObjective, d.Seconds(), "service", c.GetAppName(), "route", route,
+		"method", method)
+}
+
+// objective returns the latency objective of the route.
+func (o *latencyObjectives) objective(method, route string) time.Duration {
+	if d, ok := o.routes.Load(method + " " + route); ok {
+		return d.(time.Duration)
+	}
+
+	return o.defaultObjective
+}
+
+func containsFloat(values []float64, v float64) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
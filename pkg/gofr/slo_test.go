@@ -0,0 +1,46 @@
+package gofr
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyObjective(t *testing.T) {
+	t.Setenv("SLO_LATENCY_OBJECTIVE", "250ms")
+
+	app := New()
+
+	handler := func(*Context) (interface{}, error) {
+		return nil, nil
+	}
+
+	app.GET("/orders/{id}/", handler, LatencyObjective(100*time.Millisecond))
+	app.GET("/orders", handler)
+
+	testCases := []struct {
+		method    string
+		route     string
+		objective time.Duration
+	}{
+		{http.MethodGet, "/orders/{id}", 100 * time.Millisecond},
+		{http.MethodDelete, "/orders/{id}", 250 * time.Millisecond},
+		{http.MethodGet, "/orders", 250 * time.Millisecond},
+		{http.MethodGet, "", 250 * time.Millisecond},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.objective, app.latencyObjectives.objective(tc.method, tc.route),
+			"TEST[%d], Failed.\n%s %s", i, tc.method, tc.route)
+	}
+}
+
+func TestLatencyObjective_Invalid(t *testing.T) {
+	t.Setenv("SLO_LATENCY_OBJECTIVE", "fast")
+
+	app := New()
+
+	assert.Equal(t, defaultLatencyObjective, app.latencyObjectives.objective(http.MethodGet, "/orders"))
+}
@@ -0,0 +1,116 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var errStartupTaskPanicked = errors.New("panicked")
+
+// StartupTaskOption configures a startup task added using AddStartupTask.
+type StartupTaskOption func(t *startupTask)
+
+// FailFast aborts the startup of the application if the task fails.
+func FailFast() StartupTaskOption {
+	return func(t *startupTask) {
+		t.failFast = true
+	}
+}
+
+// StartupOrder sets the order of the task. The tasks with a lower order run first.
+func StartupOrder(order int) StartupTaskOption {
+	return func(t *startupTask) {
+		t.order = order
+	}
+}
+
+// StartupTimeout fails the task if it does not complete within the timeout.
+func StartupTimeout(timeout time.Duration) StartupTaskOption {
+	return func(t *startupTask) {
+		t.timeout = timeout
+	}
+}
+
+type startupTask struct {
+	name     string
+	fn       func(ctx *Context) error
+	order    int
+	timeout  time.Duration
+	failFast bool
+}
+
+// AddStartupTask adds a task run once on startup, after the migrations and before the servers start.
+func (a *App) AddStartupTask(name string, fn func(ctx *Context) error, opts ...StartupTaskOption) {
+	t := &startupTask{name: name, fn: fn}
+
+	for _, o := range opts {
+		o(t)
+	}
+
+	a.startupTasks = append(a.startupTasks, t)
+}
+
+// runStartupTasks runs the startup tasks in order, and returns the error of the first failed task with FailFast.
+func (a *App) runStartupTasks() error {
+	tasks := make([]*startupTask, len(a.startupTasks))
+	copy(tasks, a.startupTasks)
+
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].order < tasks[j].order })
+
+	for _, t := range tasks {
+		start := time.Now()
+
+		err := a.runStartupTask(t)
+		if err == nil {
+			a.container.Infof("startup task %s completed in %v", t.name, time.Since(start))
+
+			continue
+		}
+
+		if t.failFast {
+			return fmt.Errorf("startup task %s failed: %w", t.name, err)
+		}
+
+		a.container.Errorf("startup task %s failed, error: %v", t.name, err)
+	}
+
+	return nil
+}
+
+func (a *App) runStartupTask(t *startupTask) error {
+	ctx := context.Background()
+
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("%w: %v", errStartupTaskPanicked, r)
+			}
+		}()
+
+		done <- t.fn(&Context{
+			Context:   ctx,
+			Container: a.container,
+			Request:   noopRequest{},
+		})
+	}()
+
+	// a task which does not honour the cancellation of its context is abandoned once it times out.
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
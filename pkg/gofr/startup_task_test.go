@@ -0,0 +1,85 @@
+package gofr
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
+)
+
+var errPrimeCache = errors.New("cache unavailable")
+
+func TestApp_runStartupTasks_Order(t *testing.T) {
+	c, _ := container.NewMockContainer(t)
+	a := &App{container: c}
+
+	var ran []string
+
+	record := func(name string) func(*Context) error {
+		return func(ctx *Context) error {
+			assert.NotNil(t, ctx.Container)
+
+			ran = append(ran, name)
+
+			return nil
+		}
+	}
+
+	a.AddStartupTask("check-indexes", record("check-indexes"))
+	a.AddStartupTask("prime-cache", record("prime-cache"), StartupOrder(-1))
+	a.AddStartupTask("warm-up", record("warm-up"))
+	a.AddStartupTask("notify", record("notify"), StartupOrder(1))
+
+	assert.NoError(t, a.runStartupTasks())
+	assert.Equal(t, []string{"prime-cache", "check-indexes", "warm-up", "notify"}, ran)
+}
+
+func TestApp_runStartupTasks_FailurePolicy(t *testing.T) {
+	c, _ := container.NewMockContainer(t)
+	a := &App{container: c}
+
+	runs := 0
+
+	a.AddStartupTask("optional", func(*Context) error { return errPrimeCache })
+	a.AddStartupTask("panics", func(*Context) error { panic("boom") })
+	a.AddStartupTask("required", func(*Context) error { return errPrimeCache }, FailFast())
+	a.AddStartupTask("skipped", func(*Context) error { runs++; return nil })
+
+	err := a.runStartupTasks()
+
+	assert.ErrorIs(t, err, errPrimeCache)
+	assert.Contains(t, err.Error(), "startup task required failed")
+	assert.Equal(t, 0, runs, "tasks after a failed FailFast task should not run")
+}
+
+func TestApp_runStartupTasks_Timeout(t *testing.T) {
+	c, _ := container.NewMockContainer(t)
+	a := &App{container: c}
+
+	a.AddStartupTask("slow", func(ctx *Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}, StartupTimeout(10*time.Millisecond), FailFast())
+
+	err := a.runStartupTasks()
+
+	assert.ErrorContains(t, err, "context deadline exceeded")
+}
+
+func TestApp_Run_AbortsOnFailedStartupTask(t *testing.T) {
+	logs := testutil.StderrOutputForFunc(func() {
+		a := &App{container: &container.Container{Logger: logging.NewLogger(logging.ERROR)}}
+
+		a.AddStartupTask("required", func(*Context) error { return errPrimeCache }, FailFast())
+
+		a.Run()
+	})
+
+	assert.Contains(t, logs, "aborting the startup of the application")
+}
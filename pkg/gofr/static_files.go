@@ -0,0 +1,226 @@
+package gofr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	iofs "io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+)
+
+const (
+	// fingerprintLength is the number of hex characters of the SHA-256 of the content in the fingerprinted names.
+	fingerprintLength = 8
+	manifestName      = "manifest.json"
+
+	immutableCacheControl = "public, max-age=31536000, immutable"
+	// revalidateCacheControl makes the clients revalidate the files which are not fingerprinted.
+	revalidateCacheControl = "no-cache"
+)
+
+// StaticOption configures the static files added by AddStaticFiles.
+type StaticOption func(s *staticFiles)
+
+// FingerprintAssets serves each static file under a name with the hash of its content, cached for a year.
+func FingerprintAssets() StaticOption {
+	return func(s *staticFiles) {
+		s.fingerprint = true
+	}
+}
+
+// staticFiles serves the files of a file system under an endpoint.
+type staticFiles struct {
+	endpoint    string
+	fsys        iofs.FS
+	fingerprint bool
+
+	// fingerprinted maps the fingerprinted names of the files to their names.
+	fingerprinted map[string]string
+	// manifest maps the names of the files to their fingerprinted names.
+	manifest map[string]string
+}
+
+// assets resolves the paths of the static files to their served paths.
+type assets struct {
+	mu    sync.RWMutex
+	paths map[string]string
+}
+
+// AddStaticFiles serves the files of the file system under the endpoint, like "/static".
+//
+//	Usage:
+//	//go:embed assets
+//	var assetsFS embed.FS
+//
+//	assets, _ := fs.Sub(assetsFS, "assets")
+//	app.AddStaticFiles("/static", assets, gofr.FingerprintAssets())
+func (a *App) AddStaticFiles(endpoint string, fsys iofs.FS, opts ...StaticOption) {
+	s := &staticFiles{endpoint: strings.TrimSuffix(endpoint, "/"), fsys: fsys}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := s.walk(a.assets); err != nil {
+		a.container.Errorf("could not add the static files of %v: %v", endpoint, err)
+		return
+	}
+
+	a.httpRegistered = true
+
+	a.httpServer.router.PathPrefix(s.endpoint+"/").Methods(http.MethodGet, http.MethodHead).Handler(s)
+}
+
+// walk computes the fingerprinted names of the files, if they are fingerprinted, and adds their paths to the assets.
+func (s *staticFiles) walk(a *assets) error {
+	paths := make(map[string]string)
+
+	if s.fingerprint {
+		s.fingerprinted = make(map[string]string)
+		s.manifest = make(map[string]string)
+	}
+
+	err := iofs.WalkDir(s.fsys, ".", func(name string, d iofs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		served := name
+
+		if s.fingerprint {
+			content, err := iofs.ReadFile(s.fsys, name)
+			if err != nil {
+				return err
+			}
+
+			served = fingerprintName(name, content)
+			s.fingerprinted[served] = name
+			s.manifest[name] = served
+		}
+
+		paths[s.endpoint+"/"+name] = s.endpoint + "/" + served
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.paths == nil {
+		a.paths = make(map[string]string, len(paths))
+	}
+
+	for p, served := range paths {
+		a.paths[p] = served
+	}
+
+	return nil
+}
+
+// fingerprintName inserts the hash of the content before the extension of the name.
+func fingerprintName(name string, content []byte) string {
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])[:fingerprintLength]
+	ext := path.Ext(name)
+
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// resolve returns the path under which the static file of the path is served.
+func (a *assets) resolve(p string) (string, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	served, ok := a.paths[p]
+	if !ok {
+		return "", fmt.Errorf("asset %q is not added", p)
+	}
+
+	return served, nil
+}
+
+func (s *staticFiles) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(strings.TrimPrefix(path.Clean(r.URL.Path), s.endpoint), "/")
+	if name == "" {
+		name = "."
+	}
+
+	cacheControl := revalidateCacheControl
+
+	if original, ok := s.fingerprinted[name]; ok {
+		name, cacheControl = original, immutableCacheControl
+	} else if name == manifestName && s.manifest != nil && !s.exists(manifestName) {
+		w.Header().Set("Cache-Control", revalidateCacheControl)
+		w.Header().Set("Content-Type", "application/json")
+
+		_ = json.NewEncoder(w).Encode(s.manifest)
+
+		return
+	}
+
+	content, info, err := s.open(name)
+	if err != nil {
+		gofrHTTP.NewResponder(w, r.Method).Respond(nil, gofrHTTP.ErrorInvalidRoute{})
+		return
+	}
+
+	if c, ok := content.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	w.Header().Set("Cache-Control", cacheControl)
+
+	http.ServeContent(w, r, info.Name(), info.ModTime(), content)
+}
+
+func (s *staticFiles) exists(name string) bool {
+	_, err := iofs.Stat(s.fsys, name)
+	return err == nil
+}
+
+// open opens the file of the name, or the index.html of the directory, as a reader which can seek.
+func (s *staticFiles) open(name string) (io.ReadSeeker, iofs.FileInfo, error) {
+	if !iofs.ValidPath(name) {
+		return nil, nil, iofs.ErrNotExist
+	}
+
+	f, err := s.fsys.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	if info.IsDir() {
+		f.Close()
+		return s.open(path.Join(name, "index.html"))
+	}
+
+	if rs, ok := f.(io.ReadSeeker); ok {
+		return rs, info, nil
+	}
+
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bytes.NewReader(content), info, nil
+}
@@ -0,0 +1,96 @@
+package gofr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/http/response"
+)
+
+func testStaticFS() fstest.MapFS {
+	return fstest.MapFS{
+		"css/app.css": {Data: []byte("body{}")},
+		"index.html":  {Data: []byte("<p>home</p>")},
+	}
+}
+
+func serveStatic(app *App, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	app.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+
+	return w
+}
+
+func TestApp_AddStaticFiles(t *testing.T) {
+	app := New()
+	app.AddStaticFiles("/static", testStaticFS())
+
+	testCases := []struct {
+		target       string
+		statusCode   int
+		body         string
+		contentType  string
+		cacheControl string
+	}{
+		{"/static/css/app.css", http.StatusOK, "body{}", "text/css; charset=utf-8", revalidateCacheControl},
+		{"/static/", http.StatusOK, "<p>home</p>", "text/html; charset=utf-8", revalidateCacheControl},
+		{"/static/missing.css", http.StatusNotFound, `{"error":{"message":"route not registered"}}` + "\n",
+			"application/json", ""},
+		{"/static/manifest.json", http.StatusNotFound, `{"error":{"message":"route not registered"}}` + "\n",
+			"application/json", ""},
+	}
+
+	for i, tc := range testCases {
+		w := serveStatic(app, tc.target)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.target)
+		assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.target)
+		assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.target)
+		assert.Equal(t, tc.cacheControl, w.Header().Get("Cache-Control"), "TEST[%d], Failed.\n%s", i, tc.target)
+	}
+}
+
+func TestApp_AddStaticFiles_Fingerprint(t *testing.T) {
+	app := New()
+	app.AddStaticFiles("/static/", testStaticFS(), FingerprintAssets())
+	app.AddTemplates(fstest.MapFS{"page.html": {Data: []byte(`<link href="{{asset "/static/css/app.css"}}">`)}},
+		"*.html")
+
+	app.GET("/page", func(*Context) (interface{}, error) {
+		return response.Template{Name: "page.html"}, nil
+	})
+
+	testCases := []struct {
+		target       string
+		statusCode   int
+		body         string
+		cacheControl string
+	}{
+		{"/static/css/app.7c98040a.css", http.StatusOK, "body{}", immutableCacheControl},
+		{"/static/css/app.css", http.StatusOK, "body{}", revalidateCacheControl},
+		{"/static/css/app.00000000.css", http.StatusNotFound, `{"error":{"message":"route not registered"}}` + "\n", ""},
+		{"/static/manifest.json", http.StatusOK,
+			`{"css/app.css":"css/app.7c98040a.css","index.html":"index.33abdeb0.html"}` + "\n", revalidateCacheControl},
+		{"/page", http.StatusOK, `<link href="/static/css/app.7c98040a.css">`, ""},
+	}
+
+	for i, tc := range testCases {
+		w := serveStatic(app, tc.target)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.target)
+		assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.target)
+		assert.Equal(t, tc.cacheControl, w.Header().Get("Cache-Control"), "TEST[%d], Failed.\n%s", i, tc.target)
+	}
+}
+
+func TestAssets_Resolve(t *testing.T) {
+	a := &assets{}
+
+	_, err := a.resolve("/static/app.css")
+
+	assert.EqualError(t, err, `asset "/static/app.css" is not added`)
+}
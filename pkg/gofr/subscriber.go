@@ -4,48 +4,139 @@ import (
 	"context"
 	"errors"
 	"runtime/debug"
+	"sync"
+	"time"
 
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub/kafka"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub/nats"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/redis"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
 )
 
 type SubscribeFunc func(c *Context) error
 
+// Publish publishes the message on the topic. The []byte and string messages are published as they are.
+//
+//	Usage:
+//	err := ctx.Publish("orders.created", order)
+func (c *Context) Publish(topic string, message interface{}) error {
+	return c.Container.Publish(c.Context, topic, message)
+}
+
+// subscribeRetryInterval is the interval between the failed subscriptions to a topic.
+const subscribeRetryInterval = time.Second
+
 type SubscriptionManager struct {
 	container     *container.Container
 	subscriptions map[string]SubscribeFunc
+
+	keyspaceSubscriptions map[string]SubscribeFunc
+
+	// running counts the subscribers which have not stopped yet, once the application is shutting down.
+	running *sync.WaitGroup
 }
 
 func newSubscriptionManager(c *container.Container) SubscriptionManager {
 	return SubscriptionManager{
-		container:     c,
-		subscriptions: make(map[string]SubscribeFunc),
+		container:             c,
+		subscriptions:         make(map[string]SubscribeFunc),
+		keyspaceSubscriptions: make(map[string]SubscribeFunc),
+		running:               &sync.WaitGroup{},
 	}
 }
 
-func (s *SubscriptionManager) startSubscriber(topic string, handler SubscribeFunc) {
-	// continuously subscribe in an infinite loop
-	for {
-		msg, err := s.container.GetSubscriber().Subscribe(context.Background(), topic)
-		if msg == nil {
-			continue
-		}
+// start starts the subscribers, which stop once the application starts shutting down.
+func (s *SubscriptionManager) start() {
+	ctx, cancel := context.WithCancel(context.Background())
 
-		if errors.Is(err, kafka.ErrConsumerGroupNotProvided) {
+	go func() {
+		<-s.container.ShuttingDown()
+		cancel()
+	}()
+
+	for topic, handler := range s.subscriptions {
+		s.running.Add(1)
+
+		go func(topic string, handler SubscribeFunc) {
+			defer s.running.Done()
+			s.startSubscriber(ctx, topic, handler)
+		}(topic, handler)
+	}
+
+	for pattern, handler := range s.keyspaceSubscriptions {
+		s.running.Add(1)
+
+		go func(pattern string, handler SubscribeFunc) {
+			defer s.running.Done()
+			s.startKeyspaceSubscriber(ctx, pattern, handler)
+		}(pattern, handler)
+	}
+}
+
+// stop waits for the subscribers to handle the messages they received, until ctx is done.
+func (s *SubscriptionManager) stop(ctx context.Context) error {
+	if s.running == nil {
+		return nil
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// startSubscriber handles the messages of the topic until ctx is done.
+func (s *SubscriptionManager) startSubscriber(ctx context.Context, topic string, handler SubscribeFunc) {
+	for ctx.Err() == nil {
+		msg, err := s.container.GetSubscriber().Subscribe(ctx, topic)
+
+		switch {
+		case ctx.Err() != nil:
+			return
+		case errors.Is(err, kafka.ErrConsumerGroupNotProvided), errors.Is(err, nats.ErrConsumerGroupNotProvided):
 			s.container.Logger.Errorf("cannot subscribe as consumer_id is not provided in configs")
 			return
-		} else if err != nil {
+		case err != nil:
 			s.container.Logger.Errorf("error while reading from topic %v, err: %v", topic, err.Error())
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(subscribeRetryInterval):
+			}
+
+			continue
+		case msg == nil:
 			continue
 		}
 
-		ctx := newContext(nil, msg, s.container)
+		msgCtx := msg.Context()
+		if msgCtx == nil {
+			msgCtx = context.Background()
+		}
+
+		jobCtx, cancel := s.container.JobContext(context.WithoutCancel(msgCtx), 0)
+
+		handlerCtx := newContext(nil, msg, s.container)
+		handlerCtx.Context = jobCtx
+
 		err = func(ctx *Context) error {
 			// TODO : Move panic recovery at central location which will manage for all the different cases.
 			defer panicRecovery(ctx.Logger)
 			return handler(ctx)
-		}(ctx)
+		}(handlerCtx)
+
+		cancel()
 
 		// commit the message if the subscription function does not return error
 		if err == nil {
@@ -56,6 +147,43 @@ func (s *SubscriptionManager) startSubscriber(topic string, handler SubscribeFun
 	}
 }
 
+// keyspaceSubscriber is implemented by the redis datasource to receive keyspace and keyevent notifications.
+type keyspaceSubscriber interface {
+	SubscribeKeyspace(ctx context.Context, patterns ...string) (<-chan redis.KeyspaceEvent, error)
+}
+
+func (s *SubscriptionManager) startKeyspaceSubscriber(ctx context.Context, pattern string, handler SubscribeFunc) {
+	ks, ok := s.container.Redis.(keyspaceSubscriber)
+	if !ok {
+		s.container.Logger.Errorf("redis client does not support keyspace notifications")
+
+		return
+	}
+
+	events, err := ks.SubscribeKeyspace(ctx, pattern)
+	if err != nil {
+		s.container.Logger.Errorf("error while subscribing to keyspace notifications %v, err: %v", pattern, err)
+
+		return
+	}
+
+	for event := range events {
+		msg := pubsub.NewMessage(ctx)
+		msg.Topic = event.Channel
+		msg.Value = []byte(event.Payload)
+		msg.MetaData = event
+
+		err = func(ctx *Context) error {
+			defer panicRecovery(ctx.Logger)
+			return handler(ctx)
+		}(newContext(nil, msg, s.container))
+
+		if err != nil {
+			s.container.Logger.Errorf("error in handler for keyspace notification %s: %v", event.Channel, err)
+		}
+	}
+}
+
 type panicLog struct {
 	Error      string `json:"error,omitempty"`
 	StackTrace string `json:"stack_trace,omitempty"`
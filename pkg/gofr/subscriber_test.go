@@ -8,9 +8,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/peter-stratton/gofr/pkg/gofr/container"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
 	"github.com/peter-stratton/gofr/pkg/gofr/datasource/pubsub"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/redis"
 	"github.com/peter-stratton/gofr/pkg/gofr/logging"
 	"github.com/peter-stratton/gofr/pkg/gofr/testutil"
 )
@@ -72,14 +76,14 @@ func TestSubscriptionManager_HandlerError(t *testing.T) {
 
 		// Run the subscriber in a goroutine
 		go func() {
-			subscriptionManager.startSubscriber("test-topic",
+			subscriptionManager.startSubscriber(context.Background(), "test-topic",
 				func(*Context) error {
 					return handleError("error in test-topic")
 				})
 		}()
 
 		// this sleep is added to wait for StderrOutputForFunc to collect the logs inside the testLogs
-		time.Sleep(1 * time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
 	})
 
 	// signal the test to end
@@ -102,14 +106,14 @@ func TestSubscriptionManager_SubscribeError(t *testing.T) {
 
 		// Run the subscriber in a goroutine
 		go func() {
-			subscriptionManager.startSubscriber("abc",
+			subscriptionManager.startSubscriber(context.Background(), "abc",
 				func(*Context) error {
 					return handleError("error in abc")
 				})
 		}()
 
 		// this sleep is added to wait for StderrOutputForFunc to collect the logs inside the testLogs
-		time.Sleep(1 * time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
 	})
 
 	// signal the test to end
@@ -132,14 +136,14 @@ func TestSubscriptionManager_PanicRecovery(t *testing.T) {
 
 		// Run the subscriber in a goroutine
 		go func() {
-			subscriptionManager.startSubscriber("abc",
+			subscriptionManager.startSubscriber(context.Background(), "abc",
 				func(*Context) error {
 					panic("test panic")
 				})
 		}()
 
 		// this sleep is added to wait for StderrOutputForFunc to collect the logs inside the testLogs
-		time.Sleep(1 * time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
 	})
 
 	// signal the test to end
@@ -149,3 +153,129 @@ func TestSubscriptionManager_PanicRecovery(t *testing.T) {
 		t.Error("TestSubscriptionManager_SubscribeError Failed! Missing log message about subscription error")
 	}
 }
+
+type mockKeyspaceRedis struct {
+	container.Redis
+
+	events chan redis.KeyspaceEvent
+	err    error
+}
+
+func (m *mockKeyspaceRedis) SubscribeKeyspace(context.Context, ...string) (<-chan redis.KeyspaceEvent, error) {
+	return m.events, m.err
+}
+
+func TestSubscriptionManager_KeyspaceSubscriber(t *testing.T) {
+	events := make(chan redis.KeyspaceEvent, 1)
+	events <- redis.KeyspaceEvent{Channel: "__keyevent@0__:expired", Payload: "session:1"}
+
+	close(events)
+
+	mockContainer := container.Container{
+		Logger: logging.NewLogger(logging.ERROR),
+		Redis:  &mockKeyspaceRedis{events: events},
+	}
+	subscriptionManager := newSubscriptionManager(&mockContainer)
+
+	var key, topic string
+
+	subscriptionManager.startKeyspaceSubscriber(context.Background(), "__keyevent@0__:*", func(c *Context) error {
+		topic = c.Param("topic")
+
+		return c.Bind(&key)
+	})
+
+	assert.Equal(t, "__keyevent@0__:expired", topic)
+	assert.Equal(t, "session:1", key)
+}
+
+func TestSubscriptionManager_KeyspaceSubscriberError(t *testing.T) {
+	testLogs := testutil.StderrOutputForFunc(func() {
+		mockContainer := container.Container{
+			Logger: logging.NewLogger(logging.ERROR),
+			Redis:  &mockKeyspaceRedis{err: errSubscription},
+		}
+		subscriptionManager := newSubscriptionManager(&mockContainer)
+
+		subscriptionManager.startKeyspaceSubscriber(context.Background(), "__keyevent@0__:*", func(*Context) error {
+			return nil
+		})
+	})
+
+	assert.Contains(t, testLogs, "error while subscribing to keyspace notifications __keyevent@0__:*")
+}
+
+func TestSubscriptionManager_MockPubSub(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+	subscriptionManager := newSubscriptionManager(c)
+
+	go subscriptionManager.startSubscriber(context.Background(), "orders", func(ctx *Context) error {
+		var order struct {
+			ID string `json:"id"`
+		}
+
+		if err := ctx.Bind(&order); err != nil {
+			return err
+		}
+
+		return ctx.GetPublisher().Publish(ctx, "invoices", []byte(order.ID+":"+ctx.Request.GetHeader("tenant")))
+	})
+
+	select {
+	case <-mocks.PubSub.Inject("orders", []byte(`{"id":"123"}`), map[string]string{"tenant": "acme"}):
+	case <-time.After(time.Second):
+		t.Fatal("message should be committed")
+	}
+
+	published := mocks.PubSub.Published("invoices")
+
+	assert.Len(t, published, 1)
+	assert.Equal(t, "123:acme", string(published[0].Value))
+	assert.Empty(t, mocks.PubSub.Published("orders"))
+}
+
+func TestSubscriptionManager_Shutdown(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+	subscriptionManager := newSubscriptionManager(c)
+
+	handling, release := make(chan struct{}), make(chan struct{})
+
+	var handlerErr error
+
+	subscriptionManager.subscriptions["orders"] = func(ctx *Context) error {
+		close(handling)
+		<-release
+
+		// the message is handled with a context which is not cancelled when the application starts shutting down.
+		handlerErr = ctx.Err()
+
+		return ctx.Publish("invoices", "123")
+	}
+
+	subscriptionManager.start()
+
+	committed := mocks.PubSub.Inject("orders", []byte(`{"id":"123"}`), nil)
+
+	<-handling
+
+	c.BeginShutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, subscriptionManager.stop(ctx), context.DeadlineExceeded, "the handler should still be running")
+
+	close(release)
+
+	require.NoError(t, subscriptionManager.stop(context.Background()))
+	require.NoError(t, handlerErr)
+
+	select {
+	case <-committed:
+	default:
+		t.Fatal("the message handled during the shutdown should be committed")
+	}
+
+	assert.Len(t, mocks.PubSub.Published("invoices"), 1)
+	assert.NoError(t, (&SubscriptionManager{}).stop(context.Background()), "a manager which was never started should stop")
+}
@@ -0,0 +1,196 @@
+package gofr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	iofs "io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/bufferpool"
+	"github.com/peter-stratton/gofr/pkg/gofr/export"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/response"
+)
+
+const htmlContentType = "text/html; charset=utf-8"
+
+var (
+	errNoTemplates    = errors.New("no templates are added")
+	errNoPDFConverter = errors.New("templates are not converted to PDF, PDF_CONVERTER is required")
+)
+
+// templateSource is a file system and the patterns of the templates added from it by AddTemplates.
+type templateSource struct {
+	fsys     iofs.FS
+	patterns []string
+}
+
+// templates are the HTML templates of the application, whose layouts and partials are shared by the pages.
+type templates struct {
+	sources []templateSource
+	// funcs are the functions of the templates, like "asset".
+	funcs template.FuncMap
+	// reload parses the templates again for each response, so that their changes are seen without a restart.
+	reload bool
+
+	mu    sync.RWMutex
+	pages map[string]*template.Template
+}
+
+// AddTemplates adds the HTML templates of the file system matching the patterns, like "templates/*.html".
+func (a *App) AddTemplates(fsys iofs.FS, patterns ...string) {
+	a.templates.mu.Lock()
+	a.templates.sources = append(a.templates.sources, templateSource{fsys: fsys, patterns: patterns})
+	a.templates.mu.Unlock()
+
+	if _, err := a.templates.load(); err != nil {
+		a.container.Errorf("could not parse the templates: %v", err)
+	}
+}
+
+// load parses the templates, unless they are parsed already and are not reloaded.
+func (t *templates) load() (map[string]*template.Template, error) {
+	t.mu.RLock()
+	pages := t.pages
+	t.mu.RUnlock()
+
+	if pages != nil && !t.reload {
+		return pages, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pages != nil && !t.reload {
+		return t.pages, nil
+	}
+
+	pages, err := t.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	t.pages = pages
+
+	return pages, nil
+}
+
+func (t *templates) parse() (map[string]*template.Template, error) {
+	shared := template.New("").Funcs(t.funcs)
+	contents := make(map[string]string)
+
+	for _, src := range t.sources {
+		for _, pattern := range src.patterns {
+			names, err := iofs.Glob(src.fsys, pattern)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, name := range names {
+				content, err := iofs.ReadFile(src.fsys, name)
+				if err != nil {
+					return nil, err
+				}
+
+				if !isSharedTemplate(name) {
+					contents[name] = string(content)
+					continue
+				}
+
+				if _, err := shared.New(name).Parse(string(content)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	pages := make(map[string]*template.Template, len(contents))
+
+	for name, content := range contents {
+		page, err := shared.Clone()
+		if err != nil {
+			return nil, err
+		}
+
+		if pages[name], err = page.New(name).Parse(content); err != nil {
+			return nil, err
+		}
+	}
+
+	return pages, nil
+}
+
+// isSharedTemplate reports whether the template of the path is a layout or a partial.
+func isSharedTemplate(name string) bool {
+	for _, dir := range strings.Split(path.Dir(name), "/") {
+		if dir == "layouts" || dir == "partials" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// render writes the HTML of the template to w.
+func (t *templates) render(w io.Writer, tmpl response.Template) error {
+	if t == nil {
+		return errNoTemplates
+	}
+
+	pages, err := t.load()
+	if err != nil {
+		return err
+	}
+
+	page, ok := pages[tmpl.Name]
+	if !ok {
+		return fmt.Errorf("template %q is not added", tmpl.Name)
+	}
+
+	return page.ExecuteTemplate(w, tmpl.Name, tmpl.Data)
+}
+
+// respondTemplate renders the template in a buffer, so that a failed rendering is responded with 500.
+func (h handler) respondTemplate(c *Context, tmpl response.Template) {
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	if err := h.templates.render(buf, tmpl); err != nil {
+		c.Errorf("could not render the template %v: %v", tmpl.Name, err)
+		c.responder.Respond(nil, err)
+
+		return
+	}
+
+	c.responder.Respond(response.File{Content: buf.Bytes(), ContentType: htmlContentType}, nil)
+}
+
+// respondPDF renders the template before streaming its conversion, so that a failed rendering is responded with 500.
+func (h handler) respondPDF(c *Context, pdf response.PDF) {
+	converter := h.container.PDFConverter()
+	if converter == nil {
+		c.responder.Respond(nil, errNoPDFConverter)
+
+		return
+	}
+
+	var html bytes.Buffer
+
+	if err := h.templates.render(&html, pdf.Template); err != nil {
+		c.Errorf("could not render the template %v: %v", pdf.Template.Name, err)
+		c.responder.Respond(nil, err)
+
+		return
+	}
+
+	c.responder.Respond(export.PDF(c, pdf.Name, converter, &html), nil)
+}
+
+// SetPDFConverter replaces the PDF converter configured by PDF_CONVERTER.
+func (a *App) SetPDFConverter(converter export.Converter) {
+	a.container.SetPDFConverter(converter)
+}
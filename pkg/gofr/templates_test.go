@@ -0,0 +1,174 @@
+package gofr
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/export"
+	"github.com/peter-stratton/gofr/pkg/gofr/http/response"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+func testTemplateFS() fstest.MapFS {
+	return fstest.MapFS{
+		"templates/layouts/base.html": {Data: []byte(
+			`<title>{{block "title" .}}Shop{{end}}</title><main>{{block "content" .}}{{end}}</main>`)},
+		"templates/partials/item.html": {Data: []byte(`<li>{{.}}</li>`)},
+		"templates/orders/list.html": {Data: []byte(`{{template "templates/layouts/base.html" .}}` +
+			`{{define "title"}}Orders{{end}}` +
+			`{{define "content"}}<ul>{{range .}}{{template "templates/partials/item.html" .}}{{end}}</ul>{{end}}`)},
+		"templates/home.html": {Data: []byte(`{{template "templates/layouts/base.html" .}}` +
+			`{{define "content"}}<p>{{.}}</p>{{end}}`)},
+	}
+}
+
+func serveTemplate(t *templates, tmpl response.Template) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+
+	handler{
+		function: func(*Context) (interface{}, error) {
+			return tmpl, nil
+		},
+		container:      &container.Container{Logger: logging.NewLogger(logging.FATAL)},
+		requestTimeout: "5",
+		templates:      t,
+	}.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	return w
+}
+
+func TestTemplates(t *testing.T) {
+	tmpls := &templates{sources: []templateSource{{fsys: testTemplateFS(), patterns: []string{
+		"templates/*.html", "templates/*/*.html"}}}}
+
+	testCases := []struct {
+		desc       string
+		tmpl       response.Template
+		statusCode int
+		body       string
+	}{
+		{"page with a layout and a partial", response.Template{Name: "templates/orders/list.html",
+			Data: []string{"book", "pen"}}, http.StatusOK,
+			`<title>Orders</title><main><ul><li>book</li><li>pen</li></ul></main>`},
+		{"data is escaped", response.Template{Name: "templates/home.html", Data: "<script>alert(1)</script>"},
+			http.StatusOK, `<title>Shop</title><main><p>&lt;script&gt;alert(1)&lt;/script&gt;</p></main>`},
+		{"template is not added", response.Template{Name: "templates/missing.html"}, http.StatusInternalServerError,
+			`{"error":{"message":"template \"templates/missing.html\" is not added"}}`},
+	}
+
+	for i, tc := range testCases {
+		w := serveTemplate(tmpls, tc.tmpl)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, strings.TrimSpace(w.Body.String()), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	assert.Equal(t, htmlContentType, serveTemplate(tmpls, testCases[0].tmpl).Header().Get("Content-Type"))
+}
+
+func TestTemplates_Reload(t *testing.T) {
+	fsys := fstest.MapFS{"home.html": {Data: []byte(`<p>v1</p>`)}}
+
+	for i, tc := range []struct {
+		reload bool
+		body   string
+	}{
+		{false, `<p>v1</p>`},
+		{true, `<p>v2</p>`},
+	} {
+		fsys["home.html"] = &fstest.MapFile{Data: []byte(`<p>v1</p>`)}
+		tmpls := &templates{sources: []templateSource{{fsys: fsys, patterns: []string{"*.html"}}}, reload: tc.reload}
+
+		serveTemplate(tmpls, response.Template{Name: "home.html"})
+
+		fsys["home.html"] = &fstest.MapFile{Data: []byte(`<p>v2</p>`)}
+
+		w := serveTemplate(tmpls, response.Template{Name: "home.html"})
+
+		assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\nreload: %v", i, tc.reload)
+	}
+}
+
+func TestTemplates_NotAdded(t *testing.T) {
+	w := serveTemplate(nil, response.Template{Name: "home.html"})
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), errNoTemplates.Error())
+}
+
+func TestApp_AddTemplates(t *testing.T) {
+	app := New()
+	app.AddTemplates(testTemplateFS(), "templates/*.html", "templates/*/*.html")
+
+	app.GET("/", func(*Context) (interface{}, error) {
+		return response.Template{Name: "templates/home.html", Data: "welcome"}, nil
+	})
+
+	w := httptest.NewRecorder()
+	app.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	assert.Equal(t, `<title>Shop</title><main><p>welcome</p></main>`, w.Body.String())
+}
+
+func TestTemplates_PDF(t *testing.T) {
+	tmpls := &templates{sources: []templateSource{{fsys: testTemplateFS(), patterns: []string{
+		"templates/*.html", "templates/*/*.html"}}}}
+
+	testCases := []struct {
+		desc       string
+		converter  export.Converter
+		tmpl       response.Template
+		statusCode int
+		body       string
+	}{
+		{"converted template", htmlConverter{}, response.Template{Name: "templates/home.html", Data: "invoice"},
+			http.StatusOK, `%PDF-<title>Shop</title><main><p>invoice</p></main>`},
+		{"template is not added", htmlConverter{}, response.Template{Name: "templates/missing.html"},
+			http.StatusInternalServerError, `{"error":{"message":"template \"templates/missing.html\" is not added"}}`},
+		{"converter is not configured", nil, response.Template{Name: "templates/home.html"},
+			http.StatusInternalServerError, `{"error":{"message":"` + errNoPDFConverter.Error() + `"}}`},
+	}
+
+	for i, tc := range testCases {
+		c := &container.Container{Logger: logging.NewLogger(logging.FATAL)}
+		c.SetPDFConverter(tc.converter)
+
+		w := httptest.NewRecorder()
+
+		handler{
+			function: func(*Context) (interface{}, error) {
+				return response.PDF{Name: "invoice.pdf", Template: tc.tmpl}, nil
+			},
+			container:      c,
+			requestTimeout: "5",
+			templates:      tmpls,
+		}.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, strings.TrimSpace(w.Body.String()), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if w.Code == http.StatusOK {
+			assert.Equal(t, export.PDFContentType, w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.desc)
+			assert.Equal(t, `attachment; filename=invoice.pdf`, w.Header().Get("Content-Disposition"),
+				"TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+// htmlConverter "converts" the HTML by prefixing it with the header of the PDF files.
+type htmlConverter struct{}
+
+func (htmlConverter) Convert(_ context.Context, html io.Reader, pdf io.Writer) error {
+	_, _ = io.WriteString(pdf, "%PDF-")
+	_, err := io.Copy(pdf, html)
+
+	return err
+}
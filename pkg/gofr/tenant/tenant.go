@@ -0,0 +1,67 @@
+// Package tenant resolves the tenant of the requests and carries it in their context.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ErrNoTenant is returned by the datasources of the tenants for a context without a tenant.
+var ErrNoTenant = errors.New("context has no tenant")
+
+var validID = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,62}$`)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the tenant of the id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant of ctx, or "" if it has none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// ValidID reports whether the id is a valid tenant, of at most 63 letters, digits, '-' and '_'.
+func ValidID(id string) bool {
+	return validID.MatchString(id)
+}
+
+// Resolver returns the tenant of a request, or "" if it does not resolve it.
+type Resolver func(r *http.Request) string
+
+// FromHeader resolves the tenant from the header of the name, like "X-Tenant-ID".
+func FromHeader(name string) Resolver {
+	return func(r *http.Request) string {
+		return r.Header.Get(name)
+	}
+}
+
+// FromSubdomain resolves the tenant from the subdomain of the domain, like "acme" for "acme.example.com".
+func FromSubdomain(domain string) Resolver {
+	suffix := "." + strings.TrimPrefix(domain, ".")
+
+	return func(r *http.Request) string {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		sub, ok := strings.CutSuffix(host, suffix)
+		if !ok {
+			return ""
+		}
+
+		if i := strings.LastIndex(sub, "."); i >= 0 {
+			sub = sub[i+1:]
+		}
+
+		return sub
+	}
+}
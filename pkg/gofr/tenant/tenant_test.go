@@ -0,0 +1,68 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidID(t *testing.T) {
+	testCases := []struct {
+		id    string
+		valid bool
+	}{
+		{"acme", true},
+		{"Acme_1-eu", true},
+		{"", false},
+		{"-acme", false},
+		{"acme corp", false},
+		{"acme;drop", false},
+		{"a23456789012345678901234567890123456789012345678901234567890123", true},
+		{"a234567890123456789012345678901234567890123456789012345678901234", false},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.valid, ValidID(tc.id), "TEST[%d], Failed.\n%s", i, tc.id)
+	}
+}
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, "", FromContext(context.Background()))
+	assert.Equal(t, "acme", FromContext(NewContext(context.Background(), "acme")))
+}
+
+func TestResolvers(t *testing.T) {
+	header := func(r *http.Request) *http.Request {
+		r.Header.Set("X-Tenant-ID", "acme")
+		return r
+	}
+
+	testCases := []struct {
+		desc     string
+		resolver Resolver
+		host     string
+		modify   func(r *http.Request) *http.Request
+		tenant   string
+	}{
+		{"header", FromHeader("X-Tenant-ID"), "example.com", header, "acme"},
+		{"no header", FromHeader("X-Tenant-ID"), "example.com", nil, ""},
+		{"subdomain", FromSubdomain("example.com"), "acme.example.com", nil, "acme"},
+		{"subdomain with a port", FromSubdomain(".example.com"), "acme.example.com:8000", nil, "acme"},
+		{"nested subdomain", FromSubdomain("example.com"), "api.acme.example.com", nil, "acme"},
+		{"domain", FromSubdomain("example.com"), "example.com", nil, ""},
+		{"other domain", FromSubdomain("example.com"), "acme.example.org", nil, ""},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "http://"+tc.host+"/orders", http.NoBody)
+
+		if tc.modify != nil {
+			r = tc.modify(r)
+		}
+
+		assert.Equal(t, tc.tenant, tc.resolver(r), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,167 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const redacted = "<redacted>"
+
+// update regenerates the golden files instead of comparing with them, when the tests are run with "go test -update".
+//
+//nolint:gochecknoglobals // flags of the tests are global.
+var update = flag.Bool("update", false, "regenerate the golden files of testutil.AssertGolden")
+
+type golden struct {
+	redact []string
+}
+
+// GoldenOption configures the comparison of AssertGolden.
+type GoldenOption func(g *golden)
+
+// Redact redacts the JSON fields, like "createdAt" or "data.id", before comparing.
+func Redact(fields ...string) GoldenOption {
+	return func(g *golden) {
+		g.redact = append(g.redact, fields...)
+	}
+}
+
+// AssertGolden compares actual with testdata/<name>.golden, which is regenerated by "go test -update".
+func AssertGolden(t testing.TB, name string, actual []byte, opts ...GoldenOption) {
+	t.Helper()
+
+	assertGolden(t, filepath.Join("testdata", name+".golden"), actual, opts...)
+}
+
+// AssertGoldenResponse compares the status code and the body of the response with the golden file.
+func AssertGoldenResponse(t testing.TB, name string, rec *httptest.ResponseRecorder, opts ...GoldenOption) {
+	t.Helper()
+
+	g := &golden{}
+
+	for _, o := range opts {
+		o(g)
+	}
+
+	body := rec.Body.Bytes()
+
+	normalized, err := g.normalize(body)
+	if err != nil {
+		t.Fatalf("could not normalize the response: %v", err)
+	}
+
+	actual := fmt.Appendf(nil, "HTTP %d\n\n%s", rec.Code, normalized)
+
+	// the body is normalized already, so that only the status line is added to it.
+	assertGolden(t, filepath.Join("testdata", name+".golden"), actual)
+}
+
+func assertGolden(t testing.TB, path string, actual []byte, opts ...GoldenOption) {
+	t.Helper()
+
+	g := &golden{}
+
+	for _, o := range opts {
+		o(g)
+	}
+
+	actual, err := g.normalize(actual)
+	if err != nil {
+		t.Fatalf("could not normalize the output compared with %s: %v", path, err)
+	}
+
+	if *update {
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+			err = os.WriteFile(path, actual, 0o600)
+		}
+
+		if err != nil {
+			t.Fatalf("could not update the golden file %s: %v", path, err)
+		}
+
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("golden file %s does not exist, run the test with -update to create it", path)
+	}
+
+	if err != nil {
+		t.Fatalf("could not read the golden file %s: %v", path, err)
+	}
+
+	assert.Equal(t, string(expected), string(actual), "output differs from the golden file %s, run the test with "+
+		"-update to regenerate it if the change is expected", path)
+}
+
+// normalize indents the JSON documents with their keys sorted, after redacting their fields.
+func (g *golden) normalize(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return data, nil //nolint:nilerr // the output is not a JSON document, and is compared as is.
+	}
+
+	v = g.redactValue(v, "")
+
+	var out bytes.Buffer
+
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+func (g *golden) redactValue(v interface{}, path string) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+
+			if child != nil && g.isRedacted(k, childPath) {
+				val[k] = redacted
+				continue
+			}
+
+			val[k] = g.redactValue(child, childPath)
+		}
+	case []interface{}:
+		for i, child := range val {
+			val[i] = g.redactValue(child, path)
+		}
+	}
+
+	return v
+}
+
+func (g *golden) isRedacted(key, path string) bool {
+	for _, f := range g.redact {
+		if f == path || (!strings.Contains(f, ".") && f == key) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,74 @@
+package testutil
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertGolden(t *testing.T) {
+	AssertGolden(t, "order", []byte(`{"data":{"item":"book","id":"6f1c","createdAt":"2024-05-01T10:00:00Z",
+		"lines":[{"id":"a1","qty":2}],"deletedAt":null}}`), Redact("id", "data.createdAt", "deletedAt"))
+
+	AssertGolden(t, "text", []byte("not a JSON document\n"))
+}
+
+func TestAssertGoldenResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.WriteHeader(http.StatusCreated)
+	_, _ = rec.WriteString(`{"data":{"id":12,"item":"pen"}}`)
+
+	AssertGoldenResponse(t, "created", rec, Redact("id"))
+}
+
+// recordingT records the failures of the assertions, which are expected to fail.
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Fatalf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertGolden_Failures(t *testing.T) {
+	rt := &recordingT{TB: t}
+
+	AssertGolden(rt, "order", []byte(`{"data":{"item":"pen"}}`))
+
+	require.Len(t, rt.errors, 1)
+	assert.Contains(t, rt.errors[0], "output differs from the golden file testdata/order.golden")
+
+	rt = &recordingT{TB: t}
+
+	AssertGolden(rt, "missing", []byte(`{}`))
+
+	require.NotEmpty(t, rt.errors)
+	assert.Equal(t, "golden file testdata/missing.golden does not exist, run the test with -update to create it",
+		rt.errors[0])
+}
+
+func TestAssertGolden_Update(t *testing.T) {
+	*update = true
+
+	defer func() { *update = false }()
+
+	path := filepath.Join(t.TempDir(), "testdata", "order.golden")
+
+	assertGolden(t, path, []byte(`{"b":1,"a":{"id":2}}`), Redact("id"))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "{\n  \"a\": {\n    \"id\": \"<redacted>\"\n  },\n  \"b\": 1\n}\n", string(b))
+}
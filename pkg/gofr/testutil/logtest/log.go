@@ -0,0 +1,222 @@
+// Package logtest records the logs in tests. It is apart from testutil, as the tests of logging import testutil.
+package logtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+// LogEntry is a log recorded by LogRecorder.
+type LogEntry struct {
+	Level logging.Level
+	// Message is the message of the log, formatted like by the logger of the application.
+	Message string
+	// Fields are the fields of a structured log, like the log of a request or of a query, as they are encoded in JSON.
+	Fields map[string]interface{}
+}
+
+// LogRecorder is a logging.Logger recording the logs, which does not exit on Fatal.
+//
+//	logs := logtest.NewLogRecorder()
+//	c.Logger = logs
+//	...
+//	logs.AssertContains(t, logging.ERROR, "could not connect", "host", "localhost")
+type LogRecorder struct {
+	mu      sync.Mutex
+	level   logging.Level
+	entries []LogEntry
+}
+
+// NewLogRecorder returns a LogRecorder with no logs.
+func NewLogRecorder() *LogRecorder {
+	return &LogRecorder{level: logging.DEBUG}
+}
+
+// Entries returns the logs recorded so far.
+func (r *LogRecorder) Entries() []LogEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]LogEntry(nil), r.entries...)
+}
+
+// AssertContains fails the test unless a log of the level contains msgSubstr and the fields, as pairs of keys and values.
+func (r *LogRecorder) AssertContains(t testing.TB, level logging.Level, msgSubstr string, fields ...interface{}) bool {
+	t.Helper()
+
+	if len(fields)%2 != 0 {
+		t.Errorf("fields should be pairs of keys and values, got %v", fields)
+
+		return false
+	}
+
+	for _, e := range r.Entries() {
+		if e.matches(level, msgSubstr, fields) {
+			return true
+		}
+	}
+
+	t.Errorf("no %s log contains %q with fields %v, recorded logs:\n%s", level, msgSubstr, fields, r)
+
+	return false
+}
+
+// AssertNotContains fails the test if a log of the level contains msgSubstr in its message.
+func (r *LogRecorder) AssertNotContains(t testing.TB, level logging.Level, msgSubstr string) bool {
+	t.Helper()
+
+	for _, e := range r.Entries() {
+		if e.matches(level, msgSubstr, nil) {
+			t.Errorf("unexpected %s log %q", level, e.Message)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the recorded logs, one per line.
+func (r *LogRecorder) String() string {
+	var b strings.Builder
+
+	for _, e := range r.Entries() {
+		fmt.Fprintf(&b, "%s %s", e.Level, e.Message)
+
+		if len(e.Fields) > 0 {
+			fmt.Fprintf(&b, " %v", e.Fields)
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (e *LogEntry) matches(level logging.Level, msgSubstr string, fields []interface{}) bool {
+	if e.Level != level || !strings.Contains(e.Message, msgSubstr) {
+		return false
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		v, ok := e.Fields[fmt.Sprint(fields[i])]
+		if !ok || fmt.Sprint(v) != fmt.Sprint(fields[i+1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *LogRecorder) record(level logging.Level, format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if level < r.level {
+		return
+	}
+
+	e := LogEntry{Level: level}
+
+	switch {
+	case format != "":
+		e.Message = fmt.Sprintf(format, args...)
+	case len(args) == 1:
+		e.Message = fmt.Sprint(args[0])
+		e.Fields = fieldsOf(args[0])
+	default:
+		e.Message = strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+	}
+
+	r.entries = append(r.entries, e)
+}
+
+// fieldsOf returns the fields of a structured log, which is encoded in JSON as an object, or nil for other logs.
+func fieldsOf(message interface{}) map[string]interface{} {
+	switch message.(type) {
+	case string, error, fmt.Stringer:
+		return nil
+	}
+
+	b, err := json.Marshal(message)
+	if err != nil {
+		return nil
+	}
+
+	var fields map[string]interface{}
+
+	if json.Unmarshal(b, &fields) != nil {
+		return nil
+	}
+
+	return fields
+}
+
+func (r *LogRecorder) Debug(args ...interface{}) {
+	r.record(logging.DEBUG, "", args...)
+}
+
+func (r *LogRecorder) Debugf(format string, args ...interface{}) {
+	r.record(logging.DEBUG, format, args...)
+}
+
+func (r *LogRecorder) Log(args ...interface{}) {
+	r.record(logging.INFO, "", args...)
+}
+
+func (r *LogRecorder) Logf(format string, args ...interface{}) {
+	r.record(logging.INFO, format, args...)
+}
+
+func (r *LogRecorder) Info(args ...interface{}) {
+	r.record(logging.INFO, "", args...)
+}
+
+func (r *LogRecorder) Infof(format string, args ...interface{}) {
+	r.record(logging.INFO, format, args...)
+}
+
+func (r *LogRecorder) Notice(args ...interface{}) {
+	r.record(logging.NOTICE, "", args...)
+}
+
+func (r *LogRecorder) Noticef(format string, args ...interface{}) {
+	r.record(logging.NOTICE, format, args...)
+}
+
+func (r *LogRecorder) Warn(args ...interface{}) {
+	r.record(logging.WARN, "", args...)
+}
+
+func (r *LogRecorder) Warnf(format string, args ...interface{}) {
+	r.record(logging.WARN, format, args...)
+}
+
+func (r *LogRecorder) Error(args ...interface{}) {
+	r.record(logging.ERROR, "", args...)
+}
+
+func (r *LogRecorder) Errorf(format string, args ...interface{}) {
+	r.record(logging.ERROR, format, args...)
+}
+
+func (r *LogRecorder) Fatal(args ...interface{}) {
+	r.record(logging.FATAL, "", args...)
+}
+
+func (r *LogRecorder) Fatalf(format string, args ...interface{}) {
+	r.record(logging.FATAL, format, args...)
+}
+
+// ChangeLevel changes the level from which the logs are recorded.
+func (r *LogRecorder) ChangeLevel(level logging.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.level = level
+}
@@ -0,0 +1,87 @@
+package logtest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+)
+
+type requestLog struct {
+	Method   string `json:"method"`
+	URI      string `json:"uri"`
+	Response int    `json:"response"`
+}
+
+func TestLogRecorder(t *testing.T) {
+	var logger logging.Logger = NewLogRecorder()
+
+	logger.Debug("connecting", "to", "db")
+	logger.Infof("connected to %s", "db")
+	logger.Error(errors.New("query failed"))
+	logger.Info(requestLog{Method: "GET", URI: "/orders", Response: 200})
+
+	logger.ChangeLevel(logging.INFO)
+	logger.Debug("not recorded")
+
+	logs := logger.(*LogRecorder)
+
+	assert.Equal(t, []LogEntry{
+		{Level: logging.DEBUG, Message: "connecting to db"},
+		{Level: logging.INFO, Message: "connected to db"},
+		{Level: logging.ERROR, Message: "query failed"},
+		{Level: logging.INFO, Message: "{GET /orders 200}",
+			Fields: map[string]interface{}{"method": "GET", "uri": "/orders", "response": float64(200)}},
+	}, logs.Entries())
+
+	assert.True(t, logs.AssertContains(t, logging.INFO, "connected"))
+	assert.True(t, logs.AssertContains(t, logging.INFO, "", "uri", "/orders", "response", 200))
+	assert.True(t, logs.AssertNotContains(t, logging.ERROR, "connected"))
+}
+
+// recordingT records the failures of the assertions, which are expected to fail.
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestLogRecorder_Failures(t *testing.T) {
+	logs := NewLogRecorder()
+	logs.Info(requestLog{Method: "GET", URI: "/orders", Response: 200})
+	logs.Error("query failed")
+
+	testCases := []struct {
+		desc   string
+		assert func(rt *recordingT) bool
+		expErr string
+	}{
+		{"wrong level", func(rt *recordingT) bool {
+			return logs.AssertContains(rt, logging.WARN, "query failed")
+		}, `no WARN log contains "query failed"`},
+		{"wrong field", func(rt *recordingT) bool {
+			return logs.AssertContains(rt, logging.INFO, "", "response", 500)
+		}, `no INFO log contains "" with fields [response 500]`},
+		{"odd fields", func(rt *recordingT) bool {
+			return logs.AssertContains(rt, logging.INFO, "", "response")
+		}, "fields should be pairs of keys and values"},
+		{"unexpected log", func(rt *recordingT) bool {
+			return logs.AssertNotContains(rt, logging.ERROR, "failed")
+		}, `unexpected ERROR log "query failed"`},
+	}
+
+	for i, tc := range testCases {
+		rt := &recordingT{TB: t}
+
+		assert.False(t, tc.assert(rt), "TEST[%d], Failed.\n%s", i, tc.desc)
+		require.Len(t, rt.errors, 1, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Contains(t, rt.errors[0], tc.expErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,38 @@
+package gofr
+
+import (
+	"time"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+)
+
+// locationRequest is a request with the time zone of its caller, like the HTTP requests.
+type locationRequest interface {
+	Location() (*time.Location, error)
+}
+
+// Location returns the time zone of the caller of the request, UTC by default.
+func (c *Context) Location() (*time.Location, error) {
+	if r, ok := c.Request.(locationRequest); ok {
+		return r.Location()
+	}
+
+	if loc := gofrHTTP.LocationFromContext(c.Context); loc != nil {
+		return loc, nil
+	}
+
+	return time.UTC, nil
+}
+
+// ParseTime parses the time in RFC 3339, or in the time zone of the caller if it has none.
+//
+//	Usage:
+//	from, err := ctx.ParseTime(ctx.Param("from"))
+func (c *Context) ParseTime(value string) (time.Time, error) {
+	loc, err := c.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return gofrHTTP.ParseTime(value, loc)
+}
@@ -0,0 +1,64 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContext_ParseTime(t *testing.T) {
+	t.Setenv("RESPONSE_TIME_FORMAT", "DateTime")
+
+	app := New()
+
+	app.GET("/orders", func(ctx *Context) (interface{}, error) {
+		from, err := ctx.ParseTime(ctx.Param("from"))
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]time.Time{"from": from.UTC()}, nil
+	})
+
+	testCases := []struct {
+		desc       string
+		query      string
+		timezone   string
+		statusCode int
+		body       string
+	}{
+		{"local time of the caller", "from=2024-07-02T15:04:05", "Europe/Paris", http.StatusOK,
+			`{"data":{"from":"2024-07-02 15:04:05"}}`},
+		{"local time without a time zone", "from=2024-07-02T15:04:05", "", http.StatusOK,
+			`{"data":{"from":"2024-07-02 15:04:05"}}`},
+		{"invalid time", "from=tomorrow", "Europe/Paris", http.StatusBadRequest,
+			`{"error":{"message":"invalid time \"tomorrow\", the times must be in RFC 3339, like 2006-01-02T15:04:05Z07:00"}}`},
+		{"invalid time zone", "from=2024-07-02", "Mars/Olympus", http.StatusBadRequest,
+			`{"error":{"message":"'1' invalid parameter(s): X-Timezone"}}`},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/orders?"+tc.query, http.NoBody)
+		r.Header.Set("X-Timezone", tc.timezone)
+
+		app.httpServer.router.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.JSONEq(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestContext_Location_NotHTTP(t *testing.T) {
+	ctx := &Context{Context: context.Background(), Request: noopRequest{}}
+
+	loc, err := ctx.Location()
+
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+}
@@ -0,0 +1,70 @@
+package gofr
+
+import (
+	"database/sql"
+	"errors"
+
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+var errTransactionWithoutSQL = errors.New("the route is transactional, but SQL is not configured")
+
+// Transactional runs each request of the route in a transaction, committed unless the handler returns an error.
+//
+//	Usage:
+//	app.POST("/transfers", createTransfer, gofr.Transactional(nil))
+func Transactional(opts *sql.TxOptions) RouteOption {
+	return func(h *handler) {
+		h.function = inTransaction(h.function, opts)
+	}
+}
+
+func inTransaction(fn Handler, opts *sql.TxOptions) Handler {
+	return func(c *Context) (result interface{}, err error) {
+		if isNil(c.SQL) {
+			return nil, errTransactionWithoutSQL
+		}
+
+		tx, err := c.SQL.BeginTx(c, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		c.tx = tx
+
+		defer func() {
+			c.tx = nil
+
+			if r := recover(); r != nil {
+				_ = tx.Rollback()
+
+				panic(r)
+			}
+
+			if err != nil {
+				if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+					c.Errorf("could not roll back the transaction of the request: %v", rbErr)
+				}
+
+				return
+			}
+
+			if err = tx.Commit(); err != nil {
+				result = nil
+			}
+		}()
+
+		return fn(c)
+	}
+}
+
+// SQLTx returns the transaction of the request, or nil for the routes which are not Transactional.
+//
+//	Usage:
+//	_, err := ctx.SQLTx().ExecContext(ctx, "UPDATE accounts SET balance = balance - ? WHERE id = ?", amount, from)
+//	if err != nil {
+//		return nil, err
+//	}
+func (c *Context) SQLTx() *gofrSQL.Tx {
+	return c.tx
+}
@@ -0,0 +1,115 @@
+package gofr
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+)
+
+func TestTransactional(t *testing.T) {
+	t.Setenv("DB_DIALECT", "sqlite")
+	t.Setenv("DB_NAME", filepath.Join(t.TempDir(), "app.db"))
+
+	app := New()
+
+	defer app.container.Close()
+
+	_, err := app.container.SQL.Exec("CREATE TABLE transfers (id INTEGER)")
+	require.NoError(t, err)
+
+	insert := func(ctx *Context) error {
+		_, err := ctx.SQLTx().ExecContext(ctx, "INSERT INTO transfers VALUES (1)")
+		return err
+	}
+
+	app.POST("/ok", func(ctx *Context) (interface{}, error) {
+		return "created", insert(ctx)
+	}, Transactional(nil))
+	app.POST("/invalid", func(ctx *Context) (interface{}, error) {
+		if err := insert(ctx); err != nil {
+			return nil, err
+		}
+
+		return nil, gofrHTTP.ErrorInvalidParam{Params: []string{"amount"}}
+	}, Transactional(nil))
+	app.POST("/failed", func(ctx *Context) (interface{}, error) {
+		if err := insert(ctx); err != nil {
+			return nil, err
+		}
+
+		return nil, errors.New("ledger unavailable")
+	}, Transactional(nil))
+	app.POST("/plain", func(ctx *Context) (interface{}, error) {
+		return ctx.SQLTx() == nil, nil
+	})
+
+	testCases := []struct {
+		desc       string
+		path       string
+		statusCode int
+		rows       int
+	}{
+		{"committed", "/ok", http.StatusCreated, 1},
+		{"rolled back on a client error", "/invalid", http.StatusBadRequest, 1},
+		{"rolled back on a server error", "/failed", http.StatusInternalServerError, 1},
+		{"not transactional", "/plain", http.StatusCreated, 1},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+
+		app.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tc.path, http.NoBody))
+
+		var rows int
+
+		require.NoError(t, app.container.SQL.QueryRow("SELECT COUNT(*) FROM transfers").Scan(&rows))
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.rows, rows, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestTransactional_Panic(t *testing.T) {
+	t.Setenv("DB_DIALECT", "sqlite")
+	t.Setenv("DB_NAME", filepath.Join(t.TempDir(), "app.db"))
+
+	c := New().container
+
+	defer c.Close()
+
+	_, err := c.SQL.Exec("CREATE TABLE transfers (id INTEGER)")
+	require.NoError(t, err)
+
+	handler := inTransaction(func(ctx *Context) (interface{}, error) {
+		_, err := ctx.SQLTx().ExecContext(ctx, "INSERT INTO transfers VALUES (1)")
+		require.NoError(t, err)
+
+		panic("boom")
+	}, nil)
+
+	ctx := newContext(nil, gofrHTTP.NewRequest(httptest.NewRequest(http.MethodPost, "/", http.NoBody)), c)
+
+	assert.PanicsWithValue(t, "boom", func() { _, _ = handler(ctx) })
+	assert.Nil(t, ctx.SQLTx())
+
+	var rows int
+
+	require.NoError(t, c.SQL.QueryRow("SELECT COUNT(*) FROM transfers").Scan(&rows))
+	assert.Equal(t, 0, rows)
+}
+
+func TestTransactional_WithoutSQL(t *testing.T) {
+	handler := inTransaction(func(*Context) (interface{}, error) { return nil, nil }, nil)
+
+	ctx := newContext(nil, gofrHTTP.NewRequest(httptest.NewRequest(http.MethodPost, "/", http.NoBody)), New().container)
+
+	_, err := handler(ctx)
+	assert.Equal(t, errTransactionWithoutSQL, err)
+}
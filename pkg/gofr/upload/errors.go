@@ -0,0 +1,79 @@
+package upload
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ErrorUploadNotFound is returned for the uploads which are not initiated, or which are completed or aborted.
+type ErrorUploadNotFound struct {
+	ID string
+}
+
+func (e ErrorUploadNotFound) Error() string {
+	return fmt.Sprintf("upload %q is not found", e.ID)
+}
+
+func (ErrorUploadNotFound) StatusCode() int {
+	return http.StatusNotFound
+}
+
+// ErrorChecksumMismatch is returned for the chunks whose content does not match the checksum sent with them.
+type ErrorChecksumMismatch struct {
+	Index    int
+	Checksum string
+}
+
+func (e ErrorChecksumMismatch) Error() string {
+	return fmt.Sprintf("checksum of chunk %d is %s", e.Index, e.Checksum)
+}
+
+func (ErrorChecksumMismatch) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// ErrorChunkTooLarge is returned for the chunks larger than the limit, or than the rest of the upload.
+type ErrorChunkTooLarge struct {
+	Index int
+	Limit int64
+}
+
+func (e ErrorChunkTooLarge) Error() string {
+	return fmt.Sprintf("chunk %d is larger than %d bytes", e.Index, e.Limit)
+}
+
+func (ErrorChunkTooLarge) StatusCode() int {
+	return http.StatusRequestEntityTooLarge
+}
+
+// ErrorIncompleteUpload is returned when completing an upload of which chunks are missing.
+type ErrorIncompleteUpload struct {
+	Received int64
+	Size     int64
+	Missing  []int
+}
+
+func (e ErrorIncompleteUpload) Error() string {
+	if len(e.Missing) > 0 {
+		return fmt.Sprintf("upload is missing the chunks %v", e.Missing)
+	}
+
+	return fmt.Sprintf("upload has %d of %d bytes", e.Received, e.Size)
+}
+
+func (ErrorIncompleteUpload) StatusCode() int {
+	return http.StatusConflict
+}
+
+// ErrorInvalidChunk is returned for the chunks with a negative index.
+type ErrorInvalidChunk struct {
+	Index int
+}
+
+func (e ErrorInvalidChunk) Error() string {
+	return fmt.Sprintf("invalid chunk index %d", e.Index)
+}
+
+func (ErrorInvalidChunk) StatusCode() int {
+	return http.StatusBadRequest
+}
@@ -0,0 +1,396 @@
+// Package upload accepts the uploads of very large files as resumable chunks.
+package upload
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/file"
+)
+
+const (
+	defaultMaxChunkSize = 64 << 20 // 64 MB
+	idBytes             = 16
+)
+
+// Upload is an upload of a file, with the chunks which are received.
+type Upload struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Size is the size of the file, which is checked when the upload is completed unless it is 0.
+	Size      int64     `json:"size,omitempty"`
+	Chunks    []Chunk   `json:"chunks"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Chunk is a chunk of an upload, whose Checksum is the hex encoded SHA-256 of its content.
+type Chunk struct {
+	Index    int    `json:"index"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+// Received returns the number of bytes of the chunks which are received.
+func (u *Upload) Received() int64 {
+	var n int64
+
+	for _, c := range u.Chunks {
+		n += c.Size
+	}
+
+	return n
+}
+
+// Manager manages the uploads, whose chunks and state are stored in a file.Store.
+type Manager struct {
+	store        file.Store
+	maxChunkSize int64
+
+	mu sync.Mutex
+}
+
+// Option configures a Manager.
+type Option func(m *Manager)
+
+// WithMaxChunkSize sets the maximum size of the chunks, which is 64 MB by default.
+func WithMaxChunkSize(size int64) Option {
+	return func(m *Manager) {
+		m.maxChunkSize = size
+	}
+}
+
+// NewManager returns a Manager of the uploads stored in the store.
+//
+//	Usage:
+//	store, err := file.NewLocalStore(os.TempDir() + "/uploads")
+//	uploads := upload.NewManager(store, upload.WithMaxChunkSize(16<<20))
+func NewManager(store file.Store, opts ...Option) *Manager {
+	m := &Manager{store: store, maxChunkSize: defaultMaxChunkSize}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Initiate starts an upload of the file. Its size is checked on completion, unless it is 0.
+func (m *Manager) Initiate(ctx context.Context, name string, size int64) (*Upload, error) {
+	id := make([]byte, idBytes)
+
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	u := &Upload{ID: hex.EncodeToString(id), Name: name, Size: size, Chunks: []Chunk{}, CreatedAt: time.Now().UTC()}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.save(ctx, u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// Status returns the upload of the id, with its chunks which are received, for the client to resume it.
+func (m *Manager) Status(ctx context.Context, id string) (*Upload, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.load(ctx, id)
+}
+
+// Append stores the chunk of the index, verified against its hex encoded SHA-256 checksum, if any.
+func (m *Manager) Append(ctx context.Context, id string, index int, checksum string, r io.Reader) (*Chunk, error) {
+	if index < 0 {
+		return nil, ErrorInvalidChunk{Index: index}
+	}
+
+	if _, err := m.Status(ctx, id); err != nil {
+		return nil, err
+	}
+
+	chunk, name, err := m.write(ctx, id, index, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if checksum != "" && !strings.EqualFold(checksum, chunk.Checksum) {
+		_ = m.store.Remove(ctx, name)
+
+		return nil, ErrorChecksumMismatch{Index: index, Checksum: chunk.Checksum}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, err := m.load(ctx, id)
+	if err != nil {
+		_ = m.store.Remove(ctx, name)
+
+		return nil, err
+	}
+
+	previous := u.set(chunk)
+
+	if u.Size > 0 && u.Received() > u.Size {
+		_ = m.store.Remove(ctx, name)
+
+		return nil, ErrorChunkTooLarge{Index: index, Limit: chunk.Size - (u.Received() - u.Size)}
+	}
+
+	if err := m.save(ctx, u); err != nil {
+		return nil, err
+	}
+
+	if previous != nil && previous.Checksum != chunk.Checksum {
+		_ = m.store.Remove(ctx, chunkName(id, *previous))
+	}
+
+	return chunk, nil
+}
+
+// write stores the content of the chunk under the name of its checksum.
+func (m *Manager) write(ctx context.Context, id string, index int, r io.Reader) (*Chunk, string, error) {
+	tmp := fmt.Sprintf("%s/chunks/%d.tmp", id, index)
+
+	w, err := m.store.Create(ctx, tmp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	h := sha256.New()
+
+	n, err := io.Copy(io.MultiWriter(w, h), io.LimitReader(r, m.maxChunkSize+1))
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil && n > m.maxChunkSize {
+		err = ErrorChunkTooLarge{Index: index, Limit: m.maxChunkSize}
+	}
+
+	if err != nil {
+		_ = m.store.Remove(ctx, tmp)
+
+		return nil, "", err
+	}
+
+	chunk := &Chunk{Index: index, Size: n, Checksum: hex.EncodeToString(h.Sum(nil))}
+	name := chunkName(id, *chunk)
+
+	if err := m.copy(ctx, tmp, name); err != nil {
+		return nil, "", err
+	}
+
+	return chunk, name, nil
+}
+
+// copy moves the file of the name src to dst, as the stores, like the object storages, may not rename the files.
+func (m *Manager) copy(ctx context.Context, src, dst string) error {
+	defer m.store.Remove(ctx, src) //nolint:errcheck // the temporary chunk is removed on a best effort basis.
+
+	r, err := m.store.Open(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	w, err := m.store.Create(ctx, dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+
+		return err
+	}
+
+	return w.Close()
+}
+
+// Complete calls fn with the content of the upload, which is removed once fn succeeds.
+func (m *Manager) Complete(ctx context.Context, id string, fn func(u *Upload, r io.Reader) error) (*Upload, error) {
+	u, err := m.Status(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.verify(); err != nil {
+		return nil, err
+	}
+
+	r := &chunkReader{ctx: ctx, store: m.store, id: id, chunks: u.Chunks}
+	defer r.Close()
+
+	if err := fn(u, r); err != nil {
+		return nil, err
+	}
+
+	return u, m.store.Remove(ctx, id)
+}
+
+// Abort removes the upload with its chunks.
+func (m *Manager) Abort(ctx context.Context, id string) error {
+	if _, err := m.Status(ctx, id); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.store.Remove(ctx, id)
+}
+
+func (m *Manager) load(ctx context.Context, id string) (*Upload, error) {
+	if !validID(id) {
+		return nil, ErrorUploadNotFound{ID: id}
+	}
+
+	r, err := m.store.Open(ctx, id+"/upload.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrorUploadNotFound{ID: id}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close()
+
+	var u Upload
+
+	if err := json.NewDecoder(r).Decode(&u); err != nil {
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+func (m *Manager) save(ctx context.Context, u *Upload) error {
+	w, err := m.store.Create(ctx, u.ID+"/upload.json")
+	if err != nil {
+		return err
+	}
+
+	if err := json.NewEncoder(w).Encode(u); err != nil {
+		w.Close()
+
+		return err
+	}
+
+	return w.Close()
+}
+
+// set sets the chunk of its index, keeping the chunks sorted, and returns the chunk which it replaces.
+func (u *Upload) set(c *Chunk) *Chunk {
+	i := sort.Search(len(u.Chunks), func(i int) bool { return u.Chunks[i].Index >= c.Index })
+
+	if i < len(u.Chunks) && u.Chunks[i].Index == c.Index {
+		previous := u.Chunks[i]
+		u.Chunks[i] = *c
+
+		return &previous
+	}
+
+	u.Chunks = append(u.Chunks, Chunk{})
+	copy(u.Chunks[i+1:], u.Chunks[i:])
+	u.Chunks[i] = *c
+
+	return nil
+}
+
+// verify returns an error unless the chunks are numbered from 0 without gaps and have the size of the upload.
+func (u *Upload) verify() error {
+	var missing []int
+
+	next := 0
+
+	for _, c := range u.Chunks {
+		for ; next < c.Index; next++ {
+			missing = append(missing, next)
+		}
+
+		next = c.Index + 1
+	}
+
+	if len(missing) > 0 || (u.Size > 0 && u.Received() != u.Size) {
+		return ErrorIncompleteUpload{Received: u.Received(), Size: u.Size, Missing: missing}
+	}
+
+	return nil
+}
+
+func chunkName(id string, c Chunk) string {
+	return fmt.Sprintf("%s/chunks/%d-%s", id, c.Index, c.Checksum)
+}
+
+func validID(id string) bool {
+	b, err := hex.DecodeString(id)
+
+	return err == nil && len(b) == idBytes
+}
+
+// chunkReader reads the chunks of an upload in order, opening a chunk only once the previous one is read.
+type chunkReader struct {
+	ctx    context.Context
+	store  file.Store
+	id     string
+	chunks []Chunk
+
+	current io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.chunks) == 0 {
+				return 0, io.EOF
+			}
+
+			current, err := r.store.Open(r.ctx, chunkName(r.id, r.chunks[0]))
+			if err != nil {
+				return 0, err
+			}
+
+			r.current, r.chunks = current, r.chunks[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if errors.Is(err, io.EOF) {
+			r.current.Close()
+			r.current = nil
+
+			if n == 0 {
+				continue
+			}
+
+			err = nil
+		}
+
+		return n, err
+	}
+}
+
+func (r *chunkReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+
+	return r.current.Close()
+}
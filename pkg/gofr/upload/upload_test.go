@@ -0,0 +1,154 @@
+package upload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/file"
+)
+
+func newTestManager(t *testing.T, opts ...Option) *Manager {
+	t.Helper()
+
+	store, err := file.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	return NewManager(store, opts...)
+}
+
+func checksum(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func readAll(content *string) func(*Upload, io.Reader) error {
+	return func(_ *Upload, r io.Reader) error {
+		b, err := io.ReadAll(r)
+		*content = string(b)
+
+		return err
+	}
+}
+
+func TestManager_Upload(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	u, err := m.Initiate(ctx, "video.mp4", 11)
+	require.NoError(t, err)
+
+	// the chunks are sent out of order, and the first one is sent again as its sending is retried.
+	for _, c := range []struct {
+		index   int
+		content string
+	}{{2, "rld"}, {0, "hello"}, {1, " wo"}, {0, "hello"}} {
+		chunk, err := m.Append(ctx, u.ID, c.index, checksum(c.content), strings.NewReader(c.content))
+		require.NoError(t, err)
+
+		assert.Equal(t, Chunk{Index: c.index, Size: int64(len(c.content)), Checksum: checksum(c.content)}, *chunk)
+	}
+
+	status, err := m.Status(ctx, u.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{0, 1, 2}, []int{status.Chunks[0].Index, status.Chunks[1].Index, status.Chunks[2].Index})
+	assert.Equal(t, int64(11), status.Received())
+
+	var content string
+
+	completed, err := m.Complete(ctx, u.ID, readAll(&content))
+	require.NoError(t, err)
+
+	assert.Equal(t, "hello world", content)
+	assert.Equal(t, "video.mp4", completed.Name)
+
+	_, err = m.Status(ctx, u.ID)
+	assert.Equal(t, ErrorUploadNotFound{ID: u.ID}, err)
+}
+
+func TestManager_Append_Errors(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t, WithMaxChunkSize(4))
+
+	u, err := m.Initiate(ctx, "a.txt", 6)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		id       string
+		index    int
+		checksum string
+		content  string
+		err      error
+	}{
+		{"unknown upload", strings.Repeat("0", 32), 0, "", "abc", ErrorUploadNotFound{ID: strings.Repeat("0", 32)}},
+		{"invalid id", "../x", 0, "", "abc", ErrorUploadNotFound{ID: "../x"}},
+		{"negative index", u.ID, -1, "", "abc", ErrorInvalidChunk{Index: -1}},
+		{"checksum mismatch", u.ID, 0, checksum("abd"), "abc", ErrorChecksumMismatch{Index: 0, Checksum: checksum("abc")}},
+		{"chunk too large", u.ID, 0, "", "abcde", ErrorChunkTooLarge{Index: 0, Limit: 4}},
+		{"valid chunk", u.ID, 0, "", "abcd", nil},
+		{"upload too large", u.ID, 1, "", "efg", ErrorChunkTooLarge{Index: 1, Limit: 2}},
+	}
+
+	for i, tc := range testCases {
+		_, err := m.Append(ctx, tc.id, tc.index, tc.checksum, strings.NewReader(tc.content))
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	status, err := m.Status(ctx, u.ID)
+	require.NoError(t, err)
+
+	assert.Len(t, status.Chunks, 1)
+}
+
+func TestManager_Complete_Incomplete(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	u, err := m.Initiate(ctx, "a.txt", 0)
+	require.NoError(t, err)
+
+	_, err = m.Append(ctx, u.ID, 1, "", strings.NewReader("b"))
+	require.NoError(t, err)
+
+	_, err = m.Complete(ctx, u.ID, readAll(new(string)))
+	assert.Equal(t, ErrorIncompleteUpload{Received: 1, Missing: []int{0}}, err)
+
+	_, err = m.Append(ctx, u.ID, 0, "", strings.NewReader("a"))
+	require.NoError(t, err)
+
+	errHandler := errors.New("storage is unavailable")
+
+	_, err = m.Complete(ctx, u.ID, func(*Upload, io.Reader) error { return errHandler })
+	assert.Equal(t, errHandler, err)
+
+	var content string
+
+	_, err = m.Complete(ctx, u.ID, readAll(&content))
+	require.NoError(t, err)
+
+	assert.Equal(t, "ab", content)
+}
+
+func TestManager_Abort(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	u, err := m.Initiate(ctx, "a.txt", 0)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Abort(ctx, u.ID))
+
+	_, err = m.Append(ctx, u.ID, 0, "", strings.NewReader("a"))
+	assert.Equal(t, ErrorUploadNotFound{ID: u.ID}, err)
+	assert.Equal(t, ErrorUploadNotFound{ID: u.ID}, m.Abort(ctx, u.ID))
+}
@@ -0,0 +1,95 @@
+package gofr
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	gofrHTTP "github.com/peter-stratton/gofr/pkg/gofr/http"
+	"github.com/peter-stratton/gofr/pkg/gofr/upload"
+)
+
+// chunkChecksumHeader is the header of the hex encoded SHA-256 of the chunks, which are verified against it.
+const chunkChecksumHeader = "X-Chunk-Checksum"
+
+var errNoBody = errors.New("request has no body to read")
+
+// UploadHandler handles a completed upload, reading its file from r.
+type UploadHandler func(c *Context, u *upload.Upload, r io.Reader) (interface{}, error)
+
+// AddUploads registers the routes of the resumable uploads of the manager under the path:
+//
+//   - POST {path} initiates an upload, with a JSON body of the "name" and the "size" of the file.
+//   - PUT {path}/{id}/chunks/{index} appends the chunk, verified against its X-Chunk-Checksum header if set.
+//   - GET {path}/{id} returns the upload with its received chunks.
+//   - POST {path}/{id}/complete completes the upload, calling the handler with its file.
+//   - DELETE {path}/{id} aborts the upload.
+func (a *App) AddUploads(path string, m *upload.Manager, handler UploadHandler) {
+	a.POST(path, func(c *Context) (interface{}, error) {
+		var body struct {
+			Name string `json:"name"`
+			Size int64  `json:"size"`
+		}
+
+		if err := c.Bind(&body); err != nil {
+			return nil, err
+		}
+
+		if body.Name == "" {
+			return nil, gofrHTTP.ErrorMissingParam{Params: []string{"name"}}
+		}
+
+		u, err := m.Initiate(c, body.Name, body.Size)
+		if err != nil {
+			return nil, err
+		}
+
+		return u, nil
+	})
+
+	a.GET(path+"/{id}", func(c *Context) (interface{}, error) {
+		u, err := m.Status(c, c.PathParam("id"))
+		if err != nil {
+			return nil, err
+		}
+
+		return u, nil
+	})
+
+	a.PUT(path+"/{id}/chunks/{index}", func(c *Context) (interface{}, error) {
+		index, err := strconv.Atoi(c.PathParam("index"))
+		if err != nil {
+			return nil, gofrHTTP.ErrorInvalidParam{Params: []string{"index"}}
+		}
+
+		req, ok := c.Request.(interface{ Body() io.Reader })
+		if !ok {
+			return nil, errNoBody
+		}
+
+		chunk, err := m.Append(c, c.PathParam("id"), index, c.Request.GetHeader(chunkChecksumHeader), req.Body())
+		if err != nil {
+			return nil, err
+		}
+
+		return chunk, nil
+	})
+
+	a.POST(path+"/{id}/complete", func(c *Context) (interface{}, error) {
+		var result interface{}
+
+		_, err := m.Complete(c, c.PathParam("id"), func(u *upload.Upload, r io.Reader) error {
+			var err error
+
+			result, err = handler(c, u, r)
+
+			return err
+		})
+
+		return result, err
+	})
+
+	a.DELETE(path+"/{id}", func(c *Context) (interface{}, error) {
+		return nil, m.Abort(c, c.PathParam("id"))
+	})
+}
@@ -0,0 +1,88 @@
+package gofr
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/file"
+	"github.com/peter-stratton/gofr/pkg/gofr/upload"
+)
+
+func TestApp_AddUploads(t *testing.T) {
+	store, err := file.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	app := New()
+	app.AddUploads("/uploads", upload.NewManager(store), func(_ *Context, u *upload.Upload, r io.Reader) (interface{}, error) {
+		content, err := io.ReadAll(r)
+
+		return map[string]string{"name": u.Name, "content": string(content)}, err
+	})
+
+	serve := func(method, target, body string, headers ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+
+		w := httptest.NewRecorder()
+		app.httpServer.router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	w := serve(http.MethodPost, "/uploads", `{"name":"notes.txt","size":9}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var initiated struct {
+		Data upload.Upload `json:"data"`
+	}
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &initiated))
+
+	path := "/uploads/" + initiated.Data.ID
+
+	testCases := []struct {
+		desc       string
+		method     string
+		target     string
+		body       string
+		headers    []string
+		statusCode int
+		response   string
+	}{
+		{"missing name", http.MethodPost, "/uploads", `{"size":1}`, nil, http.StatusBadRequest,
+			`{"error":{"message":"'1' missing parameter(s): name"}}`},
+		{"invalid index", http.MethodPut, path + "/chunks/first", "a", nil, http.StatusBadRequest,
+			`{"error":{"message":"'1' invalid parameter(s): index"}}`},
+		{"checksum mismatch", http.MethodPut, path + "/chunks/1", "more", []string{chunkChecksumHeader, "00"},
+			http.StatusBadRequest, `{"error":{"message":"checksum of chunk 1 is ` +
+				`187897ce0afcf20b50ba2b37dca84a951b7046f29ed5ab94f010619f69d6e189"}}`},
+		{"second chunk", http.MethodPut, path + "/chunks/1", "more", nil, http.StatusOK,
+			`{"data":{"index":1,"size":4,"checksum":"187897ce0afcf20b50ba2b37dca84a951b7046f29ed5ab94f010619f69d6e189"}}`},
+		{"incomplete", http.MethodPost, path + "/complete", "", nil, http.StatusConflict,
+			`{"error":{"message":"upload is missing the chunks [0]"}}`},
+		{"first chunk", http.MethodPut, path + "/chunks/0", "some ", nil, http.StatusOK,
+			`{"data":{"index":0,"size":5,"checksum":"ee82cc30585022ea5102dda1f747cbfe345c261dd7c2eabea8aa1ad4308bf790"}}`},
+		{"complete", http.MethodPost, path + "/complete", "", nil, http.StatusCreated,
+			`{"data":{"content":"some more","name":"notes.txt"}}`},
+		{"completed", http.MethodGet, path, "", nil, http.StatusNotFound,
+			`{"error":{"message":"upload \"` + initiated.Data.ID + `\" is not found"}}`},
+	}
+
+	for i, tc := range testCases {
+		w := serve(tc.method, tc.target, tc.body, tc.headers...)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.response, strings.TrimSpace(w.Body.String()), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,185 @@
+package gofr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/service"
+)
+
+const defaultWarmUpTimeout = 60 * time.Second
+
+var errWarmerPanicked = errors.New("panicked")
+
+type warmer struct {
+	name string
+	fn   func(ctx *Context) error
+}
+
+// AddWarmer adds a warmer run on startup, before which the application is not ready.
+func (a *App) AddWarmer(name string, fn func(ctx *Context) error) {
+	a.warmers = append(a.warmers, warmer{name: name, fn: fn})
+}
+
+// warmUp runs the warmers once waited is closed.
+func (a *App) warmUp(waited <-chan struct{}) {
+	if len(a.warmers) == 0 {
+		return
+	}
+
+	done := a.container.StartWarmUp()
+
+	timeout := defaultWarmUpTimeout
+
+	if seconds, err := strconv.Atoi(a.Config.Get("WARMUP_TIMEOUT")); err == nil && seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	go func() {
+		defer done()
+
+		<-waited
+
+		start := time.Now()
+
+		a.runWarmers(timeout)
+
+		a.container.Infof("application warmed up in %v", time.Since(start))
+	}()
+}
+
+func (a *App) runWarmers(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	for _, w := range a.warmers {
+		wg.Add(1)
+
+		go func(w warmer) {
+			defer wg.Done()
+
+			start := time.Now()
+			status := "success"
+
+			if err := a.runWarmer(ctx, w); err != nil {
+				status = "failure"
+
+				a.container.Errorf("warmer %s failed, error: %v", w.name, err)
+			}
+
+			a.container.Metrics().RecordHistogram(context.Background(), "app_warmup_duration",
+				time.Since(start).Seconds(), "warmer", w.name, "status", status)
+		}(w)
+	}
+
+	wg.Wait()
+}
+
+func (a *App) runWarmer(ctx context.Context, w warmer) error {
+	done := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("%w: %v", errWarmerPanicked, r)
+			}
+		}()
+
+		done <- w.fn(&Context{
+			Context:   ctx,
+			Container: a.container,
+			Request:   noopRequest{},
+		})
+	}()
+
+	// a warmer which does not honour the cancellation of its context is abandoned once it times out.
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// PreDial returns a warmer dialing n connections to SQL and Redis, and checking the health of the HTTP services.
+//
+//	app.AddWarmer("connections", gofr.PreDial(10))
+func PreDial(connections int) func(ctx *Context) error {
+	return func(ctx *Context) error {
+		var errs []error
+
+		if db, ok := ctx.SQL.(interface {
+			Conn(ctx context.Context) (*sql.Conn, error)
+		}); ok && !isNil(db) {
+			errs = append(errs, dial(connections, func() (func() error, error) {
+				conn, err := db.Conn(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				return conn.Close, conn.PingContext(ctx)
+			}))
+		}
+
+		if client, ok := ctx.Redis.(interface{ Conn() *redis.Conn }); ok && !isNil(client) {
+			errs = append(errs, dial(connections, func() (func() error, error) {
+				conn := client.Conn()
+
+				return conn.Close, conn.Ping(ctx).Err()
+			}))
+		}
+
+		var wg sync.WaitGroup
+
+		for _, svc := range ctx.Services {
+			wg.Add(1)
+
+			go func(svc service.HTTP) {
+				defer wg.Done()
+
+				svc.HealthCheck(ctx)
+			}(svc)
+		}
+
+		wg.Wait()
+
+		return errors.Join(errs...)
+	}
+}
+
+// dial opens the connections at once, and closes them to return them to their pool.
+func dial(connections int, open func() (closeConn func() error, err error)) error {
+	errs := make([]error, connections)
+	closers := make([]func() error, connections)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < connections; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			closers[i], errs[i] = open()
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, c := range closers {
+		if c != nil {
+			_ = c()
+		}
+	}
+
+	return errors.Join(errs...)
+}
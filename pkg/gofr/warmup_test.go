@@ -0,0 +1,125 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource"
+	"github.com/peter-stratton/gofr/pkg/gofr/datasource/redis"
+	"github.com/peter-stratton/gofr/pkg/gofr/logging"
+	"github.com/peter-stratton/gofr/pkg/gofr/service"
+)
+
+func TestApp_warmUp(t *testing.T) {
+	app := New()
+
+	unblock := make(chan struct{})
+
+	var ran atomic.Int32
+
+	app.AddWarmer("templates", func(*Context) error {
+		<-unblock
+		ran.Add(1)
+
+		return nil
+	})
+	app.AddWarmer("cache", func(*Context) error {
+		ran.Add(1)
+
+		return errPrimeCache
+	})
+	app.AddWarmer("panics", func(*Context) error {
+		ran.Add(1)
+
+		panic("boom")
+	})
+
+	waited := make(chan struct{})
+
+	app.warmUp(waited)
+
+	// the warmers wait for the dependencies.
+	time.Sleep(10 * time.Millisecond)
+	assert.Zero(t, ran.Load())
+	assert.Equal(t, datasource.StatusDown, app.container.Ready(context.Background()).Status)
+
+	close(waited)
+
+	require.Eventually(t, func() bool { return ran.Load() == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, datasource.StatusDown, app.container.Ready(context.Background()).Status)
+
+	close(unblock)
+
+	// the application is ready once the warmers ran, even if some of them failed.
+	require.Eventually(t, func() bool {
+		return app.container.Ready(context.Background()).Status == datasource.StatusUp
+	}, time.Second, time.Millisecond)
+}
+
+func TestApp_warmUp_Timeout(t *testing.T) {
+	t.Setenv("WARMUP_TIMEOUT", "1")
+
+	app := New()
+
+	app.AddWarmer("stuck", func(*Context) error {
+		select {}
+	})
+
+	waited := make(chan struct{})
+	close(waited)
+
+	app.warmUp(waited)
+
+	assert.Equal(t, datasource.StatusDown, app.container.Ready(context.Background()).Status)
+
+	require.Eventually(t, func() bool {
+		return app.container.Ready(context.Background()).Status == datasource.StatusUp
+	}, 3*time.Second, 10*time.Millisecond)
+}
+
+func TestApp_warmUp_NoWarmers(t *testing.T) {
+	app := New()
+
+	app.warmUp(make(chan struct{}))
+
+	assert.Equal(t, datasource.StatusUp, app.container.Ready(context.Background()).Status)
+}
+
+func TestPreDial(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	var checks atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		checks.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := container.NewContainer(config.NewMockConfig(nil))
+	logger := logging.NewMockLogger(logging.ERROR)
+
+	client := redis.NewClient(config.NewMockConfig(map[string]string{"REDIS_HOST": s.Host(), "REDIS_PORT": s.Port()}),
+		logger, c.Metrics())
+	c.Redis = client
+	c.Services = map[string]service.HTTP{"orders": service.NewHTTPService(server.URL, logger, c.Metrics())}
+
+	err = PreDial(3)(&Context{Context: context.Background(), Container: c, Request: noopRequest{}})
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, int(client.PoolStats().TotalConns), 3)
+	assert.Equal(t, int32(1), checks.Load())
+}
@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	idHeader    = "X-Webhook-ID"
+	eventHeader = "X-Webhook-Event"
+
+	// maxDrainedBody bounds the bytes of a response read to reuse its connection.
+	maxDrainedBody = 4 << 10
+)
+
+// body is the body of a delivery.
+type body struct {
+	ID        string          `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Deliver attempts the delivery of the id, and returns an error for the job to retry it.
+func (m *Manager) Deliver(ctx context.Context, id string) error {
+	if m.store == nil {
+		return errSQLNotConfigured
+	}
+
+	d, err := m.store.delivery(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if d.Status != StatusPending {
+		return nil
+	}
+
+	e, err := m.store.endpoint(ctx, d.EndpointID)
+
+	var notFound ErrorEndpointNotFound
+	if errors.As(err, &notFound) {
+		d.Status, d.LastError, d.UpdatedAt = StatusFailed, err.Error(), now()
+
+		return m.store.updateDelivery(ctx, d)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Attempts++
+
+	status, deliveryErr := m.post(ctx, e, d)
+
+	d.ResponseStatus, d.UpdatedAt = status, now()
+
+	switch {
+	case deliveryErr == nil:
+		d.Status, d.LastError = StatusDelivered, ""
+	case d.Attempts >= m.maxAttempts:
+		d.Status, d.LastError = StatusFailed, deliveryErr.Error()
+	default:
+		d.LastError = deliveryErr.Error()
+	}
+
+	if err := m.store.updateDelivery(ctx, d); err != nil {
+		return err
+	}
+
+	return deliveryErr
+}
+
+// post sends the delivery to the endpoint, and returns the status of its response.
+func (m *Manager) post(ctx context.Context, e *Endpoint, d *Delivery) (int, error) {
+	data, err := json.Marshal(body{ID: d.ID, Event: d.Event, CreatedAt: d.CreatedAt, Data: d.Payload})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gofr-webhooks")
+	req.Header.Set(idHeader, d.ID)
+	req.Header.Set(eventHeader, d.Event)
+	req.Header.Set(SignatureHeader, Sign(e.Secret, time.Now(), data))
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return 0, errDeliveryFailed{err: err}
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedBody))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, errDeliveryFailed{statusCode: resp.StatusCode}
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrInvalidSignature is returned by Verify for a signature which does not match the payload, or which is too old.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+
+	errSQLNotConfigured = errors.New("webhooks require a SQL database, which is not configured")
+)
+
+// ErrorEndpointNotFound is returned for the endpoints which are not registered, or are removed.
+type ErrorEndpointNotFound struct {
+	ID string
+}
+
+func (e ErrorEndpointNotFound) Error() string {
+	return fmt.Sprintf("webhook endpoint %q is not found", e.ID)
+}
+
+func (ErrorEndpointNotFound) StatusCode() int {
+	return http.StatusNotFound
+}
+
+// ErrorDeliveryNotFound is returned for the deliveries which do not exist.
+type ErrorDeliveryNotFound struct {
+	ID string
+}
+
+func (e ErrorDeliveryNotFound) Error() string {
+	return fmt.Sprintf("webhook delivery %q is not found", e.ID)
+}
+
+func (ErrorDeliveryNotFound) StatusCode() int {
+	return http.StatusNotFound
+}
+
+// ErrorInvalidEndpoint is returned when registering an endpoint whose URL is not an absolute HTTP or HTTPS URL.
+type ErrorInvalidEndpoint struct {
+	URL string
+}
+
+func (e ErrorInvalidEndpoint) Error() string {
+	return fmt.Sprintf("webhook endpoint URL %q is not an absolute http or https URL", e.URL)
+}
+
+func (ErrorInvalidEndpoint) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// errDeliveryFailed is returned for the attempts not acknowledged by the endpoint.
+type errDeliveryFailed struct {
+	statusCode int
+	err        error
+}
+
+func (e errDeliveryFailed) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("webhook delivery failed: %v", e.err)
+	}
+
+	return fmt.Sprintf("webhook delivery failed with status %d", e.statusCode)
+}
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the header of the signature of the deliveries.
+const SignatureHeader = "X-Webhook-Signature"
+
+// Sign returns the signature of the body sent at the time, as "t=<unix time>,v1=<hex HMAC-SHA256>".
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+
+	return fmt.Sprintf("t=%s,v1=%s", ts, hex.EncodeToString(signature(secret, ts, body)))
+}
+
+// Verify returns ErrInvalidSignature unless the signature is valid and within the tolerance.
+//
+//	Usage:
+//	body, _ := io.ReadAll(r.Body)
+//	err := webhook.Verify(secret, r.Header.Get(webhook.SignatureHeader), body, 5*time.Minute)
+func Verify(secret, header string, body []byte, tolerance time.Duration) error {
+	var ts, sig string
+
+	for _, part := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+
+		switch name {
+		case "t":
+			ts = value
+		case "v1":
+			sig = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 && time.Since(time.Unix(unix, 0)).Abs() > tolerance {
+		return ErrInvalidSignature
+	}
+
+	decoded, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(decoded, signature(secret, ts, body)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func signature(secret, ts string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return mac.Sum(nil)
+}
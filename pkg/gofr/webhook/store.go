@@ -0,0 +1,234 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	gofrSQL "github.com/peter-stratton/gofr/pkg/gofr/datasource/sql"
+)
+
+const (
+	createEndpointsTable = `CREATE TABLE IF NOT EXISTS gofr_webhook_endpoints (
+    id VARCHAR(36) not null primary key,
+    owner VARCHAR(255) not null,
+    url TEXT not null,
+    secret VARCHAR(255) not null,
+    events TEXT not null,
+    created_at BIGINT not null
+);`
+
+	createDeliveriesTable = `CREATE TABLE IF NOT EXISTS gofr_webhook_deliveries (
+    id VARCHAR(36) not null primary key,
+    endpoint_id VARCHAR(36) not null,
+    event VARCHAR(255) not null,
+    payload TEXT not null,
+    status VARCHAR(16) not null,
+    attempts INT not null,
+    response_status INT not null,
+    last_error TEXT not null,
+    created_at BIGINT not null,
+    updated_at BIGINT not null
+);`
+
+	endpointColumns = `id, owner, url, secret, events, created_at`
+	deliveryColumns = `id, endpoint_id, event, payload, status, attempts, response_status, last_error, created_at, updated_at`
+
+	insertEndpoint  = `INSERT INTO gofr_webhook_endpoints (` + endpointColumns + `) VALUES (?, ?, ?, ?, ?, ?);`
+	selectEndpoint  = `SELECT ` + endpointColumns + ` FROM gofr_webhook_endpoints WHERE id = ?;`
+	selectEndpoints = `SELECT ` + endpointColumns + ` FROM gofr_webhook_endpoints WHERE owner = ? ORDER BY created_at;`
+	deleteEndpoint  = `DELETE FROM gofr_webhook_endpoints WHERE id = ?;`
+
+	insertDelivery   = `INSERT INTO gofr_webhook_deliveries (` + deliveryColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	selectDelivery   = `SELECT ` + deliveryColumns + ` FROM gofr_webhook_deliveries WHERE id = ?;`
+	selectDeliveries = `SELECT ` + deliveryColumns + ` FROM gofr_webhook_deliveries WHERE status = ? ` +
+		`ORDER BY created_at DESC`
+	updateDelivery = `UPDATE gofr_webhook_deliveries SET status = ?, attempts = ?, response_status = ?, last_error = ?, ` +
+		`updated_at = ? WHERE id = ?;`
+)
+
+// store keeps the endpoints and the deliveries in SQL tables.
+type store struct {
+	db container.DB
+
+	schema gofrSQL.Schema
+}
+
+func (s *store) insertEndpoint(ctx context.Context, e *Endpoint) error {
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, s.query(insertEndpoint), e.ID, e.Owner, e.URL, e.Secret, strings.Join(e.Events, ","),
+		e.CreatedAt.UnixMilli())
+
+	return err
+}
+
+func (s *store) endpoint(ctx context.Context, id string) (*Endpoint, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	e, err := scanEndpoint(s.db.QueryRowContext(ctx, s.query(selectEndpoint), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrorEndpointNotFound{ID: id}
+	}
+
+	return e, err
+}
+
+func (s *store) endpoints(ctx context.Context, owner string) ([]Endpoint, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.query(selectEndpoints), owner)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	endpoints := make([]Endpoint, 0)
+
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		endpoints = append(endpoints, *e)
+	}
+
+	return endpoints, rows.Err()
+}
+
+func (s *store) deleteEndpoint(ctx context.Context, id string) error {
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+
+	res, err := s.db.ExecContext(ctx, s.query(deleteEndpoint), id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrorEndpointNotFound{ID: id}
+	}
+
+	return nil
+}
+
+func (s *store) insertDelivery(ctx context.Context, d *Delivery) error {
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, s.query(insertDelivery), d.ID, d.EndpointID, d.Event, string(d.Payload), d.Status,
+		d.Attempts, d.ResponseStatus, d.LastError, d.CreatedAt.UnixMilli(), d.UpdatedAt.UnixMilli())
+
+	return err
+}
+
+func (s *store) delivery(ctx context.Context, id string) (*Delivery, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	d, err := scanDelivery(s.db.QueryRowContext(ctx, s.query(selectDelivery), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrorDeliveryNotFound{ID: id}
+	}
+
+	return d, err
+}
+
+func (s *store) deliveries(ctx context.Context, status string, limit int) ([]Delivery, error) {
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.query(selectDeliveries+gofrSQL.Limit(s.db.Dialect())), status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	deliveries := make([]Delivery, 0)
+
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, *d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (s *store) updateDelivery(ctx context.Context, d *Delivery) error {
+	_, err := s.db.ExecContext(ctx, s.query(updateDelivery), d.Status, d.Attempts, d.ResponseStatus, d.LastError,
+		d.UpdatedAt.UnixMilli(), d.ID)
+
+	return err
+}
+
+func (s *store) migrate(ctx context.Context) error {
+	return s.schema.Create(ctx, s.db, createEndpointsTable, createDeliveriesTable)
+}
+
+func (s *store) query(q string) string {
+	return gofrSQL.Rebind(s.db.Dialect(), q)
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEndpoint(row scanner) (*Endpoint, error) {
+	var (
+		e         Endpoint
+		events    string
+		createdAt int64
+	)
+
+	if err := row.Scan(&e.ID, &e.Owner, &e.URL, &e.Secret, &events, &createdAt); err != nil {
+		return nil, err
+	}
+
+	if events != "" {
+		e.Events = strings.Split(events, ",")
+	}
+
+	e.CreatedAt = time.UnixMilli(createdAt).UTC()
+
+	return &e, nil
+}
+
+func scanDelivery(row scanner) (*Delivery, error) {
+	var (
+		d                    Delivery
+		payload              string
+		createdAt, updatedAt int64
+	)
+
+	err := row.Scan(&d.ID, &d.EndpointID, &d.Event, &payload, &d.Status, &d.Attempts, &d.ResponseStatus, &d.LastError,
+		&createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Payload = []byte(payload)
+	d.CreatedAt = time.UnixMilli(createdAt).UTC()
+	d.UpdatedAt = time.UnixMilli(updatedAt).UTC()
+
+	return &d, nil
+}
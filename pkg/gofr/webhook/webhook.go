@@ -0,0 +1,280 @@
+// Package webhook delivers the events of the application to the HTTP endpoints of its consumers, with retries.
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+)
+
+// JobName is the name of the job delivering the webhooks, which is registered by App.AddWebhooks.
+const JobName = "gofr-webhook-delivery"
+
+// Statuses of the deliveries.
+const (
+	StatusPending   = "PENDING"
+	StatusDelivered = "DELIVERED"
+	StatusFailed    = "FAILED"
+)
+
+const (
+	defaultMaxAttempts = 12
+	defaultTimeout     = 10 * time.Second
+	secretBytes        = 32
+
+	// AllEvents subscribes an endpoint to all the events.
+	AllEvents = "*"
+)
+
+// Endpoint is an HTTP endpoint of an owner, to which the events it is subscribed to are delivered.
+type Endpoint struct {
+	ID    string `json:"id"`
+	Owner string `json:"owner"`
+	URL   string `json:"url"`
+	// Secret signs the deliveries to the endpoint. It is generated when the endpoint is registered without one.
+	Secret string `json:"secret,omitempty"`
+	// Events are the events delivered to the endpoint, or all of them if empty or AllEvents.
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Delivery is the delivery of an event to an endpoint, with the status of its last attempt.
+type Delivery struct {
+	ID         string          `json:"id"`
+	EndpointID string          `json:"endpointId"`
+	Event      string          `json:"event"`
+	Payload    json.RawMessage `json:"payload"`
+	Status     string          `json:"status"`
+	Attempts   int             `json:"attempts"`
+	// ResponseStatus is the status of the response of the endpoint to the last attempt, or 0 if it did not respond.
+	ResponseStatus int       `json:"responseStatus,omitempty"`
+	LastError      string    `json:"lastError,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// Queue runs the delivery jobs in the background, like container.Container.
+type Queue interface {
+	EnqueueJob(ctx context.Context, name string, payload interface{}, opts ...container.JobOptions) (string, error)
+}
+
+// Manager registers the endpoints of the webhooks and delivers the events to them as jobs.
+type Manager struct {
+	store       *store
+	queue       Queue
+	client      *http.Client
+	maxAttempts int
+}
+
+// Option configures a Manager.
+type Option func(m *Manager)
+
+// WithMaxAttempts sets the number of attempts of a delivery, 12 by default.
+func WithMaxAttempts(n int) Option {
+	return func(m *Manager) {
+		if n > 0 {
+			m.maxAttempts = n
+		}
+	}
+}
+
+// WithHTTPClient sets the client of the deliveries, whose timeout is 10 seconds by default.
+func WithHTTPClient(client *http.Client) Option {
+	return func(m *Manager) {
+		m.client = client
+	}
+}
+
+// NewManager returns a Manager storing its endpoints and deliveries in db, and delivering on the queue.
+func NewManager(db container.DB, queue Queue, opts ...Option) *Manager {
+	m := &Manager{
+		queue:       queue,
+		client:      &http.Client{Timeout: defaultTimeout},
+		maxAttempts: defaultMaxAttempts,
+	}
+
+	if db != nil && !reflect.ValueOf(db).IsNil() {
+		m.store = &store{db: db}
+	}
+
+	for _, o := range opts {
+		o(m)
+	}
+
+	return m
+}
+
+// Register registers the endpoint, generating its ID, and its secret unless it has one.
+func (m *Manager) Register(ctx context.Context, e Endpoint) (*Endpoint, error) {
+	if m.store == nil {
+		return nil, errSQLNotConfigured
+	}
+
+	u, err := url.Parse(e.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrorInvalidEndpoint{URL: e.URL}
+	}
+
+	e.ID = uuid.NewString()
+	e.CreatedAt = now()
+
+	if e.Secret == "" {
+		if e.Secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.store.insertEndpoint(ctx, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// Endpoints returns the endpoints of the owner.
+func (m *Manager) Endpoints(ctx context.Context, owner string) ([]Endpoint, error) {
+	if m.store == nil {
+		return nil, errSQLNotConfigured
+	}
+
+	return m.store.endpoints(ctx, owner)
+}
+
+// Remove removes the endpoint, whose pending deliveries fail on their next attempt.
+func (m *Manager) Remove(ctx context.Context, id string) error {
+	if m.store == nil {
+		return errSQLNotConfigured
+	}
+
+	return m.store.deleteEndpoint(ctx, id)
+}
+
+// Send delivers the event to the endpoints of the owner subscribed to it, and returns the deliveries.
+func (m *Manager) Send(ctx context.Context, owner, event string, payload interface{}) ([]Delivery, error) {
+	if m.store == nil {
+		return nil, errSQLNotConfigured
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := m.store.endpoints(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]Delivery, 0, len(endpoints))
+
+	for i := range endpoints {
+		if !endpoints[i].subscribed(event) {
+			continue
+		}
+
+		createdAt := now()
+
+		d := Delivery{ID: uuid.NewString(), EndpointID: endpoints[i].ID, Event: event, Payload: data,
+			Status: StatusPending, CreatedAt: createdAt, UpdatedAt: createdAt}
+
+		if err := m.store.insertDelivery(ctx, &d); err != nil {
+			return deliveries, err
+		}
+
+		if err := m.enqueue(ctx, d.ID); err != nil {
+			return deliveries, err
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, nil
+}
+
+// Deliveries returns up to limit deliveries in the status, the most recent first.
+func (m *Manager) Deliveries(ctx context.Context, status string, limit int) ([]Delivery, error) {
+	if m.store == nil {
+		return nil, errSQLNotConfigured
+	}
+
+	return m.store.deliveries(ctx, status, limit)
+}
+
+// Delivery returns the delivery of the id.
+func (m *Manager) Delivery(ctx context.Context, id string) (*Delivery, error) {
+	if m.store == nil {
+		return nil, errSQLNotConfigured
+	}
+
+	return m.store.delivery(ctx, id)
+}
+
+// Redeliver delivers again the delivery of the id, like a FAILED one, with its attempts reset.
+func (m *Manager) Redeliver(ctx context.Context, id string) (*Delivery, error) {
+	if m.store == nil {
+		return nil, errSQLNotConfigured
+	}
+
+	d, err := m.store.delivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Status = StatusPending
+	d.Attempts = 0
+	d.UpdatedAt = now()
+
+	if err := m.store.updateDelivery(ctx, d); err != nil {
+		return nil, err
+	}
+
+	if err := m.enqueue(ctx, d.ID); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (m *Manager) enqueue(ctx context.Context, id string) error {
+	_, err := m.queue.EnqueueJob(ctx, JobName, id, container.JobOptions{MaxAttempts: m.maxAttempts})
+
+	return err
+}
+
+func (e *Endpoint) subscribed(event string) bool {
+	if len(e.Events) == 0 {
+		return true
+	}
+
+	for _, ev := range e.Events {
+		if ev == AllEvents || ev == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, secretBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// now returns the current time in the precision of the store, so that the times returned are those which are stored.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
@@ -0,0 +1,237 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+)
+
+// queue records the enqueued deliveries instead of running them.
+type queue struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (q *queue) EnqueueJob(_ context.Context, name string, payload interface{}, _ ...container.JobOptions) (string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if name == JobName {
+		q.ids = append(q.ids, payload.(string))
+	}
+
+	return "job", nil
+}
+
+func newTestManager(t *testing.T, opts ...Option) (*Manager, *queue) {
+	t.Helper()
+
+	c := container.NewContainer(config.NewMockConfig(map[string]string{
+		"DB_DIALECT": "sqlite",
+		"DB_NAME":    filepath.Join(t.TempDir(), "webhooks"),
+	}))
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	q := &queue{}
+
+	return NewManager(c.SQL, q, opts...), q
+}
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Now()
+	header := Sign("secret", now, body)
+
+	testCases := []struct {
+		desc   string
+		secret string
+		header string
+		body   []byte
+		err    error
+	}{
+		{"valid signature", "secret", header, body, nil},
+		{"other secret", "other", header, body, ErrInvalidSignature},
+		{"tampered body", "secret", header, []byte(`{"id":"2"}`), ErrInvalidSignature},
+		{"expired signature", "secret", Sign("secret", now.Add(-time.Hour), body), body, ErrInvalidSignature},
+		{"malformed header", "secret", "v1=abc", body, ErrInvalidSignature},
+	}
+
+	for i, tc := range testCases {
+		err := Verify(tc.secret, tc.header, tc.body, 5*time.Minute)
+
+		assert.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestManager_Register(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+
+	e, err := m.Register(ctx, Endpoint{Owner: "acme", URL: "https://acme.example.com/hooks", Events: []string{"order.paid"}})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, e.ID)
+	assert.Contains(t, e.Secret, "whsec_")
+
+	_, err = m.Register(ctx, Endpoint{Owner: "acme", URL: "ftp://acme.example.com"})
+	assert.Equal(t, ErrorInvalidEndpoint{URL: "ftp://acme.example.com"}, err)
+
+	endpoints, err := m.Endpoints(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, []Endpoint{*e}, endpoints)
+
+	assert.NoError(t, m.Remove(ctx, e.ID))
+	assert.Equal(t, ErrorEndpointNotFound{ID: e.ID}, m.Remove(ctx, e.ID))
+}
+
+func TestManager_SendAndDeliver(t *testing.T) {
+	var (
+		received  body
+		signature string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+
+		_ = json.Unmarshal(data, &received)
+
+		if Verify("whsec_test", signature, data, time.Minute) != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	m, q := newTestManager(t)
+	ctx := context.Background()
+
+	e, err := m.Register(ctx, Endpoint{Owner: "acme", URL: srv.URL, Secret: "whsec_test", Events: []string{"order.paid"}})
+	require.NoError(t, err)
+
+	_, err = m.Register(ctx, Endpoint{Owner: "other", URL: srv.URL})
+	require.NoError(t, err)
+
+	deliveries, err := m.Send(ctx, "acme", "order.refunded", map[string]int{"amount": 10})
+	require.NoError(t, err)
+	assert.Empty(t, deliveries, "the endpoint is not subscribed to the event")
+
+	deliveries, err = m.Send(ctx, "acme", "order.paid", map[string]int{"amount": 10})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, []string{deliveries[0].ID}, q.ids)
+
+	require.NoError(t, m.Deliver(ctx, deliveries[0].ID))
+
+	d, err := m.Delivery(ctx, deliveries[0].ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, StatusDelivered, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, http.StatusOK, d.ResponseStatus)
+	assert.Equal(t, e.ID, d.EndpointID)
+	assert.Equal(t, "order.paid", received.Event)
+	assert.JSONEq(t, `{"amount":10}`, string(received.Data))
+
+	// a delivered delivery is not attempted again
+	signature = ""
+
+	require.NoError(t, m.Deliver(ctx, d.ID))
+	assert.Empty(t, signature)
+}
+
+func TestManager_DeliverRetriesAndRedeliver(t *testing.T) {
+	statusCode := http.StatusServiceUnavailable
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(statusCode)
+	}))
+	defer srv.Close()
+
+	m, q := newTestManager(t, WithMaxAttempts(2))
+	ctx := context.Background()
+
+	_, err := m.Register(ctx, Endpoint{Owner: "acme", URL: srv.URL})
+	require.NoError(t, err)
+
+	deliveries, err := m.Send(ctx, "acme", "order.paid", nil)
+	require.NoError(t, err)
+
+	id := deliveries[0].ID
+
+	testCases := []struct {
+		status   string
+		attempts int
+	}{
+		{StatusPending, 1},
+		{StatusFailed, 2},
+	}
+
+	for i, tc := range testCases {
+		assert.ErrorAs(t, m.Deliver(ctx, id), &errDeliveryFailed{}, "TEST[%d], Failed.\n", i)
+
+		d, err := m.Delivery(ctx, id)
+		require.NoError(t, err)
+
+		assert.Equal(t, tc.status, d.Status, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, tc.attempts, d.Attempts, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, http.StatusServiceUnavailable, d.ResponseStatus, "TEST[%d], Failed.\n", i)
+	}
+
+	failed, err := m.Deliveries(ctx, StatusFailed, 10)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+
+	statusCode = http.StatusNoContent
+
+	d, err := m.Redeliver(ctx, id)
+	require.NoError(t, err)
+
+	assert.Equal(t, StatusPending, d.Status)
+	assert.Equal(t, []string{id, id}, q.ids)
+
+	require.NoError(t, m.Deliver(ctx, id))
+
+	d, _ = m.Delivery(ctx, id)
+	assert.Equal(t, StatusDelivered, d.Status)
+
+	_, err = m.Redeliver(ctx, "unknown")
+	assert.Equal(t, ErrorDeliveryNotFound{ID: "unknown"}, err)
+}
+
+func TestManager_DeliverToRemovedEndpoint(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+
+	e, err := m.Register(ctx, Endpoint{Owner: "acme", URL: "http://localhost:1"})
+	require.NoError(t, err)
+
+	deliveries, err := m.Send(ctx, "acme", "order.paid", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Remove(ctx, e.ID))
+	require.NoError(t, m.Deliver(ctx, deliveries[0].ID))
+
+	d, _ := m.Delivery(ctx, deliveries[0].ID)
+	assert.Equal(t, StatusFailed, d.Status)
+}
+
+func TestManager_SQLNotConfigured(t *testing.T) {
+	m := NewManager(nil, &queue{})
+
+	_, err := m.Register(context.Background(), Endpoint{URL: "https://example.com"})
+	assert.Equal(t, errSQLNotConfigured, err)
+	assert.Equal(t, errSQLNotConfigured, m.Deliver(context.Background(), "id"))
+}
@@ -0,0 +1,88 @@
+package gofr
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/webhook"
+)
+
+var errInvalidWebhookStatus = errors.New("status must be one of PENDING, DELIVERED or FAILED")
+
+// AddWebhooks returns the webhook manager of the application.
+// The deliveries are listed on the metrics server with GET /webhooks?status=FAILED.
+func (a *App) AddWebhooks(opts ...webhook.Option) *webhook.Manager {
+	if a.webhooks != nil {
+		return a.webhooks
+	}
+
+	a.webhooks = webhook.NewManager(a.container.SQL, a.container, opts...)
+
+	a.RegisterJob(webhook.JobName, func(ctx *Context) error {
+		var id string
+
+		if err := ctx.Bind(&id); err != nil {
+			return err
+		}
+
+		return a.webhooks.Deliver(ctx, id)
+	})
+
+	return a.webhooks
+}
+
+// webhooksHandler lists the deliveries of the status, FAILED by default.
+func webhooksHandler(m *webhook.Manager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := strings.ToUpper(r.URL.Query().Get("status"))
+		if status == "" {
+			status = webhook.StatusFailed
+		}
+
+		if status != webhook.StatusPending && status != webhook.StatusDelivered && status != webhook.StatusFailed {
+			writeAdminError(w, http.StatusBadRequest, errInvalidWebhookStatus)
+
+			return
+		}
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = defaultJobListLimit
+		}
+
+		if limit > maxJobListLimit {
+			limit = maxJobListLimit
+		}
+
+		deliveries, err := m.Deliveries(r.Context(), status, limit)
+		if err != nil {
+			writeAdminError(w, http.StatusInternalServerError, err)
+
+			return
+		}
+
+		writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": deliveries})
+	})
+}
+
+// webhookRedeliverHandler delivers again the delivery of the id.
+func webhookRedeliverHandler(m *webhook.Manager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d, err := m.Redeliver(r.Context(), mux.Vars(r)["id"])
+
+		var notFound webhook.ErrorDeliveryNotFound
+
+		switch {
+		case errors.As(err, &notFound):
+			writeAdminError(w, http.StatusNotFound, err)
+		case err != nil:
+			writeAdminError(w, http.StatusInternalServerError, err)
+		default:
+			writeAdminJSON(w, http.StatusAccepted, map[string]interface{}{"data": d})
+		}
+	})
+}
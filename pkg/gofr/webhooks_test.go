@@ -0,0 +1,84 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peter-stratton/gofr/pkg/gofr/config"
+	"github.com/peter-stratton/gofr/pkg/gofr/container"
+	"github.com/peter-stratton/gofr/pkg/gofr/webhook"
+)
+
+func TestApp_AddWebhooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := container.NewContainer(config.NewMockConfig(map[string]string{
+		"DB_DIALECT": "sqlite",
+		"DB_NAME":    filepath.Join(t.TempDir(), "webhooks"),
+	}))
+
+	a := &App{container: c}
+
+	m := a.AddWebhooks(webhook.WithMaxAttempts(1))
+
+	assert.Same(t, m, a.AddWebhooks())
+	assert.Contains(t, a.jobs.handlers, webhook.JobName)
+
+	ctx := context.Background()
+
+	_, err := m.Register(ctx, webhook.Endpoint{Owner: "acme", URL: srv.URL})
+	require.NoError(t, err)
+
+	deliveries, err := m.Send(ctx, "acme", "order.paid", nil)
+	require.NoError(t, err)
+
+	store, err := c.JobStore()
+	require.NoError(t, err)
+
+	job, err := store.Claim(ctx, defaultJobLease)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+
+	a.jobs.runJob(ctx, c, store, job)
+
+	tests := []struct {
+		desc       string
+		method     string
+		path       string
+		handler    http.Handler
+		statusCode int
+	}{
+		{"failed deliveries by default", http.MethodGet, "/webhooks", webhooksHandler(m), http.StatusOK},
+		{"invalid status", http.MethodGet, "/webhooks?status=done", webhooksHandler(m), http.StatusBadRequest},
+		{"redeliver", http.MethodPost, "/webhooks/" + deliveries[0].ID + "/redeliver", webhookRedeliverHandler(m),
+			http.StatusAccepted},
+		{"redeliver unknown delivery", http.MethodPost, "/webhooks/unknown/redeliver", webhookRedeliverHandler(m),
+			http.StatusNotFound},
+	}
+
+	for i, tc := range tests {
+		router := mux.NewRouter()
+		router.Handle("/webhooks", tc.handler)
+		router.Handle("/webhooks/{id}/redeliver", tc.handler)
+
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, http.NoBody))
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	failed, err := m.Deliveries(ctx, webhook.StatusFailed, 10)
+	require.NoError(t, err)
+	assert.Empty(t, failed, "the failed delivery is redelivered")
+}